@@ -77,6 +77,7 @@ const (
 
 type taskContext struct {
 	currentCommand         command.Command
+	parallelBranches       []*parallelBranch
 	expansions             util.Expansions
 	privateVars            map[string]bool
 	logger                 client.LoggerProducer
@@ -694,6 +695,7 @@ func (a *Agent) endTaskResponse(tc *taskContext, status string, message string)
 	}
 	if tc.taskConfig != nil {
 		detail.Modules.Prefixes = tc.taskConfig.ModulePaths
+		detail.ResourceLimitOOM = tc.taskConfig.GetResourceLimitOOMInfo()
 	}
 	return detail
 }
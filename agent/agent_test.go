@@ -16,6 +16,7 @@ import (
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/mongodb/jasper"
 	"github.com/mongodb/jasper/mock"
 	"github.com/stretchr/testify/suite"
@@ -425,6 +426,188 @@ post:
 	}
 }
 
+func (s *AgentSuite) TestPreSkipsCommandsWithFalseCondition() {
+	projYml := `
+pre:
+  - command: shell.exec
+    if: requester == patch
+    params:
+      script: "echo patch"
+  - command: shell.exec
+    if: "!is_patch && requester == commit"
+    params:
+      script: "echo commit"
+`
+	p := &model.Project{}
+	ctx := context.Background()
+	_, err := model.LoadProjectInto(ctx, []byte(projYml), nil, "", p)
+	s.NoError(err)
+	s.tc.taskConfig = &internal.TaskConfig{
+		BuildVariant: &model.BuildVariant{
+			Name: "buildvariant_id",
+		},
+		Task: &task.Task{
+			Id:      "task_id",
+			Version: versionId,
+		},
+		Project:    p,
+		WorkDir:    s.tc.taskDirectory,
+		Expansions: util.NewExpansions(map[string]string{"requester": "commit"}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.NoError(s.a.runPreTaskCommands(ctx, s.tc))
+	_ = s.tc.logger.Close()
+	msgs := s.mockCommunicator.GetMockMessages()["task_id"]
+	var skipped, ran bool
+	for _, msg := range msgs {
+		if msg.Message == "Skipping command 'shell.exec' because condition 'requester == patch' is false (step 1 of 2)." {
+			skipped = true
+		}
+		if msg.Message == "Running command 'shell.exec' (step 2 of 2)." {
+			ran = true
+		}
+	}
+	s.True(skipped)
+	s.True(ran)
+}
+
+func (s *AgentSuite) TestPreEvaluatesFunctionConditionOnce() {
+	projYml := `
+pre:
+  - func: update_and_echo
+    if: "!updated"
+functions:
+  update_and_echo:
+    - command: expansions.update
+      params:
+        updates:
+          - key: updated
+            value: "true"
+    - command: shell.exec
+      params:
+        script: "echo after update"
+`
+	p := &model.Project{}
+	ctx := context.Background()
+	_, err := model.LoadProjectInto(ctx, []byte(projYml), nil, "", p)
+	s.NoError(err)
+	s.tc.taskConfig = &internal.TaskConfig{
+		BuildVariant: &model.BuildVariant{
+			Name: "buildvariant_id",
+		},
+		Task: &task.Task{
+			Id:      "task_id",
+			Version: versionId,
+		},
+		Project:    p,
+		WorkDir:    s.tc.taskDirectory,
+		Expansions: util.NewExpansions(map[string]string{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.NoError(s.a.runPreTaskCommands(ctx, s.tc))
+	_ = s.tc.logger.Close()
+	msgs := s.mockCommunicator.GetMockMessages()["task_id"]
+	var ran bool
+	for _, msg := range msgs {
+		s.NotContains(msg.Message, "Skipping")
+		if msg.Message == "Running command 'shell.exec' in function 'update_and_echo' (step 1.2 of 1)." {
+			ran = true
+		}
+	}
+	s.True(ran, "function should not stop after its commands change the expansions its condition depends on")
+}
+
+func (s *AgentSuite) TestPreRunsParallelBlock() {
+	projYml := `
+pre:
+  - parallel:
+      - command: shell.exec
+        display_name: first
+        params:
+          script: "echo first"
+      - command: shell.exec
+        display_name: second
+        params:
+          script: "echo second"
+`
+	p := &model.Project{}
+	ctx := context.Background()
+	_, err := model.LoadProjectInto(ctx, []byte(projYml), nil, "", p)
+	s.NoError(err)
+	s.tc.taskConfig = &internal.TaskConfig{
+		BuildVariant: &model.BuildVariant{
+			Name: "buildvariant_id",
+		},
+		Task: &task.Task{
+			Id:      "task_id",
+			Version: versionId,
+		},
+		Project:    p,
+		WorkDir:    s.tc.taskDirectory,
+		Expansions: util.NewExpansions(map[string]string{}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.NoError(s.a.runPreTaskCommands(ctx, s.tc))
+	_ = s.tc.logger.Close()
+	msgs := s.mockCommunicator.GetMockMessages()["task_id"]
+	var startedBlock, ranFirst, ranSecond bool
+	for _, msg := range msgs {
+		switch msg.Message {
+		case "Running 2 commands in parallel (step 1 of 1).":
+			startedBlock = true
+		case "[1:first] Running command 'first' ('shell.exec') (step 1.1 of 1).":
+			ranFirst = true
+		case "[2:second] Running command 'second' ('shell.exec') (step 1.2 of 1).":
+			ranSecond = true
+		}
+	}
+	s.True(startedBlock)
+	s.True(ranFirst)
+	s.True(ranSecond)
+}
+
+func (s *AgentSuite) TestFailFastParallelBlockStopsOtherCommands() {
+	cmds := []model.PluginCommandConf{
+		{
+			FailFast: true,
+			Parallel: []model.PluginCommandConf{
+				{
+					Command: "subprocess.exec",
+					Params:  map[string]interface{}{"command": "doesntexist"},
+				},
+				{
+					Command: "shell.exec",
+					Params:  map[string]interface{}{"script": "sleep 30"},
+				},
+			},
+		},
+	}
+	s.tc.taskConfig = &internal.TaskConfig{
+		BuildVariant: &model.BuildVariant{
+			Name: "buildvariant_id",
+		},
+		Task: &task.Task{
+			Id:      "task_id",
+			Version: versionId,
+		},
+		Project:    &model.Project{},
+		Timeout:    &internal.Timeout{},
+		WorkDir:    s.tc.taskDirectory,
+		Expansions: util.NewExpansions(map[string]string{}),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	start := time.Now()
+	err := s.a.runCommands(ctx, s.tc, cmds, runCommandsOptions{isTaskCommands: true})
+	s.Error(err)
+	s.True(time.Since(start) < 20*time.Second)
+	s.NoError(ctx.Err())
+	_ = s.tc.logger.Close()
+}
+
 func (s *AgentSuite) TestEndTaskResponse() {
 	factory, ok := command.GetCommandFactory("setup.initial")
 	s.True(ok)
@@ -3,10 +3,13 @@ package agent
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/agent/command"
+	"github.com/evergreen-ci/evergreen/agent/internal"
 	"github.com/evergreen-ci/evergreen/agent/internal/client"
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model"
@@ -30,11 +33,17 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 		if err := ctx.Err(); err != nil {
 			return errors.Wrap(err, "canceled while running commands")
 		}
+		if len(commandInfo.Parallel) > 0 {
+			if err = a.runParallelBlock(ctx, tc, commandInfo, options, i+1, len(commands)); err != nil {
+				return errors.WithStack(err)
+			}
+			continue
+		}
 		cmds, err = command.Render(commandInfo, tc.taskConfig.Project)
 		if err != nil {
 			return errors.Wrapf(err, "rendering command '%s'", commandInfo.Command)
 		}
-		if err = a.runCommandSet(ctx, tc, commandInfo, cmds, options, i+1, len(commands)); err != nil {
+		if err = a.runCommandSet(ctx, tc, nil, tc.logger, commandInfo, cmds, options, strconv.Itoa(i+1), len(commands)); err != nil {
 			return errors.WithStack(err)
 		}
 	}
@@ -42,14 +51,185 @@ func (a *Agent) runCommands(ctx context.Context, tc *taskContext, commands []mod
 	return errors.WithStack(err)
 }
 
-func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo model.PluginCommandConf,
-	cmds []command.Command, options runCommandsOptions, index, total int) error {
+// parallelBranch is the state of a single command running in a parallel
+// block. Each branch has its own copy of the task config so that commands
+// running concurrently do not share expansions, and tracks its own current
+// command and idle timeout rather than setting the task's.
+type parallelBranch struct {
+	taskConfig     *internal.TaskConfig
+	currentCommand command.Command
+	idleTimeout    time.Duration
+	mu             sync.RWMutex
+}
+
+func (b *parallelBranch) setCurrentCommand(cmd command.Command, idleTimeout time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.currentCommand = cmd
+	b.idleTimeout = idleTimeout
+}
+
+func (b *parallelBranch) getCurrentCommand() command.Command {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.currentCommand
+}
+
+func (b *parallelBranch) getIdleTimeout() time.Duration {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.idleTimeout
+}
+
+// runParallelBlock runs each of the commands in a parallel block concurrently.
+// Each command's log lines are prefixed so that they can be told apart. The
+// block fails if any of its commands fails; if the block is fail_fast, the
+// remaining commands are stopped as soon as one fails. Once all of the
+// commands finish, the expansions they set are merged back into the task's
+// expansions in the order that the commands are listed.
+func (a *Agent) runParallelBlock(ctx context.Context, tc *taskContext, blockInfo model.PluginCommandConf,
+	options runCommandsOptions, index, total int) error {
+
+	if !blockInfo.RunOnVariant(tc.taskConfig.BuildVariant.Name) {
+		tc.logger.Task().Infof("Skipping parallel block on variant %s (step %d of %d).",
+			tc.taskConfig.BuildVariant.Name, index, total)
+		return nil
+	}
+	if blockInfo.If != "" {
+		run, err := evalCondition(tc.taskConfig, blockInfo.If)
+		if err != nil {
+			return errors.Wrap(err, "evaluating condition for parallel block")
+		}
+		if !run {
+			tc.logger.Task().Infof("Skipping parallel block because condition '%s' is false (step %d of %d).",
+				blockInfo.If, index, total)
+			return nil
+		}
+	}
+
+	cmdSets := make([][]command.Command, 0, len(blockInfo.Parallel))
+	for _, commandInfo := range blockInfo.Parallel {
+		cmds, err := command.Render(commandInfo, tc.taskConfig.Project)
+		if err != nil {
+			return errors.Wrapf(err, "rendering command '%s' in parallel block", commandInfo.Command)
+		}
+		cmdSets = append(cmdSets, cmds)
+	}
+
+	tc.logger.Task().Infof("Running %d commands in parallel (step %d of %d).", len(cmdSets), index, total)
+	start := time.Now()
+
+	branches := make([]*parallelBranch, len(cmdSets))
+	for i := range branches {
+		branches[i] = &parallelBranch{taskConfig: tc.taskConfig.NewParallelBranch()}
+	}
+	tc.setParallelBranches(branches)
+	defer func() {
+		tc.setParallelBranches(nil)
+		for _, branch := range branches {
+			tc.taskConfig.MergeParallelBranch(branch.taskConfig)
+		}
+	}()
+
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failed   bool
+		failedAt = -1
+		catcher  = grip.NewBasicCatcher()
+		blockErr error
+	)
+	for i, commandInfo := range blockInfo.Parallel {
+		wg.Add(1)
+		go func(i int, commandInfo model.PluginCommandConf) {
+			defer wg.Done()
+			defer func() {
+				if panicErr := recovery.HandlePanicWithError(recover(), nil, "running parallel command"); panicErr != nil {
+					mu.Lock()
+					catcher.Add(panicErr)
+					mu.Unlock()
+				}
+			}()
+
+			logger := client.NewPrefixedLoggerProducer(tc.logger, fmt.Sprintf("%d:%s", i+1, getFunctionName(commandInfo)))
+			defer func() {
+				grip.Error(errors.Wrap(logger.Close(), "closing parallel command logger"))
+			}()
+
+			step := fmt.Sprintf("%d.%d", index, i+1)
+			err := a.runCommandSet(blockCtx, tc, branches[i], logger, commandInfo, cmdSets[i], options, step, total)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				return
+			}
+			// Commands stopped because another command in a fail fast block
+			// failed are not themselves failures.
+			if failed && blockInfo.FailFast && ctx.Err() == nil {
+				return
+			}
+			failed = true
+			if failedAt < 0 || i < failedAt {
+				failedAt = i
+			}
+			catcher.Wrapf(err, "parallel command %s", getFunctionName(commandInfo))
+			if blockInfo.FailFast {
+				cancel()
+			}
+		}(i, commandInfo)
+	}
+	wg.Wait()
+
+	// Report the failed command, if any, as the task's current command so
+	// that the task's end details describe it.
+	if failedAt >= 0 {
+		if cmd := branches[failedAt].getCurrentCommand(); cmd != nil {
+			tc.setCurrentCommand(cmd)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		blockErr = errors.Wrap(err, "canceled while running parallel block")
+	} else {
+		blockErr = catcher.Resolve()
+	}
+
+	tc.logger.Task().Infof("Finished parallel block in %s.", time.Since(start).String())
+	return blockErr
+}
+
+// evalCondition returns whether a command's `if` condition is true for the
+// task config's current expansions.
+func evalCondition(conf *internal.TaskConfig, condition string) (bool, error) {
+	cond, err := model.ParseCommandCondition(condition)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return cond.Eval(*conf.Expansions), nil
+}
+
+// runCommandSet runs the commands rendered from a single command
+// configuration. Progress is logged to blockLogger, which is the task logger
+// unless the commands are part of a parallel block. branch is the parallel
+// block branch that the commands run in, or nil if they are not part of a
+// parallel block.
+func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, branch *parallelBranch, blockLogger client.LoggerProducer,
+	commandInfo model.PluginCommandConf, cmds []command.Command, options runCommandsOptions, step string, total int) error {
+
+	taskConfig := tc.taskConfig
+	if branch != nil {
+		taskConfig = branch.taskConfig
+	}
 
 	var err error
 	var logger client.LoggerProducer
-	// if there is a command-specific logger, make it here otherwise use the task-level logger
+	// if there is a command-specific logger, make it here otherwise use the block logger
 	if commandInfo.Loggers == nil {
-		logger = tc.logger
+		logger = blockLogger
 	} else {
 		logger, err = a.makeLoggerProducer(ctx, tc, commandInfo.Loggers, getFunctionName(commandInfo))
 		if err != nil {
@@ -60,6 +240,20 @@ func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo
 		}()
 	}
 
+	// A function's condition is evaluated once when it is invoked, so that
+	// its commands changing the expansions cannot stop it partway through.
+	if commandInfo.Function != "" && commandInfo.If != "" && commandInfo.RunOnVariant(tc.taskConfig.BuildVariant.Name) {
+		run, err := evalCondition(taskConfig, commandInfo.If)
+		if err != nil {
+			return errors.Wrapf(err, "evaluating condition for function '%s'", commandInfo.Function)
+		}
+		if !run {
+			blockLogger.Task().Infof("Skipping function '%s' because condition '%s' is false (step %s of %d).",
+				commandInfo.Function, commandInfo.If, step, total)
+			return nil
+		}
+	}
+
 	for idx, cmd := range cmds {
 		if err := ctx.Err(); err != nil {
 			return errors.Wrap(err, "canceled while running command list")
@@ -70,27 +264,46 @@ func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo
 		fullCommandName := getCommandName(commandInfo, cmd)
 
 		if !commandInfo.RunOnVariant(tc.taskConfig.BuildVariant.Name) {
-			tc.logger.Task().Infof("Skipping command %s on variant %s (step %d of %d).",
-				fullCommandName, tc.taskConfig.BuildVariant.Name, index, total)
+			blockLogger.Task().Infof("Skipping command %s on variant %s (step %s of %d).",
+				fullCommandName, tc.taskConfig.BuildVariant.Name, step, total)
 			continue
 		}
 
+		if condition := cmd.Condition(); condition != "" {
+			run, err := evalCondition(taskConfig, condition)
+			if err != nil {
+				return errors.Wrapf(err, "evaluating condition for command %s", fullCommandName)
+			}
+			if !run {
+				blockLogger.Task().Infof("Skipping command %s because condition '%s' is false (step %s of %d).",
+					fullCommandName, condition, step, total)
+				continue
+			}
+		}
+
 		if len(cmds) == 1 {
-			tc.logger.Task().Infof("Running command %s (step %d of %d).", fullCommandName, index, total)
+			blockLogger.Task().Infof("Running command %s (step %s of %d).", fullCommandName, step, total)
 		} else {
 			// for functions with more than one command
-			tc.logger.Task().Infof("Running command %s (step %d.%d of %d).", fullCommandName, index, idx+1, total)
+			blockLogger.Task().Infof("Running command %s (step %s.%d of %d).", fullCommandName, step, idx+1, total)
 		}
 		for key, val := range commandInfo.Vars {
 			var newVal string
-			newVal, err = tc.taskConfig.Expansions.ExpandString(val)
+			newVal, err = taskConfig.Expansions.ExpandString(val)
 			if err != nil {
 				return errors.Wrapf(err, "expanding '%s'", val)
 			}
-			tc.taskConfig.Expansions.Put(key, newVal)
+			taskConfig.Expansions.Put(key, newVal)
 		}
 
-		if options.isTaskCommands || options.failPreAndPost {
+		if branch != nil {
+			if options.isTaskCommands || options.failPreAndPost {
+				branch.setCurrentCommand(cmd, tc.getCommandIdleTimeout(cmd))
+				a.comm.UpdateLastMessageTime()
+			} else {
+				branch.setCurrentCommand(nil, defaultIdleTimeout)
+			}
+		} else if options.isTaskCommands || options.failPreAndPost {
 			tc.setCurrentCommand(cmd)
 			tc.setCurrentIdleTimeout(cmd)
 			a.comm.UpdateLastMessageTime()
@@ -110,12 +323,12 @@ func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo
 				cmdChan <- recovery.HandlePanicWithError(recover(), nil,
 					fmt.Sprintf("running command %s", fullCommandName))
 			}()
-			cmdChan <- cmd.Execute(ctx, a.comm, logger, tc.taskConfig)
+			cmdChan <- cmd.Execute(ctx, a.comm, logger, taskConfig)
 		}()
 		select {
 		case err = <-cmdChan:
 			if err != nil {
-				tc.logger.Task().Errorf("Command %s failed: %s.", fullCommandName, err)
+				blockLogger.Task().Errorf("Command %s failed: %s.", fullCommandName, err)
 				if options.isTaskCommands || options.failPreAndPost ||
 					(cmd.Name() == "git.get_project" && tc.taskModel.Requester == evergreen.MergeTestRequester) {
 					// any git.get_project in the commit queue should fail
@@ -124,13 +337,13 @@ func (a *Agent) runCommandSet(ctx context.Context, tc *taskContext, commandInfo
 			}
 		case <-ctx.Done():
 			if ctx.Err() == context.DeadlineExceeded {
-				tc.logger.Task().Errorf("Command %s stopped early because idle timeout duration of %d seconds has been reached.", fullCommandName, int(tc.timeout.idleTimeoutDuration.Seconds()))
+				blockLogger.Task().Errorf("Command %s stopped early because idle timeout duration of %d seconds has been reached.", fullCommandName, int(tc.getCurrentTimeout().Seconds()))
 			} else {
-				tc.logger.Task().Errorf("Command %s stopped early: %s.", fullCommandName, ctx.Err())
+				blockLogger.Task().Errorf("Command %s stopped early: %s.", fullCommandName, ctx.Err())
 			}
 			return errors.Wrap(ctx.Err(), "agent stopped early")
 		}
-		tc.logger.Task().Infof("Finished command %s in %s.", fullCommandName, time.Since(start).String())
+		blockLogger.Task().Infof("Finished command %s in %s.", fullCommandName, time.Since(start).String())
 		if (options.isTaskCommands || options.failPreAndPost) && a.endTaskResp != nil && !a.endTaskResp.ShouldContinue {
 			// only error if we're running a command that should fail, and we don't want to continue to run other tasks
 			return errors.Errorf("task status has been set to '%s'; triggering end task", a.endTaskResp.Status)
@@ -62,13 +62,19 @@ func (e *autoExtract) Execute(ctx context.Context,
 		return errors.Errorf("archive '%s' does not exist", e.ArchivePath)
 	}
 
-	unzipper := archiver.MatchingFormat(e.ArchivePath)
+	return extractArchive(e.ArchivePath, e.TargetDirectory)
+}
+
+// extractArchive extracts the archive into the target directory, detecting
+// the archive's format from its file name.
+func extractArchive(archivePath, targetDirectory string) error {
+	unzipper := archiver.MatchingFormat(archivePath)
 	if unzipper == nil {
-		return errors.Errorf("could not detect archive format for archive '%s'", e.ArchivePath)
+		return errors.Errorf("could not detect archive format for archive '%s'", archivePath)
 	}
 
-	if err := unzipper.Open(e.ArchivePath, e.TargetDirectory); err != nil {
-		return errors.Wrapf(err, "extracting archive '%s'", e.ArchivePath)
+	if err := unzipper.Open(archivePath, targetDirectory); err != nil {
+		return errors.Wrapf(err, "extracting archive '%s'", archivePath)
 	}
 
 	return nil
@@ -0,0 +1,133 @@
+package command
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// attachProvenance is a command to create a signed provenance attestation for
+// artifacts produced by the task. The agent only computes the digests of the
+// artifacts; the app server fills in what it knows about the task and signs
+// the attestation, so the attestation cannot be forged by the task itself.
+type attachProvenance struct {
+	// Files is a list of artifacts to attest to, using gitignore syntax.
+	Files []string `mapstructure:"files" plugin:"expand"`
+
+	// Prefix is an optional directory prefix to start file globbing in,
+	// relative to Evergreen's working directory. Artifacts are named in the
+	// attestation by their path relative to it.
+	Prefix string `mapstructure:"prefix" plugin:"expand"`
+
+	// OutputFile is an optional path to write the signed attestation to, so
+	// that it can be uploaded alongside the artifacts.
+	OutputFile string `mapstructure:"output_file" plugin:"expand"`
+
+	// Optional, when set to true, causes this command to be skipped over
+	// without an error when no files match.
+	Optional bool `mapstructure:"optional"`
+
+	base
+}
+
+func attachProvenanceFactory() Command   { return &attachProvenance{} }
+func (c *attachProvenance) Name() string { return "attach.provenance" }
+
+func (c *attachProvenance) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrap(err, "decoding mapstructure params")
+	}
+
+	if len(c.Files) == 0 {
+		return errors.New("must specify at least one file pattern to attest to")
+	}
+	return nil
+}
+
+func (c *attachProvenance) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *internal.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "applying expansions")
+	}
+
+	workDir := getJoinedWithWorkDir(conf, c.Prefix)
+	b := utility.FileListBuilder{
+		WorkingDir: workDir,
+		Include:    utility.NewGitIgnoreFileMatcher(workDir, c.Files...),
+	}
+	files, err := b.Build()
+	if err != nil {
+		return errors.Wrap(err, "building wildcard paths")
+	}
+	if len(files) == 0 {
+		err = errors.New("expanded file specification had no items")
+		if c.Optional {
+			logger.Task().Error(err)
+			return nil
+		}
+		return err
+	}
+	sort.Strings(files)
+
+	subjects := make([]artifact.ProvenanceSubject, 0, len(files))
+	for _, fn := range files {
+		digest, err := sha256File(filepath.Join(workDir, fn))
+		if err != nil {
+			return errors.Wrapf(err, "computing digest of artifact '%s'", fn)
+		}
+		subjects = append(subjects, artifact.ProvenanceSubject{
+			Name:   filepath.ToSlash(fn),
+			Digest: map[string]string{artifact.DigestAlgorithmSHA256: digest},
+		})
+		logger.Task().Infof("Artifact '%s' has %s digest '%s'.", fn, artifact.DigestAlgorithmSHA256, digest)
+	}
+
+	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+	envelope, err := comm.CreateProvenance(ctx, td, subjects)
+	if err != nil {
+		return errors.Wrap(err, "creating provenance attestation")
+	}
+
+	if c.OutputFile != "" {
+		outputFile := c.OutputFile
+		if !filepath.IsAbs(outputFile) {
+			outputFile = getJoinedWithWorkDir(conf, outputFile)
+		}
+		if err = createEnclosingDirectoryIfNeeded(outputFile); err != nil {
+			return errors.Wrapf(err, "creating parent directories for output file '%s'", outputFile)
+		}
+		out, err := json.MarshalIndent(envelope, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshalling provenance attestation")
+		}
+		if err = os.WriteFile(outputFile, out, 0644); err != nil {
+			return errors.Wrapf(err, "writing provenance attestation to file '%s'", outputFile)
+		}
+		logger.Task().Infof("Wrote provenance attestation to file '%s'.", outputFile)
+	}
+
+	logger.Task().Infof("'%s' attested to %d artifacts.", c.Name(), len(subjects))
+	return nil
+}
+
+// sha256File returns the hex-encoded SHA-256 digest of the file.
+func sha256File(fn string) (string, error) {
+	h := sha256.New()
+	if err := hashFile(h, fn); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
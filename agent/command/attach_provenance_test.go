@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachProvenance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for tName, tCase := range map[string]func(t *testing.T, conf *internal.TaskConfig, comm *client.Mock, logger client.LoggerProducer){
+		"ParseParamsRequiresFiles": func(t *testing.T, conf *internal.TaskConfig, comm *client.Mock, logger client.LoggerProducer) {
+			cmd := attachProvenanceFactory().(*attachProvenance)
+			assert.Error(t, cmd.ParseParams(map[string]interface{}{}))
+			assert.NoError(t, cmd.ParseParams(map[string]interface{}{"files": []string{"dist/*"}}))
+		},
+		"AttestsToMatchingFiles": func(t *testing.T, conf *internal.TaskConfig, comm *client.Mock, logger client.LoggerProducer) {
+			cmd := attachProvenanceFactory().(*attachProvenance)
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"files":       []string{"*.tgz"},
+				"prefix":      "dist",
+				"output_file": "provenance/${task_id}.intoto.json",
+			}))
+			conf.Expansions.Put("task_id", "task")
+
+			require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+
+			subjects := comm.ProvenanceSubjects[conf.Task.Id]
+			require.Len(t, subjects, 2)
+			assert.Equal(t, "a.tgz", subjects[0].Name)
+			assert.Equal(t, sha256Hex([]byte("a")), subjects[0].Digest[artifact.DigestAlgorithmSHA256])
+			assert.Equal(t, "b.tgz", subjects[1].Name)
+			assert.Equal(t, sha256Hex([]byte("b")), subjects[1].Digest[artifact.DigestAlgorithmSHA256])
+
+			raw, err := os.ReadFile(filepath.Join(conf.WorkDir, "provenance", "task.intoto.json"))
+			require.NoError(t, err)
+			envelope := artifact.ProvenanceEnvelope{}
+			require.NoError(t, json.Unmarshal(raw, &envelope))
+			assert.Equal(t, artifact.InTotoPayloadType, envelope.PayloadType)
+			statement, err := envelope.Statement()
+			require.NoError(t, err)
+			assert.Equal(t, subjects, statement.Subject)
+		},
+		"FailsWithoutMatchingFiles": func(t *testing.T, conf *internal.TaskConfig, comm *client.Mock, logger client.LoggerProducer) {
+			cmd := attachProvenanceFactory().(*attachProvenance)
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{"files": []string{"*.zip"}}))
+			assert.Error(t, cmd.Execute(ctx, comm, logger, conf))
+			assert.Empty(t, comm.ProvenanceSubjects[conf.Task.Id])
+		},
+		"OptionalSucceedsWithoutMatchingFiles": func(t *testing.T, conf *internal.TaskConfig, comm *client.Mock, logger client.LoggerProducer) {
+			cmd := attachProvenanceFactory().(*attachProvenance)
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{"files": []string{"*.zip"}, "optional": true}))
+			assert.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+			assert.Empty(t, comm.ProvenanceSubjects[conf.Task.Id])
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			workDir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(workDir, "dist"), 0777))
+			require.NoError(t, os.WriteFile(filepath.Join(workDir, "dist", "a.tgz"), []byte("a"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(workDir, "dist", "b.tgz"), []byte("b"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(workDir, "dist", "notes.txt"), []byte("notes"), 0644))
+
+			conf := &internal.TaskConfig{
+				Expansions: &util.Expansions{},
+				Task:       &task.Task{Id: "task", Secret: "secret"},
+				Project:    &model.Project{},
+				WorkDir:    workDir,
+			}
+			comm := client.NewMock("http://localhost.com")
+			logger, err := comm.GetLoggerProducer(ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}, nil)
+			require.NoError(t, err)
+
+			tCase(t, conf, comm, logger)
+		})
+	}
+}
@@ -69,3 +69,13 @@ func (c *manifestLoad) Execute(ctx context.Context,
 	logger.Execution().Warningf("%s is deprecated. Manifest load is now called automatically in git.get_project.", evergreen.ManifestLoadCommandName)
 	return nil
 }
+
+// DeprecatedCommands maps the names of commands that are deprecated and no
+// longer do anything to a description of what replaced them.
+var DeprecatedCommands = map[string]string{
+	"git.apply_patch":                 "patches are applied in git.get_project",
+	"expansions.fetch_vars":           "project variables are available as expansions automatically",
+	"shell.cleanup":                   "process cleanup is enabled by default",
+	"shell.track":                     "process tracking is enabled by default",
+	evergreen.ManifestLoadCommandName: "the manifest is loaded automatically in git.get_project",
+}
@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/agent/internal/client"
 	agentutil "github.com/evergreen-ci/evergreen/agent/util"
 	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
 	"github.com/google/shlex"
 	"github.com/mitchellh/mapstructure"
 	"github.com/mongodb/grip"
@@ -75,9 +78,42 @@ type subprocessExec struct {
 	// note that non-blank whitespace arguments are never stripped
 	KeepEmptyArgs bool `mapstructure:"keep_empty_args"`
 
+	// ResourceLimits limits the resources that the process and all of its
+	// descendants can use. Outside of a container, the limits are enforced
+	// with a cgroup v2, which is only supported on Linux.
+	ResourceLimits *subprocessResourceLimits `mapstructure:"resource_limits"`
+
+	// Image, if set, is the Docker image to run the command in. The task's
+	// working directory is mounted into the container at the same path and
+	// the command's environment is passed through to the container.
+	Image string `mapstructure:"image"`
+
 	base
 }
 
+type subprocessResourceLimits struct {
+	// CPUs is the number of CPUs' worth of time the command can use. It may
+	// be fractional.
+	CPUs float64 `mapstructure:"cpus"`
+	// MemoryMB is the amount of memory in megabytes that the command can
+	// use before it is OOM killed.
+	MemoryMB int `mapstructure:"memory_mb"`
+	// MaxProcesses is the number of processes and threads that the command
+	// can have running at once.
+	MaxProcesses int `mapstructure:"max_processes"`
+}
+
+func (l *subprocessResourceLimits) export() agentutil.ResourceLimits {
+	if l == nil {
+		return agentutil.ResourceLimits{}
+	}
+	return agentutil.ResourceLimits{
+		CPUs:         l.CPUs,
+		MemoryMB:     l.MemoryMB,
+		MaxProcesses: l.MaxProcesses,
+	}
+}
+
 func subprocessExecFactory() Command   { return &subprocessExec{} }
 func (c *subprocessExec) Name() string { return "subprocess.exec" }
 
@@ -119,6 +155,15 @@ func (c *subprocessExec) ParseParams(params map[string]interface{}) error {
 		c.Env = make(map[string]string)
 	}
 
+	if c.ResourceLimits != nil {
+		if c.ResourceLimits.CPUs < 0 || c.ResourceLimits.MemoryMB < 0 || c.ResourceLimits.MaxProcesses < 0 {
+			return errors.New("resource limits cannot be negative")
+		}
+	}
+	if c.Background && (c.Image != "" || !c.ResourceLimits.export().IsZero()) {
+		return errors.New("cannot run a background process in a container or with resource limits")
+	}
+
 	return nil
 }
 
@@ -132,6 +177,9 @@ func (c *subprocessExec) doExpansions(exp *util.Expansions) error {
 	c.Binary, err = exp.ExpandString(c.Binary)
 	catcher.Wrap(err, "expanding binary")
 
+	c.Image, err = exp.ExpandString(c.Image)
+	catcher.Wrap(err, "expanding image")
+
 	for idx := range c.Args {
 		c.Args[idx], err = exp.ExpandString(c.Args[idx])
 		catcher.Wrap(err, "expanding args")
@@ -203,8 +251,21 @@ func defaultAndApplyExpansionsToEnv(env map[string]string, opts modifyEnvOptions
 	return env
 }
 
-func (c *subprocessExec) getProc(ctx context.Context, taskID string, logger client.LoggerProducer) *jasper.Command {
-	cmd := c.JasperManager().CreateCommand(ctx).Add(append([]string{c.Binary}, c.Args...)).
+func (c *subprocessExec) getProc(ctx context.Context, taskID string, cgroup *agentutil.Cgroup, logger client.LoggerProducer) *jasper.Command {
+	args := append([]string{c.Binary}, c.Args...)
+	if cgroup != nil {
+		// The wrapper looks up the binary in the command's PATH rather
+		// than the agent's, so resolve it the way it would be resolved
+		// without the wrapper.
+		if !strings.ContainsRune(c.Binary, filepath.Separator) {
+			if binary, err := exec.LookPath(c.Binary); err == nil {
+				args[0] = binary
+			}
+		}
+		args = cgroup.WrapCommand(args)
+	}
+
+	cmd := c.JasperManager().CreateCommand(ctx).Add(args).
 		Background(c.Background).Environment(c.Env).Directory(c.WorkingDir).
 		SuppressStandardError(c.IgnoreStandardError).SuppressStandardOutput(c.IgnoreStandardOutput).RedirectErrorToOutput(c.RedirectStandardErrorToOutput).
 		ProcConstructor(func(lctx context.Context, opts *options.Create) (jasper.Process, error) {
@@ -321,9 +382,33 @@ func (c *subprocessExec) Execute(ctx context.Context, comm client.Communicator,
 		"working_directory": c.WorkingDir,
 		"background":        c.Background,
 		"binary":            c.Binary,
+		"image":             c.Image,
 	})
 
-	err = errors.WithStack(c.runCommand(ctx, conf.Task.Id, c.getProc(ctx, conf.Task.Id, logger), logger))
+	// OOM kills are only attributed to the command if it has its own memory
+	// limit; otherwise, they're reported by the host's OOM tracker.
+	limits := c.ResourceLimits.export()
+	var cgroup *agentutil.Cgroup
+	if c.Image != "" {
+		containerName := c.containerize(conf)
+		defer c.removeContainer(conf.Task.Id, containerName, logger)
+		if limits.MemoryMB > 0 {
+			defer c.checkContainerOOMKilled(ctx, conf, containerName, logger)
+		}
+	} else if !limits.IsZero() {
+		cgroup, err = agentutil.NewCgroup(fmt.Sprintf("%s-%s", conf.Task.Id, utility.RandomString()), limits)
+		if err != nil {
+			return errors.Wrap(err, "creating cgroup to enforce resource limits")
+		}
+		defer func() {
+			logger.Execution().Warning(errors.Wrap(cgroup.Close(), "cleaning up cgroup"))
+		}()
+		if limits.MemoryMB > 0 {
+			defer c.checkCgroupOOMKilled(conf, cgroup, logger)
+		}
+	}
+
+	err = errors.WithStack(c.runCommand(ctx, conf.Task.Id, c.getProc(ctx, conf.Task.Id, cgroup, logger), logger))
 
 	if ctxErr := ctx.Err(); ctxErr != nil {
 		logger.System().Debugf("Canceled command '%s', dumping running processes.", c.Name())
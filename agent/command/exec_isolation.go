@@ -0,0 +1,141 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	agentutil "github.com/evergreen-ci/evergreen/agent/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+const (
+	// containerTaskIDLabel labels the containers that subprocess.exec
+	// starts with the ID of the task that started them.
+	containerTaskIDLabel = "evergreen.task_id"
+	// containerCleanupTimeout is how long to wait for a container to be
+	// removed after its command finishes.
+	containerCleanupTimeout = time.Minute
+)
+
+// containerize replaces the command's binary and arguments with a docker run
+// command that runs them in the command's image. It returns the name of the
+// container.
+func (c *subprocessExec) containerize(conf *internal.TaskConfig) string {
+	containerName := fmt.Sprintf("evg-%s", utility.RandomString())
+
+	args := []string{
+		"run",
+		"--name", containerName,
+		"--label", fmt.Sprintf("%s=%s", containerTaskIDLabel, conf.Task.Id),
+		"--init",
+		"--volume", fmt.Sprintf("%s:%s", conf.WorkDir, conf.WorkDir),
+		"--workdir", c.WorkingDir,
+	}
+	// Run as the agent's user so that files the command creates in the
+	// working directory can be cleaned up with the rest of the task
+	// directory.
+	if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 && gid >= 0 {
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, gid))
+	}
+
+	limits := c.ResourceLimits.export()
+	if limits.CPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(limits.CPUs, 'f', -1, 64))
+	}
+	if limits.MemoryMB > 0 {
+		memory := fmt.Sprintf("%dm", limits.MemoryMB)
+		args = append(args, "--memory", memory, "--memory-swap", memory)
+	}
+	if limits.MaxProcesses > 0 {
+		args = append(args, "--pids-limit", strconv.Itoa(limits.MaxProcesses))
+	}
+
+	// Only the names of the environment variables are passed as arguments so
+	// that secret values are not visible in the process list; docker reads
+	// their values from its own environment. The host's PATH is not
+	// meaningful in the container.
+	envNames := make([]string, 0, len(c.Env))
+	for name := range c.Env {
+		if name == "PATH" {
+			continue
+		}
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		args = append(args, "--env", name)
+	}
+
+	args = append(args, c.Image, c.Binary)
+	args = append(args, c.Args...)
+
+	c.Binary = "docker"
+	c.Args = args
+
+	return containerName
+}
+
+// checkContainerOOMKilled records whether the command's container was OOM
+// killed for exceeding its memory limit.
+func (c *subprocessExec) checkContainerOOMKilled(ctx context.Context, conf *internal.TaskConfig, containerName string, logger client.LoggerProducer) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	out := noopWriteCloser{&bytes.Buffer{}}
+	err := c.JasperManager().CreateCommand(ctx).
+		Add([]string{"docker", "inspect", "--format", "{{.State.OOMKilled}}", containerName}).
+		SetOutputWriter(out).Run(ctx)
+	if err != nil {
+		logger.Execution().Warning(errors.Wrapf(err, "checking if container '%s' was OOM killed", containerName))
+		return
+	}
+	if strings.TrimSpace(out.String()) == "true" {
+		c.reportOOMKilled(conf, logger)
+	}
+}
+
+// removeContainer removes the command's container, stopping it first if the
+// command was interrupted.
+func (c *subprocessExec) removeContainer(taskID, containerName string, logger client.LoggerProducer) {
+	// The command's context may have already been canceled, but the
+	// container should still be cleaned up.
+	ctx, cancel := context.WithTimeout(context.Background(), containerCleanupTimeout)
+	defer cancel()
+
+	err := c.JasperManager().CreateCommand(ctx).
+		Add([]string{"docker", "rm", "--force", containerName}).
+		SetOutputWriter(noopWriteCloser{&bytes.Buffer{}}).Run(ctx)
+	logger.Execution().Warning(errors.Wrapf(err, "removing container '%s' for task '%s'", containerName, taskID))
+}
+
+// checkCgroupOOMKilled records whether the command's processes were OOM
+// killed for exceeding its memory limit.
+func (c *subprocessExec) checkCgroupOOMKilled(conf *internal.TaskConfig, cgroup *agentutil.Cgroup, logger client.LoggerProducer) {
+	oomKilled, err := cgroup.OOMKilled()
+	if err != nil {
+		logger.Execution().Warning(errors.Wrap(err, "checking if command was OOM killed"))
+		return
+	}
+	if oomKilled {
+		c.reportOOMKilled(conf, logger)
+	}
+}
+
+func (c *subprocessExec) reportOOMKilled(conf *internal.TaskConfig, logger client.LoggerProducer) {
+	name := c.DisplayName()
+	if name == "" {
+		name = c.Name()
+	}
+	logger.Task().Errorf("Command '%s' was OOM killed because it exceeded its memory limit of %d MB.", name, c.ResourceLimits.export().MemoryMB)
+	conf.AddResourceLimitOOM(name)
+}
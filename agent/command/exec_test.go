@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -171,13 +172,90 @@ func (s *execCmdSuite) TestInvalidToSpecifyCommandInMultipleWays() {
 	s.Error(cmd.ParseParams(map[string]interface{}{}))
 }
 
+func (s *execCmdSuite) TestParseParamsResourceLimits() {
+	cmd := &subprocessExec{}
+	s.NoError(cmd.ParseParams(map[string]interface{}{
+		"binary": "make",
+		"resource_limits": map[string]interface{}{
+			"cpus":          1.5,
+			"memory_mb":     2048,
+			"max_processes": 256,
+		},
+	}))
+	s.Require().NotNil(cmd.ResourceLimits)
+	s.Equal(1.5, cmd.ResourceLimits.CPUs)
+	s.Equal(2048, cmd.ResourceLimits.MemoryMB)
+	s.Equal(256, cmd.ResourceLimits.MaxProcesses)
+
+	cmd = &subprocessExec{}
+	s.Error(cmd.ParseParams(map[string]interface{}{
+		"binary":          "make",
+		"resource_limits": map[string]interface{}{"memory_mb": -1},
+	}))
+}
+
+func (s *execCmdSuite) TestParseParamsErrorsForIsolatedBackgroundProcess() {
+	cmd := &subprocessExec{}
+	s.Error(cmd.ParseParams(map[string]interface{}{
+		"binary":     "make",
+		"background": true,
+		"image":      "ubuntu:22.04",
+	}))
+
+	cmd = &subprocessExec{}
+	s.Error(cmd.ParseParams(map[string]interface{}{
+		"binary":          "make",
+		"background":      true,
+		"resource_limits": map[string]interface{}{"cpus": 1},
+	}))
+}
+
+func (s *execCmdSuite) TestContainerize() {
+	s.conf.WorkDir = "/data/task"
+	s.conf.Task.Id = "task_id"
+	cmd := &subprocessExec{
+		Binary:     "make",
+		Args:       []string{"test"},
+		Image:      "golang:1.20",
+		WorkingDir: "/data/task/src",
+		Env: map[string]string{
+			"PATH":   "/usr/bin",
+			"SECRET": "hunter2",
+			"CI":     "true",
+		},
+		ResourceLimits: &subprocessResourceLimits{
+			CPUs:         0.5,
+			MemoryMB:     512,
+			MaxProcesses: 64,
+		},
+	}
+
+	containerName := cmd.containerize(s.conf)
+	s.NotZero(containerName)
+	s.Equal("docker", cmd.Binary)
+	s.Equal("run", cmd.Args[0])
+
+	args := strings.Join(cmd.Args, " ")
+	s.Contains(args, "--name "+containerName)
+	s.Contains(args, "--label evergreen.task_id=task_id")
+	s.Contains(args, "--volume /data/task:/data/task")
+	s.Contains(args, "--workdir /data/task/src")
+	s.Contains(args, "--cpus 0.5")
+	s.Contains(args, "--memory 512m")
+	s.Contains(args, "--pids-limit 64")
+	s.Contains(args, "--env CI --env SECRET")
+	s.NotContains(args, "PATH")
+	s.NotContains(args, "hunter2")
+	s.True(strings.HasSuffix(args, "golang:1.20 make test"))
+}
+
 func (s *execCmdSuite) TestRunCommand() {
 	cmd := &subprocessExec{
 		Binary: "bash",
 	}
 	cmd.SetJasperManager(s.jasper)
 	s.NoError(cmd.ParseParams(map[string]interface{}{}))
-	exec := cmd.getProc(s.ctx, "foo", s.logger)
+	exec := cmd.getProc(s.ctx, "foo", nil, s.logger)
 	s.NoError(cmd.runCommand(s.ctx, "foo", exec, s.logger))
 }
 
@@ -187,7 +265,7 @@ func (s *execCmdSuite) TestRunCommandPropagatesError() {
 	}
 	cmd.SetJasperManager(s.jasper)
 	s.NoError(cmd.ParseParams(map[string]interface{}{}))
-	exec := cmd.getProc(s.ctx, "foo", s.logger)
+	exec := cmd.getProc(s.ctx, "foo", nil, s.logger)
 	err := cmd.runCommand(s.ctx, "foo", exec, s.logger)
 	s.Require().NotNil(err)
 	s.Contains(err.Error(), "process encountered problem: exit code 1")
@@ -201,7 +279,7 @@ func (s *execCmdSuite) TestRunCommandContinueOnErrorNoError() {
 	}
 	cmd.SetJasperManager(s.jasper)
 	s.NoError(cmd.ParseParams(map[string]interface{}{}))
-	exec := cmd.getProc(s.ctx, "foo", s.logger)
+	exec := cmd.getProc(s.ctx, "foo", nil, s.logger)
 	s.NoError(cmd.runCommand(s.ctx, "foo", exec, s.logger))
 }
 
@@ -213,7 +291,7 @@ func (s *execCmdSuite) TestRunCommandBackgroundAlwaysNil() {
 	}
 	cmd.SetJasperManager(s.jasper)
 	s.NoError(cmd.ParseParams(map[string]interface{}{}))
-	exec := cmd.getProc(s.ctx, "foo", s.logger)
+	exec := cmd.getProc(s.ctx, "foo", nil, s.logger)
 	s.NoError(cmd.runCommand(s.ctx, "foo", exec, s.logger))
 }
 
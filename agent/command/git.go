@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -60,6 +61,24 @@ type gitFetchProject struct {
 
 	CloneParams string `mapstructure:"clone_params"`
 
+	// CloneFilter is a partial clone filter, such as "blob:none", that limits
+	// the objects fetched for the project and its modules.
+	CloneFilter string `plugin:"expand" mapstructure:"clone_filter"`
+
+	// SparseCheckout restricts the project's working tree to files matching
+	// these patterns, which use the same syntax as .gitignore files.
+	SparseCheckout []string `plugin:"expand" mapstructure:"sparse_checkout"`
+	// ModuleSparseCheckout restricts the working tree of each module to files
+	// matching the given patterns.
+	ModuleSparseCheckout map[string][]string `plugin:"expand" mapstructure:"module_sparse_checkout"`
+
+	// ReferenceCache clones the project and its modules using a cache of
+	// their repositories that is shared by all tasks on the host.
+	ReferenceCache bool `mapstructure:"reference_cache"`
+	// ReferenceCacheMaxSizeMB is the size above which the least recently used
+	// repositories are removed from the host's cache.
+	ReferenceCacheMaxSizeMB int `mapstructure:"reference_cache_max_size_mb"`
+
 	base
 }
 
@@ -76,8 +95,13 @@ type cloneOpts struct {
 	mergeTestRequester bool
 	useVerbose         bool
 	cloneDepth         int
+	filter             string
+	sparseCheckout     []string
+	referenceRepo      string
 }
 
+var cloneFilterRegexp = regexp.MustCompile(`^(blob:none|blob:limit=[0-9]+[kmg]?|tree:[0-9]+)$`)
+
 func (opts cloneOpts) validate() error {
 	catcher := grip.NewBasicCatcher()
 	if opts.owner == "" {
@@ -98,6 +122,13 @@ func (opts cloneOpts) validate() error {
 	if opts.cloneDepth < 0 {
 		catcher.New("clone depth cannot be negative")
 	}
+	if opts.filter != "" && !cloneFilterRegexp.MatchString(opts.filter) {
+		catcher.Errorf("invalid clone filter '%s', must be 'blob:none', 'blob:limit=<n>[kmg]', or 'tree:<depth>'", opts.filter)
+	}
+	for _, pattern := range opts.sparseCheckout {
+		catcher.NewWhen(strings.TrimSpace(pattern) == "", "sparse checkout patterns cannot be empty")
+		catcher.ErrorfWhen(strings.Contains(pattern, "'"), "sparse checkout pattern '%s' cannot contain single quotes", pattern)
+	}
 	return catcher.Resolve()
 }
 
@@ -109,6 +140,58 @@ func (opts cloneOpts) httpLocation() string {
 	return fmt.Sprintf("https://github.com/%s/%s.git", opts.owner, opts.repo)
 }
 
+// remoteURL returns the URL to fetch from, including the token if cloning
+// with OAuth.
+func (opts cloneOpts) remoteURL() (string, error) {
+	if opts.method != evergreen.CloneMethodOAuth {
+		return opts.location, nil
+	}
+	urlLocation, err := url.Parse(opts.location)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing URL from location")
+	}
+	return thirdparty.FormGitUrl(urlLocation.Host, opts.owner, opts.repo, opts.token), nil
+}
+
+// extraCloneArgs returns the clone arguments for the reference repository,
+// the partial clone filter, and sparse checkout.
+func (opts cloneOpts) extraCloneArgs() string {
+	var args []string
+	if opts.referenceRepo != "" {
+		args = append(args, fmt.Sprintf("--reference '%s' --dissociate", opts.referenceRepo))
+	}
+	if opts.filter != "" {
+		args = append(args, fmt.Sprintf("--filter '%s'", opts.filter))
+	}
+	if len(opts.sparseCheckout) > 0 {
+		// The sparse checkout patterns are set before anything is checked
+		// out so that files outside of them are never written.
+		args = append(args, "--no-checkout")
+	}
+	return strings.Join(args, " ")
+}
+
+// sparseCheckoutCommands returns the commands to check out only the files
+// matching the sparse checkout patterns. They must run in the cloned
+// repository.
+func (opts cloneOpts) sparseCheckoutCommands() []string {
+	if len(opts.sparseCheckout) == 0 {
+		return nil
+	}
+	patterns := make([]string, 0, len(opts.sparseCheckout))
+	for _, pattern := range opts.sparseCheckout {
+		patterns = append(patterns, fmt.Sprintf("'%s'", pattern))
+	}
+	cmds := []string{
+		fmt.Sprintf("git sparse-checkout set --no-cone %s", strings.Join(patterns, " ")),
+		"git checkout",
+	}
+	if opts.recurseSubmodules {
+		cmds = append(cmds, "git submodule update --init --recursive")
+	}
+	return cmds
+}
+
 // setLocation sets the location to clone from.
 func (opts *cloneOpts) setLocation() error {
 	switch opts.method {
@@ -176,11 +259,11 @@ func (opts cloneOpts) getCloneCommand() ([]string, error) {
 }
 
 func (opts cloneOpts) buildHTTPCloneCommand() ([]string, error) {
-	urlLocation, err := url.Parse(opts.location)
+	remote, err := opts.remoteURL()
 	if err != nil {
-		return nil, errors.Wrap(err, "parsing URL from location")
+		return nil, err
 	}
-	clone := fmt.Sprintf("git clone %s '%s'", thirdparty.FormGitUrl(urlLocation.Host, opts.owner, opts.repo, opts.token), opts.dir)
+	clone := fmt.Sprintf("git clone %s '%s'", remote, opts.dir)
 	if opts.recurseSubmodules {
 		clone = fmt.Sprintf("%s --recurse-submodules", clone)
 	}
@@ -193,6 +276,9 @@ func (opts cloneOpts) buildHTTPCloneCommand() ([]string, error) {
 	if opts.branch != "" {
 		clone = fmt.Sprintf("%s --branch '%s'", clone, opts.branch)
 	}
+	if args := opts.extraCloneArgs(); args != "" {
+		clone = fmt.Sprintf("%s %s", clone, args)
+	}
 	if opts.cloneParams != "" {
 		clone = fmt.Sprintf("%s %s", clone, opts.cloneParams)
 	}
@@ -221,6 +307,9 @@ func (opts cloneOpts) buildSSHCloneCommand() ([]string, error) {
 	if opts.branch != "" {
 		cloneCmd = fmt.Sprintf("%s --branch '%s'", cloneCmd, opts.branch)
 	}
+	if args := opts.extraCloneArgs(); args != "" {
+		cloneCmd = fmt.Sprintf("%s %s", cloneCmd, args)
+	}
 	if opts.cloneParams != "" {
 		cloneCmd = fmt.Sprintf("%s %s", cloneCmd, opts.cloneParams)
 	}
@@ -270,6 +359,9 @@ func (c *gitFetchProject) ParseParams(params map[string]interface{}) error {
 	if c.Directory == "" {
 		return errors.New("directory must not be blank")
 	}
+	if c.ReferenceCacheMaxSizeMB < 0 {
+		return errors.New("reference cache max size cannot be negative")
+	}
 
 	return nil
 }
@@ -286,6 +378,7 @@ func (c *gitFetchProject) buildCloneCommand(ctx context.Context, comm client.Com
 		return nil, errors.Wrap(err, "getting command to clone repo")
 	}
 	gitCommands = append(gitCommands, cloneCmd...)
+	gitCommands = append(gitCommands, opts.sparseCheckoutCommands()...)
 
 	// if there's a PR checkout the ref containing the changes
 	if isGitHub(conf) {
@@ -317,6 +410,11 @@ func (c *gitFetchProject) buildCloneCommand(ctx context.Context, comm client.Com
 		}
 
 	} else {
+		if evergreen.IsGithubMergeQueueRequester(conf.Task.Requester) {
+			// The merge group's commit is only on GitHub's temporary merge
+			// queue branch, so it must be fetched explicitly.
+			gitCommands = append(gitCommands, fmt.Sprintf("git fetch origin %s", conf.Task.Revision))
+		}
 		if opts.cloneDepth > 0 {
 			// If this git log fails, then we know the clone is too shallow so we unshallow before reset.
 			gitCommands = append(gitCommands, fmt.Sprintf("git log HEAD..%s || git fetch --unshallow", conf.Task.Revision))
@@ -388,6 +486,7 @@ func (c *gitFetchProject) buildModuleCloneCommand(conf *internal.TaskConfig, opt
 		return nil, errors.Wrap(err, "getting command to clone repo")
 	}
 	gitCommands = append(gitCommands, cloneCmd...)
+	gitCommands = append(gitCommands, opts.sparseCheckoutCommands()...)
 
 	if isGitHubPRModulePatch(conf, modulePatch) {
 		branchName := fmt.Sprintf("evg-merge-test-%s", utility.RandomString())
@@ -415,6 +514,8 @@ func (c *gitFetchProject) opts(projectMethod, projectToken string, logger client
 		cloneParams:        c.CloneParams,
 		recurseSubmodules:  c.RecurseSubmodules,
 		mergeTestRequester: conf.Task.Requester == evergreen.MergeTestRequester,
+		filter:             c.CloneFilter,
+		sparseCheckout:     c.SparseCheckout,
 	}
 	cloneDepth := c.CloneDepth
 	if cloneDepth == 0 && c.ShallowClone {
@@ -510,6 +611,11 @@ func (c *gitFetchProject) fetchSource(ctx context.Context,
 	jpm jasper.Manager,
 	opts cloneOpts) error {
 
+	if c.ReferenceCache {
+		release := c.useReferenceCache(ctx, logger, conf, jpm, &opts)
+		defer release()
+	}
+
 	gitCommands, err := c.buildCloneCommand(ctx, comm, logger, conf, opts)
 	if err != nil {
 		return err
@@ -630,11 +736,13 @@ func (c *gitFetchProject) fetchModuleSource(ctx context.Context,
 	}
 
 	opts := cloneOpts{
-		location: module.Repo,
-		owner:    owner,
-		repo:     repo,
-		branch:   "",
-		dir:      moduleBase,
+		location:       module.Repo,
+		owner:          owner,
+		repo:           repo,
+		branch:         "",
+		dir:            moduleBase,
+		filter:         c.CloneFilter,
+		sparseCheckout: c.ModuleSparseCheckout[moduleName],
 	}
 	// Module's location takes precedence over the project-level clone
 	// method.
@@ -647,6 +755,10 @@ func (c *gitFetchProject) fetchModuleSource(ctx context.Context,
 	if err = opts.validate(); err != nil {
 		return errors.Wrap(err, "validating clone options")
 	}
+	if c.ReferenceCache {
+		release := c.useReferenceCache(ctx, logger, conf, jpm, &opts)
+		defer release()
+	}
 
 	var moduleCmds []string
 	moduleCmds, err = c.buildModuleCloneCommand(conf, opts, revision, modulePatch)
@@ -783,20 +895,21 @@ func (c *gitFetchProject) fetch(ctx context.Context,
 	return nil
 }
 
-// reorder a slice of ModulePatches so the main patch is last
+// reorder a slice of ModulePatches so the main patches are last. Patches
+// otherwise keep their relative order, so stacked patches still apply in the
+// order of the stack.
 func reorderPatches(originalPatches []patch.ModulePatch) []patch.ModulePatch {
-	patches := make([]patch.ModulePatch, len(originalPatches))
-	index := 0
+	patches := make([]patch.ModulePatch, 0, len(originalPatches))
+	var mainPatches []patch.ModulePatch
 	for _, mp := range originalPatches {
 		if mp.ModuleName == "" {
-			patches[len(patches)-1] = mp
+			mainPatches = append(mainPatches, mp)
 		} else {
-			patches[index] = mp
-			index++
+			patches = append(patches, mp)
 		}
 	}
 
-	return patches
+	return append(patches, mainPatches...)
 }
 
 func (c *gitFetchProject) logModuleRevision(logger client.LoggerProducer, revision, module, reason string) {
@@ -869,8 +982,10 @@ func isMailboxPatch(patchFile string, conf *internal.TaskConfig) (bool, error) {
 }
 
 // getPatchCommands, given a module patch of a patch, will return the appropriate list of commands that
-// need to be executed, except for apply. If the patch is empty it will not apply the patch.
-func getPatchCommands(modulePatch patch.ModulePatch, conf *internal.TaskConfig, moduleDir, patchPath string) []string {
+// need to be executed, except for apply. If the patch is empty it will not apply the patch. The
+// checkout is only reset to the module patch's revision if reset is true, so that later changes
+// to the same module in a stacked patch apply on top of earlier ones.
+func getPatchCommands(modulePatch patch.ModulePatch, conf *internal.TaskConfig, moduleDir, patchPath string, reset bool) []string {
 	patchCommands := []string{
 		"set -o xtrace",
 		"set -o errexit",
@@ -878,7 +993,7 @@ func getPatchCommands(modulePatch patch.ModulePatch, conf *internal.TaskConfig,
 	if moduleDir != "" {
 		patchCommands = append(patchCommands, fmt.Sprintf("cd '%s'", moduleDir))
 	}
-	if conf.Task.Requester != evergreen.MergeTestRequester {
+	if reset && conf.Task.Requester != evergreen.MergeTestRequester {
 		patchCommands = append(patchCommands, fmt.Sprintf("git reset --hard '%s'", modulePatch.Githash))
 	}
 
@@ -896,6 +1011,7 @@ func (c *gitFetchProject) applyPatch(ctx context.Context, logger client.LoggerPr
 	jpm := c.JasperManager()
 
 	// patch sets and contain multiple patches, some of them for modules
+	patchedModules := map[string]bool{}
 	for _, patchPart := range patches {
 		if err := ctx.Err(); err != nil {
 			return errors.Wrapf(err, "canceled while applying module patch '%s'", patchPart.ModuleName)
@@ -951,7 +1067,8 @@ func (c *gitFetchProject) applyPatch(ctx context.Context, logger client.LoggerPr
 		tempAbsPath := tempFile.Name()
 
 		// this applies the patch using the patch files in the temp directory
-		patchCommandStrings := getPatchCommands(patchPart, conf, moduleDir, tempAbsPath)
+		patchCommandStrings := getPatchCommands(patchPart, conf, moduleDir, tempAbsPath, !patchedModules[patchPart.ModuleName])
+		patchedModules[patchPart.ModuleName] = true
 		applyCommand, err := c.getApplyCommand(tempAbsPath, conf)
 		if err != nil {
 			return errors.Wrap(err, "getting git apply command")
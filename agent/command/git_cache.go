@@ -0,0 +1,329 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/jasper"
+	"github.com/pkg/errors"
+)
+
+const (
+	// gitReferenceCacheDirName is the name of the directory in the agent's
+	// working directory that holds the reference cache. It starts with a dot
+	// so that the agent does not remove it when it cleans up the working
+	// directory on startup.
+	gitReferenceCacheDirName = ".git_reference_cache"
+
+	defaultGitReferenceCacheMaxSizeMB = 20 * 1024
+	gitReferenceCacheLockTimeout      = 15 * time.Minute
+	// gitReferenceCacheStaleLockAge is how old a lock has to be before it is
+	// assumed to belong to an agent that exited without releasing it.
+	gitReferenceCacheStaleLockAge = 2 * time.Hour
+	// gitReferenceCacheLockRefreshInterval is how often a held lock is
+	// touched so that it does not become stale during a long clone.
+	gitReferenceCacheLockRefreshInterval = time.Minute
+)
+
+// gitReferenceCache is a host-level cache of bare repositories that clones
+// use as a reference, so that only the objects missing from the cache are
+// fetched over the network. The cache is shared by all tasks that run on the
+// host. Each repository is protected by a lock file, and the least recently
+// used repositories are removed once the cache grows too large.
+type gitReferenceCache struct {
+	dir                 string
+	maxSize             int64
+	lockTimeout         time.Duration
+	lockRefreshInterval time.Duration
+}
+
+// newGitReferenceCache returns the reference cache for the host. The cache
+// is kept next to the task directories in the agent's working directory.
+func newGitReferenceCache(workDir string, maxSizeMB int) (*gitReferenceCache, error) {
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting absolute path of working directory '%s'", workDir)
+	}
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultGitReferenceCacheMaxSizeMB
+	}
+	return &gitReferenceCache{
+		dir:                 filepath.Join(filepath.Dir(absWorkDir), gitReferenceCacheDirName),
+		maxSize:             int64(maxSizeMB) * 1024 * 1024,
+		lockTimeout:         gitReferenceCacheLockTimeout,
+		lockRefreshInterval: gitReferenceCacheLockRefreshInterval,
+	}, nil
+}
+
+// repoPath returns the path of the cached bare repository for owner/repo.
+func (c *gitReferenceCache) repoPath(owner, repo string) string {
+	return filepath.Join(c.dir, owner, fmt.Sprintf("%s.git", repo))
+}
+
+func lockPath(repoPath string) string { return repoPath + ".lock" }
+
+// lock waits until it holds the lock for the cached repository. The returned
+// function releases the lock.
+func (c *gitReferenceCache) lock(ctx context.Context, repoPath string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(repoPath), 0777); err != nil {
+		return nil, errors.Wrapf(err, "creating reference cache directory for '%s'", repoPath)
+	}
+
+	timer := time.NewTimer(c.lockTimeout)
+	defer timer.Stop()
+	for {
+		unlock, err := c.tryLock(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if unlock != nil {
+			return unlock, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ctx.Err(), "waiting for lock on '%s'", repoPath)
+		case <-timer.C:
+			return nil, errors.Errorf("timed out after %s waiting for lock on '%s'", c.lockTimeout, repoPath)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// tryLock attempts to take the lock for the cached repository without
+// waiting. It returns a nil function if another process holds the lock. While
+// the lock is held, it is periodically touched so that other processes do not
+// consider it stale.
+func (c *gitReferenceCache) tryLock(repoPath string) (func(), error) {
+	path := lockPath(repoPath)
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(os.Getpid()))
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, errors.Wrapf(err, "writing lock file '%s'", path)
+			}
+			return c.holdLock(path), nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.Wrapf(err, "creating lock file '%s'", path)
+		}
+
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking lock file '%s'", path)
+		}
+		if time.Since(info.ModTime()) < gitReferenceCacheStaleLockAge {
+			return nil, nil
+		}
+		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "removing stale lock file '%s'", path)
+		}
+	}
+	return nil, nil
+}
+
+// holdLock keeps the lock file fresh until the returned function is called,
+// which releases the lock.
+func (c *gitReferenceCache) holdLock(path string) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(c.lockRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				_ = os.Chtimes(path, now, now)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			<-stopped
+			_ = os.Remove(path)
+		})
+	}
+}
+
+// updateCommands returns the commands to create the cached repository if it
+// does not exist yet and fetch all of its branches and tags. The remote is
+// passed on the command line rather than stored in the repository so that
+// tokens are never written to disk.
+func (c *gitReferenceCache) updateCommands(repoPath string, opts cloneOpts) ([]string, error) {
+	remote, err := opts.remoteURL()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting repository URL")
+	}
+	cmds := []string{"set -o errexit"}
+	if _, err := os.Stat(filepath.Join(repoPath, "HEAD")); os.IsNotExist(err) {
+		cmds = append(cmds,
+			fmt.Sprintf("rm -rf '%s'", repoPath),
+			fmt.Sprintf("git init --bare --quiet '%s'", repoPath),
+		)
+	}
+	return append(cmds, fmt.Sprintf("git -C '%s' fetch --prune --quiet '%s' '+refs/heads/*:refs/heads/*' '+refs/tags/*:refs/tags/*'", repoPath, remote)), nil
+}
+
+// update brings the cached repository up to date with its remote. The caller
+// must hold the repository's lock.
+func (c *gitReferenceCache) update(ctx context.Context, logger client.LoggerProducer, jpm jasper.Manager, repoPath string, opts cloneOpts) error {
+	cmds, err := c.updateCommands(repoPath, opts)
+	if err != nil {
+		return err
+	}
+
+	logger.Execution().Infof("Updating reference cache '%s'.", repoPath)
+	stdErr := utility.MakeSafeBuffer(bytes.Buffer{})
+	err = jpm.CreateCommand(ctx).Add([]string{"bash", "-c", strings.Join(cmds, "\n")}).
+		SetOutputSender(level.Info, logger.Task().GetSender()).SetErrorWriter(stdErr).Run(ctx)
+	if out := stdErr.String(); out != "" {
+		if opts.token != "" {
+			out = strings.Replace(out, opts.token, "[redacted oauth token]", -1)
+		}
+		logger.Execution().Info(out)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "fetching into reference cache '%s'", repoPath)
+	}
+
+	now := time.Now()
+	return errors.Wrapf(os.Chtimes(repoPath, now, now), "marking reference cache '%s' as used", repoPath)
+}
+
+type cachedGitRepo struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// prune removes the least recently used repositories from the cache until it
+// is smaller than its maximum size. Repositories that are in use, either by
+// the caller or by another process holding their lock, are not removed.
+func (c *gitReferenceCache) prune(logger client.LoggerProducer, inUse string) error {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*", "*.git"))
+	if err != nil {
+		return errors.Wrap(err, "listing cached repositories")
+	}
+
+	var total int64
+	repos := make([]cachedGitRepo, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		size, err := dirSize(path)
+		if err != nil {
+			return errors.Wrapf(err, "getting size of cached repository '%s'", path)
+		}
+		total += size
+		repos = append(repos, cachedGitRepo{path: path, size: size, lastUsed: info.ModTime()})
+	}
+	if total <= c.maxSize {
+		return nil
+	}
+
+	sort.Slice(repos, func(i, j int) bool { return repos[i].lastUsed.Before(repos[j].lastUsed) })
+	for _, repo := range repos {
+		if total <= c.maxSize {
+			break
+		}
+		if repo.path == inUse {
+			continue
+		}
+		unlock, err := c.tryLock(repo.path)
+		if err != nil {
+			return err
+		}
+		if unlock == nil {
+			continue
+		}
+		err = os.RemoveAll(repo.path)
+		unlock()
+		if err != nil {
+			return errors.Wrapf(err, "removing cached repository '%s'", repo.path)
+		}
+		total -= repo.size
+		logger.Execution().Info(message.Fields{
+			"message":    "removed least recently used repository from reference cache",
+			"repository": repo.path,
+			"size_bytes": repo.size,
+			"last_used":  repo.lastUsed,
+		})
+	}
+	if total > c.maxSize {
+		logger.Execution().Warningf("Reference cache '%s' is still %d bytes, which exceeds its maximum size of %d bytes, because the remaining repositories are in use.", c.dir, total, c.maxSize)
+	}
+	return nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// useReferenceCache updates the host's cached copy of the repository and sets
+// it as the clone's reference repository. Since the cache only speeds up the
+// clone, failing to use it is logged instead of failing the clone. The
+// returned function must be called once the clone finishes.
+func (c *gitFetchProject) useReferenceCache(ctx context.Context, logger client.LoggerProducer, conf *internal.TaskConfig, jpm jasper.Manager, opts *cloneOpts) func() {
+	cache, err := newGitReferenceCache(conf.WorkDir, c.ReferenceCacheMaxSizeMB)
+	if err != nil {
+		logger.Execution().Warning(errors.Wrap(err, "getting reference cache, cloning without it"))
+		return func() {}
+	}
+	repoPath := cache.repoPath(opts.owner, opts.repo)
+	unlock, err := cache.lock(ctx, repoPath)
+	if err != nil {
+		logger.Execution().Warning(errors.Wrapf(err, "locking reference cache for repository '%s/%s', cloning without it", opts.owner, opts.repo))
+		return func() {}
+	}
+	if err = cache.update(ctx, logger, jpm, repoPath, *opts); err != nil {
+		unlock()
+		logger.Execution().Warning(errors.Wrapf(err, "updating reference cache for repository '%s/%s', cloning without it", opts.owner, opts.repo))
+		return func() {}
+	}
+
+	opts.referenceRepo = repoPath
+	return func() {
+		unlock()
+		logger.Execution().Warning(errors.Wrap(cache.prune(logger, repoPath), "pruning reference cache"))
+	}
+}
@@ -562,6 +562,45 @@ func (s *GitGetProjectSuite) TestBuildCommand() {
 	s.Equal("git log --oneline -n 10", cmds[9])
 }
 
+func (s *GitGetProjectSuite) TestBuildCommandWithSparseCheckout() {
+	conf := s.taskConfig1
+	logger, err := s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}, nil)
+	s.NoError(err)
+
+	c := gitFetchProject{
+		Directory: "dir",
+	}
+	opts := cloneOpts{
+		method:         evergreen.CloneMethodLegacySSH,
+		branch:         conf.ProjectRef.Branch,
+		owner:          conf.ProjectRef.Owner,
+		repo:           conf.ProjectRef.Repo,
+		dir:            c.Directory,
+		filter:         "blob:none",
+		sparseCheckout: []string{"/src/"},
+		referenceRepo:  "/cache/deafgoat/mci_test.git",
+	}
+	s.Require().NoError(opts.setLocation())
+	cmds, err := c.buildCloneCommand(s.ctx, s.comm, logger, conf, opts)
+	s.NoError(err)
+	s.Require().Len(cmds, 9)
+	s.Equal("git clone 'git@github.com:deafgoat/mci_test.git' 'dir' --branch 'master' --reference '/cache/deafgoat/mci_test.git' --dissociate --filter 'blob:none' --no-checkout", cmds[3])
+	s.Equal("cd dir", cmds[4])
+	s.Equal("git sparse-checkout set --no-cone '/src/'", cmds[5])
+	s.Equal("git checkout", cmds[6])
+	s.Equal("git reset --hard ", cmds[7])
+}
+
+func (s *GitGetProjectSuite) TestExpandsSparseCheckoutPaths() {
+	c := &gitFetchProject{
+		SparseCheckout:       []string{"/${dir}/"},
+		ModuleSparseCheckout: map[string][]string{"sample": {"/${dir}/", "/docs/"}},
+	}
+	s.Require().NoError(util.ExpandValues(c, util.NewExpansions(map[string]string{"dir": "src"})))
+	s.Equal([]string{"/src/"}, c.SparseCheckout)
+	s.Equal([]string{"/src/", "/docs/"}, c.ModuleSparseCheckout["sample"])
+}
+
 func (s *GitGetProjectSuite) TestBuildCommandForPullRequests() {
 	conf := s.taskConfig3
 	logger, err := s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}, nil)
@@ -589,6 +628,34 @@ func (s *GitGetProjectSuite) TestBuildCommandForPullRequests() {
 	s.Equal("git log --oneline -n 10", cmds[8])
 }
 
+func (s *GitGetProjectSuite) TestBuildCommandForGithubMergeQueue() {
+	conf := s.taskConfig1
+	conf.Task.Requester = evergreen.GithubMergeRequester
+	conf.Task.Revision = "abcdef"
+	logger, err := s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}, nil)
+	s.NoError(err)
+
+	c := gitFetchProject{
+		Directory: "dir",
+	}
+	opts := cloneOpts{
+		method: evergreen.CloneMethodLegacySSH,
+		branch: conf.ProjectRef.Branch,
+		owner:  conf.ProjectRef.Owner,
+		repo:   conf.ProjectRef.Repo,
+		dir:    c.Directory,
+	}
+	s.Require().NoError(opts.setLocation())
+
+	cmds, err := c.buildCloneCommand(s.ctx, s.comm, logger, conf, opts)
+	s.NoError(err)
+	s.Require().Len(cmds, 8)
+	s.Equal("cd dir", cmds[4])
+	s.Equal("git fetch origin abcdef", cmds[5])
+	s.Equal("git reset --hard abcdef", cmds[6])
+	s.Equal("git log --oneline -n 10", cmds[7])
+}
+
 func (s *GitGetProjectSuite) TestBuildCommandForCLIMergeTests() {
 	conf := s.taskConfig2
 	logger, err := s.comm.GetLoggerProducer(s.ctx, client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}, nil)
@@ -954,6 +1021,17 @@ func (s *GitGetProjectSuite) TestReorderPatches() {
 	s.Equal("m0", patches[0].ModuleName)
 	s.Equal("m1", patches[1].ModuleName)
 	s.Equal("", patches[2].ModuleName)
+
+	patches = []patch.ModulePatch{
+		{ModuleName: "", Githash: "base"},
+		{ModuleName: "m0"},
+		{ModuleName: "", Githash: "stacked"},
+	}
+	patches = reorderPatches(patches)
+	s.Require().Len(patches, 3)
+	s.Equal("m0", patches[0].ModuleName)
+	s.Equal("base", patches[1].Githash)
+	s.Equal("stacked", patches[2].Githash)
 }
 
 func (s *GitGetProjectSuite) TestMergeMultiplePatches() {
@@ -0,0 +1,308 @@
+package command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// httpGet is a command to download a file over HTTP, verify its checksum,
+// and either save it or extract it.
+type httpGet struct {
+	// URL is the URL of the file to download.
+	URL string `mapstructure:"url" plugin:"expand"`
+
+	// Headers are added to the request, such as an Authorization header
+	// whose value comes from an expansion. Their values are never logged.
+	Headers map[string]string `mapstructure:"headers" plugin:"expand"`
+
+	// SHA256 and SHA512 are the hex-encoded checksum that the file must
+	// match. If both are given, only SHA512 is verified.
+	SHA256 string `mapstructure:"sha256" plugin:"expand"`
+	SHA512 string `mapstructure:"sha512" plugin:"expand"`
+
+	// Only one of these two should be specified. LocalFile is the path to
+	// save the file to, and ExtractTo is the directory to extract the file
+	// into, in which case its archive format is detected from the URL.
+	LocalFile string `mapstructure:"local_file" plugin:"expand"`
+	ExtractTo string `mapstructure:"extract_to" plugin:"expand"`
+
+	// MaxAttempts is the number of times to try the download before
+	// failing.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// SkipCache downloads the file even if the host's download cache has a
+	// file with the same checksum, and does not add it to the cache.
+	SkipCache bool `mapstructure:"skip_cache"`
+	// CacheMaxSizeMB is the size above which the least recently used files
+	// are removed from the host's download cache.
+	CacheMaxSizeMB int `mapstructure:"cache_max_size_mb"`
+
+	checksum *httpChecksum
+
+	base
+}
+
+func httpGetFactory() Command   { return &httpGet{} }
+func (c *httpGet) Name() string { return "http.get" }
+
+func (c *httpGet) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrap(err, "decoding mapstructure params")
+	}
+
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = defaultHTTPOpAttempts
+	}
+
+	return errors.Wrap(c.validateParams(), "validating params")
+}
+
+func (c *httpGet) validateParams() error {
+	if c.URL == "" {
+		return errors.New("URL cannot be blank")
+	}
+	if c.LocalFile != "" && c.ExtractTo != "" {
+		return errors.New("cannot specify both local file path and directory to extract to")
+	}
+	if c.LocalFile == "" && c.ExtractTo == "" {
+		return errors.New("must specify either local file path or directory to extract to")
+	}
+	if c.MaxAttempts < 0 {
+		return errors.New("max attempts cannot be negative")
+	}
+	if c.CacheMaxSizeMB < 0 {
+		return errors.New("cache max size cannot be negative")
+	}
+	if util.IsExpandable(c.SHA256) || util.IsExpandable(c.SHA512) {
+		return nil
+	}
+
+	checksum, err := newHTTPChecksum(c.SHA256, c.SHA512)
+	if err != nil {
+		return errors.Wrap(err, "parsing checksum")
+	}
+	c.checksum = checksum
+
+	return nil
+}
+
+func (c *httpGet) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *internal.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "applying expansions")
+	}
+	if err := c.validateParams(); err != nil {
+		return errors.Wrap(err, "validating expanded params")
+	}
+
+	parsedURL, err := url.Parse(c.URL)
+	if err != nil {
+		return errors.Wrapf(err, "parsing URL")
+	}
+	fileName := path.Base(parsedURL.Path)
+	if fileName == "." || fileName == "/" {
+		fileName = "download"
+	}
+
+	if c.LocalFile != "" {
+		if !filepath.IsAbs(c.LocalFile) {
+			c.LocalFile = getJoinedWithWorkDir(conf, c.LocalFile)
+		}
+		if err = createEnclosingDirectoryIfNeeded(c.LocalFile); err != nil {
+			return errors.Wrapf(err, "creating parent directories for local file '%s'", c.LocalFile)
+		}
+	}
+	if c.ExtractTo != "" {
+		if !filepath.IsAbs(c.ExtractTo) {
+			c.ExtractTo = getJoinedWithWorkDir(conf, c.ExtractTo)
+		}
+		if err = createEnclosingDirectoryIfNeeded(c.ExtractTo); err != nil {
+			return errors.Wrapf(err, "creating parent directories for extraction directory '%s'", c.ExtractTo)
+		}
+	}
+
+	if c.checksum == nil {
+		logger.Task().Warningf("No checksum was given for '%s', so the download will not be verified.", parsedURL.Redacted())
+	}
+
+	downloaded, cleanup, err := c.download(ctx, logger, conf, parsedURL, fileName)
+	if err != nil {
+		if isChecksumMismatch(err) {
+			c.SetType(evergreen.CommandTypeSetup)
+		} else if ctx.Err() == nil && isSystemHTTPError(err) {
+			c.SetType(evergreen.CommandTypeSystem)
+		}
+		return errors.Wrapf(err, "downloading '%s'", parsedURL.Redacted())
+	}
+	defer cleanup()
+
+	if c.LocalFile != "" {
+		return errors.Wrapf(copyFile(downloaded, c.LocalFile), "saving download to local file '%s'", c.LocalFile)
+	}
+	return errors.Wrapf(extractArchive(downloaded, c.ExtractTo), "extracting download to '%s'", c.ExtractTo)
+}
+
+// download returns the path of a verified copy of the file, either from the
+// host's download cache or from downloading it. The returned function must be
+// called once the file is no longer needed.
+func (c *httpGet) download(ctx context.Context, logger client.LoggerProducer, conf *internal.TaskConfig, u *url.URL, fileName string) (string, func(), error) {
+	var cache *httpDownloadCache
+	if c.checksum != nil && !c.SkipCache {
+		var err error
+		cache, err = newHTTPDownloadCache(conf.WorkDir, c.CacheMaxSizeMB)
+		if err != nil {
+			logger.Execution().Warning(errors.Wrap(err, "getting download cache, downloading without it"))
+		} else if cached, ok := cache.get(c.checksum); ok {
+			logger.Task().Infof("Using cached copy of '%s' with %s checksum '%s'.", u.Redacted(), c.checksum.algorithm, c.checksum.expected)
+			return c.linkCached(conf, cache, cached, fileName)
+		}
+	}
+
+	var (
+		tmp *os.File
+		err error
+	)
+	if cache != nil {
+		tmp, err = cache.tempFile()
+	} else {
+		var tmpDir string
+		tmpDir, err = conf.GetWorkingDirectory("tmp")
+		if err == nil {
+			tmp, err = os.CreateTemp(tmpDir, "download-*-"+fileName)
+		}
+	}
+	if err != nil {
+		return "", nil, errors.Wrap(err, "creating temporary file for download")
+	}
+	tmpPath := tmp.Name()
+	_ = tmp.Close()
+	removeTmp := func() { _ = os.Remove(tmpPath) }
+
+	if err = c.getWithRetry(ctx, logger, u, tmpPath); err != nil {
+		removeTmp()
+		return "", nil, err
+	}
+
+	if cache == nil {
+		return tmpPath, removeTmp, nil
+	}
+	cached, err := cache.put(tmpPath, c.checksum)
+	if err != nil {
+		removeTmp()
+		return "", nil, errors.Wrap(err, "adding download to cache")
+	}
+	logger.Execution().Warning(errors.Wrap(cache.prune(logger, filepath.Dir(cached)), "pruning download cache"))
+	return c.linkCached(conf, cache, cached, fileName)
+}
+
+// linkCached makes the cached file available under the name of the file being
+// downloaded in a temporary directory, which is removed by the returned
+// function.
+func (c *httpGet) linkCached(conf *internal.TaskConfig, cache *httpDownloadCache, cached, fileName string) (string, func(), error) {
+	tmpDir, err := conf.GetWorkingDirectory("tmp")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "getting temporary directory")
+	}
+	linkDir, err := os.MkdirTemp(tmpDir, "download-")
+	if err != nil {
+		return "", nil, errors.Wrap(err, "creating temporary directory for cached download")
+	}
+	removeLinkDir := func() { _ = os.RemoveAll(linkDir) }
+
+	path, err := cache.linkAs(cached, linkDir, fileName)
+	if err != nil {
+		removeLinkDir()
+		return "", nil, err
+	}
+	return path, removeLinkDir, nil
+}
+
+// getWithRetry downloads the file, retrying failures that may be transient.
+// A download that does not match its checksum is retried, since it may have
+// been corrupted in transit.
+func (c *httpGet) getWithRetry(ctx context.Context, logger client.LoggerProducer, u *url.URL, dst string) error {
+	backoffCounter := getHTTPOpBackoff()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var err error
+	for i := 1; i <= c.MaxAttempts; i++ {
+		logger.Task().Infof("Downloading '%s' (attempt %d of %d).", u.Redacted(), i, c.MaxAttempts)
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "canceled while running command '%s'", c.Name())
+		case <-timer.C:
+			err = c.get(ctx, u, dst)
+			if err == nil {
+				return nil
+			}
+			if !isRetryableHTTPError(err) {
+				return err
+			}
+
+			logger.Execution().Errorf("Problem downloading '%s', retrying: %s", u.Redacted(), err)
+			timer.Reset(backoffCounter.Duration())
+		}
+	}
+
+	return errors.Wrapf(err, "command '%s' failed after %d attempts", c.Name(), c.MaxAttempts)
+}
+
+// get downloads the file to dst and verifies its checksum.
+func (c *httpGet) get(ctx context.Context, u *url.URL, dst string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+
+	httpClient := utility.GetHTTPClient()
+	httpClient.Timeout = httpClientTimeout
+	defer utility.PutHTTPClient(httpClient)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return &httpTransportError{err: errors.Wrap(err, "sending request")}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &httpStatusError{statusCode: resp.StatusCode, status: resp.Status}
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return errors.Wrapf(err, "creating file '%s'", dst)
+	}
+	defer f.Close()
+
+	if c.checksum == nil {
+		if _, err = io.Copy(f, resp.Body); err != nil {
+			return &httpTransportError{err: errors.Wrap(err, "reading response body")}
+		}
+		return nil
+	}
+
+	hasher := c.checksum.newHash()
+	if _, err = io.Copy(io.MultiWriter(f, hasher), resp.Body); err != nil {
+		return &httpTransportError{err: errors.Wrap(err, "reading response body")}
+	}
+	return c.checksum.verify(hasher)
+}
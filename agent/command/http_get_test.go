@@ -0,0 +1,306 @@
+package command
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func sha512Hex(data []byte) string {
+	sum := sha512.Sum512(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestHTTPGetParseParams(t *testing.T) {
+	validSHA256 := sha256Hex([]byte("content"))
+	for tName, tCase := range map[string]struct {
+		params      map[string]interface{}
+		expectError bool
+	}{
+		"SucceedsWithLocalFile": {
+			params: map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "sha256": validSHA256},
+		},
+		"SucceedsWithExtractTo": {
+			params: map[string]interface{}{"url": "https://example.com/file.tgz", "extract_to": "dir", "sha256": validSHA256},
+		},
+		"SucceedsWithoutChecksum": {
+			params: map[string]interface{}{"url": "https://example.com/file", "local_file": "file"},
+		},
+		"SucceedsWithExpandableChecksum": {
+			params: map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "sha256": "${sum}"},
+		},
+		"FailsWithoutURL": {
+			params:      map[string]interface{}{"local_file": "file"},
+			expectError: true,
+		},
+		"FailsWithBothDestinations": {
+			params:      map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "extract_to": "dir"},
+			expectError: true,
+		},
+		"FailsWithoutDestination": {
+			params:      map[string]interface{}{"url": "https://example.com/file"},
+			expectError: true,
+		},
+		"FailsWithNonHexChecksum": {
+			params:      map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "sha256": strings.Repeat("z", 64)},
+			expectError: true,
+		},
+		"FailsWithWrongLengthChecksum": {
+			params:      map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "sha512": validSHA256},
+			expectError: true,
+		},
+		"FailsWithNegativeMaxAttempts": {
+			params:      map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "max_attempts": -1},
+			expectError: true,
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			cmd := &httpGet{}
+			err := cmd.ParseParams(tCase.params)
+			if tCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, defaultHTTPOpAttempts, cmd.MaxAttempts)
+		})
+	}
+}
+
+func TestHTTPGetExecute(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	comm := client.NewMock("http://localhost.com")
+	logger, err := comm.GetLoggerProducer(ctx, client.TaskData{}, nil)
+	require.NoError(t, err)
+
+	content := []byte("the file content")
+
+	newConf := func(t *testing.T) *internal.TaskConfig {
+		workDir := filepath.Join(t.TempDir(), "task")
+		require.NoError(t, os.MkdirAll(filepath.Join(workDir, "tmp"), 0777))
+		return &internal.TaskConfig{
+			Expansions: &util.Expansions{},
+			Task:       &task.Task{},
+			Project:    &model.Project{},
+			WorkDir:    workDir,
+		}
+	}
+
+	type serverState struct {
+		requests   int32
+		statusCode int32
+	}
+	newServer := func(t *testing.T, state *serverState) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&state.requests, 1)
+			if code := atomic.LoadInt32(&state.statusCode); code != 0 {
+				w.WriteHeader(int(code))
+				return
+			}
+			if r.Header.Get("Authorization") != "" && r.Header.Get("Authorization") != "token secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write(content)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	for tName, tCase := range map[string]func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server){
+		"DownloadsAndVerifiesFile": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			cmd := &httpGet{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":        srv.URL + "/file.txt",
+				"local_file": "out/file.txt",
+				"sha512":     sha512Hex(content),
+				"headers":    map[string]string{"Authorization": "token ${token}"},
+			}))
+			conf.Expansions.Put("token", "secret")
+
+			require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+			downloaded, err := os.ReadFile(filepath.Join(conf.WorkDir, "out", "file.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, content, downloaded)
+			assert.EqualValues(t, 1, atomic.LoadInt32(&state.requests))
+		},
+		"UsesCachedDownload": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			for i := 0; i < 2; i++ {
+				cmd := &httpGet{}
+				require.NoError(t, cmd.ParseParams(map[string]interface{}{
+					"url":        srv.URL + "/file.txt",
+					"local_file": "file.txt",
+					"sha256":     sha256Hex(content),
+				}))
+				require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+			}
+			assert.EqualValues(t, 1, atomic.LoadInt32(&state.requests), "second download should come from cache")
+
+			cache, err := newHTTPDownloadCache(conf.WorkDir, 0)
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(filepath.Base(cache.dir), "."), "cache directory must be skipped by agent working directory cleanup")
+			_, ok := cache.get(&httpChecksum{algorithm: "sha256", expected: sha256Hex(content)})
+			assert.True(t, ok)
+		},
+		"UsesCachedDownloadWithDifferentName": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			for _, name := range []string{"file.txt", "other.txt"} {
+				cmd := &httpGet{}
+				require.NoError(t, cmd.ParseParams(map[string]interface{}{
+					"url":        srv.URL + "/" + name,
+					"local_file": name,
+					"sha256":     sha256Hex(content),
+				}))
+				require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+
+				downloaded, err := os.ReadFile(filepath.Join(conf.WorkDir, name))
+				require.NoError(t, err)
+				assert.Equal(t, content, downloaded)
+			}
+			assert.EqualValues(t, 1, atomic.LoadInt32(&state.requests), "same file with a different name should come from cache")
+		},
+		"SkipCacheAlwaysDownloads": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			for i := 0; i < 2; i++ {
+				cmd := &httpGet{}
+				require.NoError(t, cmd.ParseParams(map[string]interface{}{
+					"url":        srv.URL + "/file.txt",
+					"local_file": "file.txt",
+					"sha256":     sha256Hex(content),
+					"skip_cache": true,
+				}))
+				require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+			}
+			assert.EqualValues(t, 2, atomic.LoadInt32(&state.requests))
+		},
+		"DownloadsWithoutChecksum": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			cmd := &httpGet{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":        srv.URL + "/file.txt",
+				"local_file": "file.txt",
+			}))
+			require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+			downloaded, err := os.ReadFile(filepath.Join(conf.WorkDir, "file.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, content, downloaded)
+		},
+		"ChecksumMismatchIsSetupFailure": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			cmd := &httpGet{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":          srv.URL + "/file.txt",
+				"local_file":   "file.txt",
+				"sha256":       sha256Hex([]byte("other content")),
+				"max_attempts": 1,
+			}))
+			err := cmd.Execute(ctx, comm, logger, conf)
+			require.Error(t, err)
+			assert.True(t, isChecksumMismatch(err))
+			assert.Equal(t, evergreen.CommandTypeSetup, cmd.Type())
+			assert.NoFileExists(t, filepath.Join(conf.WorkDir, "file.txt"))
+		},
+		"ClientErrorIsNotRetriedAndIsTestFailure": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			atomic.StoreInt32(&state.statusCode, http.StatusNotFound)
+			cmd := &httpGet{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":        srv.URL + "/file.txt",
+				"local_file": "file.txt",
+				"sha256":     sha256Hex(content),
+			}))
+			require.Error(t, cmd.Execute(ctx, comm, logger, conf))
+			assert.EqualValues(t, 1, atomic.LoadInt32(&state.requests))
+			assert.Empty(t, cmd.Type(), "client errors should be test failures")
+		},
+		"ServerErrorIsSystemFailure": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			atomic.StoreInt32(&state.statusCode, http.StatusServiceUnavailable)
+			cmd := &httpGet{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":          srv.URL + "/file.txt",
+				"local_file":   "file.txt",
+				"sha256":       sha256Hex(content),
+				"max_attempts": 1,
+			}))
+			require.Error(t, cmd.Execute(ctx, comm, logger, conf))
+			assert.Equal(t, evergreen.CommandTypeSystem, cmd.Type())
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			state := &serverState{}
+			tCase(t, newConf(t), state, newServer(t, state))
+		})
+	}
+}
+
+func TestIsRetryableHTTPError(t *testing.T) {
+	assert.True(t, isRetryableHTTPError(&httpStatusError{statusCode: http.StatusInternalServerError}))
+	assert.True(t, isRetryableHTTPError(&httpStatusError{statusCode: http.StatusTooManyRequests}))
+	assert.False(t, isRetryableHTTPError(&httpStatusError{statusCode: http.StatusNotFound}))
+	assert.False(t, isRetryableHTTPError(&httpStatusError{statusCode: http.StatusForbidden}))
+	assert.True(t, isRetryableHTTPError(os.ErrDeadlineExceeded))
+}
+
+func TestIsSystemHTTPError(t *testing.T) {
+	assert.True(t, isSystemHTTPError(&httpStatusError{statusCode: http.StatusInternalServerError}))
+	assert.True(t, isSystemHTTPError(errors.Wrap(&httpTransportError{err: os.ErrDeadlineExceeded}, "downloading")))
+	assert.False(t, isSystemHTTPError(&httpStatusError{statusCode: http.StatusNotFound}))
+	assert.False(t, isSystemHTTPError(&httpStatusError{statusCode: http.StatusUnauthorized}))
+	assert.False(t, isSystemHTTPError(errors.New("creating file")))
+}
+
+func TestHTTPDownloadCachePrune(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	comm := client.NewMock("http://localhost.com")
+	logger, err := comm.GetLoggerProducer(ctx, client.TaskData{}, nil)
+	require.NoError(t, err)
+
+	cache, err := newHTTPDownloadCache(filepath.Join(t.TempDir(), "task"), 1)
+	require.NoError(t, err)
+
+	var sums []*httpChecksum
+	for i := 0; i < 3; i++ {
+		data := make([]byte, 600*1024)
+		data[0] = byte(i)
+		sum := &httpChecksum{algorithm: "sha256", expected: sha256Hex(data)}
+		tmp, err := cache.tempFile()
+		require.NoError(t, err)
+		_, err = tmp.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, tmp.Close())
+		_, err = cache.put(tmp.Name(), sum)
+		require.NoError(t, err)
+		sums = append(sums, sum)
+	}
+
+	require.NoError(t, cache.prune(logger, cache.entryDir(sums[0])))
+
+	_, ok := cache.get(sums[0])
+	assert.True(t, ok, "file in use should not be pruned")
+	_, ok = cache.get(sums[1])
+	assert.False(t, ok, "least recently used file should be pruned")
+	_, ok = cache.get(sums[2])
+	assert.False(t, ok, "cache should be pruned below its maximum size")
+}
@@ -0,0 +1,235 @@
+package command
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+const (
+	sha256ChecksumHeader = "X-Checksum-Sha256"
+	sha512ChecksumHeader = "X-Checksum-Sha512"
+)
+
+// httpPut is a command to upload a file over HTTP after verifying its
+// checksum.
+type httpPut struct {
+	// URL is the URL to upload the file to.
+	URL string `mapstructure:"url" plugin:"expand"`
+
+	// LocalFile is the path of the file to upload.
+	LocalFile string `mapstructure:"local_file" plugin:"expand"`
+
+	// Method is the HTTP method to upload the file with, either PUT or
+	// POST. It defaults to PUT.
+	Method string `mapstructure:"method" plugin:"expand"`
+
+	// ContentType is the content type of the file.
+	ContentType string `mapstructure:"content_type" plugin:"expand"`
+
+	// Headers are added to the request, such as an Authorization header
+	// whose value comes from an expansion. Their values are never logged.
+	Headers map[string]string `mapstructure:"headers" plugin:"expand"`
+
+	// SHA256 and SHA512 are the hex-encoded checksum that the file must
+	// match before it is uploaded. If both are given, only SHA512 is
+	// verified.
+	SHA256 string `mapstructure:"sha256" plugin:"expand"`
+	SHA512 string `mapstructure:"sha512" plugin:"expand"`
+
+	// SendChecksumHeaders sends the file's SHA-256 and SHA-512 checksums in
+	// the X-Checksum-Sha256 and X-Checksum-Sha512 headers so that the
+	// server can verify the upload.
+	SendChecksumHeaders bool `mapstructure:"send_checksum_headers"`
+
+	// MaxAttempts is the number of times to try the upload before failing.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	checksum *httpChecksum
+
+	base
+}
+
+func httpPutFactory() Command   { return &httpPut{} }
+func (c *httpPut) Name() string { return "http.put" }
+
+func (c *httpPut) ParseParams(params map[string]interface{}) error {
+	if err := mapstructure.Decode(params, c); err != nil {
+		return errors.Wrap(err, "decoding mapstructure params")
+	}
+
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = defaultHTTPOpAttempts
+	}
+
+	return errors.Wrap(c.validateParams(), "validating params")
+}
+
+func (c *httpPut) validateParams() error {
+	if c.URL == "" {
+		return errors.New("URL cannot be blank")
+	}
+	if c.LocalFile == "" {
+		return errors.New("local file cannot be blank")
+	}
+	if c.MaxAttempts < 0 {
+		return errors.New("max attempts cannot be negative")
+	}
+	if c.Method == "" {
+		c.Method = http.MethodPut
+	}
+	c.Method = strings.ToUpper(c.Method)
+	if !util.IsExpandable(c.Method) && c.Method != http.MethodPut && c.Method != http.MethodPost {
+		return errors.Errorf("method must be PUT or POST, not '%s'", c.Method)
+	}
+	if util.IsExpandable(c.SHA256) || util.IsExpandable(c.SHA512) {
+		return nil
+	}
+
+	checksum, err := newHTTPChecksum(c.SHA256, c.SHA512)
+	if err != nil {
+		return errors.Wrap(err, "parsing checksum")
+	}
+	c.checksum = checksum
+
+	return nil
+}
+
+func (c *httpPut) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *internal.TaskConfig) error {
+
+	if err := util.ExpandValues(c, conf.Expansions); err != nil {
+		return errors.Wrap(err, "applying expansions")
+	}
+	if err := c.validateParams(); err != nil {
+		return errors.Wrap(err, "validating expanded params")
+	}
+
+	parsedURL, err := url.Parse(c.URL)
+	if err != nil {
+		return errors.Wrapf(err, "parsing URL")
+	}
+
+	if !filepath.IsAbs(c.LocalFile) {
+		c.LocalFile = getJoinedWithWorkDir(conf, c.LocalFile)
+	}
+
+	if c.checksum != nil {
+		if err = c.checksum.verifyFile(c.LocalFile); err != nil {
+			if isChecksumMismatch(err) {
+				c.SetType(evergreen.CommandTypeSetup)
+			}
+			return errors.Wrapf(err, "verifying local file '%s'", c.LocalFile)
+		}
+		logger.Task().Infof("Verified %s checksum of local file '%s'.", c.checksum.algorithm, c.LocalFile)
+	}
+
+	headers := map[string]string{}
+	for k, v := range c.Headers {
+		headers[k] = v
+	}
+	if c.ContentType != "" {
+		headers["Content-Type"] = c.ContentType
+	}
+	if c.SendChecksumHeaders {
+		for header, algorithm := range map[string]string{
+			sha256ChecksumHeader: "sha256",
+			sha512ChecksumHeader: "sha512",
+		} {
+			sum := httpChecksum{algorithm: algorithm}
+			h := sum.newHash()
+			if err = hashFile(h, c.LocalFile); err != nil {
+				return errors.Wrapf(err, "computing %s checksum of local file '%s'", algorithm, c.LocalFile)
+			}
+			headers[header] = hex.EncodeToString(h.Sum(nil))
+		}
+	}
+
+	if err = c.putWithRetry(ctx, logger, parsedURL, headers); err != nil {
+		if ctx.Err() == nil && isSystemHTTPError(err) {
+			c.SetType(evergreen.CommandTypeSystem)
+		}
+		return errors.Wrapf(err, "uploading local file '%s' to '%s'", c.LocalFile, parsedURL.Redacted())
+	}
+
+	return nil
+}
+
+// putWithRetry uploads the file, retrying failures that may be transient.
+func (c *httpPut) putWithRetry(ctx context.Context, logger client.LoggerProducer, u *url.URL, headers map[string]string) error {
+	backoffCounter := getHTTPOpBackoff()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	var err error
+	for i := 1; i <= c.MaxAttempts; i++ {
+		logger.Task().Infof("Uploading local file '%s' to '%s' (attempt %d of %d).", c.LocalFile, u.Redacted(), i, c.MaxAttempts)
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "canceled while running command '%s'", c.Name())
+		case <-timer.C:
+			err = c.put(ctx, u, headers)
+			if err == nil {
+				return nil
+			}
+			if !isRetryableHTTPError(err) {
+				return err
+			}
+
+			logger.Execution().Errorf("Problem uploading local file '%s', retrying: %s", c.LocalFile, err)
+			timer.Reset(backoffCounter.Duration())
+		}
+	}
+
+	return errors.Wrapf(err, "command '%s' failed after %d attempts", c.Name(), c.MaxAttempts)
+}
+
+func (c *httpPut) put(ctx context.Context, u *url.URL, headers map[string]string) error {
+	f, err := os.Open(c.LocalFile)
+	if err != nil {
+		return errors.Wrapf(err, "opening local file '%s'", c.LocalFile)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "getting info for local file '%s'", c.LocalFile)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, c.Method, u.String(), f)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	req.ContentLength = info.Size()
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	httpClient := utility.GetHTTPClient()
+	httpClient.Timeout = httpClientTimeout
+	defer utility.PutHTTPClient(httpClient)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return &httpTransportError{err: errors.Wrap(err, "sending request")}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &httpStatusError{statusCode: resp.StatusCode, status: resp.Status}
+	}
+
+	return nil
+}
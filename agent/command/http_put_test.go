@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPPutParseParams(t *testing.T) {
+	for tName, tCase := range map[string]struct {
+		params         map[string]interface{}
+		expectError    bool
+		expectedMethod string
+	}{
+		"DefaultsToPut": {
+			params:         map[string]interface{}{"url": "https://example.com/file", "local_file": "file"},
+			expectedMethod: http.MethodPut,
+		},
+		"AllowsPost": {
+			params:         map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "method": "post"},
+			expectedMethod: http.MethodPost,
+		},
+		"FailsWithOtherMethod": {
+			params:      map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "method": "DELETE"},
+			expectError: true,
+		},
+		"FailsWithoutURL": {
+			params:      map[string]interface{}{"local_file": "file"},
+			expectError: true,
+		},
+		"FailsWithoutLocalFile": {
+			params:      map[string]interface{}{"url": "https://example.com/file"},
+			expectError: true,
+		},
+		"FailsWithInvalidChecksum": {
+			params:      map[string]interface{}{"url": "https://example.com/file", "local_file": "file", "sha256": "abc"},
+			expectError: true,
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			cmd := &httpPut{}
+			err := cmd.ParseParams(tCase.params)
+			if tCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tCase.expectedMethod, cmd.Method)
+		})
+	}
+}
+
+func TestHTTPPutExecute(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	comm := client.NewMock("http://localhost.com")
+	logger, err := comm.GetLoggerProducer(ctx, client.TaskData{}, nil)
+	require.NoError(t, err)
+
+	content := []byte("the file content")
+
+	type upload struct {
+		method  string
+		body    []byte
+		headers http.Header
+	}
+	type serverState struct {
+		requests   int32
+		statusCode int32
+		uploads    chan upload
+	}
+
+	for tName, tCase := range map[string]func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server){
+		"UploadsVerifiedFile": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			cmd := &httpPut{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":                   srv.URL + "/file.txt",
+				"local_file":            "file.txt",
+				"method":                "POST",
+				"content_type":          "text/plain",
+				"sha256":                sha256Hex(content),
+				"send_checksum_headers": true,
+			}))
+			require.NoError(t, cmd.Execute(ctx, comm, logger, conf))
+
+			require.Len(t, state.uploads, 1)
+			received := <-state.uploads
+			assert.Equal(t, http.MethodPost, received.method)
+			assert.Equal(t, content, received.body)
+			assert.Equal(t, "text/plain", received.headers.Get("Content-Type"))
+			assert.Equal(t, sha256Hex(content), received.headers.Get(sha256ChecksumHeader))
+			assert.Equal(t, sha512Hex(content), received.headers.Get(sha512ChecksumHeader))
+		},
+		"ChecksumMismatchIsSetupFailure": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			cmd := &httpPut{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":        srv.URL + "/file.txt",
+				"local_file": "file.txt",
+				"sha512":     sha512Hex([]byte("other content")),
+			}))
+			err := cmd.Execute(ctx, comm, logger, conf)
+			require.Error(t, err)
+			assert.True(t, isChecksumMismatch(err))
+			assert.Equal(t, evergreen.CommandTypeSetup, cmd.Type())
+			assert.Zero(t, atomic.LoadInt32(&state.requests), "file should not be uploaded")
+		},
+		"ClientErrorIsNotRetriedAndIsTestFailure": func(t *testing.T, conf *internal.TaskConfig, state *serverState, srv *httptest.Server) {
+			atomic.StoreInt32(&state.statusCode, http.StatusForbidden)
+			cmd := &httpPut{}
+			require.NoError(t, cmd.ParseParams(map[string]interface{}{
+				"url":        srv.URL + "/file.txt",
+				"local_file": "file.txt",
+			}))
+			require.Error(t, cmd.Execute(ctx, comm, logger, conf))
+			assert.EqualValues(t, 1, atomic.LoadInt32(&state.requests))
+			assert.Empty(t, cmd.Type(), "client errors should be test failures")
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			workDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(workDir, "file.txt"), content, 0644))
+			conf := &internal.TaskConfig{
+				Expansions: &util.Expansions{},
+				Task:       &task.Task{},
+				Project:    &model.Project{},
+				WorkDir:    workDir,
+			}
+
+			state := &serverState{uploads: make(chan upload, 10)}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&state.requests, 1)
+				if code := atomic.LoadInt32(&state.statusCode); code != 0 {
+					w.WriteHeader(int(code))
+					return
+				}
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				state.uploads <- upload{method: r.Method, body: body, headers: r.Header.Clone()}
+			}))
+			defer srv.Close()
+
+			tCase(t, conf, state, srv)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package command
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/jpillora/backoff"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHTTPOpAttempts = 5
+	httpOpSleep           = 2 * time.Second
+	httpOpRetryMaxSleep   = 30 * time.Second
+	httpClientTimeout     = 60 * time.Minute
+
+	// httpDownloadCacheDirName is the name of the directory in the agent's
+	// working directory that holds the download cache. It starts with a dot
+	// so that the agent does not remove it when it cleans up the working
+	// directory on startup.
+	httpDownloadCacheDirName = ".http_download_cache"
+	// httpDownloadCacheFileName is the name that every file is stored under
+	// in the download cache, so that the same file downloaded from URLs with
+	// different names is only cached once.
+	httpDownloadCacheFileName         = "download"
+	defaultHTTPDownloadCacheMaxSizeMB = 10 * 1024
+)
+
+func getHTTPOpBackoff() *backoff.Backoff {
+	return &backoff.Backoff{
+		Min:    httpOpSleep,
+		Max:    httpOpRetryMaxSleep,
+		Factor: 2,
+		Jitter: true,
+	}
+}
+
+// httpStatusError is returned for a request that got an unsuccessful
+// response.
+type httpStatusError struct {
+	statusCode int
+	status     string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("received unsuccessful response: %s", e.status)
+}
+
+// httpTransportError is returned for a request that could not be sent or
+// whose response could not be read.
+type httpTransportError struct {
+	err error
+}
+
+func (e *httpTransportError) Error() string {
+	return e.err.Error()
+}
+
+// isSystemHTTPError returns whether a request failed for a reason outside of
+// the task's control, which is either a transport error or a server error.
+// Client errors, such as from a bad URL or missing credentials, are caused by
+// the task's configuration.
+func isSystemHTTPError(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *httpTransportError:
+		return true
+	case *httpStatusError:
+		return cause.statusCode >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// isRetryableHTTPError returns whether a request that failed with the error
+// may succeed if it is retried. Requests rejected by the server with a client
+// error, other than being rate limited, will keep failing.
+func isRetryableHTTPError(err error) bool {
+	statusErr, ok := errors.Cause(err).(*httpStatusError)
+	if !ok {
+		return true
+	}
+	return statusErr.statusCode >= http.StatusInternalServerError || statusErr.statusCode == http.StatusTooManyRequests
+}
+
+// httpChecksum is the expected checksum of a file.
+type httpChecksum struct {
+	algorithm string
+	expected  string
+}
+
+// newHTTPChecksum returns the checksum to verify, preferring SHA-512 if both
+// are given. It returns nil if neither is given.
+func newHTTPChecksum(sha256Sum, sha512Sum string) (*httpChecksum, error) {
+	var sum httpChecksum
+	switch {
+	case sha512Sum != "":
+		sum = httpChecksum{algorithm: "sha512", expected: strings.ToLower(sha512Sum)}
+	case sha256Sum != "":
+		sum = httpChecksum{algorithm: "sha256", expected: strings.ToLower(sha256Sum)}
+	default:
+		return nil, nil
+	}
+
+	decoded, err := hex.DecodeString(sum.expected)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s checksum must be hex-encoded", sum.algorithm)
+	}
+	if len(decoded) != sum.newHash().Size() {
+		return nil, errors.Errorf("%s checksum must be %d bytes, but is %d bytes", sum.algorithm, sum.newHash().Size(), len(decoded))
+	}
+	return &sum, nil
+}
+
+func (c *httpChecksum) newHash() hash.Hash {
+	if c.algorithm == "sha512" {
+		return sha512.New()
+	}
+	return sha256.New()
+}
+
+// verify returns an error if the hash does not match the expected checksum.
+func (c *httpChecksum) verify(h hash.Hash) error {
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != c.expected {
+		return &checksumMismatchError{algorithm: c.algorithm, expected: c.expected, actual: actual}
+	}
+	return nil
+}
+
+// verifyFile returns an error if the file does not match the expected
+// checksum.
+func (c *httpChecksum) verifyFile(path string) error {
+	h := c.newHash()
+	if err := hashFile(h, path); err != nil {
+		return err
+	}
+	return c.verify(h)
+}
+
+// hashFile writes the contents of the file to the hash.
+func hashFile(h hash.Hash, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "opening file '%s'", path)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(h, f)
+	return errors.Wrapf(err, "reading file '%s'", path)
+}
+
+// checksumMismatchError is returned when a file does not match its expected
+// checksum.
+type checksumMismatchError struct {
+	algorithm string
+	expected  string
+	actual    string
+}
+
+func (e *checksumMismatchError) Error() string {
+	return fmt.Sprintf("%s checksum verification failed: expected '%s' but got '%s'", e.algorithm, e.expected, e.actual)
+}
+
+func isChecksumMismatch(err error) bool {
+	_, ok := errors.Cause(err).(*checksumMismatchError)
+	return ok
+}
+
+// httpDownloadCache is a host-level cache of downloaded files, keyed by their
+// checksum. Since a file is only added once it matches its checksum, any task
+// that expects the same checksum can use it instead of downloading the file
+// again. The least recently used files are removed once the cache grows too
+// large.
+type httpDownloadCache struct {
+	dir     string
+	maxSize int64
+}
+
+// newHTTPDownloadCache returns the download cache for the host. The cache is
+// kept next to the task directories in the agent's working directory.
+func newHTTPDownloadCache(workDir string, maxSizeMB int) (*httpDownloadCache, error) {
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting absolute path of working directory '%s'", workDir)
+	}
+	if maxSizeMB <= 0 {
+		maxSizeMB = defaultHTTPDownloadCacheMaxSizeMB
+	}
+	return &httpDownloadCache{
+		dir:     filepath.Join(filepath.Dir(absWorkDir), httpDownloadCacheDirName),
+		maxSize: int64(maxSizeMB) * 1024 * 1024,
+	}, nil
+}
+
+// entryDir returns the directory that holds the cached file with the
+// checksum.
+func (c *httpDownloadCache) entryDir(sum *httpChecksum) string {
+	return filepath.Join(c.dir, sum.algorithm, sum.expected)
+}
+
+// get returns the path to the cached file with the checksum, if there is one
+// and it still matches its checksum.
+func (c *httpDownloadCache) get(sum *httpChecksum) (string, bool) {
+	path := filepath.Join(c.entryDir(sum), httpDownloadCacheFileName)
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	if err := sum.verifyFile(path); err != nil {
+		_ = os.RemoveAll(c.entryDir(sum))
+		return "", false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(c.entryDir(sum), now, now)
+	return path, true
+}
+
+// tempFile creates a file in the cache directory to download into, so that
+// it can be moved into the cache without copying it.
+func (c *httpDownloadCache) tempFile() (*os.File, error) {
+	if err := os.MkdirAll(c.dir, 0777); err != nil {
+		return nil, errors.Wrapf(err, "creating download cache directory '%s'", c.dir)
+	}
+	f, err := os.CreateTemp(c.dir, "download-")
+	return f, errors.Wrap(err, "creating temporary file in download cache")
+}
+
+// put moves a file that matches the checksum into the cache and returns its
+// path in the cache. If another process has cached the same file in the
+// meantime, that file is used instead.
+func (c *httpDownloadCache) put(tmpPath string, sum *httpChecksum) (string, error) {
+	stagingDir, err := os.MkdirTemp(c.dir, "staging-")
+	if err != nil {
+		return "", errors.Wrap(err, "creating staging directory in download cache")
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err = os.Rename(tmpPath, filepath.Join(stagingDir, httpDownloadCacheFileName)); err != nil {
+		return "", errors.Wrap(err, "moving download into staging directory")
+	}
+	if err = os.MkdirAll(filepath.Dir(c.entryDir(sum)), 0777); err != nil {
+		return "", errors.Wrap(err, "creating download cache directory")
+	}
+	if err = os.Rename(stagingDir, c.entryDir(sum)); err != nil {
+		if path, ok := c.get(sum); ok {
+			return path, nil
+		}
+		return "", errors.Wrapf(err, "adding download to cache")
+	}
+	return filepath.Join(c.entryDir(sum), httpDownloadCacheFileName), nil
+}
+
+// linkAs makes the cached file available at a new path with the given name in
+// the directory, since the name determines how an archive is extracted. The
+// file is hard linked if possible and copied otherwise.
+func (c *httpDownloadCache) linkAs(cachedPath, dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	if err := os.Link(cachedPath, path); err == nil {
+		return path, nil
+	}
+	return path, errors.Wrap(copyFile(cachedPath, path), "copying cached download")
+}
+
+type cachedDownload struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// prune removes the least recently used files from the cache until it is
+// smaller than its maximum size. The file in use by the caller is not
+// removed.
+func (c *httpDownloadCache) prune(logger client.LoggerProducer, inUse string) error {
+	paths, err := filepath.Glob(filepath.Join(c.dir, "*", "*"))
+	if err != nil {
+		return errors.Wrap(err, "listing cached downloads")
+	}
+
+	var total int64
+	entries := make([]cachedDownload, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.IsDir() {
+			continue
+		}
+		size, err := dirSize(path)
+		if err != nil {
+			return errors.Wrapf(err, "getting size of cached download '%s'", path)
+		}
+		total += size
+		entries = append(entries, cachedDownload{path: path, size: size, lastUsed: info.ModTime()})
+	}
+	if total <= c.maxSize {
+		return nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].lastUsed.Before(entries[j].lastUsed) })
+	for _, entry := range entries {
+		if total <= c.maxSize {
+			break
+		}
+		if entry.path == inUse {
+			continue
+		}
+		if err := os.RemoveAll(entry.path); err != nil {
+			return errors.Wrapf(err, "removing cached download '%s'", entry.path)
+		}
+		total -= entry.size
+		logger.Execution().Info(message.Fields{
+			"message":    "removed least recently used file from download cache",
+			"path":       entry.path,
+			"size_bytes": entry.size,
+			"last_used":  entry.lastUsed,
+		})
+	}
+	return nil
+}
+
+// copyFile copies the file at src to dst, replacing dst if it exists.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return errors.Wrapf(err, "opening file '%s'", src)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return errors.Wrapf(err, "creating file '%s'", dst)
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return errors.Wrapf(err, "copying file '%s' to '%s'", src, dst)
+	}
+	return errors.Wrapf(out.Close(), "closing file '%s'", dst)
+}
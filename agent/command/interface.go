@@ -40,6 +40,12 @@ type Command interface {
 
 	SetJasperManager(jasper.Manager)
 	JasperManager() jasper.Manager
+
+	// Condition returns the command's `if` condition, which must be true
+	// for the command to run. An empty condition is always true.
+	Condition() string
+	// SetCondition sets the command's `if` condition.
+	SetCondition(string)
 }
 
 // base contains a basic implementation of functionality that is
@@ -48,6 +54,7 @@ type base struct {
 	idleTimeout time.Duration
 	typeName    string
 	displayName string
+	condition   string
 	jasper      jasper.Manager
 	mu          sync.RWMutex
 }
@@ -107,3 +114,17 @@ func (b *base) JasperManager() jasper.Manager {
 
 	return b.jasper
 }
+
+func (b *base) Condition() string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.condition
+}
+
+func (b *base) SetCondition(c string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.condition = c
+}
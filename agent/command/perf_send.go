@@ -6,6 +6,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/agent/internal"
 	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/poplar"
 	"github.com/evergreen-ci/poplar/rpc"
@@ -68,11 +69,23 @@ func (c *perfSend) Execute(ctx context.Context, comm client.Communicator, logger
 	}
 	c.addEvgData(report, conf)
 
+	// Send the metrics to Evergreen, which stores them in the tests'
+	// performance series and detects changes in performance. This is best
+	// effort so that it cannot prevent the report from reaching Cedar.
+	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+	if err = comm.SendPerfResults(ctx, td, perfResults(report.Tests)); err != nil {
+		logger.Task().Warning(errors.Wrap(err, "sending perf results to Evergreen"))
+	}
+
 	// Send data to the Cedar and Data-Pipes services.
 	conn, err := comm.GetCedarGRPCConn(ctx)
 	if err != nil {
 		return errors.Wrap(err, "connecting to Cedar")
 	}
+	if conn == nil {
+		logger.Task().Info("Cedar is not configured, skipping uploading the report to Cedar.")
+		return nil
+	}
 	dataPipes, err := comm.GetDataPipesConfig(ctx)
 	if err != nil {
 		return errors.Wrap(err, "getting the Data-Pipes config")
@@ -109,3 +122,43 @@ func (c *perfSend) addEvgData(report *poplar.Report, conf *internal.TaskConfig)
 	report.BucketConf.Prefix = c.Prefix
 	report.BucketConf.Region = c.Region
 }
+
+// perfResults returns the numeric metrics of the tests and their subtests.
+func perfResults(tests []poplar.Test) []apimodels.PerfResult {
+	var results []apimodels.PerfResult
+	for _, test := range tests {
+		for _, metric := range test.Metrics {
+			value, ok := perfMetricValue(metric.Value)
+			if !ok {
+				continue
+			}
+			results = append(results, apimodels.PerfResult{
+				Test:        test.Info.TestName,
+				Measurement: metric.Name,
+				Args:        test.Info.Arguments,
+				Value:       value,
+			})
+		}
+		results = append(results, perfResults(test.SubTests)...)
+	}
+	return results
+}
+
+func perfMetricValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
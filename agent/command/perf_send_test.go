@@ -5,6 +5,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/poplar"
 	"github.com/stretchr/testify/assert"
@@ -81,3 +82,28 @@ func TestPerfSendAddEvgData(t *testing.T) {
 	cmd.addEvgData(report, conf)
 	assert.Equal(t, expectedReport, report)
 }
+
+func TestPerfSendPerfResults(t *testing.T) {
+	tests := []poplar.Test{
+		{
+			Info: poplar.TestInfo{TestName: "insert", Arguments: map[string]int32{"threads": 8}},
+			Metrics: []poplar.TestMetrics{
+				{Name: "throughput", Value: 1500.5},
+				{Name: "ops", Value: int64(100)},
+				{Name: "label", Value: "not a number"},
+			},
+			SubTests: []poplar.Test{
+				{
+					Info:    poplar.TestInfo{TestName: "insert-one"},
+					Metrics: []poplar.TestMetrics{{Name: "latency", Value: 3}},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []apimodels.PerfResult{
+		{Test: "insert", Measurement: "throughput", Args: map[string]int32{"threads": 8}, Value: 1500.5},
+		{Test: "insert", Measurement: "ops", Args: map[string]int32{"threads": 8}, Value: 100},
+		{Test: "insert-one", Measurement: "latency", Value: 3},
+	}, perfResults(tests))
+}
@@ -25,6 +25,7 @@ func init() {
 		evergreen.AttachResultsCommandName:      attachResultsFactory,
 		evergreen.AttachXUnitResultsCommandName: xunitResultsFactory,
 		evergreen.AttachArtifactsCommandName:    attachArtifactsFactory,
+		"attach.provenance":                     attachProvenanceFactory,
 		evergreen.HostCreateCommandName:         createHostFactory,
 		"ec2.assume_role":                       ec2AssumeRoleFactory,
 		"host.list":                             listHostFactory,
@@ -38,6 +39,8 @@ func init() {
 		"git.push":                              gitPushFactory,
 		"gotest.parse_files":                    goTestFactory,
 		"gotest.parse_json":                     goTest2JSONFactory,
+		"http.get":                              httpGetFactory,
+		"http.put":                              httpPutFactory,
 		"keyval.inc":                            keyValIncFactory,
 		"mac.sign":                              macSignFactory,
 		evergreen.ManifestLoadCommandName:       manifestLoadFactory,
@@ -140,6 +143,10 @@ func (r *commandRegistry) renderCommands(commandInfo model.PluginCommandConf,
 	)
 	catcher := grip.NewBasicCatcher()
 
+	if len(commandInfo.Parallel) > 0 {
+		return nil, errors.New("cannot render a parallel block as a single command list; render each of its commands instead")
+	}
+
 	if name := commandInfo.Function; name != "" {
 		cmds, ok := project.Functions[name]
 		if !ok {
@@ -150,6 +157,10 @@ func (r *commandRegistry) renderCommands(commandInfo model.PluginCommandConf,
 					catcher.Errorf("cannot reference a function ('%s') within another function ('%s')", c.Function, name)
 					continue
 				}
+				if len(c.Parallel) > 0 {
+					catcher.Errorf("cannot define a parallel block within a function ('%s')", name)
+					continue
+				}
 
 				// if no command specific type, use the function's command type
 				if c.Type == "" {
@@ -188,6 +199,7 @@ func (r *commandRegistry) renderCommands(commandInfo model.PluginCommandConf,
 		cmd.SetType(c.GetType(project))
 		cmd.SetDisplayName(c.DisplayName)
 		cmd.SetIdleTimeout(time.Duration(c.TimeoutSecs) * time.Second)
+		cmd.SetCondition(c.If)
 
 		out = append(out, cmd)
 	}
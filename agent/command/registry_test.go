@@ -98,4 +98,68 @@ func TestRenderCommands(t *testing.T) {
 		require.Len(t, cmds, 1)
 		assert.Equal(t, evergreen.CommandTypeSystem, cmds[0].Type())
 	})
+	t.Run("CommandHasCondition", func(t *testing.T) {
+		info := model.PluginCommandConf{
+			Command: "command.mock",
+			If:      "requester == patch",
+		}
+		project := model.Project{}
+
+		cmds, err := registry.renderCommands(info, &project)
+		assert.NoError(t, err)
+		require.Len(t, cmds, 1)
+		assert.Equal(t, "requester == patch", cmds[0].Condition())
+	})
+
+	t.Run("FunctionConditionIsNotCopiedToItsCommands", func(t *testing.T) {
+		info := model.PluginCommandConf{
+			Function: "func",
+			If:       "is_patch",
+		}
+		project := model.Project{
+			Functions: map[string]*model.YAMLCommandSet{
+				"func": {
+					MultiCommand: []model.PluginCommandConf{
+						{Command: "command.mock"},
+						{Command: "command.mock", If: "!skip_lint"},
+					},
+				},
+			},
+		}
+
+		cmds, err := registry.renderCommands(info, &project)
+		assert.NoError(t, err)
+		require.Len(t, cmds, 2)
+		assert.Empty(t, cmds[0].Condition(), "function condition should be evaluated once when the function is invoked")
+		assert.Equal(t, "!skip_lint", cmds[1].Condition())
+	})
+
+	t.Run("FailsWithParallelBlock", func(t *testing.T) {
+		info := model.PluginCommandConf{
+			Parallel: []model.PluginCommandConf{
+				{Command: "command.mock"},
+				{Command: "command.mock"},
+			},
+		}
+		project := model.Project{}
+
+		_, err := registry.renderCommands(info, &project)
+		assert.Error(t, err)
+	})
+
+	t.Run("FailsWithParallelBlockInFunction", func(t *testing.T) {
+		info := model.PluginCommandConf{Function: "func"}
+		project := model.Project{
+			Functions: map[string]*model.YAMLCommandSet{
+				"func": {
+					SingleCommand: &model.PluginCommandConf{
+						Parallel: []model.PluginCommandConf{{Command: "command.mock"}},
+					},
+				},
+			},
+		}
+
+		_, err := registry.renderCommands(info, &project)
+		assert.Error(t, err)
+	})
 }
@@ -29,8 +29,19 @@ func sendTestResults(ctx context.Context, comm client.Communicator, logger clien
 	logger.Task().Info("Attaching test results...")
 	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
 
-	if err := sendTestResultsToCedar(ctx, conf, td, comm, results); err != nil {
-		return errors.Wrap(err, "sending test results to Cedar")
+	service, err := getTestResultsService(ctx, comm)
+	if err != nil {
+		return err
+	}
+	switch service {
+	case testresult.TestResultsServiceCedar:
+		if err := sendTestResultsToCedar(ctx, conf, td, comm, results); err != nil {
+			return errors.Wrap(err, "sending test results to Cedar")
+		}
+	default:
+		if err := comm.SendTestResults(ctx, td, results); err != nil {
+			return errors.Wrap(err, "sending test results to Evergreen")
+		}
 	}
 
 	logger.Task().Info("Successfully attached results.")
@@ -40,7 +51,31 @@ func sendTestResults(ctx context.Context, comm client.Communicator, logger clien
 
 // sendTestLog sends test logs to the backend logging service.
 func sendTestLog(ctx context.Context, comm client.Communicator, conf *internal.TaskConfig, log *model.TestLog) error {
-	return errors.Wrap(sendTestLogToCedar(ctx, conf.Task, comm, log), "sending test logs to Cedar")
+	service, err := getTestResultsService(ctx, comm)
+	if err != nil {
+		return err
+	}
+	if service == testresult.TestResultsServiceCedar {
+		return errors.Wrap(sendTestLogToCedar(ctx, conf.Task, comm, log), "sending test logs to Cedar")
+	}
+
+	td := client.TaskData{ID: conf.Task.Id, Secret: conf.Task.Secret}
+	_, err = comm.SendTestLog(ctx, td, log)
+	return errors.Wrap(err, "sending test logs to Evergreen")
+}
+
+// getTestResultsService returns the test results service that the task
+// should send its test results and test logs to.
+func getTestResultsService(ctx context.Context, comm client.Communicator) (string, error) {
+	config, err := comm.GetTestResultsConfig(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting test results config")
+	}
+	if config.Service == "" {
+		return testresult.TestResultsServiceLocal, nil
+	}
+
+	return config.Service, nil
 }
 
 // sendTestLogsAndResults sends the test logs and test results to backend
@@ -183,6 +183,37 @@ func TestSendTestResults(t *testing.T) {
 			})
 		}
 	})
+	t.Run("ToEvergreen", func(t *testing.T) {
+		comm.TestResultsService = testresult.TestResultsServiceLocal
+		defer func() {
+			comm.TestResultsService = testresult.TestResultsServiceCedar
+		}()
+
+		t.Run("PassingResults", func(t *testing.T) {
+			comm.LocalTestResults = nil
+			comm.ResultsService = ""
+			comm.ResultsFailed = false
+			require.NoError(t, sendTestResults(ctx, comm, logger, conf, results))
+
+			assert.Equal(t, results, comm.LocalTestResults)
+			assert.Equal(t, testresult.TestResultsServiceLocal, comm.ResultsService)
+			assert.False(t, comm.ResultsFailed)
+		})
+		t.Run("FailingResults", func(t *testing.T) {
+			comm.LocalTestResults = nil
+			comm.ResultsService = ""
+			comm.ResultsFailed = false
+			results[0].Status = evergreen.TestFailedStatus
+			defer func() {
+				results[0].Status = "pass"
+			}()
+			require.NoError(t, sendTestResults(ctx, comm, logger, conf, results))
+
+			assert.Equal(t, results, comm.LocalTestResults)
+			assert.Equal(t, testresult.TestResultsServiceLocal, comm.ResultsService)
+			assert.True(t, comm.ResultsFailed)
+		})
+	})
 }
 
 func TestSendTestLog(t *testing.T) {
@@ -268,6 +299,31 @@ func TestSendTestLog(t *testing.T) {
 	})
 }
 
+func TestSendTestLogToEvergreen(t *testing.T) {
+	ctx := context.TODO()
+	conf := &internal.TaskConfig{
+		Task: &task.Task{
+			Id:        "id",
+			Secret:    "secret",
+			Execution: 5,
+		},
+		ProjectRef: &model.ProjectRef{},
+	}
+	log := &model.TestLog{
+		Name:          "test",
+		Task:          conf.Task.Id,
+		TaskExecution: conf.Task.Execution,
+		Lines:         []string{"log line 1", "log line 2"},
+	}
+	comm := client.NewMock("url")
+	comm.TestResultsService = testresult.TestResultsServiceLocal
+
+	require.NoError(t, sendTestLog(ctx, comm, conf, log))
+	require.Len(t, comm.TestLogs, 1)
+	assert.Equal(t, log.Name, comm.TestLogs[0].Name)
+	assert.Equal(t, log.Lines, comm.TestLogs[0].Lines)
+}
+
 func setupCedarServer(ctx context.Context, t *testing.T, comm *client.Mock) *timberutil.MockCedarServer {
 	srv, err := timberutil.NewMockCedarServer(ctx, serviceutil.NextPort())
 	require.NoError(t, err)
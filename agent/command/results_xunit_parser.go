@@ -57,6 +57,8 @@ type testCase struct {
 	Name      string          `xml:"name,attr"`
 	Time      customFloat     `xml:"time,attr"`
 	ClassName string          `xml:"classname,attr"`
+	File      string          `xml:"file,attr"`
+	Line      int             `xml:"line,attr"`
 	Failure   *failureDetails `xml:"failure"`
 	Error     *failureDetails `xml:"error"`
 	SysOut    string          `xml:"system-out"`
@@ -105,6 +107,8 @@ func (tc testCase) toModelTestResultAndLog(conf *internal.TaskConfig) (testresul
 	}
 	// Replace spaces, dashes, etc. with underscores.
 	res.TestName = util.CleanForPath(res.TestName)
+	res.FilePath = tc.File
+	res.FileLine = tc.Line
 
 	res.TestStartTime = time.Now()
 	res.TestEndTime = res.TestStartTime.Add(time.Duration(float64(tc.Time) * float64(time.Second)))
@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/testutil"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -210,6 +211,25 @@ func TestXMLParsing(t *testing.T) {
 	})
 }
 
+func TestXMLToModelConversionWithFileLocation(t *testing.T) {
+	file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "xunit", "pytest.xml"))
+	require.NoError(t, err)
+	defer file.Close()
+	res, err := parseXMLResults(file)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Len(t, res[0].TestCases, 2)
+
+	conf := &internal.TaskConfig{
+		ProjectRef: &model.ProjectRef{},
+		Task:       &task.Task{Id: "TEST", Execution: 0},
+	}
+	test, _ := res[0].TestCases[1].toModelTestResultAndLog(conf)
+	assert.Equal(t, evergreen.TestFailedStatus, test.Status)
+	assert.Equal(t, "tests/test_math.py", test.FilePath)
+	assert.Equal(t, 12, test.FileLine)
+}
+
 func TestXMLToModelConversion(t *testing.T) {
 	Convey("With a parsed XML file and a task", t, func() {
 		file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "xunit", "junit_3.xml"))
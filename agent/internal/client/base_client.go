@@ -19,6 +19,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/juniper/gopb"
@@ -685,6 +686,26 @@ func (c *baseCommunicator) GetDataPipesConfig(ctx context.Context) (*apimodels.D
 	return config, nil
 }
 
+// GetTestResultsConfig returns the test results configuration.
+func (c *baseCommunicator) GetTestResultsConfig(ctx context.Context) (*apimodels.TestResultsConfig, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   "agent/test_results_config",
+	}
+
+	resp, err := c.retryRequest(ctx, info, nil)
+	if err != nil {
+		return nil, util.RespErrorf(resp, errors.Wrap(err, "getting the test results config").Error())
+	}
+
+	config := &apimodels.TestResultsConfig{}
+	if err := utility.ReadJSON(resp.Body, config); err != nil {
+		return nil, errors.Wrap(err, "reading the test results config from response")
+	}
+
+	return config, nil
+}
+
 // GetPatchFiles is used by the git.get_project plugin and fetches
 // patches from the database, used in patch builds.
 func (c *baseCommunicator) GetPatchFile(ctx context.Context, taskData TaskData, patchFileID string) (string, error) {
@@ -814,6 +835,70 @@ func (c *baseCommunicator) AttachFiles(ctx context.Context, taskData TaskData, t
 	return nil
 }
 
+// CreateProvenance has the app server create and sign a provenance
+// attestation for the artifacts produced by the task.
+func (c *baseCommunicator) CreateProvenance(ctx context.Context, taskData TaskData, subjects []artifact.ProvenanceSubject) (*artifact.ProvenanceEnvelope, error) {
+	info := requestInfo{
+		method:   http.MethodPost,
+		taskData: &taskData,
+	}
+	info.setTaskPathSuffix("provenance")
+	resp, err := c.retryRequest(ctx, info, subjects)
+	if err != nil {
+		return nil, util.RespErrorf(resp, errors.Wrap(err, "creating provenance").Error())
+	}
+	defer resp.Body.Close()
+
+	envelope := &artifact.ProvenanceEnvelope{}
+	if err = utility.ReadJSON(resp.Body, envelope); err != nil {
+		return nil, errors.Wrap(err, "reading provenance envelope from response")
+	}
+
+	return envelope, nil
+}
+
+// SendPerfResults sends the task's performance results to the app server to
+// add to the tests' performance series.
+func (c *baseCommunicator) SendPerfResults(ctx context.Context, taskData TaskData, results []apimodels.PerfResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	info := requestInfo{
+		method:   http.MethodPost,
+		taskData: &taskData,
+	}
+	info.setTaskPathSuffix("perf_results")
+	resp, err := c.retryRequest(ctx, info, results)
+	if err != nil {
+		return util.RespErrorf(resp, errors.Wrap(err, "sending perf results").Error())
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// SendTestResults sends the task's test results to the local test results
+// service.
+func (c *baseCommunicator) SendTestResults(ctx context.Context, taskData TaskData, results []testresult.TestResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	info := requestInfo{
+		method:   http.MethodPost,
+		taskData: &taskData,
+	}
+	info.setTaskPathSuffix("test_results")
+	resp, err := c.retryRequest(ctx, info, results)
+	if err != nil {
+		return util.RespErrorf(resp, errors.Wrap(err, "sending test results").Error())
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 func (c *baseCommunicator) SetDownstreamParams(ctx context.Context, downstreamParams []patchmodel.Parameter, taskData TaskData) error {
 	info := requestInfo{
 		method:   http.MethodPost,
@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	patchmodel "github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/mongodb/grip"
 	"google.golang.org/grpc"
@@ -77,6 +78,8 @@ type SharedCommunicator interface {
 	SetResultsInfo(context.Context, TaskData, string, bool) error
 	// GetDataPipesConfig returns the Data-Pipes service configuration.
 	GetDataPipesConfig(context.Context) (*apimodels.DataPipesConfig, error)
+	// GetTestResultsConfig returns the test results configuration.
+	GetTestResultsConfig(context.Context) (*apimodels.TestResultsConfig, error)
 
 	// GetPullRequestInfo takes in a PR number, owner, and repo and returns information from the corresponding pull request.
 	GetPullRequestInfo(context.Context, TaskData, int, string, string) (*apimodels.PullRequestInfo, error)
@@ -93,12 +96,20 @@ type SharedCommunicator interface {
 
 	// The following operations are used by task commands.
 	SendTestLog(context.Context, TaskData, *model.TestLog) (string, error)
+	// SendTestResults sends test results to the local test results service.
+	SendTestResults(context.Context, TaskData, []testresult.TestResult) error
 	GetTaskPatch(context.Context, TaskData, string) (*patchmodel.Patch, error)
 	GetPatchFile(context.Context, TaskData, string) (string, error)
 
 	NewPush(context.Context, TaskData, *apimodels.S3CopyRequest) (*model.PushLog, error)
 	UpdatePushStatus(context.Context, TaskData, *model.PushLog) error
 	AttachFiles(context.Context, TaskData, []*artifact.File) error
+	// CreateProvenance creates a signed provenance attestation for the
+	// task's artifacts.
+	CreateProvenance(context.Context, TaskData, []artifact.ProvenanceSubject) (*artifact.ProvenanceEnvelope, error)
+	// SendPerfResults sends performance results to add to the tests'
+	// performance series.
+	SendPerfResults(context.Context, TaskData, []apimodels.PerfResult) error
 	GetManifest(context.Context, TaskData) (*manifest.Manifest, error)
 	KeyValInc(context.Context, TaskData, *model.KeyVal) error
 
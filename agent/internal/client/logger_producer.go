@@ -2,10 +2,13 @@ package client
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/message"
 	"github.com/mongodb/grip/send"
 	"github.com/pkg/errors"
 )
@@ -134,3 +137,84 @@ func (l *singleChannelLogHarness) Closed() bool {
 	defer l.mu.RUnlock()
 	return l.closed
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// Prefixed LoggerProducer
+
+// prefixedLogHarness is a LoggerProducer that prefixes every line it logs
+// and sends it to another LoggerProducer, so that the logs of commands
+// running concurrently can be told apart.
+type prefixedLogHarness struct {
+	parent    LoggerProducer
+	execution grip.Journaler
+	task      grip.Journaler
+	system    grip.Journaler
+	mu        sync.RWMutex
+	closed    bool
+}
+
+// NewPrefixedLoggerProducer returns a LoggerProducer that prefixes each line
+// of every message with "[prefix] " before sending it to the parent. Closing
+// it does not close the parent.
+func NewPrefixedLoggerProducer(parent LoggerProducer, prefix string) LoggerProducer {
+	return &prefixedLogHarness{
+		parent:    parent,
+		execution: logging.MakeGrip(newPrefixSender(parent.Execution().GetSender(), prefix)),
+		task:      logging.MakeGrip(newPrefixSender(parent.Task().GetSender(), prefix)),
+		system:    logging.MakeGrip(newPrefixSender(parent.System().GetSender(), prefix)),
+	}
+}
+
+func (l *prefixedLogHarness) Execution() grip.Journaler { return l.execution }
+func (l *prefixedLogHarness) Task() grip.Journaler      { return l.task }
+func (l *prefixedLogHarness) System() grip.Journaler    { return l.system }
+
+func (l *prefixedLogHarness) Flush(ctx context.Context) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.closed {
+		return nil
+	}
+
+	return l.parent.Flush(ctx)
+}
+
+func (l *prefixedLogHarness) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closed = true
+	return nil
+}
+
+func (l *prefixedLogHarness) Closed() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.closed || l.parent.Closed()
+}
+
+type prefixSender struct {
+	send.Sender
+	prefix string
+}
+
+func newPrefixSender(sender send.Sender, prefix string) send.Sender {
+	return &prefixSender{
+		Sender: sender,
+		prefix: fmt.Sprintf("[%s] ", prefix),
+	}
+}
+
+func (s *prefixSender) Send(m message.Composer) {
+	if !s.Level().ShouldLog(m) {
+		return
+	}
+	msg := s.prefix + strings.ReplaceAll(m.String(), "\n", "\n"+s.prefix)
+	s.Sender.Send(message.NewDefaultMessage(m.Priority(), msg))
+}
+
+// Close is a no-op because the underlying sender belongs to the parent
+// LoggerProducer.
+func (s *prefixSender) Close() error { return nil }
@@ -56,13 +56,16 @@ type Mock struct {
 
 	CedarGRPCConn *grpc.ClientConn
 
-	AttachedFiles    map[string][]*artifact.File
-	LogID            string
-	LocalTestResults []testresult.TestResult
-	ResultsService   string
-	ResultsFailed    bool
-	TestLogs         []*serviceModel.TestLog
-	TestLogCount     int
+	AttachedFiles      map[string][]*artifact.File
+	ProvenanceSubjects map[string][]artifact.ProvenanceSubject
+	PerfResults        map[string][]apimodels.PerfResult
+	LogID              string
+	LocalTestResults   []testresult.TestResult
+	TestResultsService string
+	ResultsService     string
+	ResultsFailed      bool
+	TestLogs           []*serviceModel.TestLog
+	TestLogCount       int
 
 	// data collected by mocked methods
 	logMessages      map[string][]apimodels.LogMessage
@@ -83,14 +86,17 @@ type endTaskResult struct {
 // NewMock returns a Communicator for testing.
 func NewMock(serverURL string) *Mock {
 	return &Mock{
-		maxAttempts:   defaultMaxAttempts,
-		timeoutStart:  defaultTimeoutStart,
-		timeoutMax:    defaultTimeoutMax,
-		logMessages:   make(map[string][]apimodels.LogMessage),
-		PatchFiles:    make(map[string]string),
-		keyVal:        make(map[string]*serviceModel.KeyVal),
-		AttachedFiles: make(map[string][]*artifact.File),
-		serverURL:     serverURL,
+		maxAttempts:        defaultMaxAttempts,
+		timeoutStart:       defaultTimeoutStart,
+		timeoutMax:         defaultTimeoutMax,
+		logMessages:        make(map[string][]apimodels.LogMessage),
+		PatchFiles:         make(map[string]string),
+		keyVal:             make(map[string]*serviceModel.KeyVal),
+		AttachedFiles:      make(map[string][]*artifact.File),
+		ProvenanceSubjects: make(map[string][]artifact.ProvenanceSubject),
+		PerfResults:        make(map[string][]apimodels.PerfResult),
+		TestResultsService: testresult.TestResultsServiceCedar,
+		serverURL:          serverURL,
 	}
 }
 
@@ -418,6 +424,59 @@ func (c *Mock) AttachFiles(ctx context.Context, td TaskData, taskFiles []*artifa
 	return nil
 }
 
+// GetTestResultsConfig returns a mock test results configuration.
+func (c *Mock) GetTestResultsConfig(ctx context.Context) (*apimodels.TestResultsConfig, error) {
+	return &apimodels.TestResultsConfig{Service: c.TestResultsService}, nil
+}
+
+// SendTestResults records the test results and sets the results info as the
+// local test results service would.
+func (c *Mock) SendTestResults(ctx context.Context, td TaskData, results []testresult.TestResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.LocalTestResults = append(c.LocalTestResults, results...)
+	c.ResultsService = testresult.TestResultsServiceLocal
+	for _, result := range results {
+		if result.Status == evergreen.TestFailedStatus {
+			c.ResultsFailed = true
+		}
+	}
+
+	return nil
+}
+
+// SendPerfResults records the performance results.
+func (c *Mock) SendPerfResults(ctx context.Context, td TaskData, results []apimodels.PerfResult) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.PerfResults[td.ID] = append(c.PerfResults[td.ID], results...)
+
+	return nil
+}
+
+// CreateProvenance records the subjects and returns an unsigned envelope.
+func (c *Mock) CreateProvenance(ctx context.Context, td TaskData, subjects []artifact.ProvenanceSubject) (*artifact.ProvenanceEnvelope, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ProvenanceSubjects[td.ID] = append(c.ProvenanceSubjects[td.ID], subjects...)
+	payload, err := json.Marshal(artifact.ProvenanceStatement{
+		Type:          artifact.InTotoStatementType,
+		Subject:       subjects,
+		PredicateType: artifact.SLSAProvenancePredicateType,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling provenance statement")
+	}
+
+	return &artifact.ProvenanceEnvelope{
+		PayloadType: artifact.InTotoPayloadType,
+		Payload:     payload,
+	}, nil
+}
+
 func (c *Mock) SetDownstreamParams(ctx context.Context, downstreamParams []patchmodel.Parameter, taskData TaskData) error {
 	c.DownstreamParams = downstreamParams
 	return nil
@@ -32,6 +32,18 @@ type TaskConfig struct {
 	ModulePaths        map[string]string
 	CedarTestResultsID string
 
+	// resourceLimitOOMCommands are the commands that were OOM killed for
+	// exceeding their own memory limit.
+	resourceLimitOOMCommands []string
+
+	// parent is the task config that this config was copied from for a
+	// command in a parallel block, if any. branchExpansions and
+	// branchModulePaths are the expansions and module paths as they were
+	// when the config was copied.
+	parent            *TaskConfig
+	branchExpansions  map[string]string
+	branchModulePaths map[string]string
+
 	mu sync.RWMutex
 }
 
@@ -41,29 +53,122 @@ type Timeout struct {
 }
 
 func (t *TaskConfig) SetIdleTimeout(timeout int) {
+	t = t.root()
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.Timeout.IdleTimeoutSecs = timeout
 }
 
 func (t *TaskConfig) SetExecTimeout(timeout int) {
+	t = t.root()
 	t.mu.Lock()
 	defer t.mu.Unlock()
 	t.Timeout.ExecTimeoutSecs = timeout
 }
 
 func (t *TaskConfig) GetIdleTimeout() int {
+	t = t.root()
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 	return t.Timeout.IdleTimeoutSecs
 }
 
 func (t *TaskConfig) GetExecTimeout() int {
+	t = t.root()
 	t.mu.RLock()
 	defer t.mu.RUnlock()
 	return t.Timeout.ExecTimeoutSecs
 }
 
+// AddResourceLimitOOM records that the command was OOM killed for exceeding
+// its own memory limit.
+func (t *TaskConfig) AddResourceLimitOOM(command string) {
+	t = t.root()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resourceLimitOOMCommands = append(t.resourceLimitOOMCommands, command)
+}
+
+// GetResourceLimitOOMInfo returns the commands that were OOM killed for
+// exceeding their own memory limit, or nil if there were none.
+func (t *TaskConfig) GetResourceLimitOOMInfo() *apimodels.ResourceLimitOOMInfo {
+	t = t.root()
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.resourceLimitOOMCommands) == 0 {
+		return nil
+	}
+	return &apimodels.ResourceLimitOOMInfo{
+		Detected: true,
+		Commands: append([]string{}, t.resourceLimitOOMCommands...),
+	}
+}
+
+// root returns the task config that holds the state shared by all of the
+// task's commands.
+func (t *TaskConfig) root() *TaskConfig {
+	if t.parent != nil {
+		return t.parent
+	}
+	return t
+}
+
+// NewParallelBranch returns a copy of the task config for a command in a
+// parallel block. The copy has its own expansions and module paths so that
+// commands running concurrently never read and write the same maps. Timeouts
+// and OOM information are still shared with the original config.
+func (t *TaskConfig) NewParallelBranch() *TaskConfig {
+	return &TaskConfig{
+		Distro:             t.Distro,
+		ProjectRef:         t.ProjectRef,
+		Project:            t.Project,
+		Task:               t.Task,
+		BuildVariant:       t.BuildVariant,
+		Expansions:         util.NewExpansions(t.Expansions.Map()),
+		Redacted:           t.Redacted,
+		WorkDir:            t.WorkDir,
+		GithubPatchData:    t.GithubPatchData,
+		Timeout:            t.Timeout,
+		TaskSync:           t.TaskSync,
+		EC2Keys:            t.EC2Keys,
+		ModulePaths:        copyModulePaths(t.ModulePaths),
+		CedarTestResultsID: t.CedarTestResultsID,
+		parent:             t.root(),
+		branchExpansions:   util.NewExpansions(t.Expansions.Map()).Map(),
+		branchModulePaths:  copyModulePaths(t.ModulePaths),
+	}
+}
+
+func copyModulePaths(modulePaths map[string]string) map[string]string {
+	out := make(map[string]string, len(modulePaths))
+	for module, path := range modulePaths {
+		out[module] = path
+	}
+	return out
+}
+
+// MergeParallelBranch merges the expansions and module paths that a parallel
+// block command added or changed in its copy of the task config back into this
+// config.
+func (t *TaskConfig) MergeParallelBranch(branch *TaskConfig) {
+	for key, val := range branch.Expansions.Map() {
+		if origVal, ok := branch.branchExpansions[key]; !ok || origVal != val {
+			t.Expansions.Put(key, val)
+		}
+	}
+	for module, path := range branch.ModulePaths {
+		if origPath, ok := branch.branchModulePaths[module]; !ok || origPath != path {
+			if t.ModulePaths == nil {
+				t.ModulePaths = map[string]string{}
+			}
+			t.ModulePaths[module] = path
+		}
+	}
+	if t.CedarTestResultsID == "" {
+		t.CedarTestResultsID = branch.CedarTestResultsID
+	}
+}
+
 func NewTaskConfig(workDir string, d *apimodels.DistroView, p *model.Project, t *task.Task, r *model.ProjectRef, patchDoc *patch.Patch, e util.Expansions) (*TaskConfig, error) {
 	// do a check on if the project is empty
 	if p == nil {
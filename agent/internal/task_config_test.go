@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -96,3 +97,38 @@ task_groups:
 	assert.Equal(t, 2, tg.MaxHosts)
 	assert.NotEmpty(t, tg.Timeout) // Defaults to project-level timeout if not defined.
 }
+
+func TestTaskConfigParallelBranch(t *testing.T) {
+	conf := &TaskConfig{
+		Timeout:     &Timeout{},
+		Expansions:  util.NewExpansions(map[string]string{"shared": "orig", "unchanged": "val"}),
+		ModulePaths: map[string]string{"shared_module": "orig", "unchanged_module": "val"},
+	}
+	first := conf.NewParallelBranch()
+	second := conf.NewParallelBranch()
+
+	first.Expansions.Put("shared", "first")
+	first.Expansions.Put("first_only", "first")
+	second.Expansions.Put("shared", "second")
+	assert.Equal(t, "orig", conf.Expansions.Get("shared"), "branches should not change the original expansions")
+	assert.Equal(t, "first", first.Expansions.Get("shared"))
+	assert.Equal(t, "second", second.Expansions.Get("shared"))
+
+	first.ModulePaths["shared_module"] = "first"
+	first.ModulePaths["first_only_module"] = "first"
+	second.ModulePaths["shared_module"] = "second"
+	assert.Equal(t, "orig", conf.ModulePaths["shared_module"], "branches should not change the original module paths")
+
+	second.SetIdleTimeout(10)
+	assert.Equal(t, 10, conf.GetIdleTimeout(), "timeouts should be shared with the original config")
+	assert.Equal(t, 10, first.GetIdleTimeout())
+
+	conf.MergeParallelBranch(first)
+	conf.MergeParallelBranch(second)
+	assert.Equal(t, "second", conf.Expansions.Get("shared"))
+	assert.Equal(t, "first", conf.Expansions.Get("first_only"))
+	assert.Equal(t, "val", conf.Expansions.Get("unchanged"))
+	assert.Equal(t, "second", conf.ModulePaths["shared_module"])
+	assert.Equal(t, "first", conf.ModulePaths["first_only_module"])
+	assert.Equal(t, "val", conf.ModulePaths["unchanged_module"])
+}
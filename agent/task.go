@@ -246,20 +246,32 @@ func (tc *taskContext) getCurrentCommand() command.Command {
 	return tc.currentCommand
 }
 
-func (tc *taskContext) setCurrentIdleTimeout(cmd command.Command) {
+// setParallelBranches sets the branches of the parallel block that is
+// currently running, or clears them if branches is nil.
+func (tc *taskContext) setParallelBranches(branches []*parallelBranch) {
 	tc.Lock()
 	defer tc.Unlock()
+	tc.parallelBranches = branches
+}
 
-	var timeout time.Duration
+// getCommandIdleTimeout returns the idle timeout that applies while the
+// command runs.
+func (tc *taskContext) getCommandIdleTimeout(cmd command.Command) time.Duration {
 	if cmd == nil {
-		timeout = defaultIdleTimeout
+		return defaultIdleTimeout
 	} else if dynamicTimeout := tc.taskConfig.GetIdleTimeout(); dynamicTimeout != 0 {
-		timeout = time.Duration(dynamicTimeout) * time.Second
+		return time.Duration(dynamicTimeout) * time.Second
 	} else if cmd.IdleTimeout() > 0 {
-		timeout = cmd.IdleTimeout()
-	} else {
-		timeout = defaultIdleTimeout
+		return cmd.IdleTimeout()
 	}
+	return defaultIdleTimeout
+}
+
+func (tc *taskContext) setCurrentIdleTimeout(cmd command.Command) {
+	timeout := tc.getCommandIdleTimeout(cmd)
+
+	tc.Lock()
+	defer tc.Unlock()
 
 	tc.setIdleTimeout(timeout)
 	if tc.currentCommand != nil {
@@ -274,6 +286,22 @@ func (tc *taskContext) getCurrentTimeout() time.Duration {
 	tc.RLock()
 	defer tc.RUnlock()
 
+	// While a parallel block is running, output from any of its commands
+	// counts as activity, so the block is idle only once the longest idle
+	// timeout of its running commands has passed.
+	if len(tc.parallelBranches) > 0 {
+		var timeout time.Duration
+		for _, branch := range tc.parallelBranches {
+			if branchTimeout := branch.getIdleTimeout(); branchTimeout > timeout {
+				timeout = branchTimeout
+			}
+		}
+		if timeout > 0 {
+			return timeout
+		}
+		return defaultIdleTimeout
+	}
+
 	timeout := tc.getIdleTimeout()
 	if timeout > 0 {
 		return timeout
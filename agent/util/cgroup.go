@@ -0,0 +1,21 @@
+package util
+
+// ResourceLimits are limits on the resources that a process and all of its
+// descendants can use together. A zero value for a limit means that the
+// resource is not limited.
+type ResourceLimits struct {
+	// CPUs is the number of CPUs' worth of time the processes can use. It
+	// may be fractional.
+	CPUs float64
+	// MemoryMB is the maximum amount of memory in megabytes the processes
+	// can use before they are OOM killed.
+	MemoryMB int
+	// MaxProcesses is the maximum number of processes and threads that can
+	// exist at once.
+	MaxProcesses int
+}
+
+// IsZero returns whether no resources are limited.
+func (l ResourceLimits) IsZero() bool {
+	return l.CPUs == 0 && l.MemoryMB == 0 && l.MaxProcesses == 0
+}
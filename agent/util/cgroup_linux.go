@@ -0,0 +1,141 @@
+//go:build linux
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	cgroupRoot = "/sys/fs/cgroup"
+	// cgroupParent is the cgroup under which the agent creates a cgroup for
+	// each resource-limited process.
+	cgroupParent = "evergreen"
+	// cpuPeriodMicros is the period over which the CPU quota applies.
+	cpuPeriodMicros = 100000
+)
+
+var cgroupControllers = []string{"cpu", "memory", "pids"}
+
+// Cgroup is a cgroup v2 control group that enforces resource limits on the
+// processes in it.
+type Cgroup struct {
+	path string
+}
+
+// NewCgroup creates a cgroup with the given name that enforces the resource
+// limits. It requires the host to use the unified cgroup v2 hierarchy and the
+// agent to have permission to create cgroups in it.
+func NewCgroup(name string, limits ResourceLimits) (*Cgroup, error) {
+	if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+		return nil, errors.Wrap(err, "host does not use the cgroup v2 unified hierarchy")
+	}
+
+	parent := filepath.Join(cgroupRoot, cgroupParent)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, errors.Wrapf(err, "creating parent cgroup '%s'", parent)
+	}
+	for _, dir := range []string{cgroupRoot, parent} {
+		if err := enableCgroupControllers(dir); err != nil {
+			return nil, errors.Wrapf(err, "enabling controllers for cgroup '%s'", dir)
+		}
+	}
+
+	path := filepath.Join(parent, name)
+	if err := os.Mkdir(path, 0755); err != nil {
+		return nil, errors.Wrapf(err, "creating cgroup '%s'", path)
+	}
+	cg := &Cgroup{path: path}
+
+	catcher := grip.NewBasicCatcher()
+	if limits.CPUs > 0 {
+		quota := int(limits.CPUs * cpuPeriodMicros)
+		catcher.Wrap(cg.write("cpu.max", fmt.Sprintf("%d %d", quota, cpuPeriodMicros)), "setting CPU limit")
+	}
+	if limits.MemoryMB > 0 {
+		catcher.Wrap(cg.write("memory.max", strconv.Itoa(limits.MemoryMB*1024*1024)), "setting memory limit")
+		// Swap is not always enabled, in which case there's nothing to limit.
+		grip.Debug(errors.Wrap(cg.write("memory.swap.max", "0"), "disabling swap"))
+	}
+	if limits.MaxProcesses > 0 {
+		catcher.Wrap(cg.write("pids.max", strconv.Itoa(limits.MaxProcesses)), "setting process limit")
+	}
+	if catcher.HasErrors() {
+		catcher.Wrap(cg.Close(), "cleaning up cgroup")
+		return nil, catcher.Resolve()
+	}
+
+	return cg, nil
+}
+
+// enableCgroupControllers enables the controllers needed to enforce resource
+// limits for the children of the cgroup.
+func enableCgroupControllers(dir string) error {
+	enabled, err := os.ReadFile(filepath.Join(dir, "cgroup.subtree_control"))
+	if err != nil {
+		return errors.Wrap(err, "reading enabled controllers")
+	}
+	for _, controller := range cgroupControllers {
+		if strings.Contains(" "+strings.TrimSpace(string(enabled))+" ", " "+controller+" ") {
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, "cgroup.subtree_control"), []byte("+"+controller), 0644); err != nil {
+			return errors.Wrapf(err, "enabling controller '%s'", controller)
+		}
+	}
+	return nil
+}
+
+func (c *Cgroup) write(file, value string) error {
+	return errors.WithStack(os.WriteFile(filepath.Join(c.path, file), []byte(value), 0644))
+}
+
+// WrapCommand returns a command that runs the given command inside the
+// cgroup. The command is started by a shell that moves itself into the cgroup
+// and then execs the command, so the command and every process it starts are
+// limited from the moment they start running. The binary must be a path
+// rather than a name to look up in the PATH.
+func (c *Cgroup) WrapCommand(args []string) []string {
+	return append([]string{"/bin/sh", "-c", `echo $$ > "$0" && exec "$@"`, filepath.Join(c.path, "cgroup.procs")}, args...)
+}
+
+// OOMKilled returns whether any process in the cgroup was killed because the
+// cgroup exceeded its memory limit.
+func (c *Cgroup) OOMKilled() (bool, error) {
+	f, err := os.Open(filepath.Join(c.path, "memory.events"))
+	if err != nil {
+		return false, errors.Wrap(err, "opening memory events")
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || fields[0] != "oom_kill" {
+			continue
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return false, errors.Wrapf(err, "parsing OOM kill count '%s'", fields[1])
+		}
+		return count > 0, nil
+	}
+	return false, errors.Wrap(scanner.Err(), "reading memory events")
+}
+
+// Close kills any processes remaining in the cgroup and removes it.
+func (c *Cgroup) Close() error {
+	// cgroup.kill is only available in newer kernels, so processes may
+	// still be left behind to be cleaned up with the rest of the task's
+	// processes.
+	grip.Debug(errors.Wrap(c.write("cgroup.kill", "1"), "killing processes in cgroup"))
+	return errors.Wrapf(os.Remove(c.path), "removing cgroup '%s'", c.path)
+}
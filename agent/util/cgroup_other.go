@@ -0,0 +1,17 @@
+//go:build !linux
+
+package util
+
+import "github.com/pkg/errors"
+
+// Cgroup is a cgroup v2 control group, which is only supported on Linux.
+type Cgroup struct{}
+
+// NewCgroup returns an error because cgroups are only supported on Linux.
+func NewCgroup(name string, limits ResourceLimits) (*Cgroup, error) {
+	return nil, errors.New("resource limits are only supported on Linux")
+}
+
+func (c *Cgroup) WrapCommand(args []string) []string { return args }
+func (c *Cgroup) OOMKilled() (bool, error)           { return false, nil }
+func (c *Cgroup) Close() error                       { return nil }
@@ -64,8 +64,11 @@ type TaskEndDetail struct {
 	TimeoutType     string          `bson:"timeout_type,omitempty" json:"timeout_type,omitempty"`
 	TimeoutDuration time.Duration   `bson:"timeout_duration,omitempty" json:"timeout_duration,omitempty"`
 	OOMTracker      *OOMTrackerInfo `bson:"oom_killer,omitempty" json:"oom_killer,omitempty"`
-	Logs            *TaskLogs       `bson:"-" json:"logs,omitempty"`
-	Modules         ModuleCloneInfo `bson:"modules,omitempty" json:"modules,omitempty"`
+	// ResourceLimitOOM records commands that were OOM killed because they
+	// exceeded their own memory limit rather than the host's memory.
+	ResourceLimitOOM *ResourceLimitOOMInfo `bson:"resource_limit_oom,omitempty" json:"resource_limit_oom,omitempty"`
+	Logs             *TaskLogs             `bson:"-" json:"logs,omitempty"`
+	Modules          ModuleCloneInfo       `bson:"modules,omitempty" json:"modules,omitempty"`
 }
 
 type OOMTrackerInfo struct {
@@ -73,6 +76,13 @@ type OOMTrackerInfo struct {
 	Pids     []int `bson:"pids" json:"pids"`
 }
 
+// ResourceLimitOOMInfo describes the commands that were OOM killed for
+// exceeding the memory limit set on the command.
+type ResourceLimitOOMInfo struct {
+	Detected bool     `bson:"detected" json:"detected"`
+	Commands []string `bson:"commands" json:"commands"`
+}
+
 type TaskLogs struct {
 	AgentLogURLs  []LogInfo `bson:"agent" json:"agent"`
 	SystemLogURLs []LogInfo `bson:"system" json:"system"`
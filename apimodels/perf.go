@@ -50,3 +50,12 @@ func CedarPerfResultsCount(ctx context.Context, opts GetCedarPerfCountOptions) (
 
 	return testCount, nil
 }
+
+// PerfResult is the value of a measurement of a performance test that a task
+// sends to the app server to add to the test's performance series.
+type PerfResult struct {
+	Test        string           `json:"test"`
+	Measurement string           `json:"measurement"`
+	Args        map[string]int32 `json:"args,omitempty"`
+	Value       float64          `json:"value"`
+}
@@ -6,3 +6,11 @@ type DisplayTaskInfo struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
+
+// TestResultsConfig represents the API model for the test results
+// configuration.
+type TestResultsConfig struct {
+	// Service is the test results service that the agent sends test results
+	// and test logs to.
+	Service string `json:"service"`
+}
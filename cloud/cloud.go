@@ -80,6 +80,19 @@ type Manager interface {
 	// GetVolumeAttachment gets a volume's attachment
 	GetVolumeAttachment(context.Context, string) (*host.VolumeAttachment, error)
 
+	// CreateVolumeSnapshot creates a snapshot of a volume.
+	CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error)
+
+	// ListVolumeSnapshots lists a user's volume snapshots in the manager's
+	// region, updating the status of snapshots that are still pending.
+	ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error)
+
+	// DeleteVolumeSnapshot deletes a volume snapshot.
+	DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error
+
+	// RestoreVolumeSnapshot creates a new volume from a volume snapshot.
+	RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error)
+
 	// CheckInstanceType determines if the given instance type is available in the current region.
 	CheckInstanceType(context.Context, string) error
 
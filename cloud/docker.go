@@ -239,6 +239,22 @@ func (m *dockerManager) GetVolumeAttachment(context.Context, string) (*host.Volu
 	return nil, errors.New("can't get volume attachment with Docker provider")
 }
 
+func (m *dockerManager) CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	return nil, errors.New("can't create volume snapshot with Docker provider")
+}
+
+func (m *dockerManager) ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error) {
+	return nil, errors.New("can't list volume snapshots with Docker provider")
+}
+
+func (m *dockerManager) DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error {
+	return errors.New("can't delete volume snapshot with Docker provider")
+}
+
+func (m *dockerManager) RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error) {
+	return nil, errors.New("can't restore volume snapshot with Docker provider")
+}
+
 func (m *dockerManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with Docker provider")
 }
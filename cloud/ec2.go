@@ -1482,6 +1482,12 @@ func (m *ec2Manager) CreateVolume(ctx context.Context, volume *host.Volume) (*ho
 	}
 	defer m.client.Close()
 
+	return m.createVolume(ctx, volume, "")
+}
+
+// createVolume creates the volume, optionally from a snapshot. The client
+// must already be created.
+func (m *ec2Manager) createVolume(ctx context.Context, volume *host.Volume, snapshotID string) (*host.Volume, error) {
 	volume.Expiration = time.Now().Add(evergreen.DefaultSpawnHostExpiration)
 	volumeTags := []*ec2.Tag{
 		{Key: aws.String(evergreen.TagOwner), Value: aws.String(volume.CreatedBy)},
@@ -1496,6 +1502,10 @@ func (m *ec2Manager) CreateVolume(ctx context.Context, volume *host.Volume) (*ho
 		},
 	}
 
+	if snapshotID != "" {
+		input.SnapshotId = aws.String(snapshotID)
+	}
+
 	if volume.Throughput > 0 {
 		input.Throughput = aws.Int64(int64(volume.Throughput))
 	}
@@ -1578,6 +1588,177 @@ func (m *ec2Manager) GetVolumeAttachment(ctx context.Context, volumeID string) (
 	return attachment, nil
 }
 
+func (m *ec2Manager) CreateVolumeSnapshot(ctx context.Context, volume *host.Volume, snapshot *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	if volume.AvailabilityZone == "" {
+		return nil, errors.Errorf("volume '%s' has no availability zone", volume.ID)
+	}
+	if err := m.client.Create(m.credentials, m.region); err != nil {
+		return nil, errors.Wrap(err, "creating client")
+	}
+	defer m.client.Close()
+
+	if utility.IsZeroTime(snapshot.Expiration) {
+		snapshot.Expiration = time.Now().Add(evergreen.DefaultVolumeSnapshotExpiration)
+	}
+	resp, err := m.client.CreateSnapshot(ctx, &ec2.CreateSnapshotInput{
+		VolumeId:    aws.String(volume.ID),
+		Description: aws.String(fmt.Sprintf("Snapshot of volume '%s' created by '%s'", volume.ID, snapshot.CreatedBy)),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+				Tags: []*ec2.Tag{
+					{Key: aws.String(evergreen.TagOwner), Value: aws.String(snapshot.CreatedBy)},
+					{Key: aws.String(evergreen.TagExpireOn), Value: aws.String(snapshot.Expiration.Add(time.Hour * 24 * evergreen.SpawnHostExpireDays).Format(evergreen.ExpireOnFormat))},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating snapshot of volume '%s' in client", volume.ID)
+	}
+	if resp.SnapshotId == nil {
+		return nil, errors.New("new snapshot returned by EC2 does not have an ID")
+	}
+
+	snapshot.ID = *resp.SnapshotId
+	snapshot.VolumeID = volume.ID
+	snapshot.Size = volume.Size
+	snapshot.VolumeType = volume.Type
+	snapshot.AvailabilityZone = volume.AvailabilityZone
+	snapshot.HomeVolume = volume.HomeVolume
+	snapshot.Status = ec2SnapshotStateToStatus(aws.StringValue(resp.State))
+	if err = snapshot.Insert(); err != nil {
+		return nil, errors.Wrapf(err, "creating snapshot '%s' in DB", snapshot.ID)
+	}
+
+	return snapshot, nil
+}
+
+func (m *ec2Manager) ListVolumeSnapshots(ctx context.Context, userID string) ([]host.VolumeSnapshot, error) {
+	allSnapshots, err := host.FindVolumeSnapshotsByUser(userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding volume snapshots for user '%s'", userID)
+	}
+	var snapshots []host.VolumeSnapshot
+	pending := map[string]int{}
+	for _, snapshot := range allSnapshots {
+		if snapshot.AvailabilityZone != "" && AztoRegion(snapshot.AvailabilityZone) != m.region {
+			continue
+		}
+		if snapshot.Status == host.VolumeSnapshotStatusPending {
+			pending[snapshot.ID] = len(snapshots)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if len(pending) == 0 {
+		return snapshots, nil
+	}
+
+	if err = m.client.Create(m.credentials, m.region); err != nil {
+		return nil, errors.Wrap(err, "creating client")
+	}
+	defer m.client.Close()
+
+	ids := make([]string, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	// Filter by ID rather than requesting the IDs directly so that a
+	// snapshot that no longer exists is left out instead of failing the
+	// whole request.
+	resp, err := m.client.DescribeSnapshots(ctx, &ec2.DescribeSnapshotsInput{
+		OwnerIds: []*string{aws.String("self")},
+		Filters: []*ec2.Filter{
+			{Name: aws.String("snapshot-id"), Values: aws.StringSlice(ids)},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing pending snapshots")
+	}
+	found := map[string]bool{}
+	for _, ec2Snapshot := range resp.Snapshots {
+		if ec2Snapshot == nil {
+			continue
+		}
+		i, ok := pending[aws.StringValue(ec2Snapshot.SnapshotId)]
+		if !ok {
+			continue
+		}
+		found[snapshots[i].ID] = true
+		status := ec2SnapshotStateToStatus(aws.StringValue(ec2Snapshot.State))
+		if status == snapshots[i].Status {
+			continue
+		}
+		if err = snapshots[i].SetStatus(status); err != nil {
+			return nil, errors.Wrapf(err, "updating status of snapshot '%s' in DB", snapshots[i].ID)
+		}
+	}
+
+	// Pending snapshots that EC2 no longer has were deleted outside of
+	// Evergreen, so clean up their records.
+	existing := make([]host.VolumeSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if _, isPending := pending[snapshot.ID]; isPending && !found[snapshot.ID] {
+			grip.Info(message.Fields{
+				"message":  "removing record of snapshot that no longer exists in EC2",
+				"snapshot": snapshot.ID,
+				"user":     userID,
+			})
+			if err = snapshot.Remove(); err != nil {
+				return nil, errors.Wrapf(err, "removing snapshot '%s' from DB", snapshot.ID)
+			}
+			continue
+		}
+		existing = append(existing, snapshot)
+	}
+
+	return existing, nil
+}
+
+func (m *ec2Manager) DeleteVolumeSnapshot(ctx context.Context, snapshot *host.VolumeSnapshot) error {
+	if err := m.client.Create(m.credentials, m.region); err != nil {
+		return errors.Wrap(err, "creating client")
+	}
+	defer m.client.Close()
+
+	_, err := m.client.DeleteSnapshot(ctx, &ec2.DeleteSnapshotInput{
+		SnapshotId: aws.String(snapshot.ID),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "deleting snapshot '%s' in client", snapshot.ID)
+	}
+
+	return errors.Wrapf(snapshot.Remove(), "deleting snapshot '%s' in DB", snapshot.ID)
+}
+
+func (m *ec2Manager) RestoreVolumeSnapshot(ctx context.Context, snapshot *host.VolumeSnapshot, volume *host.Volume) (*host.Volume, error) {
+	if snapshot.Status != host.VolumeSnapshotStatusCompleted {
+		return nil, errors.Errorf("snapshot '%s' is not completed yet", snapshot.ID)
+	}
+	if volume.AvailabilityZone == "" {
+		volume.AvailabilityZone = snapshot.AvailabilityZone
+	}
+	if volume.AvailabilityZone == "" {
+		return nil, errors.New("availability zone is required to restore a snapshot")
+	}
+	if snapshot.AvailabilityZone != "" && AztoRegion(volume.AvailabilityZone) != AztoRegion(snapshot.AvailabilityZone) {
+		return nil, errors.Errorf("snapshot '%s' can only be restored in region '%s'", snapshot.ID, AztoRegion(snapshot.AvailabilityZone))
+	}
+	if volume.Size < snapshot.Size {
+		volume.Size = snapshot.Size
+	}
+	if volume.Type == "" {
+		volume.Type = snapshot.VolumeType
+	}
+
+	if err := m.client.Create(m.credentials, m.region); err != nil {
+		return nil, errors.Wrap(err, "creating client")
+	}
+	defer m.client.Close()
+
+	return m.createVolume(ctx, volume, snapshot.ID)
+}
+
 func (m *ec2Manager) modifyVolumeExpiration(ctx context.Context, volume *host.Volume, newExpiration time.Time) error {
 	if err := volume.SetExpiration(newExpiration); err != nil {
 		return errors.Wrapf(err, "updating expiration for volume '%s'", volume.ID)
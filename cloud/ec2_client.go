@@ -95,6 +95,15 @@ type AWSClient interface {
 	// DescribeVolumes is a wrapper for ec2.DescribeVolumes.
 	DescribeVolumes(context.Context, *ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error)
 
+	// CreateSnapshot is a wrapper for ec2.CreateSnapshot.
+	CreateSnapshot(context.Context, *ec2.CreateSnapshotInput) (*ec2.Snapshot, error)
+
+	// DeleteSnapshot is a wrapper for ec2.DeleteSnapshot.
+	DeleteSnapshot(context.Context, *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error)
+
+	// DescribeSnapshots is a wrapper for ec2.DescribeSnapshots.
+	DescribeSnapshots(context.Context, *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error)
+
 	// DescribeSpotPriceHistory is a wrapper for ec2.DescribeSpotPriceHistory.
 	DescribeSpotPriceHistory(context.Context, *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error)
 
@@ -720,6 +729,86 @@ func (c *awsClientImpl) DescribeVolumes(ctx context.Context, input *ec2.Describe
 	return output, nil
 }
 
+// CreateSnapshot is a wrapper for ec2.CreateSnapshot.
+func (c *awsClientImpl) CreateSnapshot(ctx context.Context, input *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
+	var output *ec2.Snapshot
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("CreateSnapshot", fmt.Sprintf("%T", c), input)
+			output, err = c.EC2.CreateSnapshotWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					if strings.Contains(ec2err.Error(), EC2InvalidParam) || strings.Contains(ec2err.Error(), EC2VolumeNotFound) {
+						return false, err
+					}
+					grip.Debug(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// DeleteSnapshot is a wrapper for ec2.DeleteSnapshot.
+func (c *awsClientImpl) DeleteSnapshot(ctx context.Context, input *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+	var output *ec2.DeleteSnapshotOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("DeleteSnapshot", fmt.Sprintf("%T", c), input)
+			output, err = c.EC2.DeleteSnapshotWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					if strings.Contains(ec2err.Error(), EC2SnapshotNotFound) {
+						return false, nil
+					}
+					grip.Debug(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// DescribeSnapshots is a wrapper for ec2.DescribeSnapshots.
+func (c *awsClientImpl) DescribeSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	var output *ec2.DescribeSnapshotsOutput
+	var err error
+	err = utility.Retry(
+		ctx,
+		func() (bool, error) {
+			msg := makeAWSLogMessage("DescribeSnapshots", fmt.Sprintf("%T", c), input)
+			output, err = c.EC2.DescribeSnapshotsWithContext(ctx, input)
+			if err != nil {
+				if ec2err, ok := err.(awserr.Error); ok {
+					grip.Debug(message.WrapError(ec2err, msg))
+				}
+				return true, err
+			}
+			grip.Info(msg)
+			return false, nil
+		}, awsClientDefaultRetryOptions())
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
 // DescribeSpotPriceHistory is a wrapper for ec2.DescribeSpotPriceHistory.
 func (c *awsClientImpl) DescribeSpotPriceHistory(ctx context.Context, input *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error) {
 	var output *ec2.DescribeSpotPriceHistoryOutput
@@ -1214,6 +1303,9 @@ type awsClientMock struct { //nolint
 	*ec2.DetachVolumeInput
 	*ec2.ModifyVolumeInput
 	*ec2.DescribeVolumesInput
+	*ec2.CreateSnapshotInput
+	*ec2.DeleteSnapshotInput
+	*ec2.DescribeSnapshotsInput
 	*ec2.DescribeSpotPriceHistoryInput
 	*ec2.DescribeSubnetsInput
 	*ec2.DescribeVpcsInput
@@ -1231,7 +1323,8 @@ type awsClientMock struct { //nolint
 	RequestGetInstanceInfoError error
 	*ec2.DescribeInstanceTypeOfferingsOutput
 
-	launchTemplates []*ec2.LaunchTemplate
+	launchTemplates    []*ec2.LaunchTemplate
+	deletedSnapshotIDs []string
 }
 
 // Create a new mock client.
@@ -1490,6 +1583,44 @@ func (c *awsClientMock) DescribeVolumes(ctx context.Context, input *ec2.Describe
 	}, nil
 }
 
+// CreateSnapshot is a mock for ec2.CreateSnapshot.
+func (c *awsClientMock) CreateSnapshot(ctx context.Context, input *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
+	c.CreateSnapshotInput = input
+	return &ec2.Snapshot{
+		SnapshotId: aws.String("test-snapshot"),
+		VolumeId:   input.VolumeId,
+		State:      aws.String(ec2.SnapshotStatePending),
+	}, nil
+}
+
+// DeleteSnapshot is a mock for ec2.DeleteSnapshot.
+func (c *awsClientMock) DeleteSnapshot(ctx context.Context, input *ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+	c.DeleteSnapshotInput = input
+	return nil, nil
+}
+
+// DescribeSnapshots is a mock for ec2.DescribeSnapshots.
+func (c *awsClientMock) DescribeSnapshots(ctx context.Context, input *ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	c.DescribeSnapshotsInput = input
+	ids := input.SnapshotIds
+	for _, filter := range input.Filters {
+		if aws.StringValue(filter.Name) == "snapshot-id" {
+			ids = append(ids, filter.Values...)
+		}
+	}
+	output := &ec2.DescribeSnapshotsOutput{}
+	for _, id := range ids {
+		if utility.StringSliceContains(c.deletedSnapshotIDs, aws.StringValue(id)) {
+			continue
+		}
+		output.Snapshots = append(output.Snapshots, &ec2.Snapshot{
+			SnapshotId: id,
+			State:      aws.String(ec2.SnapshotStateCompleted),
+		})
+	}
+	return output, nil
+}
+
 // DescribeSpotPriceHistory is a mock for ec2.DescribeSpotPriceHistory.
 func (c *awsClientMock) DescribeSpotPriceHistory(ctx context.Context, input *ec2.DescribeSpotPriceHistoryInput) (*ec2.DescribeSpotPriceHistoryOutput, error) {
 	c.DescribeSpotPriceHistoryInput = input
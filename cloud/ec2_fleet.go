@@ -420,6 +420,22 @@ func (m *ec2FleetManager) GetVolumeAttachment(context.Context, string) (*host.Vo
 	return nil, errors.New("can't get volume attachment with EC2 fleet provider")
 }
 
+func (m *ec2FleetManager) CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	return nil, errors.New("can't create volume snapshot with EC2 fleet provider")
+}
+
+func (m *ec2FleetManager) ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error) {
+	return nil, errors.New("can't list volume snapshots with EC2 fleet provider")
+}
+
+func (m *ec2FleetManager) DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error {
+	return errors.New("can't delete volume snapshot with EC2 fleet provider")
+}
+
+func (m *ec2FleetManager) RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error) {
+	return nil, errors.New("can't restore volume snapshot with EC2 fleet provider")
+}
+
 func (m *ec2FleetManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
 	if err := m.client.Create(m.credentials, m.region); err != nil {
 		return "", errors.Wrap(err, "creating client")
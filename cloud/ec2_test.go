@@ -59,7 +59,7 @@ func TestEC2Suite(t *testing.T) {
 }
 
 func (s *EC2Suite) SetupTest() {
-	s.Require().NoError(db.ClearCollections(host.Collection, host.VolumesCollection, task.Collection, model.ProjectVarsCollection))
+	s.Require().NoError(db.ClearCollections(host.Collection, host.VolumesCollection, host.VolumeSnapshotsCollection, task.Collection, model.ProjectVarsCollection))
 	s.onDemandOpts = &EC2ManagerOptions{
 		client:   &awsClientMock{},
 		provider: onDemandProvider,
@@ -1672,6 +1672,144 @@ func (s *EC2Suite) TestDeleteVolume() {
 	s.NoError(err)
 }
 
+func (s *EC2Suite) TestCreateVolumeSnapshot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.volume.AvailabilityZone = "us-east-1a"
+	s.Require().NoError(s.volume.Insert())
+	snapshot, err := s.onDemandManager.CreateVolumeSnapshot(ctx, s.volume, &host.VolumeSnapshot{CreatedBy: "test-user"})
+	s.Require().NoError(err)
+
+	manager, ok := s.onDemandManager.(*ec2Manager)
+	s.True(ok)
+	mock, ok := manager.client.(*awsClientMock)
+	s.True(ok)
+
+	input := *mock.CreateSnapshotInput
+	s.Equal("test-volume", *input.VolumeId)
+	s.Require().Len(input.TagSpecifications, 1)
+	s.Equal(ec2.ResourceTypeSnapshot, *input.TagSpecifications[0].ResourceType)
+
+	s.Equal("test-snapshot", snapshot.ID)
+	s.Equal(host.VolumeSnapshotStatusPending, snapshot.Status)
+	s.Equal(s.volume.Size, snapshot.Size)
+	s.False(snapshot.Expiration.IsZero())
+
+	foundSnapshot, err := host.FindVolumeSnapshotByID(snapshot.ID)
+	s.NoError(err)
+	s.Require().NotNil(foundSnapshot)
+	s.Equal("test-volume", foundSnapshot.VolumeID)
+	s.Equal("us-east-1a", foundSnapshot.AvailabilityZone)
+}
+
+func (s *EC2Suite) TestListVolumeSnapshots() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Require().NoError((&host.VolumeSnapshot{ID: "pending", CreatedBy: "test-user", AvailabilityZone: "us-east-1a", Status: host.VolumeSnapshotStatusPending}).Insert())
+	s.Require().NoError((&host.VolumeSnapshot{ID: "other-region", CreatedBy: "test-user", AvailabilityZone: "us-west-2a", Status: host.VolumeSnapshotStatusPending}).Insert())
+	s.Require().NoError((&host.VolumeSnapshot{ID: "other-user", CreatedBy: "other-user", AvailabilityZone: "us-east-1a", Status: host.VolumeSnapshotStatusPending}).Insert())
+
+	snapshots, err := s.onDemandManager.ListVolumeSnapshots(ctx, "test-user")
+	s.Require().NoError(err)
+	s.Require().Len(snapshots, 1)
+	s.Equal("pending", snapshots[0].ID)
+	s.Equal(host.VolumeSnapshotStatusCompleted, snapshots[0].Status)
+
+	manager, ok := s.onDemandManager.(*ec2Manager)
+	s.True(ok)
+	mock, ok := manager.client.(*awsClientMock)
+	s.True(ok)
+	s.Require().Len(mock.DescribeSnapshotsInput.Filters, 1)
+	s.Equal([]string{"pending"}, aws.StringValueSlice(mock.DescribeSnapshotsInput.Filters[0].Values))
+
+	foundSnapshot, err := host.FindVolumeSnapshotByID("pending")
+	s.NoError(err)
+	s.Require().NotNil(foundSnapshot)
+	s.Equal(host.VolumeSnapshotStatusCompleted, foundSnapshot.Status)
+}
+
+func (s *EC2Suite) TestListVolumeSnapshotsRemovesSnapshotsDeletedInEC2() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Require().NoError((&host.VolumeSnapshot{ID: "pending", CreatedBy: "test-user", AvailabilityZone: "us-east-1a", Status: host.VolumeSnapshotStatusPending}).Insert())
+	s.Require().NoError((&host.VolumeSnapshot{ID: "deleted", CreatedBy: "test-user", AvailabilityZone: "us-east-1a", Status: host.VolumeSnapshotStatusPending}).Insert())
+
+	manager, ok := s.onDemandManager.(*ec2Manager)
+	s.Require().True(ok)
+	mock, ok := manager.client.(*awsClientMock)
+	s.Require().True(ok)
+	mock.deletedSnapshotIDs = []string{"deleted"}
+
+	snapshots, err := s.onDemandManager.ListVolumeSnapshots(ctx, "test-user")
+	s.Require().NoError(err)
+	s.Require().Len(snapshots, 1)
+	s.Equal("pending", snapshots[0].ID)
+	s.Equal(host.VolumeSnapshotStatusCompleted, snapshots[0].Status)
+
+	foundSnapshot, err := host.FindVolumeSnapshotByID("deleted")
+	s.NoError(err)
+	s.Nil(foundSnapshot, "snapshot that no longer exists in EC2 should be removed")
+}
+
+func (s *EC2Suite) TestDeleteVolumeSnapshot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshot := &host.VolumeSnapshot{ID: "test-snapshot"}
+	s.Require().NoError(snapshot.Insert())
+	s.NoError(s.onDemandManager.DeleteVolumeSnapshot(ctx, snapshot))
+
+	manager, ok := s.onDemandManager.(*ec2Manager)
+	s.True(ok)
+	mock, ok := manager.client.(*awsClientMock)
+	s.True(ok)
+	s.Equal("test-snapshot", *mock.DeleteSnapshotInput.SnapshotId)
+
+	foundSnapshot, err := host.FindVolumeSnapshotByID(snapshot.ID)
+	s.NoError(err)
+	s.Nil(foundSnapshot)
+}
+
+func (s *EC2Suite) TestRestoreVolumeSnapshot() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshot := &host.VolumeSnapshot{
+		ID:               "test-snapshot",
+		Size:             64,
+		VolumeType:       "gp3",
+		AvailabilityZone: "us-east-1a",
+		Status:           host.VolumeSnapshotStatusPending,
+	}
+	_, err := s.onDemandManager.RestoreVolumeSnapshot(ctx, snapshot, &host.Volume{CreatedBy: "test-user"})
+	s.Error(err, "pending snapshots should not be restorable")
+
+	snapshot.Status = host.VolumeSnapshotStatusCompleted
+	_, err = s.onDemandManager.RestoreVolumeSnapshot(ctx, snapshot, &host.Volume{CreatedBy: "test-user", AvailabilityZone: "us-west-2a"})
+	s.Error(err, "snapshots should not be restorable in another region")
+
+	volume, err := s.onDemandManager.RestoreVolumeSnapshot(ctx, snapshot, &host.Volume{CreatedBy: "test-user", Size: 10})
+	s.Require().NoError(err)
+	s.Equal(64, volume.Size)
+	s.Equal("gp3", volume.Type)
+	s.Equal("us-east-1a", volume.AvailabilityZone)
+
+	manager, ok := s.onDemandManager.(*ec2Manager)
+	s.True(ok)
+	mock, ok := manager.client.(*awsClientMock)
+	s.True(ok)
+	input := *mock.CreateVolumeInput
+	s.Equal("test-snapshot", *input.SnapshotId)
+	s.EqualValues(64, *input.Size)
+
+	foundVolume, err := host.FindVolumeByID(volume.ID)
+	s.NoError(err)
+	s.NotNil(foundVolume)
+}
+
 func (s *EC2Suite) TestAttachVolume() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
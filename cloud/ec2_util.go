@@ -32,6 +32,7 @@ const (
 	EC2UnfulfillableCapacity = "UnfulfillableCapacity"
 	EC2InvalidParam          = "InvalidParameterValue"
 	EC2VolumeNotFound        = "InvalidVolume.NotFound"
+	EC2SnapshotNotFound      = "InvalidSnapshot.NotFound"
 	EC2VolumeResizeRate      = "VolumeModificationRateExceeded"
 	ec2TemplateNameExists    = "InvalidLaunchTemplateName.AlreadyExistsException"
 )
@@ -578,6 +579,19 @@ func AttachVolumeBadRequest(err error) bool {
 	return false
 }
 
+// ec2SnapshotStateToStatus converts the state of an EC2 snapshot to a volume
+// snapshot status.
+func ec2SnapshotStateToStatus(state string) string {
+	switch state {
+	case ec2.SnapshotStateCompleted:
+		return host.VolumeSnapshotStatusCompleted
+	case ec2.SnapshotStateError:
+		return host.VolumeSnapshotStatusError
+	default:
+		return host.VolumeSnapshotStatusPending
+	}
+}
+
 func ModifyVolumeBadRequest(err error) bool {
 	for _, noRetryError := range []string{EC2VolumeNotFound, EC2VolumeResizeRate} {
 		if strings.Contains(err.Error(), noRetryError) {
@@ -251,6 +251,22 @@ func (m *gceManager) GetVolumeAttachment(context.Context, string) (*host.VolumeA
 	return nil, errors.New("can't get volume attachment with GCE provider")
 }
 
+func (m *gceManager) CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	return nil, errors.New("can't create volume snapshot with GCE provider")
+}
+
+func (m *gceManager) ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error) {
+	return nil, errors.New("can't list volume snapshots with GCE provider")
+}
+
+func (m *gceManager) DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error {
+	return errors.New("can't delete volume snapshot with GCE provider")
+}
+
+func (m *gceManager) RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error) {
+	return nil, errors.New("can't restore volume snapshot with GCE provider")
+}
+
 func (m *gceManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with GCE provider")
 }
@@ -439,6 +439,54 @@ func (m *mockManager) ModifyVolume(ctx context.Context, volume *host.Volume, opt
 	return nil
 }
 
+func (m *mockManager) CreateVolumeSnapshot(ctx context.Context, volume *host.Volume, snapshot *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	l := m.mutex
+	l.Lock()
+	defer l.Unlock()
+	if snapshot.ID == "" {
+		snapshot.ID = primitive.NewObjectID().Hex()
+	}
+	if utility.IsZeroTime(snapshot.Expiration) {
+		snapshot.Expiration = time.Now().Add(evergreen.DefaultVolumeSnapshotExpiration)
+	}
+	snapshot.VolumeID = volume.ID
+	snapshot.Size = volume.Size
+	snapshot.VolumeType = volume.Type
+	snapshot.AvailabilityZone = volume.AvailabilityZone
+	snapshot.HomeVolume = volume.HomeVolume
+	snapshot.Status = host.VolumeSnapshotStatusCompleted
+	if err := snapshot.Insert(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return snapshot, nil
+}
+
+func (m *mockManager) ListVolumeSnapshots(ctx context.Context, userID string) ([]host.VolumeSnapshot, error) {
+	snapshots, err := host.FindVolumeSnapshotsByUser(userID)
+	return snapshots, errors.WithStack(err)
+}
+
+func (m *mockManager) DeleteVolumeSnapshot(ctx context.Context, snapshot *host.VolumeSnapshot) error {
+	return errors.WithStack(snapshot.Remove())
+}
+
+func (m *mockManager) RestoreVolumeSnapshot(ctx context.Context, snapshot *host.VolumeSnapshot, volume *host.Volume) (*host.Volume, error) {
+	if snapshot.Status != host.VolumeSnapshotStatusCompleted {
+		return nil, errors.Errorf("snapshot '%s' is not completed yet", snapshot.ID)
+	}
+	if volume.AvailabilityZone == "" {
+		volume.AvailabilityZone = snapshot.AvailabilityZone
+	}
+	if volume.Size < snapshot.Size {
+		volume.Size = snapshot.Size
+	}
+	if volume.Type == "" {
+		volume.Type = snapshot.VolumeType
+	}
+	return m.CreateVolume(ctx, volume)
+}
+
 func (m *mockManager) GetVolumeAttachment(ctx context.Context, volumeID string) (*host.VolumeAttachment, error) {
 	l := m.mutex
 	l.Lock()
@@ -251,6 +251,22 @@ func (m *openStackManager) GetVolumeAttachment(context.Context, string) (*host.V
 	return nil, errors.New("can't get volume attachment with OpenStack provider")
 }
 
+func (m *openStackManager) CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	return nil, errors.New("can't create volume snapshot with OpenStack provider")
+}
+
+func (m *openStackManager) ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error) {
+	return nil, errors.New("can't list volume snapshots with OpenStack provider")
+}
+
+func (m *openStackManager) DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error {
+	return errors.New("can't delete volume snapshot with OpenStack provider")
+}
+
+func (m *openStackManager) RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error) {
+	return nil, errors.New("can't restore volume snapshot with OpenStack provider")
+}
+
 func (m *openStackManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with OpenStack provider")
 }
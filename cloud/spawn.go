@@ -13,6 +13,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/gimlet"
@@ -86,6 +87,28 @@ func (so *SpawnOptions) validate(settings *evergreen.Settings) error {
 	return nil
 }
 
+// useTaskDistro prepares the options of a spawn host that reproduces a task,
+// defaulting its distro to the one that the task ran on.
+func (so *SpawnOptions) useTaskDistro() error {
+	if so.ProvisionOptions.TaskId == "" {
+		return errors.New("must specify a task to reproduce")
+	}
+	t, err := task.FindOneId(so.ProvisionOptions.TaskId)
+	if err != nil {
+		return errors.Wrapf(err, "finding task '%s'", so.ProvisionOptions.TaskId)
+	}
+	if t == nil {
+		return errors.Errorf("task '%s' not found", so.ProvisionOptions.TaskId)
+	}
+	if so.DistroId == "" {
+		so.DistroId = t.DistroId
+	}
+	// The host fetches the task's artifacts and then pulls its task sync
+	// data, so it should not only pull the task sync data.
+	so.ProvisionOptions.TaskSync = false
+	return nil
+}
+
 func checkSpawnHostLimitExceeded(numCurrentHosts int, settings *evergreen.Settings) error {
 	if numCurrentHosts >= settings.Spawnhost.SpawnHostsPerUser {
 		return errors.Errorf("user is already running the max allowed number of spawn hosts (%d of %d)", numCurrentHosts, settings.Spawnhost.SpawnHostsPerUser)
@@ -95,6 +118,11 @@ func checkSpawnHostLimitExceeded(numCurrentHosts int, settings *evergreen.Settin
 
 // CreateSpawnHost spawns a host with the given options.
 func CreateSpawnHost(ctx context.Context, so SpawnOptions, settings *evergreen.Settings) (*host.Host, error) {
+	if so.ProvisionOptions != nil && so.ProvisionOptions.ReproduceTask {
+		if err := so.useTaskDistro(); err != nil {
+			return nil, errors.Wrap(err, "using task's distro")
+		}
+	}
 	if err := so.validate(settings); err != nil {
 		return nil, errors.WithStack(err)
 	}
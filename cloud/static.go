@@ -154,6 +154,22 @@ func (m *staticManager) GetVolumeAttachment(context.Context, string) (*host.Volu
 	return nil, errors.New("can't get volume attachment with static provider")
 }
 
+func (m *staticManager) CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	return nil, errors.New("can't create volume snapshot with static provider")
+}
+
+func (m *staticManager) ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error) {
+	return nil, errors.New("can't list volume snapshots with static provider")
+}
+
+func (m *staticManager) DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error {
+	return errors.New("can't delete volume snapshot with static provider")
+}
+
+func (m *staticManager) RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error) {
+	return nil, errors.New("can't restore volume snapshot with static provider")
+}
+
 func (staticMgr *staticManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with static provider")
 }
@@ -212,6 +212,22 @@ func (m *vsphereManager) GetVolumeAttachment(context.Context, string) (*host.Vol
 	return nil, errors.New("can't get volume attachment with vSphere provider")
 }
 
+func (m *vsphereManager) CreateVolumeSnapshot(context.Context, *host.Volume, *host.VolumeSnapshot) (*host.VolumeSnapshot, error) {
+	return nil, errors.New("can't create volume snapshot with vSphere provider")
+}
+
+func (m *vsphereManager) ListVolumeSnapshots(context.Context, string) ([]host.VolumeSnapshot, error) {
+	return nil, errors.New("can't list volume snapshots with vSphere provider")
+}
+
+func (m *vsphereManager) DeleteVolumeSnapshot(context.Context, *host.VolumeSnapshot) error {
+	return errors.New("can't delete volume snapshot with vSphere provider")
+}
+
+func (m *vsphereManager) RestoreVolumeSnapshot(context.Context, *host.VolumeSnapshot, *host.Volume) (*host.Volume, error) {
+	return nil, errors.New("can't restore volume snapshot with vSphere provider")
+}
+
 func (m *vsphereManager) CheckInstanceType(context.Context, string) error {
 	return errors.New("can't specify instance type with vSphere provider")
 }
@@ -49,6 +49,7 @@ func buildApp() *cli.App {
 		operations.Volume(),
 		operations.Notification(),
 		operations.Buildlogger(),
+		operations.Logs(),
 		operations.Generate(),
 
 		// Top-level commands.
@@ -57,6 +58,7 @@ func buildApp() *cli.App {
 		operations.Pull(),
 		operations.Evaluate(),
 		operations.Validate(),
+		operations.Config(),
 		operations.List(),
 		operations.LastGreen(),
 		operations.Subscriptions(),
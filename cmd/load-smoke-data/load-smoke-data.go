@@ -14,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/queue"
@@ -71,9 +72,35 @@ func insertFileDocsToDB(ctx context.Context, fn string, db *mongo.Database, logs
 			{
 				Keys: task.DurationIndex,
 			},
+			{
+				Keys: task.DistroDemandIndex,
+			},
+			{
+				Keys: task.CedarTestResultsIndex,
+			},
 		}); err != nil {
 			return errors.Wrap(err, "creating task indexes")
 		}
+	case task.OldCollection:
+		if _, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: task.CedarTestResultsIndex,
+		}); err != nil {
+			return errors.Wrap(err, "creating old task index")
+		}
+	case testresult.Collection:
+		if _, err = collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+			{
+				Keys: testresult.TaskIndex,
+			},
+			{
+				Keys: testresult.TaskStatusIndex,
+			},
+			{
+				Keys: testresult.TaskTestNameIndex,
+			},
+		}); err != nil {
+			return errors.Wrap(err, "creating test results indexes")
+		}
 	case host.Collection:
 		if _, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
 			Keys: host.StatusIndex,
@@ -32,10 +32,10 @@ var (
 	BuildRevision = ""
 
 	// ClientVersion is the commandline version string used to control auto-updating.
-	ClientVersion = "2023-04-10"
+	ClientVersion = "2023-04-21"
 
 	// Agent version to control agent rollover.
-	AgentVersion = "2023-04-13"
+	AgentVersion = "2023-04-28"
 )
 
 // ConfigSection defines a sub-document in the evergreen config
@@ -107,6 +107,10 @@ type Settings struct {
 	Spawnhost           SpawnHostConfig         `yaml:"spawnhost" bson:"spawnhost" json:"spawnhost" id:"spawnhost"`
 	ShutdownWaitSeconds int                     `yaml:"shutdown_wait_seconds" bson:"shutdown_wait_seconds" json:"shutdown_wait_seconds"`
 	Tracer              TracerConfig            `yaml:"tracer" bson:"tracer" json:"tracer" id:"tracer"`
+	EventRetention      EventRetentionConfig    `yaml:"event_retention" bson:"event_retention" json:"event_retention" id:"event_retention"`
+	Provenance          ProvenanceConfig        `yaml:"provenance" bson:"provenance" json:"provenance" id:"provenance"`
+	TestResults         TestResultsConfig       `yaml:"test_results" bson:"test_results" json:"test_results" id:"test_results"`
+	GithubApp           GithubAppConfig         `yaml:"github_app" bson:"github_app" json:"github_app" id:"github_app"`
 }
 
 func (c *Settings) SectionId() string { return ConfigDocID }
@@ -117,6 +117,24 @@ var (
 
 	tracerEnabledKey        = bsonutil.MustHaveTag(TracerConfig{}, "Enabled")
 	tracerCollectorEndpoint = bsonutil.MustHaveTag(TracerConfig{}, "CollectorEndpoint")
+
+	eventRetentionBucketTypeKey = bsonutil.MustHaveTag(EventRetentionConfig{}, "BucketType")
+	eventRetentionBucketKey     = bsonutil.MustHaveTag(EventRetentionConfig{}, "Bucket")
+	eventRetentionPrefixKey     = bsonutil.MustHaveTag(EventRetentionConfig{}, "Prefix")
+	eventRetentionKeyKey        = bsonutil.MustHaveTag(EventRetentionConfig{}, "Key")
+	eventRetentionSecretKey     = bsonutil.MustHaveTag(EventRetentionConfig{}, "Secret")
+	eventRetentionPoliciesKey   = bsonutil.MustHaveTag(EventRetentionConfig{}, "Policies")
+
+	provenanceSigningKeyKey = bsonutil.MustHaveTag(ProvenanceConfig{}, "SigningKey")
+	provenanceKeyIDKey      = bsonutil.MustHaveTag(ProvenanceConfig{}, "KeyID")
+	provenanceBuilderIDKey  = bsonutil.MustHaveTag(ProvenanceConfig{}, "BuilderID")
+
+	githubAppIDKey         = bsonutil.MustHaveTag(GithubAppConfig{}, "AppID")
+	githubAppPrivateKeyKey = bsonutil.MustHaveTag(GithubAppConfig{}, "PrivateKey")
+
+	testResultsServiceKey           = bsonutil.MustHaveTag(TestResultsConfig{}, "Service")
+	testResultsBackfillFromCedarKey = bsonutil.MustHaveTag(TestResultsConfig{}, "BackfillFromCedar")
+	testResultsBackfillBatchSizeKey = bsonutil.MustHaveTag(TestResultsConfig{}, "BackfillBatchSize")
 )
 
 func byId(id string) bson.M {
@@ -0,0 +1,107 @@
+package evergreen
+
+import (
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// EventArchiveBucketTypeS3 archives expiring events to an S3 bucket.
+	EventArchiveBucketTypeS3 = "s3"
+	// EventArchiveBucketTypeLocal archives expiring events to a directory on
+	// the local file system. It is intended for testing.
+	EventArchiveBucketTypeLocal = "local"
+)
+
+// EventRetentionConfig configures how long events are kept in the database
+// before they are archived and removed.
+type EventRetentionConfig struct {
+	// BucketType is the kind of bucket that expiring events are archived to.
+	BucketType string `yaml:"bucket_type" bson:"bucket_type" json:"bucket_type"`
+	// Bucket is the S3 bucket name or, for local buckets, the directory
+	// that events are archived to.
+	Bucket string `yaml:"bucket" bson:"bucket" json:"bucket"`
+	// Prefix is prepended to the key of every archived file.
+	Prefix string `yaml:"prefix" bson:"prefix" json:"prefix"`
+	Key    string `yaml:"key" bson:"key" json:"key"`
+	Secret string `yaml:"secret" bson:"secret" json:"secret"`
+	// Policies are the retention periods for event resource types. Events
+	// for resource types without a policy are not archived.
+	Policies []EventRetentionPolicy `yaml:"policies" bson:"policies" json:"policies"`
+}
+
+// EventRetentionPolicy is how long events for a resource type are kept in
+// the database.
+type EventRetentionPolicy struct {
+	ResourceType  string `yaml:"resource_type" bson:"resource_type" json:"resource_type"`
+	RetentionDays int    `yaml:"retention_days" bson:"retention_days" json:"retention_days"`
+}
+
+func (c *EventRetentionConfig) SectionId() string { return "event_retention" }
+
+func (c *EventRetentionConfig) Get(env Environment) error {
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+	res := coll.FindOne(ctx, byId(c.SectionId()))
+	if err := res.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			*c = EventRetentionConfig{}
+			return nil
+		}
+		return errors.Wrapf(err, "getting config section '%s'", c.SectionId())
+	}
+
+	if err := res.Decode(c); err != nil {
+		return errors.Wrapf(err, "decoding config section '%s'", c.SectionId())
+	}
+
+	return nil
+}
+
+func (c *EventRetentionConfig) Set() error {
+	env := GetEnvironment()
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+
+	_, err := coll.UpdateOne(ctx, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			eventRetentionBucketTypeKey: c.BucketType,
+			eventRetentionBucketKey:     c.Bucket,
+			eventRetentionPrefixKey:     c.Prefix,
+			eventRetentionKeyKey:        c.Key,
+			eventRetentionSecretKey:     c.Secret,
+			eventRetentionPoliciesKey:   c.Policies,
+		},
+	}, options.Update().SetUpsert(true))
+	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
+}
+
+func (c *EventRetentionConfig) ValidateAndDefault() error {
+	catcher := grip.NewBasicCatcher()
+	if len(c.Policies) == 0 {
+		return nil
+	}
+
+	if c.BucketType == "" {
+		c.BucketType = EventArchiveBucketTypeS3
+	}
+	catcher.ErrorfWhen(c.BucketType != EventArchiveBucketTypeS3 && c.BucketType != EventArchiveBucketTypeLocal, "invalid event archive bucket type '%s'", c.BucketType)
+	catcher.NewWhen(c.Bucket == "", "event archive bucket must be specified")
+
+	resourceTypes := map[string]bool{}
+	for _, p := range c.Policies {
+		catcher.NewWhen(p.ResourceType == "", "event retention policy must specify a resource type")
+		catcher.ErrorfWhen(resourceTypes[p.ResourceType], "duplicate event retention policy for resource type '%s'", p.ResourceType)
+		catcher.ErrorfWhen(p.RetentionDays <= 0, "event retention for resource type '%s' must be a positive number of days", p.ResourceType)
+		resourceTypes[p.ResourceType] = true
+	}
+
+	return catcher.Resolve()
+}
@@ -0,0 +1,90 @@
+package evergreen
+
+import (
+	"crypto/rsa"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// GithubAppConfig holds the credentials of the GitHub App that Evergreen acts
+// as for the GitHub APIs that only GitHub Apps can use, such as check runs.
+type GithubAppConfig struct {
+	// AppID is the ID of the GitHub App.
+	AppID int64 `yaml:"app_id" bson:"app_id" json:"app_id"`
+	// PrivateKey is the PEM-encoded RSA private key that GitHub generated for
+	// the app. If it is not set, Evergreen cannot act as the app.
+	PrivateKey string `yaml:"private_key" bson:"private_key" json:"private_key"`
+}
+
+func (c *GithubAppConfig) SectionId() string { return "github_app" }
+
+func (c *GithubAppConfig) Get(env Environment) error {
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+	res := coll.FindOne(ctx, byId(c.SectionId()))
+	if err := res.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			*c = GithubAppConfig{}
+			return nil
+		}
+		return errors.Wrapf(err, "getting config section '%s'", c.SectionId())
+	}
+
+	if err := res.Decode(c); err != nil {
+		return errors.Wrapf(err, "decoding config section '%s'", c.SectionId())
+	}
+
+	return nil
+}
+
+func (c *GithubAppConfig) Set() error {
+	env := GetEnvironment()
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+
+	_, err := coll.UpdateOne(ctx, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			githubAppIDKey:         c.AppID,
+			githubAppPrivateKeyKey: c.PrivateKey,
+		},
+	}, options.Update().SetUpsert(true))
+	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
+}
+
+func (c *GithubAppConfig) ValidateAndDefault() error {
+	if c.PrivateKey == "" {
+		return nil
+	}
+	if _, err := c.RSAPrivateKey(); err != nil {
+		return errors.Wrap(err, "invalid GitHub App private key")
+	}
+	if c.AppID <= 0 {
+		return errors.New("GitHub App private key must have an app ID")
+	}
+	return nil
+}
+
+// Enabled returns whether Evergreen can act as the GitHub App.
+func (c *GithubAppConfig) Enabled() bool {
+	return c.AppID > 0 && c.PrivateKey != ""
+}
+
+// RSAPrivateKey decodes the app's private key.
+func (c *GithubAppConfig) RSAPrivateKey() (*rsa.PrivateKey, error) {
+	if c.PrivateKey == "" {
+		return nil, errors.New("GitHub App private key is not configured")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(c.PrivateKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing PEM-encoded RSA private key")
+	}
+	return key, nil
+}
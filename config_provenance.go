@@ -0,0 +1,113 @@
+package evergreen
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ProvenanceConfig configures how the app server signs provenance
+// attestations for task artifacts.
+type ProvenanceConfig struct {
+	// SigningKey is the base64-encoded ed25519 private key, either as a
+	// 32-byte seed or as the full 64-byte private key, that attestations are
+	// signed with. If it is not set, attestations cannot be created.
+	SigningKey string `yaml:"signing_key" bson:"signing_key" json:"signing_key"`
+	// KeyID identifies the signing key in signed attestations so that
+	// verifiers can tell which key to check the signature against.
+	KeyID string `yaml:"key_id" bson:"key_id" json:"key_id"`
+	// BuilderID identifies this Evergreen instance as the builder in
+	// provenance statements. It defaults to the API URL.
+	BuilderID string `yaml:"builder_id" bson:"builder_id" json:"builder_id"`
+}
+
+func (c *ProvenanceConfig) SectionId() string { return "provenance" }
+
+func (c *ProvenanceConfig) Get(env Environment) error {
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+	res := coll.FindOne(ctx, byId(c.SectionId()))
+	if err := res.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			*c = ProvenanceConfig{}
+			return nil
+		}
+		return errors.Wrapf(err, "getting config section '%s'", c.SectionId())
+	}
+
+	if err := res.Decode(c); err != nil {
+		return errors.Wrapf(err, "decoding config section '%s'", c.SectionId())
+	}
+
+	return nil
+}
+
+func (c *ProvenanceConfig) Set() error {
+	env := GetEnvironment()
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+
+	_, err := coll.UpdateOne(ctx, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			provenanceSigningKeyKey: c.SigningKey,
+			provenanceKeyIDKey:      c.KeyID,
+			provenanceBuilderIDKey:  c.BuilderID,
+		},
+	}, options.Update().SetUpsert(true))
+	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
+}
+
+func (c *ProvenanceConfig) ValidateAndDefault() error {
+	if c.SigningKey == "" {
+		return nil
+	}
+	if _, err := c.PrivateKey(); err != nil {
+		return errors.Wrap(err, "invalid provenance signing key")
+	}
+	if c.KeyID == "" {
+		return errors.New("provenance signing key must have a key ID")
+	}
+	return nil
+}
+
+// Enabled returns whether the app server can sign provenance attestations.
+func (c *ProvenanceConfig) Enabled() bool {
+	return c.SigningKey != ""
+}
+
+// PrivateKey decodes the signing key.
+func (c *ProvenanceConfig) PrivateKey() (ed25519.PrivateKey, error) {
+	if c.SigningKey == "" {
+		return nil, errors.New("provenance signing key is not configured")
+	}
+	raw, err := base64.StdEncoding.DecodeString(c.SigningKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding base64 signing key")
+	}
+	switch len(raw) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(raw), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(raw), nil
+	default:
+		return nil, errors.Errorf("signing key must be %d or %d bytes, but is %d bytes", ed25519.SeedSize, ed25519.PrivateKeySize, len(raw))
+	}
+}
+
+// PublicKey returns the public key that verifies signatures made with the
+// signing key.
+func (c *ProvenanceConfig) PublicKey() (ed25519.PublicKey, error) {
+	key, err := c.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return key.Public().(ed25519.PublicKey), nil
+}
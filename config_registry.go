@@ -59,6 +59,10 @@ func resetRegistry() error {
 		&TriggerConfig{},
 		&SpawnHostConfig{},
 		&TracerConfig{},
+		&EventRetentionConfig{},
+		&ProvenanceConfig{},
+		&GithubAppConfig{},
+		&TestResultsConfig{},
 	}
 
 	ConfigRegistry = newConfigSectionRegistry()
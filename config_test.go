@@ -2,6 +2,12 @@ package evergreen
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -944,6 +950,246 @@ func (s *AdminSuite) TestTracerConfig() {
 	s.Equal(config, settings.Tracer)
 }
 
+func (s *AdminSuite) TestEventRetentionConfig() {
+	config := EventRetentionConfig{
+		BucketType: EventArchiveBucketTypeS3,
+		Bucket:     "event-archive",
+		Prefix:     "events",
+		Policies: []EventRetentionPolicy{
+			{ResourceType: "ADMIN", RetentionDays: 2557},
+			{ResourceType: "HOST", RetentionDays: 90},
+		},
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.EventRetention)
+
+	config.Policies = config.Policies[:1]
+	s.NoError(config.Set())
+
+	settings, err = GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.EventRetention)
+}
+
+func TestEventRetentionConfigValidateAndDefault(t *testing.T) {
+	for testName, testCase := range map[string]struct {
+		config    EventRetentionConfig
+		expectErr bool
+	}{
+		"EmptyIsValid": {},
+		"DefaultsToS3": {
+			config: EventRetentionConfig{
+				Bucket:   "bucket",
+				Policies: []EventRetentionPolicy{{ResourceType: "HOST", RetentionDays: 30}},
+			},
+		},
+		"RequiresBucket": {
+			config: EventRetentionConfig{
+				Policies: []EventRetentionPolicy{{ResourceType: "HOST", RetentionDays: 30}},
+			},
+			expectErr: true,
+		},
+		"RejectsInvalidBucketType": {
+			config: EventRetentionConfig{
+				BucketType: "gcs",
+				Bucket:     "bucket",
+				Policies:   []EventRetentionPolicy{{ResourceType: "HOST", RetentionDays: 30}},
+			},
+			expectErr: true,
+		},
+		"RejectsDuplicatePolicies": {
+			config: EventRetentionConfig{
+				Bucket: "bucket",
+				Policies: []EventRetentionPolicy{
+					{ResourceType: "HOST", RetentionDays: 30},
+					{ResourceType: "HOST", RetentionDays: 60},
+				},
+			},
+			expectErr: true,
+		},
+		"RejectsNonPositiveRetention": {
+			config: EventRetentionConfig{
+				Bucket:   "bucket",
+				Policies: []EventRetentionPolicy{{ResourceType: "HOST"}},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			err := testCase.config.ValidateAndDefault()
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if len(testCase.config.Policies) > 0 {
+				assert.Equal(t, EventArchiveBucketTypeS3, testCase.config.BucketType)
+			}
+		})
+	}
+}
+
+func (s *AdminSuite) TestProvenanceConfig() {
+	config := ProvenanceConfig{
+		SigningKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+		KeyID:      "key",
+		BuilderID:  "https://evergreen.example.com",
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.Provenance)
+}
+
+func (s *AdminSuite) TestGithubAppConfig() {
+	config := GithubAppConfig{
+		AppID:      1234,
+		PrivateKey: "private_key",
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.GithubApp)
+}
+
+func (s *AdminSuite) TestTestResultsConfig() {
+	config := TestResultsConfig{
+		Service:           TestResultsServiceLocal,
+		BackfillFromCedar: true,
+		BackfillBatchSize: 50,
+	}
+
+	err := config.Set()
+	s.NoError(err)
+	settings, err := GetConfig()
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.TestResults)
+}
+
+func TestTestResultsConfigValidateAndDefault(t *testing.T) {
+	config := TestResultsConfig{}
+	require.NoError(t, config.ValidateAndDefault())
+	assert.Equal(t, TestResultsServiceLocal, config.Service)
+	assert.Equal(t, defaultTestResultsBackfillBatchSize, config.BackfillBatchSize)
+
+	config = TestResultsConfig{Service: TestResultsServiceCedar, BackfillBatchSize: 10}
+	require.NoError(t, config.ValidateAndDefault())
+	assert.Equal(t, TestResultsServiceCedar, config.Service)
+	assert.Equal(t, 10, config.BackfillBatchSize)
+
+	config = TestResultsConfig{Service: "s3"}
+	assert.Error(t, config.ValidateAndDefault())
+
+	config = TestResultsConfig{BackfillBatchSize: -1}
+	assert.Error(t, config.ValidateAndDefault())
+}
+
+func TestGithubAppConfigValidateAndDefault(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+
+	for testName, testCase := range map[string]struct {
+		config    GithubAppConfig
+		expectErr bool
+		enabled   bool
+	}{
+		"EmptyIsValid": {},
+		"AcceptsPEMKey": {
+			config:  GithubAppConfig{AppID: 1234, PrivateKey: keyPEM},
+			enabled: true,
+		},
+		"RejectsInvalidKey": {
+			config:    GithubAppConfig{AppID: 1234, PrivateKey: "not a key"},
+			expectErr: true,
+		},
+		"RequiresAppID": {
+			config:    GithubAppConfig{PrivateKey: keyPEM},
+			expectErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			err := testCase.config.ValidateAndDefault()
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.enabled, testCase.config.Enabled())
+			if testCase.enabled {
+				parsed, err := testCase.config.RSAPrivateKey()
+				require.NoError(t, err)
+				assert.True(t, key.Equal(parsed))
+			}
+		})
+	}
+}
+
+func TestProvenanceConfigKeys(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	expected := ed25519.NewKeyFromSeed(seed)
+
+	for testName, testCase := range map[string]struct {
+		config    ProvenanceConfig
+		expectErr bool
+	}{
+		"EmptyIsValid": {},
+		"AcceptsSeed": {
+			config: ProvenanceConfig{SigningKey: base64.StdEncoding.EncodeToString(seed), KeyID: "key"},
+		},
+		"AcceptsFullPrivateKey": {
+			config: ProvenanceConfig{SigningKey: base64.StdEncoding.EncodeToString(expected), KeyID: "key"},
+		},
+		"RejectsInvalidBase64": {
+			config:    ProvenanceConfig{SigningKey: "not base64!", KeyID: "key"},
+			expectErr: true,
+		},
+		"RejectsWrongKeyLength": {
+			config:    ProvenanceConfig{SigningKey: base64.StdEncoding.EncodeToString(seed[:16]), KeyID: "key"},
+			expectErr: true,
+		},
+		"RequiresKeyID": {
+			config:    ProvenanceConfig{SigningKey: base64.StdEncoding.EncodeToString(seed)},
+			expectErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			err := testCase.config.ValidateAndDefault()
+			if testCase.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if !testCase.config.Enabled() {
+				return
+			}
+
+			key, err := testCase.config.PrivateKey()
+			require.NoError(t, err)
+			assert.Equal(t, expected, key)
+			pub, err := testCase.config.PublicKey()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Public(), pub)
+		})
+	}
+}
+
 func (s *AdminSuite) TestDataPipesConfig() {
 	config := DataPipesConfig{
 		Host:         "https://url.com",
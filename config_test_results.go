@@ -0,0 +1,98 @@
+package evergreen
+
+import (
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Test results services that tasks can send their test results to. These
+// must match the services supported by the test results model.
+const (
+	TestResultsServiceLocal = "local"
+	TestResultsServiceCedar = "cedar"
+)
+
+const defaultTestResultsBackfillBatchSize = 100
+
+// TestResultsConfig configures where tasks store their test results and test
+// logs.
+type TestResultsConfig struct {
+	// Service is the test results service that tasks send new test results
+	// and test logs to. It defaults to the local service, which stores them
+	// in the application database.
+	Service string `yaml:"service" bson:"service" json:"service"`
+	// BackfillFromCedar enables the background job that copies test results
+	// stored in Cedar into the local test results service.
+	BackfillFromCedar bool `yaml:"backfill_from_cedar" bson:"backfill_from_cedar" json:"backfill_from_cedar"`
+	// BackfillBatchSize is the maximum number of tasks whose test results are
+	// copied each time the backfill job runs.
+	BackfillBatchSize int `yaml:"backfill_batch_size" bson:"backfill_batch_size" json:"backfill_batch_size"`
+}
+
+func (c *TestResultsConfig) SectionId() string { return "test_results" }
+
+func (c *TestResultsConfig) Get(env Environment) error {
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+	res := coll.FindOne(ctx, byId(c.SectionId()))
+	if err := res.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			*c = TestResultsConfig{}
+			return nil
+		}
+		return errors.Wrapf(err, "getting config section '%s'", c.SectionId())
+	}
+
+	if err := res.Decode(c); err != nil {
+		return errors.Wrapf(err, "decoding config section '%s'", c.SectionId())
+	}
+
+	return nil
+}
+
+func (c *TestResultsConfig) Set() error {
+	env := GetEnvironment()
+	ctx, cancel := env.Context()
+	defer cancel()
+
+	coll := env.DB().Collection(ConfigCollection)
+
+	_, err := coll.UpdateOne(ctx, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			testResultsServiceKey:           c.Service,
+			testResultsBackfillFromCedarKey: c.BackfillFromCedar,
+			testResultsBackfillBatchSizeKey: c.BackfillBatchSize,
+		},
+	}, options.Update().SetUpsert(true))
+	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
+}
+
+func (c *TestResultsConfig) ValidateAndDefault() error {
+	if c.Service == "" {
+		c.Service = TestResultsServiceLocal
+	}
+	if !utility.StringSliceContains([]string{TestResultsServiceLocal, TestResultsServiceCedar}, c.Service) {
+		return errors.Errorf("invalid test results service '%s'", c.Service)
+	}
+	if c.BackfillBatchSize < 0 {
+		return errors.New("test results backfill batch size cannot be negative")
+	}
+	if c.BackfillBatchSize == 0 {
+		c.BackfillBatchSize = defaultTestResultsBackfillBatchSize
+	}
+	return nil
+}
+
+// GetService returns the test results service that tasks should send new test
+// results to.
+func (c *TestResultsConfig) GetService() string {
+	if c.Service == "" {
+		return TestResultsServiceLocal
+	}
+	return c.Service
+}
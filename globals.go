@@ -13,6 +13,7 @@ import (
 const (
 	User            = "mci"
 	GithubPatchUser = "github_pull_request"
+	GithubMergeUser = "github_merge_queue"
 	ParentPatchUser = "parent_patch"
 
 	HostRunning       = "running"
@@ -230,6 +231,10 @@ const (
 	DefaultMaxVolumeSizePerUser         = 500
 	DefaultUnexpirableHostsPerUser      = 1
 	DefaultUnexpirableVolumesPerUser    = 1
+	DefaultVolumeSnapshotExpiration     = 24 * time.Hour * 30
+	MaxVolumeSnapshotExpiration         = 24 * time.Hour * 90
+	MinSpawnHostIdleTimeout             = time.Hour
+	SpawnHostActivityReportInterval     = 5 * time.Minute
 
 	// host resource tag names
 	TagName             = "name"
@@ -248,6 +253,7 @@ const (
 
 	HostAllocatorDeficit     = "deficit"
 	HostAllocatorUtilization = "utilization"
+	HostAllocatorPredictive  = "predictive"
 
 	HostAllocatorRoundDown    = "round-down"
 	HostAllocatorRoundUp      = "round-up"
@@ -267,6 +273,11 @@ const (
 	GithubChecksAlias = "__github_checks"
 	GitTagAlias       = "__git_tag"
 
+	// ImpactedAlias is a special alias that selects a patch's variants and
+	// tasks based on the files it changes, using the project's impacted tasks
+	// rules.
+	ImpactedAlias = "__impacted"
+
 	MergeTaskVariant = "commit-queue-merge"
 	MergeTaskName    = "merge-patch"
 	MergeTaskGroup   = "merge-task-group"
@@ -555,8 +566,9 @@ const (
 	GitTagRequester             = "git_tag_request"
 	RepotrackerVersionRequester = "gitter_request"
 	TriggerRequester            = "trigger_request"
-	MergeTestRequester          = "merge_test" // commit queue
-	AdHocRequester              = "ad_hoc"     // periodic build
+	MergeTestRequester          = "merge_test"           // commit queue
+	AdHocRequester              = "ad_hoc"               // periodic build
+	GithubMergeRequester        = "github_merge_request" // GitHub merge queue
 )
 
 var AllRequesterTypes = []string{
@@ -567,6 +579,7 @@ var AllRequesterTypes = []string{
 	TriggerRequester,
 	MergeTestRequester,
 	AdHocRequester,
+	GithubMergeRequester,
 }
 
 // Constants related to requester types.
@@ -755,6 +768,7 @@ var (
 	// Set of valid Host Allocators types
 	ValidHostAllocators = []string{
 		HostAllocatorUtilization,
+		HostAllocatorPredictive,
 	}
 
 	ValidHostAllocatorRoundingRules = []string{
@@ -857,12 +871,18 @@ func IsGitTagRequester(requester string) bool {
 	return requester == GitTagRequester
 }
 
+// IsGithubMergeQueueRequester returns true if the requester is a GitHub merge
+// queue merge group.
+func IsGithubMergeQueueRequester(requester string) bool {
+	return requester == GithubMergeRequester
+}
+
 func IsCommitQueueRequester(requester string) bool {
 	return requester == MergeTestRequester
 }
 
 func ShouldConsiderBatchtime(requester string) bool {
-	return !IsPatchRequester(requester) && requester != AdHocRequester && requester != GitTagRequester
+	return !IsPatchRequester(requester) && requester != AdHocRequester && requester != GitTagRequester && requester != GithubMergeRequester
 }
 
 func PermissionsDisabledForTests() bool {
@@ -351,6 +351,17 @@ type TestFilterOptions struct {
 	Page     *int               `json:"page"`
 }
 
+// TestHistoryOptions is an input to the testHistory query.
+// Its fields determine which task's tests and which mainline versions and build variants we fetch test history for.
+type TestHistoryOptions struct {
+	BuildVariants     []string `json:"buildVariants"`
+	NumVersions       *int     `json:"numVersions"`
+	ProjectIdentifier string   `json:"projectIdentifier"`
+	StartAt           *int     `json:"startAt"`
+	TaskName          string   `json:"taskName"`
+	TestName          string   `json:"testName"`
+}
+
 // TestSortOptions is an input for the task.Tests query.
 // It's used to define sort criteria for test results of a task.
 type TestSortOptions struct {
@@ -19,18 +19,18 @@ func (r *projectSubscriberResolver) Subscriber(ctx context.Context, obj *restMod
 	subscriberType := utility.FromStringPtr(obj.Type)
 
 	switch subscriberType {
-	case event.GithubPullRequestSubscriberType:
+	case event.GithubPullRequestSubscriberType, event.GithubPRCommentSubscriberType:
 		sub := restModel.APIGithubPRSubscriber{}
 		if err := mapstructure.Decode(obj.Target, &sub); err != nil {
 			return nil, InternalServerError.Send(ctx, fmt.Sprintf("problem converting %s subscriber: %s",
-				event.GithubPullRequestSubscriberType, err.Error()))
+				subscriberType, err.Error()))
 		}
 		res.GithubPRSubscriber = &sub
-	case event.GithubCheckSubscriberType:
+	case event.GithubCheckSubscriberType, event.GithubCheckRunSubscriberType:
 		sub := restModel.APIGithubCheckSubscriber{}
 		if err := mapstructure.Decode(obj.Target, &sub); err != nil {
 			return nil, InternalServerError.Send(ctx, fmt.Sprintf("problem building %s subscriber from service: %s",
-				event.GithubCheckSubscriberType, err.Error()))
+				subscriberType, err.Error()))
 		}
 		res.GithubCheckSubscriber = &sub
 
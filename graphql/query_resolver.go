@@ -23,6 +23,7 @@ import (
 	"github.com/evergreen-ci/evergreen/rest/data"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/plank"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/anser/bsonutil"
@@ -706,6 +707,49 @@ func (r *queryResolver) TaskTestSample(ctx context.Context, tasks []string, filt
 	return apiSamples, nil
 }
 
+// TestHistory is the resolver for the testHistory field.
+func (r *queryResolver) TestHistory(ctx context.Context, options TestHistoryOptions) ([]*restModel.APITestHistory, error) {
+	projectID, err := model.GetIdForProject(options.ProjectIdentifier)
+	if err != nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("Could not find project with id: %s", options.ProjectIdentifier))
+	}
+	usr := mustHaveUser(ctx)
+	requiredPermission := gimlet.PermissionOpts{
+		Resource:      projectID,
+		ResourceType:  evergreen.ProjectResourceType,
+		Permission:    evergreen.PermissionTasks,
+		RequiredLevel: evergreen.TasksView.Value,
+	}
+	if !usr.HasPermission(requiredPermission) {
+		return nil, Forbidden.Send(ctx, fmt.Sprintf("user %s does not have permission to view tasks for the project %s", usr.Username(), projectID))
+	}
+	opts := model.TestHistoryOptions{
+		ProjectID:     projectID,
+		TaskName:      options.TaskName,
+		TestName:      options.TestName,
+		BuildVariants: options.BuildVariants,
+		NumVersions:   utility.FromIntPtr(options.NumVersions),
+		StartAt:       utility.FromIntPtr(options.StartAt),
+	}
+	if err = opts.Validate(); err != nil {
+		return nil, InputValidationError.Send(ctx, err.Error())
+	}
+
+	histories, err := model.GetTestHistory(ctx, evergreen.GetEnvironment(), opts)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("getting test history for task '%s': %s", options.TaskName, err.Error()))
+	}
+
+	apiHistories := []*restModel.APITestHistory{}
+	for _, history := range histories {
+		apiHistory := &restModel.APITestHistory{}
+		apiHistory.BuildFromService(history)
+		apiHistories = append(apiHistories, apiHistory)
+	}
+
+	return apiHistories, nil
+}
+
 // MyPublicKeys is the resolver for the myPublicKeys field.
 func (r *queryResolver) MyPublicKeys(ctx context.Context) ([]*restModel.APIPubKey, error) {
 	publicKeys := getMyPublicKeys(ctx)
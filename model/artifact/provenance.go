@@ -0,0 +1,288 @@
+package artifact
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	ProvenanceCollection = "artifact_provenance"
+
+	// InTotoStatementType is the type of an in-toto attestation statement.
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+	// SLSAProvenancePredicateType is the type of a SLSA provenance predicate.
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v1"
+	// ProvenanceBuildType describes how to interpret the build definition in
+	// provenance created for a task.
+	ProvenanceBuildType = "https://evergreen-ci.github.io/provenance/task/v1"
+	// InTotoPayloadType is the DSSE payload type of a signed in-toto
+	// statement.
+	InTotoPayloadType = "application/vnd.in-toto+json"
+
+	// DigestAlgorithmSHA256 is the name of the digest algorithm used for
+	// artifact subjects.
+	DigestAlgorithmSHA256 = "sha256"
+)
+
+// ProvenanceSubject is an artifact that provenance describes, identified by
+// its digest.
+type ProvenanceSubject struct {
+	Name   string            `json:"name" bson:"name"`
+	Digest map[string]string `json:"digest" bson:"digest"`
+}
+
+// ProvenanceStatement is an in-toto statement whose predicate is SLSA
+// provenance.
+type ProvenanceStatement struct {
+	Type          string              `json:"_type"`
+	Subject       []ProvenanceSubject `json:"subject"`
+	PredicateType string              `json:"predicateType"`
+	Predicate     ProvenancePredicate `json:"predicate"`
+}
+
+// ProvenancePredicate is a SLSA provenance predicate.
+type ProvenancePredicate struct {
+	BuildDefinition ProvenanceBuildDefinition `json:"buildDefinition"`
+	RunDetails      ProvenanceRunDetails      `json:"runDetails"`
+}
+
+type ProvenanceBuildDefinition struct {
+	BuildType            string                         `json:"buildType"`
+	ExternalParameters   ProvenanceExternalParams       `json:"externalParameters"`
+	InternalParameters   ProvenanceInternalParams       `json:"internalParameters"`
+	ResolvedDependencies []ProvenanceResolvedDependency `json:"resolvedDependencies,omitempty"`
+}
+
+// ProvenanceExternalParams are the inputs to the task that users control.
+type ProvenanceExternalParams struct {
+	Project      string `json:"project"`
+	Version      string `json:"version"`
+	Revision     string `json:"revision"`
+	BuildVariant string `json:"buildVariant"`
+	Task         string `json:"task"`
+	Requester    string `json:"requester"`
+}
+
+// ProvenanceInternalParams are the inputs to the task that Evergreen
+// controls.
+type ProvenanceInternalParams struct {
+	TaskID    string `json:"taskId"`
+	Execution int    `json:"execution"`
+	// ConfigHash is the SHA-256 digest of the project configuration that was
+	// resolved for the version.
+	ConfigHash string `json:"configHash"`
+}
+
+// ProvenanceResolvedDependency is an input to the task that is identified by
+// its digest, such as the source revision.
+type ProvenanceResolvedDependency struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+type ProvenanceRunDetails struct {
+	Builder  ProvenanceBuilder  `json:"builder"`
+	Metadata ProvenanceMetadata `json:"metadata"`
+}
+
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+type ProvenanceMetadata struct {
+	InvocationID string    `json:"invocationId"`
+	StartedOn    time.Time `json:"startedOn"`
+	FinishedOn   time.Time `json:"finishedOn"`
+}
+
+// ProvenanceTaskInfo is the information about the task that produced the
+// artifacts, as recorded by the app server.
+type ProvenanceTaskInfo struct {
+	TaskID       string
+	Execution    int
+	TaskName     string
+	Project      string
+	Version      string
+	Revision     string
+	BuildVariant string
+	Requester    string
+	ConfigHash   string
+	StartTime    time.Time
+	// SourceURI is the URI of the repository that the revision is from.
+	SourceURI string
+}
+
+// NewProvenanceStatement returns a SLSA provenance statement for the
+// artifacts produced by the task.
+func NewProvenanceStatement(builderID string, info ProvenanceTaskInfo, subjects []ProvenanceSubject, finishedOn time.Time) ProvenanceStatement {
+	var deps []ProvenanceResolvedDependency
+	if info.SourceURI != "" && info.Revision != "" {
+		deps = append(deps, ProvenanceResolvedDependency{
+			URI:    info.SourceURI,
+			Digest: map[string]string{"gitCommit": info.Revision},
+		})
+	}
+	return ProvenanceStatement{
+		Type:          InTotoStatementType,
+		Subject:       subjects,
+		PredicateType: SLSAProvenancePredicateType,
+		Predicate: ProvenancePredicate{
+			BuildDefinition: ProvenanceBuildDefinition{
+				BuildType: ProvenanceBuildType,
+				ExternalParameters: ProvenanceExternalParams{
+					Project:      info.Project,
+					Version:      info.Version,
+					Revision:     info.Revision,
+					BuildVariant: info.BuildVariant,
+					Task:         info.TaskName,
+					Requester:    info.Requester,
+				},
+				InternalParameters: ProvenanceInternalParams{
+					TaskID:     info.TaskID,
+					Execution:  info.Execution,
+					ConfigHash: info.ConfigHash,
+				},
+				ResolvedDependencies: deps,
+			},
+			RunDetails: ProvenanceRunDetails{
+				Builder: ProvenanceBuilder{ID: builderID},
+				Metadata: ProvenanceMetadata{
+					InvocationID: fmt.Sprintf("%s_%d", info.TaskID, info.Execution),
+					StartedOn:    info.StartTime.UTC(),
+					FinishedOn:   finishedOn.UTC(),
+				},
+			},
+		},
+	}
+}
+
+// ProvenanceEnvelope is a DSSE envelope holding a signed in-toto statement.
+// The payload is encoded as base64 in JSON, as the DSSE specification
+// requires.
+type ProvenanceEnvelope struct {
+	PayloadType string                `json:"payloadType" bson:"payload_type"`
+	Payload     []byte                `json:"payload" bson:"payload"`
+	Signatures  []ProvenanceSignature `json:"signatures" bson:"signatures"`
+}
+
+type ProvenanceSignature struct {
+	KeyID string `json:"keyid" bson:"keyid"`
+	Sig   []byte `json:"sig" bson:"sig"`
+}
+
+// preAuthEncoding returns the DSSE pre-authentication encoding of the
+// payload, which is what is actually signed.
+func preAuthEncoding(payloadType string, payload []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "DSSEv1 %d %s %d ", len(payloadType), payloadType, len(payload))
+	buf.Write(payload)
+	return buf.Bytes()
+}
+
+// SignProvenance signs the statement with the key and returns it in a DSSE
+// envelope.
+func SignProvenance(statement ProvenanceStatement, key ed25519.PrivateKey, keyID string) (*ProvenanceEnvelope, error) {
+	payload, err := json.Marshal(statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling provenance statement")
+	}
+	return &ProvenanceEnvelope{
+		PayloadType: InTotoPayloadType,
+		Payload:     payload,
+		Signatures: []ProvenanceSignature{{
+			KeyID: keyID,
+			Sig:   ed25519.Sign(key, preAuthEncoding(InTotoPayloadType, payload)),
+		}},
+	}, nil
+}
+
+// Verify checks that the envelope has a valid signature from the key with
+// the given ID.
+func (e *ProvenanceEnvelope) Verify(key ed25519.PublicKey, keyID string) error {
+	if e.PayloadType != InTotoPayloadType {
+		return errors.Errorf("unsupported payload type '%s'", e.PayloadType)
+	}
+	for _, sig := range e.Signatures {
+		if sig.KeyID != keyID {
+			continue
+		}
+		if ed25519.Verify(key, preAuthEncoding(e.PayloadType, e.Payload), sig.Sig) {
+			return nil
+		}
+		return errors.Errorf("signature from key '%s' is invalid", keyID)
+	}
+	return errors.Errorf("envelope has no signature from key '%s'", keyID)
+}
+
+// Statement decodes the statement in the envelope.
+func (e *ProvenanceEnvelope) Statement() (*ProvenanceStatement, error) {
+	statement := &ProvenanceStatement{}
+	if err := json.Unmarshal(e.Payload, statement); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling provenance statement")
+	}
+	return statement, nil
+}
+
+// Provenance is a signed provenance attestation for artifacts produced by a
+// task.
+type Provenance struct {
+	ID         string              `bson:"_id" json:"id"`
+	TaskID     string              `bson:"task_id" json:"task_id"`
+	ProjectID  string              `bson:"project_id" json:"project_id"`
+	Execution  int                 `bson:"execution" json:"execution"`
+	Subjects   []ProvenanceSubject `bson:"subjects" json:"subjects"`
+	Envelope   ProvenanceEnvelope  `bson:"envelope" json:"envelope"`
+	CreateTime time.Time           `bson:"create_time" json:"create_time"`
+}
+
+var (
+	ProvenanceIDKey         = bsonutil.MustHaveTag(Provenance{}, "ID")
+	ProvenanceTaskIDKey     = bsonutil.MustHaveTag(Provenance{}, "TaskID")
+	ProvenanceExecutionKey  = bsonutil.MustHaveTag(Provenance{}, "Execution")
+	ProvenanceSubjectsKey   = bsonutil.MustHaveTag(Provenance{}, "Subjects")
+	ProvenanceCreateTimeKey = bsonutil.MustHaveTag(Provenance{}, "CreateTime")
+
+	provenanceSubjectDigestKey = bsonutil.MustHaveTag(ProvenanceSubject{}, "Digest")
+)
+
+// ProvenanceSHA256DigestIndex supports finding provenance by the SHA-256
+// digest of one of its subjects.
+var ProvenanceSHA256DigestIndex = bson.D{
+	{Key: bsonutil.GetDottedKeyName(ProvenanceSubjectsKey, provenanceSubjectDigestKey, DigestAlgorithmSHA256), Value: 1},
+	{Key: ProvenanceCreateTimeKey, Value: -1},
+}
+
+// Insert stores the provenance.
+func (p *Provenance) Insert() error {
+	return db.Insert(ProvenanceCollection, p)
+}
+
+// FindProvenanceByDigest returns all provenance that describes an artifact
+// with the given digest, most recent first.
+func FindProvenanceByDigest(algorithm, digest string) ([]Provenance, error) {
+	out := []Provenance{}
+	q := db.Query(bson.M{
+		bsonutil.GetDottedKeyName(ProvenanceSubjectsKey, provenanceSubjectDigestKey, algorithm): digest,
+	}).Sort([]string{"-" + ProvenanceCreateTimeKey})
+	err := db.FindAllQ(ProvenanceCollection, q, &out)
+	return out, errors.Wrapf(err, "finding provenance for %s digest '%s'", algorithm, digest)
+}
+
+// FindProvenanceByTask returns all provenance created by the task execution.
+func FindProvenanceByTask(taskID string, execution int) ([]Provenance, error) {
+	out := []Provenance{}
+	q := db.Query(bson.M{
+		ProvenanceTaskIDKey:    taskID,
+		ProvenanceExecutionKey: execution,
+	}).Sort([]string{ProvenanceCreateTimeKey})
+	err := db.FindAllQ(ProvenanceCollection, q, &out)
+	return out, errors.Wrapf(err, "finding provenance for task '%s' execution %d", taskID, execution)
+}
@@ -0,0 +1,141 @@
+package artifact
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestProvenanceSigning(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	key := ed25519.NewKeyFromSeed(seed)
+	pub := key.Public().(ed25519.PublicKey)
+
+	info := ProvenanceTaskInfo{
+		TaskID:       "task",
+		Execution:    1,
+		TaskName:     "compile",
+		Project:      "evergreen",
+		Version:      "version",
+		Revision:     "abcdef",
+		BuildVariant: "ubuntu",
+		Requester:    "gitter_request",
+		ConfigHash:   "1234",
+		StartTime:    time.Now().Add(-time.Hour),
+		SourceURI:    "git+https://github.com/evergreen-ci/evergreen",
+	}
+	subjects := []ProvenanceSubject{{Name: "dist/evergreen", Digest: map[string]string{DigestAlgorithmSHA256: "deadbeef"}}}
+	statement := NewProvenanceStatement("https://evergreen.example.com", info, subjects, time.Now())
+
+	t.Run("StatementIncludesTaskInfo", func(t *testing.T) {
+		assert.Equal(t, InTotoStatementType, statement.Type)
+		assert.Equal(t, SLSAProvenancePredicateType, statement.PredicateType)
+		assert.Equal(t, subjects, statement.Subject)
+		params := statement.Predicate.BuildDefinition.ExternalParameters
+		assert.Equal(t, "evergreen", params.Project)
+		assert.Equal(t, "version", params.Version)
+		assert.Equal(t, "abcdef", params.Revision)
+		assert.Equal(t, "ubuntu", params.BuildVariant)
+		assert.Equal(t, "compile", params.Task)
+		assert.Equal(t, "1234", statement.Predicate.BuildDefinition.InternalParameters.ConfigHash)
+		require.Len(t, statement.Predicate.BuildDefinition.ResolvedDependencies, 1)
+		assert.Equal(t, "abcdef", statement.Predicate.BuildDefinition.ResolvedDependencies[0].Digest["gitCommit"])
+	})
+	t.Run("SignedEnvelopeVerifies", func(t *testing.T) {
+		env, err := SignProvenance(statement, key, "key")
+		require.NoError(t, err)
+		assert.NoError(t, env.Verify(pub, "key"))
+
+		decoded, err := env.Statement()
+		require.NoError(t, err)
+		assert.Equal(t, statement.Subject, decoded.Subject)
+		assert.Equal(t, statement.Predicate.BuildDefinition, decoded.Predicate.BuildDefinition)
+	})
+	t.Run("EnvelopeIsDSSEJSON", func(t *testing.T) {
+		env, err := SignProvenance(statement, key, "key")
+		require.NoError(t, err)
+		raw, err := json.Marshal(env)
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &fields))
+		assert.Equal(t, InTotoPayloadType, fields["payloadType"])
+		assert.IsType(t, "", fields["payload"], "payload should be base64-encoded")
+		assert.Len(t, fields["signatures"], 1)
+	})
+	t.Run("TamperedPayloadFailsVerification", func(t *testing.T) {
+		env, err := SignProvenance(statement, key, "key")
+		require.NoError(t, err)
+		tampered := statement
+		tampered.Subject = []ProvenanceSubject{{Name: "dist/evergreen", Digest: map[string]string{DigestAlgorithmSHA256: "cafef00d"}}}
+		env.Payload, err = json.Marshal(tampered)
+		require.NoError(t, err)
+		assert.Error(t, env.Verify(pub, "key"))
+	})
+	t.Run("OtherKeyFailsVerification", func(t *testing.T) {
+		env, err := SignProvenance(statement, key, "key")
+		require.NoError(t, err)
+		otherSeed := make([]byte, ed25519.SeedSize)
+		otherSeed[0] = 1
+		otherPub := ed25519.NewKeyFromSeed(otherSeed).Public().(ed25519.PublicKey)
+		assert.Error(t, env.Verify(otherPub, "key"))
+		assert.Error(t, env.Verify(pub, "other-key"))
+	})
+}
+
+func TestFindProvenance(t *testing.T) {
+	require.NoError(t, db.Clear(ProvenanceCollection))
+	require.NoError(t, db.EnsureIndex(ProvenanceCollection, mongo.IndexModel{Keys: ProvenanceSHA256DigestIndex}))
+	defer func() {
+		assert.NoError(t, db.Clear(ProvenanceCollection))
+	}()
+
+	now := time.Now().Round(time.Millisecond)
+	for _, p := range []Provenance{
+		{
+			ID:         "p1",
+			TaskID:     "t1",
+			Execution:  0,
+			Subjects:   []ProvenanceSubject{{Name: "a", Digest: map[string]string{DigestAlgorithmSHA256: "aaaa"}}},
+			CreateTime: now.Add(-time.Hour),
+		},
+		{
+			ID:        "p2",
+			TaskID:    "t1",
+			Execution: 1,
+			Subjects: []ProvenanceSubject{
+				{Name: "a", Digest: map[string]string{DigestAlgorithmSHA256: "aaaa"}},
+				{Name: "b", Digest: map[string]string{DigestAlgorithmSHA256: "bbbb"}},
+			},
+			CreateTime: now,
+		},
+	} {
+		require.NoError(t, p.Insert())
+	}
+
+	found, err := FindProvenanceByDigest(DigestAlgorithmSHA256, "aaaa")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "p2", found[0].ID, "most recent provenance should be first")
+	assert.Equal(t, "p1", found[1].ID)
+
+	found, err = FindProvenanceByDigest(DigestAlgorithmSHA256, "bbbb")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "p2", found[0].ID)
+
+	found, err = FindProvenanceByDigest(DigestAlgorithmSHA256, "cccc")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	found, err = FindProvenanceByTask("t1", 0)
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "p1", found[0].ID)
+}
@@ -45,6 +45,7 @@ var (
 	// HostAllocatorSettingsMinimumHostsKey           = bsonutil.MustHaveTag(HostAllocatorSettings{}, "MinimumHosts")
 	HostAllocatorSettingsMaximumHostsKey = bsonutil.MustHaveTag(HostAllocatorSettings{}, "MaximumHosts")
 	// HostAllocatorSettingsAcceptableHostIdleTimeKey = bsonutil.MustHaveTag(HostAllocatorSettings{}, "AcceptableHostIdleTime")
+	HostAllocatorSettingsWarmPoolKey = bsonutil.MustHaveTag(HostAllocatorSettings{}, "WarmPool")
 )
 
 var (
@@ -168,6 +169,14 @@ func ByIsDisabled(containerPools []evergreen.ContainerPool) db.Q {
 	})
 }
 
+// ByHasWarmPool returns a query that selects distros that have a warm pool
+// configured.
+func ByHasWarmPool() db.Q {
+	return db.Query(bson.M{
+		bsonutil.GetDottedKeyName(HostAllocatorSettingsKey, HostAllocatorSettingsWarmPoolKey): bson.M{"$exists": true},
+	})
+}
+
 // ByIds creates a query that finds all distros for the given ids and implicitly
 // returns them ordered by {"_id": 1}
 func ByIds(ids []string) db.Q {
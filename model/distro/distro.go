@@ -22,35 +22,36 @@ import (
 )
 
 type Distro struct {
-	Id                    string                `bson:"_id" json:"_id,omitempty" mapstructure:"_id,omitempty"`
-	Aliases               []string              `bson:"aliases,omitempty" json:"aliases,omitempty" mapstructure:"aliases,omitempty"`
-	Arch                  string                `bson:"arch" json:"arch,omitempty" mapstructure:"arch,omitempty"`
-	WorkDir               string                `bson:"work_dir" json:"work_dir,omitempty" mapstructure:"work_dir,omitempty"`
-	Provider              string                `bson:"provider" json:"provider,omitempty" mapstructure:"provider,omitempty"`
-	ProviderSettingsList  []*birch.Document     `bson:"provider_settings,omitempty" json:"provider_settings,omitempty" mapstructure:"provider_settings,omitempty"`
-	SetupAsSudo           bool                  `bson:"setup_as_sudo,omitempty" json:"setup_as_sudo,omitempty" mapstructure:"setup_as_sudo,omitempty"`
-	Setup                 string                `bson:"setup,omitempty" json:"setup,omitempty" mapstructure:"setup,omitempty"`
-	User                  string                `bson:"user,omitempty" json:"user,omitempty" mapstructure:"user,omitempty"`
-	BootstrapSettings     BootstrapSettings     `bson:"bootstrap_settings" json:"bootstrap_settings" mapstructure:"bootstrap_settings"`
-	CloneMethod           string                `bson:"clone_method" json:"clone_method,omitempty" mapstructure:"clone_method,omitempty"`
-	SSHKey                string                `bson:"ssh_key,omitempty" json:"ssh_key,omitempty" mapstructure:"ssh_key,omitempty"`
-	SSHOptions            []string              `bson:"ssh_options,omitempty" json:"ssh_options,omitempty" mapstructure:"ssh_options,omitempty"`
-	AuthorizedKeysFile    string                `bson:"authorized_keys_file,omitempty" json:"authorized_keys_file,omitempty" mapstructure:"authorized_keys_file,omitempty"`
-	SpawnAllowed          bool                  `bson:"spawn_allowed" json:"spawn_allowed,omitempty" mapstructure:"spawn_allowed,omitempty"`
-	Expansions            []Expansion           `bson:"expansions,omitempty" json:"expansions,omitempty" mapstructure:"expansions,omitempty"`
-	Disabled              bool                  `bson:"disabled,omitempty" json:"disabled,omitempty" mapstructure:"disabled,omitempty"`
-	ContainerPool         string                `bson:"container_pool,omitempty" json:"container_pool,omitempty" mapstructure:"container_pool,omitempty"`
-	FinderSettings        FinderSettings        `bson:"finder_settings" json:"finder_settings" mapstructure:"finder_settings"`
-	PlannerSettings       PlannerSettings       `bson:"planner_settings" json:"planner_settings" mapstructure:"planner_settings"`
-	DispatcherSettings    DispatcherSettings    `bson:"dispatcher_settings" json:"dispatcher_settings" mapstructure:"dispatcher_settings"`
-	HostAllocatorSettings HostAllocatorSettings `bson:"host_allocator_settings" json:"host_allocator_settings" mapstructure:"host_allocator_settings"`
-	DisableShallowClone   bool                  `bson:"disable_shallow_clone" json:"disable_shallow_clone" mapstructure:"disable_shallow_clone"`
-	Note                  string                `bson:"note" json:"note" mapstructure:"note"`
-	ValidProjects         []string              `bson:"valid_projects,omitempty" json:"valid_projects,omitempty" mapstructure:"valid_projects,omitempty"`
-	IsVirtualWorkstation  bool                  `bson:"is_virtual_workstation" json:"is_virtual_workstation" mapstructure:"is_virtual_workstation"`
-	IsCluster             bool                  `bson:"is_cluster" json:"is_cluster" mapstructure:"is_cluster"`
-	HomeVolumeSettings    HomeVolumeSettings    `bson:"home_volume_settings" json:"home_volume_settings" mapstructure:"home_volume_settings"`
-	IceCreamSettings      IceCreamSettings      `bson:"icecream_settings,omitempty" json:"icecream_settings,omitempty" mapstructure:"icecream_settings,omitempty"`
+	Id                    string                    `bson:"_id" json:"_id,omitempty" mapstructure:"_id,omitempty"`
+	Aliases               []string                  `bson:"aliases,omitempty" json:"aliases,omitempty" mapstructure:"aliases,omitempty"`
+	Arch                  string                    `bson:"arch" json:"arch,omitempty" mapstructure:"arch,omitempty"`
+	WorkDir               string                    `bson:"work_dir" json:"work_dir,omitempty" mapstructure:"work_dir,omitempty"`
+	Provider              string                    `bson:"provider" json:"provider,omitempty" mapstructure:"provider,omitempty"`
+	ProviderSettingsList  []*birch.Document         `bson:"provider_settings,omitempty" json:"provider_settings,omitempty" mapstructure:"provider_settings,omitempty"`
+	SetupAsSudo           bool                      `bson:"setup_as_sudo,omitempty" json:"setup_as_sudo,omitempty" mapstructure:"setup_as_sudo,omitempty"`
+	Setup                 string                    `bson:"setup,omitempty" json:"setup,omitempty" mapstructure:"setup,omitempty"`
+	User                  string                    `bson:"user,omitempty" json:"user,omitempty" mapstructure:"user,omitempty"`
+	BootstrapSettings     BootstrapSettings         `bson:"bootstrap_settings" json:"bootstrap_settings" mapstructure:"bootstrap_settings"`
+	CloneMethod           string                    `bson:"clone_method" json:"clone_method,omitempty" mapstructure:"clone_method,omitempty"`
+	SSHKey                string                    `bson:"ssh_key,omitempty" json:"ssh_key,omitempty" mapstructure:"ssh_key,omitempty"`
+	SSHOptions            []string                  `bson:"ssh_options,omitempty" json:"ssh_options,omitempty" mapstructure:"ssh_options,omitempty"`
+	AuthorizedKeysFile    string                    `bson:"authorized_keys_file,omitempty" json:"authorized_keys_file,omitempty" mapstructure:"authorized_keys_file,omitempty"`
+	SpawnAllowed          bool                      `bson:"spawn_allowed" json:"spawn_allowed,omitempty" mapstructure:"spawn_allowed,omitempty"`
+	Expansions            []Expansion               `bson:"expansions,omitempty" json:"expansions,omitempty" mapstructure:"expansions,omitempty"`
+	Disabled              bool                      `bson:"disabled,omitempty" json:"disabled,omitempty" mapstructure:"disabled,omitempty"`
+	ContainerPool         string                    `bson:"container_pool,omitempty" json:"container_pool,omitempty" mapstructure:"container_pool,omitempty"`
+	FinderSettings        FinderSettings            `bson:"finder_settings" json:"finder_settings" mapstructure:"finder_settings"`
+	PlannerSettings       PlannerSettings           `bson:"planner_settings" json:"planner_settings" mapstructure:"planner_settings"`
+	DispatcherSettings    DispatcherSettings        `bson:"dispatcher_settings" json:"dispatcher_settings" mapstructure:"dispatcher_settings"`
+	HostAllocatorSettings HostAllocatorSettings     `bson:"host_allocator_settings" json:"host_allocator_settings" mapstructure:"host_allocator_settings"`
+	DisableShallowClone   bool                      `bson:"disable_shallow_clone" json:"disable_shallow_clone" mapstructure:"disable_shallow_clone"`
+	Note                  string                    `bson:"note" json:"note" mapstructure:"note"`
+	ValidProjects         []string                  `bson:"valid_projects,omitempty" json:"valid_projects,omitempty" mapstructure:"valid_projects,omitempty"`
+	IsVirtualWorkstation  bool                      `bson:"is_virtual_workstation" json:"is_virtual_workstation" mapstructure:"is_virtual_workstation"`
+	IsCluster             bool                      `bson:"is_cluster" json:"is_cluster" mapstructure:"is_cluster"`
+	HomeVolumeSettings    HomeVolumeSettings        `bson:"home_volume_settings" json:"home_volume_settings" mapstructure:"home_volume_settings"`
+	IceCreamSettings      IceCreamSettings          `bson:"icecream_settings,omitempty" json:"icecream_settings,omitempty" mapstructure:"icecream_settings,omitempty"`
+	SpawnHostAutoStop     SpawnHostAutoStopSettings `bson:"spawn_host_auto_stop,omitempty" json:"spawn_host_auto_stop,omitempty" mapstructure:"spawn_host_auto_stop,omitempty"`
 }
 
 // DistroData is the same as a distro, with the only difference being that all
@@ -137,6 +138,31 @@ type IceCreamSettings struct {
 	ConfigPath    string `bson:"config_path,omitempty" json:"config_path,omitempty" mapstructure:"config_path,omitempty"`
 }
 
+// SpawnHostAutoStopSettings configure when the distro's spawn hosts are
+// automatically stopped because nobody is using them.
+type SpawnHostAutoStopSettings struct {
+	// IdleTimeout is the longest a spawn host can be idle before it is
+	// stopped. Users can choose a shorter idle timeout for their own hosts. If
+	// zero, spawn hosts are only stopped if their owner has set an idle
+	// timeout.
+	IdleTimeout time.Duration `bson:"idle_timeout,omitempty" json:"idle_timeout,omitempty" mapstructure:"idle_timeout,omitempty"`
+	// LoadThreshold is the one-minute load average at or above which a spawn
+	// host is considered busy. If zero, DefaultSpawnHostLoadThreshold is used.
+	LoadThreshold float64 `bson:"load_threshold,omitempty" json:"load_threshold,omitempty" mapstructure:"load_threshold,omitempty"`
+}
+
+// DefaultSpawnHostLoadThreshold is the default one-minute load average at or
+// above which a spawn host is considered busy.
+const DefaultSpawnHostLoadThreshold = 0.5
+
+// GetLoadThreshold returns the load threshold, or the default if it is unset.
+func (s *SpawnHostAutoStopSettings) GetLoadThreshold() float64 {
+	if s.LoadThreshold <= 0 {
+		return DefaultSpawnHostLoadThreshold
+	}
+	return s.LoadThreshold
+}
+
 // WriteConfigScript returns the shell script to update the icecream config
 // file.
 func (s IceCreamSettings) GetUpdateConfigScript() string {
@@ -237,8 +263,24 @@ type HostAllocatorSettings struct {
 	// AcceptableHostIdleTime is the amount of time we wait for an idle host to be marked as idle.
 	AcceptableHostIdleTime time.Duration `bson:"acceptable_host_idle_time" json:"acceptable_host_idle_time" mapstructure:"acceptable_host_idle_time"`
 	FutureHostFraction     float64       `bson:"future_host_fraction" json:"future_host_fraction" mapstructure:"future_host_fraction"`
+	// WarmPool is the pool of free hosts kept ready for new tasks.
+	WarmPool WarmPoolSettings `bson:"warm_pool,omitempty" json:"warm_pool,omitempty" mapstructure:"warm_pool,omitempty"`
+	// ForecastWindow is how far ahead the predictive host allocator forecasts
+	// demand for hosts. If zero, a default window is used.
+	ForecastWindow time.Duration `bson:"forecast_window,omitempty" json:"forecast_window,omitempty" mapstructure:"forecast_window,omitempty"`
+	// ForecastDryRun makes the predictive host allocator log the hosts it
+	// would have requested for the forecast without requesting them.
+	ForecastDryRun bool `bson:"forecast_dry_run,omitempty" json:"forecast_dry_run,omitempty" mapstructure:"forecast_dry_run,omitempty"`
 }
 
+const (
+	// DefaultForecastWindow is how far ahead the predictive host allocator
+	// forecasts demand if the distro does not set a forecast window.
+	DefaultForecastWindow = 30 * time.Minute
+	// MaxForecastWindow is the longest forecast window a distro can set.
+	MaxForecastWindow = 24 * time.Hour
+)
+
 type FinderSettings struct {
 	Version string `bson:"version" json:"version" mapstructure:"version"`
 }
@@ -644,6 +686,9 @@ func (d *Distro) GetResolvedHostAllocatorSettings(s *evergreen.Settings) (HostAl
 		FeedbackRule:           has.FeedbackRule,
 		HostsOverallocatedRule: has.HostsOverallocatedRule,
 		FutureHostFraction:     has.FutureHostFraction,
+		WarmPool:               has.WarmPool,
+		ForecastWindow:         has.ForecastWindow,
+		ForecastDryRun:         has.ForecastDryRun,
 	}
 
 	catcher := grip.NewBasicCatcher()
@@ -0,0 +1,128 @@
+package distro
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+)
+
+// WarmPoolSettings configure a pool of provisioned hosts that are kept free
+// so that tasks do not have to wait for new hosts to start up.
+type WarmPoolSettings struct {
+	// MinReadyHosts is the number of free hosts to keep ready at all times.
+	MinReadyHosts int `bson:"min_ready_hosts,omitempty" json:"min_ready_hosts,omitempty" mapstructure:"min_ready_hosts,omitempty"`
+	// Schedule raises the number of free hosts to keep ready during certain
+	// times of the week, such as business hours.
+	Schedule []WarmPoolScheduleEntry `bson:"schedule,omitempty" json:"schedule,omitempty" mapstructure:"schedule,omitempty"`
+	// TimeZone is the name of the time zone in which the schedule is
+	// interpreted. If empty, the schedule is in UTC.
+	TimeZone string `bson:"time_zone,omitempty" json:"time_zone,omitempty" mapstructure:"time_zone,omitempty"`
+}
+
+// WarmPoolScheduleEntry is a recurring weekly window during which a distro
+// keeps a given number of free hosts ready.
+type WarmPoolScheduleEntry struct {
+	// Weekdays are the days of the week on which the window applies. If
+	// empty, it applies every day.
+	Weekdays []time.Weekday `bson:"weekdays,omitempty" json:"weekdays,omitempty" mapstructure:"weekdays,omitempty"`
+	// StartTime is the time of day in the format HH:MM at which the window
+	// begins.
+	StartTime string `bson:"start_time" json:"start_time" mapstructure:"start_time"`
+	// EndTime is the time of day in the format HH:MM at which the window
+	// ends. It must be after the start time.
+	EndTime string `bson:"end_time" json:"end_time" mapstructure:"end_time"`
+	// ReadyHosts is the number of free hosts to keep ready during the window.
+	ReadyHosts int `bson:"ready_hosts" json:"ready_hosts" mapstructure:"ready_hosts"`
+}
+
+// IsZero returns whether the distro has no warm pool.
+func (s *WarmPoolSettings) IsZero() bool {
+	return s.MinReadyHosts == 0 && len(s.Schedule) == 0
+}
+
+// TargetReadyHosts returns the number of free hosts that the distro should
+// keep ready at the given time.
+func (s *WarmPoolSettings) TargetReadyHosts(t time.Time) int {
+	target := s.MinReadyHosts
+	if len(s.Schedule) == 0 {
+		return target
+	}
+
+	loc := time.UTC
+	if s.TimeZone != "" {
+		if tz, err := time.LoadLocation(s.TimeZone); err == nil {
+			loc = tz
+		}
+	}
+	local := t.In(loc)
+	for _, entry := range s.Schedule {
+		if entry.ReadyHosts > target && entry.contains(local) {
+			target = entry.ReadyHosts
+		}
+	}
+
+	return target
+}
+
+// Validate checks that the warm pool settings are valid. The warm pool cannot
+// be larger than the maximum number of hosts in the distro.
+func (s *WarmPoolSettings) Validate(maxHosts int) error {
+	catcher := grip.NewBasicCatcher()
+	catcher.ErrorfWhen(s.MinReadyHosts < 0, "minimum ready hosts cannot be negative")
+	catcher.ErrorfWhen(s.MinReadyHosts > maxHosts, "minimum ready hosts %d cannot exceed the maximum number of hosts %d", s.MinReadyHosts, maxHosts)
+	if s.TimeZone != "" {
+		_, err := time.LoadLocation(s.TimeZone)
+		catcher.Wrapf(err, "invalid time zone '%s'", s.TimeZone)
+	}
+
+	for i, entry := range s.Schedule {
+		catcher.Wrapf(entry.validate(maxHosts), "invalid schedule entry %d", i)
+	}
+
+	return catcher.Resolve()
+}
+
+func (e *WarmPoolScheduleEntry) validate(maxHosts int) error {
+	catcher := grip.NewBasicCatcher()
+	catcher.ErrorfWhen(e.ReadyHosts < 0, "ready hosts cannot be negative")
+	catcher.ErrorfWhen(e.ReadyHosts > maxHosts, "ready hosts %d cannot exceed the maximum number of hosts %d", e.ReadyHosts, maxHosts)
+	for _, d := range e.Weekdays {
+		catcher.ErrorfWhen(d < time.Sunday || d > time.Saturday, "invalid weekday %d", d)
+	}
+
+	start, err := util.ParseTimeOfDay(e.StartTime)
+	catcher.Wrap(err, "invalid start time")
+	end, err := util.ParseTimeOfDay(e.EndTime)
+	catcher.Wrap(err, "invalid end time")
+	catcher.NewWhen(!catcher.HasErrors() && end <= start, "end time must be after start time")
+
+	return catcher.Resolve()
+}
+
+// contains returns whether the given local time falls within the window.
+func (e *WarmPoolScheduleEntry) contains(local time.Time) bool {
+	if len(e.Weekdays) != 0 {
+		var matchesDay bool
+		for _, d := range e.Weekdays {
+			if local.Weekday() == d {
+				matchesDay = true
+				break
+			}
+		}
+		if !matchesDay {
+			return false
+		}
+	}
+
+	start, err := util.ParseTimeOfDay(e.StartTime)
+	if err != nil {
+		return false
+	}
+	end, err := util.ParseTimeOfDay(e.EndTime)
+	if err != nil {
+		return false
+	}
+	now := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	return now >= start && now < end
+}
@@ -0,0 +1,114 @@
+package distro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarmPoolTargetReadyHosts(t *testing.T) {
+	// Monday, January 6, 2025.
+	monday := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	at := func(daysAfterMonday int, hour, min int) time.Time {
+		return monday.AddDate(0, 0, daysAfterMonday).Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+
+	t.Run("ZeroSettings", func(t *testing.T) {
+		s := WarmPoolSettings{}
+		assert.True(t, s.IsZero())
+		assert.Zero(t, s.TargetReadyHosts(at(0, 12, 0)))
+	})
+	t.Run("MinReadyHostsOnly", func(t *testing.T) {
+		s := WarmPoolSettings{MinReadyHosts: 2}
+		assert.False(t, s.IsZero())
+		assert.Equal(t, 2, s.TargetReadyHosts(at(0, 12, 0)))
+		assert.Equal(t, 2, s.TargetReadyHosts(at(6, 3, 0)))
+	})
+	t.Run("BusinessHoursSchedule", func(t *testing.T) {
+		s := WarmPoolSettings{
+			MinReadyHosts: 1,
+			Schedule: []WarmPoolScheduleEntry{{
+				Weekdays:   []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+				StartTime:  "09:00",
+				EndTime:    "17:00",
+				ReadyHosts: 5,
+			}},
+		}
+		assert.Equal(t, 1, s.TargetReadyHosts(at(0, 8, 59)))
+		assert.Equal(t, 5, s.TargetReadyHosts(at(0, 9, 0)))
+		assert.Equal(t, 5, s.TargetReadyHosts(at(4, 16, 59)))
+		assert.Equal(t, 1, s.TargetReadyHosts(at(4, 17, 0)))
+		assert.Equal(t, 1, s.TargetReadyHosts(at(5, 12, 0)))
+	})
+	t.Run("UsesLargestMatchingEntry", func(t *testing.T) {
+		s := WarmPoolSettings{
+			Schedule: []WarmPoolScheduleEntry{
+				{StartTime: "08:00", EndTime: "18:00", ReadyHosts: 2},
+				{StartTime: "10:00", EndTime: "12:00", ReadyHosts: 4},
+			},
+		}
+		assert.Equal(t, 2, s.TargetReadyHosts(at(0, 9, 0)))
+		assert.Equal(t, 4, s.TargetReadyHosts(at(0, 11, 0)))
+		assert.Zero(t, s.TargetReadyHosts(at(0, 20, 0)))
+	})
+	t.Run("UsesTimeZone", func(t *testing.T) {
+		s := WarmPoolSettings{
+			TimeZone: "America/New_York",
+			Schedule: []WarmPoolScheduleEntry{{StartTime: "09:00", EndTime: "17:00", ReadyHosts: 3}},
+		}
+		// 09:00 UTC is 04:00 in New York.
+		assert.Zero(t, s.TargetReadyHosts(at(0, 9, 0)))
+		// 15:00 UTC is 10:00 in New York.
+		assert.Equal(t, 3, s.TargetReadyHosts(at(0, 15, 0)))
+	})
+}
+
+func TestWarmPoolSettingsValidate(t *testing.T) {
+	t.Run("SucceedsForZeroSettings", func(t *testing.T) {
+		s := WarmPoolSettings{}
+		assert.NoError(t, s.Validate(0))
+	})
+	t.Run("SucceedsForValidSettings", func(t *testing.T) {
+		s := WarmPoolSettings{
+			MinReadyHosts: 1,
+			TimeZone:      "America/New_York",
+			Schedule: []WarmPoolScheduleEntry{{
+				Weekdays:   []time.Weekday{time.Monday},
+				StartTime:  "09:00",
+				EndTime:    "17:00",
+				ReadyHosts: 5,
+			}},
+		}
+		require.NoError(t, s.Validate(10))
+	})
+	t.Run("FailsForNegativeMinReadyHosts", func(t *testing.T) {
+		s := WarmPoolSettings{MinReadyHosts: -1}
+		assert.Error(t, s.Validate(10))
+	})
+	t.Run("FailsForMinReadyHostsAboveMaximumHosts", func(t *testing.T) {
+		s := WarmPoolSettings{MinReadyHosts: 11}
+		assert.Error(t, s.Validate(10))
+	})
+	t.Run("FailsForInvalidTimeZone", func(t *testing.T) {
+		s := WarmPoolSettings{MinReadyHosts: 1, TimeZone: "Nowhere/Special"}
+		assert.Error(t, s.Validate(10))
+	})
+	t.Run("FailsForReadyHostsAboveMaximumHosts", func(t *testing.T) {
+		s := WarmPoolSettings{Schedule: []WarmPoolScheduleEntry{{StartTime: "09:00", EndTime: "17:00", ReadyHosts: 11}}}
+		assert.Error(t, s.Validate(10))
+	})
+	t.Run("FailsForInvalidWeekday", func(t *testing.T) {
+		s := WarmPoolSettings{Schedule: []WarmPoolScheduleEntry{{Weekdays: []time.Weekday{-1}, StartTime: "09:00", EndTime: "17:00", ReadyHosts: 1}}}
+		assert.Error(t, s.Validate(10))
+	})
+	t.Run("FailsForMalformedTime", func(t *testing.T) {
+		s := WarmPoolSettings{Schedule: []WarmPoolScheduleEntry{{StartTime: "9am", EndTime: "17:00", ReadyHosts: 1}}}
+		assert.Error(t, s.Validate(10))
+	})
+	t.Run("FailsForEndTimeBeforeStartTime", func(t *testing.T) {
+		s := WarmPoolSettings{Schedule: []WarmPoolScheduleEntry{{StartTime: "17:00", EndTime: "09:00", ReadyHosts: 1}}}
+		assert.Error(t, s.Validate(10))
+	})
+}
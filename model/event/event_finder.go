@@ -142,6 +142,20 @@ func MostRecentPaginatedHostEvents(id string, tag string, limit, page int) ([]Ev
 	return FindPaginatedWithTotalCount(recentHostsQuery, limit, page)
 }
 
+// HostTaskEnvironmentEvents returns the events for each step of loading a
+// task's environment onto the given spawn host, in the order they happened.
+func HostTaskEnvironmentEvents(id string, tag string) db.Q {
+	filter := ResourceTypeKeyIs(ResourceTypeHost)
+	if tag != "" {
+		filter[ResourceIdKey] = bson.M{"$in": []string{id, tag}}
+	} else {
+		filter[ResourceIdKey] = id
+	}
+	filter[TypeKey] = EventHostTaskEnvironmentStep
+
+	return db.Query(filter).Sort([]string{TimestampKey})
+}
+
 // Task Events
 func TaskEventsForId(id string) db.Q {
 	filter := ResourceTypeKeyIs(ResourceTypeTask)
@@ -0,0 +1,249 @@
+package event
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/pail"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// DefaultExportLimit is the default maximum number of events returned by
+	// a single export.
+	DefaultExportLimit = 1000
+	// MaxExportLimit is the maximum number of events that can be requested in
+	// a single export.
+	MaxExportLimit = 10000
+
+	archiveTimeFormat = "20060102T150405.000Z"
+)
+
+// ExportedEvent is the representation of an event in exports and archives.
+// Unlike EventLogEntry, it includes the event ID.
+type ExportedEvent struct {
+	ID           string      `json:"id"`
+	ResourceType string      `json:"resource_type"`
+	ResourceId   string      `json:"resource_id"`
+	EventType    string      `json:"event_type"`
+	Timestamp    time.Time   `json:"timestamp"`
+	ProcessedAt  time.Time   `json:"processed_at"`
+	Data         interface{} `json:"data"`
+}
+
+// Export returns the exported representation of the event.
+func (e *EventLogEntry) Export() ExportedEvent {
+	return ExportedEvent{
+		ID:           e.ID,
+		ResourceType: e.ResourceType,
+		ResourceId:   e.ResourceId,
+		EventType:    e.EventType,
+		Timestamp:    e.Timestamp,
+		ProcessedAt:  e.ProcessedAt,
+		Data:         e.Data,
+	}
+}
+
+// ExportOptions filter the events to export.
+type ExportOptions struct {
+	// ResourceTypes and ResourceIDs optionally restrict the export to events
+	// for the given resource types and resources.
+	ResourceTypes []string
+	ResourceIDs   []string
+	// StartTime is the inclusive lower bound of the event timestamps.
+	StartTime time.Time
+	// EndTime is the exclusive upper bound of the event timestamps.
+	EndTime time.Time
+	Limit   int
+}
+
+// Validate checks that the export options are valid and sets defaults.
+func (o *ExportOptions) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(utility.IsZeroTime(o.EndTime), "must specify an end time")
+	catcher.NewWhen(!o.EndTime.After(o.StartTime), "end time must be after start time")
+	catcher.NewWhen(o.Limit < 0, "limit cannot be negative")
+	catcher.ErrorfWhen(o.Limit > MaxExportLimit, "limit cannot exceed %d", MaxExportLimit)
+	if o.Limit == 0 {
+		o.Limit = DefaultExportLimit
+	}
+	return catcher.Resolve()
+}
+
+func (o *ExportOptions) filter() bson.M {
+	filter := bson.M{
+		TimestampKey: bson.M{
+			"$gte": o.StartTime,
+			"$lt":  o.EndTime,
+		},
+	}
+	if len(o.ResourceTypes) > 0 {
+		filter[ResourceTypeKey] = bson.M{"$in": o.ResourceTypes}
+	}
+	if len(o.ResourceIDs) > 0 {
+		filter[ResourceIdKey] = bson.M{"$in": o.ResourceIDs}
+	}
+	return filter
+}
+
+// FindForExport returns the events matching the options in ascending
+// timestamp order, along with the start time to use to fetch the next page of
+// events. The next start time is zero if there are no more events. Events
+// with the same timestamp are never split across pages, so a page may
+// contain fewer events than the limit, or more if more than the limit share
+// a timestamp.
+func FindForExport(opts ExportOptions) ([]EventLogEntry, time.Time, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "invalid export options")
+	}
+
+	events, err := Find(db.Query(opts.filter()).Sort([]string{TimestampKey}).Limit(opts.Limit))
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "finding events")
+	}
+	if len(events) < opts.Limit {
+		return events, time.Time{}, nil
+	}
+
+	// Drop the events sharing the last timestamp so that the next page can
+	// start from it without returning duplicates.
+	last := events[len(events)-1].Timestamp
+	i := len(events)
+	for i > 0 && events[i-1].Timestamp.Equal(last) {
+		i--
+	}
+	if i > 0 {
+		return events[:i], last, nil
+	}
+
+	// Every event in the page has the same timestamp, so return all of the
+	// events at that timestamp.
+	sameTimestamp := opts
+	sameTimestamp.StartTime = last
+	filter := sameTimestamp.filter()
+	filter[TimestampKey] = last
+	events, err = Find(db.Query(filter))
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "finding events with the same timestamp")
+	}
+	// Stored timestamps have millisecond precision.
+	next := last.Add(time.Millisecond)
+	if !next.Before(opts.EndTime) {
+		next = time.Time{}
+	}
+
+	return events, next, nil
+}
+
+// WriteNDJSON writes the events to the writer as newline-delimited JSON.
+func WriteNDJSON(w io.Writer, events []EventLogEntry) error {
+	enc := json.NewEncoder(w)
+	for i := range events {
+		if err := enc.Encode(events[i].Export()); err != nil {
+			return errors.Wrapf(err, "encoding event '%s'", events[i].ID)
+		}
+	}
+	return nil
+}
+
+// NewArchiveBucket returns the bucket that expiring events are archived to.
+func NewArchiveBucket(ctx context.Context, conf evergreen.EventRetentionConfig) (pail.Bucket, error) {
+	switch conf.BucketType {
+	case evergreen.EventArchiveBucketTypeLocal:
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{
+			Path:   conf.Bucket,
+			Prefix: conf.Prefix,
+		})
+		return bucket, errors.Wrap(err, "creating local event archive bucket")
+	case evergreen.EventArchiveBucketTypeS3, "":
+		opts := pail.S3Options{
+			Name:   conf.Bucket,
+			Prefix: conf.Prefix,
+			Region: evergreen.DefaultEC2Region,
+		}
+		if conf.Key != "" && conf.Secret != "" {
+			opts.Credentials = pail.CreateAWSCredentials(conf.Key, conf.Secret, "")
+		}
+		bucket, err := pail.NewS3Bucket(opts)
+		return bucket, errors.Wrap(err, "creating S3 event archive bucket")
+	default:
+		return nil, errors.Errorf("unrecognized event archive bucket type '%s'", conf.BucketType)
+	}
+}
+
+// ArchiveExpiredEvents writes the events for the resource type that are older
+// than the given time to the bucket as gzipped NDJSON files of at most
+// roughly batchSize events and then removes them from the database. It
+// returns the number of events archived.
+func ArchiveExpiredEvents(ctx context.Context, bucket pail.Bucket, resourceType string, before time.Time, batchSize int) (int, error) {
+	var archived int
+	for {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+
+		opts := ExportOptions{
+			ResourceTypes: []string{resourceType},
+			EndTime:       before,
+			Limit:         batchSize,
+		}
+		events, next, err := FindForExport(opts)
+		if err != nil {
+			return archived, errors.Wrap(err, "finding expired events")
+		}
+		if len(events) == 0 {
+			return archived, nil
+		}
+
+		if err = archiveEvents(ctx, bucket, resourceType, events); err != nil {
+			return archived, err
+		}
+
+		// The page contains every event for the resource type up to the next
+		// start time, so they can be removed by timestamp.
+		removeBefore := next
+		if utility.IsZeroTime(removeBefore) {
+			removeBefore = before
+		}
+		if err = db.RemoveAll(EventCollection, bson.M{
+			ResourceTypeKey: resourceType,
+			TimestampKey:    bson.M{"$lt": removeBefore},
+		}); err != nil {
+			return archived, errors.Wrap(err, "removing archived events")
+		}
+		archived += len(events)
+
+		if utility.IsZeroTime(next) {
+			return archived, nil
+		}
+	}
+}
+
+func archiveEvents(ctx context.Context, bucket pail.Bucket, resourceType string, events []EventLogEntry) error {
+	first := events[0]
+	last := events[len(events)-1]
+	key := fmt.Sprintf("%s/%s_%s_%s.ndjson.gz", resourceType,
+		first.Timestamp.UTC().Format(archiveTimeFormat), last.Timestamp.UTC().Format(archiveTimeFormat), last.ID)
+
+	w, err := bucket.Writer(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "opening archive file '%s'", key)
+	}
+	gz := gzip.NewWriter(w)
+
+	catcher := grip.NewBasicCatcher()
+	catcher.Wrap(WriteNDJSON(gz, events), "writing events")
+	catcher.Wrap(gz.Close(), "closing gzip writer")
+	catcher.Wrapf(w.Close(), "closing archive file '%s'", key)
+
+	return catcher.Resolve()
+}
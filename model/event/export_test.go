@@ -0,0 +1,182 @@
+package event
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindForExport(t *testing.T) {
+	require.NoError(t, db.ClearCollections(EventCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(EventCollection))
+	}()
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	for i := 0; i < 5; i++ {
+		e := EventLogEntry{
+			ResourceType: ResourceTypeHost,
+			ResourceId:   fmt.Sprintf("h%d", i%2),
+			EventType:    EventHostCreated,
+			Timestamp:    start.Add(time.Duration(i) * time.Minute),
+			Data:         &HostEventData{},
+		}
+		require.NoError(t, e.Log())
+	}
+	// Two events share a timestamp so pages must not split them.
+	e := EventLogEntry{
+		ResourceType: ResourceTypeHost,
+		ResourceId:   "h0",
+		EventType:    EventHostStarted,
+		Timestamp:    start.Add(2 * time.Minute),
+		Data:         &HostEventData{},
+	}
+	require.NoError(t, e.Log())
+	admin := EventLogEntry{
+		ResourceType: ResourceTypeAdmin,
+		EventType:    EventTypeValueChanged,
+		Timestamp:    start,
+		Data:         &AdminEventData{Section: "tracer"},
+	}
+	require.NoError(t, admin.Log())
+
+	t.Run("ReturnsAllMatchingEvents", func(t *testing.T) {
+		events, next, err := FindForExport(ExportOptions{
+			ResourceTypes: []string{ResourceTypeHost},
+			EndTime:       time.Now(),
+		})
+		require.NoError(t, err)
+		assert.Len(t, events, 6)
+		assert.True(t, next.IsZero())
+		for i := 1; i < len(events); i++ {
+			assert.False(t, events[i].Timestamp.Before(events[i-1].Timestamp))
+		}
+	})
+	t.Run("FiltersByResourceID", func(t *testing.T) {
+		events, _, err := FindForExport(ExportOptions{
+			ResourceIDs: []string{"h1"},
+			EndTime:     time.Now(),
+		})
+		require.NoError(t, err)
+		assert.Len(t, events, 2)
+	})
+	t.Run("FiltersByTimeRange", func(t *testing.T) {
+		events, _, err := FindForExport(ExportOptions{
+			StartTime: start.Add(time.Minute),
+			EndTime:   start.Add(3 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Len(t, events, 3)
+	})
+	t.Run("PaginatesWithoutSplittingTimestamps", func(t *testing.T) {
+		opts := ExportOptions{
+			ResourceTypes: []string{ResourceTypeHost},
+			EndTime:       time.Now(),
+			Limit:         3,
+		}
+		seen := map[string]bool{}
+		for {
+			events, next, err := FindForExport(opts)
+			require.NoError(t, err)
+			for _, e := range events {
+				assert.False(t, seen[e.ID], "event '%s' should not be returned twice", e.ID)
+				seen[e.ID] = true
+			}
+			if next.IsZero() {
+				break
+			}
+			opts.StartTime = next
+		}
+		assert.Len(t, seen, 6)
+	})
+	t.Run("FailsWithInvalidOptions", func(t *testing.T) {
+		_, _, err := FindForExport(ExportOptions{EndTime: start, StartTime: start.Add(time.Hour)})
+		assert.Error(t, err)
+		_, _, err = FindForExport(ExportOptions{EndTime: time.Now(), Limit: MaxExportLimit + 1})
+		assert.Error(t, err)
+	})
+}
+
+func TestArchiveExpiredEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(EventCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(EventCollection))
+	}()
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		e := EventLogEntry{
+			ResourceType: ResourceTypeHost,
+			ResourceId:   "h",
+			EventType:    EventHostCreated,
+			Timestamp:    now.Add(-time.Duration(i) * 24 * time.Hour),
+			Data:         &HostEventData{},
+		}
+		require.NoError(t, e.Log())
+	}
+	admin := EventLogEntry{
+		ResourceType: ResourceTypeAdmin,
+		EventType:    EventTypeValueChanged,
+		Timestamp:    now.Add(-30 * 24 * time.Hour),
+		Data:         &AdminEventData{Section: "tracer"},
+	}
+	require.NoError(t, admin.Log())
+
+	bucket, err := NewArchiveBucket(ctx, evergreen.EventRetentionConfig{
+		BucketType: evergreen.EventArchiveBucketTypeLocal,
+		Bucket:     t.TempDir(),
+		Prefix:     "events",
+	})
+	require.NoError(t, err)
+
+	archived, err := ArchiveExpiredEvents(ctx, bucket, ResourceTypeHost, now.Add(-5*24*time.Hour+time.Minute), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, archived)
+
+	remaining, err := Find(db.Query(ResourceTypeKeyIs(ResourceTypeHost)))
+	require.NoError(t, err)
+	assert.Len(t, remaining, 5)
+	for _, e := range remaining {
+		assert.True(t, e.Timestamp.After(now.Add(-5*24*time.Hour)))
+	}
+	adminEvents, err := Find(db.Query(ResourceTypeKeyIs(ResourceTypeAdmin)))
+	require.NoError(t, err)
+	assert.Len(t, adminEvents, 1, "events of other resource types should not be archived")
+
+	iter, err := bucket.List(ctx, ResourceTypeHost)
+	require.NoError(t, err)
+	var numFiles, numArchived int
+	for iter.Next(ctx) {
+		numFiles++
+		r, err := iter.Item().Get(ctx)
+		require.NoError(t, err)
+		gz, err := gzip.NewReader(r)
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(gz)
+		for scanner.Scan() {
+			exported := ExportedEvent{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &exported))
+			assert.NotEmpty(t, exported.ID)
+			assert.Equal(t, ResourceTypeHost, exported.ResourceType)
+			numArchived++
+		}
+		require.NoError(t, scanner.Err())
+		assert.NoError(t, gz.Close())
+		assert.NoError(t, r.Close())
+	}
+	require.NoError(t, iter.Err())
+	assert.Equal(t, 3, numFiles)
+	assert.Equal(t, 5, numArchived)
+}
@@ -13,6 +13,7 @@ import (
 func init() {
 	registry.AddType(ResourceTypeHost, func() interface{} { return &HostEventData{} })
 	registry.AllowSubscription(ResourceTypeHost, EventHostExpirationWarningSent)
+	registry.AllowSubscription(ResourceTypeHost, EventHostIdleStopWarningSent)
 	registry.AllowSubscription(ResourceTypeHost, EventVolumeExpirationWarningSent)
 	registry.AllowSubscription(ResourceTypeHost, EventHostProvisioned)
 	registry.AllowSubscription(ResourceTypeHost, EventHostProvisionFailed)
@@ -52,8 +53,10 @@ const (
 	EventHostTaskFinished                = "HOST_TASK_FINISHED"
 	EventHostTerminatedExternally        = "HOST_TERMINATED_EXTERNALLY"
 	EventHostExpirationWarningSent       = "HOST_EXPIRATION_WARNING_SENT"
+	EventHostIdleStopWarningSent         = "HOST_IDLE_STOP_WARNING_SENT"
 	EventHostScriptExecuted              = "HOST_SCRIPT_EXECUTED"
 	EventHostScriptExecuteFailed         = "HOST_SCRIPT_EXECUTE_FAILED"
+	EventHostTaskEnvironmentStep         = "HOST_TASK_ENVIRONMENT_STEP"
 	EventVolumeExpirationWarningSent     = "VOLUME_EXPIRATION_WARNING_SENT"
 	EventVolumeMigrationFailed           = "VOLUME_MIGRATION_FAILED"
 )
@@ -68,6 +71,7 @@ type HostEventData struct {
 	Logs               string        `bson:"log,omitempty" json:"logs,omitempty"`
 	Hostname           string        `bson:"hn,omitempty" json:"hostname,omitempty"`
 	ProvisioningMethod string        `bson:"prov_method" json:"provisioning_method,omitempty"`
+	ProvisioningStep   string        `bson:"prov_step,omitempty" json:"provisioning_step,omitempty"`
 	TaskId             string        `bson:"t_id,omitempty" json:"task_id,omitempty"`
 	TaskPid            string        `bson:"t_pid,omitempty" json:"task_pid,omitempty"`
 	TaskStatus         string        `bson:"t_st,omitempty" json:"task_status,omitempty"`
@@ -248,6 +252,12 @@ func LogSpawnhostExpirationWarningSent(hostID string) {
 	LogHostEvent(hostID, EventHostExpirationWarningSent, HostEventData{})
 }
 
+// LogSpawnHostIdleStopWarningSent is used when the owner of an idle spawn host
+// is warned that the host will be stopped.
+func LogSpawnHostIdleStopWarningSent(hostID string) {
+	LogHostEvent(hostID, EventHostIdleStopWarningSent, HostEventData{})
+}
+
 func LogVolumeExpirationWarningSent(volumeID string) {
 	LogHostEvent(volumeID, EventVolumeExpirationWarningSent, HostEventData{})
 }
@@ -260,6 +270,18 @@ func LogHostScriptExecuteFailed(hostID string, err error) {
 	LogHostEvent(hostID, EventHostScriptExecuteFailed, HostEventData{Logs: err.Error()})
 }
 
+// LogHostTaskEnvironmentStepSucceeded is used when a spawn host reproducing a
+// task finishes a step of loading the task's environment.
+func LogHostTaskEnvironmentStepSucceeded(hostID, step, logs string) {
+	LogHostEvent(hostID, EventHostTaskEnvironmentStep, HostEventData{ProvisioningStep: step, Successful: true, Logs: logs})
+}
+
+// LogHostTaskEnvironmentStepFailed is used when a spawn host reproducing a
+// task fails a step of loading the task's environment.
+func LogHostTaskEnvironmentStepFailed(hostID, step string, err error) {
+	LogHostEvent(hostID, EventHostTaskEnvironmentStep, HostEventData{ProvisioningStep: step, Logs: err.Error()})
+}
+
 // LogVolumeMigrationFailed is used when a volume is unable to migrate to a new host.
 func LogVolumeMigrationFailed(hostID string, err error) {
 	LogHostEvent(hostID, EventVolumeMigrationFailed, HostEventData{Logs: err.Error()})
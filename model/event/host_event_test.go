@@ -6,7 +6,10 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLoggingHostEvents(t *testing.T) {
@@ -118,3 +121,31 @@ func TestLoggingHostEvents(t *testing.T) {
 		})
 	})
 }
+
+func TestHostTaskEnvironmentEvents(t *testing.T) {
+	require.NoError(t, db.Clear(EventCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(EventCollection))
+	}()
+
+	LogHostCreated("host_id")
+	LogHostTaskEnvironmentStepSucceeded("host_id", "fetch-task-data", "")
+	time.Sleep(time.Millisecond)
+	LogHostTaskEnvironmentStepFailed("host_tag", "pull-task-sync", errors.New("no sync data"))
+	LogHostTaskEnvironmentStepSucceeded("other_host", "fetch-task-data", "")
+
+	events, err := Find(HostTaskEnvironmentEvents("host_id", "host_tag"))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	data, ok := events[0].Data.(*HostEventData)
+	require.True(t, ok)
+	assert.Equal(t, "fetch-task-data", data.ProvisioningStep)
+	assert.True(t, data.Successful)
+
+	data, ok = events[1].Data.(*HostEventData)
+	require.True(t, ok)
+	assert.Equal(t, "pull-task-sync", data.ProvisioningStep)
+	assert.False(t, data.Successful)
+	assert.Equal(t, "no sync data", data.Logs)
+}
@@ -13,6 +13,8 @@ import (
 const (
 	GithubPullRequestSubscriberType = "github_pull_request"
 	GithubCheckSubscriberType       = "github_check"
+	GithubCheckRunSubscriberType    = "github_check_run"
+	GithubPRCommentSubscriberType   = "github_pr_comment"
 	JIRAIssueSubscriberType         = "jira-issue"
 	JIRACommentSubscriberType       = "jira-comment"
 	EvergreenWebhookSubscriberType  = "evergreen-webhook"
@@ -30,6 +32,8 @@ const (
 var SubscriberTypes = []string{
 	GithubPullRequestSubscriberType,
 	GithubCheckSubscriberType,
+	GithubCheckRunSubscriberType,
+	GithubPRCommentSubscriberType,
 	JIRAIssueSubscriberType,
 	JIRACommentSubscriberType,
 	EvergreenWebhookSubscriberType,
@@ -70,9 +74,9 @@ func (s *Subscriber) SetBSON(raw mgobson.Raw) error {
 	s.Type = temp.Type
 
 	switch temp.Type {
-	case GithubPullRequestSubscriberType:
+	case GithubPullRequestSubscriberType, GithubPRCommentSubscriberType:
 		s.Target = &GithubPullRequestSubscriber{}
-	case GithubCheckSubscriberType:
+	case GithubCheckSubscriberType, GithubCheckRunSubscriberType:
 		s.Target = &GithubCheckSubscriber{}
 	case EvergreenWebhookSubscriberType:
 		s.Target = &WebhookSubscriber{}
@@ -238,6 +242,24 @@ func NewGithubCheckAPISubscriber(s GithubCheckSubscriber) Subscriber {
 	}
 }
 
+// NewGithubCheckRunSubscriber returns a subscriber that posts the results of
+// a build or task to GitHub as a check run.
+func NewGithubCheckRunSubscriber(s GithubCheckSubscriber) Subscriber {
+	return Subscriber{
+		Type:   GithubCheckRunSubscriberType,
+		Target: s,
+	}
+}
+
+// NewGithubPRCommentSubscriber returns a subscriber that posts a summary of a
+// patch's results to its GitHub PR as a comment.
+func NewGithubPRCommentSubscriber(s GithubPullRequestSubscriber) Subscriber {
+	return Subscriber{
+		Type:   GithubPRCommentSubscriberType,
+		Target: s,
+	}
+}
+
 func NewEmailSubscriber(t string) Subscriber {
 	return Subscriber{
 		Type:   EmailSubscriberType,
@@ -61,6 +61,7 @@ const (
 	VersionPercentChangeKey                          = "version-percent-change"
 	TestRegexKey                                     = "test-regex"
 	RenotifyIntervalKey                              = "renotify-interval"
+	PerfPercentChangeKey                             = "perf-percent-change"
 	GeneralSubscriptionPatchOutcome                  = "patch-outcome"
 	GeneralSubscriptionPatchFirstFailure             = "patch-first-failure"
 	GeneralSubscriptionBuildBreak                    = "build-break"
@@ -90,6 +91,7 @@ const (
 	TriggerPatchStarted              = "started"
 	TriggerTaskFirstFailureInVersion = "first-failure-in-version"
 	TriggerTaskStarted               = "task-started"
+	TriggerPerfChangePoint           = "perf-change-point"
 )
 
 type Subscription struct {
@@ -606,6 +608,9 @@ func (s *Subscription) runCustomValidation() error {
 	if buildPercentVal, ok := s.TriggerData[BuildPercentChangeKey]; ok {
 		catcher.Wrap(validatePositiveFloat(buildPercentVal), "invalid build percentage runtime change")
 	}
+	if perfPercentVal, ok := s.TriggerData[PerfPercentChangeKey]; ok {
+		catcher.Wrap(validatePositiveFloat(perfPercentVal), "invalid performance percentage change")
+	}
 	if testRegex, ok := s.TriggerData[TestRegexKey]; ok {
 		catcher.Wrap(validateRegex(testRegex), "invalid test regex")
 	}
@@ -911,6 +916,25 @@ func NewGithubCheckBuildOutcomeSubscriptionByVersion(versionID string, sub Subsc
 	}
 }
 
+// NewGithubCheckRunSubscriptionByVersion returns a subscription to the given
+// trigger for the builds or tasks in a version, which is used to post their
+// results to GitHub as check runs.
+func NewGithubCheckRunSubscriptionByVersion(versionID, resourceType, trigger string, sub Subscriber) Subscription {
+	return Subscription{
+		ResourceType: resourceType,
+		Trigger:      trigger,
+		Selectors: []Selector{
+			{
+				Type: SelectorInVersion,
+				Data: versionID,
+			},
+		},
+		Filter:      Filter{InVersion: versionID},
+		Subscriber:  sub,
+		LastUpdated: time.Now(),
+	}
+}
+
 func NewSpawnHostOutcomeByOwner(owner string, sub Subscriber) Subscription {
 	return Subscription{
 		ResourceType: ResourceTypeHost,
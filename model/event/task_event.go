@@ -14,6 +14,7 @@ func init() {
 	registry.AllowSubscription(ResourceTypeTask, TaskStarted)
 	registry.AllowSubscription(ResourceTypeTask, TaskFinished)
 	registry.AllowSubscription(ResourceTypeTask, TaskBlocked)
+	registry.AllowSubscription(ResourceTypeTask, TaskPerfChangePoint)
 }
 
 const (
@@ -36,6 +37,7 @@ const (
 	TaskJiraAlertCreated       = "TASK_JIRA_ALERT_CREATED"
 	TaskDependenciesOverridden = "TASK_DEPENDENCIES_OVERRIDDEN"
 	MergeTaskUnscheduled       = "MERGE_TASK_UNSCHEDULED"
+	TaskPerfChangePoint        = "TASK_PERF_CHANGE_POINT"
 )
 
 // implements Data
@@ -46,6 +48,9 @@ type TaskEventData struct {
 	UserId    string `bson:"u_id,omitempty" json:"user_id,omitempty"`
 	Status    string `bson:"s,omitempty" json:"status,omitempty"`
 	JiraIssue string `bson:"jira,omitempty" json:"jira,omitempty"`
+	// ChangePointID is the ID of the performance change point detected at
+	// the task.
+	ChangePointID string `bson:"cp_id,omitempty" json:"change_point_id,omitempty"`
 
 	Timestamp time.Time `bson:"ts,omitempty" json:"timestamp,omitempty"`
 	Priority  int64     `bson:"pri,omitempty" json:"priority,omitempty"`
@@ -109,6 +114,12 @@ func LogTaskPriority(taskId string, execution int, user string, priority int64)
 	logTaskEvent(taskId, TaskPriorityChanged, TaskEventData{Execution: execution, UserId: user, Priority: priority})
 }
 
+// LogTaskPerfChangePoint logs an event for a performance change point being
+// detected at the task.
+func LogTaskPerfChangePoint(taskId string, execution int, changePointID string) {
+	logTaskEvent(taskId, TaskPerfChangePoint, TaskEventData{Execution: execution, ChangePointID: changePointID})
+}
+
 func LogTaskCreated(taskId string, execution int) {
 	logTaskEvent(taskId, TaskCreated, TaskEventData{Execution: execution})
 }
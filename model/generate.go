@@ -695,16 +695,20 @@ func isNonZeroBV(bv parserBV) bool {
 func (g *GeneratedProject) validateNoRecursiveGenerateTasks(cachedProject projectMaps) error {
 	catcher := grip.NewBasicCatcher()
 	for _, t := range g.Tasks {
-		for _, cmd := range t.Commands {
-			if cmd.Command == evergreen.GenerateTasksCommandName {
-				catcher.New("cannot define 'generate.tasks' from a 'generate.tasks' block")
+		for _, block := range t.Commands {
+			for _, cmd := range block.BlockCommands() {
+				if cmd.Command == evergreen.GenerateTasksCommandName {
+					catcher.New("cannot define 'generate.tasks' from a 'generate.tasks' block")
+				}
 			}
 		}
 	}
 	for _, f := range g.Functions {
-		for _, cmd := range f.List() {
-			if cmd.Command == evergreen.GenerateTasksCommandName {
-				catcher.New("cannot define 'generate.tasks' from a 'generate.tasks' block")
+		for _, block := range f.List() {
+			for _, cmd := range block.BlockCommands() {
+				if cmd.Command == evergreen.GenerateTasksCommandName {
+					catcher.New("cannot define 'generate.tasks' from a 'generate.tasks' block")
+				}
 			}
 		}
 	}
@@ -720,15 +724,19 @@ func (g *GeneratedProject) validateNoRecursiveGenerateTasks(cachedProject projec
 
 func validateCommands(projectTask *ProjectTask, cachedProject projectMaps, pvt parserBVTaskUnit) error {
 	catcher := grip.NewBasicCatcher()
-	for _, cmd := range projectTask.Commands {
-		if cmd.Command == evergreen.GenerateTasksCommandName {
-			catcher.Errorf("cannot assign a task that calls 'generate.tasks' from a 'generate.tasks' block (%s)", pvt.Name)
-		}
-		if cmd.Function != "" {
-			if functionCmds, ok := cachedProject.functions[cmd.Function]; ok {
-				for _, functionCmd := range functionCmds.List() {
-					if functionCmd.Command == evergreen.GenerateTasksCommandName {
-						catcher.Errorf("cannot assign a task that calls 'generate.tasks' from a 'generate.tasks' block (%s)", cmd.Function)
+	for _, block := range projectTask.Commands {
+		for _, cmd := range block.BlockCommands() {
+			if cmd.Command == evergreen.GenerateTasksCommandName {
+				catcher.Errorf("cannot assign a task that calls 'generate.tasks' from a 'generate.tasks' block (%s)", pvt.Name)
+			}
+			if cmd.Function != "" {
+				if functionCmds, ok := cachedProject.functions[cmd.Function]; ok {
+					for _, functionBlock := range functionCmds.List() {
+						for _, functionCmd := range functionBlock.BlockCommands() {
+							if functionCmd.Command == evergreen.GenerateTasksCommandName {
+								catcher.Errorf("cannot assign a task that calls 'generate.tasks' from a 'generate.tasks' block (%s)", cmd.Function)
+							}
+						}
 					}
 				}
 			}
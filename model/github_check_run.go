@@ -0,0 +1,316 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/google/go-github/v34/github"
+	"github.com/pkg/errors"
+)
+
+const (
+	githubCheckRunTimeout = time.Minute
+
+	// maxFailedTestsPerCheckRunTask is the number of failed tests listed for
+	// each failed task in a check run summary.
+	maxFailedTestsPerCheckRunTask = 10
+	// maxCheckRunAnnotations is the number of line-level annotations that
+	// a check run can have.
+	maxCheckRunAnnotations = 200
+	// checkRunAnnotationsPerRequest is the number of annotations that GitHub
+	// accepts in a single request.
+	checkRunAnnotationsPerRequest = 50
+)
+
+// GithubCheckRun is the payload of a notification that posts the results of a
+// build variant or a task to GitHub as a check run. The check run is created
+// the first time and updated when the build variant or task finishes again.
+type GithubCheckRun struct {
+	Owner string `bson:"owner" json:"owner"`
+	Repo  string `bson:"repo" json:"repo"`
+	Ref   string `bson:"ref" json:"ref"`
+	// Name is the name of the check run on GitHub.
+	Name string `bson:"name" json:"name"`
+	// URL links the check run to the build or task in Evergreen.
+	URL string `bson:"url" json:"url"`
+	// Exactly one of BuildID and TaskID is set.
+	BuildID string `bson:"build_id,omitempty" json:"build_id,omitempty"`
+	TaskID  string `bson:"task_id,omitempty" json:"task_id,omitempty"`
+	// GithubChecksOnly limits a build's check run to the tasks that are
+	// mainline GitHub checks.
+	GithubChecksOnly bool `bson:"github_checks_only,omitempty" json:"github_checks_only,omitempty"`
+}
+
+func (c *GithubCheckRun) String() string {
+	return fmt.Sprintf("GitHub check run '%s' for '%s/%s' at ref '%s'", c.Name, c.Owner, c.Repo, c.Ref)
+}
+
+func (c *GithubCheckRun) Valid() bool {
+	return c.Owner != "" && c.Repo != "" && c.Ref != "" && c.Name != "" && (c.BuildID == "") != (c.TaskID == "")
+}
+
+// Send creates or updates the check run on GitHub with the current results of
+// the build or task.
+func (c *GithubCheckRun) Send() error {
+	ctx, cancel := context.WithTimeout(context.Background(), githubCheckRunTimeout)
+	defer cancel()
+	env := evergreen.GetEnvironment()
+
+	tasks, err := c.findTasks()
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	failedTests := map[string][]testresult.TestResult{}
+	for _, t := range tasks {
+		if t.Status != evergreen.TaskFailed || !t.HasResults() {
+			continue
+		}
+		results, err := t.GetTestResults(ctx, env, &testresult.FilterOptions{Statuses: []string{evergreen.TestFailedStatus}})
+		if err != nil {
+			return errors.Wrapf(err, "getting failed tests for task '%s'", t.Id)
+		}
+		for i := range results.Results {
+			results.Results[i].LogURL = results.Results[i].GetLogURL(env, evergreen.LogViewerHTML)
+		}
+		failedTests[t.Id] = results.Results
+	}
+
+	output := makeGithubCheckRunOutput(env.Settings().Ui.Url, tasks, failedTests)
+
+	token, err := getGithubCheckRunToken(ctx, env.Settings().GithubApp, c.Owner, c.Repo)
+	if err != nil {
+		return err
+	}
+	existing, err := thirdparty.FindCheckRun(ctx, token, c.Owner, c.Repo, c.Ref, c.Name)
+	if err != nil {
+		return err
+	}
+
+	annotations := output.annotations
+	firstAnnotations := annotations
+	if len(firstAnnotations) > checkRunAnnotationsPerRequest {
+		firstAnnotations = firstAnnotations[:checkRunAnnotationsPerRequest]
+	}
+	annotations = annotations[len(firstAnnotations):]
+	completedAt := github.Timestamp{Time: time.Now()}
+
+	var checkRunID int64
+	if existing == nil {
+		checkRun, err := thirdparty.CreateCheckRun(ctx, token, c.Owner, c.Repo, github.CreateCheckRunOptions{
+			Name:        c.Name,
+			HeadSHA:     c.Ref,
+			DetailsURL:  github.String(c.URL),
+			Status:      github.String("completed"),
+			Conclusion:  github.String(output.conclusion),
+			CompletedAt: &completedAt,
+			Output:      output.toGithub(firstAnnotations),
+		})
+		if err != nil {
+			return err
+		}
+		checkRunID = checkRun.GetID()
+	} else {
+		checkRunID = existing.GetID()
+		if _, err = thirdparty.UpdateCheckRun(ctx, token, c.Owner, c.Repo, checkRunID, github.UpdateCheckRunOptions{
+			Name:        c.Name,
+			DetailsURL:  github.String(c.URL),
+			Status:      github.String("completed"),
+			Conclusion:  github.String(output.conclusion),
+			CompletedAt: &completedAt,
+			Output:      output.toGithub(firstAnnotations),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// GitHub limits the number of annotations per request, so add the rest
+	// of them in subsequent updates.
+	for len(annotations) > 0 {
+		batch := annotations
+		if len(batch) > checkRunAnnotationsPerRequest {
+			batch = batch[:checkRunAnnotationsPerRequest]
+		}
+		annotations = annotations[len(batch):]
+		if _, err = thirdparty.UpdateCheckRun(ctx, token, c.Owner, c.Repo, checkRunID, github.UpdateCheckRunOptions{
+			Name:   c.Name,
+			Output: output.toGithub(batch),
+		}); err != nil {
+			return errors.Wrap(err, "adding annotations")
+		}
+	}
+
+	return nil
+}
+
+// getGithubCheckRunToken returns a token that can write check runs to the repo.
+// GitHub only lets GitHub Apps write check runs, so this is a token for the
+// installation of Evergreen's GitHub App on the repo.
+func getGithubCheckRunToken(ctx context.Context, appConf evergreen.GithubAppConfig, owner, repo string) (string, error) {
+	if !appConf.Enabled() {
+		return "", errors.New("GitHub App is not configured, so check runs cannot be sent")
+	}
+	key, err := appConf.RSAPrivateKey()
+	if err != nil {
+		return "", errors.Wrap(err, "getting GitHub App private key")
+	}
+	token, err := thirdparty.GetGithubAppInstallationToken(ctx, appConf.AppID, key, owner, repo)
+	if err != nil {
+		return "", errors.Wrap(err, "getting GitHub App installation token")
+	}
+	return token, nil
+}
+
+// findTasks returns the tasks whose results are posted to the check run. For a
+// build, these are the build's tasks that are not execution tasks.
+func (c *GithubCheckRun) findTasks() ([]task.Task, error) {
+	if c.TaskID != "" {
+		t, err := task.FindOneId(c.TaskID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding task '%s'", c.TaskID)
+		}
+		if t == nil {
+			return nil, errors.Errorf("task '%s' not found", c.TaskID)
+		}
+		return []task.Task{*t}, nil
+	}
+
+	b, err := build.FindOneId(c.BuildID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding build '%s'", c.BuildID)
+	}
+	if b == nil {
+		return nil, errors.Errorf("build '%s' not found", c.BuildID)
+	}
+	query := task.ByBuildId(b.Id)
+	if c.GithubChecksOnly {
+		query = task.ByBuildIdAndGithubChecks(b.Id)
+	}
+	tasks, err := task.FindAll(db.Query(query))
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding tasks in build '%s'", b.Id)
+	}
+
+	taskMap := task.TaskSliceToMap(tasks)
+	buildTasks := make([]task.Task, 0, len(b.Tasks))
+	for _, taskCache := range b.Tasks {
+		t, ok := taskMap[taskCache.Id]
+		if !ok || !t.Activated {
+			continue
+		}
+		buildTasks = append(buildTasks, t)
+	}
+
+	return buildTasks, nil
+}
+
+// githubCheckRunOutput is the result of a check run.
+type githubCheckRunOutput struct {
+	conclusion  string
+	title       string
+	summary     string
+	annotations []*github.CheckRunAnnotation
+}
+
+func (o *githubCheckRunOutput) toGithub(annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	return &github.CheckRunOutput{
+		Title:       github.String(o.title),
+		Summary:     github.String(o.summary),
+		Annotations: annotations,
+	}
+}
+
+// makeGithubCheckRunOutput summarizes the results of the tasks in markdown,
+// listing the failed tasks and their failed tests with links to their logs, and
+// annotates the lines of the failed tests that report their location in the
+// source code.
+func makeGithubCheckRunOutput(uiURL string, tasks []task.Task, failedTests map[string][]testresult.TestResult) githubCheckRunOutput {
+	var failedTasks []task.Task
+	for _, t := range tasks {
+		if evergreen.IsFailedTaskStatus(t.Status) || t.Aborted {
+			failedTasks = append(failedTasks, t)
+		}
+	}
+
+	output := githubCheckRunOutput{conclusion: "success"}
+	if len(failedTasks) == 0 {
+		output.title = fmt.Sprintf("%d of %d tasks succeeded", len(tasks), len(tasks))
+		output.summary = fmt.Sprintf("All %d tasks succeeded.", len(tasks))
+		return output
+	}
+
+	output.conclusion = "failure"
+	output.title = fmt.Sprintf("%d of %d tasks failed", len(failedTasks), len(tasks))
+
+	var summary strings.Builder
+	summary.WriteString(fmt.Sprintf("**%d** of **%d** tasks failed.\n", len(failedTasks), len(tasks)))
+	for _, t := range failedTasks {
+		summary.WriteString(fmt.Sprintf("\n#### [%s](%s) %s\n", t.DisplayName, githubCheckRunTaskLink(uiURL, t), githubCheckRunTaskStatus(t)))
+
+		tests := failedTests[t.Id]
+		for i, test := range tests {
+			if i == maxFailedTestsPerCheckRunTask {
+				summary.WriteString(fmt.Sprintf("- ...and %d more failed tests\n", len(tests)-maxFailedTestsPerCheckRunTask))
+				break
+			}
+			if test.LogURL != "" {
+				summary.WriteString(fmt.Sprintf("- [%s](%s)\n", test.GetDisplayTestName(), test.LogURL))
+			} else {
+				summary.WriteString(fmt.Sprintf("- %s\n", test.GetDisplayTestName()))
+			}
+		}
+
+		for _, test := range tests {
+			if test.FilePath == "" || test.FileLine <= 0 || len(output.annotations) == maxCheckRunAnnotations {
+				continue
+			}
+			message := fmt.Sprintf("Test '%s' failed in task '%s'.", test.GetDisplayTestName(), t.DisplayName)
+			if test.LogURL != "" {
+				message += fmt.Sprintf("\nLogs: %s", test.LogURL)
+			}
+			output.annotations = append(output.annotations, &github.CheckRunAnnotation{
+				Path:            github.String(test.FilePath),
+				StartLine:       github.Int(test.FileLine),
+				EndLine:         github.Int(test.FileLine),
+				AnnotationLevel: github.String("failure"),
+				Title:           github.String(test.GetDisplayTestName()),
+				Message:         github.String(message),
+			})
+		}
+	}
+	output.summary = summary.String()
+
+	return output
+}
+
+func githubCheckRunTaskStatus(t task.Task) string {
+	if t.Aborted {
+		return "was aborted"
+	}
+	if t.Details.TimedOut {
+		return "timed out"
+	}
+	if t.Details.Type == evergreen.CommandTypeSetup {
+		return "failed during setup"
+	}
+	if t.Details.Type == evergreen.CommandTypeSystem {
+		return "failed with a system error"
+	}
+	return "failed"
+}
+
+func githubCheckRunTaskLink(uiURL string, t task.Task) string {
+	return fmt.Sprintf("%s/task/%s/%d?redirect_spruce_users=true", uiURL, url.PathEscape(t.Id), t.Execution)
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGithubCheckRunValid(t *testing.T) {
+	checkRun := GithubCheckRun{
+		Owner:   "evergreen-ci",
+		Repo:    "evergreen",
+		Ref:     "abcdef",
+		Name:    "evergreen/ubuntu",
+		BuildID: "b0",
+	}
+	assert.True(t, checkRun.Valid())
+
+	checkRun.TaskID = "t0"
+	assert.False(t, checkRun.Valid())
+
+	checkRun.BuildID = ""
+	assert.True(t, checkRun.Valid())
+
+	checkRun.Name = ""
+	assert.False(t, checkRun.Valid())
+}
+
+func TestMakeGithubCheckRunOutput(t *testing.T) {
+	const uiURL = "https://evergreen.example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		tasks := []task.Task{
+			{Id: "t0", DisplayName: "compile", Status: evergreen.TaskSucceeded},
+			{Id: "t1", DisplayName: "test", Status: evergreen.TaskSucceeded},
+		}
+		output := makeGithubCheckRunOutput(uiURL, tasks, nil)
+		assert.Equal(t, "success", output.conclusion)
+		assert.Equal(t, "2 of 2 tasks succeeded", output.title)
+		assert.Empty(t, output.annotations)
+	})
+	t.Run("FailureSummarizesFailedTasksAndTests", func(t *testing.T) {
+		tasks := []task.Task{
+			{Id: "t0", DisplayName: "compile", Status: evergreen.TaskSucceeded},
+			{Id: "t1", DisplayName: "test", Status: evergreen.TaskFailed},
+			{Id: "t2", DisplayName: "lint", Status: evergreen.TaskFailed, Details: apimodels.TaskEndDetail{TimedOut: true}},
+		}
+		failedTests := map[string][]testresult.TestResult{
+			"t1": {
+				{TestName: "test_divide", Status: evergreen.TestFailedStatus, LogURL: "https://logs/test_divide", FilePath: "tests/test_math.py", FileLine: 12},
+				{TestName: "test_multiply", Status: evergreen.TestFailedStatus},
+			},
+		}
+		output := makeGithubCheckRunOutput(uiURL, tasks, failedTests)
+		assert.Equal(t, "failure", output.conclusion)
+		assert.Equal(t, "2 of 3 tasks failed", output.title)
+		assert.Contains(t, output.summary, fmt.Sprintf("#### [test](%s/task/t1/0?redirect_spruce_users=true) failed", uiURL))
+		assert.Contains(t, output.summary, "#### [lint]")
+		assert.Contains(t, output.summary, "timed out")
+		assert.NotContains(t, output.summary, "compile")
+		assert.Contains(t, output.summary, "- [test_divide](https://logs/test_divide)")
+		assert.Contains(t, output.summary, "- test_multiply")
+
+		require.Len(t, output.annotations, 1)
+		annotation := output.annotations[0]
+		assert.Equal(t, "tests/test_math.py", annotation.GetPath())
+		assert.Equal(t, 12, annotation.GetStartLine())
+		assert.Equal(t, 12, annotation.GetEndLine())
+		assert.Equal(t, "failure", annotation.GetAnnotationLevel())
+		assert.Equal(t, "test_divide", annotation.GetTitle())
+		assert.Contains(t, annotation.GetMessage(), "https://logs/test_divide")
+	})
+	t.Run("LimitsFailedTestsAndAnnotations", func(t *testing.T) {
+		var tasks []task.Task
+		failedTests := map[string][]testresult.TestResult{}
+		for i := 0; i < 10; i++ {
+			tsk := task.Task{Id: fmt.Sprintf("t%d", i), DisplayName: fmt.Sprintf("test%d", i), Status: evergreen.TaskFailed}
+			tasks = append(tasks, tsk)
+			for j := 0; j < 25; j++ {
+				failedTests[tsk.Id] = append(failedTests[tsk.Id], testresult.TestResult{
+					TestName: fmt.Sprintf("test%d_%d", i, j),
+					Status:   evergreen.TestFailedStatus,
+					FilePath: "test.go",
+					FileLine: j + 1,
+				})
+			}
+		}
+		output := makeGithubCheckRunOutput(uiURL, tasks, failedTests)
+		assert.Contains(t, output.summary, "- test0_9\n")
+		assert.NotContains(t, output.summary, "- test0_10\n")
+		assert.Contains(t, output.summary, "...and 15 more failed tests")
+		assert.Len(t, output.annotations, maxCheckRunAnnotations)
+	})
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// GithubMergeGroup is a group of pull requests in a GitHub merge queue that
+// GitHub tests together before merging them.
+type GithubMergeGroup struct {
+	// HeadSHA is the commit that merges the pull requests in the group into
+	// the base branch.
+	HeadSHA string
+	// HeadRef is the temporary branch that GitHub creates for the group.
+	HeadRef string
+	// BaseRef is the branch that the group merges into.
+	BaseRef string
+}
+
+// VersionsByGithubMergeGroup returns a query for the versions created for the
+// merge group with the given head commit in the repo.
+func VersionsByGithubMergeGroup(owner, repo, headSHA string) db.Q {
+	return db.Query(bson.M{
+		VersionOwnerNameKey: owner,
+		VersionRepoKey:      repo,
+		VersionRevisionKey:  headSHA,
+		VersionRequesterKey: evergreen.GithubMergeRequester,
+	})
+}
+
+// AbortGithubMergeGroupVersions deactivates the versions created for a merge
+// group that GitHub has removed from the merge queue and aborts their running
+// tasks.
+func AbortGithubMergeGroupVersions(owner, repo, headSHA, caller string) error {
+	versions, err := VersionFind(VersionsByGithubMergeGroup(owner, repo, headSHA).WithFields(VersionIdKey))
+	if err != nil {
+		return errors.Wrapf(err, "finding versions for merge group '%s'", headSHA)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, v := range versions {
+		if err = SetVersionActivation(v.Id, false, caller); err != nil {
+			catcher.Wrapf(err, "deactivating version '%s'", v.Id)
+			continue
+		}
+		catcher.Wrapf(task.AbortVersion(v.Id, task.AbortInfo{User: caller}), "aborting version '%s'", v.Id)
+	}
+
+	return catcher.Resolve()
+}
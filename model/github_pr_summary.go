@@ -0,0 +1,378 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/pkg/errors"
+)
+
+const (
+	githubPRSummaryTimeout = 2 * time.Minute
+
+	// maxPRSummaryEntriesPerSection is the number of entries listed in each
+	// section of a PR summary comment before it is truncated.
+	maxPRSummaryEntriesPerSection = 20
+	// maxPRSummaryLength is the longest PR summary comment that is posted,
+	// which leaves room under GitHub's limit of 65536 characters.
+	maxPRSummaryLength = 60000
+
+	// A task's runtime has regressed if it took at least
+	// prSummaryRuntimeRegressionRatio times as long as on the base commit and
+	// at least prSummaryMinRuntimeRegression longer.
+	prSummaryRuntimeRegressionRatio = 1.5
+	prSummaryMinRuntimeRegression   = 2 * time.Minute
+)
+
+// GithubPRSummaryComment is the payload of a notification that posts a
+// summary of a finished PR patch's results compared to its base commit as a
+// comment on the PR. Each project has a single summary comment on a PR, which
+// is edited in place for subsequent patches.
+type GithubPRSummaryComment struct {
+	Owner    string `bson:"owner" json:"owner"`
+	Repo     string `bson:"repo" json:"repo"`
+	PRNumber int    `bson:"pr_number" json:"pr_number"`
+	PatchID  string `bson:"patch_id" json:"patch_id"`
+}
+
+func (c *GithubPRSummaryComment) String() string {
+	return fmt.Sprintf("GitHub PR summary comment for patch '%s' on '%s/%s' PR #%d", c.PatchID, c.Owner, c.Repo, c.PRNumber)
+}
+
+func (c *GithubPRSummaryComment) Valid() bool {
+	return c.Owner != "" && c.Repo != "" && c.PRNumber > 0 && c.PatchID != ""
+}
+
+// Send posts the summary of the patch to the PR, or edits the project's
+// existing summary comment.
+func (c *GithubPRSummaryComment) Send() error {
+	ctx, cancel := context.WithTimeout(context.Background(), githubPRSummaryTimeout)
+	defer cancel()
+	env := evergreen.GetEnvironment()
+
+	p, err := patch.FindOneId(c.PatchID)
+	if err != nil {
+		return errors.Wrapf(err, "finding patch '%s'", c.PatchID)
+	}
+	if p == nil {
+		return errors.Errorf("patch '%s' not found", c.PatchID)
+	}
+	if p.Version == "" {
+		return errors.Errorf("patch '%s' is not finalized", c.PatchID)
+	}
+
+	summary, hasBase, err := makeGithubPRSummary(ctx, env, p)
+	if err != nil {
+		return err
+	}
+
+	uiURL := env.Settings().Ui.Url
+	marker := githubPRSummaryMarker(p.Project)
+	header := fmt.Sprintf("%s\n## Evergreen summary for `%s`\n\n[Patch](%s/version/%s?redirect_spruce_users=true) on commit `%s` %s",
+		marker, p.Project, uiURL, p.Version, shortHash(p.GithubPatchData.HeadHash), prSummaryPatchStatus(p.Status))
+	if hasBase {
+		header += fmt.Sprintf(", compared to base commit `%s`.", shortHash(p.Githash))
+	} else {
+		header += fmt.Sprintf(". The base commit `%s` has no results to compare to.", shortHash(p.Githash))
+	}
+	// The legacy UI's version page shows the full status diff.
+	body := summary.render(header, fmt.Sprintf("%s/version/%s", uiURL, p.Version))
+
+	token, err := env.Settings().GetGithubOauthToken()
+	if err != nil {
+		return errors.Wrap(err, "getting GitHub token")
+	}
+	existing, err := thirdparty.FindPullRequestComment(ctx, token, c.Owner, c.Repo, c.PRNumber, marker)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return thirdparty.EditPullRequestComment(ctx, token, c.Owner, c.Repo, existing.GetID(), body)
+	}
+	return thirdparty.PostCommentToPullRequest(ctx, token, c.Owner, c.Repo, c.PRNumber, body)
+}
+
+// githubPRSummaryMarker identifies a project's summary comment on a PR.
+func githubPRSummaryMarker(projectID string) string {
+	return fmt.Sprintf("<!-- evergreen-pr-summary:%s -->", projectID)
+}
+
+// makeGithubPRSummary diffs the patch's tasks and their tests against the
+// base commit. It also returns whether the base commit has any builds to
+// compare to.
+func makeGithubPRSummary(ctx context.Context, env evergreen.Environment, p *patch.Patch) (*githubPRSummary, bool, error) {
+	patchBuilds, err := build.Find(build.ByVersion(p.Version))
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "finding builds for version '%s'", p.Version)
+	}
+	baseBuilds, err := build.Find(build.ByRevisionWithSystemVersionRequester(p.Githash))
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "finding base builds for revision '%s'", p.Githash)
+	}
+	baseBuildsByVariant := map[string]*build.Build{}
+	for i := range baseBuilds {
+		if baseBuilds[i].Project == p.Project {
+			baseBuildsByVariant[baseBuilds[i].BuildVariant] = &baseBuilds[i]
+		}
+	}
+
+	var diffs []TaskStatusDiff
+	for i := range patchBuilds {
+		diff, err := StatusDiffBuilds(baseBuildsByVariant[patchBuilds[i].BuildVariant], &patchBuilds[i])
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "diffing build '%s'", patchBuilds[i].Id)
+		}
+		if diff.Name == "" {
+			// There's no build to compare to on the base commit.
+			diff.Tasks = patchOnlyTaskStatusDiffs(&patchBuilds[i])
+		}
+		diffs = append(diffs, diff.Tasks...)
+	}
+
+	var taskIDs []string
+	for _, diff := range diffs {
+		taskIDs = append(taskIDs, diff.Patch)
+		if diff.Original != "" {
+			taskIDs = append(taskIDs, diff.Original)
+		}
+	}
+	tasks, err := task.FindAll(db.Query(task.ByIds(taskIDs)))
+	if err != nil {
+		return nil, false, errors.Wrap(err, "finding patch and base tasks")
+	}
+	tasksByID := task.TaskSliceToMap(tasks)
+
+	uiURL := env.Settings().Ui.Url
+	summary := &githubPRSummary{}
+	for _, diff := range diffs {
+		patchTask, ok := tasksByID[diff.Patch]
+		if !ok || !patchTask.IsFinished() {
+			continue
+		}
+		var baseTask *task.Task
+		if t, ok := tasksByID[diff.Original]; ok && t.IsFinished() {
+			baseTask = &t
+		}
+
+		var patchTests, baseTests []testresult.TestResult
+		if evergreen.IsFailedTaskStatus(patchTask.Status) || (baseTask != nil && evergreen.IsFailedTaskStatus(baseTask.Status)) {
+			if patchTests, err = getPRSummaryTestResults(ctx, env, &patchTask); err != nil {
+				return nil, false, err
+			}
+			if baseTask != nil {
+				if baseTests, err = getPRSummaryTestResults(ctx, env, baseTask); err != nil {
+					return nil, false, err
+				}
+			}
+		}
+
+		summary.addTask(githubCheckRunTaskLink(uiURL, patchTask), diff.BuildVariant, &patchTask, baseTask, patchTests, baseTests)
+	}
+
+	return summary, len(baseBuildsByVariant) > 0, nil
+}
+
+// patchOnlyTaskStatusDiffs returns the diffs of a build's tasks when there is
+// no build on the base commit to compare to.
+func patchOnlyTaskStatusDiffs(b *build.Build) []TaskStatusDiff {
+	diffs := make([]TaskStatusDiff, 0, len(b.Tasks))
+	for _, t := range b.Tasks {
+		diffs = append(diffs, TaskStatusDiff{
+			Patch:        t.Id,
+			BuildVariant: b.DisplayName,
+		})
+	}
+	return diffs
+}
+
+func getPRSummaryTestResults(ctx context.Context, env evergreen.Environment, t *task.Task) ([]testresult.TestResult, error) {
+	if !t.HasResults() {
+		return nil, nil
+	}
+	results, err := t.GetTestResults(ctx, env, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting test results for task '%s'", t.Id)
+	}
+	for i := range results.Results {
+		results.Results[i].LogURL = results.Results[i].GetLogURL(env, evergreen.LogViewerHTML)
+	}
+	return results.Results, nil
+}
+
+// githubPRSummaryEntry is a task, or a test in a task, listed in a PR summary.
+type githubPRSummaryEntry struct {
+	variant  string
+	taskName string
+	taskURL  string
+	testName string
+	testURL  string
+	// baseDuration and patchDuration are set for runtime regressions.
+	baseDuration  time.Duration
+	patchDuration time.Duration
+}
+
+func (e githubPRSummaryEntry) String() string {
+	res := fmt.Sprintf("- [%s / %s](%s)", e.variant, e.taskName, e.taskURL)
+	if e.testName != "" {
+		if e.testURL != "" {
+			res += fmt.Sprintf(": [%s](%s)", e.testName, e.testURL)
+		} else {
+			res += fmt.Sprintf(": %s", e.testName)
+		}
+	}
+	if e.patchDuration > 0 {
+		res += fmt.Sprintf(": %s (base: %s)", e.patchDuration.Round(time.Second), e.baseDuration.Round(time.Second))
+	}
+	return res
+}
+
+// githubPRSummary classifies the results of a patch's tasks and tests
+// compared to the base commit.
+type githubPRSummary struct {
+	newFailures         []githubPRSummaryEntry
+	fixed               []githubPRSummaryEntry
+	preexistingFailures []githubPRSummaryEntry
+	runtimeRegressions  []githubPRSummaryEntry
+}
+
+// addTask classifies a finished patch task, and its tests if it or the base
+// task failed. baseTask is nil if there is no finished task on the base commit
+// to compare to. A failed task whose failures aren't explained by its tests is
+// listed as a whole.
+func (s *githubPRSummary) addTask(taskURL, variant string, patchTask, baseTask *task.Task, patchTests, baseTests []testresult.TestResult) {
+	taskEntry := githubPRSummaryEntry{
+		variant:  variant,
+		taskName: patchTask.DisplayName,
+		taskURL:  taskURL,
+	}
+	patchFailed := evergreen.IsFailedTaskStatus(patchTask.Status)
+	baseFailed := baseTask != nil && evergreen.IsFailedTaskStatus(baseTask.Status)
+
+	var newTestFailures, preexistingTestFailures, fixedTests []githubPRSummaryEntry
+	testEntry := func(name, url string) githubPRSummaryEntry {
+		entry := taskEntry
+		entry.testName = name
+		entry.testURL = url
+		return entry
+	}
+	if len(baseTests) == 0 {
+		for _, test := range patchTests {
+			if isFailedTestStatus(test.Status) {
+				newTestFailures = append(newTestFailures, testEntry(test.GetDisplayTestName(), test.LogURL))
+			}
+		}
+	} else {
+		for _, diff := range StatusDiffTests(baseTests, patchTests) {
+			switch {
+			case isFailedTestStatus(diff.Diff.Patch) && isFailedTestStatus(diff.Diff.Original):
+				preexistingTestFailures = append(preexistingTestFailures, testEntry(diff.Name, diff.Patch))
+			case isFailedTestStatus(diff.Diff.Patch):
+				newTestFailures = append(newTestFailures, testEntry(diff.Name, diff.Patch))
+			case isFailedTestStatus(diff.Diff.Original) && diff.Diff.Patch == evergreen.TestSucceededStatus:
+				fixedTests = append(fixedTests, testEntry(diff.Name, diff.Patch))
+			}
+		}
+	}
+
+	switch {
+	case patchFailed && len(newTestFailures)+len(preexistingTestFailures) > 0:
+		s.newFailures = append(s.newFailures, newTestFailures...)
+		s.preexistingFailures = append(s.preexistingFailures, preexistingTestFailures...)
+	case patchFailed && baseFailed:
+		s.preexistingFailures = append(s.preexistingFailures, taskEntry)
+	case patchFailed:
+		s.newFailures = append(s.newFailures, taskEntry)
+	case baseFailed:
+		s.fixed = append(s.fixed, taskEntry)
+	}
+	if patchFailed {
+		s.fixed = append(s.fixed, fixedTests...)
+	}
+
+	if baseTask != nil && !patchFailed && !baseFailed {
+		patchDuration, baseDuration := patchTask.TimeTaken, baseTask.TimeTaken
+		if baseDuration > 0 &&
+			float64(patchDuration) >= prSummaryRuntimeRegressionRatio*float64(baseDuration) &&
+			patchDuration-baseDuration >= prSummaryMinRuntimeRegression {
+			entry := taskEntry
+			entry.patchDuration = patchDuration
+			entry.baseDuration = baseDuration
+			s.runtimeRegressions = append(s.runtimeRegressions, entry)
+		}
+	}
+}
+
+// render formats the summary as markdown. Sections with too many entries, and
+// summaries that are too long for a GitHub comment, are truncated with a link
+// to the full diff.
+func (s *githubPRSummary) render(header, fullDiffURL string) string {
+	sections := []struct {
+		title   string
+		entries []githubPRSummaryEntry
+	}{
+		{title: "New failures", entries: s.newFailures},
+		{title: "Fixed", entries: s.fixed},
+		{title: "Pre-existing failures", entries: s.preexistingFailures},
+		{title: "Runtime regressions", entries: s.runtimeRegressions},
+	}
+
+	truncatedMessage := fmt.Sprintf("\n\nThis summary is truncated. See the [full diff](%s).\n", fullDiffURL)
+	var body strings.Builder
+	body.WriteString(header)
+	body.WriteString("\n")
+	var hasEntries bool
+	for _, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		hasEntries = true
+		body.WriteString(fmt.Sprintf("\n### %s (%d)\n", section.title, len(section.entries)))
+		for i, entry := range section.entries {
+			if i == maxPRSummaryEntriesPerSection {
+				body.WriteString(fmt.Sprintf("- ...and [%d more](%s)\n", len(section.entries)-maxPRSummaryEntriesPerSection, fullDiffURL))
+				break
+			}
+			line := entry.String() + "\n"
+			if body.Len()+len(line)+len(truncatedMessage) > maxPRSummaryLength {
+				return strings.TrimSuffix(body.String(), "\n") + truncatedMessage
+			}
+			body.WriteString(line)
+		}
+	}
+	if !hasEntries {
+		body.WriteString("\nNo new failures, fixes, pre-existing failures, or runtime regressions.\n")
+	}
+
+	return body.String()
+}
+
+func isFailedTestStatus(status string) bool {
+	return status == evergreen.TestFailedStatus || status == evergreen.TestSilentlyFailedStatus
+}
+
+func prSummaryPatchStatus(status string) string {
+	switch status {
+	case evergreen.PatchSucceeded:
+		return "succeeded"
+	case evergreen.PatchFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("finished with status '%s'", status)
+	}
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,128 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGithubPRSummaryCommentValid(t *testing.T) {
+	comment := GithubPRSummaryComment{
+		Owner:    "evergreen-ci",
+		Repo:     "evergreen",
+		PRNumber: 5,
+		PatchID:  "p0",
+	}
+	assert.True(t, comment.Valid())
+
+	comment.PRNumber = 0
+	assert.False(t, comment.Valid())
+}
+
+func TestGithubPRSummaryAddTask(t *testing.T) {
+	failedTest := func(name string) testresult.TestResult {
+		return testresult.TestResult{TestName: name, Status: evergreen.TestFailedStatus, LogURL: "https://logs/" + name}
+	}
+	passedTest := func(name string) testresult.TestResult {
+		return testresult.TestResult{TestName: name, Status: evergreen.TestSucceededStatus}
+	}
+
+	t.Run("ClassifiesTests", func(t *testing.T) {
+		s := &githubPRSummary{}
+		patchTask := &task.Task{DisplayName: "test", Status: evergreen.TaskFailed}
+		baseTask := &task.Task{DisplayName: "test", Status: evergreen.TaskFailed}
+		s.addTask("https://task", "ubuntu", patchTask, baseTask,
+			[]testresult.TestResult{failedTest("new"), failedTest("old"), passedTest("fixed"), passedTest("ok")},
+			[]testresult.TestResult{passedTest("new"), failedTest("old"), failedTest("fixed"), passedTest("ok")},
+		)
+		require.Len(t, s.newFailures, 1)
+		assert.Equal(t, "new", s.newFailures[0].testName)
+		assert.Equal(t, "https://logs/new", s.newFailures[0].testURL)
+		require.Len(t, s.preexistingFailures, 1)
+		assert.Equal(t, "old", s.preexistingFailures[0].testName)
+		require.Len(t, s.fixed, 1)
+		assert.Equal(t, "fixed", s.fixed[0].testName)
+		assert.Empty(t, s.runtimeRegressions)
+	})
+	t.Run("ClassifiesTasksWithoutTests", func(t *testing.T) {
+		s := &githubPRSummary{}
+		s.addTask("https://t0", "ubuntu", &task.Task{DisplayName: "t0", Status: evergreen.TaskFailed}, &task.Task{Status: evergreen.TaskSucceeded}, nil, nil)
+		s.addTask("https://t1", "ubuntu", &task.Task{DisplayName: "t1", Status: evergreen.TaskFailed}, nil, nil, nil)
+		s.addTask("https://t2", "ubuntu", &task.Task{DisplayName: "t2", Status: evergreen.TaskFailed}, &task.Task{Status: evergreen.TaskFailed}, nil, nil)
+		s.addTask("https://t3", "ubuntu", &task.Task{DisplayName: "t3", Status: evergreen.TaskSucceeded}, &task.Task{Status: evergreen.TaskFailed}, nil, nil)
+		s.addTask("https://t4", "ubuntu", &task.Task{DisplayName: "t4", Status: evergreen.TaskSucceeded}, &task.Task{Status: evergreen.TaskSucceeded}, nil, nil)
+
+		require.Len(t, s.newFailures, 2)
+		assert.Equal(t, "t0", s.newFailures[0].taskName)
+		assert.Equal(t, "t1", s.newFailures[1].taskName)
+		require.Len(t, s.preexistingFailures, 1)
+		assert.Equal(t, "t2", s.preexistingFailures[0].taskName)
+		require.Len(t, s.fixed, 1)
+		assert.Equal(t, "t3", s.fixed[0].taskName)
+	})
+	t.Run("RuntimeRegressions", func(t *testing.T) {
+		s := &githubPRSummary{}
+		s.addTask("https://t0", "ubuntu", &task.Task{DisplayName: "slow", Status: evergreen.TaskSucceeded, TimeTaken: 20 * time.Minute},
+			&task.Task{Status: evergreen.TaskSucceeded, TimeTaken: 10 * time.Minute}, nil, nil)
+		s.addTask("https://t1", "ubuntu", &task.Task{DisplayName: "short", Status: evergreen.TaskSucceeded, TimeTaken: 20 * time.Second},
+			&task.Task{Status: evergreen.TaskSucceeded, TimeTaken: 5 * time.Second}, nil, nil)
+		s.addTask("https://t2", "ubuntu", &task.Task{DisplayName: "similar", Status: evergreen.TaskSucceeded, TimeTaken: 12 * time.Minute},
+			&task.Task{Status: evergreen.TaskSucceeded, TimeTaken: 10 * time.Minute}, nil, nil)
+
+		require.Len(t, s.runtimeRegressions, 1)
+		assert.Equal(t, "slow", s.runtimeRegressions[0].taskName)
+		assert.Contains(t, s.runtimeRegressions[0].String(), "20m0s (base: 10m0s)")
+	})
+}
+
+func TestGithubPRSummaryRender(t *testing.T) {
+	const fullDiffURL = "https://evergreen.example.com/version/v0"
+
+	t.Run("NoEntries", func(t *testing.T) {
+		s := &githubPRSummary{}
+		body := s.render("header", fullDiffURL)
+		assert.True(t, strings.HasPrefix(body, "header\n"))
+		assert.Contains(t, body, "No new failures")
+		assert.NotContains(t, body, fullDiffURL)
+	})
+	t.Run("Sections", func(t *testing.T) {
+		s := &githubPRSummary{
+			newFailures: []githubPRSummaryEntry{{variant: "ubuntu", taskName: "test", taskURL: "https://task", testName: "test_divide", testURL: "https://logs"}},
+			fixed:       []githubPRSummaryEntry{{variant: "ubuntu", taskName: "lint", taskURL: "https://lint"}},
+		}
+		body := s.render("header", fullDiffURL)
+		assert.Contains(t, body, "### New failures (1)\n- [ubuntu / test](https://task): [test_divide](https://logs)\n")
+		assert.Contains(t, body, "### Fixed (1)\n- [ubuntu / lint](https://lint)\n")
+		assert.NotContains(t, body, "Pre-existing failures")
+		assert.NotContains(t, body, "Runtime regressions")
+	})
+	t.Run("TruncatesLongSections", func(t *testing.T) {
+		s := &githubPRSummary{}
+		for i := 0; i < maxPRSummaryEntriesPerSection+5; i++ {
+			s.newFailures = append(s.newFailures, githubPRSummaryEntry{variant: "ubuntu", taskName: fmt.Sprintf("t%d", i), taskURL: "https://task"})
+		}
+		body := s.render("header", fullDiffURL)
+		assert.Contains(t, body, fmt.Sprintf("### New failures (%d)", maxPRSummaryEntriesPerSection+5))
+		assert.Contains(t, body, fmt.Sprintf("[ubuntu / t%d]", maxPRSummaryEntriesPerSection-1))
+		assert.NotContains(t, body, fmt.Sprintf("[ubuntu / t%d]", maxPRSummaryEntriesPerSection))
+		assert.Contains(t, body, fmt.Sprintf("- ...and [5 more](%s)", fullDiffURL))
+	})
+	t.Run("TruncatesLongComments", func(t *testing.T) {
+		s := &githubPRSummary{}
+		longName := strings.Repeat("a", 5000)
+		for i := 0; i < maxPRSummaryEntriesPerSection; i++ {
+			s.newFailures = append(s.newFailures, githubPRSummaryEntry{variant: "ubuntu", taskName: "test", taskURL: "https://task", testName: longName})
+			s.preexistingFailures = append(s.preexistingFailures, githubPRSummaryEntry{variant: "ubuntu", taskName: "test", taskURL: "https://task", testName: longName})
+		}
+		body := s.render("header", fullDiffURL)
+		assert.LessOrEqual(t, len(body), maxPRSummaryLength)
+		assert.Contains(t, body, fmt.Sprintf("This summary is truncated. See the [full diff](%s).", fullDiffURL))
+	})
+}
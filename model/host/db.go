@@ -25,6 +25,9 @@ const (
 	// Collection is the name of the MongoDB collection that stores hosts.
 	Collection        = "hosts"
 	VolumesCollection = "volumes"
+	// VolumeSnapshotsCollection is the name of the MongoDB collection that
+	// stores snapshots of volumes.
+	VolumeSnapshotsCollection = "volume_snapshots"
 )
 
 var (
@@ -92,6 +95,8 @@ var (
 	HomeVolumeIDKey                    = bsonutil.MustHaveTag(Host{}, "HomeVolumeID")
 	PortBindingsKey                    = bsonutil.MustHaveTag(Host{}, "PortBindings")
 	IsVirtualWorkstationKey            = bsonutil.MustHaveTag(Host{}, "IsVirtualWorkstation")
+	SpawnHostActivityKey               = bsonutil.MustHaveTag(Host{}, "SpawnHostActivity")
+	AutoStopKey                        = bsonutil.MustHaveTag(Host{}, "AutoStop")
 	SpawnOptionsTaskIDKey              = bsonutil.MustHaveTag(SpawnOptions{}, "TaskID")
 	SpawnOptionsTaskExecutionNumberKey = bsonutil.MustHaveTag(SpawnOptions{}, "TaskExecutionNumber")
 	SpawnOptionsBuildIDKey             = bsonutil.MustHaveTag(SpawnOptions{}, "BuildID")
@@ -108,6 +113,17 @@ var (
 	VolumeMigratingKey                 = bsonutil.MustHaveTag(Volume{}, "Migrating")
 	VolumeAttachmentIDKey              = bsonutil.MustHaveTag(VolumeAttachment{}, "VolumeID")
 	VolumeDeviceNameKey                = bsonutil.MustHaveTag(VolumeAttachment{}, "DeviceName")
+
+	SpawnHostAutoStopIdleStopTimeKey           = bsonutil.MustHaveTag(SpawnHostAutoStopState{}, "IdleStopTime")
+	SpawnHostAutoStopSleepScheduleStopTimeKey  = bsonutil.MustHaveTag(SpawnHostAutoStopState{}, "SleepScheduleStopTime")
+	SpawnHostAutoStopStoppedBySleepScheduleKey = bsonutil.MustHaveTag(SpawnHostAutoStopState{}, "StoppedBySleepSchedule")
+
+	VolumeSnapshotIDKey         = bsonutil.MustHaveTag(VolumeSnapshot{}, "ID")
+	VolumeSnapshotVolumeIDKey   = bsonutil.MustHaveTag(VolumeSnapshot{}, "VolumeID")
+	VolumeSnapshotCreatedByKey  = bsonutil.MustHaveTag(VolumeSnapshot{}, "CreatedBy")
+	VolumeSnapshotStatusKey     = bsonutil.MustHaveTag(VolumeSnapshot{}, "Status")
+	VolumeSnapshotExpirationKey = bsonutil.MustHaveTag(VolumeSnapshot{}, "Expiration")
+	VolumeSnapshotCreatedAtKey  = bsonutil.MustHaveTag(VolumeSnapshot{}, "CreationDate")
 )
 
 var (
@@ -155,6 +155,13 @@ type Host struct {
 	// HomeVolumeSize is the size of the home volume in GB
 	HomeVolumeSize int    `bson:"home_volume_size" json:"home_volume_size"`
 	HomeVolumeID   string `bson:"home_volume_id" json:"home_volume_id"`
+
+	// SpawnHostActivity is the activity on a spawn host that was most
+	// recently reported by its agent monitor.
+	SpawnHostActivity *SpawnHostActivity `bson:"spawn_host_activity,omitempty" json:"spawn_host_activity,omitempty"`
+	// AutoStop tracks the actions that Evergreen has taken to automatically
+	// stop a spawn host.
+	AutoStop SpawnHostAutoStopState `bson:"auto_stop,omitempty" json:"auto_stop,omitempty"`
 }
 
 type Tag struct {
@@ -285,6 +292,16 @@ type ProvisionOptions struct {
 
 	// SetupScript runs after other host provisioning is done (i.e. loading task data/artifacts).
 	SetupScript string `bson:"setup_script" json:"setup_script"`
+
+	// ReproduceTask, if set along with TaskId, loads both the task's
+	// artifacts and its task sync data onto the spawn host and writes the
+	// task's non-private expansions and a script of its commands into the
+	// home directory.
+	ReproduceTask bool `bson:"reproduce_task,omitempty" json:"reproduce_task,omitempty"`
+
+	// RunTaskSetup, if set along with ReproduceTask, runs the task's setup
+	// commands once the task's environment has been written to the host.
+	RunTaskSetup bool `bson:"run_task_setup,omitempty" json:"run_task_setup,omitempty"`
 }
 
 // SpawnOptions holds data which the monitor uses to determine when to terminate hosts spawned by tasks.
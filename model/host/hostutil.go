@@ -1035,6 +1035,15 @@ func (h *Host) AgentMonitorOptions(settings *evergreen.Settings) *options.Create
 	}
 }
 
+// SpawnHostActivityMonitorOptions assembles the input to a Jasper request to
+// start the agent monitor on a spawn host so that it reports the host's
+// activity instead of running the agent.
+func (h *Host) SpawnHostActivityMonitorOptions(settings *evergreen.Settings) *options.Create {
+	opts := h.AgentMonitorOptions(settings)
+	opts.Args = append(opts.Args, fmt.Sprintf("--spawn_host_user=%s", h.Distro.User))
+	return opts
+}
+
 // AddPublicKeyScript returns the shell script to add a public key to the
 // authorized keys file on the host. If the public key already exists on the
 // host, the authorized keys file will not be modified.
@@ -1156,6 +1165,12 @@ func (h *Host) SpawnHostPullTaskSyncCommand() []string {
 	}
 }
 
+// SpawnHostTaskEnvironmentDir returns the directory in the home directory of
+// a spawn host that the environment of the task it reproduces is written to.
+func (h *Host) SpawnHostTaskEnvironmentDir() string {
+	return filepath.Join(h.Distro.HomeDir(), "evergreen_task")
+}
+
 const (
 	userDataProvisioningStartedFile = "user_data_started"
 	userDataProvisioningDoneFile    = "user_data_done"
@@ -0,0 +1,162 @@
+package host
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SpawnHostActivity is a report from a spawn host's agent monitor about how
+// the host is being used.
+type SpawnHostActivity struct {
+	// ReportTime is when the agent monitor reported the activity.
+	ReportTime time.Time `bson:"report_time" json:"report_time"`
+	// LastActiveTime is the last time the host was reported to be busy. If
+	// the host has not been busy since the agent monitor started reporting,
+	// it is the time of the first report.
+	LastActiveTime time.Time `bson:"last_active_time" json:"last_active_time"`
+	// SSHSessions is the number of open SSH sessions.
+	SSHSessions int `bson:"ssh_sessions" json:"ssh_sessions"`
+	// LoadAverage is the one-minute load average.
+	LoadAverage float64 `bson:"load_average" json:"load_average"`
+	// ProcessStarts is the number of processes that the host's user started
+	// since the previous report.
+	ProcessStarts int `bson:"process_starts" json:"process_starts"`
+}
+
+// IsBusy returns whether the reported activity indicates that someone is
+// using the host.
+func (a *SpawnHostActivity) IsBusy(loadThreshold float64) bool {
+	return a.SSHSessions > 0 || a.ProcessStarts > 0 || a.LoadAverage >= loadThreshold
+}
+
+// IsStale returns whether the agent monitor has stopped reporting activity,
+// for example because the host was stopped.
+func (a *SpawnHostActivity) IsStale(now time.Time) bool {
+	return now.Sub(a.ReportTime) > 3*evergreen.SpawnHostActivityReportInterval
+}
+
+// SpawnHostAutoStopState tracks the actions that Evergreen has taken to
+// automatically stop a spawn host.
+type SpawnHostAutoStopState struct {
+	// IdleStopTime is when the host will be stopped for being idle. It is
+	// set when the owner is warned and cleared when the host becomes busy.
+	IdleStopTime time.Time `bson:"idle_stop_time,omitempty" json:"idle_stop_time,omitempty"`
+	// SleepScheduleStopTime is when the owner's sleep schedule last stopped
+	// the host.
+	SleepScheduleStopTime time.Time `bson:"sleep_schedule_stop_time,omitempty" json:"sleep_schedule_stop_time,omitempty"`
+	// StoppedBySleepSchedule is whether the host should be started again
+	// when the owner's sleep schedule ends.
+	StoppedBySleepSchedule bool `bson:"stopped_by_sleep_schedule,omitempty" json:"stopped_by_sleep_schedule,omitempty"`
+}
+
+// SpawnHostIdleTimeout returns how long a spawn host can be idle before it is
+// stopped. The shorter of the distro's and the owner's idle timeout wins. If
+// neither sets one, it returns zero and the host is never stopped for being
+// idle.
+func SpawnHostIdleTimeout(settings distro.SpawnHostAutoStopSettings, policy user.SpawnHostAutoStopPolicy) time.Duration {
+	switch {
+	case settings.IdleTimeout <= 0:
+		return policy.IdleTimeout
+	case policy.IdleTimeout <= 0:
+		return settings.IdleTimeout
+	case policy.IdleTimeout < settings.IdleTimeout:
+		return policy.IdleTimeout
+	default:
+		return settings.IdleTimeout
+	}
+}
+
+// SpawnHostIdleTime returns how long the spawn host has been idle. If the
+// host's activity is unknown, it returns zero.
+func (h *Host) SpawnHostIdleTime(now time.Time) time.Duration {
+	if h.SpawnHostActivity == nil || h.SpawnHostActivity.IsStale(now) {
+		return 0
+	}
+	return now.Sub(h.SpawnHostActivity.LastActiveTime)
+}
+
+// RecordSpawnHostActivity saves the activity reported by the spawn host's
+// agent monitor. If the host is busy, any pending idle stop warning is
+// cleared so that the owner is warned again before the host is stopped.
+func (h *Host) RecordSpawnHostActivity(activity SpawnHostActivity, busy bool) error {
+	switch {
+	case busy:
+		activity.LastActiveTime = activity.ReportTime
+	case h.SpawnHostActivity != nil && !h.SpawnHostActivity.IsStale(activity.ReportTime):
+		activity.LastActiveTime = h.SpawnHostActivity.LastActiveTime
+	default:
+		// The host's idle time only counts from when the agent monitor
+		// started reporting, so a host that was just started is not
+		// considered idle for the time it was stopped.
+		activity.LastActiveTime = activity.ReportTime
+	}
+
+	update := bson.M{"$set": bson.M{SpawnHostActivityKey: activity}}
+	if busy {
+		update["$unset"] = bson.M{bsonutil.GetDottedKeyName(AutoStopKey, SpawnHostAutoStopIdleStopTimeKey): 1}
+	}
+	if err := UpdateOne(bson.M{IdKey: h.Id}, update); err != nil {
+		return errors.Wrap(err, "updating spawn host activity")
+	}
+
+	h.SpawnHostActivity = &activity
+	if busy {
+		h.AutoStop.IdleStopTime = time.Time{}
+	}
+	return nil
+}
+
+// SetIdleStopTime records when the host will be stopped for being idle.
+func (h *Host) SetIdleStopTime(t time.Time) error {
+	if err := UpdateOne(bson.M{IdKey: h.Id}, bson.M{
+		"$set": bson.M{bsonutil.GetDottedKeyName(AutoStopKey, SpawnHostAutoStopIdleStopTimeKey): t},
+	}); err != nil {
+		return errors.Wrap(err, "setting idle stop time")
+	}
+	h.AutoStop.IdleStopTime = t
+	return nil
+}
+
+// SetStoppedBySleepSchedule records that the owner's sleep schedule stopped
+// the host, so that it is started again when the schedule ends.
+func (h *Host) SetStoppedBySleepSchedule(t time.Time) error {
+	if err := UpdateOne(bson.M{IdKey: h.Id}, bson.M{
+		"$set": bson.M{
+			bsonutil.GetDottedKeyName(AutoStopKey, SpawnHostAutoStopSleepScheduleStopTimeKey):  t,
+			bsonutil.GetDottedKeyName(AutoStopKey, SpawnHostAutoStopStoppedBySleepScheduleKey): true,
+		},
+	}); err != nil {
+		return errors.Wrap(err, "setting sleep schedule stop")
+	}
+	h.AutoStop.SleepScheduleStopTime = t
+	h.AutoStop.StoppedBySleepSchedule = true
+	return nil
+}
+
+// UnsetStoppedBySleepSchedule records that the host no longer needs to be
+// started when the owner's sleep schedule ends.
+func (h *Host) UnsetStoppedBySleepSchedule() error {
+	if err := UpdateOne(bson.M{IdKey: h.Id}, bson.M{
+		"$unset": bson.M{bsonutil.GetDottedKeyName(AutoStopKey, SpawnHostAutoStopStoppedBySleepScheduleKey): 1},
+	}); err != nil {
+		return errors.Wrap(err, "unsetting sleep schedule stop")
+	}
+	h.AutoStop.StoppedBySleepSchedule = false
+	return nil
+}
+
+// FindSpawnHostsToAutoStop finds all the spawn hosts that could be
+// automatically stopped or started again.
+func FindSpawnHostsToAutoStop() ([]Host, error) {
+	return Find(db.Query(bson.M{
+		UserHostKey: true,
+		StatusKey:   bson.M{"$in": []string{evergreen.HostRunning, evergreen.HostStopped}},
+	}))
+}
@@ -0,0 +1,105 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnHostIdleTimeout(t *testing.T) {
+	assert.Zero(t, SpawnHostIdleTimeout(distro.SpawnHostAutoStopSettings{}, user.SpawnHostAutoStopPolicy{}))
+	assert.Equal(t, time.Hour, SpawnHostIdleTimeout(distro.SpawnHostAutoStopSettings{IdleTimeout: time.Hour}, user.SpawnHostAutoStopPolicy{}))
+	assert.Equal(t, 2*time.Hour, SpawnHostIdleTimeout(distro.SpawnHostAutoStopSettings{}, user.SpawnHostAutoStopPolicy{IdleTimeout: 2 * time.Hour}))
+	assert.Equal(t, time.Hour, SpawnHostIdleTimeout(distro.SpawnHostAutoStopSettings{IdleTimeout: 3 * time.Hour}, user.SpawnHostAutoStopPolicy{IdleTimeout: time.Hour}))
+	assert.Equal(t, time.Hour, SpawnHostIdleTimeout(distro.SpawnHostAutoStopSettings{IdleTimeout: time.Hour}, user.SpawnHostAutoStopPolicy{IdleTimeout: 3 * time.Hour}))
+}
+
+func TestRecordSpawnHostActivity(t *testing.T) {
+	defer func() {
+		assert.NoError(t, db.Clear(Collection))
+	}()
+
+	for tName, tCase := range map[string]func(t *testing.T, h *Host){
+		"FirstReportStartsIdleTime": func(t *testing.T, h *Host) {
+			now := time.Now().Round(time.Millisecond)
+			require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: now}, false))
+
+			dbHost, err := FindOneId(h.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost.SpawnHostActivity)
+			assert.True(t, now.Equal(dbHost.SpawnHostActivity.LastActiveTime))
+		},
+		"IdleReportKeepsLastActiveTime": func(t *testing.T, h *Host) {
+			lastActive := time.Now().Add(-time.Hour).Round(time.Millisecond)
+			require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: lastActive}, true))
+			for reportTime := lastActive.Add(evergreen.SpawnHostActivityReportInterval); reportTime.Before(time.Now()); reportTime = reportTime.Add(evergreen.SpawnHostActivityReportInterval) {
+				require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: reportTime}, false))
+			}
+
+			dbHost, err := FindOneId(h.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost.SpawnHostActivity)
+			assert.True(t, lastActive.Equal(dbHost.SpawnHostActivity.LastActiveTime))
+			assert.True(t, dbHost.SpawnHostIdleTime(time.Now()) >= time.Hour)
+		},
+		"StaleReportRestartsIdleTime": func(t *testing.T, h *Host) {
+			lastActive := time.Now().Add(-time.Hour).Round(time.Millisecond)
+			require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: lastActive}, true))
+			now := time.Now().Round(time.Millisecond)
+			require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: now}, false))
+
+			dbHost, err := FindOneId(h.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost.SpawnHostActivity)
+			assert.True(t, now.Equal(dbHost.SpawnHostActivity.LastActiveTime))
+		},
+		"BusyReportClearsIdleStopTime": func(t *testing.T, h *Host) {
+			require.NoError(t, h.SetIdleStopTime(time.Now().Add(time.Hour)))
+			require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: time.Now(), SSHSessions: 1}, true))
+			assert.Zero(t, h.AutoStop.IdleStopTime)
+
+			dbHost, err := FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.Zero(t, dbHost.AutoStop.IdleStopTime)
+		},
+		"IdleTimeIsZeroForStaleActivity": func(t *testing.T, h *Host) {
+			require.NoError(t, h.RecordSpawnHostActivity(SpawnHostActivity{ReportTime: time.Now().Add(-time.Hour)}, false))
+			assert.Zero(t, h.SpawnHostIdleTime(time.Now()))
+		},
+		"SetsAndUnsetsStoppedBySleepSchedule": func(t *testing.T, h *Host) {
+			now := time.Now().Round(time.Millisecond)
+			require.NoError(t, h.SetStoppedBySleepSchedule(now))
+
+			dbHost, err := FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.True(t, dbHost.AutoStop.StoppedBySleepSchedule)
+			assert.True(t, now.Equal(dbHost.AutoStop.SleepScheduleStopTime))
+
+			require.NoError(t, h.UnsetStoppedBySleepSchedule())
+			dbHost, err = FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.False(t, dbHost.AutoStop.StoppedBySleepSchedule)
+			assert.True(t, now.Equal(dbHost.AutoStop.SleepScheduleStopTime))
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.Clear(Collection))
+			h := &Host{Id: "h0", UserHost: true, Status: evergreen.HostRunning}
+			require.NoError(t, h.Insert())
+			tCase(t, h)
+		})
+	}
+}
+
+func TestSpawnHostActivityIsBusy(t *testing.T) {
+	assert.False(t, (&SpawnHostActivity{LoadAverage: 0.1}).IsBusy(0.5))
+	assert.True(t, (&SpawnHostActivity{LoadAverage: 0.5}).IsBusy(0.5))
+	assert.True(t, (&SpawnHostActivity{SSHSessions: 1}).IsBusy(0.5))
+	assert.True(t, (&SpawnHostActivity{ProcessStarts: 2}).IsBusy(0.5))
+}
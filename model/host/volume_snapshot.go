@@ -0,0 +1,95 @@
+package host
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// VolumeSnapshotStatusPending indicates that the provider is still
+	// copying the volume's data into the snapshot.
+	VolumeSnapshotStatusPending = "pending"
+	// VolumeSnapshotStatusCompleted indicates that the snapshot can be used
+	// to restore the volume.
+	VolumeSnapshotStatusCompleted = "completed"
+	// VolumeSnapshotStatusError indicates that the provider failed to create
+	// the snapshot.
+	VolumeSnapshotStatusError = "error"
+)
+
+// VolumeSnapshot is a point-in-time copy of a volume, which can be restored
+// into a new volume even after the original volume is deleted.
+type VolumeSnapshot struct {
+	ID               string    `bson:"_id" json:"id"`
+	VolumeID         string    `bson:"volume_id" json:"volume_id"`
+	DisplayName      string    `bson:"display_name" json:"display_name"`
+	CreatedBy        string    `bson:"created_by" json:"created_by"`
+	Size             int       `bson:"size" json:"size"`
+	VolumeType       string    `bson:"volume_type" json:"volume_type"`
+	AvailabilityZone string    `bson:"availability_zone" json:"availability_zone"`
+	HomeVolume       bool      `bson:"home_volume" json:"home_volume"`
+	Status           string    `bson:"status" json:"status"`
+	CreationDate     time.Time `bson:"created_at" json:"created_at"`
+	Expiration       time.Time `bson:"expiration" json:"expiration"`
+	// Automatic is true if Evergreen took the snapshot before deleting the
+	// volume because it expired.
+	Automatic bool `bson:"automatic" json:"automatic"`
+}
+
+// Insert a snapshot into the volume snapshots collection.
+func (s *VolumeSnapshot) Insert() error {
+	s.CreationDate = time.Now()
+	return db.Insert(VolumeSnapshotsCollection, s)
+}
+
+// Remove a snapshot from the volume snapshots collection. This does not
+// delete the snapshot from the provider.
+func (s *VolumeSnapshot) Remove() error {
+	return db.Remove(VolumeSnapshotsCollection, bson.M{VolumeSnapshotIDKey: s.ID})
+}
+
+// SetStatus sets the snapshot's status.
+func (s *VolumeSnapshot) SetStatus(status string) error {
+	if err := db.UpdateId(VolumeSnapshotsCollection, s.ID, bson.M{"$set": bson.M{VolumeSnapshotStatusKey: status}}); err != nil {
+		return errors.WithStack(err)
+	}
+	s.Status = status
+	return nil
+}
+
+// FindVolumeSnapshotByID finds a volume snapshot by its ID.
+func FindVolumeSnapshotByID(id string) (*VolumeSnapshot, error) {
+	s := &VolumeSnapshot{}
+	err := db.FindOneQ(VolumeSnapshotsCollection, db.Query(bson.M{VolumeSnapshotIDKey: id}), s)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	return s, err
+}
+
+// FindVolumeSnapshotsByUser finds all the volume snapshots created by the
+// user, newest first.
+func FindVolumeSnapshotsByUser(userID string) ([]VolumeSnapshot, error) {
+	return findVolumeSnapshots(bson.M{VolumeSnapshotCreatedByKey: userID})
+}
+
+// FindVolumeSnapshotsByVolume finds all the snapshots of the volume, newest
+// first.
+func FindVolumeSnapshotsByVolume(volumeID string) ([]VolumeSnapshot, error) {
+	return findVolumeSnapshots(bson.M{VolumeSnapshotVolumeIDKey: volumeID})
+}
+
+// FindVolumeSnapshotsToDelete finds all the volume snapshots that expire
+// before the given time.
+func FindVolumeSnapshotsToDelete(expirationTime time.Time) ([]VolumeSnapshot, error) {
+	return findVolumeSnapshots(bson.M{VolumeSnapshotExpirationKey: bson.M{"$lte": expirationTime}})
+}
+
+func findVolumeSnapshots(q bson.M) ([]VolumeSnapshot, error) {
+	snapshots := []VolumeSnapshot{}
+	return snapshots, db.FindAllQ(VolumeSnapshotsCollection, db.Query(q).Sort([]string{"-" + VolumeSnapshotCreatedAtKey, "-" + VolumeSnapshotIDKey}), &snapshots)
+}
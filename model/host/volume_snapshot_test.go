@@ -0,0 +1,69 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeSnapshots(t *testing.T) {
+	for tName, tCase := range map[string]func(t *testing.T){
+		"FindByUserSortsNewestFirst": func(t *testing.T) {
+			require.NoError(t, (&VolumeSnapshot{ID: "s0", VolumeID: "v0", CreatedBy: "u0"}).Insert())
+			require.NoError(t, (&VolumeSnapshot{ID: "s1", VolumeID: "v1", CreatedBy: "u1"}).Insert())
+			require.NoError(t, (&VolumeSnapshot{ID: "s2", VolumeID: "v0", CreatedBy: "u0"}).Insert())
+
+			snapshots, err := FindVolumeSnapshotsByUser("u0")
+			require.NoError(t, err)
+			require.Len(t, snapshots, 2)
+			assert.Equal(t, "s2", snapshots[0].ID)
+			assert.Equal(t, "s0", snapshots[1].ID)
+		},
+		"FindByVolume": func(t *testing.T) {
+			require.NoError(t, (&VolumeSnapshot{ID: "s0", VolumeID: "v0"}).Insert())
+			require.NoError(t, (&VolumeSnapshot{ID: "s1", VolumeID: "v1"}).Insert())
+
+			snapshots, err := FindVolumeSnapshotsByVolume("v1")
+			require.NoError(t, err)
+			require.Len(t, snapshots, 1)
+			assert.Equal(t, "s1", snapshots[0].ID)
+		},
+		"FindToDeleteReturnsExpiredSnapshots": func(t *testing.T) {
+			now := time.Now()
+			require.NoError(t, (&VolumeSnapshot{ID: "s0", Expiration: now.Add(-time.Hour)}).Insert())
+			require.NoError(t, (&VolumeSnapshot{ID: "s1", Expiration: now.Add(time.Hour)}).Insert())
+
+			snapshots, err := FindVolumeSnapshotsToDelete(now)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 1)
+			assert.Equal(t, "s0", snapshots[0].ID)
+		},
+		"SetStatusAndRemove": func(t *testing.T) {
+			s := &VolumeSnapshot{ID: "s0", Status: VolumeSnapshotStatusPending}
+			require.NoError(t, s.Insert())
+			require.NoError(t, s.SetStatus(VolumeSnapshotStatusCompleted))
+			assert.Equal(t, VolumeSnapshotStatusCompleted, s.Status)
+
+			dbSnapshot, err := FindVolumeSnapshotByID(s.ID)
+			require.NoError(t, err)
+			require.NotZero(t, dbSnapshot)
+			assert.Equal(t, VolumeSnapshotStatusCompleted, dbSnapshot.Status)
+
+			require.NoError(t, s.Remove())
+			dbSnapshot, err = FindVolumeSnapshotByID(s.ID)
+			assert.NoError(t, err)
+			assert.Zero(t, dbSnapshot)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(VolumeSnapshotsCollection))
+			defer func() {
+				assert.NoError(t, db.ClearCollections(VolumeSnapshotsCollection))
+			}()
+			tCase(t)
+		})
+	}
+}
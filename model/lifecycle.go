@@ -620,6 +620,8 @@ func CreateBuildFromVersionNoInsert(creationInfo TaskCreationInfo) (*build.Build
 		rev = creationInfo.Version.Id
 	} else if creationInfo.Version.Requester == evergreen.GitTagRequester {
 		rev = fmt.Sprintf("%s_%s", creationInfo.SourceRev, creationInfo.Version.TriggeredByGitTag.Tag)
+	} else if creationInfo.Version.Requester == evergreen.GithubMergeRequester {
+		rev = fmt.Sprintf("merge_group_%s", creationInfo.Version.Revision)
 	}
 
 	// create a new build id
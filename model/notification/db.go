@@ -70,6 +70,12 @@ func (n *Notification) SetBSON(raw mgobson.Raw) error {
 	case event.GithubPullRequestSubscriberType, event.GithubCheckSubscriberType:
 		n.Payload = &message.GithubStatus{}
 
+	case event.GithubCheckRunSubscriberType:
+		n.Payload = &model.GithubCheckRun{}
+
+	case event.GithubPRCommentSubscriberType:
+		n.Payload = &model.GithubPRSummaryComment{}
+
 	case event.EnqueuePatchSubscriberType:
 		n.Payload = &model.EnqueuePatch{}
 
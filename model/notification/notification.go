@@ -83,7 +83,7 @@ func (n *Notification) SenderKey() (evergreen.SenderKey, error) {
 	case event.GithubPullRequestSubscriberType, event.GithubCheckSubscriberType:
 		return evergreen.SenderGithubStatus, nil
 
-	case event.EnqueuePatchSubscriberType:
+	case event.EnqueuePatchSubscriberType, event.GithubCheckRunSubscriberType, event.GithubPRCommentSubscriberType:
 		return evergreen.SenderGeneric, nil
 	default:
 		return evergreen.SenderEmail, errors.Errorf("unknown type '%s'", n.Subscriber.Type)
@@ -203,6 +203,28 @@ func (n *Notification) Composer(env evergreen.Environment) (message.Composer, er
 		payload.Ref = sub.Ref
 		return message.NewGithubStatusMessageWithRepo(level.Notice, *payload), nil
 
+	case event.GithubCheckRunSubscriberType:
+		sub := n.Subscriber.Target.(*event.GithubCheckSubscriber)
+		payload, ok := n.Payload.(*model.GithubCheckRun)
+		if !ok || payload == nil {
+			return nil, errors.New("github_check_run payload is invalid")
+		}
+		payload.Owner = sub.Owner
+		payload.Repo = sub.Repo
+		payload.Ref = sub.Ref
+		return message.NewGenericMessage(level.Notice, payload, payload.String()), nil
+
+	case event.GithubPRCommentSubscriberType:
+		sub := n.Subscriber.Target.(*event.GithubPullRequestSubscriber)
+		payload, ok := n.Payload.(*model.GithubPRSummaryComment)
+		if !ok || payload == nil {
+			return nil, errors.New("github_pr_comment payload is invalid")
+		}
+		payload.Owner = sub.Owner
+		payload.Repo = sub.Repo
+		payload.PRNumber = sub.PRNumber
+		return message.NewGenericMessage(level.Notice, payload, payload.String()), nil
+
 	case event.EnqueuePatchSubscriberType:
 		payload, ok := n.Payload.(*model.EnqueuePatch)
 		if !ok || payload == nil {
@@ -297,6 +319,8 @@ type NotificationStats struct {
 	Email             int `json:"email" bson:"email" yaml:"email"`
 	Slack             int `json:"slack" bson:"slack" yaml:"slack"`
 	GithubCheck       int `json:"github_check" bson:"github_check" yaml:"github_check"`
+	GithubCheckRun    int `json:"github_check_run" bson:"github_check_run" yaml:"github_check_run"`
+	GithubPRComment   int `json:"github_pr_comment" bson:"github_pr_comment" yaml:"github_pr_comment"`
 	EnqueuePatch      int `json:"enqueue_patch" bson:"enqueue_patch" yaml:"enqueue_patch"`
 }
 
@@ -339,6 +363,12 @@ func CollectUnsentNotificationStats() (*NotificationStats, error) {
 		case event.GithubCheckSubscriberType:
 			nStats.GithubCheck = data.Count
 
+		case event.GithubCheckRunSubscriberType:
+			nStats.GithubCheckRun = data.Count
+
+		case event.GithubPRCommentSubscriberType:
+			nStats.GithubPRComment = data.Count
+
 		case event.JIRAIssueSubscriberType:
 			nStats.JIRAIssue = data.Count
 
@@ -91,6 +91,9 @@ type cliIntent struct {
 	// GitInfo contains information about the author's git environment.
 	GitInfo *GitMetadata `bson:"git_info,omitempty"`
 
+	// BasePatchID is the ID of the patch to stack this patch on top of.
+	BasePatchID string `bson:"base_patch_id,omitempty"`
+
 	// RepeatDefinition reuses the latest patch's task/variants (if no patch ID is provided)
 	RepeatDefinition bool `bson:"reuse_definition"`
 	// RepeatFailed reuses the latest patch's failed tasks (if no patch ID is provided)
@@ -206,6 +209,7 @@ func (c *cliIntent) NewPatch() *Patch {
 		BackportOf:         c.BackportOf,
 		Patches:            []ModulePatch{},
 		GitInfo:            c.GitInfo,
+		BasePatchID:        c.BasePatchID,
 	}
 	if len(c.PatchFileID) > 0 {
 		p.Patches = append(p.Patches,
@@ -241,6 +245,7 @@ type CLIIntentParams struct {
 	RepeatDefinition bool
 	RepeatFailed     bool
 	RepeatPatchId    string
+	BasePatchID      string
 	SyncParams       SyncAtEndOptions
 }
 
@@ -265,6 +270,14 @@ func NewCliIntent(params CLIIntentParams) (Intent, error) {
 			return nil, errors.New("no tasks provided")
 		}
 	}
+	if params.BasePatchID != "" {
+		if !IsValidId(params.BasePatchID) {
+			return nil, errors.Errorf("base patch ID '%s' is not a valid patch ID", params.BasePatchID)
+		}
+		if len(params.BackportOf.PatchID) != 0 || len(params.BackportOf.SHA) != 0 {
+			return nil, errors.New("backport patches can't be stacked on another patch")
+		}
+	}
 	if len(params.SyncParams.BuildVariants) != 0 && len(params.SyncParams.Tasks) == 0 {
 		return nil, errors.New("build variants provided for task sync but task names missing")
 	}
@@ -305,6 +318,7 @@ func NewCliIntent(params CLIIntentParams) (Intent, error) {
 		RepeatDefinition:   params.RepeatDefinition,
 		RepeatFailed:       params.RepeatFailed,
 		RepeatPatchId:      params.RepeatPatchId,
+		BasePatchID:        params.BasePatchID,
 	}, nil
 }
 
@@ -50,6 +50,8 @@ var (
 	githubPatchDataKey      = bsonutil.MustHaveTag(Patch{}, "GithubPatchData")
 	MergePatchKey           = bsonutil.MustHaveTag(Patch{}, "MergePatch")
 	TriggersKey             = bsonutil.MustHaveTag(Patch{}, "Triggers")
+	BasePatchIDKey          = bsonutil.MustHaveTag(Patch{}, "BasePatchID")
+	ImpactedTasksKey        = bsonutil.MustHaveTag(Patch{}, "ImpactedTasks")
 
 	// BSON fields for sync at end struct
 	SyncAtEndOptionsBuildVariantsKey = bsonutil.MustHaveTag(SyncAtEndOptions{}, "BuildVariants")
@@ -62,6 +64,7 @@ var (
 	ModulePatchNameKey    = bsonutil.MustHaveTag(ModulePatch{}, "ModuleName")
 	ModulePatchGithashKey = bsonutil.MustHaveTag(ModulePatch{}, "Githash")
 	ModulePatchSetKey     = bsonutil.MustHaveTag(ModulePatch{}, "PatchSet")
+	ModulePatchBaseKey    = bsonutil.MustHaveTag(ModulePatch{}, "BasePatchID")
 
 	// BSON fields for the patch set struct
 	PatchSetPatchKey   = bsonutil.MustHaveTag(PatchSet{}, "Patch")
@@ -90,6 +90,10 @@ type githubIntent struct {
 
 	// CalledBy indicates whether the intent was created automatically by Evergreen or by a user
 	CalledBy string `bson:"called_by"`
+
+	// Alias is the alias that defines the variants and tasks to run. If
+	// empty, the GitHub PR alias is used.
+	Alias string `bson:"alias,omitempty"`
 }
 
 // BSON fields for the patches
@@ -113,8 +117,9 @@ var (
 )
 
 // NewGithubIntent creates an Intent from a google/go-github PullRequestEvent,
-// or returns an error if the some part of the struct is invalid
-func NewGithubIntent(msgDeliveryID, patchOwner, calledBy string, pr *github.PullRequest) (Intent, error) {
+// or returns an error if the some part of the struct is invalid. If alias is
+// empty, the patch runs the GitHub PR alias.
+func NewGithubIntent(msgDeliveryID, patchOwner, calledBy, alias string, pr *github.PullRequest) (Intent, error) {
 	if pr == nil ||
 		pr.Base == nil || pr.Base.Repo == nil ||
 		pr.Head == nil || pr.Head.Repo == nil || pr.Head.Repo.PushedAt == nil ||
@@ -170,6 +175,7 @@ func NewGithubIntent(msgDeliveryID, patchOwner, calledBy string, pr *github.Pull
 		IntentType:   GithubIntentType,
 		PushedAt:     pr.Head.Repo.PushedAt.Time.UTC(),
 		CalledBy:     calledBy,
+		Alias:        alias,
 	}, nil
 }
 
@@ -257,7 +263,7 @@ func (g *githubIntent) NewPatch() *Patch {
 	pullURL := fmt.Sprintf("https://github.com/%s/pull/%d", g.BaseRepoName, g.PRNumber)
 	patchDoc := &Patch{
 		Id:          mgobson.NewObjectId(),
-		Alias:       evergreen.GithubPRAlias,
+		Alias:       g.GetAlias(),
 		Description: fmt.Sprintf("'%s' pull request #%d by %s: %s (%s)", g.BaseRepoName, g.PRNumber, g.User, g.Title, pullURL),
 		Author:      evergreen.GithubPatchUser,
 		Status:      evergreen.PatchCreated,
@@ -279,5 +285,8 @@ func (g *githubIntent) NewPatch() *Patch {
 }
 
 func (g *githubIntent) GetAlias() string {
+	if g.Alias != "" {
+		return g.Alias
+	}
 	return evergreen.GithubPRAlias
 }
@@ -194,6 +194,14 @@ type Patch struct {
 	// MergedFrom is populated with the patch id of the existing patch
 	// the merged patch is based off of, if applicable.
 	MergedFrom string `bson:"merged_from,omitempty"`
+	// BasePatchID is the ID of the unmerged patch that this patch is stacked
+	// on top of, if any. A stacked patch applies its base patch's changes
+	// before its own and compares its results against the base patch.
+	BasePatchID string `bson:"base_patch_id,omitempty"`
+	// ImpactedTasks records the tasks selected for the patch by the files it
+	// changes and why each one was selected. It is only set for patches that
+	// use the impacted alias.
+	ImpactedTasks []ImpactedTask `bson:"impacted_tasks,omitempty"`
 }
 
 func (p *Patch) MarshalBSON() ([]byte, error)  { return mgobson.Marshal(p) }
@@ -205,6 +213,9 @@ type ModulePatch struct {
 	Githash    string   `bson:"githash"`
 	PatchSet   PatchSet `bson:"patch_set"`
 	IsMbox     bool     `bson:"is_mbox"`
+	// BasePatchID is set on changes inherited from a patch lower in the
+	// stack and identifies the patch that the changes belong to.
+	BasePatchID string `bson:"base_patch_id,omitempty"`
 }
 
 // PatchSet stores information about the actual patch
@@ -215,6 +226,19 @@ type PatchSet struct {
 	Summary        []thirdparty.Summary `bson:"summary"`
 }
 
+// ImpactedTask is a task that change-impact selection picked for a patch.
+type ImpactedTask struct {
+	Variant string `bson:"variant"`
+	Task    string `bson:"task"`
+	// Reasons describe why the task was selected, either because of the
+	// changed files that matched a project rule or because another selected
+	// task depends on it.
+	Reasons []string `bson:"reasons"`
+	// TestTags are the test tags of the rules that selected the task, which
+	// are passed to the task in the impacted_test_tags expansion.
+	TestTags []string `bson:"test_tags,omitempty"`
+}
+
 type TriggerInfo struct {
 	Aliases              []string    `bson:"aliases,omitempty"`
 	ParentPatch          string      `bson:"parent_patch,omitempty"`
@@ -678,7 +702,8 @@ func (p *Patch) UpdateModulePatch(modulePatch ModulePatch) error {
 	// update the in-memory patch
 	patchFound := false
 	for i, patch := range p.Patches {
-		if patch.ModuleName == modulePatch.ModuleName {
+		// Changes inherited from a base patch are never replaced.
+		if patch.ModuleName == modulePatch.ModuleName && patch.BasePatchID == "" {
 			p.Patches[i] = modulePatch
 			patchFound = true
 			break
@@ -690,8 +715,11 @@ func (p *Patch) UpdateModulePatch(modulePatch ModulePatch) error {
 
 	// check that a patch for this module exists
 	query := bson.M{
-		IdKey:                                 p.Id,
-		PatchesKey + "." + ModulePatchNameKey: modulePatch.ModuleName,
+		IdKey: p.Id,
+		PatchesKey: bson.M{"$elemMatch": bson.M{
+			ModulePatchNameKey: modulePatch.ModuleName,
+			ModulePatchBaseKey: bson.M{"$exists": false},
+		}},
 	}
 	update := bson.M{PatchesKey + ".$": modulePatch}
 	result, err := UpdateAll(query, bson.M{"$set": update})
@@ -719,7 +747,10 @@ func (p *Patch) RemoveModulePatch(moduleName string) error {
 	}
 	update := bson.M{
 		"$pull": bson.M{
-			PatchesKey: bson.M{ModulePatchNameKey: moduleName},
+			PatchesKey: bson.M{
+				ModulePatchNameKey: moduleName,
+				ModulePatchBaseKey: bson.M{"$exists": false},
+			},
 		},
 	}
 	return UpdateOne(query, update)
@@ -761,6 +792,59 @@ func (p *Patch) IsBackport() bool {
 	return len(p.BackportOf.PatchID) != 0 || len(p.BackportOf.SHA) != 0
 }
 
+// IsStacked returns whether the patch is stacked on top of another patch.
+func (p *Patch) IsStacked() bool {
+	return p.BasePatchID != ""
+}
+
+// StackOn makes the patch apply on top of the given base patch. The patch
+// uses the base patch's revision and applies the base patch's changes,
+// including the changes it inherited from its own base patches, before its
+// own.
+func (p *Patch) StackOn(base *Patch) {
+	p.BasePatchID = base.Id.Hex()
+	p.Githash = base.Githash
+
+	patches := make([]ModulePatch, 0, len(base.Patches)+len(p.Patches))
+	for _, modulePatch := range base.Patches {
+		if modulePatch.BasePatchID == "" {
+			modulePatch.BasePatchID = base.Id.Hex()
+		}
+		patches = append(patches, modulePatch)
+	}
+	for _, modulePatch := range p.Patches {
+		if modulePatch.ModuleName == "" {
+			modulePatch.Githash = base.Githash
+		}
+		patches = append(patches, modulePatch)
+	}
+	p.Patches = patches
+}
+
+// FindBasePatches returns the patches that the patch is stacked on, ordered
+// from the bottom of the stack to the patch's immediate base patch.
+func (p *Patch) FindBasePatches() ([]Patch, error) {
+	var stack []Patch
+	seen := map[string]bool{p.Id.Hex(): true}
+	for basePatchID := p.BasePatchID; basePatchID != ""; {
+		if seen[basePatchID] {
+			return nil, errors.Errorf("patch '%s' is stacked on itself", basePatchID)
+		}
+		seen[basePatchID] = true
+
+		basePatch, err := FindOneId(basePatchID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding base patch '%s'", basePatchID)
+		}
+		if basePatch == nil {
+			return nil, errors.Errorf("base patch '%s' not found", basePatchID)
+		}
+		stack = append([]Patch{*basePatch}, stack...)
+		basePatchID = basePatch.BasePatchID
+	}
+	return stack, nil
+}
+
 func (p *Patch) IsChild() bool {
 	return p.Triggers.ParentPatch != ""
 }
@@ -946,3 +946,98 @@ func TestSetChildPatches(t *testing.T) {
 	assert.Equal(dbPatch.Triggers.ChildPatches[1], "id_1")
 	assert.Equal(dbPatch.Triggers.ChildPatches[2], "id_2")
 }
+
+func TestStackOn(t *testing.T) {
+	base := Patch{
+		Id:      bson.NewObjectId(),
+		Githash: "base_hash",
+		Patches: []ModulePatch{
+			{ModuleName: "", Githash: "base_hash", PatchSet: PatchSet{PatchFileId: "bottom"}, BasePatchID: "bottom_patch"},
+			{ModuleName: "", Githash: "base_hash", PatchSet: PatchSet{PatchFileId: "base"}},
+			{ModuleName: "module", Githash: "module_hash", PatchSet: PatchSet{PatchFileId: "base_module"}},
+		},
+	}
+	p := Patch{
+		Id:      bson.NewObjectId(),
+		Githash: "other_hash",
+		Patches: []ModulePatch{
+			{ModuleName: "", Githash: "other_hash", PatchSet: PatchSet{PatchFileId: "stacked"}},
+		},
+	}
+	assert.False(t, p.IsStacked())
+
+	p.StackOn(&base)
+	assert.True(t, p.IsStacked())
+	assert.Equal(t, base.Id.Hex(), p.BasePatchID)
+	assert.Equal(t, "base_hash", p.Githash)
+	require.Len(t, p.Patches, 4)
+	assert.Equal(t, "bottom", p.Patches[0].PatchSet.PatchFileId)
+	assert.Equal(t, "bottom_patch", p.Patches[0].BasePatchID)
+	assert.Equal(t, "base", p.Patches[1].PatchSet.PatchFileId)
+	assert.Equal(t, base.Id.Hex(), p.Patches[1].BasePatchID)
+	assert.Equal(t, "base_module", p.Patches[2].PatchSet.PatchFileId)
+	assert.Equal(t, base.Id.Hex(), p.Patches[2].BasePatchID)
+	assert.Equal(t, "stacked", p.Patches[3].PatchSet.PatchFileId)
+	assert.Equal(t, "base_hash", p.Patches[3].Githash)
+	assert.Empty(t, p.Patches[3].BasePatchID)
+	assert.Empty(t, base.Patches[1].BasePatchID, "base patch should not be modified")
+}
+
+func TestFindBasePatches(t *testing.T) {
+	require.NoError(t, db.ClearCollections(Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection))
+	}()
+
+	bottom := Patch{Id: bson.NewObjectId()}
+	middle := Patch{Id: bson.NewObjectId(), BasePatchID: bottom.Id.Hex()}
+	top := Patch{Id: bson.NewObjectId(), BasePatchID: middle.Id.Hex()}
+	for _, p := range []Patch{bottom, middle, top} {
+		require.NoError(t, p.Insert())
+	}
+
+	stack, err := top.FindBasePatches()
+	require.NoError(t, err)
+	require.Len(t, stack, 2)
+	assert.Equal(t, bottom.Id, stack[0].Id)
+	assert.Equal(t, middle.Id, stack[1].Id)
+
+	stack, err = bottom.FindBasePatches()
+	assert.NoError(t, err)
+	assert.Empty(t, stack)
+
+	missing := Patch{Id: bson.NewObjectId(), BasePatchID: bson.NewObjectId().Hex()}
+	_, err = missing.FindBasePatches()
+	assert.Error(t, err)
+}
+
+func TestUpdateModulePatchKeepsBasePatchChanges(t *testing.T) {
+	require.NoError(t, db.ClearCollections(Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection))
+	}()
+
+	p := Patch{
+		Id: bson.NewObjectId(),
+		Patches: []ModulePatch{
+			{ModuleName: "module", PatchSet: PatchSet{PatchFileId: "base"}, BasePatchID: "base_patch"},
+		},
+	}
+	require.NoError(t, p.Insert())
+
+	require.NoError(t, p.UpdateModulePatch(ModulePatch{ModuleName: "module", PatchSet: PatchSet{PatchFileId: "first"}}))
+	require.NoError(t, p.UpdateModulePatch(ModulePatch{ModuleName: "module", PatchSet: PatchSet{PatchFileId: "second"}}))
+	dbPatch, err := FindOneId(p.Id.Hex())
+	require.NoError(t, err)
+	require.NotNil(t, dbPatch)
+	require.Len(t, dbPatch.Patches, 2)
+	assert.Equal(t, "base", dbPatch.Patches[0].PatchSet.PatchFileId)
+	assert.Equal(t, "second", dbPatch.Patches[1].PatchSet.PatchFileId)
+
+	require.NoError(t, p.RemoveModulePatch("module"))
+	dbPatch, err = FindOneId(p.Id.Hex())
+	require.NoError(t, err)
+	require.NotNil(t, dbPatch)
+	require.Len(t, dbPatch.Patches, 1)
+	assert.Equal(t, "base", dbPatch.Patches[0].PatchSet.PatchFileId)
+}
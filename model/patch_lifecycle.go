@@ -374,98 +374,114 @@ func getProjectConfigYAML(p *patch.Patch, projectFileBytes []byte) (string, erro
 // MakePatchedConfig takes in the project's remote file path containing the
 // project YAML configuration and a stringified version of the project YAML
 // configuration, and returns an unmarshalled version of the project with the
-// patch applied.
+// patch applied. Stacked patches have multiple changes to the main project,
+// which are applied in order.
 func MakePatchedConfig(ctx context.Context, env evergreen.Environment, p *patch.Patch, remoteConfigPath, projectConfig string) ([]byte, error) {
+	var patched bool
 	for _, patchPart := range p.Patches {
 		// we only need to patch the main project and not any other modules
 		if patchPart.ModuleName != "" {
 			continue
 		}
 
-		var patchFilePath string
-		var err error
-		if patchPart.PatchSet.Patch == "" {
-			var patchContents string
-			patchContents, err = patch.FetchPatchContents(patchPart.PatchSet.PatchFileId)
-			if err != nil {
-				return nil, errors.Wrap(err, "fetching patch contents")
-			}
-			patchFilePath, err = util.WriteToTempFile(patchContents)
-			if err != nil {
-				return nil, errors.Wrap(err, "writing temporary patch file")
-			}
-		} else {
-			patchFilePath, err = util.WriteToTempFile(patchPart.PatchSet.Patch)
-			if err != nil {
-				return nil, errors.Wrap(err, "writing to temporary patch file")
-			}
-		}
-
-		defer os.Remove(patchFilePath) //nolint:evg-lint
-		// write project configuration
-		configFilePath, err := util.WriteToTempFile(projectConfig)
+		data, err := applyPatchToConfig(ctx, env, p, patchPart, remoteConfigPath, projectConfig)
 		if err != nil {
-			return nil, errors.Wrap(err, "writing config file")
+			return nil, err
 		}
-		defer os.Remove(configFilePath) //nolint:evg-lint
+		projectConfig = string(data)
+		patched = true
+	}
+	if !patched {
+		return nil, errors.New("no patch on project")
+	}
+	if projectConfig == "" {
+		return nil, errors.New(EmptyConfigurationError)
+	}
+	return []byte(projectConfig), nil
+}
 
-		// clean the working directory
-		workingDirectory := filepath.Dir(patchFilePath)
-		localConfigPath := filepath.Join(
-			workingDirectory,
-			remoteConfigPath,
-		)
-		parentDir := strings.Split(
-			remoteConfigPath,
-			string(os.PathSeparator),
-		)[0]
-		err = os.RemoveAll(filepath.Join(workingDirectory, parentDir))
+// applyPatchToConfig applies a single change to the main project to the
+// project YAML configuration.
+func applyPatchToConfig(ctx context.Context, env evergreen.Environment, p *patch.Patch, patchPart patch.ModulePatch, remoteConfigPath, projectConfig string) ([]byte, error) {
+	var patchFilePath string
+	var err error
+	if patchPart.PatchSet.Patch == "" {
+		var patchContents string
+		patchContents, err = patch.FetchPatchContents(patchPart.PatchSet.PatchFileId)
 		if err != nil {
-			return nil, errors.WithStack(err)
+			return nil, errors.Wrap(err, "fetching patch contents")
 		}
-		if err = os.MkdirAll(filepath.Dir(localConfigPath), 0755); err != nil {
-			return nil, errors.WithStack(err)
+		patchFilePath, err = util.WriteToTempFile(patchContents)
+		if err != nil {
+			return nil, errors.Wrap(err, "writing temporary patch file")
 		}
-		// rename the temporary config file name to the remote config
-		// file path if we are patching an existing remote config
-		if len(projectConfig) > 0 {
-			if err = os.Rename(configFilePath, localConfigPath); err != nil {
-				return nil, errors.Wrapf(err, "renaming file '%s' to '%s'", configFilePath, localConfigPath)
-			}
-			defer os.Remove(localConfigPath)
+	} else {
+		patchFilePath, err = util.WriteToTempFile(patchPart.PatchSet.Patch)
+		if err != nil {
+			return nil, errors.Wrap(err, "writing to temporary patch file")
 		}
+	}
 
-		// selectively apply the patch to the config file
-		patchCommandStrings := []string{
-			"set -o errexit",
-			fmt.Sprintf("git apply --whitespace=fix --include=%v < '%v'",
-				remoteConfigPath, patchFilePath),
+	defer os.Remove(patchFilePath)
+	// write project configuration
+	configFilePath, err := util.WriteToTempFile(projectConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "writing config file")
+	}
+	defer os.Remove(configFilePath)
+
+	// clean the working directory
+	workingDirectory := filepath.Dir(patchFilePath)
+	localConfigPath := filepath.Join(
+		workingDirectory,
+		remoteConfigPath,
+	)
+	parentDir := strings.Split(
+		remoteConfigPath,
+		string(os.PathSeparator),
+	)[0]
+	err = os.RemoveAll(filepath.Join(workingDirectory, parentDir))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err = os.MkdirAll(filepath.Dir(localConfigPath), 0755); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// rename the temporary config file name to the remote config
+	// file path if we are patching an existing remote config
+	if len(projectConfig) > 0 {
+		if err = os.Rename(configFilePath, localConfigPath); err != nil {
+			return nil, errors.Wrapf(err, "renaming file '%s' to '%s'", configFilePath, localConfigPath)
 		}
+		defer os.Remove(localConfigPath)
+	}
 
-		output := util.NewMBCappedWriter()
-		err = env.JasperManager().CreateCommand(ctx).Add([]string{"bash", "-c", strings.Join(patchCommandStrings, "\n")}).
-			Directory(workingDirectory).SetCombinedWriter(output).Run(ctx)
-		if err != nil {
-			grip.Error(message.WrapError(err, message.Fields{
-				"message":       "error running patch command",
-				"patch_id":      p.Id.Hex(),
-				"output":        output.String(),
-				"patch_command": patchCommandStrings,
-			}))
-			return nil, errors.Wrap(err, "running patch command (possibly due to merge conflict on evergreen configuration file)")
-		}
+	// selectively apply the patch to the config file
+	patchCommandStrings := []string{
+		"set -o errexit",
+		fmt.Sprintf("git apply --whitespace=fix --include=%v < '%v'",
+			remoteConfigPath, patchFilePath),
+	}
 
-		// read in the patched config file
-		data, err := os.ReadFile(localConfigPath)
-		if err != nil {
-			return nil, errors.Wrap(err, "reading patched config file")
-		}
-		if string(data) == "" {
-			return nil, errors.New(EmptyConfigurationError)
-		}
-		return data, nil
+	output := util.NewMBCappedWriter()
+	err = env.JasperManager().CreateCommand(ctx).Add([]string{"bash", "-c", strings.Join(patchCommandStrings, "\n")}).
+		Directory(workingDirectory).SetCombinedWriter(output).Run(ctx)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message":       "error running patch command",
+			"patch_id":      p.Id.Hex(),
+			"output":        output.String(),
+			"patch_command": patchCommandStrings,
+		}))
+		return nil, errors.Wrap(err, "running patch command (possibly due to merge conflict on evergreen configuration file)")
 	}
-	return nil, errors.New("no patch on project")
+
+	// read in the patched config file
+	data, err := os.ReadFile(localConfigPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading patched config file")
+	}
+	return data, nil
 }
 
 // FinalizePatch finalizes a patch:
@@ -555,6 +571,17 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		return nil, errors.Wrap(err, "fetching patch parameters")
 	}
 
+	resolvedAxes, err := intermediateProject.ResolveMatrixAxes(projectRef.Id, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving matrix axes")
+	}
+	if resolvedAxes {
+		project, err = TranslateProject(intermediateProject)
+		if err != nil {
+			return nil, errors.Wrapf(err, "translating project with resolved matrix axes for patch '%s'", p.Id.Hex())
+		}
+	}
+
 	authorEmail := ""
 	if p.GitInfo != nil {
 		authorEmail = p.GitInfo.Email
@@ -692,7 +719,9 @@ func FinalizePatch(ctx context.Context, p *patch.Patch, requester string, github
 		// unfinalized patches, try storing the parser project in in the DB.
 		ppStorageMethod = evergreen.ProjectStorageMethodDB
 	}
-	if mustInsertParserProjectDuringFinalization {
+	// The parser project must also be updated if matrix axes were resolved
+	// so that the version keeps the resolved values.
+	if mustInsertParserProjectDuringFinalization || resolvedAxes {
 		intermediateProject.Init(p.Id.Hex(), patchVersion.CreateTime)
 		ppStorageMethod, err = ParserProjectUpsertOneWithS3Fallback(ctx, settings, ppStorageMethod, intermediateProject)
 		if err != nil {
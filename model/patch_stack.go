@@ -0,0 +1,71 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FindPatchBaseVersionID returns the ID of the version that a stacked patch's
+// results are compared to, which is its base patch's version. It returns an
+// empty string if the base patch hasn't been finalized yet.
+func FindPatchBaseVersionID(p *patch.Patch) (string, error) {
+	if !p.IsStacked() {
+		return "", errors.Errorf("patch '%s' is not stacked on another patch", p.Id.Hex())
+	}
+	basePatch, err := patch.FindOneId(p.BasePatchID)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding base patch '%s'", p.BasePatchID)
+	}
+	if basePatch == nil {
+		return "", errors.Errorf("base patch '%s' not found", p.BasePatchID)
+	}
+	return basePatch.Version, nil
+}
+
+// FindPatchBaseBuilds returns the builds that a patch's builds are compared
+// to. A stacked patch is compared to its base patch and any other patch is
+// compared to its base commit.
+func FindPatchBaseBuilds(p *patch.Patch) ([]build.Build, error) {
+	if !p.IsStacked() {
+		return build.Find(build.ByRevisionWithSystemVersionRequester(p.Githash))
+	}
+	versionID, err := FindPatchBaseVersionID(p)
+	if err != nil || versionID == "" {
+		return nil, err
+	}
+	return build.Find(build.ByVersion(versionID))
+}
+
+// FindPatchBaseBuild returns the build that the given patch build is compared
+// to, if any.
+func FindPatchBaseBuild(p *patch.Patch, b *build.Build) (*build.Build, error) {
+	if !p.IsStacked() {
+		return b.FindBuildOnBaseCommit()
+	}
+	versionID, err := FindPatchBaseVersionID(p)
+	if err != nil || versionID == "" {
+		return nil, err
+	}
+	return build.FindOne(build.ByVersionAndVariant(versionID, b.BuildVariant))
+}
+
+// FindPatchBaseTask returns the task that the given patch task is compared
+// to, if any.
+func FindPatchBaseTask(p *patch.Patch, t *task.Task) (*task.Task, error) {
+	if !p.IsStacked() {
+		return t.FindTaskOnBaseCommit()
+	}
+	versionID, err := FindPatchBaseVersionID(p)
+	if err != nil || versionID == "" {
+		return nil, err
+	}
+	return task.FindOne(db.Query(bson.M{
+		task.VersionKey:      versionID,
+		task.BuildVariantKey: t.BuildVariant,
+		task.DisplayNameKey:  t.DisplayName,
+	}))
+}
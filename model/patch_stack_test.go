@@ -0,0 +1,87 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPatchBase(t *testing.T) {
+	require.NoError(t, db.ClearCollections(patch.Collection, build.Collection, task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(patch.Collection, build.Collection, task.Collection))
+	}()
+
+	basePatch := patch.Patch{Id: mgobson.NewObjectId(), Githash: "abc", Version: "base_version"}
+	require.NoError(t, basePatch.Insert())
+	stackedPatch := patch.Patch{Id: mgobson.NewObjectId(), Githash: "abc", Version: "stacked_version", BasePatchID: basePatch.Id.Hex()}
+	require.NoError(t, stackedPatch.Insert())
+	unfinalizedPatch := patch.Patch{Id: mgobson.NewObjectId(), Githash: "abc"}
+	require.NoError(t, unfinalizedPatch.Insert())
+
+	for _, b := range []build.Build{
+		{Id: "commit_build", Version: "commit_version", Revision: "abc", BuildVariant: "ubuntu", Requester: evergreen.RepotrackerVersionRequester},
+		{Id: "base_build", Version: "base_version", Revision: "abc", BuildVariant: "ubuntu", Requester: evergreen.PatchVersionRequester},
+		{Id: "stacked_build", Version: "stacked_version", Revision: "abc", BuildVariant: "ubuntu", Requester: evergreen.PatchVersionRequester},
+	} {
+		require.NoError(t, b.Insert())
+	}
+	for _, tsk := range []task.Task{
+		{Id: "commit_task", Version: "commit_version", Revision: "abc", BuildVariant: "ubuntu", DisplayName: "test", Project: "proj", Requester: evergreen.RepotrackerVersionRequester},
+		{Id: "base_task", Version: "base_version", Revision: "abc", BuildVariant: "ubuntu", DisplayName: "test", Project: "proj", Requester: evergreen.PatchVersionRequester},
+		{Id: "stacked_task", Version: "stacked_version", Revision: "abc", BuildVariant: "ubuntu", DisplayName: "test", Project: "proj", Requester: evergreen.PatchVersionRequester},
+	} {
+		require.NoError(t, tsk.Insert())
+	}
+	stackedBuild := build.Build{Id: "stacked_build", Version: "stacked_version", Revision: "abc", BuildVariant: "ubuntu"}
+	stackedTask := task.Task{Id: "stacked_task", Version: "stacked_version", Revision: "abc", BuildVariant: "ubuntu", DisplayName: "test", Project: "proj"}
+
+	t.Run("StackedPatchComparesToBasePatch", func(t *testing.T) {
+		versionID, err := FindPatchBaseVersionID(&stackedPatch)
+		require.NoError(t, err)
+		assert.Equal(t, "base_version", versionID)
+
+		builds, err := FindPatchBaseBuilds(&stackedPatch)
+		require.NoError(t, err)
+		require.Len(t, builds, 1)
+		assert.Equal(t, "base_build", builds[0].Id)
+
+		b, err := FindPatchBaseBuild(&stackedPatch, &stackedBuild)
+		require.NoError(t, err)
+		require.NotNil(t, b)
+		assert.Equal(t, "base_build", b.Id)
+
+		tsk, err := FindPatchBaseTask(&stackedPatch, &stackedTask)
+		require.NoError(t, err)
+		require.NotNil(t, tsk)
+		assert.Equal(t, "base_task", tsk.Id)
+	})
+	t.Run("UnstackedPatchComparesToBaseCommit", func(t *testing.T) {
+		builds, err := FindPatchBaseBuilds(&basePatch)
+		require.NoError(t, err)
+		require.Len(t, builds, 1)
+		assert.Equal(t, "commit_build", builds[0].Id)
+
+		tsk, err := FindPatchBaseTask(&basePatch, &stackedTask)
+		require.NoError(t, err)
+		require.NotNil(t, tsk)
+		assert.Equal(t, "commit_task", tsk.Id)
+	})
+	t.Run("UnfinalizedBasePatchHasNothingToCompareTo", func(t *testing.T) {
+		p := patch.Patch{Id: mgobson.NewObjectId(), BasePatchID: unfinalizedPatch.Id.Hex()}
+		builds, err := FindPatchBaseBuilds(&p)
+		assert.NoError(t, err)
+		assert.Empty(t, builds)
+
+		tsk, err := FindPatchBaseTask(&p, &stackedTask)
+		assert.NoError(t, err)
+		assert.Nil(t, tsk)
+	})
+}
@@ -0,0 +1,59 @@
+package perf
+
+import (
+	"math"
+	"time"
+)
+
+// DetectChangePoints runs E-Divisive means change point detection over the
+// points of a series, which must be in ascending order of version, and returns
+// the change points it finds.
+func DetectChangePoints(series []Result, opts EDivisiveOptions) []ChangePoint {
+	values := make([]float64, 0, len(series))
+	for _, r := range series {
+		values = append(values, r.Value)
+	}
+
+	indexes := EDivisive(values, opts)
+	bounds := segmentBounds(indexes, len(values))
+	now := time.Now()
+	changePoints := make([]ChangePoint, 0, len(indexes))
+	for i, idx := range indexes {
+		before := mean(values[bounds[i]:idx])
+		after := mean(values[idx:bounds[i+2]])
+		r := series[idx]
+		changePoints = append(changePoints, ChangePoint{
+			ID:            NewChangePointID(r.SeriesKey, r.Version),
+			SeriesKey:     r.SeriesKey,
+			Version:       r.Version,
+			Order:         r.Order,
+			TaskID:        r.TaskID,
+			Execution:     r.Execution,
+			MeanBefore:    before,
+			MeanAfter:     after,
+			PercentChange: percentChange(before, after),
+			CreateTime:    now,
+		})
+	}
+	return changePoints
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// percentChange returns the percent change from before to after. The change
+// from zero is undefined, so it is reported as no change.
+func percentChange(before, after float64) float64 {
+	if before == 0 {
+		return 0
+	}
+	return (after - before) / math.Abs(before) * 100
+}
@@ -0,0 +1,78 @@
+package perf
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noisySeries(rng *rand.Rand, means ...float64) []float64 {
+	var series []float64
+	for _, m := range means {
+		for i := 0; i < 20; i++ {
+			series = append(series, m+rng.NormFloat64())
+		}
+	}
+	return series
+}
+
+func TestEDivisive(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+
+	t.Run("FindsNothingInConstantSeries", func(t *testing.T) {
+		assert.Empty(t, EDivisive([]float64{5, 5, 5, 5, 5, 5, 5, 5, 5, 5}, EDivisiveOptions{}))
+	})
+	t.Run("FindsNothingInNoise", func(t *testing.T) {
+		assert.Empty(t, EDivisive(noisySeries(rng, 100, 100), EDivisiveOptions{}))
+	})
+	t.Run("FindsSingleShift", func(t *testing.T) {
+		assert.Equal(t, []int{20}, EDivisive(noisySeries(rng, 100, 120), EDivisiveOptions{}))
+	})
+	t.Run("FindsMultipleShifts", func(t *testing.T) {
+		assert.Equal(t, []int{20, 40}, EDivisive(noisySeries(rng, 100, 120, 90), EDivisiveOptions{}))
+	})
+	t.Run("IsDeterministic", func(t *testing.T) {
+		series := noisySeries(rng, 100, 103, 100)
+		assert.Equal(t, EDivisive(series, EDivisiveOptions{}), EDivisive(series, EDivisiveOptions{}))
+	})
+	t.Run("RespectsMinimumSize", func(t *testing.T) {
+		assert.Empty(t, EDivisive([]float64{1, 1, 1, 1, 1, 100, 100}, EDivisiveOptions{MinSize: 3}))
+	})
+	t.Run("FindsNothingInShortSeries", func(t *testing.T) {
+		assert.Empty(t, EDivisive([]float64{1, 100}, EDivisiveOptions{}))
+	})
+}
+
+func TestDetectChangePoints(t *testing.T) {
+	key := SeriesKey{Project: "project", Variant: "variant", Task: "task", Test: "test", Measurement: "latency"}
+	var series []Result
+	for i, v := range noisySeries(rand.New(rand.NewSource(7)), 100, 150) {
+		series = append(series, Result{
+			SeriesKey: key,
+			Version:   fmt.Sprintf("v%d", i),
+			Order:     i + 1,
+			TaskID:    fmt.Sprintf("t%d", i),
+			Value:     v,
+		})
+	}
+
+	changePoints := DetectChangePoints(series, EDivisiveOptions{})
+	require.Len(t, changePoints, 1)
+	cp := changePoints[0]
+	assert.Equal(t, NewChangePointID(key, "v20"), cp.ID)
+	assert.Equal(t, key, cp.SeriesKey)
+	assert.Equal(t, "v20", cp.Version)
+	assert.Equal(t, 21, cp.Order)
+	assert.Equal(t, "t20", cp.TaskID)
+	assert.InDelta(t, 100, cp.MeanBefore, 1)
+	assert.InDelta(t, 150, cp.MeanAfter, 1)
+	assert.InDelta(t, 50, cp.PercentChange, 2)
+}
+
+func TestFormatArgs(t *testing.T) {
+	assert.Empty(t, FormatArgs(nil))
+	assert.Equal(t, "size=2,threads=8", FormatArgs(map[string]int32{"threads": 8, "size": 2}))
+}
@@ -0,0 +1,155 @@
+package perf
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	defaultEDivisivePValue       = 0.05
+	defaultEDivisivePermutations = 100
+	defaultEDivisiveMinSize      = 3
+	defaultEDivisiveSeed         = 1
+)
+
+// EDivisiveOptions configure E-Divisive means change point detection.
+type EDivisiveOptions struct {
+	// PValue is the significance level a candidate change point must meet to
+	// be accepted. Defaults to 0.05.
+	PValue float64
+	// Permutations is the number of random permutations of the series used to
+	// estimate the significance of a candidate change point. Defaults to 100.
+	Permutations int
+	// MinSize is the minimum number of points between change points, and
+	// between a change point and the ends of the series. Defaults to 3 and
+	// cannot be less than 2.
+	MinSize int
+	// Seed seeds the permutations so that detection is deterministic for a
+	// given series. Defaults to 1.
+	Seed int64
+}
+
+func (o *EDivisiveOptions) setDefaults() {
+	if o.PValue <= 0 {
+		o.PValue = defaultEDivisivePValue
+	}
+	if o.Permutations <= 0 {
+		o.Permutations = defaultEDivisivePermutations
+	}
+	if o.MinSize < 2 {
+		o.MinSize = defaultEDivisiveMinSize
+	}
+	if o.Seed == 0 {
+		o.Seed = defaultEDivisiveSeed
+	}
+}
+
+// EDivisive finds the change points in the series using the E-Divisive means
+// algorithm (Matteson and James, 2014). It repeatedly bisects the segments of
+// the series at the point that maximizes the divergence in distribution
+// between the two sides, and stops once the best candidate is no longer
+// statistically significant according to a permutation test. It returns the
+// indexes of the change points in ascending order, where an index i means that
+// the distribution of the series changes starting at series[i].
+func EDivisive(series []float64, opts EDivisiveOptions) []int {
+	opts.setDefaults()
+	rng := rand.New(rand.NewSource(opts.Seed))
+
+	var changePoints []int
+	permuted := make([]float64, len(series))
+	for {
+		bounds := segmentBounds(changePoints, len(series))
+		candidate, q := bestChangePoint(series, bounds, opts.MinSize)
+		if candidate < 0 || q <= 0 {
+			break
+		}
+
+		// The candidate is significant if bisecting randomly permuted
+		// segments rarely diverges as much as it does.
+		exceeded := 0
+		for i := 0; i < opts.Permutations; i++ {
+			copy(permuted, series)
+			for j := 0; j+1 < len(bounds); j++ {
+				segment := permuted[bounds[j]:bounds[j+1]]
+				rng.Shuffle(len(segment), func(a, b int) { segment[a], segment[b] = segment[b], segment[a] })
+			}
+			if _, permutedQ := bestChangePoint(permuted, bounds, opts.MinSize); permutedQ >= q {
+				exceeded++
+			}
+		}
+		if float64(exceeded+1)/float64(opts.Permutations+1) > opts.PValue {
+			break
+		}
+
+		changePoints = append(changePoints, candidate)
+		sort.Ints(changePoints)
+	}
+
+	return changePoints
+}
+
+// segmentBounds returns the boundaries of the segments that the change points
+// split a series of length n into, including 0 and n.
+func segmentBounds(changePoints []int, n int) []int {
+	bounds := make([]int, 0, len(changePoints)+2)
+	bounds = append(bounds, 0)
+	bounds = append(bounds, changePoints...)
+	return append(bounds, n)
+}
+
+// bestChangePoint returns the index that maximizes the E-Divisive divergence
+// statistic over all the segments, along with the statistic. It returns -1 if
+// no segment is large enough to bisect.
+func bestChangePoint(series []float64, bounds []int, minSize int) (int, float64) {
+	best, bestQ := -1, math.Inf(-1)
+	for i := 0; i+1 < len(bounds); i++ {
+		idx, q := bestSegmentChangePoint(series[bounds[i]:bounds[i+1]], minSize)
+		if idx >= 0 && q > bestQ {
+			best, bestQ = bounds[i]+idx, q
+		}
+	}
+	return best, bestQ
+}
+
+// bestSegmentChangePoint returns the index within the segment that maximizes
+// the divergence statistic between the points before and after it.
+func bestSegmentChangePoint(segment []float64, minSize int) (int, float64) {
+	n := len(segment)
+	if n < 2*minSize {
+		return -1, 0
+	}
+
+	// prefix[i*(n+1)+j] is the sum of the distances between segment[a] and
+	// segment[b] for all a < i and b < j, so the sum over any rectangle of the
+	// distance matrix can be computed in constant time.
+	stride := n + 1
+	prefix := make([]float64, stride*stride)
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= n; j++ {
+			prefix[i*stride+j] = math.Abs(segment[i-1]-segment[j-1]) +
+				prefix[(i-1)*stride+j] + prefix[i*stride+j-1] - prefix[(i-1)*stride+j-1]
+		}
+	}
+	rect := func(rowStart, rowEnd, colStart, colEnd int) float64 {
+		return prefix[rowEnd*stride+colEnd] - prefix[rowStart*stride+colEnd] -
+			prefix[rowEnd*stride+colStart] + prefix[rowStart*stride+colStart]
+	}
+
+	best, bestQ := -1, math.Inf(-1)
+	for tau := minSize; tau <= n-minSize; tau++ {
+		left, right := float64(tau), float64(n-tau)
+		between := rect(0, tau, tau, n)
+		withinLeft := rect(0, tau, 0, tau) / 2
+		withinRight := rect(tau, n, tau, n) / 2
+
+		divergence := 2*between/(left*right) -
+			withinLeft/(left*(left-1)/2) -
+			withinRight/(right*(right-1)/2)
+		q := left * right / (left + right) * divergence
+		if q > bestQ {
+			best, bestQ = tau, q
+		}
+	}
+	return best, bestQ
+}
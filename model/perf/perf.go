@@ -0,0 +1,290 @@
+package perf
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// ResultsCollection stores the points of the performance series reported
+	// by tasks.
+	ResultsCollection = "perf_results"
+	// ChangePointsCollection stores the change points detected in mainline
+	// performance series.
+	ChangePointsCollection = "perf_change_points"
+
+	// DefaultSeriesWindow is the number of most recent mainline points of a
+	// series that change point detection considers.
+	DefaultSeriesWindow = 100
+)
+
+// SeriesKey identifies a performance series, which is a single measurement of
+// a test across the mainline versions of a project.
+type SeriesKey struct {
+	Project     string `bson:"project" json:"project"`
+	Variant     string `bson:"variant" json:"variant"`
+	Task        string `bson:"task" json:"task"`
+	Test        string `bson:"test" json:"test"`
+	Measurement string `bson:"measurement" json:"measurement"`
+	// Args distinguishes runs of the same test with different arguments
+	// (e.g. thread counts). It is in the form returned by FormatArgs.
+	Args string `bson:"args,omitempty" json:"args,omitempty"`
+}
+
+// String returns a human-readable description of the series.
+func (k SeriesKey) String() string {
+	s := fmt.Sprintf("%s/%s/%s/%s/%s", k.Project, k.Variant, k.Task, k.Test, k.Measurement)
+	if k.Args != "" {
+		s += fmt.Sprintf(" [%s]", k.Args)
+	}
+	return s
+}
+
+func (k SeriesKey) query() bson.M {
+	q := bson.M{
+		SeriesProjectKey:     k.Project,
+		SeriesVariantKey:     k.Variant,
+		SeriesTaskKey:        k.Task,
+		SeriesTestKey:        k.Test,
+		SeriesMeasurementKey: k.Measurement,
+		SeriesArgsKey:        k.Args,
+	}
+	if k.Args == "" {
+		q[SeriesArgsKey] = bson.M{"$exists": false}
+	}
+	return q
+}
+
+// FormatArgs returns the canonical form of test arguments, which is a
+// comma-separated list of name=value pairs sorted by name.
+func FormatArgs(args map[string]int32) string {
+	pairs := make([]string, 0, len(args))
+	for name, val := range args {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", name, val))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Result is a single point in a performance series, which is the value of a
+// measurement reported by a task.
+type Result struct {
+	ID         string `bson:"_id" json:"id"`
+	SeriesKey  `bson:",inline"`
+	Version    string    `bson:"version" json:"version"`
+	Order      int       `bson:"order" json:"order"`
+	TaskID     string    `bson:"task_id" json:"task_id"`
+	Execution  int       `bson:"execution" json:"execution"`
+	Mainline   bool      `bson:"mainline" json:"mainline"`
+	Value      float64   `bson:"value" json:"value"`
+	CreateTime time.Time `bson:"create_time" json:"create_time"`
+}
+
+// ChangePoint is a point in a mainline performance series where the
+// distribution of its values changed.
+type ChangePoint struct {
+	ID        string `bson:"_id" json:"id"`
+	SeriesKey `bson:",inline"`
+	// Version, Order, TaskID and Execution identify the first point after
+	// the change.
+	Version   string `bson:"version" json:"version"`
+	Order     int    `bson:"order" json:"order"`
+	TaskID    string `bson:"task_id" json:"task_id"`
+	Execution int    `bson:"execution" json:"execution"`
+	// MeanBefore and MeanAfter are the means of the values between the
+	// change point and its neighboring change points (or the ends of the
+	// analyzed series).
+	MeanBefore float64 `bson:"mean_before" json:"mean_before"`
+	MeanAfter  float64 `bson:"mean_after" json:"mean_after"`
+	// PercentChange is the change from MeanBefore to MeanAfter.
+	PercentChange float64   `bson:"percent_change" json:"percent_change"`
+	CreateTime    time.Time `bson:"create_time" json:"create_time"`
+}
+
+var (
+	SeriesProjectKey     = bsonutil.MustHaveTag(SeriesKey{}, "Project")
+	SeriesVariantKey     = bsonutil.MustHaveTag(SeriesKey{}, "Variant")
+	SeriesTaskKey        = bsonutil.MustHaveTag(SeriesKey{}, "Task")
+	SeriesTestKey        = bsonutil.MustHaveTag(SeriesKey{}, "Test")
+	SeriesMeasurementKey = bsonutil.MustHaveTag(SeriesKey{}, "Measurement")
+	SeriesArgsKey        = bsonutil.MustHaveTag(SeriesKey{}, "Args")
+
+	ResultIDKey        = bsonutil.MustHaveTag(Result{}, "ID")
+	ResultOrderKey     = bsonutil.MustHaveTag(Result{}, "Order")
+	ResultTaskIDKey    = bsonutil.MustHaveTag(Result{}, "TaskID")
+	ResultExecutionKey = bsonutil.MustHaveTag(Result{}, "Execution")
+	ResultMainlineKey  = bsonutil.MustHaveTag(Result{}, "Mainline")
+
+	ChangePointIDKey      = bsonutil.MustHaveTag(ChangePoint{}, "ID")
+	ChangePointVersionKey = bsonutil.MustHaveTag(ChangePoint{}, "Version")
+	ChangePointOrderKey   = bsonutil.MustHaveTag(ChangePoint{}, "Order")
+)
+
+var (
+	// ResultsSeriesIndex supports finding the most recent mainline points of
+	// a series.
+	ResultsSeriesIndex = bson.D{
+		{Key: SeriesProjectKey, Value: 1},
+		{Key: SeriesVariantKey, Value: 1},
+		{Key: SeriesTaskKey, Value: 1},
+		{Key: SeriesTestKey, Value: 1},
+		{Key: SeriesMeasurementKey, Value: 1},
+		{Key: SeriesArgsKey, Value: 1},
+		{Key: ResultMainlineKey, Value: 1},
+		{Key: ResultOrderKey, Value: -1},
+	}
+	// ResultsTaskIndex supports finding and removing the results reported by
+	// a task's executions.
+	ResultsTaskIndex = bson.D{
+		{Key: ResultTaskIDKey, Value: 1},
+		{Key: ResultExecutionKey, Value: 1},
+	}
+	// ChangePointsSeriesIndex supports finding the change points in a series
+	// in order.
+	ChangePointsSeriesIndex = bson.D{
+		{Key: SeriesProjectKey, Value: 1},
+		{Key: SeriesVariantKey, Value: 1},
+		{Key: SeriesTaskKey, Value: 1},
+		{Key: SeriesTestKey, Value: 1},
+		{Key: SeriesMeasurementKey, Value: 1},
+		{Key: SeriesArgsKey, Value: 1},
+		{Key: ChangePointOrderKey, Value: 1},
+	}
+	// ChangePointsVersionIndex supports finding the change points detected
+	// at a version.
+	ChangePointsVersionIndex = bson.D{
+		{Key: ChangePointVersionKey, Value: 1},
+	}
+)
+
+// makeID returns a stable ID derived from the given parts.
+func makeID(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// NewResultID returns the ID of the point in the series reported by the task
+// execution, so that reporting the same point again replaces it.
+func NewResultID(taskID string, execution int, key SeriesKey) string {
+	return makeID(taskID, fmt.Sprint(execution), key.Project, key.Variant, key.Task, key.Test, key.Measurement, key.Args)
+}
+
+// NewChangePointID returns the ID of the change point in the series at the
+// version, so that detecting the same change point again is a no-op.
+func NewChangePointID(key SeriesKey, version string) string {
+	return makeID(key.Project, key.Variant, key.Task, key.Test, key.Measurement, key.Args, version)
+}
+
+// SaveResults stores the results reported by a task execution, replacing any
+// previously reported by the same execution. Series only keep the results of
+// the latest execution of a task, so results from its previous executions are
+// removed.
+func SaveResults(results []Result) error {
+	catcher := grip.NewBasicCatcher()
+	latestExecutions := map[string]int{}
+	for _, r := range results {
+		if r.ID == "" {
+			r.ID = NewResultID(r.TaskID, r.Execution, r.SeriesKey)
+		}
+		_, err := db.Upsert(ResultsCollection, bson.M{ResultIDKey: r.ID}, r)
+		catcher.Wrapf(err, "saving result for series '%s' from task '%s'", r.SeriesKey.String(), r.TaskID)
+		if r.Execution > latestExecutions[r.TaskID] {
+			latestExecutions[r.TaskID] = r.Execution
+		}
+	}
+	for taskID, execution := range latestExecutions {
+		err := db.RemoveAll(ResultsCollection, bson.M{
+			ResultTaskIDKey:    taskID,
+			ResultExecutionKey: bson.M{"$lt": execution},
+		})
+		catcher.Wrapf(err, "removing results from previous executions of task '%s'", taskID)
+	}
+	return catcher.Resolve()
+}
+
+// FindResultsByTask returns the results reported by the task execution.
+func FindResultsByTask(taskID string, execution int) ([]Result, error) {
+	out := []Result{}
+	q := db.Query(bson.M{
+		ResultTaskIDKey:    taskID,
+		ResultExecutionKey: execution,
+	})
+	err := db.FindAllQ(ResultsCollection, q, &out)
+	return out, errors.Wrapf(err, "finding perf results for task '%s' execution %d", taskID, execution)
+}
+
+// FindMainlineSeries returns up to the given number of the most recent
+// mainline points of the series, in ascending order of version.
+func FindMainlineSeries(key SeriesKey, limit int) ([]Result, error) {
+	out := []Result{}
+	filter := key.query()
+	filter[ResultMainlineKey] = true
+	q := db.Query(filter).Sort([]string{"-" + ResultOrderKey}).Limit(limit)
+	if err := db.FindAllQ(ResultsCollection, q, &out); err != nil {
+		return nil, errors.Wrapf(err, "finding mainline perf series '%s'", key.String())
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out, nil
+}
+
+// Insert stores the change point if it has not been detected before. It
+// returns whether the change point is new.
+func (cp *ChangePoint) Insert() (bool, error) {
+	if cp.ID == "" {
+		cp.ID = NewChangePointID(cp.SeriesKey, cp.Version)
+	}
+	err := db.Insert(ChangePointsCollection, cp)
+	if db.IsDuplicateKey(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "inserting change point for series '%s' at version '%s'", cp.SeriesKey.String(), cp.Version)
+	}
+	return true, nil
+}
+
+// FindChangePointByID returns the change point with the given ID, or nil if
+// it does not exist.
+func FindChangePointByID(id string) (*ChangePoint, error) {
+	cp := &ChangePoint{}
+	err := db.FindOneQ(ChangePointsCollection, db.Query(bson.M{ChangePointIDKey: id}), cp)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	return cp, errors.Wrapf(err, "finding change point '%s'", id)
+}
+
+// FindChangePointsByVersion returns the change points detected at the
+// version.
+func FindChangePointsByVersion(versionID string) ([]ChangePoint, error) {
+	out := []ChangePoint{}
+	q := db.Query(bson.M{ChangePointVersionKey: versionID})
+	err := db.FindAllQ(ChangePointsCollection, q, &out)
+	return out, errors.Wrapf(err, "finding change points for version '%s'", versionID)
+}
+
+// FindChangePointsBySeries returns the change points detected in the series,
+// in ascending order of version.
+func FindChangePointsBySeries(key SeriesKey) ([]ChangePoint, error) {
+	out := []ChangePoint{}
+	q := db.Query(key.query()).Sort([]string{ChangePointOrderKey})
+	err := db.FindAllQ(ChangePointsCollection, q, &out)
+	return out, errors.Wrapf(err, "finding change points for series '%s'", key.String())
+}
@@ -51,6 +51,7 @@ type Project struct {
 	DisplayName        string                     `yaml:"display_name,omitempty" bson:"display_name"`
 	CommandType        string                     `yaml:"command_type,omitempty" bson:"command_type"`
 	Ignore             []string                   `yaml:"ignore,omitempty" bson:"ignore"`
+	ImpactedTasks      []ImpactedTasksRule        `yaml:"impacted_tasks,omitempty" bson:"impacted_tasks,omitempty"`
 	Parameters         []ParameterInfo            `yaml:"parameters,omitempty" bson:"parameters,omitempty"`
 	Pre                *YAMLCommandSet            `yaml:"pre,omitempty" bson:"pre"`
 	Post               *YAMLCommandSet            `yaml:"post,omitempty" bson:"post"`
@@ -401,6 +402,19 @@ type TestSuite struct {
 	Phase string `yaml:"phase,omitempty"`
 }
 
+// RequesterExpansions maps each requester to the value of the "requester"
+// expansion for tasks with that requester.
+var RequesterExpansions = map[string]string{
+	evergreen.PatchVersionRequester:       "patch",
+	evergreen.GithubPRRequester:           "github_pr",
+	evergreen.GitTagRequester:             "github_tag",
+	evergreen.RepotrackerVersionRequester: "commit",
+	evergreen.TriggerRequester:            "trigger",
+	evergreen.MergeTestRequester:          "commit_queue",
+	evergreen.AdHocRequester:              "ad_hoc",
+	evergreen.GithubMergeRequester:        "github_merge_queue",
+}
+
 type PluginCommandConf struct {
 	Function string `yaml:"func,omitempty" bson:"func,omitempty"`
 	// Type is used to differentiate between setup related commands and actual
@@ -434,6 +448,31 @@ type PluginCommandConf struct {
 	Vars map[string]string `yaml:"vars,omitempty" bson:"vars,omitempty"`
 
 	Loggers *LoggerConfig `yaml:"loggers,omitempty" bson:"loggers,omitempty"`
+
+	// If is a condition on the task's expansions that must be true for the
+	// command to run, such as "requester == patch" or "is_patch && !skip_lint".
+	// A name on its own is true if the expansion is set to something other
+	// than an empty string or "false".
+	If string `yaml:"if,omitempty" bson:"if,omitempty"`
+
+	// Parallel makes this a block of commands that run concurrently rather
+	// than a single command. Each entry is a command or a function, and the
+	// commands in a function run in order. The block fails if any of its
+	// commands fails.
+	Parallel []PluginCommandConf `yaml:"parallel,omitempty" bson:"parallel,omitempty"`
+	// FailFast stops the rest of a parallel block as soon as one of its
+	// commands fails, rather than waiting for all of them to finish.
+	FailFast bool `yaml:"fail_fast,omitempty" bson:"fail_fast,omitempty"`
+}
+
+// BlockCommands returns the commands that this command configuration runs
+// directly, which are the commands in its parallel block if it has one and
+// otherwise the command itself.
+func (c PluginCommandConf) BlockCommands() []PluginCommandConf {
+	if len(c.Parallel) > 0 {
+		return c.Parallel
+	}
+	return []PluginCommandConf{c}
 }
 
 func (c *PluginCommandConf) resolveParams() error {
@@ -447,6 +486,11 @@ func (c *PluginCommandConf) resolveParams() error {
 		}
 		c.Params = out
 	}
+	for i := range c.Parallel {
+		if err := c.Parallel[i].resolveParams(); err != nil {
+			return errors.Wrapf(err, "resolving params for parallel command %d", i)
+		}
+	}
 	return nil
 }
 
@@ -462,6 +506,9 @@ func (c *PluginCommandConf) UnmarshalYAML(unmarshal func(interface{}) error) err
 		ParamsYAML  string                 `yaml:"params_yaml,omitempty" bson:"params_yaml,omitempty"`
 		Vars        map[string]string      `yaml:"vars,omitempty" bson:"vars,omitempty"`
 		Loggers     *LoggerConfig          `yaml:"loggers,omitempty" bson:"loggers,omitempty"`
+		If          string                 `yaml:"if,omitempty" bson:"if,omitempty"`
+		Parallel    []PluginCommandConf    `yaml:"parallel,omitempty" bson:"parallel,omitempty"`
+		FailFast    bool                   `yaml:"fail_fast,omitempty" bson:"fail_fast,omitempty"`
 	}{}
 
 	if err := unmarshal(&temp); err != nil {
@@ -477,6 +524,9 @@ func (c *PluginCommandConf) UnmarshalYAML(unmarshal func(interface{}) error) err
 	c.Loggers = temp.Loggers
 	c.ParamsYAML = temp.ParamsYAML
 	c.Params = temp.Params
+	c.If = temp.If
+	c.Parallel = temp.Parallel
+	c.FailFast = temp.FailFast
 	return c.unmarshalParams()
 }
 
@@ -490,6 +540,14 @@ func (c *PluginCommandConf) UnmarshalBSON(in []byte) error {
 // We read from YAML when available, as the given params could be corrupted from the roundtrip.
 // If params is passed, then it means that we haven't yet stored this in the DB.
 func (c *PluginCommandConf) unmarshalParams() error {
+	// Commands in a parallel block are decoded along with the block, which
+	// does not call their BSON unmarshaller, so their params are restored
+	// here.
+	for i := range c.Parallel {
+		if err := c.Parallel[i].unmarshalParams(); err != nil {
+			return errors.Wrapf(err, "unmarshalling params for parallel command %d", i)
+		}
+	}
 	if c.ParamsYAML != "" {
 		out := map[string]interface{}{}
 		if err := yaml.Unmarshal([]byte(c.ParamsYAML), &out); err != nil {
@@ -522,7 +580,7 @@ func (c *YAMLCommandSet) List() []PluginCommandConf {
 	if len(c.MultiCommand) > 0 {
 		return c.MultiCommand
 	}
-	if c.SingleCommand != nil && (c.SingleCommand.Command != "" || c.SingleCommand.Function != "") {
+	if c.SingleCommand != nil && (c.SingleCommand.Command != "" || c.SingleCommand.Function != "" || len(c.SingleCommand.Parallel) > 0) {
 		return []PluginCommandConf{*c.SingleCommand}
 	}
 	return []PluginCommandConf{}
@@ -843,6 +901,8 @@ func NewTaskIdTable(p *Project, v *Version, sourceRev, defID string) TaskIdConfi
 			rev = v.Id
 		} else if v.Requester == evergreen.GitTagRequester {
 			rev = fmt.Sprintf("%s_%s", sourceRev, v.TriggeredByGitTag.Tag)
+		} else if v.Requester == evergreen.GithubMergeRequester {
+			rev = fmt.Sprintf("merge_group_%s", v.Revision)
 		}
 		for _, t := range bv.Tasks {
 			// omit tasks excluded from the version
@@ -1071,23 +1131,8 @@ func PopulateExpansions(t *task.Task, h *host.Host, oauthToken string) (util.Exp
 	expansions.Put("author_email", v.AuthorEmail)
 	expansions.Put("created_at", v.CreateTime.Format(build.IdTimeLayout))
 
-	requesterExpansion := ""
-	switch v.Requester {
-	case evergreen.PatchVersionRequester:
-		requesterExpansion = "patch"
-	case evergreen.GithubPRRequester:
-		requesterExpansion = "github_pr"
-	case evergreen.GitTagRequester:
-		requesterExpansion = "github_tag"
-	case evergreen.RepotrackerVersionRequester:
-		requesterExpansion = "commit"
-	case evergreen.TriggerRequester:
-		requesterExpansion = "trigger"
-	case evergreen.MergeTestRequester:
-		requesterExpansion = "commit_queue"
-	case evergreen.AdHocRequester:
-		requesterExpansion = "ad_hoc"
-	default:
+	requesterExpansion, ok := RequesterExpansions[v.Requester]
+	if !ok {
 		requesterExpansion = "unknown_requester"
 	}
 	expansions.Put("requester", requesterExpansion)
@@ -1108,6 +1153,11 @@ func PopulateExpansions(t *task.Task, h *host.Host, oauthToken string) (util.Exp
 		expansions.Put("is_patch", "true")
 		expansions.Put("revision_order_id", fmt.Sprintf("%s_%d", v.Author, v.RevisionOrderNumber))
 		expansions.Put("alias", p.Alias)
+		for _, it := range p.ImpactedTasks {
+			if it.Variant == t.BuildVariant && it.Task == t.DisplayName && len(it.TestTags) > 0 {
+				expansions.Put("impacted_test_tags", strings.Join(it.TestTags, ","))
+			}
+		}
 
 		if v.Requester == evergreen.MergeTestRequester {
 			expansions.Put("is_commit_queue", "true")
@@ -1592,6 +1642,9 @@ func (p *Project) IgnoresAllFiles(files []string) bool {
 // BuildProjectTVPairs resolves the build variants and tasks into which build
 // variants will run and which tasks will run on each build variant.
 func (p *Project) BuildProjectTVPairs(patchDoc *patch.Patch, alias string) {
+	if alias == evergreen.ImpactedAlias {
+		patchDoc.ImpactedTasks = p.FindImpactedTasks(patchDoc.FilesChanged())
+	}
 	patchDoc.BuildVariants, patchDoc.Tasks, patchDoc.VariantsTasks = p.ResolvePatchVTs(patchDoc, patchDoc.GetRequester(), alias, true)
 }
 
@@ -1681,7 +1734,11 @@ func (p *Project) ResolvePatchVTs(patchDoc *patch.Patch, requester, alias string
 		}
 	}
 
-	if alias != "" {
+	if alias == evergreen.ImpactedAlias {
+		for _, it := range p.FindImpactedTasks(patchDoc.FilesChanged()) {
+			pairs.ExecTasks = append(pairs.ExecTasks, TVPair{Variant: it.Variant, TaskName: it.Task})
+		}
+	} else if alias != "" {
 		catcher := grip.NewBasicCatcher()
 		vars, err := findAliasesForPatch(p.Identifier, alias, patchDoc)
 		catcher.Wrapf(err, "retrieving alias '%s' for patched project config '%s'", alias, patchDoc.Id.Hex())
@@ -1747,9 +1804,11 @@ func (p *Project) TasksThatCallCommand(find string) map[string]int {
 		if cmds == nil {
 			continue
 		}
-		for _, c := range cmds.List() {
-			if c.Command == find {
-				fs[f] = fs[f] + 1
+		for _, block := range cmds.List() {
+			for _, c := range block.BlockCommands() {
+				if c.Command == find {
+					fs[f] = fs[f] + 1
+				}
 			}
 		}
 	}
@@ -1757,14 +1816,16 @@ func (p *Project) TasksThatCallCommand(find string) map[string]int {
 	// get all tasks that call the command.
 	ts := map[string]int{}
 	for _, t := range p.Tasks {
-		for _, c := range t.Commands {
-			if c.Function != "" {
-				if times, ok := fs[c.Function]; ok {
-					ts[t.Name] = ts[t.Name] + times
+		for _, block := range t.Commands {
+			for _, c := range block.BlockCommands() {
+				if c.Function != "" {
+					if times, ok := fs[c.Function]; ok {
+						ts[t.Name] = ts[t.Name] + times
+					}
+				}
+				if c.Command == find {
+					ts[t.Name] = ts[t.Name] + 1
 				}
-			}
-			if c.Command == find {
-				ts[t.Name] = ts[t.Name] + 1
 			}
 		}
 	}
@@ -1962,19 +2023,23 @@ func (p *Project) CommandsRunOnTV(tv TVPair, cmd string) ([]PluginCommandConf, e
 // the named command on the build variant.
 func (p *Project) CommandsRunOnBV(cmds []PluginCommandConf, cmd, bv string) []PluginCommandConf {
 	var matchingCmds []PluginCommandConf
-	for _, c := range cmds {
-		if c.Function != "" {
-			f, ok := p.Functions[c.Function]
-			if !ok || f == nil {
-				continue
-			}
-			for _, funcCmd := range f.List() {
-				if funcCmd.Command == cmd && funcCmd.RunOnVariant(bv) {
-					matchingCmds = append(matchingCmds, funcCmd)
+	for _, block := range cmds {
+		for _, c := range block.BlockCommands() {
+			if c.Function != "" {
+				f, ok := p.Functions[c.Function]
+				if !ok || f == nil {
+					continue
+				}
+				for _, funcBlock := range f.List() {
+					for _, funcCmd := range funcBlock.BlockCommands() {
+						if funcCmd.Command == cmd && funcCmd.RunOnVariant(bv) {
+							matchingCmds = append(matchingCmds, funcCmd)
+						}
+					}
 				}
+			} else if c.Command == cmd && c.RunOnVariant(bv) {
+				matchingCmds = append(matchingCmds, c)
 			}
-		} else if c.Command == cmd && c.RunOnVariant(bv) {
-			matchingCmds = append(matchingCmds, c)
 		}
 	}
 	return matchingCmds
@@ -0,0 +1,44 @@
+package model
+
+// CommandCondition is a command's `if` condition, which is evaluated against
+// the task's expansions.
+type CommandCondition struct {
+	expr boolExpression
+}
+
+// CommandConditionTerm is a single term of a command condition, which either
+// compares an expansion to a value or checks that the expansion is set.
+type CommandConditionTerm struct {
+	Expansion string
+	Value     string
+	IsSet     bool
+}
+
+// ParseCommandCondition parses a command's `if` condition.
+func ParseCommandCondition(s string) (*CommandCondition, error) {
+	expr, err := parseExpression(s, true)
+	if err != nil {
+		return nil, err
+	}
+	return &CommandCondition{expr: expr}, nil
+}
+
+// Eval returns whether the condition is true for the expansions.
+func (c *CommandCondition) Eval(expansions map[string]string) bool {
+	return c.expr.eval(expansions)
+}
+
+// Terms returns every term in the condition.
+func (c *CommandCondition) Terms() []CommandConditionTerm {
+	var terms []CommandConditionTerm
+	for _, comparison := range c.expr.comparisons() {
+		terms = append(terms, CommandConditionTerm{
+			Expansion: comparison.name,
+			Value:     comparison.value,
+			IsSet:     comparison.isSet,
+		})
+	}
+	return terms
+}
+
+func (c *CommandCondition) String() string { return c.expr.String() }
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommandCondition(t *testing.T) {
+	values := map[string]string{
+		"requester":  "patch",
+		"is_patch":   "true",
+		"empty":      "",
+		"disabled":   "false",
+		"has_config": "yes",
+	}
+	for exprString, expected := range map[string]bool{
+		"is_patch":                           true,
+		"!is_patch":                          false,
+		"empty":                              false,
+		"disabled":                           false,
+		"missing":                            false,
+		"!missing":                           true,
+		"has_config && requester == patch":   true,
+		"requester == commit || !has_config": false,
+		"(missing || is_patch) && !empty":    true,
+	} {
+		t.Run(exprString, func(t *testing.T) {
+			cond, err := ParseCommandCondition(exprString)
+			require.NoError(t, err)
+			assert.Equal(t, expected, cond.Eval(values))
+		})
+	}
+}
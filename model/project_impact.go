@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// maxImpactReasonFiles is the maximum number of matching files listed in the
+// reason a task was selected.
+const maxImpactReasonFiles = 3
+
+// ImpactedTasksRule maps changes to files in a project to the tasks that they
+// impact, which are selected for patches that use the impacted alias.
+type ImpactedTasksRule struct {
+	// Name optionally identifies the rule in the reasons tasks were selected.
+	Name string `yaml:"name,omitempty" bson:"name,omitempty"`
+	// Paths are gitignore-style patterns that are matched against the files
+	// changed by a patch.
+	Paths []string `yaml:"paths,omitempty" bson:"paths,omitempty"`
+	// Tasks are the names or tags (prefixed with ".") of the tasks to select
+	// when a changed file matches.
+	Tasks []string `yaml:"tasks,omitempty" bson:"tasks,omitempty"`
+	// Variants are the names or tags (prefixed with ".") of the build variants
+	// to select the tasks on. If empty, the tasks are selected on every build
+	// variant that runs them.
+	Variants []string `yaml:"variants,omitempty" bson:"variants,omitempty"`
+	// TestTags are passed to the selected tasks in the impacted_test_tags
+	// expansion so they can run only the impacted tests.
+	TestTags []string `yaml:"test_tags,omitempty" bson:"test_tags,omitempty"`
+}
+
+func (r ImpactedTasksRule) displayName(idx int) string {
+	if r.Name != "" {
+		return fmt.Sprintf("'%s'", r.Name)
+	}
+	return fmt.Sprintf("#%d", idx+1)
+}
+
+// matchingFiles returns the files that match any of the rule's paths.
+func (r ImpactedTasksRule) matchingFiles(files []string) []string {
+	if len(r.Paths) == 0 {
+		return nil
+	}
+	// CompileIgnoreLines has a silly API: it always returns a nil error.
+	matcher := ignore.CompileIgnoreLines(r.Paths...)
+	var matched []string
+	for _, f := range files {
+		if matcher.MatchesPath(f) {
+			matched = append(matched, f)
+		}
+	}
+	return matched
+}
+
+// FindImpactedTasks returns the tasks impacted by changes to the given files
+// according to the project's impacted tasks rules, along with all the tasks
+// that they transitively depend on. Each task records why it was selected.
+func (p *Project) FindImpactedTasks(files []string) []patch.ImpactedTask {
+	impacted := map[TVPair]*patch.ImpactedTask{}
+	var selected []TVPair
+	addReason := func(pair TVPair, reason string, testTags []string) {
+		it, ok := impacted[pair]
+		if !ok {
+			it = &patch.ImpactedTask{Variant: pair.Variant, Task: pair.TaskName}
+			impacted[pair] = it
+			selected = append(selected, pair)
+		}
+		if !utility.StringSliceContains(it.Reasons, reason) {
+			it.Reasons = append(it.Reasons, reason)
+		}
+		it.TestTags = utility.UniqueStrings(append(it.TestTags, testTags...))
+	}
+
+	for i, rule := range p.ImpactedTasks {
+		matched := rule.matchingFiles(files)
+		if len(matched) == 0 {
+			continue
+		}
+		reason := fmt.Sprintf("rule %s matched changed files %s", rule.displayName(i), describeImpactedFiles(matched))
+		for _, pair := range p.impactedTasksRulePairs(rule) {
+			addReason(pair, reason, rule.TestTags)
+		}
+	}
+
+	// Select the dependencies of the impacted tasks so that they can run. New
+	// tasks are appended while iterating, so transitive dependencies are
+	// selected too.
+	g := p.DependencyGraph()
+	for i := 0; i < len(selected); i++ {
+		dependent := selected[i]
+		for _, edge := range g.EdgesFromTask(task.TaskNode{Name: dependent.TaskName, Variant: dependent.Variant}) {
+			dependedOn := TVPair{Variant: edge.To.Variant, TaskName: edge.To.Name}
+			addReason(dependedOn, fmt.Sprintf("dependency of '%s'", dependent.String()), nil)
+		}
+	}
+
+	res := make([]patch.ImpactedTask, 0, len(selected))
+	for _, pair := range selected {
+		res = append(res, *impacted[pair])
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Variant != res[j].Variant {
+			return res[i].Variant < res[j].Variant
+		}
+		return res[i].Task < res[j].Task
+	})
+	return res
+}
+
+// impactedTasksRulePairs returns the variant/task pairs selected by the rule.
+func (p *Project) impactedTasksRulePairs(rule ImpactedTasksRule) []TVPair {
+	var variants, variantTags, tasks, taskTags []string
+	for _, v := range rule.Variants {
+		if strings.HasPrefix(v, ".") {
+			variantTags = append(variantTags, v[1:])
+		} else {
+			variants = append(variants, v)
+		}
+	}
+	if len(rule.Variants) == 0 {
+		for _, bv := range p.BuildVariants {
+			if !bv.Disable {
+				variants = append(variants, bv.Name)
+			}
+		}
+	} else if len(variantTags) > 0 {
+		variants = append(variants, p.findBuildVariantsWithTag(variantTags)...)
+	}
+	for _, t := range rule.Tasks {
+		if strings.HasPrefix(t, ".") {
+			taskTags = append(taskTags, t[1:])
+		} else {
+			tasks = append(tasks, t)
+		}
+	}
+	if len(taskTags) > 0 {
+		tasks = append(tasks, p.findProjectTasksWithTag(taskTags)...)
+	}
+
+	var pairs []TVPair
+	for _, v := range utility.UniqueStrings(variants) {
+		for _, t := range utility.UniqueStrings(tasks) {
+			if p.FindTaskForVariant(t, v) != nil {
+				pairs = append(pairs, TVPair{Variant: v, TaskName: t})
+			}
+		}
+	}
+	return pairs
+}
+
+func describeImpactedFiles(files []string) string {
+	quoted := make([]string, 0, maxImpactReasonFiles)
+	for i := 0; i < len(files) && i < maxImpactReasonFiles; i++ {
+		quoted = append(quoted, fmt.Sprintf("'%s'", files[i]))
+	}
+	description := strings.Join(quoted, ", ")
+	if len(files) > maxImpactReasonFiles {
+		description = fmt.Sprintf("%s and %d more", description, len(files)-maxImpactReasonFiles)
+	}
+	return description
+}
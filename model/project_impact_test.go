@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindImpactedTasks(t *testing.T) {
+	p := &Project{
+		Tasks: []ProjectTask{
+			{Name: "compile"},
+			{Name: "unit", Tags: []string{"test"}, DependsOn: []TaskUnitDependency{{Name: "compile"}}},
+			{Name: "integration", Tags: []string{"test"}, DependsOn: []TaskUnitDependency{{Name: "unit"}}},
+			{Name: "docs"},
+		},
+		BuildVariants: []BuildVariant{
+			{
+				Name: "ubuntu",
+				Tags: []string{"linux"},
+				Tasks: []BuildVariantTaskUnit{
+					{Name: "compile", Variant: "ubuntu"},
+					{Name: "unit", Variant: "ubuntu"},
+					{Name: "integration", Variant: "ubuntu"},
+					{Name: "docs", Variant: "ubuntu"},
+				},
+			},
+			{
+				Name: "windows",
+				Tasks: []BuildVariantTaskUnit{
+					{Name: "compile", Variant: "windows"},
+					{Name: "unit", Variant: "windows"},
+				},
+			},
+		},
+		ImpactedTasks: []ImpactedTasksRule{
+			{Name: "server", Paths: []string{"server/**"}, Tasks: []string{".test"}, Variants: []string{".linux"}, TestTags: []string{"server"}},
+			{Paths: []string{"*.md"}, Tasks: []string{"docs"}},
+		},
+	}
+
+	t.Run("SelectsMatchingTasksAndTheirDependencies", func(t *testing.T) {
+		impacted := p.FindImpactedTasks([]string{"server/api.go", "server/db.go", "README.md"})
+		require.Len(t, impacted, 4)
+
+		byName := map[string]patch.ImpactedTask{}
+		for _, it := range impacted {
+			assert.Equal(t, "ubuntu", it.Variant)
+			byName[it.Task] = it
+		}
+		assert.Equal(t, []string{"rule 'server' matched changed files 'server/api.go', 'server/db.go'"}, byName["integration"].Reasons)
+		assert.Equal(t, []string{"server"}, byName["integration"].TestTags)
+		assert.Equal(t, []string{
+			"rule 'server' matched changed files 'server/api.go', 'server/db.go'",
+			"dependency of 'ubuntu/integration'",
+		}, byName["unit"].Reasons)
+		assert.Equal(t, []string{"dependency of 'ubuntu/unit'"}, byName["compile"].Reasons)
+		assert.Empty(t, byName["compile"].TestTags)
+		assert.Equal(t, []string{"rule #2 matched changed files 'README.md'"}, byName["docs"].Reasons)
+	})
+	t.Run("SelectsOnAllVariantsWithoutVariantSelectors", func(t *testing.T) {
+		p.ImpactedTasks[0].Variants = nil
+		defer func() {
+			p.ImpactedTasks[0].Variants = []string{".linux"}
+		}()
+
+		impacted := p.FindImpactedTasks([]string{"server/api.go"})
+		var windowsTasks []string
+		for _, it := range impacted {
+			if it.Variant == "windows" {
+				windowsTasks = append(windowsTasks, it.Task)
+			}
+		}
+		assert.ElementsMatch(t, []string{"compile", "unit"}, windowsTasks)
+	})
+	t.Run("SelectsNothingWithoutMatchingFiles", func(t *testing.T) {
+		assert.Empty(t, p.FindImpactedTasks([]string{"client/main.go"}))
+	})
+	t.Run("SummarizesManyMatchingFiles", func(t *testing.T) {
+		impacted := p.FindImpactedTasks([]string{"a.md", "b.md", "c.md", "d.md", "e.md"})
+		require.Len(t, impacted, 1)
+		assert.Equal(t, []string{"rule #2 matched changed files 'a.md', 'b.md', 'c.md' and 2 more"}, impacted[0].Reasons)
+	})
+}
@@ -4,9 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"unicode"
 
+	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
 
@@ -42,6 +47,11 @@ type matrix struct {
 	RunOn       parserStringSlice `yaml:"run_on,omitempty" bson:"run_on,omitempty"`
 	Tasks       parserBVTaskUnits `yaml:"tasks,omitempty" bson:"tasks,omitempty"`
 	Rules       []matrixRule      `yaml:"rules,omitempty" bson:"rules,omitempty"`
+
+	// ExcludeExpressions are boolean expressions over a cell's axis values,
+	// such as "os == windows && compiler == clang". Cells for which any
+	// expression is true are excluded.
+	ExcludeExpressions parserStringSlice `yaml:"exclude,omitempty" bson:"exclude_expressions,omitempty"`
 }
 
 // matrixAxis represents one axis of a matrix definition.
@@ -49,6 +59,17 @@ type matrixAxis struct {
 	Id          string      `yaml:"id,omitempty" bson:"id,omitempty"`
 	DisplayName string      `yaml:"display_name,omitempty" bson:"display_name,omitempty"`
 	Values      []axisValue `yaml:"values,omitempty" bson:"values,omitempty"`
+
+	// ValuesFrom is the name of a parameter or project variable whose value
+	// is a comma- or space-separated list of the axis's value ids. It is
+	// resolved when a version is created.
+	ValuesFrom string `yaml:"values_from,omitempty" bson:"values_from,omitempty"`
+	// ValueTemplate defines the settings of values from ValuesFrom that are
+	// not listed in Values.
+	ValueTemplate *axisValue `yaml:"value_template,omitempty" bson:"value_template,omitempty"`
+	// ResolvedValues are the value ids that ValuesFrom resolved to when the
+	// version was created.
+	ResolvedValues []string `yaml:"resolved_values,omitempty" bson:"resolved_values,omitempty"`
 }
 
 // find returns the axisValue with the given name.
@@ -264,42 +285,306 @@ func buildMatrixVariants(axes []matrixAxis, ase *axisSelectorEvaluator, matrices
 	var errs []error
 	// for each matrix, build out its declarations
 	matrixVariants := []parserBV{}
-	for i, m := range matrices {
-		// for each axis value, iterate through possible inputs
-		evaluatedSpec, evalErrs := m.Spec.evaluatedCopy(ase)
-		if len(evalErrs) > 0 {
-			errs = append(errs, evalErrs...)
+	for i := range matrices {
+		cells, cellErrs, err := evaluateMatrixCells(axes, ase, &matrices[i])
+		if err != nil {
+			// If the cells cannot be enumerated we should exit immediately
+			return nil, []error{err}
+		}
+		errs = append(errs, cellErrs...)
+		for _, cell := range cells {
+			if cell.err != nil {
+				errs = append(errs, cell.err)
+				continue
+			}
+			if cell.variant != nil {
+				matrixVariants = append(matrixVariants, *cell.variant)
+			}
+		}
+	}
+	return matrixVariants, errs
+}
+
+// matrixCell is a single combination of axis values in a matrix along with
+// the outcome of evaluating it.
+type matrixCell struct {
+	value matrixValue
+	// variant is the variant built from the cell if it was not excluded.
+	variant *parserBV
+	// excludedBy describes the exclusion that matched the cell.
+	excludedBy string
+	// err is the error building the cell's variant.
+	err error
+}
+
+// evaluateMatrixCells enumerates every cell of the matrix and either builds
+// its variant or records why it was excluded. It returns errors evaluating
+// the matrix's selectors and exclusions, as well as an error if the cells
+// cannot be enumerated at all.
+func evaluateMatrixCells(axes []matrixAxis, ase *axisSelectorEvaluator, m *matrix) ([]matrixCell, []error, error) {
+	// for each axis value, iterate through possible inputs
+	evaluatedSpec, evalErrs := m.Spec.evaluatedCopy(ase)
+	if len(evalErrs) > 0 {
+		return nil, evalErrs, nil
+	}
+	evaluatedExcludes, evalErrs := m.Exclude.evaluatedCopies(ase)
+	if len(evalErrs) > 0 {
+		return nil, evalErrs, nil
+	}
+	excludeExprs, err := m.parseExcludeExpressions(axes)
+	if err != nil {
+		return nil, []error{err}, nil
+	}
+	unpruned, err := evaluatedSpec.allCells()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var errs []error
+	cells := make([]matrixCell, 0, len(unpruned))
+	specExcluded := false
+	for _, mv := range unpruned {
+		cell := matrixCell{value: mv}
+		for _, md := range evaluatedExcludes {
+			if md.contains(mv) {
+				cell.excludedBy = fmt.Sprintf("matches exclude_spec %s", md)
+				specExcluded = true
+				break
+			}
+		}
+		for i, expr := range excludeExprs {
+			if cell.excludedBy != "" {
+				break
+			}
+			if expr.eval(mv) {
+				cell.excludedBy = fmt.Sprintf("matches exclude expression '%s'", m.ExcludeExpressions[i])
+			}
+		}
+		// create the variant if it isn't excluded
+		if cell.excludedBy == "" {
+			cell.variant, cell.err = buildMatrixVariant(axes, mv, m, ase)
+			if cell.err != nil {
+				cell.err = errors.Wrapf(cell.err, "building cell '%v' for matrix '%s'", mv, m.Id)
+			}
+		}
+		cells = append(cells, cell)
+	}
+	// safety check to make sure the exclude field is actually working
+	if len(m.Exclude) > 0 && !specExcluded {
+		errs = append(errs, errors.Errorf("exclude field did not exclude anything for matrix '%s'", m.Id))
+	}
+	return cells, errs, nil
+}
+
+// parseExcludeExpressions parses the matrix's exclude expressions and checks
+// that they only refer to axes in the matrix spec and to values defined for
+// those axes. Values of axes with values from a parameter or variable are not
+// checked, since they may legitimately be absent for some versions.
+func (m *matrix) parseExcludeExpressions(axes []matrixAxis) ([]boolExpression, error) {
+	axesByID := map[string]matrixAxis{}
+	for _, a := range axes {
+		axesByID[a.Id] = a
+	}
+
+	catcher := grip.NewBasicCatcher()
+	exprs := make([]boolExpression, 0, len(m.ExcludeExpressions))
+	for _, raw := range m.ExcludeExpressions {
+		expr, err := parseMatrixExpression(raw)
+		if err != nil {
+			catcher.Wrapf(err, "invalid exclude expression for matrix '%s'", m.Id)
 			continue
 		}
-		evaluatedExcludes, evalErrs := m.Exclude.evaluatedCopies(ase)
-		if len(evalErrs) > 0 {
-			errs = append(errs, evalErrs...)
+		for _, c := range expr.comparisons() {
+			if _, ok := m.Spec[c.name]; !ok {
+				catcher.Errorf("exclude expression '%s' for matrix '%s' refers to axis '%s', which is not in the matrix spec", raw, m.Id, c.name)
+				continue
+			}
+			a := axesByID[c.name]
+			if a.ValuesFrom != "" {
+				continue
+			}
+			if _, err := a.find(c.value); err != nil {
+				catcher.Wrapf(err, "exclude expression '%s' for matrix '%s'", raw, m.Id)
+			}
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, catcher.Resolve()
+}
+
+// MatrixCellEvaluation describes whether a combination of axis values in a
+// matrix became a variant, and why.
+type MatrixCellEvaluation struct {
+	Matrix  string            `yaml:"matrix" json:"matrix"`
+	Values  map[string]string `yaml:"values" json:"values"`
+	Variant string            `yaml:"variant,omitempty" json:"variant,omitempty"`
+	Kept    bool              `yaml:"kept" json:"kept"`
+	Reason  string            `yaml:"reason" json:"reason"`
+}
+
+// EvaluateMatrices expands the project's matrices and returns every
+// combination of axis values, whether it was kept as a variant, and the
+// reason it was dropped if it was not. Axes with values from a parameter or
+// variable use the values resolved for the version or, if the version has
+// not been created, the parameter's default.
+func (pp *ParserProject) EvaluateMatrices() ([]MatrixCellEvaluation, error) {
+	axes, err := resolveMatrixAxes(pp.Axes, pp.Parameters)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving matrix axes")
+	}
+	ase := NewAxisSelectorEvaluator(axes)
+	_, matrices := sieveMatrixVariants(pp.BuildVariants)
+
+	catcher := grip.NewBasicCatcher()
+	var evaluations []MatrixCellEvaluation
+	for i := range matrices {
+		cells, errs, err := evaluateMatrixCells(axes, ase, &matrices[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "enumerating cells for matrix '%s'", matrices[i].Id)
+		}
+		catcher.Extend(errs)
+
+		matrixEvaluations := make([]MatrixCellEvaluation, 0, len(cells))
+		for _, cell := range cells {
+			eval := MatrixCellEvaluation{
+				Matrix: matrices[i].Id,
+				Values: cell.value,
+			}
+			switch {
+			case cell.excludedBy != "":
+				eval.Reason = cell.excludedBy
+			case cell.err != nil:
+				eval.Reason = cell.err.Error()
+			default:
+				eval.Kept = true
+				eval.Variant = cell.variant.Name
+				eval.Reason = "not excluded"
+			}
+			matrixEvaluations = append(matrixEvaluations, eval)
+		}
+		sort.Slice(matrixEvaluations, func(i, j int) bool {
+			return matrixValue(matrixEvaluations[i].Values).String() < matrixValue(matrixEvaluations[j].Values).String()
+		})
+		evaluations = append(evaluations, matrixEvaluations...)
+	}
+
+	return evaluations, catcher.Resolve()
+}
+
+// resolveMatrixAxes returns a copy of the axes in which axes with values from
+// a parameter or variable have their values filled in. Values that were
+// resolved when the version was created take precedence over the parameter's
+// default. If neither is available, the axis keeps its statically listed
+// values.
+func resolveMatrixAxes(axes []matrixAxis, params []ParameterInfo) ([]matrixAxis, error) {
+	defaults := map[string]string{}
+	for _, p := range params {
+		defaults[p.Key] = p.Value
+	}
+
+	catcher := grip.NewBasicCatcher()
+	resolved := make([]matrixAxis, 0, len(axes))
+	for _, a := range axes {
+		if a.ValuesFrom == "" {
+			resolved = append(resolved, a)
 			continue
 		}
-		unpruned, err := evaluatedSpec.allCells()
+		ids := a.ResolvedValues
+		if ids == nil {
+			defaultValue, ok := defaults[a.ValuesFrom]
+			if !ok {
+				resolved = append(resolved, a)
+				continue
+			}
+			ids = splitMatrixAxisValues(defaultValue)
+		}
+		values, err := a.valuesForIDs(ids)
 		if err != nil {
-			// If allCells fails we should exit immediately
-			return nil, []error{err}
+			catcher.Wrapf(err, "resolving values for axis '%s'", a.Id)
+			continue
 		}
-		pruned := []parserBV{}
-		for _, cell := range unpruned {
-			// create the variant if it isn't excluded
-			if !evaluatedExcludes.contain(cell) {
-				v, err := buildMatrixVariant(axes, cell, &matrices[i], ase)
-				if err != nil {
-					errs = append(errs, errors.Wrapf(err, "building cell '%v' for matrix '%s'", cell, m.Id))
-					continue
-				}
-				pruned = append(pruned, *v)
+		a.Values = values
+		resolved = append(resolved, a)
+	}
+	return resolved, catcher.Resolve()
+}
+
+// ResolveMatrixAxes records the values of the matrix axes that take their
+// values from a parameter or project variable, so that the version's variants
+// do not change if the parameter or variable changes later. The given
+// parameters take precedence over the project's variables, and private
+// variables are never used. Axes whose source is not set keep using the
+// parameter's default. It returns whether any axis was resolved.
+func (pp *ParserProject) ResolveMatrixAxes(projectID string, params []patch.Parameter) (bool, error) {
+	var unresolved []int
+	for i, a := range pp.Axes {
+		if a.ValuesFrom != "" && a.ResolvedValues == nil {
+			unresolved = append(unresolved, i)
+		}
+	}
+	if len(unresolved) == 0 {
+		return false, nil
+	}
+
+	sources := map[string]string{}
+	vars, err := FindMergedProjectVars(projectID)
+	if err != nil {
+		return false, errors.Wrapf(err, "getting project variables for project '%s'", projectID)
+	}
+	if vars != nil {
+		for k, v := range vars.Vars {
+			if !vars.PrivateVars[k] {
+				sources[k] = v
 			}
 		}
-		// safety check to make sure the exclude field is actually working
-		if len(m.Exclude) > 0 && len(unpruned) == len(pruned) {
-			errs = append(errs, errors.Errorf("exclude field did not exclude anything for matrix '%s'", m.Id))
+	}
+	for _, p := range params {
+		sources[p.Key] = p.Value
+	}
+
+	resolved := false
+	for _, i := range unresolved {
+		value, ok := sources[pp.Axes[i].ValuesFrom]
+		if !ok {
+			continue
 		}
-		matrixVariants = append(matrixVariants, pruned...)
+		pp.Axes[i].ResolvedValues = splitMatrixAxisValues(value)
+		resolved = true
 	}
-	return matrixVariants, errs
+	return resolved, nil
+}
+
+// valuesForIDs returns the axis values with the given ids. Values that are
+// not listed for the axis are created from its value template.
+func (ma matrixAxis) valuesForIDs(ids []string) ([]axisValue, error) {
+	values := make([]axisValue, 0, len(ids))
+	for _, id := range ids {
+		if v, err := ma.find(id); err == nil {
+			values = append(values, v)
+			continue
+		}
+		v := axisValue{Id: id}
+		if ma.ValueTemplate != nil {
+			v = *ma.ValueTemplate
+			v.Id = id
+			displayName, err := util.NewExpansions(map[string]string{ma.Id: id}).ExpandString(ma.ValueTemplate.DisplayName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "expanding display name for value '%s'", id)
+			}
+			v.DisplayName = displayName
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// splitMatrixAxisValues splits a comma- or space-separated list of axis value
+// ids, dropping duplicates.
+func splitMatrixAxisValues(s string) []string {
+	ids := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	return utility.UniqueStrings(ids)
 }
 
 // buildMatrixVariant does the heavy lifting of building a matrix variant based on axis information.
@@ -0,0 +1,296 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// boolExpression is a boolean expression over a set of named values, such as
+// the axis values of a matrix cell or a task's expansions. For example,
+// `os == windows && compiler != gcc`. Expressions support the operators ==,
+// !=, !, &&, and ||, as well as parentheses. Values can be written bare or
+// quoted.
+type boolExpression interface {
+	// eval returns whether the expression is true for the values.
+	eval(values map[string]string) bool
+	// comparisons returns every comparison in the expression.
+	comparisons() []expressionComparison
+	String() string
+}
+
+// expressionComparison compares a named value to a constant or, if isSet is
+// true, checks that the named value is set.
+type expressionComparison struct {
+	name    string
+	value   string
+	negated bool
+	isSet   bool
+}
+
+func (c expressionComparison) eval(values map[string]string) bool {
+	v, ok := values[c.name]
+	if c.isSet {
+		return ok && v != "" && v != "false"
+	}
+	if !ok {
+		return false
+	}
+	return (v == c.value) != c.negated
+}
+
+func (c expressionComparison) comparisons() []expressionComparison {
+	return []expressionComparison{c}
+}
+
+func (c expressionComparison) String() string {
+	if c.isSet {
+		return c.name
+	}
+	op := "=="
+	if c.negated {
+		op = "!="
+	}
+	return fmt.Sprintf("%s %s %s", c.name, op, c.value)
+}
+
+type expressionNot struct {
+	expr boolExpression
+}
+
+func (n expressionNot) eval(values map[string]string) bool  { return !n.expr.eval(values) }
+func (n expressionNot) comparisons() []expressionComparison { return n.expr.comparisons() }
+func (n expressionNot) String() string                      { return fmt.Sprintf("!(%s)", n.expr) }
+
+type expressionAnd struct {
+	left, right boolExpression
+}
+
+func (a expressionAnd) eval(values map[string]string) bool {
+	return a.left.eval(values) && a.right.eval(values)
+}
+func (a expressionAnd) comparisons() []expressionComparison {
+	return append(a.left.comparisons(), a.right.comparisons()...)
+}
+func (a expressionAnd) String() string { return fmt.Sprintf("(%s && %s)", a.left, a.right) }
+
+type expressionOr struct {
+	left, right boolExpression
+}
+
+func (o expressionOr) eval(values map[string]string) bool {
+	return o.left.eval(values) || o.right.eval(values)
+}
+func (o expressionOr) comparisons() []expressionComparison {
+	return append(o.left.comparisons(), o.right.comparisons()...)
+}
+func (o expressionOr) String() string { return fmt.Sprintf("(%s || %s)", o.left, o.right) }
+
+// parseMatrixExpression parses an expression over the axis values of a matrix
+// cell. Every term must compare an axis to a value.
+func parseMatrixExpression(s string) (boolExpression, error) {
+	return parseExpression(s, false)
+}
+
+// parseExpression parses an expression. If allowIsSet is true, a name on its
+// own is a term that is true if the named value is set to something other
+// than an empty string or "false".
+func parseExpression(s string, allowIsSet bool) (boolExpression, error) {
+	tokens, err := tokenizeExpression(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing expression '%s'", s)
+	}
+	p := expressionParser{tokens: tokens, allowIsSet: allowIsSet}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing expression '%s'", s)
+	}
+	if !p.done() {
+		return nil, errors.Errorf("parsing expression '%s': unexpected '%s'", s, p.peek().value)
+	}
+	return expr, nil
+}
+
+type expressionTokenType int
+
+const (
+	expressionTokenWord expressionTokenType = iota
+	expressionTokenOperator
+)
+
+type expressionToken struct {
+	typ   expressionTokenType
+	value string
+}
+
+var expressionOperators = []string{"==", "!=", "&&", "||", "!", "(", ")"}
+
+func tokenizeExpression(s string) ([]expressionToken, error) {
+	var tokens []expressionToken
+	for i := 0; i < len(s); {
+		r := rune(s[i])
+		if unicode.IsSpace(r) {
+			i++
+			continue
+		}
+
+		isOperator := false
+		for _, op := range expressionOperators {
+			if strings.HasPrefix(s[i:], op) {
+				tokens = append(tokens, expressionToken{typ: expressionTokenOperator, value: op})
+				i += len(op)
+				isOperator = true
+				break
+			}
+		}
+		if isOperator {
+			continue
+		}
+
+		if r == '"' || r == '\'' {
+			end := strings.IndexRune(s[i+1:], r)
+			if end < 0 {
+				return nil, errors.Errorf("unterminated quoted value at position %d", i)
+			}
+			tokens = append(tokens, expressionToken{typ: expressionTokenWord, value: s[i+1 : i+1+end]})
+			i += end + 2
+			continue
+		}
+
+		start := i
+		for i < len(s) && isExpressionWordChar(rune(s[i])) {
+			i++
+		}
+		if start == i {
+			return nil, errors.Errorf("unexpected character '%c' at position %d", r, i)
+		}
+		tokens = append(tokens, expressionToken{typ: expressionTokenWord, value: s[start:i]})
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("expression is empty")
+	}
+	return tokens, nil
+}
+
+func isExpressionWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.+/:*~", r)
+}
+
+// expressionParser is a recursive descent parser for the grammar:
+//
+//	or         := and ("||" and)*
+//	and        := unary ("&&" unary)*
+//	unary      := "!" unary | primary
+//	primary    := "(" or ")" | comparison
+//	comparison := word ("==" | "!=") word
+//	           |  word  (only if allowIsSet is true)
+type expressionParser struct {
+	tokens     []expressionToken
+	pos        int
+	allowIsSet bool
+}
+
+func (p *expressionParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *expressionParser) peek() expressionToken {
+	if p.done() {
+		return expressionToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) acceptOperator(op string) bool {
+	if t := p.peek(); t.typ == expressionTokenOperator && t.value == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) parseOr() (boolExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = expressionOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (boolExpression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = expressionAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (boolExpression, error) {
+	if p.acceptOperator("!") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return expressionNot{expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *expressionParser) parsePrimary() (boolExpression, error) {
+	if p.acceptOperator("(") {
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptOperator(")") {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return expr, nil
+	}
+
+	name, err := p.parseWord("a name")
+	if err != nil {
+		return nil, err
+	}
+	var negated bool
+	switch {
+	case p.acceptOperator("=="):
+	case p.acceptOperator("!="):
+		negated = true
+	case p.allowIsSet:
+		return expressionComparison{name: name, isSet: true}, nil
+	default:
+		return nil, errors.Errorf("expected '==' or '!=' after '%s'", name)
+	}
+	value, err := p.parseWord(fmt.Sprintf("a value for '%s'", name))
+	if err != nil {
+		return nil, err
+	}
+	return expressionComparison{name: name, value: value, negated: negated}, nil
+}
+
+func (p *expressionParser) parseWord(expected string) (string, error) {
+	if p.done() {
+		return "", errors.Errorf("expected %s but reached the end of the expression", expected)
+	}
+	t := p.peek()
+	if t.typ != expressionTokenWord {
+		return "", errors.Errorf("expected %s but found '%s'", expected, t.value)
+	}
+	p.pos++
+	return t.value, nil
+}
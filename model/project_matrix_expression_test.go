@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMatrixExpression(t *testing.T) {
+	windowsClang := matrixValue{"os": "windows", "compiler": "clang"}
+	windowsGCC := matrixValue{"os": "windows", "compiler": "gcc"}
+	linuxClang := matrixValue{"os": "linux", "compiler": "clang"}
+
+	for exprString, expected := range map[string][]bool{
+		"os == windows":                                     {true, true, false},
+		"os != windows":                                     {false, false, true},
+		"os == windows && compiler == clang":                {true, false, false},
+		"os == windows || compiler == clang":                {true, true, true},
+		"!(os == windows)":                                  {false, false, true},
+		"!os == windows && compiler == clang":               {false, false, true},
+		"os == linux || os == windows && compiler == gcc":   {false, true, true},
+		"(os == linux || os == windows) && compiler == gcc": {false, true, false},
+		`os == "windows" && compiler == 'clang'`:            {true, false, false},
+		"arch == x86_64":                                    {false, false, false},
+	} {
+		t.Run(exprString, func(t *testing.T) {
+			expr, err := parseMatrixExpression(exprString)
+			require.NoError(t, err)
+			assert.Equal(t, expected[0], expr.eval(windowsClang))
+			assert.Equal(t, expected[1], expr.eval(windowsGCC))
+			assert.Equal(t, expected[2], expr.eval(linuxClang))
+		})
+	}
+
+	t.Run("ReturnsComparisons", func(t *testing.T) {
+		expr, err := parseMatrixExpression("os == windows && (compiler != gcc || !(arch == arm64))")
+		require.NoError(t, err)
+		comparisons := expr.comparisons()
+		require.Len(t, comparisons, 3)
+		assert.Equal(t, expressionComparison{name: "os", value: "windows"}, comparisons[0])
+		assert.Equal(t, expressionComparison{name: "compiler", value: "gcc", negated: true}, comparisons[1])
+		assert.Equal(t, expressionComparison{name: "arch", value: "arm64"}, comparisons[2])
+	})
+
+	for name, exprString := range map[string]string{
+		"Empty":                 "",
+		"MissingOperator":       "os windows",
+		"MissingValue":          "os ==",
+		"MissingAxis":           "== windows",
+		"DanglingAnd":           "os == windows &&",
+		"UnclosedParenthesis":   "(os == windows",
+		"ExtraParenthesis":      "os == windows)",
+		"UnterminatedQuote":     `os == "windows`,
+		"InvalidCharacter":      "os == windows; rm -rf",
+		"SingleEquals":          "os = windows",
+		"ComparisonWithoutAxis": "(os) == windows",
+	} {
+		t.Run("FailsWith"+name, func(t *testing.T) {
+			_, err := parseMatrixExpression(exprString)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseMatrixExpressionRequiresComparisons(t *testing.T) {
+	_, err := parseMatrixExpression("os")
+	assert.Error(t, err)
+	_, err = parseMatrixExpression("os == linux && compiler")
+	assert.Error(t, err)
+}
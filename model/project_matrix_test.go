@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/util"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -512,6 +513,154 @@ func TestMatrixVariantsSimple(t *testing.T) {
 				So(len(errs), ShouldEqual, 2)
 			})
 		})
+		Convey("and a matrix with exclude expressions", func() {
+			m := matrix{
+				Id: "candy",
+				Spec: matrixDefinition{
+					"color": []string{"red", "green", "purple"},
+					"brand": []string{"m&ms", "skittles", "necco"},
+				},
+				ExcludeExpressions: []string{
+					"brand == necco && color != purple",
+					"color == purple && !(brand == skittles || brand == necco)",
+				},
+			}
+			Convey("building a list of variants should succeed", func() {
+				vs, errs := buildMatrixVariants(axes, ase, []matrix{m})
+				So(errs, ShouldBeNil)
+				So(len(vs), ShouldEqual, 6)
+				vals := []matrixValue{}
+				for _, v := range vs {
+					vals = append(vals, v.MatrixVal)
+				}
+				So(vals, ShouldContainResembling, matrixValue{"brand": "m&ms", "color": "red"})
+				So(vals, ShouldContainResembling, matrixValue{"brand": "m&ms", "color": "green"})
+				So(vals, ShouldContainResembling, matrixValue{"brand": "skittles", "color": "red"})
+				So(vals, ShouldContainResembling, matrixValue{"brand": "skittles", "color": "green"})
+				So(vals, ShouldContainResembling, matrixValue{"brand": "skittles", "color": "purple"})
+				So(vals, ShouldContainResembling, matrixValue{"brand": "necco", "color": "purple"})
+			})
+			Convey("an expression that refers to an axis outside the spec should fail", func() {
+				m.Spec = matrixDefinition{"color": []string{"red", "green"}}
+				m.ExcludeExpressions = []string{"brand == necco"}
+				_, errs := buildMatrixVariants(axes, ase, []matrix{m})
+				So(len(errs), ShouldEqual, 1)
+				So(errs[0].Error(), ShouldContainSubstring, "axis 'brand'")
+			})
+			Convey("an expression that refers to an undefined value should fail", func() {
+				m.ExcludeExpressions = []string{"color == salmon"}
+				_, errs := buildMatrixVariants(axes, ase, []matrix{m})
+				So(len(errs), ShouldEqual, 1)
+				So(errs[0].Error(), ShouldContainSubstring, "salmon")
+			})
+			Convey("an invalid expression should fail", func() {
+				m.ExcludeExpressions = []string{"color == red &&"}
+				_, errs := buildMatrixVariants(axes, ase, []matrix{m})
+				So(len(errs), ShouldEqual, 1)
+			})
+		})
+	})
+}
+
+func TestResolveMatrixAxes(t *testing.T) {
+	Convey("With an axis whose values come from a parameter", t, func() {
+		axes := []matrixAxis{
+			{
+				Id:         "version",
+				ValuesFrom: "versions",
+				Values: []axisValue{
+					{Id: "1.0", DisplayName: "Legacy", Tags: []string{"legacy"}},
+				},
+				ValueTemplate: &axisValue{
+					DisplayName: "Version ${version}",
+					Tags:        []string{"current"},
+				},
+			},
+			{
+				Id:     "os",
+				Values: []axisValue{{Id: "linux"}},
+			},
+		}
+		Convey("the parameter's default should be used", func() {
+			resolved, err := resolveMatrixAxes(axes, []ParameterInfo{
+				{Parameter: patch.Parameter{Key: "versions", Value: "1.0, 2.0 3.0,2.0"}},
+			})
+			So(err, ShouldBeNil)
+			So(len(resolved), ShouldEqual, 2)
+			So(resolved[0].Values, ShouldResemble, []axisValue{
+				{Id: "1.0", DisplayName: "Legacy", Tags: []string{"legacy"}},
+				{Id: "2.0", DisplayName: "Version 2.0", Tags: []string{"current"}},
+				{Id: "3.0", DisplayName: "Version 3.0", Tags: []string{"current"}},
+			})
+			So(resolved[1], ShouldResemble, axes[1])
+			So(len(axes[0].Values), ShouldEqual, 1)
+		})
+		Convey("values resolved for the version should take precedence", func() {
+			axes[0].ResolvedValues = []string{"4.0"}
+			resolved, err := resolveMatrixAxes(axes, []ParameterInfo{
+				{Parameter: patch.Parameter{Key: "versions", Value: "1.0"}},
+			})
+			So(err, ShouldBeNil)
+			So(resolved[0].Values, ShouldResemble, []axisValue{
+				{Id: "4.0", DisplayName: "Version 4.0", Tags: []string{"current"}},
+			})
+		})
+		Convey("the static values should be kept without a source", func() {
+			resolved, err := resolveMatrixAxes(axes, nil)
+			So(err, ShouldBeNil)
+			So(resolved, ShouldResemble, axes)
+		})
+	})
+}
+
+func TestEvaluateMatrices(t *testing.T) {
+	Convey("With a project containing a matrix", t, func() {
+		pp := &ParserProject{
+			Axes: []matrixAxis{
+				{Id: "os", Values: []axisValue{{Id: "linux"}, {Id: "windows"}}},
+				{Id: "compiler", ValuesFrom: "compilers", ValueTemplate: &axisValue{}},
+			},
+			Parameters: []ParameterInfo{
+				{Parameter: patch.Parameter{Key: "compilers", Value: "gcc,clang"}},
+			},
+			BuildVariants: []parserBV{
+				{Name: "regular"},
+				{Matrix: &matrix{
+					Id: "build",
+					Spec: matrixDefinition{
+						"os":       []string{"*"},
+						"compiler": []string{"*"},
+					},
+					Exclude: []matrixDefinition{
+						{"os": []string{"linux"}, "compiler": []string{"clang"}},
+					},
+					ExcludeExpressions: []string{"os == windows && compiler == gcc"},
+				}},
+			},
+		}
+		Convey("every cell should be reported with its outcome", func() {
+			evaluations, err := pp.EvaluateMatrices()
+			So(err, ShouldBeNil)
+			So(len(evaluations), ShouldEqual, 4)
+
+			kept := 0
+			for _, eval := range evaluations {
+				So(eval.Matrix, ShouldEqual, "build")
+				switch {
+				case eval.Values["os"] == "linux" && eval.Values["compiler"] == "clang":
+					So(eval.Kept, ShouldBeFalse)
+					So(eval.Reason, ShouldContainSubstring, "exclude_spec")
+				case eval.Values["os"] == "windows" && eval.Values["compiler"] == "gcc":
+					So(eval.Kept, ShouldBeFalse)
+					So(eval.Reason, ShouldEqual, "matches exclude expression 'os == windows && compiler == gcc'")
+				default:
+					So(eval.Kept, ShouldBeTrue)
+					So(eval.Variant, ShouldNotBeEmpty)
+					kept++
+				}
+			}
+			So(kept, ShouldEqual, 2)
+		})
 	})
 }
 
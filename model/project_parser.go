@@ -2,7 +2,9 @@ package model
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"reflect"
@@ -20,6 +22,7 @@ import (
 	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
+	"gopkg.in/yaml.v3"
 )
 
 const LoadProjectError = "load project error(s)"
@@ -90,6 +93,7 @@ type ParserProject struct {
 	DisplayName        *string                    `yaml:"display_name,omitempty" bson:"display_name,omitempty"`
 	CommandType        *string                    `yaml:"command_type,omitempty" bson:"command_type,omitempty"`
 	Ignore             parserStringSlice          `yaml:"ignore,omitempty" bson:"ignore,omitempty"`
+	ImpactedTasks      []ImpactedTasksRule        `yaml:"impacted_tasks,omitempty" bson:"impacted_tasks,omitempty"`
 	Parameters         []ParameterInfo            `yaml:"parameters,omitempty" bson:"parameters,omitempty"`
 	Pre                *YAMLCommandSet            `yaml:"pre,omitempty" bson:"pre,omitempty"`
 	Post               *YAMLCommandSet            `yaml:"post,omitempty" bson:"post,omitempty"`
@@ -176,6 +180,18 @@ func (pp *ParserProject) MarshalYAML() (interface{}, error) {
 	return *pp, nil
 }
 
+// ConfigDigest returns the hex-encoded SHA-256 digest of the parser project's
+// YAML form. YAML map keys are sorted when marshalling, so the digest is the
+// same every time the same parser project is hashed.
+func (pp *ParserProject) ConfigDigest() (string, error) {
+	ppBytes, err := yaml.Marshal(pp)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling parser project to YAML")
+	}
+	digest := sha256.Sum256(ppBytes)
+	return hex.EncodeToString(digest[:]), nil
+}
+
 type displayTask struct {
 	Name           string   `yaml:"name,omitempty" bson:"name,omitempty"`
 	ExecutionTasks []string `yaml:"execution_tasks,omitempty" bson:"execution_tasks,omitempty"`
@@ -901,6 +917,7 @@ func TranslateProject(pp *ParserProject) (*Project, error) {
 		DisplayName:        utility.FromStringPtr(pp.DisplayName),
 		CommandType:        utility.FromStringPtr(pp.CommandType),
 		Ignore:             pp.Ignore,
+		ImpactedTasks:      pp.ImpactedTasks,
 		Parameters:         pp.Parameters,
 		Containers:         pp.Containers,
 		Pre:                pp.Pre,
@@ -916,8 +933,10 @@ func TranslateProject(pp *ParserProject) (*Project, error) {
 	catcher := grip.NewBasicCatcher()
 	tse := NewParserTaskSelectorEvaluator(pp.Tasks)
 	tgse := newTaskGroupSelectorEvaluator(pp.TaskGroups)
-	ase := NewAxisSelectorEvaluator(pp.Axes)
-	buildVariants, errs := GetVariantsWithMatrices(ase, pp.Axes, pp.BuildVariants)
+	axes, err := resolveMatrixAxes(pp.Axes, pp.Parameters)
+	catcher.Wrap(err, "resolving matrix axes")
+	ase := NewAxisSelectorEvaluator(axes)
+	buildVariants, errs := GetVariantsWithMatrices(ase, axes, pp.BuildVariants)
 	catcher.Extend(errs)
 	vse := NewVariantSelectorEvaluator(buildVariants, ase)
 	proj.Tasks, proj.TaskGroups, errs = evaluateTaskUnits(tse, tgse, vse, pp.Tasks, pp.TaskGroups, pp.Containers)
@@ -28,6 +28,7 @@ var (
 	ParserProjectDisplayNameKey       = bsonutil.MustHaveTag(ParserProject{}, "DisplayName")
 	ParserProjectCommandTypeKey       = bsonutil.MustHaveTag(ParserProject{}, "CommandType")
 	ParserProjectIgnoreKey            = bsonutil.MustHaveTag(ParserProject{}, "Ignore")
+	ParserProjectImpactedTasksKey     = bsonutil.MustHaveTag(ParserProject{}, "ImpactedTasks")
 	ParserProjectParametersKey        = bsonutil.MustHaveTag(ParserProject{}, "Parameters")
 	ParserProjectPreKey               = bsonutil.MustHaveTag(ParserProject{}, "Pre")
 	ParserProjectPostKey              = bsonutil.MustHaveTag(ParserProject{}, "Post")
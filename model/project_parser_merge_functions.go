@@ -94,9 +94,10 @@ func (pp *ParserProject) mergeUnorderedUnique(toMerge *ParserProject) error {
 
 // mergeUnordered merges fields that are lists where the order doesn't matter.
 // These fields can only be defined in one yaml and does not consider naming conflicts.
-// These fields include: [ignore, loggers]
+// These fields include: [ignore, impacted tasks, loggers]
 func (pp *ParserProject) mergeUnordered(toMerge *ParserProject) {
 	pp.Ignore = append(pp.Ignore, toMerge.Ignore...)
+	pp.ImpactedTasks = append(pp.ImpactedTasks, toMerge.ImpactedTasks...)
 	pp.Loggers = mergeAllLogs(pp.Loggers, toMerge.Loggers)
 }
 
@@ -2004,6 +2004,7 @@ func TestMergeUnordered(t *testing.T) {
 		Ignore: parserStringSlice{
 			"a",
 		},
+		ImpactedTasks: []ImpactedTasksRule{{Paths: []string{"a"}, Tasks: []string{"t1"}}},
 		Loggers: &LoggerConfig{
 			Agent:  []LogOpts{{Type: LogkeeperLogSender}},
 			System: []LogOpts{{Type: LogkeeperLogSender}},
@@ -2015,6 +2016,7 @@ func TestMergeUnordered(t *testing.T) {
 		Ignore: parserStringSlice{
 			"b",
 		},
+		ImpactedTasks: []ImpactedTasksRule{{Paths: []string{"b"}, Tasks: []string{"t2"}}},
 		Loggers: &LoggerConfig{
 			Agent:  []LogOpts{{LogDirectory: "a"}},
 			System: []LogOpts{{LogDirectory: "a"}},
@@ -2023,6 +2025,7 @@ func TestMergeUnordered(t *testing.T) {
 	}
 	main.mergeUnordered(add)
 	assert.Equal(t, len(main.Ignore), 2)
+	assert.Equal(t, len(main.ImpactedTasks), 2)
 	assert.Equal(t, len(main.Loggers.Agent), 2)
 	assert.Equal(t, len(main.Loggers.System), 2)
 	assert.Equal(t, len(main.Loggers.Task), 2)
@@ -2621,3 +2624,46 @@ func TestFindAndTranslateProjectForPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestParserProjectConfigDigest(t *testing.T) {
+	yml := `
+functions:
+  f1:
+    command: shell.exec
+    params:
+      script: echo one
+  f2:
+    command: shell.exec
+    params:
+      script: echo two
+  f3:
+    command: shell.exec
+    params:
+      script: echo three
+  f4:
+    command: shell.exec
+    params:
+      script: echo four
+tasks:
+  - name: t1
+    commands:
+      - func: f1
+      - func: f2
+`
+	pp, err := createIntermediateProject([]byte(yml), false)
+	require.NoError(t, err)
+
+	digest, err := pp.ConfigDigest()
+	require.NoError(t, err)
+	assert.Len(t, digest, 64)
+	for i := 0; i < 10; i++ {
+		otherDigest, err := pp.ConfigDigest()
+		require.NoError(t, err)
+		assert.Equal(t, digest, otherDigest, "digest should not depend on map ordering")
+	}
+
+	pp.Functions["f4"] = pp.Functions["f1"]
+	changedDigest, err := pp.ConfigDigest()
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, changedDigest)
+}
@@ -49,26 +49,31 @@ type ProjectRef struct {
 	// Identifier must be unique, but is modifiable. Used by users.
 	Identifier string `bson:"identifier" json:"identifier" yaml:"identifier"`
 
-	DisplayName            string              `bson:"display_name" json:"display_name,omitempty" yaml:"display_name"`
-	Enabled                bool                `bson:"enabled,omitempty" json:"enabled,omitempty" yaml:"enabled"`
-	Private                *bool               `bson:"private,omitempty" json:"private,omitempty" yaml:"private"`
-	Restricted             *bool               `bson:"restricted,omitempty" json:"restricted,omitempty" yaml:"restricted"`
-	Owner                  string              `bson:"owner_name" json:"owner_name" yaml:"owner"`
-	Repo                   string              `bson:"repo_name" json:"repo_name" yaml:"repo"`
-	Branch                 string              `bson:"branch_name" json:"branch_name" yaml:"branch"`
-	RemotePath             string              `bson:"remote_path" json:"remote_path" yaml:"remote_path"`
-	PatchingDisabled       *bool               `bson:"patching_disabled,omitempty" json:"patching_disabled,omitempty"`
-	RepotrackerDisabled    *bool               `bson:"repotracker_disabled,omitempty" json:"repotracker_disabled,omitempty" yaml:"repotracker_disabled"`
-	DispatchingDisabled    *bool               `bson:"dispatching_disabled,omitempty" json:"dispatching_disabled,omitempty" yaml:"dispatching_disabled"`
-	StepbackDisabled       *bool               `bson:"stepback_disabled,omitempty" json:"stepback_disabled,omitempty" yaml:"stepback_disabled"`
-	VersionControlEnabled  *bool               `bson:"version_control_enabled,omitempty" json:"version_control_enabled,omitempty" yaml:"version_control_enabled"`
-	PRTestingEnabled       *bool               `bson:"pr_testing_enabled,omitempty" json:"pr_testing_enabled,omitempty" yaml:"pr_testing_enabled"`
-	ManualPRTestingEnabled *bool               `bson:"manual_pr_testing_enabled,omitempty" json:"manual_pr_testing_enabled,omitempty" yaml:"manual_pr_testing_enabled"`
-	GithubChecksEnabled    *bool               `bson:"github_checks_enabled,omitempty" json:"github_checks_enabled,omitempty" yaml:"github_checks_enabled"`
-	BatchTime              int                 `bson:"batch_time" json:"batch_time" yaml:"batchtime"`
-	DeactivatePrevious     *bool               `bson:"deactivate_previous,omitempty" json:"deactivate_previous,omitempty" yaml:"deactivate_previous"`
-	NotifyOnBuildFailure   *bool               `bson:"notify_on_failure,omitempty" json:"notify_on_failure,omitempty"`
-	Triggers               []TriggerDefinition `bson:"triggers" json:"triggers"`
+	DisplayName             string              `bson:"display_name" json:"display_name,omitempty" yaml:"display_name"`
+	Enabled                 bool                `bson:"enabled,omitempty" json:"enabled,omitempty" yaml:"enabled"`
+	Private                 *bool               `bson:"private,omitempty" json:"private,omitempty" yaml:"private"`
+	Restricted              *bool               `bson:"restricted,omitempty" json:"restricted,omitempty" yaml:"restricted"`
+	Owner                   string              `bson:"owner_name" json:"owner_name" yaml:"owner"`
+	Repo                    string              `bson:"repo_name" json:"repo_name" yaml:"repo"`
+	Branch                  string              `bson:"branch_name" json:"branch_name" yaml:"branch"`
+	RemotePath              string              `bson:"remote_path" json:"remote_path" yaml:"remote_path"`
+	PatchingDisabled        *bool               `bson:"patching_disabled,omitempty" json:"patching_disabled,omitempty"`
+	RepotrackerDisabled     *bool               `bson:"repotracker_disabled,omitempty" json:"repotracker_disabled,omitempty" yaml:"repotracker_disabled"`
+	DispatchingDisabled     *bool               `bson:"dispatching_disabled,omitempty" json:"dispatching_disabled,omitempty" yaml:"dispatching_disabled"`
+	StepbackDisabled        *bool               `bson:"stepback_disabled,omitempty" json:"stepback_disabled,omitempty" yaml:"stepback_disabled"`
+	VersionControlEnabled   *bool               `bson:"version_control_enabled,omitempty" json:"version_control_enabled,omitempty" yaml:"version_control_enabled"`
+	PRTestingEnabled        *bool               `bson:"pr_testing_enabled,omitempty" json:"pr_testing_enabled,omitempty" yaml:"pr_testing_enabled"`
+	ManualPRTestingEnabled  *bool               `bson:"manual_pr_testing_enabled,omitempty" json:"manual_pr_testing_enabled,omitempty" yaml:"manual_pr_testing_enabled"`
+	GithubChecksEnabled     *bool               `bson:"github_checks_enabled,omitempty" json:"github_checks_enabled,omitempty" yaml:"github_checks_enabled"`
+	GithubCheckRunsEnabled  *bool               `bson:"github_check_runs_enabled,omitempty" json:"github_check_runs_enabled,omitempty" yaml:"github_check_runs_enabled"`
+	GithubCheckRunsPerTask  *bool               `bson:"github_check_runs_per_task,omitempty" json:"github_check_runs_per_task,omitempty" yaml:"github_check_runs_per_task"`
+	GithubMergeQueueEnabled *bool               `bson:"github_merge_queue_enabled,omitempty" json:"github_merge_queue_enabled,omitempty" yaml:"github_merge_queue_enabled"`
+	GithubMergeQueueAlias   string              `bson:"github_merge_queue_alias,omitempty" json:"github_merge_queue_alias,omitempty" yaml:"github_merge_queue_alias"`
+	PRSummaryCommentEnabled *bool               `bson:"pr_summary_comment_enabled,omitempty" json:"pr_summary_comment_enabled,omitempty" yaml:"pr_summary_comment_enabled"`
+	BatchTime               int                 `bson:"batch_time" json:"batch_time" yaml:"batchtime"`
+	DeactivatePrevious      *bool               `bson:"deactivate_previous,omitempty" json:"deactivate_previous,omitempty" yaml:"deactivate_previous"`
+	NotifyOnBuildFailure    *bool               `bson:"notify_on_failure,omitempty" json:"notify_on_failure,omitempty"`
+	Triggers                []TriggerDefinition `bson:"triggers" json:"triggers"`
 	// all aliases defined for the project
 	PatchTriggerAliases []patch.PatchTriggerDefinition `bson:"patch_trigger_aliases" json:"patch_trigger_aliases"`
 	// all PatchTriggerAliases applied to github patch intents
@@ -291,6 +296,11 @@ var (
 	projectRefPRTestingEnabledKey         = bsonutil.MustHaveTag(ProjectRef{}, "PRTestingEnabled")
 	projectRefManualPRTestingEnabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "ManualPRTestingEnabled")
 	projectRefGithubChecksEnabledKey      = bsonutil.MustHaveTag(ProjectRef{}, "GithubChecksEnabled")
+	projectRefGithubCheckRunsEnabledKey   = bsonutil.MustHaveTag(ProjectRef{}, "GithubCheckRunsEnabled")
+	projectRefGithubCheckRunsPerTaskKey   = bsonutil.MustHaveTag(ProjectRef{}, "GithubCheckRunsPerTask")
+	projectRefGithubMergeQueueEnabledKey  = bsonutil.MustHaveTag(ProjectRef{}, "GithubMergeQueueEnabled")
+	projectRefGithubMergeQueueAliasKey    = bsonutil.MustHaveTag(ProjectRef{}, "GithubMergeQueueAlias")
+	projectRefPRSummaryCommentEnabledKey  = bsonutil.MustHaveTag(ProjectRef{}, "PRSummaryCommentEnabled")
 	projectRefGitTagVersionsEnabledKey    = bsonutil.MustHaveTag(ProjectRef{}, "GitTagVersionsEnabled")
 	projectRefRepotrackerDisabledKey      = bsonutil.MustHaveTag(ProjectRef{}, "RepotrackerDisabled")
 	projectRefCommitQueueKey              = bsonutil.MustHaveTag(ProjectRef{}, "CommitQueue")
@@ -370,6 +380,31 @@ func (p *ProjectRef) IsGithubChecksEnabled() bool {
 	return utility.FromBoolPtr(p.GithubChecksEnabled)
 }
 
+func (p *ProjectRef) IsGithubCheckRunsEnabled() bool {
+	return utility.FromBoolPtr(p.GithubCheckRunsEnabled)
+}
+
+func (p *ProjectRef) IsGithubCheckRunsPerTask() bool {
+	return utility.FromBoolPtr(p.GithubCheckRunsPerTask)
+}
+
+func (p *ProjectRef) IsPRSummaryCommentEnabled() bool {
+	return utility.FromBoolPtr(p.PRSummaryCommentEnabled)
+}
+
+func (p *ProjectRef) IsGithubMergeQueueEnabled() bool {
+	return utility.FromBoolPtr(p.GithubMergeQueueEnabled)
+}
+
+// GetGithubMergeQueueAlias returns the alias that selects the tasks to run for
+// a GitHub merge group.
+func (p *ProjectRef) GetGithubMergeQueueAlias() string {
+	if p.GithubMergeQueueAlias != "" {
+		return p.GithubMergeQueueAlias
+	}
+	return evergreen.CommitQueueAlias
+}
+
 func (p *ProjectRef) ShouldDeactivatePrevious() bool {
 	return utility.FromBoolPtr(p.DeactivatePrevious)
 }
@@ -1977,13 +2012,18 @@ func SaveProjectPageForSection(projectId string, p *ProjectRef, section ProjectP
 			bson.M{ProjectRefIdKey: projectId},
 			bson.M{
 				"$set": bson.M{
-					projectRefPRTestingEnabledKey:       p.PRTestingEnabled,
-					projectRefManualPRTestingEnabledKey: p.ManualPRTestingEnabled,
-					projectRefGithubChecksEnabledKey:    p.GithubChecksEnabled,
-					projectRefGitTagVersionsEnabledKey:  p.GitTagVersionsEnabled,
-					ProjectRefGitTagAuthorizedUsersKey:  p.GitTagAuthorizedUsers,
-					ProjectRefGitTagAuthorizedTeamsKey:  p.GitTagAuthorizedTeams,
-					projectRefCommitQueueKey:            p.CommitQueue,
+					projectRefPRTestingEnabledKey:        p.PRTestingEnabled,
+					projectRefManualPRTestingEnabledKey:  p.ManualPRTestingEnabled,
+					projectRefGithubChecksEnabledKey:     p.GithubChecksEnabled,
+					projectRefGithubCheckRunsEnabledKey:  p.GithubCheckRunsEnabled,
+					projectRefGithubCheckRunsPerTaskKey:  p.GithubCheckRunsPerTask,
+					projectRefGithubMergeQueueEnabledKey: p.GithubMergeQueueEnabled,
+					projectRefGithubMergeQueueAliasKey:   p.GithubMergeQueueAlias,
+					projectRefPRSummaryCommentEnabledKey: p.PRSummaryCommentEnabled,
+					projectRefGitTagVersionsEnabledKey:   p.GitTagVersionsEnabled,
+					ProjectRefGitTagAuthorizedUsersKey:   p.GitTagAuthorizedUsers,
+					ProjectRefGitTagAuthorizedTeamsKey:   p.GitTagAuthorizedTeams,
+					projectRefCommitQueueKey:             p.CommitQueue,
 				},
 			})
 	case ProjectPageNotificationsSection:
@@ -15,6 +15,10 @@ type Repository struct {
 	Project             string `bson:"_id"`
 	LastRevision        string `bson:"last_revision"`
 	RevisionOrderNumber int    `bson:"last_commit_number"`
+	// MergeQueueOrderNumber is the order number of the project's most
+	// recent GitHub merge queue version. Merge queue versions are numbered
+	// separately from mainline versions.
+	MergeQueueOrderNumber int `bson:"last_merge_queue_number,omitempty"`
 }
 
 var (
@@ -25,6 +29,8 @@ var (
 		"LastRevision")
 	RepositoryOrderNumberKey = bsonutil.MustHaveTag(Repository{},
 		"RevisionOrderNumber")
+	RepositoryMergeQueueOrderNumberKey = bsonutil.MustHaveTag(Repository{},
+		"MergeQueueOrderNumber")
 )
 
 const (
@@ -93,6 +99,25 @@ func UpdateLastRevision(projectId, revision string) error {
 
 // GetNewRevisionOrderNumber gets a new revision order number for a project.
 func GetNewRevisionOrderNumber(projectId string) (int, error) {
+	repo, err := incRepositoryOrderNumber(projectId, RepositoryOrderNumberKey)
+	if err != nil {
+		return 0, err
+	}
+	return repo.RevisionOrderNumber, nil
+}
+
+// GetNewMergeQueueOrderNumber gets a new order number for a project's GitHub
+// merge queue version. It does not use up a mainline revision order number,
+// so mainline versions stay numbered consecutively.
+func GetNewMergeQueueOrderNumber(projectId string) (int, error) {
+	repo, err := incRepositoryOrderNumber(projectId, RepositoryMergeQueueOrderNumberKey)
+	if err != nil {
+		return 0, err
+	}
+	return repo.MergeQueueOrderNumber, nil
+}
+
+func incRepositoryOrderNumber(projectId, key string) (*Repository, error) {
 	repo := &Repository{}
 	_, err := db.FindAndModify(
 		RepositoriesCollection,
@@ -103,7 +128,7 @@ func GetNewRevisionOrderNumber(projectId string) (int, error) {
 		adb.Change{
 			Update: bson.M{
 				"$inc": bson.M{
-					RepositoryOrderNumberKey: 1,
+					key: 1,
 				},
 			},
 			Upsert:    true,
@@ -112,7 +137,7 @@ func GetNewRevisionOrderNumber(projectId string) (int, error) {
 		repo,
 	)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return repo.RevisionOrderNumber, nil
+	return repo, nil
 }
@@ -58,6 +58,28 @@ func TestGetNewRevisionOrderNumber(t *testing.T) {
 	})
 }
 
+func TestGetNewMergeQueueOrderNumber(t *testing.T) {
+	require.NoError(t, db.Clear(RepositoriesCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(RepositoriesCollection))
+	}()
+
+	ron, err := GetNewRevisionOrderNumber(projectName)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ron)
+
+	mqon, err := GetNewMergeQueueOrderNumber(projectName)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mqon)
+	mqon, err = GetNewMergeQueueOrderNumber(projectName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, mqon)
+
+	ron, err = GetNewRevisionOrderNumber(projectName)
+	require.NoError(t, err)
+	assert.Equal(t, 2, ron, "merge queue versions should not use up mainline order numbers")
+}
+
 func TestUpdateLastRevision(t *testing.T) {
 	for name, test := range map[string]func(*testing.T, string, string){
 		"InvalidProject": func(t *testing.T, project string, revision string) {
@@ -33,6 +33,20 @@ var (
 		{Key: OverrideDependenciesKey, Value: 1},
 		{Key: bsonutil.GetDottedKeyName(DependsOnKey, DependencyUnattainableKey), Value: 1},
 	}
+	// CedarTestResultsIndex supports finding tasks whose test results are
+	// stored in Cedar. It is created on both the tasks and old tasks
+	// collections.
+	CedarTestResultsIndex = bson.D{
+		{Key: ResultsServiceKey, Value: 1},
+		{Key: HasCedarResultsKey, Value: 1},
+		{Key: StatusKey, Value: 1},
+	}
+	// DistroDemandIndex supports finding the tasks that were activated in a
+	// distro during a window of time.
+	DistroDemandIndex = bson.D{
+		{Key: DistroIdKey, Value: 1},
+		{Key: ActivatedTimeKey, Value: 1},
+	}
 )
 
 var (
@@ -96,6 +110,7 @@ var (
 	GeneratedByKey                 = bsonutil.MustHaveTag(Task{}, "GeneratedBy")
 	ResultsServiceKey              = bsonutil.MustHaveTag(Task{}, "ResultsService")
 	HasCedarResultsKey             = bsonutil.MustHaveTag(Task{}, "HasCedarResults")
+	ResultsBackfillFailuresKey     = bsonutil.MustHaveTag(Task{}, "ResultsBackfillFailures")
 	ResultsFailedKey               = bsonutil.MustHaveTag(Task{}, "ResultsFailed")
 	IsGithubCheckKey               = bsonutil.MustHaveTag(Task{}, "IsGithubCheck")
 	HostCreateDetailsKey           = bsonutil.MustHaveTag(Task{}, "HostCreateDetails")
@@ -1635,6 +1650,60 @@ func FindOldWithFields(filter bson.M, fields ...string) ([]Task, error) {
 	return tasks, err
 }
 
+// MaxResultsBackfillFailures is the number of times that copying a task's
+// test results out of Cedar can fail before the backfill stops trying, so
+// that tasks that keep failing do not block the rest of the backfill.
+const MaxResultsBackfillFailures = 3
+
+// ByCedarTestResults returns a query for finished execution tasks whose test
+// results are stored in Cedar. Tasks that finished before the test results
+// service was recorded only have HasCedarResults set. Tasks whose test
+// results have failed to be copied out of Cedar too many times are excluded.
+func ByCedarTestResults() bson.M {
+	return bson.M{
+		StatusKey:                  bson.M{"$in": evergreen.TaskCompletedStatuses},
+		DisplayOnlyKey:             bson.M{"$ne": true},
+		ResultsBackfillFailuresKey: bson.M{"$not": bson.M{"$gte": MaxResultsBackfillFailures}},
+		"$or": []bson.M{
+			{ResultsServiceKey: evergreen.TestResultsServiceCedar},
+			{
+				ResultsServiceKey:  bson.M{"$exists": false},
+				HasCedarResultsKey: true,
+			},
+		},
+	}
+}
+
+// FindWithCedarTestResults returns up to limit finished execution tasks whose
+// test results are stored in Cedar.
+func FindWithCedarTestResults(limit int) ([]Task, error) {
+	tasks := []Task{}
+	query := db.Query(ByCedarTestResults()).
+		WithFields(IdKey, ExecutionKey, ResultsServiceKey, HasCedarResultsKey).
+		Limit(limit)
+	err := db.FindAllQ(Collection, query, &tasks)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+
+	return tasks, err
+}
+
+// FindOldWithCedarTestResults returns up to limit archived execution tasks
+// whose test results are stored in Cedar.
+func FindOldWithCedarTestResults(limit int) ([]Task, error) {
+	tasks := []Task{}
+	query := db.Query(ByCedarTestResults()).
+		WithFields(IdKey, OldTaskIdKey, ArchivedKey, ExecutionKey, ResultsServiceKey, HasCedarResultsKey).
+		Limit(limit)
+	err := db.FindAllQ(OldCollection, query, &tasks)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+
+	return tasks, err
+}
+
 // FindOldWithDisplayTasks returns all display and execution tasks from the old
 // collection that satisfy the given query.
 func FindOldWithDisplayTasks(filter bson.M) ([]Task, error) {
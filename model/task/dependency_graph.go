@@ -165,6 +165,24 @@ func (g *DependencyGraph) EdgesIntoTask(t TaskNode) []DependencyEdge {
 	return edges
 }
 
+// EdgesFromTask returns all the edges that point from t.
+// For a regular graph these edges are tasks t directly depends on.
+// If the graph is transposed these edges are tasks that directly depend on t.
+func (g *DependencyGraph) EdgesFromTask(t TaskNode) []DependencyEdge {
+	node := g.tasksToNodes[t]
+	if node == nil {
+		return nil
+	}
+
+	var edges []DependencyEdge
+	nodes := g.graph.From(node.ID())
+	for nodes.Next() {
+		edges = append(edges, g.edgesToDependencies[edgeKey{from: t, to: g.nodesToTasks[nodes.Node()]}])
+	}
+
+	return edges
+}
+
 // GetDependencyEdge returns a pointer to the edge from fromNode to toNode.
 // If the edge doesn't exist it returns nil.
 func (g *DependencyGraph) GetDependencyEdge(fromTask, toTask TaskNode) *DependencyEdge {
@@ -250,6 +250,26 @@ func TestTasksDependingOnTask(t *testing.T) {
 	assert.Equal(t, tasks[0].Id, edges[0].From.ID)
 }
 
+func TestTasksDependedOnByTask(t *testing.T) {
+	tasks := []Task{
+		{Id: "t0", DependsOn: []Dependency{{TaskId: "t1"}, {TaskId: "t2"}}},
+		{Id: "t1"},
+		{Id: "t2"},
+	}
+	g := NewDependencyGraph(false)
+	g.buildFromTasks(tasks)
+
+	assert.Empty(t, g.EdgesFromTask(tasks[1].ToTaskNode()))
+	edges := g.EdgesFromTask(tasks[0].ToTaskNode())
+	require.Len(t, edges, 2)
+	var dependedOn []string
+	for _, edge := range edges {
+		assert.Equal(t, tasks[0].Id, edge.From.ID)
+		dependedOn = append(dependedOn, edge.To.ID)
+	}
+	assert.ElementsMatch(t, []string{tasks[1].Id, tasks[2].Id}, dependedOn)
+}
+
 func TestReachableFromNode(t *testing.T) {
 	tasks := []Task{
 		{Id: "t0", DependsOn: []Dependency{{TaskId: "t1"}, {TaskId: "t2"}}},
@@ -0,0 +1,72 @@
+package task
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DistroDemand is the amount of work that the tasks in a distro created within
+// a window of time.
+type DistroDemand struct {
+	// Start is the beginning of the window.
+	Start time.Time `json:"start"`
+	// NumTasks is the number of tasks that were activated during the window.
+	NumTasks int `json:"num_tasks"`
+	// TotalDuration is the sum of the durations of the tasks that were
+	// activated during the window. Each task's duration is capped at the
+	// length of the window, since the remainder of the task runs after the
+	// window has ended.
+	TotalDuration time.Duration `json:"total_duration"`
+}
+
+// GetDistroDemand returns the demand for hosts created by the tasks in the
+// distro that were activated within each window of the given length. A task's
+// duration is the time it took to run or, if it has not finished, its expected
+// duration.
+func GetDistroDemand(distroID string, starts []time.Time, window time.Duration) ([]DistroDemand, error) {
+	if len(starts) == 0 {
+		return nil, nil
+	}
+
+	windows := make([]bson.M, 0, len(starts))
+	for _, start := range starts {
+		windows = append(windows, bson.M{ActivatedTimeKey: bson.M{
+			"$gte": start,
+			"$lt":  start.Add(window),
+		}})
+	}
+	tasks, err := FindAll(db.Query(bson.M{
+		DistroIdKey:    distroID,
+		DisplayOnlyKey: bson.M{"$ne": true},
+		"$or":          windows,
+	}).WithFields(ActivatedTimeKey, TimeTakenKey, ExpectedDurationKey))
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding tasks activated in distro '%s'", distroID)
+	}
+
+	demand := make([]DistroDemand, len(starts))
+	for i, start := range starts {
+		demand[i].Start = start
+	}
+	for _, t := range tasks {
+		duration := t.TimeTaken
+		if duration == 0 {
+			duration = t.ExpectedDuration
+		}
+		if duration > window {
+			duration = window
+		}
+		for i, start := range starts {
+			if t.ActivatedTime.Before(start) || !t.ActivatedTime.Before(start.Add(window)) {
+				continue
+			}
+			demand[i].NumTasks++
+			demand[i].TotalDuration += duration
+		}
+	}
+
+	return demand, nil
+}
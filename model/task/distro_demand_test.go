@@ -0,0 +1,53 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestGetDistroDemand(t *testing.T) {
+	require.NoError(t, db.ClearCollections(Collection))
+	require.NoError(t, db.EnsureIndex(Collection, mongo.IndexModel{Keys: DistroDemandIndex}))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection))
+	}()
+
+	now := time.Now().Round(time.Second)
+	lastWeek := now.Add(-7 * 24 * time.Hour)
+	twoWeeksAgo := now.Add(-14 * 24 * time.Hour)
+	window := 30 * time.Minute
+
+	for _, tsk := range []Task{
+		{Id: "t0", DistroId: "d0", ActivatedTime: lastWeek.Add(time.Minute), TimeTaken: 10 * time.Minute},
+		{Id: "t1", DistroId: "d0", ActivatedTime: lastWeek.Add(5 * time.Minute), TimeTaken: 2 * time.Hour},
+		{Id: "t2", DistroId: "d0", ActivatedTime: twoWeeksAgo.Add(10 * time.Minute), ExpectedDuration: 20 * time.Minute},
+		{Id: "t3", DistroId: "d0", ActivatedTime: lastWeek.Add(window)},
+		{Id: "t4", DistroId: "d1", ActivatedTime: lastWeek.Add(time.Minute), TimeTaken: time.Minute},
+		{Id: "t5", DistroId: "d0", ActivatedTime: lastWeek.Add(time.Minute), DisplayOnly: true},
+	} {
+		require.NoError(t, tsk.Insert())
+	}
+
+	demand, err := GetDistroDemand("d0", []time.Time{lastWeek, twoWeeksAgo, now}, window)
+	require.NoError(t, err)
+	require.Len(t, demand, 3)
+
+	assert.True(t, lastWeek.Equal(demand[0].Start))
+	assert.Equal(t, 2, demand[0].NumTasks)
+	assert.Equal(t, 40*time.Minute, demand[0].TotalDuration)
+
+	assert.Equal(t, 1, demand[1].NumTasks)
+	assert.Equal(t, 20*time.Minute, demand[1].TotalDuration)
+
+	assert.Zero(t, demand[2].NumTasks)
+	assert.Zero(t, demand[2].TotalDuration)
+
+	demand, err = GetDistroDemand("d0", nil, window)
+	assert.NoError(t, err)
+	assert.Empty(t, demand)
+}
@@ -96,6 +96,10 @@ type Task struct {
 	// sent back by the agent
 	LastHeartbeat time.Time `bson:"last_heartbeat" json:"last_heartbeat"`
 
+	// ResultsBackfillFailures is the number of times that copying the task's
+	// test results out of Cedar has failed.
+	ResultsBackfillFailures int `bson:"results_backfill_failures,omitempty" json:"results_backfill_failures,omitempty"`
+
 	// Activated indicates whether the task should be scheduled to run or not.
 	Activated                bool   `bson:"activated" json:"activated"`
 	ActivatedBy              string `bson:"activated_by" json:"activated_by"`
@@ -1448,6 +1452,51 @@ func (t *Task) SetResultsInfo(service string, failedResults bool) error {
 	return errors.WithStack(UpdateOne(bson.M{IdKey: t.Id}, bson.M{"$set": set}))
 }
 
+// MigrateResultsService sets the test results service of a finished task
+// whose test results have been copied to another test results service. Unlike
+// SetResultsInfo, this replaces the task's existing service.
+func (t *Task) MigrateResultsService(service string) error {
+	coll := Collection
+	if t.Archived {
+		coll = OldCollection
+	}
+
+	err := db.Update(coll, bson.M{
+		IdKey:        t.Id,
+		ExecutionKey: t.Execution,
+	}, bson.M{
+		"$set": bson.M{ResultsServiceKey: service},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "migrating test results service for task '%s'", t.Id)
+	}
+	t.ResultsService = service
+
+	return nil
+}
+
+// IncResultsBackfillFailures records that copying the task's test results out
+// of Cedar failed, so that the task is eventually skipped by the backfill.
+func (t *Task) IncResultsBackfillFailures() error {
+	coll := Collection
+	if t.Archived {
+		coll = OldCollection
+	}
+
+	err := db.Update(coll, bson.M{
+		IdKey:        t.Id,
+		ExecutionKey: t.Execution,
+	}, bson.M{
+		"$inc": bson.M{ResultsBackfillFailuresKey: 1},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "recording test results backfill failure for task '%s'", t.Id)
+	}
+	t.ResultsBackfillFailures++
+
+	return nil
+}
+
 // HasResults returns whether the task has test results or not.
 func (t *Task) HasResults() bool {
 	if t.DisplayOnly && len(t.ExecutionTasks) > 0 {
@@ -0,0 +1,284 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// TaskEnvironment is what is needed to reproduce a task outside of the agent,
+// such as on a spawn host.
+type TaskEnvironment struct {
+	// Expansions are the task's expansions, excluding private project
+	// variables and credentials.
+	Expansions map[string]string
+	// SetupScript is a shell script that runs the task's setup commands.
+	SetupScript string
+	// TaskScript is a shell script that runs the task's commands.
+	TaskScript string
+}
+
+// FetchSourceDirName returns the name of the directory that `evergreen fetch
+// --source` clones a task's source into, within the directory that it fetches
+// into.
+func FetchSourceDirName(projectID, requester, revision string, patchNumber int) string {
+	if evergreen.IsPatchRequester(requester) {
+		return util.CleanForPath(fmt.Sprintf("source-patch-%v_%v", patchNumber, projectID))
+	}
+	if len(revision) >= 6 {
+		return util.CleanForPath(fmt.Sprintf("source-%v-%v", projectID, revision[0:6]))
+	}
+	return util.CleanForPath(fmt.Sprintf("source-%v", projectID))
+}
+
+// MakeTaskEnvironment resolves the expansions and commands of a task so that
+// it can be reproduced on a spawn host whose task data was fetched into
+// fetchDir. The task runs in the source directory that the fetch created. Only
+// shell.exec and subprocess.exec commands can run outside of the agent, so
+// the scripts list the task's other commands as comments.
+func MakeTaskEnvironment(t *task.Task, h *host.Host, fetchDir string) (*TaskEnvironment, error) {
+	if t == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	var workDir string
+	if fetchDir != "" {
+		var patchNumber int
+		if evergreen.IsPatchRequester(t.Requester) {
+			p, err := patch.FindOneId(t.Version)
+			if err != nil {
+				return nil, errors.Wrapf(err, "finding patch '%s'", t.Version)
+			}
+			if p == nil {
+				return nil, errors.Errorf("patch '%s' not found", t.Version)
+			}
+			patchNumber = p.PatchNumber
+		}
+		workDir = path.Join(fetchDir, FetchSourceDirName(t.Project, t.Requester, t.Revision, patchNumber))
+	}
+
+	project, err := FindProjectFromVersionID(t.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding project for version '%s'", t.Version)
+	}
+
+	// Credentials are deliberately left out since the environment is written
+	// to a host that is not managed by Evergreen.
+	expansions, err := PopulateExpansions(t, h, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "populating expansions")
+	}
+	expansions.Remove(evergreen.GlobalGitHubTokenExpansion)
+	if workDir != "" {
+		expansions.Put("workdir", workDir)
+	}
+	if bv := project.FindBuildVariant(t.BuildVariant); bv != nil {
+		expansions.Update(bv.Expansions)
+	}
+
+	projectVars, err := FindMergedProjectVars(t.Project)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting merged project vars")
+	}
+	if projectVars != nil {
+		for k, v := range projectVars.GetVars(t) {
+			if !projectVars.PrivateVars[k] {
+				expansions.Put(k, v)
+			}
+		}
+	}
+
+	v, err := VersionFindOne(VersionById(t.Version))
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding version '%s'", t.Version)
+	}
+	if v != nil {
+		for _, param := range v.Parameters {
+			expansions.Put(param.Key, param.Value)
+		}
+	}
+
+	var setupCmds []PluginCommandConf
+	if tg := project.FindTaskGroup(t.TaskGroup); t.TaskGroup != "" && tg != nil {
+		if tg.SetupGroup != nil {
+			setupCmds = append(setupCmds, tg.SetupGroup.List()...)
+		}
+		if tg.SetupTask != nil {
+			setupCmds = append(setupCmds, tg.SetupTask.List()...)
+		}
+	} else if project.Pre != nil {
+		setupCmds = project.Pre.List()
+	}
+	var taskCmds []PluginCommandConf
+	if pt := project.FindProjectTask(t.DisplayName); pt != nil {
+		taskCmds = pt.Commands
+	}
+
+	r := taskScriptRenderer{project: project, task: t, expansions: expansions}
+	return &TaskEnvironment{
+		Expansions:  expansions.Map(),
+		SetupScript: r.render(fmt.Sprintf("Setup commands for task '%s' (%s).", t.DisplayName, t.Id), workDir, setupCmds),
+		TaskScript:  r.render(fmt.Sprintf("Commands for task '%s' (%s) on build variant '%s'.", t.DisplayName, t.Id, t.BuildVariant), workDir, taskCmds),
+	}, nil
+}
+
+// taskScriptRenderer renders a task's commands as a shell script.
+type taskScriptRenderer struct {
+	project    *Project
+	task       *task.Task
+	expansions util.Expansions
+}
+
+func (r *taskScriptRenderer) render(description, workDir string, cmds []PluginCommandConf) string {
+	lines := []string{
+		"#!/usr/bin/env bash",
+		"# " + description,
+		"# Generated by Evergreen. Commands that can only run in the agent are listed as comments.",
+		"set -o errexit",
+	}
+	if workDir != "" {
+		lines = append(lines, fmt.Sprintf("cd %s", shellQuote(workDir)))
+	}
+	for _, cmd := range cmds {
+		lines = append(lines, r.renderCommand(cmd, r.expansions, "")...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (r *taskScriptRenderer) renderCommand(cmd PluginCommandConf, expansions util.Expansions, function string) []string {
+	if len(cmd.Parallel) > 0 {
+		lines := []string{"", "# The following commands run in parallel in the agent."}
+		for _, blockCmd := range cmd.Parallel {
+			lines = append(lines, r.renderCommand(blockCmd, expansions, function)...)
+		}
+		return lines
+	}
+	if len(cmd.Variants) > 0 && !utility.StringSliceContains(cmd.Variants, r.task.BuildVariant) {
+		return nil
+	}
+
+	if cmd.Function != "" {
+		fn, ok := r.project.Functions[cmd.Function]
+		if !ok || fn == nil {
+			return []string{"", fmt.Sprintf("# Function '%s' is not defined.", cmd.Function)}
+		}
+		fnExpansions := *util.NewExpansions(expansions.Map())
+		fnExpansions.Update(cmd.Vars)
+		var lines []string
+		for _, fnCmd := range fn.List() {
+			lines = append(lines, r.renderCommand(fnCmd, fnExpansions, cmd.Function)...)
+		}
+		return lines
+	}
+
+	header := "# " + cmd.Command
+	if function != "" {
+		header += fmt.Sprintf(" (function '%s')", function)
+	}
+	if cmd.DisplayName != "" {
+		header += fmt.Sprintf(" '%s'", cmd.DisplayName)
+	}
+	lines := []string{"", header}
+	if cmd.If != "" {
+		lines = append(lines, fmt.Sprintf("# The agent only runs this command if: %s", cmd.If))
+	}
+
+	if cmd.Command == "git.get_project" {
+		return append(lines, "# The task's source was already fetched into the working directory.")
+	}
+	body, err := r.renderExec(cmd, expansions)
+	if err != nil {
+		return append(lines, fmt.Sprintf("# Cannot run this command outside of the agent: %s", strings.ReplaceAll(err.Error(), "\n", " ")))
+	}
+	return append(lines, body...)
+}
+
+// taskScriptExecParams are the parameters of shell.exec and subprocess.exec
+// that are needed to run the commands in a script.
+type taskScriptExecParams struct {
+	Script     string            `mapstructure:"script" plugin:"expand"`
+	Shell      string            `mapstructure:"shell" plugin:"expand"`
+	Binary     string            `mapstructure:"binary" plugin:"expand"`
+	Args       []string          `mapstructure:"args" plugin:"expand"`
+	Command    string            `mapstructure:"command" plugin:"expand"`
+	Env        map[string]string `mapstructure:"env" plugin:"expand"`
+	WorkingDir string            `mapstructure:"working_dir" plugin:"expand"`
+}
+
+func (r *taskScriptRenderer) renderExec(cmd PluginCommandConf, expansions util.Expansions) ([]string, error) {
+	if cmd.Command != "shell.exec" && cmd.Command != "subprocess.exec" {
+		return nil, errors.Errorf("command '%s' is not supported", cmd.Command)
+	}
+
+	params := taskScriptExecParams{}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{WeaklyTypedInput: true, Result: &params})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating params decoder")
+	}
+	if err = decoder.Decode(cmd.Params); err != nil {
+		return nil, errors.Wrap(err, "decoding params")
+	}
+	if err = util.ExpandValues(&params, &expansions); err != nil {
+		return nil, errors.Wrap(err, "applying expansions")
+	}
+
+	lines := []string{"("}
+	if params.WorkingDir != "" {
+		lines = append(lines, fmt.Sprintf("cd %s", shellQuote(params.WorkingDir)))
+	}
+	envKeys := make([]string, 0, len(params.Env))
+	for k := range params.Env {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		lines = append(lines, fmt.Sprintf("export %s=%s", k, shellQuote(params.Env[k])))
+	}
+
+	if cmd.Command == "shell.exec" {
+		shell := params.Shell
+		if shell == "" {
+			shell = "sh"
+		}
+		delimiter := "EVERGREEN_SCRIPT_EOF"
+		for strings.Contains(params.Script, delimiter) {
+			delimiter += "_"
+		}
+		lines = append(lines, fmt.Sprintf("%s <<'%s'", shell, delimiter), params.Script, delimiter)
+	} else {
+		args := params.Args
+		binary := params.Binary
+		if params.Command != "" {
+			args = strings.Fields(params.Command)
+			if len(args) == 0 {
+				return nil, errors.New("command must not be empty")
+			}
+			binary, args = args[0], args[1:]
+		}
+		if binary == "" {
+			return nil, errors.New("binary must not be empty")
+		}
+		quoted := []string{shellQuote(binary)}
+		for _, arg := range args {
+			quoted = append(quoted, shellQuote(arg))
+		}
+		lines = append(lines, strings.Join(quoted, " "))
+	}
+
+	return append(lines, ")"), nil
+}
+
+// shellQuote quotes the string so that a POSIX shell treats it as one word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchSourceDirName(t *testing.T) {
+	assert.Equal(t, "source-project-abcdef", FetchSourceDirName("project", evergreen.RepotrackerVersionRequester, "abcdef0123", 0))
+	assert.Equal(t, "source-project", FetchSourceDirName("project", evergreen.RepotrackerVersionRequester, "", 0))
+	assert.Equal(t, "source-patch-5_project", FetchSourceDirName("project", evergreen.PatchVersionRequester, "abcdef0123", 5))
+}
+
+func TestMakeTaskEnvironment(t *testing.T) {
+	const projYml = `
+pre:
+  - command: shell.exec
+    params:
+      script: echo "setting up ${project}"
+functions:
+  compile:
+    - command: shell.exec
+      params:
+        working_dir: src
+        env:
+          GOPATH: ${workdir}/gopath
+        script: make ${target}
+tasks:
+  - name: compile
+    commands:
+      - func: compile
+        vars:
+          target: dist
+      - command: subprocess.exec
+        variants: ["bv"]
+        params:
+          binary: ./scripts/check.sh
+          args: ["${public_var}", "${private_var}"]
+      - command: subprocess.exec
+        variants: ["other_bv"]
+        params:
+          binary: ./scripts/skipped.sh
+      - command: s3.put
+        params:
+          local_file: dist.tgz
+buildvariants:
+  - name: bv
+    expansions:
+      bv_expansion: bv_value
+    run_on: [d1]
+    tasks:
+      - name: compile
+`
+
+	require.NoError(t, db.ClearCollections(task.Collection, VersionCollection, ParserProjectCollection, ProjectRefCollection, ProjectVarsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, VersionCollection, ParserProjectCollection, ProjectRefCollection, ProjectVarsCollection))
+	}()
+
+	require.NoError(t, (&ProjectRef{Id: "p1", Identifier: "my-project"}).Insert())
+	require.NoError(t, (&Version{Id: "v1", Identifier: "p1", Revision: "abcdef", Requester: evergreen.RepotrackerVersionRequester}).Insert())
+	pp := &ParserProject{}
+	require.NoError(t, util.UnmarshalYAMLWithFallback([]byte(projYml), &pp))
+	pp.Id = "v1"
+	require.NoError(t, pp.Insert())
+	_, err := (&ProjectVars{
+		Id:          "p1",
+		Vars:        map[string]string{"public_var": "public", "private_var": "secret"},
+		PrivateVars: map[string]bool{"private_var": true},
+	}).Upsert()
+	require.NoError(t, err)
+
+	tsk := &task.Task{
+		Id:           "t1",
+		DisplayName:  "compile",
+		Project:      "p1",
+		Version:      "v1",
+		BuildVariant: "bv",
+		Revision:     "abcdef",
+		Requester:    evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(t, tsk.Insert())
+
+	env, err := MakeTaskEnvironment(tsk, &host.Host{Id: "h1"}, "/data/mci")
+	require.NoError(t, err)
+
+	t.Run("ExpansionsExcludePrivateVarsAndCredentials", func(t *testing.T) {
+		assert.Equal(t, "t1", env.Expansions["task_id"])
+		assert.Equal(t, "my-project", env.Expansions["project"])
+		assert.Equal(t, "/data/mci/source-p1-abcdef", env.Expansions["workdir"])
+		assert.Equal(t, "bv_value", env.Expansions["bv_expansion"])
+		assert.Equal(t, "public", env.Expansions["public_var"])
+		assert.NotContains(t, env.Expansions, "private_var")
+		assert.NotContains(t, env.Expansions, evergreen.GlobalGitHubTokenExpansion)
+	})
+	t.Run("SetupScriptRunsPreCommands", func(t *testing.T) {
+		assert.Contains(t, env.SetupScript, "cd '/data/mci/source-p1-abcdef'")
+		assert.Contains(t, env.SetupScript, `echo "setting up my-project"`)
+	})
+	t.Run("TaskScriptRunsExecCommands", func(t *testing.T) {
+		assert.Contains(t, env.TaskScript, "# shell.exec (function 'compile')")
+		assert.Contains(t, env.TaskScript, "cd 'src'")
+		assert.Contains(t, env.TaskScript, "export GOPATH='/data/mci/source-p1-abcdef/gopath'")
+		assert.Contains(t, env.TaskScript, "make dist")
+		assert.Contains(t, env.TaskScript, "'./scripts/check.sh' 'public' ''")
+		assert.NotContains(t, env.TaskScript, "secret")
+	})
+	t.Run("TaskScriptSkipsOtherVariantsCommands", func(t *testing.T) {
+		assert.NotContains(t, env.TaskScript, "skipped.sh")
+	})
+	t.Run("TaskScriptListsUnsupportedCommands", func(t *testing.T) {
+		assert.Contains(t, env.TaskScript, "# s3.put")
+		assert.Contains(t, env.TaskScript, "# Cannot run this command outside of the agent: command 's3.put' is not supported")
+	})
+}
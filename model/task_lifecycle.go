@@ -673,8 +673,10 @@ func MarkEnd(settings *evergreen.Settings, t *task.Task, caller string, finishTi
 		}
 	}
 
-	// activate/deactivate other task if this is not a patch request's task
-	if !evergreen.IsPatchRequester(t.Requester) {
+	// activate/deactivate other task if this is not a patch request's task.
+	// Merge queue tasks are also skipped because earlier merge queue versions
+	// may already have been discarded.
+	if !evergreen.IsPatchRequester(t.Requester) && !evergreen.IsGithubMergeQueueRequester(t.Requester) {
 		if t.IsPartOfDisplay() {
 			_, err = t.GetDisplayTask()
 			if err != nil {
@@ -0,0 +1,347 @@
+package model
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Valid task log search stores.
+const (
+	TaskLogSearchStoreDB = "db"
+)
+
+const (
+	// DefaultTaskLogSearchLimit is the default number of matching tasks
+	// returned in a single page of task log search results.
+	DefaultTaskLogSearchLimit = 20
+	// MaxTaskLogSearchLimit is the maximum number of matching tasks returned
+	// in a single page of task log search results.
+	MaxTaskLogSearchLimit = 100
+	// DefaultTaskLogSearchMatchesPerTask is the default number of matching
+	// log lines returned for each task.
+	DefaultTaskLogSearchMatchesPerTask = 10
+	// MaxTaskLogSearchMatchesPerTask is the maximum number of matching log
+	// lines returned for each task.
+	MaxTaskLogSearchMatchesPerTask = 100
+	// MaxTaskLogSearchScannedTasks is the maximum number of tasks whose logs
+	// are scanned in a single page of task log search results, regardless of
+	// how many of them match.
+	MaxTaskLogSearchScannedTasks = 500
+	// MaxTaskLogSearchWindow is the widest time window a task log search can
+	// cover.
+	MaxTaskLogSearchWindow = 31 * 24 * time.Hour
+
+	maxTaskLogSearchExcerptLength = 512
+)
+
+// TaskLogSearchOptions represent the scope and pattern of a search over task
+// logs.
+type TaskLogSearchOptions struct {
+	// Project is the ID of the project whose tasks are searched.
+	Project string
+	// BuildVariant optionally restricts the search to tasks in a build
+	// variant.
+	BuildVariant string
+	// TaskName optionally restricts the search to tasks with a display name.
+	TaskName string
+	// Requesters optionally restricts the search to tasks with the given
+	// requesters.
+	Requesters []string
+	// StartTime and EndTime bound the finish times of the searched tasks.
+	StartTime time.Time
+	EndTime   time.Time
+	// Pattern is the substring or regular expression to search for.
+	Pattern string
+	// Regex indicates whether Pattern is a regular expression rather than a
+	// plain substring.
+	Regex bool
+	// StartAt is the task ID from which to resume a previous search.
+	StartAt string
+	// Limit is the maximum number of matching tasks to return.
+	Limit int
+	// MatchesPerTask is the maximum number of matching lines to return for
+	// each task.
+	MatchesPerTask int
+}
+
+// Validate checks that the search options are well-formed and bounded, and
+// fills in the defaults for any unset limits.
+func (opts *TaskLogSearchOptions) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(opts.Project == "", "must specify a project")
+	catcher.NewWhen(opts.Pattern == "", "must specify a search pattern")
+	catcher.NewWhen(utility.IsZeroTime(opts.StartTime), "must specify a start time")
+	if utility.IsZeroTime(opts.EndTime) {
+		opts.EndTime = time.Now()
+	}
+	catcher.NewWhen(opts.EndTime.Before(opts.StartTime), "end time cannot be before start time")
+	catcher.ErrorfWhen(opts.EndTime.Sub(opts.StartTime) > MaxTaskLogSearchWindow, "time window cannot be longer than %s", MaxTaskLogSearchWindow)
+	for _, requester := range opts.Requesters {
+		catcher.ErrorfWhen(!utility.StringSliceContains(evergreen.AllRequesterTypes, requester), "invalid requester '%s'", requester)
+	}
+	catcher.ErrorfWhen(opts.Limit < 0 || opts.Limit > MaxTaskLogSearchLimit, "limit must be between 0 and %d", MaxTaskLogSearchLimit)
+	catcher.ErrorfWhen(opts.MatchesPerTask < 0 || opts.MatchesPerTask > MaxTaskLogSearchMatchesPerTask, "matches per task must be between 0 and %d", MaxTaskLogSearchMatchesPerTask)
+	if opts.Regex {
+		_, err := regexp.Compile(opts.Pattern)
+		catcher.Wrap(err, "invalid regular expression")
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = DefaultTaskLogSearchLimit
+	}
+	if opts.MatchesPerTask == 0 {
+		opts.MatchesPerTask = DefaultTaskLogSearchMatchesPerTask
+	}
+
+	return catcher.Resolve()
+}
+
+// TaskLogSearchMatch is a single log line that matched a task log search.
+type TaskLogSearchMatch struct {
+	Timestamp time.Time
+	Severity  string
+	Type      string
+	Excerpt   string
+}
+
+// TaskLogSearchResult contains the matching lines from a single task
+// execution's logs.
+type TaskLogSearchResult struct {
+	TaskID       string
+	Execution    int
+	BuildVariant string
+	TaskName     string
+	Requester    string
+	FinishTime   time.Time
+	Matches      []TaskLogSearchMatch
+	// Truncated indicates that the task has more matching lines than were
+	// returned.
+	Truncated bool
+}
+
+// TaskLogSearchResults is a single page of task log search results.
+type TaskLogSearchResults struct {
+	Results []TaskLogSearchResult
+	// NextKey is the StartAt value for the next page of results. It is empty
+	// if there are no more tasks to search.
+	NextKey string
+}
+
+// TaskLogMatcher matches log lines against a task log search pattern.
+type TaskLogMatcher struct {
+	Pattern string
+	Regex   bool
+
+	re *regexp.Regexp
+}
+
+// NewTaskLogMatcher returns a matcher for the given substring or regular
+// expression pattern.
+func NewTaskLogMatcher(pattern string, isRegex bool) (*TaskLogMatcher, error) {
+	m := &TaskLogMatcher{Pattern: pattern, Regex: isRegex}
+	if isRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, "compiling regular expression")
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+// Match returns an excerpt of the line around the first match of the pattern
+// and whether the line matched.
+func (m *TaskLogMatcher) Match(line string) (string, bool) {
+	var start, end int
+	if m.re != nil {
+		loc := m.re.FindStringIndex(line)
+		if loc == nil {
+			return "", false
+		}
+		start, end = loc[0], loc[1]
+	} else {
+		start = strings.Index(line, m.Pattern)
+		if start < 0 {
+			return "", false
+		}
+		end = start + len(m.Pattern)
+	}
+
+	return excerptLogLine(line, start, end), true
+}
+
+// excerptLogLine truncates long lines to a window around the matched range.
+func excerptLogLine(line string, start, end int) string {
+	if len(line) <= maxTaskLogSearchExcerptLength {
+		return line
+	}
+
+	margin := (maxTaskLogSearchExcerptLength - (end - start)) / 2
+	if margin < 0 {
+		return line[start : start+maxTaskLogSearchExcerptLength]
+	}
+	from := start - margin
+	if from < 0 {
+		from = 0
+	}
+	to := from + maxTaskLogSearchExcerptLength
+	if to > len(line) {
+		to = len(line)
+		from = to - maxTaskLogSearchExcerptLength
+	}
+
+	excerpt := line[from:to]
+	if from > 0 {
+		excerpt = "..." + excerpt
+	}
+	if to < len(line) {
+		excerpt = excerpt + "..."
+	}
+	return excerpt
+}
+
+// TaskLogStore is the storage backend that a task log search reads from.
+type TaskLogStore interface {
+	// FindMatches returns up to limit matching lines from the logs of the
+	// given task execution, and whether there are more matching lines than
+	// were returned.
+	FindMatches(ctx context.Context, taskID string, execution int, m *TaskLogMatcher, limit int) ([]TaskLogSearchMatch, bool, error)
+}
+
+// GetTaskLogStore returns the task log store with the given name. If the name
+// is empty, it returns the store backed by the task log collection.
+func GetTaskLogStore(name string) (TaskLogStore, error) {
+	switch name {
+	case "", TaskLogSearchStoreDB:
+		return &dbTaskLogStore{}, nil
+	default:
+		return nil, errors.Errorf("unsupported task log store '%s'", name)
+	}
+}
+
+// dbTaskLogStore searches the task log collection.
+type dbTaskLogStore struct{}
+
+func (s *dbTaskLogStore) FindMatches(ctx context.Context, taskID string, execution int, m *TaskLogMatcher, limit int) ([]TaskLogSearchMatch, bool, error) {
+	session, db, err := getSessionAndDB()
+	if err != nil {
+		return nil, false, err
+	}
+	defer session.Close()
+
+	iter := db.C(TaskLogCollection).Find(bson.M{
+		TaskLogTaskIdKey:    taskID,
+		TaskLogExecutionKey: execution,
+	}).Sort(TaskLogTimestampKey).Iter()
+	defer iter.Close()
+
+	var matches []TaskLogSearchMatch
+	logObj := TaskLog{}
+	for iter.Next(&logObj) {
+		if err = ctx.Err(); err != nil {
+			return nil, false, err
+		}
+		for _, msg := range logObj.Messages {
+			excerpt, ok := m.Match(msg.Message)
+			if !ok {
+				continue
+			}
+			if len(matches) == limit {
+				return matches, true, nil
+			}
+			matches = append(matches, TaskLogSearchMatch{
+				Timestamp: msg.Timestamp,
+				Severity:  msg.Severity,
+				Type:      msg.Type,
+				Excerpt:   excerpt,
+			})
+		}
+	}
+
+	return matches, false, errors.Wrap(iter.Err(), "iterating over task logs")
+}
+
+// SearchTaskLogs searches the logs of the tasks in scope of the given options
+// using the given log store. Tasks are scanned in task ID order and at most
+// MaxTaskLogSearchScannedTasks are scanned per call, so a page may contain
+// fewer than the requested number of results even if more remain; callers
+// should continue from NextKey until it is empty.
+func SearchTaskLogs(ctx context.Context, store TaskLogStore, opts TaskLogSearchOptions) (*TaskLogSearchResults, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid task log search options")
+	}
+	m, err := NewTaskLogMatcher(opts.Pattern, opts.Regex)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{
+		task.ProjectKey:     opts.Project,
+		task.DisplayOnlyKey: bson.M{"$ne": true},
+		task.FinishTimeKey: bson.M{
+			"$gte": opts.StartTime,
+			"$lte": opts.EndTime,
+		},
+	}
+	if opts.BuildVariant != "" {
+		filter[task.BuildVariantKey] = opts.BuildVariant
+	}
+	if opts.TaskName != "" {
+		filter[task.DisplayNameKey] = opts.TaskName
+	}
+	if len(opts.Requesters) > 0 {
+		filter[task.RequesterKey] = bson.M{"$in": opts.Requesters}
+	}
+	if opts.StartAt != "" {
+		filter[task.IdKey] = bson.M{"$gte": opts.StartAt}
+	}
+
+	// Fetch one more task than will be scanned so that the next page can
+	// start from it.
+	tasks, err := task.FindAll(db.Query(filter).
+		WithFields(task.IdKey, task.ExecutionKey, task.BuildVariantKey, task.DisplayNameKey, task.RequesterKey, task.FinishTimeKey).
+		Sort([]string{task.IdKey}).
+		Limit(MaxTaskLogSearchScannedTasks + 1))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks to search")
+	}
+
+	res := &TaskLogSearchResults{}
+	for i, t := range tasks {
+		if i == MaxTaskLogSearchScannedTasks || len(res.Results) == opts.Limit {
+			res.NextKey = t.Id
+			break
+		}
+
+		matches, truncated, err := store.FindMatches(ctx, t.Id, t.Execution, m, opts.MatchesPerTask)
+		if err != nil {
+			return nil, errors.Wrapf(err, "searching logs for task '%s' execution %d", t.Id, t.Execution)
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		res.Results = append(res.Results, TaskLogSearchResult{
+			TaskID:       t.Id,
+			Execution:    t.Execution,
+			BuildVariant: t.BuildVariant,
+			TaskName:     t.DisplayName,
+			Requester:    t.Requester,
+			FinishTime:   t.FinishTime,
+			Matches:      matches,
+			Truncated:    truncated,
+		})
+	}
+
+	return res, nil
+}
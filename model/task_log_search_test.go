@@ -0,0 +1,220 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogMatcher(t *testing.T) {
+	t.Run("Substring", func(t *testing.T) {
+		m, err := NewTaskLogMatcher("Segmentation fault", false)
+		require.NoError(t, err)
+
+		excerpt, ok := m.Match("[mongod] Segmentation fault (core dumped)")
+		assert.True(t, ok)
+		assert.Equal(t, "[mongod] Segmentation fault (core dumped)", excerpt)
+
+		_, ok = m.Match("segmentation fault")
+		assert.False(t, ok)
+	})
+	t.Run("Regex", func(t *testing.T) {
+		m, err := NewTaskLogMatcher("(?i)segmentation fault", true)
+		require.NoError(t, err)
+
+		_, ok := m.Match("segmentation fault")
+		assert.True(t, ok)
+		_, ok = m.Match("segfault")
+		assert.False(t, ok)
+	})
+	t.Run("InvalidRegex", func(t *testing.T) {
+		_, err := NewTaskLogMatcher("(unclosed", true)
+		assert.Error(t, err)
+	})
+	t.Run("LongLinesAreExcerpted", func(t *testing.T) {
+		m, err := NewTaskLogMatcher("needle", false)
+		require.NoError(t, err)
+
+		line := strings.Repeat("a", 2000) + "needle" + strings.Repeat("b", 2000)
+		excerpt, ok := m.Match(line)
+		assert.True(t, ok)
+		assert.Contains(t, excerpt, "needle")
+		assert.True(t, strings.HasPrefix(excerpt, "..."))
+		assert.True(t, strings.HasSuffix(excerpt, "..."))
+		assert.Len(t, excerpt, maxTaskLogSearchExcerptLength+6)
+	})
+}
+
+func TestTaskLogSearchOptionsValidate(t *testing.T) {
+	now := time.Now()
+	for tName, tCase := range map[string]struct {
+		opts    TaskLogSearchOptions
+		isValid bool
+	}{
+		"SucceedsWithMinimalOptions": {
+			opts:    TaskLogSearchOptions{Project: "project", Pattern: "fault", StartTime: now.Add(-time.Hour)},
+			isValid: true,
+		},
+		"FailsWithoutProject": {
+			opts: TaskLogSearchOptions{Pattern: "fault", StartTime: now.Add(-time.Hour)},
+		},
+		"FailsWithoutPattern": {
+			opts: TaskLogSearchOptions{Project: "project", StartTime: now.Add(-time.Hour)},
+		},
+		"FailsWithoutStartTime": {
+			opts: TaskLogSearchOptions{Project: "project", Pattern: "fault"},
+		},
+		"FailsWithUnboundedWindow": {
+			opts: TaskLogSearchOptions{Project: "project", Pattern: "fault", StartTime: now.Add(-2 * MaxTaskLogSearchWindow)},
+		},
+		"FailsWithInvalidRequester": {
+			opts: TaskLogSearchOptions{Project: "project", Pattern: "fault", StartTime: now.Add(-time.Hour), Requesters: []string{"foo"}},
+		},
+		"FailsWithExcessiveLimit": {
+			opts: TaskLogSearchOptions{Project: "project", Pattern: "fault", StartTime: now.Add(-time.Hour), Limit: MaxTaskLogSearchLimit + 1},
+		},
+		"FailsWithInvalidRegex": {
+			opts: TaskLogSearchOptions{Project: "project", Pattern: "(", Regex: true, StartTime: now.Add(-time.Hour)},
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			err := tCase.opts.Validate()
+			if tCase.isValid {
+				assert.NoError(t, err)
+				assert.Equal(t, DefaultTaskLogSearchLimit, tCase.opts.Limit)
+				assert.Equal(t, DefaultTaskLogSearchMatchesPerTask, tCase.opts.MatchesPerTask)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSearchTaskLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.Clear(task.Collection))
+	require.NoError(t, cleanUpLogDB())
+	defer func() {
+		assert.NoError(t, db.Clear(task.Collection))
+		assert.NoError(t, cleanUpLogDB())
+	}()
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		tsk := task.Task{
+			Id:           fmt.Sprintf("t%d", i),
+			Project:      "project",
+			BuildVariant: "bv",
+			DisplayName:  "test",
+			Requester:    evergreen.RepotrackerVersionRequester,
+			Execution:    1,
+			FinishTime:   now.Add(-time.Duration(i) * time.Hour),
+		}
+		if i == 4 {
+			tsk.Requester = evergreen.PatchVersionRequester
+		}
+		require.NoError(t, tsk.Insert())
+
+		messages := []apimodels.LogMessage{{Message: "starting"}}
+		if i%2 == 0 {
+			messages = append(messages,
+				apimodels.LogMessage{Message: "Segmentation fault", Severity: apimodels.LogErrorPrefix},
+				apimodels.LogMessage{Message: "Segmentation fault again", Severity: apimodels.LogErrorPrefix},
+			)
+		}
+		taskLog := &TaskLog{
+			TaskId:       tsk.Id,
+			Execution:    tsk.Execution,
+			Timestamp:    tsk.FinishTime,
+			MessageCount: len(messages),
+			Messages:     messages,
+		}
+		require.NoError(t, taskLog.Insert())
+	}
+
+	store, err := GetTaskLogStore("")
+	require.NoError(t, err)
+
+	t.Run("ReturnsMatchingTasks", func(t *testing.T) {
+		res, err := SearchTaskLogs(ctx, store, TaskLogSearchOptions{
+			Project:   "project",
+			Pattern:   "Segmentation fault",
+			StartTime: now.Add(-24 * time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 3)
+		assert.Empty(t, res.NextKey)
+		for i, taskID := range []string{"t0", "t2", "t4"} {
+			assert.Equal(t, taskID, res.Results[i].TaskID)
+			assert.Equal(t, 1, res.Results[i].Execution)
+			assert.Len(t, res.Results[i].Matches, 2)
+			assert.False(t, res.Results[i].Truncated)
+		}
+	})
+	t.Run("FiltersByRequester", func(t *testing.T) {
+		res, err := SearchTaskLogs(ctx, store, TaskLogSearchOptions{
+			Project:    "project",
+			Pattern:    "Segmentation fault",
+			Requesters: []string{evergreen.PatchVersionRequester},
+			StartTime:  now.Add(-24 * time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, "t4", res.Results[0].TaskID)
+	})
+	t.Run("FiltersByTimeWindow", func(t *testing.T) {
+		res, err := SearchTaskLogs(ctx, store, TaskLogSearchOptions{
+			Project:   "project",
+			Pattern:   "Segmentation fault",
+			StartTime: now.Add(-90 * time.Minute),
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, "t0", res.Results[0].TaskID)
+	})
+	t.Run("TruncatesMatchesPerTask", func(t *testing.T) {
+		res, err := SearchTaskLogs(ctx, store, TaskLogSearchOptions{
+			Project:        "project",
+			Pattern:        "^Segmentation",
+			Regex:          true,
+			StartTime:      now.Add(-24 * time.Hour),
+			MatchesPerTask: 1,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Results, 3)
+		for _, result := range res.Results {
+			assert.Len(t, result.Matches, 1)
+			assert.True(t, result.Truncated)
+		}
+	})
+	t.Run("Paginates", func(t *testing.T) {
+		opts := TaskLogSearchOptions{
+			Project:   "project",
+			Pattern:   "Segmentation fault",
+			StartTime: now.Add(-24 * time.Hour),
+			Limit:     2,
+		}
+		res, err := SearchTaskLogs(ctx, store, opts)
+		require.NoError(t, err)
+		require.Len(t, res.Results, 2)
+		assert.Equal(t, "t3", res.NextKey)
+
+		opts.StartAt = res.NextKey
+		res, err = SearchTaskLogs(ctx, store, opts)
+		require.NoError(t, err)
+		require.Len(t, res.Results, 1)
+		assert.Equal(t, "t4", res.Results[0].TaskID)
+		assert.Empty(t, res.NextKey)
+	})
+}
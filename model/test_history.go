@@ -0,0 +1,297 @@
+package model
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// MaxTestHistoryVersions is the maximum number of mainline versions that
+	// test history can be fetched for at once.
+	MaxTestHistoryVersions = 100
+	// MaxTestHistoryBuildVariants is the maximum number of build variants
+	// that test history can be fetched for at once.
+	MaxTestHistoryBuildVariants = 20
+	// MaxTestHistoryResults is the maximum number of test results that test
+	// history can be built from at once.
+	MaxTestHistoryResults = 5000
+)
+
+// TestHistoryOptions specify which test history to fetch.
+type TestHistoryOptions struct {
+	// ProjectID is the ID or identifier of the project.
+	ProjectID string
+	// TaskName is the display name of the task that runs the tests.
+	TaskName string
+	// TestName is a regular expression that test names must match.
+	TestName string
+	// BuildVariants optionally limits the history to these build variants.
+	// At most MaxTestHistoryBuildVariants can be specified.
+	BuildVariants []string
+	// NumVersions is the number of mainline versions to fetch history for.
+	// Defaults to 20.
+	NumVersions int
+	// StartAt is the revision order number of the newest version to fetch
+	// history for. Defaults to the latest version.
+	StartAt int
+}
+
+// Validate checks that the options are valid and sets defaults.
+func (o *TestHistoryOptions) Validate() error {
+	if o.ProjectID == "" {
+		return errors.New("must specify a project")
+	}
+	if o.TaskName == "" {
+		return errors.New("must specify a task name")
+	}
+	if o.TestName == "" {
+		return errors.New("must specify a test name")
+	}
+	if _, err := regexp.Compile(o.TestName); err != nil {
+		return errors.Wrapf(err, "compiling test name regex '%s'", o.TestName)
+	}
+	if len(o.BuildVariants) > MaxTestHistoryBuildVariants {
+		return errors.Errorf("cannot specify more than %d build variants", MaxTestHistoryBuildVariants)
+	}
+	if o.NumVersions < 0 || o.NumVersions > MaxTestHistoryVersions {
+		return errors.Errorf("number of versions must be between 0 and %d", MaxTestHistoryVersions)
+	}
+	if o.NumVersions == 0 {
+		o.NumVersions = defaultVersionLimit
+	}
+	if o.StartAt < 0 {
+		return errors.New("start must be a non-negative integer")
+	}
+
+	return nil
+}
+
+// TestHistory is the history of a single test across recent mainline
+// versions and build variants.
+type TestHistory struct {
+	TestName string
+	// Results are the test's results, ordered from newest to oldest version
+	// and then by build variant.
+	Results []TestHistoryResult
+	// NumPassed and NumFailed are the number of results that passed and
+	// failed, respectively.
+	NumPassed int
+	NumFailed int
+	// FailureRate is the fraction of passed and failed results that failed.
+	// Skipped results are not counted.
+	FailureRate float64
+	// LastPassRevision and LastPassVersion are the newest version where the
+	// test passed.
+	LastPassRevision string
+	LastPassVersion  string
+	// FirstFailRevision and FirstFailVersion are the oldest version after the
+	// last pass where the test failed, which is where the test most likely
+	// started failing. They are empty if the test has not failed since it
+	// last passed.
+	FirstFailRevision string
+	FirstFailVersion  string
+}
+
+// TestHistoryResult is the result of a test in a single task.
+type TestHistoryResult struct {
+	Version             string
+	Revision            string
+	RevisionOrderNumber int
+	BuildVariant        string
+	TaskID              string
+	Execution           int
+	Status              string
+	Duration            time.Duration
+}
+
+// GetTestHistory returns the history of the tests run by a task over the
+// project's recent mainline versions, ordered by test name.
+func GetTestHistory(ctx context.Context, env evergreen.Environment, opts TestHistoryOptions) ([]TestHistory, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid test history options")
+	}
+
+	projectID, err := GetIdForProject(opts.ProjectID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting ID for project '%s'", opts.ProjectID)
+	}
+	newestOrder := opts.StartAt
+	if newestOrder == 0 {
+		repo, err := FindRepository(projectID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding repository for project '%s'", projectID)
+		}
+		if repo == nil {
+			return nil, errors.Errorf("repository for project '%s' not found", projectID)
+		}
+		newestOrder = repo.RevisionOrderNumber
+	}
+
+	tasks, err := findTestHistoryTasks(projectID, opts, newestOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test results are stored by the tasks that ran them, which for display
+	// tasks are their execution tasks.
+	tasksByResultsTaskID := map[string]*task.Task{}
+	var allTaskOpts []testresult.TaskOptions
+	for i := range tasks {
+		taskOpts, err := tasks[i].CreateTestResultsTaskOptions()
+		if err != nil {
+			return nil, errors.Wrapf(err, "creating test results task options for task '%s'", tasks[i].Id)
+		}
+		for _, o := range taskOpts {
+			tasksByResultsTaskID[o.TaskID] = &tasks[i]
+		}
+		allTaskOpts = append(allTaskOpts, taskOpts...)
+	}
+
+	// Fetch one more result than the maximum so that exceeding it can be
+	// detected without fetching every result.
+	var allResults []testresult.TestResult
+	for service, taskOpts := range testresult.GroupTasksByService(allTaskOpts) {
+		filterOpts := &testresult.FilterOptions{
+			TestName: opts.TestName,
+			Limit:    MaxTestHistoryResults + 1 - len(allResults),
+		}
+		taskResults, err := testresult.GetMergedTaskTestResults(ctx, env, taskOpts, filterOpts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting test results from service '%s'", service)
+		}
+		allResults = append(allResults, taskResults.Results...)
+		if len(allResults) > MaxTestHistoryResults {
+			return nil, errors.Errorf("test history has more than %d test results, narrow the test name, build variants, or number of versions", MaxTestHistoryResults)
+		}
+	}
+
+	return buildTestHistory(tasksByResultsTaskID, allResults)
+}
+
+// findTestHistoryTasks returns the finished tasks with the options' task name
+// in the project's mainline versions up to and including the newest order.
+func findTestHistoryTasks(projectID string, opts TestHistoryOptions, newestOrder int) ([]task.Task, error) {
+	query := bson.M{
+		task.ProjectKey:     projectID,
+		task.DisplayNameKey: opts.TaskName,
+		task.RequesterKey:   evergreen.RepotrackerVersionRequester,
+		task.StatusKey:      bson.M{"$in": evergreen.TaskCompletedStatuses},
+		task.RevisionOrderNumberKey: bson.M{
+			"$gt":  newestOrder - opts.NumVersions,
+			"$lte": newestOrder,
+		},
+	}
+	if len(opts.BuildVariants) > 0 {
+		query[task.BuildVariantKey] = bson.M{"$in": opts.BuildVariants}
+	}
+	q := db.Query(query).WithFields(
+		task.IdKey,
+		task.ExecutionKey,
+		task.VersionKey,
+		task.RevisionKey,
+		task.RevisionOrderNumberKey,
+		task.BuildVariantKey,
+		task.DisplayOnlyKey,
+		task.ExecutionTasksKey,
+		task.ResultsServiceKey,
+		task.HasCedarResultsKey,
+	)
+
+	tasks, err := task.FindAll(q)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks")
+	}
+
+	return tasks, nil
+}
+
+// buildTestHistory groups the test results by test name and summarizes each
+// test's history. The tasks are keyed by the task IDs that the test results
+// were stored under.
+func buildTestHistory(tasksByResultsTaskID map[string]*task.Task, results []testresult.TestResult) ([]TestHistory, error) {
+	historiesByName := map[string]*TestHistory{}
+	for _, result := range results {
+		t, ok := tasksByResultsTaskID[result.TaskID]
+		if !ok {
+			return nil, errors.Errorf("unexpected task '%s' in test results", result.TaskID)
+		}
+
+		name := result.GetDisplayTestName()
+		history, ok := historiesByName[name]
+		if !ok {
+			history = &TestHistory{TestName: name}
+			historiesByName[name] = history
+		}
+		history.Results = append(history.Results, TestHistoryResult{
+			Version:             t.Version,
+			Revision:            t.Revision,
+			RevisionOrderNumber: t.RevisionOrderNumber,
+			BuildVariant:        t.BuildVariant,
+			TaskID:              t.Id,
+			Execution:           t.Execution,
+			Status:              result.Status,
+			Duration:            result.Duration(),
+		})
+	}
+
+	histories := make([]TestHistory, 0, len(historiesByName))
+	for _, history := range historiesByName {
+		history.summarize()
+		histories = append(histories, *history)
+	}
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].TestName < histories[j].TestName
+	})
+
+	return histories, nil
+}
+
+// summarize sorts the test's results from newest to oldest and computes its
+// pass and failure statistics.
+func (h *TestHistory) summarize() {
+	sort.SliceStable(h.Results, func(i, j int) bool {
+		if h.Results[i].RevisionOrderNumber != h.Results[j].RevisionOrderNumber {
+			return h.Results[i].RevisionOrderNumber > h.Results[j].RevisionOrderNumber
+		}
+		return h.Results[i].BuildVariant < h.Results[j].BuildVariant
+	})
+
+	lastPassOrder := -1
+	for _, result := range h.Results {
+		switch result.Status {
+		case evergreen.TestSucceededStatus:
+			h.NumPassed++
+			if lastPassOrder < 0 {
+				lastPassOrder = result.RevisionOrderNumber
+				h.LastPassRevision = result.Revision
+				h.LastPassVersion = result.Version
+			}
+		case evergreen.TestFailedStatus:
+			h.NumFailed++
+		}
+	}
+	if total := h.NumPassed + h.NumFailed; total > 0 {
+		h.FailureRate = float64(h.NumFailed) / float64(total)
+	}
+
+	// Results are ordered newest first, so the last failure newer than the
+	// last pass is the first failure after it.
+	for _, result := range h.Results {
+		if result.RevisionOrderNumber <= lastPassOrder {
+			break
+		}
+		if result.Status == evergreen.TestFailedStatus {
+			h.FirstFailRevision = result.Revision
+			h.FirstFailVersion = result.Version
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestHistoryOptionsValidate(t *testing.T) {
+	t.Run("SetsDefaults", func(t *testing.T) {
+		opts := TestHistoryOptions{ProjectID: "project", TaskName: "unit", TestName: "test"}
+		require.NoError(t, opts.Validate())
+		assert.Equal(t, defaultVersionLimit, opts.NumVersions)
+	})
+	t.Run("RequiresTaskName", func(t *testing.T) {
+		opts := TestHistoryOptions{ProjectID: "project", TestName: "test"}
+		assert.Error(t, opts.Validate())
+	})
+	t.Run("RequiresTestName", func(t *testing.T) {
+		opts := TestHistoryOptions{ProjectID: "project", TaskName: "unit"}
+		assert.Error(t, opts.Validate())
+	})
+	t.Run("RejectsInvalidRegex", func(t *testing.T) {
+		opts := TestHistoryOptions{ProjectID: "project", TaskName: "unit", TestName: "test("}
+		assert.Error(t, opts.Validate())
+	})
+	t.Run("RejectsTooManyVersions", func(t *testing.T) {
+		opts := TestHistoryOptions{ProjectID: "project", TaskName: "unit", TestName: "test", NumVersions: MaxTestHistoryVersions + 1}
+		assert.Error(t, opts.Validate())
+	})
+	t.Run("RejectsTooManyBuildVariants", func(t *testing.T) {
+		opts := TestHistoryOptions{ProjectID: "project", TaskName: "unit", TestName: "test", BuildVariants: make([]string, MaxTestHistoryBuildVariants+1)}
+		assert.Error(t, opts.Validate())
+	})
+}
+
+func TestBuildTestHistory(t *testing.T) {
+	tasks := map[string]*task.Task{}
+	for _, tsk := range []task.Task{
+		{Id: "t1_linux", Version: "v1", Revision: "r1", RevisionOrderNumber: 1, BuildVariant: "linux"},
+		{Id: "t2_linux", Version: "v2", Revision: "r2", RevisionOrderNumber: 2, BuildVariant: "linux"},
+		{Id: "t2_windows", Version: "v2", Revision: "r2", RevisionOrderNumber: 2, BuildVariant: "windows"},
+		{Id: "t3_linux", Version: "v3", Revision: "r3", RevisionOrderNumber: 3, BuildVariant: "linux", Execution: 1},
+		{Id: "t4_windows", Version: "v4", Revision: "r4", RevisionOrderNumber: 4, BuildVariant: "windows"},
+	} {
+		tsk := tsk
+		tasks[tsk.Id] = &tsk
+	}
+	// Display tasks' results are stored under their execution tasks.
+	tasks["t4_windows_exec"] = tasks["t4_windows"]
+
+	start := time.Now()
+	result := func(taskID, testName, status string) testresult.TestResult {
+		return testresult.TestResult{
+			TaskID:        taskID,
+			TestName:      testName,
+			Status:        status,
+			TestStartTime: start,
+			TestEndTime:   start.Add(time.Second),
+		}
+	}
+
+	t.Run("SummarizesEachTest", func(t *testing.T) {
+		histories, err := buildTestHistory(tasks, []testresult.TestResult{
+			result("t4_windows_exec", "test_a", evergreen.TestFailedStatus),
+			result("t1_linux", "test_a", evergreen.TestSucceededStatus),
+			result("t3_linux", "test_a", evergreen.TestFailedStatus),
+			result("t2_windows", "test_a", evergreen.TestSucceededStatus),
+			result("t2_linux", "test_a", evergreen.TestSucceededStatus),
+			result("t1_linux", "test_b", evergreen.TestFailedStatus),
+			result("t2_linux", "test_b", evergreen.TestSucceededStatus),
+			result("t3_linux", "test_b", evergreen.TestSkippedStatus),
+		})
+		require.NoError(t, err)
+		require.Len(t, histories, 2)
+
+		testA := histories[0]
+		assert.Equal(t, "test_a", testA.TestName)
+		require.Len(t, testA.Results, 5)
+		assert.Equal(t, TestHistoryResult{
+			Version:             "v4",
+			Revision:            "r4",
+			RevisionOrderNumber: 4,
+			BuildVariant:        "windows",
+			TaskID:              "t4_windows",
+			Status:              evergreen.TestFailedStatus,
+			Duration:            time.Second,
+		}, testA.Results[0])
+		assert.Equal(t, 1, testA.Results[1].Execution)
+		assert.Equal(t, "t2_linux", testA.Results[2].TaskID)
+		assert.Equal(t, "t2_windows", testA.Results[3].TaskID)
+		assert.Equal(t, "t1_linux", testA.Results[4].TaskID)
+		assert.Equal(t, 3, testA.NumPassed)
+		assert.Equal(t, 2, testA.NumFailed)
+		assert.Equal(t, 0.4, testA.FailureRate)
+		assert.Equal(t, "r2", testA.LastPassRevision)
+		assert.Equal(t, "v2", testA.LastPassVersion)
+		assert.Equal(t, "r3", testA.FirstFailRevision)
+		assert.Equal(t, "v3", testA.FirstFailVersion)
+
+		testB := histories[1]
+		assert.Equal(t, "test_b", testB.TestName)
+		require.Len(t, testB.Results, 3)
+		assert.Equal(t, 1, testB.NumPassed)
+		assert.Equal(t, 1, testB.NumFailed)
+		assert.Equal(t, 0.5, testB.FailureRate)
+		assert.Equal(t, "r2", testB.LastPassRevision)
+		assert.Empty(t, testB.FirstFailRevision)
+		assert.Empty(t, testB.FirstFailVersion)
+	})
+	t.Run("NeverPassed", func(t *testing.T) {
+		histories, err := buildTestHistory(tasks, []testresult.TestResult{
+			result("t1_linux", "test_a", evergreen.TestFailedStatus),
+			result("t2_linux", "test_a", evergreen.TestFailedStatus),
+		})
+		require.NoError(t, err)
+		require.Len(t, histories, 1)
+		assert.Equal(t, 1.0, histories[0].FailureRate)
+		assert.Empty(t, histories[0].LastPassRevision)
+		assert.Equal(t, "r1", histories[0].FirstFailRevision)
+	})
+	t.Run("NoResults", func(t *testing.T) {
+		histories, err := buildTestHistory(tasks, nil)
+		require.NoError(t, err)
+		assert.Empty(t, histories)
+	})
+	t.Run("UnexpectedTask", func(t *testing.T) {
+		_, err := buildTestHistory(tasks, []testresult.TestResult{result("other", "test_a", evergreen.TestFailedStatus)})
+		assert.Error(t, err)
+	})
+}
@@ -112,6 +112,28 @@ func (s *cedarService) GetFailedTestSamples(ctx context.Context, taskOpts []Task
 	return samples, nil
 }
 
+// getTaskTestResults returns all of the test results of a single task
+// execution, and whether Cedar has any test results for it.
+func (s *cedarService) getTaskTestResults(ctx context.Context, taskOpts TaskOptions) (TaskTestResults, bool, error) {
+	data, status, err := testresults.Get(ctx, s.convertOpts([]TaskOptions{taskOpts}, nil))
+	if err != nil {
+		return TaskTestResults{}, false, errors.Wrap(err, "getting test results from Cedar")
+	}
+	if status == http.StatusNotFound {
+		return TaskTestResults{}, false, nil
+	}
+	if status != http.StatusOK {
+		return TaskTestResults{}, false, errors.Errorf("getting test results from Cedar returned HTTP status '%d'", status)
+	}
+
+	var testResults TaskTestResults
+	if err := json.Unmarshal(data, &testResults); err != nil {
+		return TaskTestResults{}, false, errors.Wrap(err, "unmarshalling test results from Cedar")
+	}
+
+	return testResults, true, nil
+}
+
 func (s *cedarService) convertOpts(taskOpts []TaskOptions, filterOpts *FilterOptions) testresults.GetOptions {
 	cedarTaskOpts := make([]testresults.TaskOptions, len(taskOpts))
 	for i, task := range taskOpts {
@@ -10,12 +10,28 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-const Collection = "testresults"
+const (
+	// Collection stores one document per test result in the local test
+	// results store.
+	Collection = "testresults"
+	// StatsCollection stores the stats of each task's test results in the
+	// local test results store.
+	StatsCollection = "testresults_stats"
+)
+
+// dbTestResult is a test result as stored in the local test results store.
+// It also stores the fields derived from the test result that the local
+// service filters and sorts by.
+type dbTestResult struct {
+	ID          interface{} `bson:"_id,omitempty"`
+	TestResult  `bson:",inline"`
+	DisplayName string `bson:"display_name"`
+	DurationNS  int64  `bson:"duration_ns"`
+}
 
-type dbTaskTestResults struct {
-	ID      dbTaskTestResultsID  `bson:"_id"`
-	Stats   TaskTestResultsStats `bson:"stats"`
-	Results []TestResult         `bson:"results"`
+type dbTaskTestResultsStats struct {
+	ID                   dbTaskTestResultsID `bson:"_id"`
+	TaskTestResultsStats `bson:",inline"`
 }
 
 type dbTaskTestResultsID struct {
@@ -24,34 +40,89 @@ type dbTaskTestResultsID struct {
 }
 
 var (
-	idKey      = bsonutil.MustHaveTag(dbTaskTestResults{}, "ID")
-	statsKey   = bsonutil.MustHaveTag(dbTaskTestResults{}, "Stats")
-	resultsKey = bsonutil.MustHaveTag(dbTaskTestResults{}, "Results")
+	idKey = bsonutil.MustHaveTag(dbTaskTestResultsStats{}, "ID")
 
-	taskIDKey    = bsonutil.MustHaveTag(dbTaskTestResultsID{}, "TaskID")
-	executionKey = bsonutil.MustHaveTag(dbTaskTestResultsID{}, "Execution")
+	taskIDKey        = bsonutil.MustHaveTag(TestResult{}, "TaskID")
+	executionKey     = bsonutil.MustHaveTag(TestResult{}, "Execution")
+	groupIDKey       = bsonutil.MustHaveTag(TestResult{}, "GroupID")
+	statusKey        = bsonutil.MustHaveTag(TestResult{}, "Status")
+	testStartTimeKey = bsonutil.MustHaveTag(TestResult{}, "TestStartTime")
+	displayNameKey   = bsonutil.MustHaveTag(dbTestResult{}, "DisplayName")
+	durationNSKey    = bsonutil.MustHaveTag(dbTestResult{}, "DurationNS")
 
 	totalCountKey  = bsonutil.MustHaveTag(TaskTestResultsStats{}, "TotalCount")
 	failedCountKey = bsonutil.MustHaveTag(TaskTestResultsStats{}, "FailedCount")
 )
 
+// Indexes on the local test results collection. Every query is scoped to a
+// set of task executions, so each index is prefixed by the task ID and
+// execution.
+var (
+	// TaskIndex supports fetching the results of tasks in the order they
+	// were inserted.
+	TaskIndex = bson.D{
+		{Name: taskIDKey, Value: 1},
+		{Name: executionKey, Value: 1},
+		{Name: "_id", Value: 1},
+	}
+	// TaskStatusIndex supports filtering the results of tasks by status,
+	// such as when sampling failed tests.
+	TaskStatusIndex = bson.D{
+		{Name: taskIDKey, Value: 1},
+		{Name: executionKey, Value: 1},
+		{Name: statusKey, Value: 1},
+	}
+	// TaskTestNameIndex supports looking up tests by name across tasks, such
+	// as when fetching base statuses or test history.
+	TaskTestNameIndex = bson.D{
+		{Name: taskIDKey, Value: 1},
+		{Name: executionKey, Value: 1},
+		{Name: displayNameKey, Value: 1},
+	}
+)
+
+func newDBTestResult(result TestResult) dbTestResult {
+	// Base statuses are always computed on read.
+	result.BaseStatus = ""
+	return dbTestResult{
+		TestResult:  result,
+		DisplayName: result.GetDisplayTestName(),
+		DurationNS:  int64(result.Duration()),
+	}
+}
+
 func (id dbTaskTestResultsID) appendResults(ctx context.Context, env evergreen.Environment, results []TestResult) error {
+	docs := make([]interface{}, len(results))
 	var failedCount int
-	for _, result := range results {
+	for i, result := range results {
+		docs[i] = newDBTestResult(result)
 		if result.Status == evergreen.TestFailedStatus {
 			failedCount++
 		}
 	}
 
+	// Results are inserted in order so that they are returned in the same
+	// order when no sort is specified.
+	if _, err := env.DB().Collection(Collection).InsertMany(ctx, docs, options.InsertMany().SetOrdered(true)); err != nil {
+		return errors.Wrap(err, "inserting DB test results")
+	}
+
 	update := bson.M{
-		"$push": bson.M{resultsKey: bson.M{"$each": results}},
 		"$inc": bson.M{
-			bsonutil.GetDottedKeyName(statsKey, totalCountKey):  len(results),
-			bsonutil.GetDottedKeyName(statsKey, failedCountKey): failedCount,
+			totalCountKey:  len(results),
+			failedCountKey: failedCount,
 		},
 	}
-	_, err := env.DB().Collection(Collection).UpdateOne(ctx, bson.M{idKey: id}, update, options.Update().SetUpsert(true))
-	return errors.Wrap(err, "appending DB test results")
+	_, err := env.DB().Collection(StatsCollection).UpdateOne(ctx, bson.M{idKey: id}, update, options.Update().SetUpsert(true))
+	return errors.Wrap(err, "updating DB test results stats")
+}
+
+func (id dbTaskTestResultsID) clear(ctx context.Context, env evergreen.Environment) error {
+	if _, err := env.DB().Collection(Collection).DeleteMany(ctx, bson.M{taskIDKey: id.TaskID, executionKey: id.Execution}); err != nil {
+		return errors.Wrap(err, "deleting DB test results")
+	}
+	_, err := env.DB().Collection(StatsCollection).DeleteOne(ctx, bson.M{idKey: id})
+	return errors.Wrap(err, "deleting DB test results stats")
 }
 
 func appendDBResults(ctx context.Context, env evergreen.Environment, results []TestResult) error {
@@ -72,3 +143,29 @@ func appendDBResults(ctx context.Context, env evergreen.Environment, results []T
 
 	return nil
 }
+
+// byTasks returns a query matching the local test results of the given
+// tasks.
+func byTasks(taskOpts []TaskOptions) bson.M {
+	tasks := make([]bson.M, len(taskOpts))
+	for i, task := range taskOpts {
+		tasks[i] = bson.M{
+			taskIDKey:    task.TaskID,
+			executionKey: task.Execution,
+		}
+	}
+
+	return bson.M{"$or": tasks}
+}
+
+// byTaskIDs returns a query matching the local test results stats of the
+// given tasks.
+func byTaskIDs(taskOpts []TaskOptions) bson.M {
+	ids := make([]dbTaskTestResultsID, len(taskOpts))
+	for i, task := range taskOpts {
+		ids[i].TaskID = task.TaskID
+		ids[i].Execution = task.Execution
+	}
+
+	return bson.M{idKey: bson.M{"$in": ids}}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db/mgo/bson"
 	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/anser/bsonutil"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -15,18 +16,50 @@ import (
 
 const maxSampleSize = 10
 
-// InsertLocal inserts the given test results into the local test results store
-// for testing and local development.
+// InsertLocal inserts the given test results into the local test results
+// store.
 func InsertLocal(ctx context.Context, env evergreen.Environment, results ...TestResult) error {
 	return errors.Wrap(appendDBResults(ctx, env, results), "inserting local test results")
 }
 
 // ClearLocal clears the local test results store.
 func ClearLocal(ctx context.Context, env evergreen.Environment) error {
-	return errors.Wrap(env.DB().Collection(Collection).Drop(ctx), "clearing the local test results store")
+	catcher := grip.NewBasicCatcher()
+	catcher.Wrap(env.DB().Collection(Collection).Drop(ctx), "dropping test results")
+	catcher.Wrap(env.DB().Collection(StatsCollection).Drop(ctx), "dropping test results stats")
+	return errors.Wrap(catcher.Resolve(), "clearing the local test results store")
 }
 
-// localService implements the local test results service.
+// CopyCedarToLocal copies the test results of the given task execution from
+// Cedar into the local test results store, replacing any test results the
+// task execution already has in the local store. It returns false if Cedar
+// has no test results for the task execution.
+func CopyCedarToLocal(ctx context.Context, env evergreen.Environment, taskID string, execution int) (bool, error) {
+	taskResults, found, err := newCedarService(env).getTaskTestResults(ctx, TaskOptions{TaskID: taskID, Execution: execution})
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	id := dbTaskTestResultsID{TaskID: taskID, Execution: execution}
+	if err = id.clear(ctx, env); err != nil {
+		return false, errors.Wrap(err, "clearing existing local test results")
+	}
+	if len(taskResults.Results) == 0 {
+		return true, nil
+	}
+	for i := range taskResults.Results {
+		taskResults.Results[i].TaskID = taskID
+		taskResults.Results[i].Execution = execution
+	}
+
+	return true, errors.Wrap(id.appendResults(ctx, env, taskResults.Results), "inserting local test results")
+}
+
+// localService implements the local test results service, which stores test
+// results in the application database.
 type localService struct {
 	env evergreen.Environment
 }
@@ -37,67 +70,69 @@ func newLocalService(env evergreen.Environment) *localService {
 }
 
 func (s *localService) GetMergedTaskTestResults(ctx context.Context, taskOpts []TaskOptions, filterOpts *FilterOptions) (TaskTestResults, error) {
-	allTaskResults, err := s.get(ctx, taskOpts)
-	if err != nil {
-		return TaskTestResults{}, errors.Wrap(err, "getting local test results")
+	if filterOpts != nil {
+		if err := s.validateFilterOptions(filterOpts); err != nil {
+			return TaskTestResults{}, errors.Wrap(err, "invalid filter options")
+		}
+	}
+	if len(taskOpts) == 0 {
+		return TaskTestResults{Stats: TaskTestResultsStats{FilteredCount: utility.ToIntPtr(0)}}, nil
 	}
 
-	var mergedTaskResults TaskTestResults
-	for _, taskResults := range allTaskResults {
-		mergedTaskResults.Stats.TotalCount += taskResults.Stats.TotalCount
-		mergedTaskResults.Stats.FailedCount += taskResults.Stats.FailedCount
-		mergedTaskResults.Results = append(mergedTaskResults.Results, taskResults.Results...)
+	stats, err := s.GetMergedTaskTestResultsStats(ctx, taskOpts)
+	if err != nil {
+		return TaskTestResults{}, err
 	}
 
-	filteredResults, filteredCount, err := s.filterAndSortTestResults(ctx, mergedTaskResults.Results, filterOpts)
+	results, filteredCount, err := s.filterAndSortTestResults(ctx, taskOpts, filterOpts)
 	if err != nil {
 		return TaskTestResults{}, err
 	}
-	mergedTaskResults.Results = filteredResults
-	mergedTaskResults.Stats.FilteredCount = &filteredCount
+	stats.FilteredCount = &filteredCount
 
-	return mergedTaskResults, nil
+	return TaskTestResults{Stats: stats, Results: results}, nil
 }
 
 func (s *localService) GetMergedTaskTestResultsStats(ctx context.Context, taskOpts []TaskOptions) (TaskTestResultsStats, error) {
-	allTaskResults, err := s.get(ctx, taskOpts, statsKey)
+	allStats, err := s.getStats(ctx, taskOpts)
 	if err != nil {
-		return TaskTestResultsStats{}, errors.Wrap(err, "getting local test results")
+		return TaskTestResultsStats{}, err
 	}
 
 	var mergedStats TaskTestResultsStats
-	for _, taskResults := range allTaskResults {
-		mergedStats.TotalCount += taskResults.Stats.TotalCount
-		mergedStats.FailedCount += taskResults.Stats.FailedCount
+	for _, stats := range allStats {
+		mergedStats.TotalCount += stats.TotalCount
+		mergedStats.FailedCount += stats.FailedCount
 	}
 
 	return mergedStats, nil
 }
 
 func (s *localService) GetMergedFailedTestSample(ctx context.Context, taskOpts []TaskOptions) ([]string, error) {
-	mergedTaskResults, err := s.GetMergedTaskTestResults(ctx, taskOpts, &FilterOptions{Statuses: []string{evergreen.TestFailedStatus}})
+	if len(taskOpts) == 0 {
+		return nil, nil
+	}
+
+	filter := byTasks(taskOpts)
+	filter[statusKey] = evergreen.TestFailedStatus
+	opts := options.Find().
+		SetSort(defaultSort()).
+		SetLimit(maxSampleSize).
+		SetProjection(bson.M{displayNameKey: 1})
+	results, err := s.find(ctx, filter, opts)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting failed test results")
 	}
 
-	sampleSize := maxSampleSize
-	if len(mergedTaskResults.Results) < sampleSize {
-		sampleSize = len(mergedTaskResults.Results)
-	}
 	var mergedSample []string
-	for i := 0; i < sampleSize; i++ {
-		mergedSample = append(mergedSample, mergedTaskResults.Results[i].GetDisplayTestName())
+	for _, result := range results {
+		mergedSample = append(mergedSample, result.DisplayName)
 	}
 
 	return mergedSample, nil
 }
 
 func (s *localService) GetFailedTestSamples(ctx context.Context, taskOpts []TaskOptions, regexFilters []string) ([]TaskTestResultsFailedSample, error) {
-	allTaskResults, err := s.get(ctx, taskOpts)
-	if err != nil {
-		return nil, errors.Wrap(err, "getting local test results")
-	}
-
 	regexes := make([]*regexp.Regexp, len(regexFilters))
 	for i, filter := range regexFilters {
 		testNameRegex, err := regexp.Compile(filter)
@@ -107,115 +142,253 @@ func (s *localService) GetFailedTestSamples(ctx context.Context, taskOpts []Task
 		regexes[i] = testNameRegex
 	}
 
-	samples := make([]TaskTestResultsFailedSample, len(allTaskResults))
-	for i, taskResults := range allTaskResults {
-		samples[i].TaskID = taskResults.Results[0].TaskID
-		samples[i].Execution = taskResults.Results[0].Execution
+	allStats, err := s.getStats(ctx, taskOpts)
+	if err != nil {
+		return nil, err
+	}
 
-		if taskResults.Stats.FailedCount == 0 {
+	var failedTasks []TaskOptions
+	samples := make([]TaskTestResultsFailedSample, len(allStats))
+	sampleIndexes := map[dbTaskTestResultsID]int{}
+	for i, stats := range allStats {
+		samples[i].TaskID = stats.ID.TaskID
+		samples[i].Execution = stats.ID.Execution
+		if stats.FailedCount == 0 {
 			continue
 		}
 
-		samples[i].TotalFailedNames = taskResults.Stats.FailedCount
-		for _, result := range taskResults.Results {
-			if result.Status == evergreen.TestFailedStatus {
-				match := true
-				for _, regex := range regexes {
-					if match = regex.MatchString(result.GetDisplayTestName()); match {
-						break
-					}
-				}
-				if match {
-					samples[i].MatchingFailedTestNames = append(samples[i].MatchingFailedTestNames, result.GetDisplayTestName())
-				}
+		samples[i].TotalFailedNames = stats.FailedCount
+		sampleIndexes[stats.ID] = i
+		failedTasks = append(failedTasks, TaskOptions{TaskID: stats.ID.TaskID, Execution: stats.ID.Execution})
+	}
+	if len(failedTasks) == 0 {
+		return samples, nil
+	}
+
+	filter := byTasks(failedTasks)
+	filter[statusKey] = evergreen.TestFailedStatus
+	opts := options.Find().
+		SetSort(defaultSort()).
+		SetProjection(bson.M{taskIDKey: 1, executionKey: 1, displayNameKey: 1})
+	results, err := s.find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting failed test results")
+	}
+
+	for _, result := range results {
+		match := true
+		for _, regex := range regexes {
+			if match = regex.MatchString(result.DisplayName); match {
+				break
 			}
 		}
+		if !match {
+			continue
+		}
+
+		i := sampleIndexes[dbTaskTestResultsID{TaskID: result.TaskID, Execution: result.Execution}]
+		samples[i].MatchingFailedTestNames = append(samples[i].MatchingFailedTestNames, result.DisplayName)
 	}
 
 	return samples, nil
 }
 
-// get fetches the unmerged test results for the given tasks from the local
-// store.
-func (s *localService) get(ctx context.Context, taskOpts []TaskOptions, fields ...string) ([]TaskTestResults, error) {
-	ids := make([]dbTaskTestResultsID, len(taskOpts))
-	for i, task := range taskOpts {
-		ids[i].TaskID = task.TaskID
-		ids[i].Execution = task.Execution
-	}
-
-	filter := bson.M{idKey: bson.M{"$in": ids}}
-	opts := options.Find()
-	opts.SetSort(bson.D{{Name: taskIDKey, Value: 1}, {Name: executionKey, Value: 1}})
-	if len(fields) > 0 {
-		projection := bson.M{}
-		for _, field := range fields {
-			projection[field] = 1
-		}
-		opts.SetProjection(projection)
+// getStats fetches the unmerged test results stats for the given tasks from
+// the local store.
+func (s *localService) getStats(ctx context.Context, taskOpts []TaskOptions) ([]dbTaskTestResultsStats, error) {
+	if len(taskOpts) == 0 {
+		return nil, nil
 	}
 
-	var allDBTaskResults []dbTaskTestResults
-	cur, err := s.env.DB().Collection(Collection).Find(ctx, filter, opts)
+	opts := options.Find().SetSort(bson.D{
+		{Name: bsonutil.GetDottedKeyName(idKey, taskIDKey), Value: 1},
+		{Name: bsonutil.GetDottedKeyName(idKey, executionKey), Value: 1},
+	})
+	cur, err := s.env.DB().Collection(StatsCollection).Find(ctx, byTaskIDs(taskOpts), opts)
 	if err != nil {
-		return nil, errors.Wrap(err, "finding DB test results")
+		return nil, errors.Wrap(err, "finding DB test results stats")
 	}
-	if err = cur.All(ctx, &allDBTaskResults); err != nil {
-		return nil, errors.Wrap(err, "reading DB test results")
+	var allStats []dbTaskTestResultsStats
+	if err = cur.All(ctx, &allStats); err != nil {
+		return nil, errors.Wrap(err, "reading DB test results stats")
 	}
 
-	allTaskResults := make([]TaskTestResults, len(allDBTaskResults))
-	for i, dbTaskResults := range allDBTaskResults {
-		allTaskResults[i].Stats = dbTaskResults.Stats
-		allTaskResults[i].Results = dbTaskResults.Results
+	return allStats, nil
+}
+
+// find fetches test results from the local store.
+func (s *localService) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]dbTestResult, error) {
+	cur, err := s.env.DB().Collection(Collection).Find(ctx, filter, opts.SetAllowDiskUse(true))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding DB test results")
+	}
+	var results []dbTestResult
+	if err = cur.All(ctx, &results); err != nil {
+		return nil, errors.Wrap(err, "reading DB test results")
 	}
 
-	return allTaskResults, nil
+	return results, nil
 }
 
-// filterAndSortTestResults takes a slice of test results and returns a
-// filtered, sorted, and paginated version of that slice.
-func (s *localService) filterAndSortTestResults(ctx context.Context, results []TestResult, opts *FilterOptions) ([]TestResult, int, error) {
+// filterAndSortTestResults returns the filtered, sorted, and paginated test
+// results of the given tasks along with the number of test results that
+// match the filter before pagination. Filtering, sorting, and pagination are
+// done by the database, except when sorting by base status, in which case
+// only the test names of the filtered results are sorted in memory.
+func (s *localService) filterAndSortTestResults(ctx context.Context, taskOpts []TaskOptions, opts *FilterOptions) ([]TestResult, int, error) {
 	if opts == nil {
+		dbResults, err := s.find(ctx, byTasks(taskOpts), options.Find().SetSort(defaultSort()))
+		if err != nil {
+			return nil, 0, err
+		}
+		results := toTestResults(dbResults)
 		return results, len(results), nil
 	}
-	if err := s.validateFilterOptions(opts); err != nil {
-		return nil, 0, errors.Wrap(err, "invalid filter options")
+
+	filter, err := s.filterQuery(taskOpts, opts)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "creating filter")
+	}
+	count, err := s.env.DB().Collection(Collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "counting filtered DB test results")
 	}
 
-	baseStatusMap := map[string]string{}
-	baseResults, err := s.GetMergedTaskTestResults(ctx, opts.BaseTasks, nil)
+	var dbResults []dbTestResult
+	if opts.SortBy == SortByBaseStatus && len(opts.BaseTasks) > 0 {
+		dbResults, err = s.findSortedByBaseStatus(ctx, filter, opts)
+	} else {
+		findOpts := options.Find().SetSort(sortQuery(opts))
+		if opts.Limit > 0 {
+			findOpts.SetSkip(int64(opts.Limit * opts.Page)).SetLimit(int64(opts.Limit))
+		}
+		dbResults, err = s.find(ctx, filter, findOpts)
+	}
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "getting base test results")
+		return nil, 0, err
 	}
-	for _, result := range baseResults.Results {
-		baseStatusMap[result.GetDisplayTestName()] = result.Status
+
+	results := toTestResults(dbResults)
+	if err = s.setBaseStatuses(ctx, results, opts.BaseTasks); err != nil {
+		return nil, 0, err
+	}
+
+	return results, int(count), nil
+}
+
+// findSortedByBaseStatus sorts and paginates the filtered test results by
+// the status of the test in the base tasks. The base statuses are not stored
+// with the test results, so only the IDs and names of the filtered results
+// are fetched to sort them before fetching the requested page.
+func (s *localService) findSortedByBaseStatus(ctx context.Context, filter bson.M, opts *FilterOptions) ([]dbTestResult, error) {
+	baseStatuses, err := s.getBaseStatuses(ctx, opts.BaseTasks, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	results, err = s.filterTestResults(results, opts)
+	var names []struct {
+		ID          interface{} `bson:"_id"`
+		DisplayName string      `bson:"display_name"`
+	}
+	findOpts := options.Find().
+		SetSort(defaultSort()).
+		SetProjection(bson.M{displayNameKey: 1}).
+		SetAllowDiskUse(true)
+	cur, err := s.env.DB().Collection(Collection).Find(ctx, filter, findOpts)
 	if err != nil {
-		return nil, 0, errors.Wrap(err, "filtering test results")
+		return nil, errors.Wrap(err, "finding DB test result names")
+	}
+	if err = cur.All(ctx, &names); err != nil {
+		return nil, errors.Wrap(err, "reading DB test result names")
 	}
-	s.sortTestResults(results, opts, baseStatusMap)
 
-	totalCount := len(results)
+	sort.SliceStable(names, func(i, j int) bool {
+		if opts.SortOrderDSC {
+			return baseStatuses[names[i].DisplayName] > baseStatuses[names[j].DisplayName]
+		}
+		return baseStatuses[names[i].DisplayName] < baseStatuses[names[j].DisplayName]
+	})
 	if opts.Limit > 0 {
 		offset := opts.Limit * opts.Page
 		end := offset + opts.Limit
-		if offset > totalCount {
-			offset = totalCount
+		if offset > len(names) {
+			offset = len(names)
 		}
-		if end > totalCount {
-			end = totalCount
+		if end > len(names) {
+			end = len(names)
 		}
-		results = results[offset:end]
+		names = names[offset:end]
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]interface{}, len(names))
+	for i, name := range names {
+		ids[i] = name.ID
+	}
+	dbResults, err := s.find(ctx, bson.M{"_id": bson.M{"$in": ids}}, options.Find())
+	if err != nil {
+		return nil, err
+	}
+
+	positions := map[interface{}]int{}
+	for i, id := range ids {
+		positions[id] = i
+	}
+	sortedResults := make([]dbTestResult, len(ids))
+	for _, result := range dbResults {
+		sortedResults[positions[result.ID]] = result
+	}
+
+	return sortedResults, nil
+}
+
+// getBaseStatuses returns a map of test names to their statuses in the base
+// tasks. If names are given, only the statuses of those tests are returned.
+func (s *localService) getBaseStatuses(ctx context.Context, baseTasks []TaskOptions, names []string) (map[string]string, error) {
+	baseStatuses := map[string]string{}
+	if len(baseTasks) == 0 {
+		return baseStatuses, nil
+	}
+
+	filter := byTasks(baseTasks)
+	if names != nil {
+		filter[displayNameKey] = bson.M{"$in": names}
+	}
+	opts := options.Find().
+		SetSort(defaultSort()).
+		SetProjection(bson.M{displayNameKey: 1, statusKey: 1})
+	baseResults, err := s.find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting base test results")
+	}
+	for _, result := range baseResults {
+		baseStatuses[result.DisplayName] = result.Status
 	}
 
+	return baseStatuses, nil
+}
+
+// setBaseStatuses sets the base status of each of the given test results.
+func (s *localService) setBaseStatuses(ctx context.Context, results []TestResult, baseTasks []TaskOptions) error {
+	if len(results) == 0 || len(baseTasks) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(results))
+	for i, result := range results {
+		names[i] = result.GetDisplayTestName()
+	}
+	baseStatuses, err := s.getBaseStatuses(ctx, baseTasks, names)
+	if err != nil {
+		return err
+	}
 	for i := range results {
-		results[i].BaseStatus = baseStatusMap[results[i].GetDisplayTestName()]
+		results[i].BaseStatus = baseStatuses[results[i].GetDisplayTestName()]
 	}
 
-	return results, totalCount, nil
+	return nil
 }
 
 func (s *localService) validateFilterOptions(opts *FilterOptions) error {
@@ -233,77 +406,72 @@ func (s *localService) validateFilterOptions(opts *FilterOptions) error {
 	return catcher.Resolve()
 }
 
-func (s *localService) filterTestResults(results []TestResult, opts *FilterOptions) ([]TestResult, error) {
-	if opts.TestName == "" && len(opts.Statuses) == 0 && opts.GroupID == "" {
-		return results, nil
-	}
-
-	var testNameRegex *regexp.Regexp
+// filterQuery returns the query matching the test results of the given tasks
+// that pass the filter options.
+func (s *localService) filterQuery(taskOpts []TaskOptions, opts *FilterOptions) (bson.M, error) {
+	filter := byTasks(taskOpts)
 	if opts.TestName != "" {
-		var err error
-		testNameRegex, err = regexp.Compile(opts.TestName)
-		if err != nil {
+		// Compile the regex first so that invalid regexes are reported
+		// consistently, regardless of the database's regex support.
+		if _, err := regexp.Compile(opts.TestName); err != nil {
 			return nil, errors.Wrap(err, "compiling test name filter regex")
 		}
+		filter[displayNameKey] = bson.M{"$regex": opts.TestName}
+	}
+	if len(opts.Statuses) > 0 {
+		filter[statusKey] = bson.M{"$in": opts.Statuses}
+	}
+	if opts.GroupID != "" {
+		filter[groupIDKey] = opts.GroupID
 	}
 
-	var filteredResults []TestResult
-	for _, result := range results {
-		if testNameRegex != nil && !testNameRegex.MatchString(result.GetDisplayTestName()) {
-			continue
-		}
-		if len(opts.Statuses) > 0 && !utility.StringSliceContains(opts.Statuses, result.Status) {
-			continue
-		}
-		if opts.GroupID != "" && opts.GroupID != result.GroupID {
-			continue
-		}
+	return filter, nil
+}
 
-		filteredResults = append(filteredResults, result)
+// defaultSort sorts test results by task and then in the order they were
+// inserted.
+func defaultSort() bson.D {
+	return bson.D{
+		{Name: taskIDKey, Value: 1},
+		{Name: executionKey, Value: 1},
+		{Name: "_id", Value: 1},
 	}
-
-	return filteredResults, nil
 }
 
-func (s *localService) sortTestResults(results []TestResult, opts *FilterOptions, baseStatusMap map[string]string) {
+// sortQuery returns the sort for the filter options. Ties are always broken
+// by the default sort so that pagination is stable.
+func sortQuery(opts *FilterOptions) bson.D {
+	direction := 1
+	if opts.SortOrderDSC {
+		direction = -1
+	}
+
+	var key string
 	switch opts.SortBy {
 	case SortByStart:
-		sort.SliceStable(results, func(i, j int) bool {
-			if opts.SortOrderDSC {
-				return results[i].TestStartTime.After(results[j].TestStartTime)
-			}
-			return results[i].TestStartTime.Before(results[j].TestStartTime)
-		})
+		key = testStartTimeKey
 	case SortByDuration:
-		sort.SliceStable(results, func(i, j int) bool {
-			if opts.SortOrderDSC {
-				return results[i].Duration() > results[j].Duration()
-			}
-			return results[i].Duration() < results[j].Duration()
-		})
+		key = durationNSKey
 	case SortByTestName:
-		sort.SliceStable(results, func(i, j int) bool {
-			if opts.SortOrderDSC {
-				return results[i].GetDisplayTestName() > results[j].GetDisplayTestName()
-			}
-			return results[i].GetDisplayTestName() < results[j].GetDisplayTestName()
-		})
+		key = displayNameKey
 	case SortByStatus:
-		sort.SliceStable(results, func(i, j int) bool {
-			if opts.SortOrderDSC {
-				return results[i].Status > results[j].Status
-			}
-			return results[i].Status < results[j].Status
-		})
-	case SortByBaseStatus:
-		if len(baseStatusMap) == 0 {
-			break
-		}
-		sort.SliceStable(results, func(i, j int) bool {
-			if opts.SortOrderDSC {
-				return baseStatusMap[results[i].GetDisplayTestName()] > baseStatusMap[results[j].GetDisplayTestName()]
-			}
-			return baseStatusMap[results[i].GetDisplayTestName()] < baseStatusMap[results[j].GetDisplayTestName()]
-		})
+		key = statusKey
+	default:
+		return defaultSort()
 	}
+
+	return append(bson.D{{Name: key, Value: direction}}, defaultSort()...)
+}
+
+func toTestResults(dbResults []dbTestResult) []TestResult {
+	if len(dbResults) == 0 {
+		return nil
+	}
+
+	results := make([]TestResult, len(dbResults))
+	for i, result := range dbResults {
+		results[i] = result.TestResult
+	}
+
+	return results
 }
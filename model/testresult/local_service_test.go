@@ -2,6 +2,8 @@ package testresult
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/evergreen-ci/utility"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestLocalService(t *testing.T) {
@@ -21,6 +24,12 @@ func TestLocalService(t *testing.T) {
 	defer func() {
 		assert.NoError(t, ClearLocal(ctx, env))
 	}()
+	_, err := env.DB().Collection(Collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: TaskIndex},
+		{Keys: TaskStatusIndex},
+		{Keys: TaskTestNameIndex},
+	})
+	require.NoError(t, err)
 
 	task0 := TaskOptions{TaskID: "task0", Execution: 0}
 	savedResults0 := make([]TestResult, 10)
@@ -226,41 +235,48 @@ func TestLocalFilterAndSortTestResults(t *testing.T) {
 	defer cancel()
 	env := testutil.NewEnvironment(ctx, t)
 	svc := newLocalService(env)
+	require.NoError(t, ClearLocal(ctx, env))
 	defer func() {
 		assert.NoError(t, ClearLocal(ctx, env))
 	}()
 
+	task := TaskOptions{TaskID: "task"}
 	getResults := func() []TestResult {
 		return []TestResult{
 			{
+				TaskID:        task.TaskID,
 				TestName:      "A test",
 				Status:        "Pass",
-				TestStartTime: time.Date(1996, time.August, 31, 12, 5, 10, 1, time.UTC),
+				TestStartTime: time.Date(1996, time.August, 31, 12, 5, 10, int(time.Millisecond), time.UTC),
 				TestEndTime:   time.Date(1996, time.August, 31, 12, 5, 12, 0, time.UTC),
 			},
 			{
+				TaskID:          task.TaskID,
 				TestName:        "B test",
 				DisplayTestName: "Display",
 				Status:          "Fail",
-				TestStartTime:   time.Date(1996, time.August, 31, 12, 5, 10, 3, time.UTC),
+				TestStartTime:   time.Date(1996, time.August, 31, 12, 5, 10, int(3*time.Millisecond), time.UTC),
 				TestEndTime:     time.Date(1996, time.August, 31, 12, 5, 16, 0, time.UTC),
 			},
 			{
+				TaskID:        task.TaskID,
 				TestName:      "C test",
 				Status:        "Fail",
-				TestStartTime: time.Date(1996, time.August, 31, 12, 5, 10, 2, time.UTC),
+				TestStartTime: time.Date(1996, time.August, 31, 12, 5, 10, int(2*time.Millisecond), time.UTC),
 				TestEndTime:   time.Date(1996, time.August, 31, 12, 5, 15, 0, time.UTC),
 			},
 			{
+				TaskID:        task.TaskID,
 				TestName:      "D test",
 				Status:        "Pass",
-				TestStartTime: time.Date(1996, time.August, 31, 12, 5, 10, 4, time.UTC),
+				TestStartTime: time.Date(1996, time.August, 31, 12, 5, 10, int(4*time.Millisecond), time.UTC),
 				TestEndTime:   time.Date(1996, time.August, 31, 12, 5, 11, 0, time.UTC),
 				GroupID:       "llama",
 			},
 		}
 	}
 	results := getResults()
+	require.NoError(t, InsertLocal(ctx, env, results...))
 
 	baseTaskID := "base_task"
 	baseResults := []TestResult{
@@ -524,16 +540,74 @@ func TestLocalFilterAndSortTestResults(t *testing.T) {
 		},
 	} {
 		t.Run(test.name, func(t *testing.T) {
-			actualResults, count, err := svc.filterAndSortTestResults(ctx, getResults(), test.opts)
+			taskResults, err := svc.GetMergedTaskTestResults(ctx, []TaskOptions{task}, test.opts)
 			if test.hasErr {
-				assert.Nil(t, actualResults)
-				assert.Zero(t, count)
+				assert.Zero(t, taskResults)
 				assert.Error(t, err)
 			} else {
 				require.NoError(t, err)
-				assert.Equal(t, test.expectedResults, actualResults)
-				assert.Equal(t, test.expectedCount, count)
+				assert.Equal(t, test.expectedResults, taskResults.Results)
+				assert.Equal(t, test.expectedCount, utility.FromIntPtr(taskResults.Stats.FilteredCount))
 			}
 		})
 	}
 }
+
+func TestCopyCedarToLocal(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+	svc := newLocalService(env)
+	require.NoError(t, ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, ClearLocal(ctx, env))
+	}()
+	srv, handler := newMockCedarServer(env)
+	defer srv.Close()
+
+	task := TaskOptions{TaskID: "task", Execution: 1}
+	cedarResults := TaskTestResults{Stats: TaskTestResultsStats{TotalCount: 3, FailedCount: 1}}
+	for i := 0; i < 3; i++ {
+		result := getTestResult()
+		result.TaskID = task.TaskID
+		result.Execution = task.Execution
+		if i == 1 {
+			result.Status = evergreen.TestFailedStatus
+		}
+		cedarResults.Results = append(cedarResults.Results, result)
+	}
+	data, err := json.Marshal(&cedarResults)
+	require.NoError(t, err)
+
+	t.Run("ReplacesLocalResults", func(t *testing.T) {
+		stale := getTestResult()
+		stale.TaskID = task.TaskID
+		stale.Execution = task.Execution
+		require.NoError(t, InsertLocal(ctx, env, stale))
+
+		handler.status = http.StatusOK
+		handler.data = data
+		found, err := CopyCedarToLocal(ctx, env, task.TaskID, task.Execution)
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		taskResults, err := svc.GetMergedTaskTestResults(ctx, []TaskOptions{task}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, cedarResults.Results, taskResults.Results)
+		assert.Equal(t, 3, taskResults.Stats.TotalCount)
+		assert.Equal(t, 1, taskResults.Stats.FailedCount)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		handler.status = http.StatusNotFound
+		handler.data = nil
+		found, err := CopyCedarToLocal(ctx, env, "DNE", 0)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+	t.Run("CedarError", func(t *testing.T) {
+		handler.status = http.StatusInternalServerError
+		handler.data = nil
+		_, err := CopyCedarToLocal(ctx, env, task.TaskID, task.Execution)
+		assert.Error(t, err)
+	})
+}
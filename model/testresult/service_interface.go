@@ -9,10 +9,13 @@ import (
 
 // Valid test results services.
 const (
-	TestResultsServiceLocal = "local"
-	TestResultsServiceCedar = "cedar"
+	TestResultsServiceLocal = evergreen.TestResultsServiceLocal
+	TestResultsServiceCedar = evergreen.TestResultsServiceCedar
 )
 
+// defaultService is the service of tasks that do not record a test results
+// service. These tasks predate recording the service and sent their test
+// results to Cedar.
 const defaultService = TestResultsServiceCedar
 
 // testResultsService is an interface for fetching test results data from an
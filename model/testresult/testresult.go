@@ -42,6 +42,10 @@ type TestResult struct {
 	LineNum         int       `json:"line_num" bson:"line_num"`
 	TestStartTime   time.Time `json:"test_start_time" bson:"test_start_time"`
 	TestEndTime     time.Time `json:"test_end_time" bson:"test_end_time"`
+	// FilePath and FileLine locate the test in the source code, if the test
+	// framework reports it.
+	FilePath string `json:"file_path,omitempty" bson:"file_path,omitempty"`
+	FileLine int    `json:"file_line,omitempty" bson:"file_line,omitempty"`
 }
 
 // GetLogTestName returns the name of the test in the logging backend. This is
@@ -194,7 +198,7 @@ func GetMergedTaskTestResultsStats(ctx context.Context, env evergreen.Environmen
 	}
 
 	var allStats TaskTestResultsStats
-	for service, tasks := range groupTasksByService(taskOpts) {
+	for service, tasks := range GroupTasksByService(taskOpts) {
 		svc, err := getServiceImpl(env, service)
 		if err != nil {
 			return TaskTestResultsStats{}, err
@@ -220,7 +224,7 @@ func GetMergedFailedTestSample(ctx context.Context, env evergreen.Environment, t
 	}
 
 	var allSamples []string
-	for service, tasks := range groupTasksByService(taskOpts) {
+	for service, tasks := range GroupTasksByService(taskOpts) {
 		svc, err := getServiceImpl(env, service)
 		if err != nil {
 			return nil, err
@@ -249,7 +253,7 @@ func GetFailedTestSamples(ctx context.Context, env evergreen.Environment, taskOp
 	}
 
 	var allSamples []TaskTestResultsFailedSample
-	for service, tasks := range groupTasksByService(taskOpts) {
+	for service, tasks := range GroupTasksByService(taskOpts) {
 		svc, err := getServiceImpl(env, service)
 		if err != nil {
 			return nil, err
@@ -266,7 +270,11 @@ func GetFailedTestSamples(ctx context.Context, env evergreen.Environment, taskOp
 	return allSamples, nil
 }
 
-func groupTasksByService(taskOpts []TaskOptions) map[string][]TaskOptions {
+// GroupTasksByService groups the task options by test results service, since
+// test results can only be fetched and merged within a single service. Tasks
+// with no service are grouped under the empty string, which resolves to the
+// default service.
+func GroupTasksByService(taskOpts []TaskOptions) map[string][]TaskOptions {
 	servicesToTasks := map[string][]TaskOptions{}
 	for _, task := range taskOpts {
 		servicesToTasks[task.ResultsService] = append(servicesToTasks[task.ResultsService], task)
@@ -0,0 +1,185 @@
+package user
+
+import (
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// SpawnHostAutoStopPolicy determines when Evergreen automatically stops the
+// user's spawn hosts.
+type SpawnHostAutoStopPolicy struct {
+	// IdleTimeout is how long one of the user's spawn hosts can be idle before
+	// it is stopped. If the spawn host's distro sets a shorter idle timeout,
+	// the distro's timeout is used instead.
+	IdleTimeout time.Duration `bson:"idle_timeout,omitempty" json:"idle_timeout,omitempty"`
+	// SleepSchedule is the schedule on which the user's spawn hosts are
+	// stopped and started again.
+	SleepSchedule SleepSchedule `bson:"sleep_schedule,omitempty" json:"sleep_schedule,omitempty"`
+}
+
+// Validate checks that the policy is valid.
+func (p *SpawnHostAutoStopPolicy) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.ErrorfWhen(p.IdleTimeout < 0, "idle timeout cannot be negative")
+	catcher.ErrorfWhen(p.IdleTimeout > 0 && p.IdleTimeout < evergreen.MinSpawnHostIdleTimeout, "idle timeout cannot be less than %s", evergreen.MinSpawnHostIdleTimeout)
+	catcher.Wrap(p.SleepSchedule.Validate(), "invalid sleep schedule")
+	return catcher.Resolve()
+}
+
+// SleepSchedule is a recurring weekly schedule during which spawn hosts are
+// stopped. Spawn hosts are stopped every day from StopTime until StartTime
+// the next time it occurs, as well as the entirety of each of the
+// WholeWeekdaysOff.
+type SleepSchedule struct {
+	// StopTime is the time of day in the format HH:MM at which spawn hosts
+	// are stopped.
+	StopTime string `bson:"stop_time,omitempty" json:"stop_time,omitempty"`
+	// StartTime is the time of day in the format HH:MM at which spawn hosts
+	// are started again.
+	StartTime string `bson:"start_time,omitempty" json:"start_time,omitempty"`
+	// WholeWeekdaysOff are the days of the week on which spawn hosts stay
+	// stopped all day.
+	WholeWeekdaysOff []time.Weekday `bson:"whole_weekdays_off,omitempty" json:"whole_weekdays_off,omitempty"`
+	// TimeZone is the name of the time zone in which the schedule is
+	// interpreted. If empty, the user's time zone is used.
+	TimeZone string `bson:"time_zone,omitempty" json:"time_zone,omitempty"`
+}
+
+// IsZero returns whether the sleep schedule is unset.
+func (s *SleepSchedule) IsZero() bool {
+	return s.StopTime == "" && s.StartTime == "" && len(s.WholeWeekdaysOff) == 0
+}
+
+// Validate checks that the sleep schedule is valid.
+func (s *SleepSchedule) Validate() error {
+	if s.IsZero() {
+		return nil
+	}
+
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen((s.StopTime == "") != (s.StartTime == ""), "stop time and start time must be set together")
+	if s.StopTime != "" && s.StartTime != "" {
+		stop, err := util.ParseTimeOfDay(s.StopTime)
+		catcher.Wrap(err, "invalid stop time")
+		start, err := util.ParseTimeOfDay(s.StartTime)
+		catcher.Wrap(err, "invalid start time")
+		catcher.NewWhen(!catcher.HasErrors() && stop == start, "stop time and start time cannot be the same")
+	}
+
+	daysOff := map[time.Weekday]bool{}
+	for _, d := range s.WholeWeekdaysOff {
+		catcher.ErrorfWhen(d < time.Sunday || d > time.Saturday, "invalid weekday %d", d)
+		daysOff[d] = true
+	}
+	catcher.NewWhen(len(daysOff) == 7, "cannot stop hosts every day of the week")
+
+	if s.TimeZone != "" {
+		_, err := time.LoadLocation(s.TimeZone)
+		catcher.Wrapf(err, "invalid time zone '%s'", s.TimeZone)
+	}
+
+	return catcher.Resolve()
+}
+
+// Location returns the time zone in which the schedule is interpreted. If the
+// schedule does not specify one, the user's time zone is used; if neither is
+// valid, it falls back to UTC.
+func (s *SleepSchedule) Location(userTimeZone string) *time.Location {
+	for _, tz := range []string{s.TimeZone, userTimeZone} {
+		if tz == "" {
+			continue
+		}
+		if loc, err := time.LoadLocation(tz); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// IsAsleep returns whether spawn hosts should be stopped at the given time
+// according to the schedule.
+func (s *SleepSchedule) IsAsleep(t time.Time, loc *time.Location) bool {
+	local := t.In(loc)
+	for _, d := range s.WholeWeekdaysOff {
+		if local.Weekday() == d {
+			return true
+		}
+	}
+
+	stop, err := util.ParseTimeOfDay(s.StopTime)
+	if err != nil {
+		return false
+	}
+	start, err := util.ParseTimeOfDay(s.StartTime)
+	if err != nil {
+		return false
+	}
+
+	now := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if stop < start {
+		return now >= stop && now < start
+	}
+	return now >= stop || now < start
+}
+
+// SleepStart returns when the period of sleep containing the given time
+// began. If the schedule is not asleep at the given time, it returns the zero
+// time.
+func (s *SleepSchedule) SleepStart(t time.Time, loc *time.Location) time.Time {
+	if !s.IsAsleep(t, loc) {
+		return time.Time{}
+	}
+
+	// A sleep period can only begin at midnight (the start of a whole day
+	// off) or at the daily stop time, so the latest such time at which the
+	// schedule falls asleep is the start of the current period. Since at
+	// least one day a week is not a whole day off, it must have begun within
+	// the last week.
+	candidateOffsets := []time.Duration{0}
+	if stop, err := util.ParseTimeOfDay(s.StopTime); err == nil {
+		candidateOffsets = append(candidateOffsets, stop)
+	}
+
+	local := t.In(loc)
+	var latest time.Time
+	for daysAgo := 0; daysAgo <= 7; daysAgo++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()-daysAgo, 0, 0, 0, 0, loc)
+		for _, offset := range candidateOffsets {
+			candidate := day.Add(offset)
+			if candidate.After(t) || !candidate.After(latest) {
+				continue
+			}
+			if s.IsAsleep(candidate, loc) && !s.IsAsleep(candidate.Add(-time.Minute), loc) {
+				latest = candidate
+			}
+		}
+	}
+
+	return latest
+}
+
+// ParseWeekday parses the full or three-letter abbreviated English name of a
+// day of the week.
+func ParseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return d, nil
+		}
+	}
+	return 0, errors.Errorf("invalid weekday '%s'", s)
+}
+
+// FormatWeekdays returns the lower-case names of the given weekdays.
+func FormatWeekdays(days []time.Weekday) []string {
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		names = append(names, strings.ToLower(d.String()))
+	}
+	return names
+}
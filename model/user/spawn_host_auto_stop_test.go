@@ -0,0 +1,139 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnHostAutoStopPolicyValidate(t *testing.T) {
+	t.Run("SucceedsForZeroPolicy", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{}
+		assert.NoError(t, p.Validate())
+	})
+	t.Run("SucceedsForValidPolicy", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{
+			IdleTimeout: 2 * time.Hour,
+			SleepSchedule: SleepSchedule{
+				StopTime:         "22:00",
+				StartTime:        "08:00",
+				WholeWeekdaysOff: []time.Weekday{time.Saturday, time.Sunday},
+				TimeZone:         "America/New_York",
+			},
+		}
+		assert.NoError(t, p.Validate())
+	})
+	t.Run("FailsForShortIdleTimeout", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{IdleTimeout: time.Minute}
+		assert.Error(t, p.Validate())
+	})
+	t.Run("FailsForNegativeIdleTimeout", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{IdleTimeout: -time.Hour}
+		assert.Error(t, p.Validate())
+	})
+	t.Run("FailsForStopTimeWithoutStartTime", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{SleepSchedule: SleepSchedule{StopTime: "22:00"}}
+		assert.Error(t, p.Validate())
+	})
+	t.Run("FailsForSameStopAndStartTime", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{SleepSchedule: SleepSchedule{StopTime: "22:00", StartTime: "22:00"}}
+		assert.Error(t, p.Validate())
+	})
+	t.Run("FailsForMalformedTime", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{SleepSchedule: SleepSchedule{StopTime: "10pm", StartTime: "08:00"}}
+		assert.Error(t, p.Validate())
+	})
+	t.Run("FailsForEveryDayOff", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{SleepSchedule: SleepSchedule{
+			WholeWeekdaysOff: []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+		}}
+		assert.Error(t, p.Validate())
+	})
+	t.Run("FailsForInvalidTimeZone", func(t *testing.T) {
+		p := SpawnHostAutoStopPolicy{SleepSchedule: SleepSchedule{StopTime: "22:00", StartTime: "08:00", TimeZone: "Nowhere/Special"}}
+		assert.Error(t, p.Validate())
+	})
+}
+
+func TestSleepSchedule(t *testing.T) {
+	// Monday, January 6, 2025.
+	monday := time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC)
+	at := func(daysAfterMonday int, hour, min int) time.Time {
+		return monday.AddDate(0, 0, daysAfterMonday).Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+
+	t.Run("OvernightSchedule", func(t *testing.T) {
+		s := SleepSchedule{StopTime: "22:00", StartTime: "08:00"}
+		assert.False(t, s.IsAsleep(at(0, 12, 0), time.UTC))
+		assert.False(t, s.IsAsleep(at(0, 21, 59), time.UTC))
+		assert.True(t, s.IsAsleep(at(0, 22, 0), time.UTC))
+		assert.True(t, s.IsAsleep(at(1, 3, 0), time.UTC))
+		assert.False(t, s.IsAsleep(at(1, 8, 0), time.UTC))
+
+		assert.True(t, s.SleepStart(at(0, 12, 0), time.UTC).IsZero())
+		assert.True(t, s.SleepStart(at(1, 3, 0), time.UTC).Equal(at(0, 22, 0)))
+	})
+	t.Run("DaytimeSchedule", func(t *testing.T) {
+		s := SleepSchedule{StopTime: "09:00", StartTime: "17:00"}
+		assert.False(t, s.IsAsleep(at(0, 8, 59), time.UTC))
+		assert.True(t, s.IsAsleep(at(0, 12, 0), time.UTC))
+		assert.False(t, s.IsAsleep(at(0, 17, 0), time.UTC))
+		assert.True(t, s.SleepStart(at(0, 12, 0), time.UTC).Equal(at(0, 9, 0)))
+	})
+	t.Run("WeekendOff", func(t *testing.T) {
+		s := SleepSchedule{
+			StopTime:         "22:00",
+			StartTime:        "08:00",
+			WholeWeekdaysOff: []time.Weekday{time.Saturday, time.Sunday},
+		}
+		assert.True(t, s.IsAsleep(at(5, 12, 0), time.UTC))
+		assert.True(t, s.IsAsleep(at(6, 12, 0), time.UTC))
+		assert.True(t, s.IsAsleep(at(7, 7, 0), time.UTC))
+		assert.False(t, s.IsAsleep(at(7, 8, 0), time.UTC))
+
+		// The sleep that begins Friday night lasts through the weekend.
+		fridayNight := at(4, 22, 0)
+		assert.True(t, s.SleepStart(at(5, 12, 0), time.UTC).Equal(fridayNight))
+		assert.True(t, s.SleepStart(at(6, 23, 0), time.UTC).Equal(fridayNight))
+		assert.True(t, s.SleepStart(at(7, 7, 0), time.UTC).Equal(fridayNight))
+	})
+	t.Run("WholeDaysOffOnly", func(t *testing.T) {
+		s := SleepSchedule{WholeWeekdaysOff: []time.Weekday{time.Wednesday}}
+		assert.False(t, s.IsAsleep(at(1, 23, 59), time.UTC))
+		assert.True(t, s.IsAsleep(at(2, 0, 0), time.UTC))
+		assert.True(t, s.SleepStart(at(2, 15, 0), time.UTC).Equal(at(2, 0, 0)))
+		assert.False(t, s.IsAsleep(at(3, 0, 0), time.UTC))
+	})
+	t.Run("UsesLocation", func(t *testing.T) {
+		loc, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		s := SleepSchedule{StopTime: "22:00", StartTime: "08:00"}
+		// 22:00 UTC is 17:00 in New York.
+		assert.True(t, s.IsAsleep(at(0, 22, 0), time.UTC))
+		assert.False(t, s.IsAsleep(at(0, 22, 0), loc))
+	})
+	t.Run("LocationFallsBackToUserTimeZone", func(t *testing.T) {
+		s := SleepSchedule{}
+		assert.Equal(t, "America/New_York", s.Location("America/New_York").String())
+		assert.Equal(t, time.UTC, s.Location("invalid"))
+
+		s.TimeZone = "Europe/London"
+		assert.Equal(t, "Europe/London", s.Location("America/New_York").String())
+	})
+}
+
+func TestParseWeekday(t *testing.T) {
+	for name, expected := range map[string]time.Weekday{
+		"monday": time.Monday,
+		"Tue":    time.Tuesday,
+		"SUNDAY": time.Sunday,
+	} {
+		d, err := ParseWeekday(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, d, name)
+	}
+	_, err := ParseWeekday("someday")
+	assert.Error(t, err)
+}
@@ -65,6 +65,9 @@ type UserSettings struct {
 	Notifications    NotificationPreferences `bson:"notifications,omitempty" json:"notifications,omitempty"`
 	UseSpruceOptions UseSpruceOptions        `json:"use_spruce_options" bson:"use_spruce_options"`
 	DateFormat       string                  `json:"date_format" bson:"date_format"`
+	// SpawnHostAutoStop determines when the user's spawn hosts are
+	// automatically stopped.
+	SpawnHostAutoStop SpawnHostAutoStopPolicy `json:"spawn_host_auto_stop" bson:"spawn_host_auto_stop,omitempty"`
 }
 
 type UseSpruceOptions struct {
@@ -302,6 +302,7 @@ type VersionMetadata struct {
 	PeriodicBuildID     string
 	RemotePath          string
 	GitTag              GitTag
+	GithubMergeGroup    GithubMergeGroup
 }
 
 var (
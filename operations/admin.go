@@ -29,6 +29,7 @@ func Admin() cli.Command {
 			viewSettings(),
 			updateSettings(),
 			listEvents(),
+			exportEvents(),
 			revert(),
 			fetchAllProjectConfigs(),
 			amboyCmd(),
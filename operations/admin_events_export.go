@@ -0,0 +1,130 @@
+package operations
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func exportEvents() cli.Command {
+	const (
+		startFlagName        = "start"
+		endFlagName          = "end"
+		resourceTypeFlagName = "resource-type"
+		resourceIDFlagName   = "resource-id"
+		outputFlagName       = "output"
+	)
+
+	return cli.Command{
+		Name:   "export-events",
+		Before: setPlainLogger,
+		Usage:  "export events as newline-delimited JSON",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  startFlagName,
+				Usage: "export events at or after this RFC-3339 time (default: the earliest event)",
+			},
+			cli.StringFlag{
+				Name:  endFlagName,
+				Usage: "export events before this RFC-3339 time (default: now)",
+			},
+			cli.StringSliceFlag{
+				Name:  resourceTypeFlagName,
+				Usage: "only export events for this resource type, e.g. ADMIN or HOST (can be specified multiple times)",
+			},
+			cli.StringSliceFlag{
+				Name:  resourceIDFlagName,
+				Usage: "only export events for this resource ID (can be specified multiple times)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(outputFlagName, "o"),
+				Usage: "the file to write the events to, gzipped if it ends in '.gz' (default: stdout)",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			output := c.String(outputFlagName)
+
+			opts := event.ExportOptions{
+				ResourceTypes: c.StringSlice(resourceTypeFlagName),
+				ResourceIDs:   c.StringSlice(resourceIDFlagName),
+				EndTime:       time.Now(),
+				Limit:         event.MaxExportLimit,
+			}
+			var err error
+			if start := c.String(startFlagName); start != "" {
+				if opts.StartTime, err = time.Parse(time.RFC3339, start); err != nil {
+					return errors.Wrap(err, "parsing start time as RFC-3339")
+				}
+			}
+			if end := c.String(endFlagName); end != "" {
+				if opts.EndTime, err = time.Parse(time.RFC3339, end); err != nil {
+					return errors.Wrap(err, "parsing end time as RFC-3339")
+				}
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, output != "")
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return errors.Wrapf(err, "creating output file '%s'", output)
+				}
+				defer f.Close()
+				w = f
+				if strings.HasSuffix(output, ".gz") {
+					gz := gzip.NewWriter(f)
+					defer gz.Close()
+					w = gz
+				}
+			}
+
+			enc := json.NewEncoder(w)
+			var total int
+			for {
+				resp, err := client.ExportEvents(ctx, opts)
+				if err != nil {
+					return errors.Wrap(err, "exporting events")
+				}
+				for _, e := range resp.Events {
+					if err = enc.Encode(e); err != nil {
+						return errors.Wrap(err, "writing event")
+					}
+				}
+				total += len(resp.Events)
+				if resp.NextStart == nil {
+					break
+				}
+				opts.StartTime = utility.FromTimePtr(resp.NextStart)
+			}
+
+			if output != "" {
+				grip.Infof("Exported %d events to '%s'.", total, output)
+			}
+
+			return nil
+		},
+	}
+}
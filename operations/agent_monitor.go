@@ -58,6 +58,12 @@ type monitor struct {
 	logPrefix       string
 	jasperPort      int
 	port            int
+	// spawnHostUser is the user of the spawn host whose activity the
+	// monitor reports. If set, the monitor reports the spawn host's activity
+	// instead of running the agent.
+	spawnHostUser string
+	hostID        string
+	hostSecret    string
 
 	// Args to be forwarded to the agent
 	agentArgs []string
@@ -91,6 +97,7 @@ func agentMonitor() cli.Command {
 		logPrefixFlagName       = "log_prefix"
 		jasperPortFlagName      = "jasper_port"
 		portFlagName            = "port"
+		spawnHostUserFlagName   = "spawn_host_user"
 	)
 
 	const (
@@ -134,6 +141,10 @@ func agentMonitor() cli.Command {
 				Value: defaultMonitorPort,
 				Usage: "the port that used by the monitor",
 			},
+			cli.StringFlag{
+				Name:  spawnHostUserFlagName,
+				Usage: "report the activity of the given `USER` on the spawn host instead of running the agent",
+			},
 		},
 		Before: mergeBeforeFuncs(
 			requireStringFlag(clientPathFlagName),
@@ -161,6 +172,9 @@ func agentMonitor() cli.Command {
 				jasperPort:      c.Int(jasperPortFlagName),
 				port:            c.Int(portFlagName),
 				logPrefix:       c.String(logPrefixFlagName),
+				spawnHostUser:   c.String(spawnHostUserFlagName),
+				hostID:          c.Parent().String(agentHostIDFlagName),
+				hostSecret:      c.Parent().String(agentHostSecretFlagName),
 			}
 
 			m.agentArgs, err = getAgentArgs(c, os.Args)
@@ -181,6 +195,11 @@ func agentMonitor() cli.Command {
 			defer cancel()
 			go handleMonitorSignals(ctx, cancel)
 
+			if m.spawnHostUser != "" {
+				m.reportSpawnHostActivity(ctx)
+				return nil
+			}
+
 			if err := m.setupJasperConnection(ctx, agentMonitorDefaultRetryOptions()); err != nil {
 				return errors.Wrap(err, "connecting to RPC service")
 			}
@@ -0,0 +1,88 @@
+package operations
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	restmodel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// reportSpawnHostActivity periodically reports how the spawn host is being
+// used so that Evergreen can stop it once it has been idle for too long.
+func (m *monitor) reportSpawnHostActivity(ctx context.Context) {
+	ticker := time.NewTicker(evergreen.SpawnHostActivityReportInterval)
+	defer ticker.Stop()
+
+	lastReport := time.Now()
+	for {
+		now := time.Now()
+		activity, err := m.collectSpawnHostActivity(ctx, lastReport)
+		if err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "could not collect spawn host activity",
+				"host_id": m.hostID,
+			}))
+		} else if err = m.comm.SendSpawnHostActivity(ctx, m.hostID, m.hostSecret, *activity); err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "could not report spawn host activity",
+				"host_id": m.hostID,
+			}))
+		} else {
+			lastReport = now
+		}
+
+		select {
+		case <-ctx.Done():
+			grip.Info("context cancelled, no longer reporting spawn host activity")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// collectSpawnHostActivity gets the spawn host's current activity. Process
+// starts are counted since the given time.
+func (m *monitor) collectSpawnHostActivity(ctx context.Context, since time.Time) (*restmodel.APISpawnHostActivity, error) {
+	users, err := host.UsersWithContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting logged in users")
+	}
+	avg, err := load.AvgWithContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting load average")
+	}
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting processes")
+	}
+
+	var processStarts int
+	for _, proc := range procs {
+		if int(proc.Pid) == os.Getpid() {
+			continue
+		}
+		createTime, err := proc.CreateTimeWithContext(ctx)
+		if err != nil || time.UnixMilli(createTime).Before(since) {
+			continue
+		}
+		username, err := proc.UsernameWithContext(ctx)
+		if err != nil || username != m.spawnHostUser {
+			continue
+		}
+		processStarts++
+	}
+
+	return &restmodel.APISpawnHostActivity{
+		SSHSessions:   len(users),
+		LoadAverage:   avg.Load1,
+		ProcessStarts: processStarts,
+	}, nil
+}
@@ -0,0 +1,119 @@
+package operations
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/evergreen-ci/evergreen/validator"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+const (
+	writeFlagName = "write"
+	checkFlagName = "check"
+)
+
+func Config() cli.Command {
+	return cli.Command{
+		Name:  "config",
+		Usage: "rewrite project configuration files",
+		Subcommands: []cli.Command{
+			configFormat(),
+			configUpgrade(),
+		},
+	}
+}
+
+func configFormat() cli.Command {
+	return cli.Command{
+		Name:  "fmt",
+		Usage: "rewrite a project configuration file with a canonical key order and indentation",
+		Flags: addPathFlag(
+			cli.BoolFlag{
+				Name:  joinFlagNames(writeFlagName, "w"),
+				Usage: "write the result to the file instead of printing it",
+			},
+			cli.BoolFlag{
+				Name:  checkFlagName,
+				Usage: "only check whether the file is formatted, failing if it is not",
+			},
+		),
+		Before: mergeBeforeFuncs(requirePathFlag, mutuallyExclusiveArgs(false, writeFlagName, checkFlagName)),
+		Action: func(c *cli.Context) error {
+			path := c.String(pathFlagName)
+
+			original, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrap(err, "reading project config")
+			}
+			formatted, err := validator.FormatProjectConfig(original)
+			if err != nil {
+				return errors.Wrapf(err, "formatting project config '%s'", path)
+			}
+
+			if c.Bool(checkFlagName) {
+				if !bytes.Equal(original, formatted) {
+					return errors.Errorf("project config '%s' is not formatted", path)
+				}
+				return nil
+			}
+
+			return writeProjectConfig(path, original, formatted, c.Bool(writeFlagName))
+		},
+	}
+}
+
+func configUpgrade() cli.Command {
+	return cli.Command{
+		Name:  "upgrade",
+		Usage: "rewrite deprecated constructs in a project configuration file",
+		Flags: addPathFlag(
+			cli.BoolFlag{
+				Name:  joinFlagNames(writeFlagName, "w"),
+				Usage: "write the result to the file instead of printing it",
+			},
+		),
+		Before: requirePathFlag,
+		Action: func(c *cli.Context) error {
+			path := c.String(pathFlagName)
+
+			original, err := os.ReadFile(path)
+			if err != nil {
+				return errors.Wrap(err, "reading project config")
+			}
+			upgraded, changes, err := validator.UpgradeProjectConfig(original)
+			if err != nil {
+				return errors.Wrapf(err, "upgrading project config '%s'", path)
+			}
+
+			// Changes are reported on stderr so that the upgraded config can
+			// be redirected.
+			for _, change := range changes {
+				fmt.Fprintf(os.Stderr, "%s:%s\n", path, change)
+			}
+			if len(changes) == 0 {
+				fmt.Fprintf(os.Stderr, "%s: no deprecated constructs found\n", path)
+			}
+
+			return writeProjectConfig(path, original, upgraded, c.Bool(writeFlagName))
+		},
+	}
+}
+
+func writeProjectConfig(path string, original, rewritten []byte, write bool) error {
+	if !write {
+		_, err := os.Stdout.Write(rewritten)
+		return errors.Wrap(err, "writing project config to stdout")
+	}
+	if bytes.Equal(original, rewritten) {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "getting file info for '%s'", path)
+	}
+	return errors.Wrapf(os.WriteFile(path, rewritten, info.Mode()), "writing project config '%s'", path)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v3"
@@ -15,6 +17,7 @@ func Evaluate() cli.Command {
 	const (
 		taskFlagName     = "tasks"
 		variantsFlagName = "variants"
+		matrixFlagName   = "matrix"
 	)
 
 	return cli.Command{
@@ -29,12 +32,26 @@ func Evaluate() cli.Command {
 				Name:  variantsFlagName,
 				Usage: "only show variant definitions",
 			},
+			cli.BoolFlag{
+				Name:  matrixFlagName,
+				Usage: "show every matrix combination and whether it was kept or dropped",
+			},
+			cli.StringSliceFlag{
+				Name:  parameterFlagName,
+				Usage: "override a parameter's default as a KEY=VALUE pair",
+			},
 		),
-		Before: mergeBeforeFuncs(requirePathFlag),
+		Before: mergeBeforeFuncs(requirePathFlag, mutuallyExclusiveArgs(false, matrixFlagName, taskFlagName),
+			mutuallyExclusiveArgs(false, matrixFlagName, variantsFlagName)),
 		Action: func(c *cli.Context) error {
 			path := c.String(pathFlagName)
 			showTasks := c.Bool(taskFlagName)
 			showVariants := c.Bool(variantsFlagName)
+			showMatrix := c.Bool(matrixFlagName)
+			params, err := getParametersFromInput(c.StringSlice(parameterFlagName))
+			if err != nil {
+				return err
+			}
 
 			configBytes, err := os.ReadFile(path)
 			if err != nil {
@@ -46,7 +63,22 @@ func Evaluate() cli.Command {
 			opts := &model.GetProjectOpts{
 				ReadFileFrom: model.ReadFromLocal,
 			}
-			_, err = model.LoadProjectInto(ctx, configBytes, opts, "", p)
+			pp, err := model.LoadProjectInto(ctx, configBytes, opts, "", p)
+			if pp != nil && len(params) > 0 && (err == nil || strings.Contains(err.Error(), model.TranslateProjectError)) {
+				// Parameters can change the values of matrix axes, so the
+				// project has to be translated again.
+				setParameterDefaults(pp, params)
+				var translated *model.Project
+				translated, err = model.TranslateProject(pp)
+				if translated != nil {
+					p = translated
+				}
+			}
+			if showMatrix && pp != nil {
+				// Show the matrices even if the project has errors, since
+				// the matrices may be the cause.
+				return evaluateMatrices(pp, err)
+			}
 			if err != nil {
 				return errors.Wrap(err, "loading project")
 			}
@@ -80,3 +112,35 @@ func Evaluate() cli.Command {
 		},
 	}
 }
+
+// setParameterDefaults overrides the defaults of the project's parameters
+// with the given values.
+func setParameterDefaults(pp *model.ParserProject, params []patch.Parameter) {
+	for _, param := range params {
+		found := false
+		for i := range pp.Parameters {
+			if pp.Parameters[i].Key == param.Key {
+				pp.Parameters[i].Value = param.Value
+				found = true
+			}
+		}
+		if !found {
+			pp.Parameters = append(pp.Parameters, model.ParameterInfo{Parameter: param})
+		}
+	}
+}
+
+func evaluateMatrices(pp *model.ParserProject, loadErr error) error {
+	evaluations, err := pp.EvaluateMatrices()
+	if len(evaluations) > 0 {
+		out, marshalErr := yaml.Marshal(evaluations)
+		if marshalErr != nil {
+			return errors.Wrap(marshalErr, "marshalling matrix evaluations")
+		}
+		fmt.Println(string(out))
+	}
+	if err != nil {
+		return errors.Wrap(err, "evaluating matrices")
+	}
+	return errors.Wrap(loadErr, "loading project")
+}
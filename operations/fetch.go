@@ -165,20 +165,14 @@ func fetchSource(ctx context.Context, ac, rc *legacyClient, comm client.Communic
 		}))
 	}
 
-	cloneDir := util.CleanForPath(fmt.Sprintf("source-%v", task.Project))
 	var patch *service.RestPatch
 	if evergreen.IsPatchRequester(task.Requester) {
-		cloneDir = util.CleanForPath(fmt.Sprintf("source-patch-%v_%v", task.PatchNumber, task.Project))
 		patch, err = rc.GetRestPatch(task.PatchId)
 		if err != nil {
 			return err
 		}
-	} else {
-		if len(task.Revision) >= 5 {
-			cloneDir = util.CleanForPath(fmt.Sprintf("source-%v-%v", task.Project, task.Revision[0:6]))
-		}
 	}
-	cloneDir = filepath.Join(rootPath, cloneDir)
+	cloneDir := filepath.Join(rootPath, model.FetchSourceDirName(task.Project, task.Requester, task.Revision, task.PatchNumber))
 	err = cloneSource(task, pRef, project, cloneDir, token, mfest)
 	if err != nil {
 		return err
@@ -8,6 +8,8 @@ func Host() cli.Command {
 		Usage: "manage Evergreen spawn and build hosts",
 		Subcommands: []cli.Command{
 			hostCreate(),
+			hostTaskEnvironment(),
+			hostAutoStop(),
 			hostModify(),
 			hostConfigure(),
 			hostStop(),
@@ -0,0 +1,148 @@
+package operations
+
+import (
+	"context"
+	"strings"
+
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func hostAutoStop() cli.Command {
+	const (
+		idleTimeoutFlagName   = "idle-timeout"
+		stopTimeFlagName      = "sleep-stop"
+		startTimeFlagName     = "sleep-start"
+		dayOffFlagName        = "day-off"
+		timeZoneFlagName      = "time-zone"
+		clearScheduleFlagName = "clear-sleep-schedule"
+	)
+
+	return cli.Command{
+		Name:  "auto-stop",
+		Usage: "view or change when your spawn hosts are automatically stopped",
+		Flags: []cli.Flag{
+			cli.IntFlag{
+				Name:  idleTimeoutFlagName,
+				Usage: "stop spawn hosts after they are idle for `MINUTES` (0 to only use the distro's idle timeout)",
+			},
+			cli.StringFlag{
+				Name:  stopTimeFlagName,
+				Usage: "stop spawn hosts every day at `HH:MM`",
+			},
+			cli.StringFlag{
+				Name:  startTimeFlagName,
+				Usage: "start spawn hosts stopped by the sleep schedule every day at `HH:MM`",
+			},
+			cli.StringSliceFlag{
+				Name:  dayOffFlagName,
+				Usage: "keep spawn hosts stopped all day on `WEEKDAY`, one day per flag",
+			},
+			cli.StringFlag{
+				Name:  timeZoneFlagName,
+				Usage: "interpret the sleep schedule in time zone `TZ` (default is your user time zone)",
+			},
+			cli.BoolFlag{
+				Name:  clearScheduleFlagName,
+				Usage: "remove the sleep schedule",
+			},
+		},
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireIntValueBetween(idleTimeoutFlagName, 0, 1<<20),
+			mutuallyExclusiveArgs(false, clearScheduleFlagName, stopTimeFlagName),
+			mutuallyExclusiveArgs(false, clearScheduleFlagName, startTimeFlagName),
+			mutuallyExclusiveArgs(false, clearScheduleFlagName, dayOffFlagName),
+			mutuallyExclusiveArgs(false, clearScheduleFlagName, timeZoneFlagName),
+		),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			// Only the fields that are set are changed; the rest of the
+			// user's settings are left as they are.
+			policy := &restModel.APISpawnHostAutoStopPolicy{}
+			if c.IsSet(idleTimeoutFlagName) {
+				policy.IdleTimeoutMins = utility.ToIntPtr(c.Int(idleTimeoutFlagName))
+			}
+			if c.Bool(clearScheduleFlagName) {
+				policy.SleepSchedule = &restModel.APISleepSchedule{
+					StopTime:         utility.ToStringPtr(""),
+					StartTime:        utility.ToStringPtr(""),
+					WholeWeekdaysOff: []string{},
+					TimeZone:         utility.ToStringPtr(""),
+				}
+			} else if c.IsSet(stopTimeFlagName) || c.IsSet(startTimeFlagName) || c.IsSet(dayOffFlagName) || c.IsSet(timeZoneFlagName) {
+				policy.SleepSchedule = &restModel.APISleepSchedule{}
+				if c.IsSet(stopTimeFlagName) {
+					policy.SleepSchedule.StopTime = utility.ToStringPtr(c.String(stopTimeFlagName))
+				}
+				if c.IsSet(startTimeFlagName) {
+					policy.SleepSchedule.StartTime = utility.ToStringPtr(c.String(startTimeFlagName))
+				}
+				if c.IsSet(dayOffFlagName) {
+					policy.SleepSchedule.WholeWeekdaysOff = c.StringSlice(dayOffFlagName)
+				}
+				if c.IsSet(timeZoneFlagName) {
+					policy.SleepSchedule.TimeZone = utility.ToStringPtr(c.String(timeZoneFlagName))
+				}
+			}
+
+			if policy.IdleTimeoutMins != nil || policy.SleepSchedule != nil {
+				if err = client.UpdateUserSettings(ctx, restModel.APIUserSettings{SpawnHostAutoStop: policy}); err != nil {
+					return errors.Wrap(err, "updating spawn host auto-stop policy")
+				}
+			}
+
+			settings, err := client.GetUserSettings(ctx)
+			if err != nil {
+				return errors.Wrap(err, "getting user settings")
+			}
+			printSpawnHostAutoStopPolicy(settings.SpawnHostAutoStop)
+
+			return nil
+		},
+	}
+}
+
+func printSpawnHostAutoStopPolicy(policy *restModel.APISpawnHostAutoStopPolicy) {
+	if policy == nil {
+		policy = &restModel.APISpawnHostAutoStopPolicy{}
+	}
+
+	if mins := utility.FromIntPtr(policy.IdleTimeoutMins); mins > 0 {
+		grip.Infof("%-18s: %d minutes", "Idle Timeout", mins)
+	} else {
+		grip.Infof("%-18s: %s", "Idle Timeout", "distro default")
+	}
+
+	schedule := policy.SleepSchedule
+	if schedule == nil || (utility.FromStringPtr(schedule.StopTime) == "" && len(schedule.WholeWeekdaysOff) == 0) {
+		grip.Infof("%-18s: %s", "Sleep Schedule", "none")
+		return
+	}
+	if stop := utility.FromStringPtr(schedule.StopTime); stop != "" {
+		grip.Infof("%-18s: %s-%s", "Sleep Schedule", stop, utility.FromStringPtr(schedule.StartTime))
+	}
+	if len(schedule.WholeWeekdaysOff) != 0 {
+		grip.Infof("%-18s: %s", "Days Off", strings.Join(schedule.WholeWeekdaysOff, ", "))
+	}
+	if tz := utility.FromStringPtr(schedule.TimeZone); tz != "" {
+		grip.Infof("%-18s: %s", "Time Zone", tz)
+	}
+}
@@ -30,14 +30,16 @@ import (
 
 func hostCreate() cli.Command {
 	const (
-		distroFlagName       = "distro"
-		keyFlagName          = "key"
-		scriptFlagName       = "script"
-		tagFlagName          = "tag"
-		instanceTypeFlagName = "type"
-		noExpireFlagName     = "no-expire"
-		fileFlagName         = "file"
-		setupFlagName        = "setup"
+		distroFlagName        = "distro"
+		keyFlagName           = "key"
+		scriptFlagName        = "script"
+		tagFlagName           = "tag"
+		instanceTypeFlagName  = "type"
+		noExpireFlagName      = "no-expire"
+		fileFlagName          = "file"
+		setupFlagName         = "setup"
+		reproduceTaskFlagName = "reproduce-task"
+		runTaskSetupFlagName  = "run-task-setup"
 	)
 
 	return cli.Command{
@@ -80,6 +82,14 @@ func hostCreate() cli.Command {
 				Name:  joinFlagNames(fileFlagName, "f"),
 				Usage: "name of a JSON or YAML file containing the spawn host params",
 			},
+			cli.StringFlag{
+				Name:  reproduceTaskFlagName,
+				Usage: "ID of a task to reproduce on the host, which loads the task's data and writes its expansions and commands into the home directory (defaults to the task's distro)",
+			},
+			cli.BoolFlag{
+				Name:  runTaskSetupFlagName,
+				Usage: fmt.Sprintf("run the setup commands of the task given by --%s once its environment is loaded", reproduceTaskFlagName),
+			},
 		},
 		Before: requireStringFlag(keyFlagName),
 		Action: func(c *cli.Context) error {
@@ -93,6 +103,8 @@ func hostCreate() cli.Command {
 			region := c.String(regionFlagName)
 			noExpire := c.Bool(noExpireFlagName)
 			file := c.String(fileFlagName)
+			reproduceTask := c.String(reproduceTaskFlagName)
+			runTaskSetup := c.Bool(runTaskSetupFlagName)
 
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
@@ -138,6 +150,12 @@ func hostCreate() cli.Command {
 				}
 			}
 
+			if reproduceTask != "" {
+				spawnRequest.TaskID = reproduceTask
+				spawnRequest.ReproduceTask = true
+				spawnRequest.RunTaskSetup = runTaskSetup
+			}
+
 			if userdataFile != "" {
 				var out []byte
 				out, err = os.ReadFile(userdataFile)
@@ -164,6 +182,59 @@ func hostCreate() cli.Command {
 			}
 
 			grip.Infof("Spawn host created with ID '%s'. Visit the hosts page in Evergreen to check on its status, or check `evergreen host list --mine", utility.FromStringPtr(host.Id))
+			if spawnRequest.ReproduceTask {
+				grip.Infof("Check on the progress of loading the task's environment onto the host with `evergreen host task-environment --host %s`", utility.FromStringPtr(host.Id))
+			}
+			return nil
+		},
+	}
+}
+
+func hostTaskEnvironment() cli.Command {
+	return cli.Command{
+		Name:   "task-environment",
+		Usage:  "show the progress of loading a task's environment onto a spawn host reproducing it",
+		Flags:  addHostFlag(),
+		Before: mergeBeforeFuncs(setPlainLogger, requireHostFlag),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			hostID := c.String(hostFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			events, err := client.GetSpawnHostTaskEnvironment(ctx, hostID)
+			if err != nil {
+				return errors.Wrapf(err, "getting task environment of host '%s'", hostID)
+			}
+			if len(events) == 0 {
+				grip.Infof("Host '%s' has not started loading the task's environment yet.", hostID)
+				return nil
+			}
+			for _, e := range events {
+				if e.Data == nil {
+					continue
+				}
+				status := "succeeded"
+				if !e.Data.Successful {
+					status = "failed"
+				}
+				msg := fmt.Sprintf("[%s] %s %s", utility.FromTimePtr(e.Timestamp).Format(time.RFC3339), utility.FromStringPtr(e.Data.ProvisioningStep), status)
+				if logs := utility.FromStringPtr(e.Data.Logs); logs != "" {
+					msg += ":\n" + logs
+				}
+				grip.Info(msg)
+			}
 			return nil
 		},
 	}
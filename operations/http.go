@@ -547,6 +547,7 @@ func (ac *legacyClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, e
 		RepeatPatchId     string             `json:"repeat_patch_id"`
 		GithubAuthor      string             `json:"github_author"`
 		PatchAuthor       string             `json:"patch_author"`
+		BasePatchID       string             `json:"base_patch_id"`
 	}{
 		Description:       incomingPatch.description,
 		Project:           incomingPatch.projectName,
@@ -572,6 +573,7 @@ func (ac *legacyClient) PutPatch(incomingPatch patchSubmission) (*patch.Patch, e
 		RepeatPatchId:     incomingPatch.repeatPatchId,
 		GithubAuthor:      incomingPatch.githubAuthor,
 		PatchAuthor:       incomingPatch.patchAuthor,
+		BasePatchID:       incomingPatch.basePatchID,
 	}
 
 	rPipe, wPipe := io.Pipe()
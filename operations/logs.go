@@ -0,0 +1,205 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func Logs() cli.Command {
+	return cli.Command{
+		Name:  "logs",
+		Usage: "search and inspect task logs",
+		Subcommands: []cli.Command{
+			logsSearch(),
+		},
+	}
+}
+
+func logsSearch() cli.Command {
+	const (
+		patternFlagName        = "pattern"
+		regexFlagName          = "regex"
+		variantFlagName        = "variant"
+		taskFlagName           = "task"
+		requesterFlagName      = "requester"
+		sinceFlagName          = "since"
+		startFlagName          = "start"
+		endFlagName            = "end"
+		startAtFlagName        = "start-at"
+		matchesPerTaskFlagName = "matches"
+	)
+
+	return cli.Command{
+		Name:  "search",
+		Usage: "find tasks whose logs contain a substring or regular expression",
+		Flags: addProjectFlag(
+			cli.StringFlag{
+				Name:  joinFlagNames(patternFlagName, "e"),
+				Usage: "the substring (or regular expression with --regex) to search for",
+			},
+			cli.BoolFlag{
+				Name:  regexFlagName,
+				Usage: "interpret the pattern as a regular expression",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(variantFlagName, "v"),
+				Usage: "only search tasks in this build variant",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(taskFlagName, "t"),
+				Usage: "only search tasks with this name",
+			},
+			cli.StringSliceFlag{
+				Name:  requesterFlagName,
+				Usage: "only search tasks with this requester (can be specified multiple times)",
+			},
+			cli.DurationFlag{
+				Name:  sinceFlagName,
+				Usage: "search tasks that finished within this duration of now (ignored if --start is set)",
+				Value: 7 * 24 * time.Hour,
+			},
+			cli.StringFlag{
+				Name:  startFlagName,
+				Usage: "search tasks that finished after this RFC-3339 time",
+			},
+			cli.StringFlag{
+				Name:  endFlagName,
+				Usage: "search tasks that finished before this RFC-3339 time (default: now)",
+			},
+			cli.StringFlag{
+				Name:  startAtFlagName,
+				Usage: "resume a previous search from this task ID",
+			},
+			cli.IntFlag{
+				Name:  joinFlagNames(limitFlagName, "l"),
+				Usage: "maximum number of matching tasks to return",
+				Value: 20,
+			},
+			cli.IntFlag{
+				Name:  matchesPerTaskFlagName,
+				Usage: "maximum number of matching lines to show for each task",
+				Value: 10,
+			},
+			cli.BoolFlag{
+				Name:  jsonFlagName,
+				Usage: "output results as JSON",
+			},
+		),
+		Before: mergeBeforeFuncs(setPlainLogger, requireProjectFlag, requireStringFlag(patternFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			project := c.String(projectFlagName)
+			limit := c.Int(limitFlagName)
+			jsonOutput := c.Bool(jsonFlagName)
+			if limit <= 0 || limit > model.MaxTaskLogSearchLimit {
+				return errors.Errorf("limit must be between 1 and %d", model.MaxTaskLogSearchLimit)
+			}
+
+			req := restModel.APITaskLogSearchRequest{
+				Pattern:        utility.ToStringPtr(c.String(patternFlagName)),
+				Regex:          c.Bool(regexFlagName),
+				Requesters:     c.StringSlice(requesterFlagName),
+				StartAt:        utility.ToStringPtr(c.String(startAtFlagName)),
+				MatchesPerTask: c.Int(matchesPerTaskFlagName),
+			}
+			if variant := c.String(variantFlagName); variant != "" {
+				req.BuildVariant = utility.ToStringPtr(variant)
+			}
+			if taskName := c.String(taskFlagName); taskName != "" {
+				req.TaskName = utility.ToStringPtr(taskName)
+			}
+			startTime := time.Now().Add(-c.Duration(sinceFlagName))
+			if start := c.String(startFlagName); start != "" {
+				var err error
+				startTime, err = time.Parse(time.RFC3339, start)
+				if err != nil {
+					return errors.Wrap(err, "parsing start time as RFC-3339")
+				}
+			}
+			req.StartTime = &startTime
+			if end := c.String(endFlagName); end != "" {
+				endTime, err := time.Parse(time.RFC3339, end)
+				if err != nil {
+					return errors.Wrap(err, "parsing end time as RFC-3339")
+				}
+				req.EndTime = &endTime
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, !jsonOutput)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			// Each page scans a bounded number of tasks, so keep paging until
+			// enough matching tasks have been found or there are no tasks left
+			// to search.
+			var results []restModel.APITaskLogSearchResult
+			var nextKey string
+			for len(results) < limit {
+				req.Limit = limit - len(results)
+				resp, err := client.SearchTaskLogs(ctx, project, req)
+				if err != nil {
+					return errors.Wrap(err, "searching task logs")
+				}
+				results = append(results, resp.Results...)
+				nextKey = utility.FromStringPtr(resp.NextKey)
+				if nextKey == "" {
+					break
+				}
+				req.StartAt = resp.NextKey
+			}
+
+			if jsonOutput {
+				out, err := json.MarshalIndent(restModel.APITaskLogSearchResponse{
+					Results: results,
+					NextKey: utility.ToStringPtr(nextKey),
+				}, "", "\t")
+				if err != nil {
+					return errors.Wrap(err, "marshalling search results to JSON")
+				}
+				fmt.Println(string(out))
+				return nil
+			}
+
+			if len(results) == 0 {
+				grip.Info("No matching tasks found.")
+			}
+			for _, result := range results {
+				grip.Infof("%s (execution %d) [%s/%s, %s]", utility.FromStringPtr(result.TaskID), result.Execution,
+					utility.FromStringPtr(result.BuildVariant), utility.FromStringPtr(result.TaskName), utility.FromStringPtr(result.Requester))
+				for _, match := range result.Matches {
+					ts := ""
+					if match.Timestamp != nil {
+						ts = match.Timestamp.Format(time.RFC3339)
+					}
+					grip.Infof("\t%s %s", ts, utility.FromStringPtr(match.Excerpt))
+				}
+				if result.Truncated {
+					grip.Info("\t...")
+				}
+			}
+			if nextKey != "" {
+				grip.Infof("More tasks remain to be searched; rerun with '--%s %s' to continue.", startAtFlagName, nextKey)
+			}
+
+			return nil
+		},
+	}
+}
@@ -22,6 +22,11 @@ const (
 	repeatPatchIdFlag          = "repeat-patch"
 	includeModulesFlag         = "include-modules"
 	autoDescriptionFlag        = "auto-description"
+	basePatchFlag              = "base-patch"
+	basePatchRefFlag           = "base-patch-ref"
+	remoteRefFlag              = "remote"
+	remoteRepoFlag             = "repo"
+	impactedFlag               = "impacted"
 )
 
 func getPatchFlags(flags ...cli.Flag) []cli.Flag {
@@ -90,6 +95,9 @@ func Patch() cli.Command {
 			mutuallyExclusiveArgs(false, preserveCommitsFlag, uncommittedChangesFlag),
 			mutuallyExclusiveArgs(false, repeatDefinitionFlag, repeatPatchIdFlag,
 				repeatFailedDefinitionFlag),
+			mutuallyExclusiveArgs(false, remoteRefFlag, uncommittedChangesFlag),
+			mutuallyExclusiveArgs(false, remoteRepoFlag, uncommittedChangesFlag),
+			mutuallyExclusiveArgs(false, impactedFlag, patchAliasFlagName),
 			func(c *cli.Context) error {
 				catcher := grip.NewBasicCatcher()
 				for _, status := range utility.SplitCommas(c.StringSlice(syncStatusesFlagName)) {
@@ -107,6 +115,27 @@ func Patch() cli.Command {
 				Name:  includeModulesFlag,
 				Usage: "include module diffs using changes from defined module paths",
 			},
+			cli.StringFlag{
+				Name:  basePatchFlag,
+				Usage: "stack the patch on top of the patch with the given `ID`",
+			},
+			cli.StringFlag{
+				Name:  basePatchRefFlag,
+				Usage: "when stacking, diff against the local `REF` that contains the base patch's changes",
+				Value: "HEAD~1",
+			},
+			cli.BoolFlag{
+				Name:  remoteRefFlag,
+				Usage: "patch the ref given by --ref in the project's GitHub repository instead of a local ref, without uploading a local diff",
+			},
+			cli.BoolFlag{
+				Name:  impactedFlag,
+				Usage: "select the tasks impacted by the patch's changed files using the project's impacted tasks rules",
+			},
+			cli.StringFlag{
+				Name:  remoteRepoFlag,
+				Usage: "patch the ref given by --ref in the GitHub repository `OWNER/NAME` (e.g. a fork of the project's repository); implies --remote",
+			},
 		),
 		Action: func(c *cli.Context) error {
 			confPath := c.Parent().String(confFlagName)
@@ -134,6 +163,13 @@ func Patch() cli.Command {
 				Uncommitted:       c.Bool(uncommittedChangesFlag),
 				PreserveCommits:   c.Bool(preserveCommitsFlag),
 				TriggerAliases:    utility.SplitCommas(c.StringSlice(patchTriggerAliasFlag)),
+				BasePatchID:       c.String(basePatchFlag),
+				BasePatchRef:      c.String(basePatchRefFlag),
+				Repo:              c.String(remoteRepoFlag),
+			}
+			params.Remote = c.Bool(remoteRefFlag) || params.Repo != ""
+			if c.Bool(impactedFlag) {
+				params.Alias = evergreen.ImpactedAlias
 			}
 
 			var err error
@@ -155,10 +191,13 @@ func Patch() cli.Command {
 
 			params.PreserveCommits = params.PreserveCommits || conf.PreserveCommits
 			if !params.SkipConfirm {
-				var keepGoing bool
-				keepGoing, err = confirmUncommittedChanges("", params.PreserveCommits, params.Uncommitted || conf.UncommittedChanges)
-				if err != nil {
-					return errors.Wrap(err, "confirming uncommitted changes")
+				keepGoing := true
+				// A remote patch doesn't include any local changes.
+				if !params.Remote {
+					keepGoing, err = confirmUncommittedChanges("", params.PreserveCommits, params.Uncommitted || conf.UncommittedChanges)
+					if err != nil {
+						return errors.Wrap(err, "confirming uncommitted changes")
+					}
 				}
 				if keepGoing && utility.StringSliceContains(params.Variants, "all") && utility.StringSliceContains(params.Tasks, "all") {
 					keepGoing = confirm(`For some projects, scheduling all tasks/variants may result in a very large patch build. Continue?`, true)
@@ -183,6 +222,26 @@ func Patch() cli.Command {
 			if err != nil {
 				return err
 			}
+
+			if params.Remote {
+				if includeModules {
+					return errors.New("cannot include local module changes when patching a remote ref")
+				}
+				// Evergreen describes the patch by its ref if no description is given.
+				if !params.SkipConfirm && params.Description == "" {
+					params.Description = prompt("Enter a description for this patch (optional):")
+				}
+				newPatch, err := params.createRemotePatch(ctx, comm)
+				if err != nil {
+					return err
+				}
+				if err = params.displayPatch(newPatch, conf.UIServerHost, false); err != nil {
+					grip.Error(err)
+				}
+				params.setDefaultProject(conf)
+				return nil
+			}
+
 			params.Description = params.getDescription()
 
 			isReusing := params.RepeatDefinition || params.RepeatFailed
@@ -193,7 +252,12 @@ func Patch() cli.Command {
 				return errors.Errorf("can't define tasks, variants, regex tasks, regex variants or aliases when reusing previous patch's tasks and variants")
 			}
 
-			diffData, err := loadGitData("", ref.Branch, params.Ref, "", params.PreserveCommits, args...)
+			var diffData *localDiff
+			if params.BasePatchID != "" {
+				diffData, err = params.loadStackedGitData(ac, ref, args...)
+			} else {
+				diffData, err = loadGitData("", ref.Branch, params.Ref, "", params.PreserveCommits, args...)
+			}
 			if err != nil {
 				return err
 			}
@@ -18,6 +18,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/rest/client"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
@@ -42,6 +43,10 @@ Base Commit : {{.Githash}}
     {{range .PatchSet.Summary}}+{{.Additions}} -{{.Deletions}} {{.Name}}
     {{end}}
 {{end}}
+{{if .Patch.ImpactedTasks}}Impacted Tasks :
+{{range .Patch.ImpactedTasks}}    {{.Variant}}/{{.Task}}{{range .Reasons}}
+        - {{.}}{{end}}
+{{end}}{{end}}
 {{end}}
 `))
 
@@ -86,6 +91,14 @@ type patchParams struct {
 	RepeatPatchId     string
 	GithubAuthor      string
 	PatchAuthor       string
+	BasePatchID       string
+	BasePatchRef      string
+	// Remote indicates that Ref refers to a ref in a GitHub repository rather
+	// than a local one, so the patch diff is built by Evergreen.
+	Remote bool
+	// Repo is the GitHub repository in the form "owner/name" containing the
+	// remote ref. It defaults to the project's repository.
+	Repo string
 }
 
 type patchSubmission struct {
@@ -113,6 +126,7 @@ type patchSubmission struct {
 	repeatPatchId     string
 	githubAuthor      string
 	patchAuthor       string
+	basePatchID       string
 }
 
 func (p *patchParams) createPatch(ac *legacyClient, diffData *localDiff) (*patch.Patch, error) {
@@ -141,6 +155,7 @@ func (p *patchParams) createPatch(ac *legacyClient, diffData *localDiff) (*patch
 		path:              p.Path,
 		githubAuthor:      p.GithubAuthor,
 		patchAuthor:       p.PatchAuthor,
+		basePatchID:       p.BasePatchID,
 	}
 
 	newPatch, err := ac.PutPatch(patchSub)
@@ -151,6 +166,47 @@ func (p *patchParams) createPatch(ac *legacyClient, diffData *localDiff) (*patch
 	return newPatch, nil
 }
 
+// createRemotePatch creates a patch from a ref in a GitHub repository. The
+// diff is built by Evergreen, so no local changes are uploaded.
+func (p *patchParams) createRemotePatch(ctx context.Context, comm client.Communicator) (*patch.Patch, error) {
+	if p.Ref == "" || p.Ref == "HEAD" {
+		return nil, errors.New("must specify a branch, tag, or commit with --ref when patching a remote ref")
+	}
+	if p.BasePatchID != "" {
+		return nil, errors.New("cannot stack a patch from a remote ref on another patch")
+	}
+	if len(p.RegexVariants) > 0 || len(p.RegexTasks) > 0 || len(p.TriggerAliases) > 0 || p.RepeatDefinition || p.RepeatFailed {
+		return nil, errors.New("regex variants, regex tasks, trigger aliases, and reused definitions are not supported when patching a remote ref")
+	}
+
+	params := make([]restModel.APIParameter, 0, len(p.Parameters))
+	for _, param := range p.Parameters {
+		params = append(params, restModel.APIParameter{
+			Key:   utility.ToStringPtr(param.Key),
+			Value: utility.ToStringPtr(param.Value),
+		})
+	}
+	apiPatch, err := comm.CreatePatchFromRemoteRef(ctx, p.Project, restModel.APIRemotePatchRequest{
+		Ref:         utility.ToStringPtr(p.Ref),
+		Repo:        utility.ToStringPtr(p.Repo),
+		Description: utility.ToStringPtr(p.Description),
+		Alias:       utility.ToStringPtr(p.Alias),
+		Variants:    p.Variants,
+		Tasks:       p.Tasks,
+		Parameters:  params,
+		Finalize:    p.Finalize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newPatch, err := apiPatch.ToService()
+	if err != nil {
+		return nil, errors.Wrap(err, "converting patch to service model")
+	}
+	return &newPatch, nil
+}
+
 func (p *patchParams) validateSubmission(diffData *localDiff) error {
 	if err := validatePatchSize(diffData, p.Large); err != nil {
 		return err
@@ -240,7 +296,7 @@ func (p *patchParams) validatePatchCommand(ctx context.Context, conf *ClientSett
 		grip.Warningf("warning - failed to set default parameters: %v\n", err)
 	}
 
-	if p.Uncommitted || conf.UncommittedChanges {
+	if !p.Remote && (p.Uncommitted || conf.UncommittedChanges) {
 		p.Ref = ""
 	}
 
@@ -633,22 +689,49 @@ func loadGitData(dir, branch, ref, commits string, format bool, extraArgs ...str
 		return nil, errors.Wrapf(err, "Error getting merge base, "+
 			"may need to create local branch '%s' and have it track upstream", branch)
 	}
+	return loadGitDataFromBase(dir, mergeBase, ref, commits, format, extraArgs...)
+}
+
+// loadStackedGitData loads the diff for a patch that's stacked on top of the
+// base patch. The diff is taken against BasePatchRef, the local commit that
+// contains the base patch's changes, and uses the base patch's revision as
+// its base.
+func (p *patchParams) loadStackedGitData(ac *legacyClient, ref *model.ProjectRef, extraArgs ...string) (*localDiff, error) {
+	basePatch, err := ac.GetPatch(p.BasePatchID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting base patch '%s'", p.BasePatchID)
+	}
+	if basePatch.Project != ref.Id {
+		return nil, errors.Errorf("base patch '%s' is for a different project", p.BasePatchID)
+	}
+
+	diffData, err := loadGitDataFromBase("", p.BasePatchRef, p.Ref, "", p.PreserveCommits, extraArgs...)
+	if err != nil {
+		return nil, err
+	}
+	diffData.base = basePatch.Githash
+	return diffData, nil
+}
+
+// loadGitDataFromBase loads the diff between base and ref, or for the given
+// commits.
+func loadGitDataFromBase(dir, base, ref, commits string, format bool, extraArgs ...string) (*localDiff, error) {
 	statArgs := []string{"--stat"}
 	if len(extraArgs) > 0 {
 		statArgs = append(statArgs, extraArgs...)
 	}
-	stat, err := gitDiff(dir, mergeBase, ref, commits, statArgs...)
+	stat, err := gitDiff(dir, base, ref, commits, statArgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting diff summary")
 	}
-	log, err := gitLog(dir, mergeBase, ref, commits)
+	log, err := gitLog(dir, base, ref, commits)
 	if err != nil {
 		return nil, errors.Wrap(err, "git log")
 	}
 
 	var fullPatch string
 	if format {
-		fullPatch, err = gitFormatPatch(dir, mergeBase, ref, commits)
+		fullPatch, err = gitFormatPatch(dir, base, ref, commits)
 		if err != nil {
 			return nil, errors.Wrap(err, "getting git formatted patch")
 		}
@@ -656,7 +739,7 @@ func loadGitData(dir, branch, ref, commits string, format bool, extraArgs ...str
 		if !utility.StringSliceContains(extraArgs, "--binary") {
 			extraArgs = append(extraArgs, "--binary")
 		}
-		fullPatch, err = gitDiff(dir, mergeBase, ref, commits, extraArgs...)
+		fullPatch, err = gitDiff(dir, base, ref, commits, extraArgs...)
 		if err != nil {
 			return nil, errors.Wrap(err, "getting git diff")
 		}
@@ -671,7 +754,7 @@ func loadGitData(dir, branch, ref, commits string, format bool, extraArgs ...str
 		fullPatch:    fullPatch,
 		patchSummary: stat,
 		log:          log,
-		base:         mergeBase,
+		base:         base,
 		gitMetadata:  gitMetadata,
 	}, nil
 }
@@ -2,8 +2,15 @@ package operations
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/evergreen-ci/evergreen/scheduler"
+	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/send"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
 )
@@ -18,6 +25,8 @@ func Scheduler() cli.Command {
 		Usage: "scheduler debugging utilities",
 		Subcommands: []cli.Command{
 			compareTasks(),
+			schedulerSnapshot(),
+			schedulerSimulate(),
 		},
 		Flags: mergeFlagSlices(addPathFlag(
 			cli.BoolFlag{
@@ -75,3 +84,204 @@ func compareTasks() cli.Command {
 		},
 	}
 }
+
+func schedulerSnapshot() cli.Command {
+	const (
+		distroFlagName   = "distro"
+		lookbackFlagName = "lookback"
+		outputFlagName   = "output"
+	)
+
+	return cli.Command{
+		Name:  "snapshot",
+		Usage: "save a distro's scheduler settings and workload to a file for offline simulation",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(distroFlagName, "d"),
+				Usage: "the distro to snapshot",
+			},
+			cli.DurationFlag{
+				Name:  lookbackFlagName,
+				Usage: "replay the tasks activated within this duration of now, up to a week (default: only the current queue)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(outputFlagName, "o"),
+				Usage: "the file to write the snapshot to",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(distroFlagName), requireStringFlag(outputFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			distroID := c.String(distroFlagName)
+			lookback := c.Duration(lookbackFlagName)
+			output := c.String(outputFlagName)
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			snapshot, err := client.GetDistroSchedulerSnapshot(ctx, distroID, lookback)
+			if err != nil {
+				return errors.Wrap(err, "getting scheduler snapshot")
+			}
+			if err = utility.WriteJSONFile(output, snapshot); err != nil {
+				return errors.Wrapf(err, "writing snapshot to file '%s'", output)
+			}
+
+			grip.Infof("Wrote snapshot of distro '%s' with %d tasks and %d hosts to '%s'.", distroID, len(snapshot.Tasks), len(snapshot.Hosts), output)
+			return nil
+		},
+	}
+}
+
+// schedulerSimulationSettings are alternate scheduler settings to simulate.
+// Settings that are not specified keep their value from the snapshot.
+type schedulerSimulationSettings struct {
+	PlannerSettings       json.RawMessage `json:"planner_settings"`
+	HostAllocatorSettings json.RawMessage `json:"host_allocator_settings"`
+}
+
+func schedulerSimulate() cli.Command {
+	const (
+		snapshotFlagName = "snapshot"
+		settingsFlagName = "settings"
+		intervalFlagName = "interval"
+		startupFlagName  = "host-startup"
+		maxTimeFlagName  = "max-time"
+	)
+
+	return cli.Command{
+		Name:  "simulate",
+		Usage: "replay a scheduler snapshot offline and compare alternate settings against the snapshot's settings",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(snapshotFlagName, "s"),
+				Usage: "the snapshot file created by 'evergreen scheduler snapshot'",
+			},
+			cli.StringFlag{
+				Name:  settingsFlagName,
+				Usage: "a JSON file with alternate 'planner_settings' and 'host_allocator_settings' to compare",
+			},
+			cli.DurationFlag{
+				Name:  intervalFlagName,
+				Usage: "how often the simulated scheduler runs",
+				Value: time.Minute,
+			},
+			cli.DurationFlag{
+				Name:  startupFlagName,
+				Usage: "how long new hosts take to start running tasks (default: the snapshot's observed average)",
+			},
+			cli.DurationFlag{
+				Name:  maxTimeFlagName,
+				Usage: "the maximum amount of time to simulate",
+				Value: 7 * 24 * time.Hour,
+			},
+			cli.BoolFlag{
+				Name:  jsonFlagName,
+				Usage: "output the simulation reports as JSON",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(snapshotFlagName)),
+		Action: func(c *cli.Context) error {
+			snapshotPath := c.String(snapshotFlagName)
+			settingsPath := c.String(settingsFlagName)
+			jsonOutput := c.Bool(jsonFlagName)
+
+			snapshot := scheduler.SimulationSnapshot{}
+			if err := utility.ReadJSONFile(snapshotPath, &snapshot); err != nil {
+				return errors.Wrapf(err, "reading snapshot file '%s'", snapshotPath)
+			}
+
+			opts := scheduler.SimulationOptions{
+				SchedulerInterval:   c.Duration(intervalFlagName),
+				HostStartupDuration: c.Duration(startupFlagName),
+				MaxDuration:         c.Duration(maxTimeFlagName),
+			}
+
+			// The scheduler logs every planning and allocation decision, which
+			// would drown out the simulation results, so only log warnings.
+			sender := grip.GetSender()
+			if err := sender.SetLevel(send.LevelInfo{Default: level.Info, Threshold: level.Warning}); err != nil {
+				return errors.Wrap(err, "setting log level")
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			reports := map[string]*scheduler.SimulationReport{}
+			baseline, err := scheduler.Simulate(ctx, snapshot, opts)
+			if err != nil {
+				return errors.Wrap(err, "simulating snapshot settings")
+			}
+			reports["baseline"] = baseline
+
+			if settingsPath != "" {
+				alternate := schedulerSimulationSettings{}
+				if err = utility.ReadJSONFile(settingsPath, &alternate); err != nil {
+					return errors.Wrapf(err, "reading settings file '%s'", settingsPath)
+				}
+				plannerSettings := snapshot.PlannerSettings
+				if len(alternate.PlannerSettings) != 0 {
+					if err = json.Unmarshal(alternate.PlannerSettings, &plannerSettings); err != nil {
+						return errors.Wrap(err, "parsing planner settings")
+					}
+				}
+				allocatorSettings := snapshot.HostAllocatorSettings
+				if len(alternate.HostAllocatorSettings) != 0 {
+					if err = json.Unmarshal(alternate.HostAllocatorSettings, &allocatorSettings); err != nil {
+						return errors.Wrap(err, "parsing host allocator settings")
+					}
+				}
+				opts.PlannerSettings = &plannerSettings
+				opts.HostAllocatorSettings = &allocatorSettings
+
+				reports["alternate"], err = scheduler.Simulate(ctx, snapshot, opts)
+				if err != nil {
+					return errors.Wrap(err, "simulating alternate settings")
+				}
+			}
+
+			if jsonOutput {
+				out, err := json.MarshalIndent(reports, "", "\t")
+				if err != nil {
+					return errors.Wrap(err, "marshalling simulation reports to JSON")
+				}
+				fmt.Println(string(out))
+				return nil
+			}
+
+			fmt.Printf("Simulated %d tasks on distro '%s' starting at %s.\n", len(snapshot.Tasks), snapshot.DistroID, snapshot.WindowStart.Format(time.RFC3339))
+			printSimulationReport("Baseline", reports["baseline"])
+			if alternate, ok := reports["alternate"]; ok {
+				printSimulationReport("Alternate", alternate)
+			}
+
+			return nil
+		},
+	}
+}
+
+func printSimulationReport(name string, r *scheduler.SimulationReport) {
+	fmt.Printf("%s (planner: %s, host allocator: %s, max hosts: %d)\n", name, r.PlannerVersion, r.HostAllocatorVersion, r.MaxHosts)
+	fmt.Printf("\ttasks completed:   %d/%d\n", r.NumTasksCompleted, r.NumTasks)
+	fmt.Printf("\tqueue wait mean:   %s\n", r.QueueWaitMean)
+	fmt.Printf("\tqueue wait p50:    %s\n", r.QueueWaitP50)
+	fmt.Printf("\tqueue wait p90:    %s\n", r.QueueWaitP90)
+	fmt.Printf("\tqueue wait p99:    %s\n", r.QueueWaitP99)
+	fmt.Printf("\tqueue wait max:    %s\n", r.QueueWaitMax)
+	fmt.Printf("\thost hours:        %.2f\n", r.HostHours)
+	fmt.Printf("\thosts created:     %d\n", r.NumHostsCreated)
+	fmt.Printf("\tpeak hosts:        %d\n", r.PeakHosts)
+	fmt.Printf("\tmakespan:          %s\n", r.Makespan)
+	if r.TimedOut {
+		fmt.Println("\tthe simulation reached its maximum time before all tasks finished")
+	}
+}
@@ -1,6 +1,16 @@
 package operations
 
-import "github.com/urfave/cli"
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
 
 func Volume() cli.Command {
 	return cli.Command{
@@ -11,6 +21,250 @@ func Volume() cli.Command {
 			hostDeleteVolume(),
 			hostListVolume(),
 			hostModifyVolume(),
+			volumeSnapshot(),
+		},
+	}
+}
+
+func volumeSnapshot() cli.Command {
+	return cli.Command{
+		Name:  "snapshot",
+		Usage: "manage snapshots of volumes",
+		Subcommands: []cli.Command{
+			volumeSnapshotCreate(),
+			volumeSnapshotList(),
+			volumeSnapshotDelete(),
+			volumeSnapshotRestore(),
+		},
+	}
+}
+
+func volumeSnapshotCreate() cli.Command {
+	const (
+		idFlagName     = "id"
+		expireFlagName = "expire-days"
+	)
+
+	maxExpireDays := int(evergreen.MaxVolumeSnapshotExpiration / (24 * time.Hour))
+	return cli.Command{
+		Name:  "create",
+		Usage: "create a snapshot of a volume",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  idFlagName,
+				Usage: "`ID` of volume to snapshot",
+			},
+			cli.StringFlag{
+				Name:  displayNameFlagName,
+				Usage: "set a user-friendly name for the snapshot (default is the volume's name)",
+			},
+			cli.IntFlag{
+				Name:  expireFlagName,
+				Usage: "delete the snapshot after `DAYS` (default 30)",
+			},
+		},
+		Before: mergeBeforeFuncs(
+			setPlainLogger,
+			requireStringFlag(idFlagName),
+			requireIntValueBetween(expireFlagName, 0, maxExpireDays),
+		),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			volumeID := c.String(idFlagName)
+			name := c.String(displayNameFlagName)
+			expireDays := c.Int(expireFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "getting REST communicator")
+			}
+			defer client.Close()
+
+			opts := &restModel.VolumeSnapshotCreateOptions{DisplayName: name}
+			if expireDays > 0 {
+				opts.Expiration = time.Now().Add(time.Duration(expireDays) * 24 * time.Hour)
+			}
+			snapshot, err := client.CreateVolumeSnapshot(ctx, volumeID, opts)
+			if err != nil {
+				return err
+			}
+
+			grip.Infof("Created snapshot '%s' of volume '%s'.", utility.FromStringPtr(snapshot.ID), volumeID)
+
+			return nil
+		},
+	}
+}
+
+func volumeSnapshotList() cli.Command {
+	return cli.Command{
+		Name:   "list",
+		Usage:  "list volume snapshots for user",
+		Before: setPlainLogger,
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "getting REST communicator")
+			}
+			defer client.Close()
+
+			snapshots, err := client.GetVolumeSnapshotsByUser(ctx)
+			if err != nil {
+				return err
+			}
+			printVolumeSnapshots(snapshots, conf.User)
+			return nil
+		},
+	}
+}
+
+func printVolumeSnapshots(snapshots []restModel.APIVolumeSnapshot, userID string) {
+	if len(snapshots) == 0 {
+		grip.Infof("no volume snapshots created by user '%s'", userID)
+		return
+	}
+	grip.Infof("%d volume snapshots created by %s:", len(snapshots), userID)
+	for _, s := range snapshots {
+		grip.Infof("\n%-18s: %s\n", "ID", utility.FromStringPtr(s.ID))
+		if utility.FromStringPtr(s.DisplayName) != "" {
+			grip.Infof("%-18s: %s\n", "Name", utility.FromStringPtr(s.DisplayName))
+		}
+		grip.Infof("%-18s: %s\n", "Volume", utility.FromStringPtr(s.VolumeID))
+		grip.Infof("%-18s: %d\n", "Size", s.Size)
+		grip.Infof("%-18s: %s\n", "Availability Zone", utility.FromStringPtr(s.AvailabilityZone))
+		grip.Infof("%-18s: %s\n", "Status", utility.FromStringPtr(s.Status))
+		if s.Automatic {
+			grip.Infof("%-18s: %s\n", "Reason", "taken before the volume expired")
+		}
+		if t, err := restModel.FromTimePtr(s.CreationTime); err == nil && !utility.IsZeroTime(t) {
+			grip.Infof("%-18s: %s\n", "Created", t.Format(time.RFC3339))
+		}
+		if t, err := restModel.FromTimePtr(s.Expiration); err == nil && !utility.IsZeroTime(t) {
+			grip.Infof("%-18s: %s\n", "Expiration", t.Format(time.RFC3339))
+		}
+	}
+}
+
+func volumeSnapshotDelete() cli.Command {
+	const idFlagName = "id"
+
+	return cli.Command{
+		Name:  "delete",
+		Usage: "delete a volume snapshot",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  idFlagName,
+				Usage: "`ID` of snapshot to delete",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(idFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			snapshotID := c.String(idFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "getting REST communicator")
+			}
+			defer client.Close()
+
+			if err = client.DeleteVolumeSnapshot(ctx, snapshotID); err != nil {
+				return err
+			}
+
+			grip.Infof("Deleted volume snapshot '%s'.", snapshotID)
+
+			return nil
+		},
+	}
+}
+
+func volumeSnapshotRestore() cli.Command {
+	const (
+		idFlagName = "id"
+		sizeFlag   = "size"
+		typeFlag   = "type"
+		zoneFlag   = "zone"
+	)
+
+	return cli.Command{
+		Name:  "restore",
+		Usage: "create a new volume from a volume snapshot",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  idFlagName,
+				Usage: "`ID` of snapshot to restore",
+			},
+			cli.StringFlag{
+				Name:  displayNameFlagName,
+				Usage: "set a user-friendly name for the volume (default is the snapshot's name)",
+			},
+			cli.IntFlag{
+				Name:  joinFlagNames(sizeFlag, "s"),
+				Usage: "set volume `SIZE` in GiB (default is the snapshot's size)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(typeFlag, "t"),
+				Usage: "set volume `TYPE` (default is the snapshotted volume's type)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(zoneFlag, "z"),
+				Usage: "set volume `AVAILABILITY ZONE` in the snapshot's region (default is the snapshotted volume's zone)",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(idFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().Parent().String(confFlagName)
+			snapshotID := c.String(idFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+			client, err := conf.setupRestCommunicator(ctx, true)
+			if err != nil {
+				return errors.Wrap(err, "getting REST communicator")
+			}
+			defer client.Close()
+
+			volume, err := client.RestoreVolumeSnapshot(ctx, snapshotID, &restModel.VolumeSnapshotRestoreOptions{
+				DisplayName:      c.String(displayNameFlagName),
+				Type:             c.String(typeFlag),
+				Size:             c.Int(sizeFlag),
+				AvailabilityZone: c.String(zoneFlag),
+			})
+			if err != nil {
+				return err
+			}
+
+			grip.Infof("Restored snapshot '%s' into volume '%s'.", snapshotID, utility.FromStringPtr(volume.ID))
+
+			return nil
 		},
 	}
 }
@@ -169,6 +169,22 @@ mciModule.controller(
         validator: validatePercentage,
       },],
     },
+    {
+      trigger: "perf-change-point",
+      resource_type: "TASK",
+      label: "a performance change point is detected in a task",
+      regex_selectors: taskRegexSelectors(),
+      extraFields: [{
+        text: "Minimum percent change",
+        key: "perf-percent-change",
+        validator: validatePercentage,
+      },
+      {
+        text: "Test names matching regex",
+        key: "test-regex",
+        validator: null,
+      },],
+    },
     ];
 
     // refreshTrackedProjects will populate the list of projects that should be displayed
@@ -363,6 +363,17 @@ func (repoTracker *RepoTracker) StoreRevisions(ctx context.Context, revisions []
 				}))
 			}
 		}
+		if ref.IsGithubCheckRunsEnabled() {
+			if err = addGithubCheckRunSubscriptions(v, ref.IsGithubCheckRunsPerTask()); err != nil {
+				grip.Error(message.WrapError(err, message.Fields{
+					"message":            "error adding github check run subscriptions",
+					"runner":             RunnerName,
+					"project":            ref.Id,
+					"project_identifier": ref.Identifier,
+					"revision":           revision,
+				}))
+			}
+		}
 
 		_, err = model.CreateManifest(v, pInfo.Project, ref, repoTracker.Settings)
 		if err != nil {
@@ -493,6 +504,56 @@ func addGithubCheckSubscriptions(v *model.Version) error {
 	return catcher.Resolve()
 }
 
+// AddGithubMergeQueueSubscriptions adds a subscription to send the status of a
+// merge group's version to GitHub, which is the check that the merge queue
+// waits on before merging the group. If the version cannot run, it reports
+// the failure right away.
+func AddGithubMergeQueueSubscriptions(v *model.Version) error {
+	input := thirdparty.SendGithubStatusInput{
+		VersionId: v.Id,
+		Owner:     v.Owner,
+		Repo:      v.Repo,
+		Ref:       v.Revision,
+		Caller:    RunnerName,
+		Context:   "evergreen",
+	}
+	if len(v.Errors) > 0 {
+		input.Desc = "version has configuration errors"
+		return errors.Wrap(thirdparty.SendFailedStatusToGithub(input), "sending failed version status to GitHub")
+	}
+
+	catcher := grip.NewBasicCatcher()
+	ghSub := event.NewGithubCheckAPISubscriber(event.GithubCheckSubscriber{
+		Owner: v.Owner,
+		Repo:  v.Repo,
+		Ref:   v.Revision,
+	})
+	versionSub := event.NewSubscriptionByID(event.ResourceTypeVersion, event.TriggerOutcome, v.Id, ghSub)
+	versionSub.LastUpdated = time.Now()
+	catcher.Wrap(versionSub.Upsert(), "inserting version GitHub merge queue subscription")
+
+	input.Desc = "version created"
+	catcher.Wrap(thirdparty.SendPendingStatusToGithub(input), "sending version status to GitHub")
+	return catcher.Resolve()
+}
+
+// addGithubCheckRunSubscriptions adds a subscription to post the results of
+// the GitHub check tasks in the version to GitHub as a check run for each
+// build variant or, if perTask is set, for each task.
+func addGithubCheckRunSubscriptions(v *model.Version, perTask bool) error {
+	ghSub := event.NewGithubCheckRunSubscriber(event.GithubCheckSubscriber{
+		Owner: v.Owner,
+		Repo:  v.Repo,
+		Ref:   v.Revision,
+	})
+	resourceType := event.ResourceTypeBuild
+	if perTask {
+		resourceType = event.ResourceTypeTask
+	}
+	sub := event.NewGithubCheckRunSubscriptionByVersion(v.Id, resourceType, event.TriggerGithubCheckOutcome, ghSub)
+	return errors.Wrap(sub.Upsert(), "inserting GitHub check run subscription")
+}
+
 // AddBuildBreakSubscriptions will subscribe admins of a project to a version if no one
 // else would receive a build break notification
 func AddBuildBreakSubscriptions(v *model.Version, projectRef *model.ProjectRef) error {
@@ -604,11 +665,17 @@ func CreateVersionFromConfig(ctx context.Context, projectInfo *model.ProjectInfo
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to create shell version")
 	}
-	if err = verifyOrderNum(v.RevisionOrderNumber, projectInfo.Ref.Id, metadata.Revision.Revision); err != nil {
-		return nil, errors.Wrap(err, "inconsistent version order")
+	if !evergreen.IsGithubMergeQueueRequester(v.Requester) {
+		if err = verifyOrderNum(v.RevisionOrderNumber, projectInfo.Ref.Id, metadata.Revision.Revision); err != nil {
+			return nil, errors.Wrap(err, "inconsistent version order")
+		}
 	}
 
-	if projectInfo.Project == nil {
+	resolvedAxes, err := projectInfo.IntermediateProject.ResolveMatrixAxes(projectInfo.Ref.Id, v.Parameters)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving matrix axes")
+	}
+	if projectInfo.Project == nil || resolvedAxes {
 		projectInfo.Project, err = model.TranslateProject(projectInfo.IntermediateProject)
 		if err != nil {
 			return nil, errors.Wrap(err, "translating intermediate project")
@@ -694,7 +761,13 @@ func ShellVersionFromRevision(ctx context.Context, ref *model.ProjectRef, metada
 		}))
 	}
 
-	number, err := model.GetNewRevisionOrderNumber(ref.Id)
+	getOrderNumber := model.GetNewRevisionOrderNumber
+	if metadata.GithubMergeGroup.HeadSHA != "" {
+		// Merge queue versions are not mainline versions, so they should not
+		// leave gaps between the project's mainline order numbers.
+		getOrderNumber = model.GetNewMergeQueueOrderNumber
+	}
+	number, err := getOrderNumber(ref.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -756,6 +829,11 @@ func ShellVersionFromRevision(ctx context.Context, ref *model.ProjectRef, metada
 		if metadata.RemotePath != "" {
 			v.RemotePath = metadata.RemotePath
 		}
+	} else if metadata.GithubMergeGroup.HeadSHA != "" {
+		v.Id = makeVersionIdWithMergeGroup(ref.Identifier, metadata.GithubMergeGroup.HeadSHA)
+		v.Requester = evergreen.GithubMergeRequester
+		v.CreateTime = time.Now()
+		v.Message = fmt.Sprintf("GitHub Merge Queue '%s': %s", metadata.GithubMergeGroup.HeadRef, v.Message)
 	} else {
 		v.Id = makeVersionId(ref.Identifier, metadata.Revision.Revision)
 	}
@@ -775,6 +853,10 @@ func makeVersionIdWithTag(project, tag, id string) string {
 	return util.CleanName(fmt.Sprintf("%s_%s_%s", project, tag, id))
 }
 
+func makeVersionIdWithMergeGroup(project, headSHA string) string {
+	return util.CleanName(fmt.Sprintf("%s_merge_group_%s", project, headSHA))
+}
+
 // Verifies that the given revision order number is higher than the latest number stored for the project.
 func verifyOrderNum(revOrderNum int, projectId, revision string) error {
 	latest, err := model.VersionFindOne(model.VersionByMostRecentSystemRequester(projectId))
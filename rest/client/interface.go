@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/scheduler"
 )
 
 // Communicator is an interface for communicating with the API server.
@@ -57,6 +58,9 @@ type Communicator interface {
 	TerminateSpawnHost(context.Context, string) error
 	ChangeSpawnHostPassword(context.Context, string, string) error
 	ExtendSpawnHostExpiration(context.Context, string, int) error
+	// GetSpawnHostTaskEnvironment gets the events for each step of loading
+	// the environment of the task that a spawn host reproduces.
+	GetSpawnHostTaskEnvironment(context.Context, string) ([]restmodel.HostAPIEventLogEntry, error)
 	GetHosts(context.Context, restmodel.APIHostParams) ([]*restmodel.APIHost, error)
 	AttachVolume(context.Context, string, *host.VolumeAttachment) error
 	DetachVolume(context.Context, string, string) error
@@ -65,6 +69,19 @@ type Communicator interface {
 	ModifyVolume(context.Context, string, *restmodel.VolumeModifyOptions) error
 	GetVolume(context.Context, string) (*restmodel.APIVolume, error)
 	GetVolumesByUser(context.Context) ([]restmodel.APIVolume, error)
+	// CreateVolumeSnapshot creates a snapshot of a volume.
+	CreateVolumeSnapshot(context.Context, string, *restmodel.VolumeSnapshotCreateOptions) (*restmodel.APIVolumeSnapshot, error)
+	// GetVolumeSnapshotsByUser gets the volume snapshots created by the user.
+	GetVolumeSnapshotsByUser(context.Context) ([]restmodel.APIVolumeSnapshot, error)
+	// DeleteVolumeSnapshot deletes a volume snapshot.
+	DeleteVolumeSnapshot(context.Context, string) error
+	// RestoreVolumeSnapshot creates a new volume from a volume snapshot.
+	RestoreVolumeSnapshot(context.Context, string, *restmodel.VolumeSnapshotRestoreOptions) (*restmodel.APIVolume, error)
+	// GetUserSettings gets the user's settings.
+	GetUserSettings(context.Context) (*restmodel.APIUserSettings, error)
+	// UpdateUserSettings updates the user's settings. Fields that are unset
+	// are left unchanged.
+	UpdateUserSettings(context.Context, restmodel.APIUserSettings) error
 	StartHostProcesses(context.Context, []string, string, int) ([]restmodel.APIHostProcess, error)
 	GetHostProcessOutput(context.Context, []restmodel.APIHostProcess, int) ([]restmodel.APIHostProcess, error)
 	FindHostByIpAddress(context.Context, string) (*restmodel.APIHost, error)
@@ -98,6 +115,10 @@ type Communicator interface {
 	// CreateVersionFromConfig takes an evergreen config and makes runnable tasks from it
 	CreateVersionFromConfig(context.Context, string, string, bool, []byte) (*model.Version, error)
 
+	// CreatePatchFromRemoteRef creates a patch for the project from a ref in a
+	// GitHub repository, without uploading a local diff.
+	CreatePatchFromRemoteRef(context.Context, string, restmodel.APIRemotePatchRequest) (*restmodel.APIPatch, error)
+
 	// Commit Queue
 	GetCommitQueue(ctx context.Context, projectID string) (*restmodel.APICommitQueue, error)
 	DeleteCommitQueueItem(ctx context.Context, item string) error
@@ -127,6 +148,21 @@ type Communicator interface {
 	// GetHostProvisioningOptions gets the options to provision a host.
 	GetHostProvisioningOptions(ctx context.Context, hostID, hostSecret string) (*restmodel.APIHostProvisioningOptions, error)
 
+	// SendSpawnHostActivity sends the activity on a spawn host, as reported
+	// by its agent monitor.
+	SendSpawnHostActivity(ctx context.Context, hostID, hostSecret string, activity restmodel.APISpawnHostActivity) error
+
 	// CompareTasks returns the order that the given tasks would be scheduled, along with the scheduling logic.
 	CompareTasks(context.Context, []string, bool) ([]string, map[string]map[string]string, error)
+
+	// SearchTaskLogs returns a page of tasks in the given project whose logs
+	// match the search request.
+	SearchTaskLogs(context.Context, string, restmodel.APITaskLogSearchRequest) (*restmodel.APITaskLogSearchResponse, error)
+
+	// GetDistroSchedulerSnapshot returns a snapshot of the distro's scheduler
+	// settings and workload over the given lookback window.
+	GetDistroSchedulerSnapshot(context.Context, string, time.Duration) (*scheduler.SimulationSnapshot, error)
+
+	// ExportEvents returns a page of events matching the export options.
+	ExportEvents(context.Context, event.ExportOptions) (*restmodel.APIEventExportResponse, error)
 }
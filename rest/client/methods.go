@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -16,6 +18,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/utility"
 	"github.com/pkg/errors"
@@ -299,6 +302,154 @@ func (c *communicatorImpl) GetVolumesByUser(ctx context.Context) ([]model.APIVol
 	return getVolumesResp, nil
 }
 
+func (c *communicatorImpl) CreateVolumeSnapshot(ctx context.Context, volumeID string, opts *model.VolumeSnapshotCreateOptions) (*model.APIVolumeSnapshot, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("volumes/%s/snapshots", volumeID),
+	}
+
+	resp, err := c.request(ctx, info, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to snapshot volume '%s'", volumeID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "snapshotting volume '%s'", volumeID)
+	}
+
+	snapshotResp := &model.APIVolumeSnapshot{}
+	if err = utility.ReadJSON(resp.Body, snapshotResp); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return snapshotResp, nil
+}
+
+func (c *communicatorImpl) GetVolumeSnapshotsByUser(ctx context.Context) ([]model.APIVolumeSnapshot, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   "volume_snapshots",
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get volume snapshots for user '%s'", c.apiUser)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting volume snapshots for user '%s'", c.apiUser)
+	}
+
+	snapshotsResp := []model.APIVolumeSnapshot{}
+	if err = utility.ReadJSON(resp.Body, &snapshotsResp); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return snapshotsResp, nil
+}
+
+func (c *communicatorImpl) DeleteVolumeSnapshot(ctx context.Context, snapshotID string) error {
+	info := requestInfo{
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("volume_snapshots/%s", snapshotID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return errors.Wrapf(err, "sending request to delete volume snapshot '%s'", snapshotID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return util.RespErrorf(resp, "deleting volume snapshot '%s'", snapshotID)
+	}
+
+	return nil
+}
+
+func (c *communicatorImpl) RestoreVolumeSnapshot(ctx context.Context, snapshotID string, opts *model.VolumeSnapshotRestoreOptions) (*model.APIVolume, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("volume_snapshots/%s/restore", snapshotID),
+	}
+
+	resp, err := c.request(ctx, info, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to restore volume snapshot '%s'", snapshotID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "restoring volume snapshot '%s'", snapshotID)
+	}
+
+	volumeResp := &model.APIVolume{}
+	if err = utility.ReadJSON(resp.Body, volumeResp); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return volumeResp, nil
+}
+
+func (c *communicatorImpl) GetUserSettings(ctx context.Context) (*model.APIUserSettings, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   "user/settings",
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get settings for user '%s'", c.apiUser)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting settings for user '%s'", c.apiUser)
+	}
+
+	settings := &model.APIUserSettings{}
+	if err = utility.ReadJSON(resp.Body, settings); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return settings, nil
+}
+
+func (c *communicatorImpl) UpdateUserSettings(ctx context.Context, settings model.APIUserSettings) error {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   "user/settings",
+	}
+
+	resp, err := c.request(ctx, info, settings)
+	if err != nil {
+		return errors.Wrapf(err, "sending request to update settings for user '%s'", c.apiUser)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return util.RespErrorf(resp, "updating settings for user '%s'", c.apiUser)
+	}
+
+	return nil
+}
+
 func (c *communicatorImpl) StartSpawnHost(ctx context.Context, hostID string, subscriptionType string, wait bool) error {
 	info := requestInfo{
 		method: http.MethodPost,
@@ -439,6 +590,34 @@ func (c *communicatorImpl) ExtendSpawnHostExpiration(ctx context.Context, hostID
 	return nil
 }
 
+// GetSpawnHostTaskEnvironment gets the events for each step of loading the
+// environment of the task that a spawn host reproduces.
+func (c *communicatorImpl) GetSpawnHostTaskEnvironment(ctx context.Context, hostID string) ([]model.HostAPIEventLogEntry, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("hosts/%s/task_environment", hostID),
+	}
+
+	resp, err := c.request(ctx, info, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get task environment of host '%s'", hostID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting task environment of host '%s'", hostID)
+	}
+
+	events := []model.HostAPIEventLogEntry{}
+	if err = utility.ReadJSON(resp.Body, &events); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return events, nil
+}
+
 // GetHosts gets all hosts matching filters
 func (c *communicatorImpl) GetHosts(ctx context.Context, data model.APIHostParams) ([]*model.APIHost, error) {
 	info := requestInfo{
@@ -651,6 +830,48 @@ func (c *communicatorImpl) GetEvents(ctx context.Context, ts time.Time, limit in
 	return events, nil
 }
 
+func (c *communicatorImpl) ExportEvents(ctx context.Context, opts event.ExportOptions) (*restmodel.APIEventExportResponse, error) {
+	params := url.Values{}
+	if !utility.IsZeroTime(opts.StartTime) {
+		params.Set("start_time", opts.StartTime.Format(time.RFC3339Nano))
+	}
+	if !utility.IsZeroTime(opts.EndTime) {
+		params.Set("end_time", opts.EndTime.Format(time.RFC3339Nano))
+	}
+	for _, resourceType := range opts.ResourceTypes {
+		params.Add("resource_type", resourceType)
+	}
+	for _, resourceID := range opts.ResourceIDs {
+		params.Add("resource_id", resourceID)
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   "admin/events/export?" + params.Encode(),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending request to export events")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "exporting events")
+	}
+
+	export := &restmodel.APIEventExportResponse{}
+	if err = utility.ReadJSON(resp.Body, export); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return export, nil
+}
+
 func (c *communicatorImpl) RevertSettings(ctx context.Context, guid string) error {
 	info := requestInfo{
 		method: http.MethodPost,
@@ -1145,6 +1366,31 @@ func (c *communicatorImpl) EnqueueItem(ctx context.Context, patchID string, enqu
 	return positionResp.Position, nil
 }
 
+func (c *communicatorImpl) CreatePatchFromRemoteRef(ctx context.Context, projectID string, req model.APIRemotePatchRequest) (*model.APIPatch, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/projects/%s/patches", projectID),
+	}
+	resp, err := c.request(ctx, info, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to create patch for project '%s'", projectID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, util.RespErrorf(resp, "creating patch for project '%s'", projectID)
+	}
+
+	newPatch := &model.APIPatch{}
+	if err = utility.ReadJSON(resp.Body, newPatch); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return newPatch, nil
+}
+
 func (c *communicatorImpl) CreatePatchForMerge(ctx context.Context, patchID, commitMessage string) (*model.APIPatch, error) {
 	info := requestInfo{
 		method: http.MethodPut,
@@ -1541,6 +1787,32 @@ func (c *communicatorImpl) GetHostProvisioningOptions(ctx context.Context, hostI
 	return &opts, nil
 }
 
+func (c *communicatorImpl) SendSpawnHostActivity(ctx context.Context, hostID, hostSecret string, activity restmodel.APISpawnHostActivity) error {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/hosts/%s/spawn_host_activity", hostID),
+	}
+	r, err := c.createRequest(info, activity)
+	if err != nil {
+		return errors.Wrap(err, "creating request")
+	}
+	r.Header.Add(evergreen.HostHeader, hostID)
+	r.Header.Add(evergreen.HostSecretHeader, hostSecret)
+	resp, err := utility.RetryRequest(ctx, r, utility.RetryOptions{
+		MaxAttempts: c.maxAttempts,
+		MinDelay:    c.timeoutStart,
+		MaxDelay:    c.timeoutMax,
+	})
+	if err != nil {
+		return util.RespErrorf(resp, "sending request to report activity for host '%s'", hostID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return util.RespErrorf(resp, "reporting spawn host activity")
+	}
+	return nil
+}
+
 func (c *communicatorImpl) CompareTasks(ctx context.Context, tasks []string, useLegacy bool) ([]string, map[string]map[string]string, error) {
 	info := requestInfo{
 		method: http.MethodPost,
@@ -1570,6 +1842,58 @@ func (c *communicatorImpl) CompareTasks(ctx context.Context, tasks []string, use
 	return results.Order, results.Logic, nil
 }
 
+func (c *communicatorImpl) SearchTaskLogs(ctx context.Context, projectID string, req restmodel.APITaskLogSearchRequest) (*restmodel.APITaskLogSearchResponse, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("projects/%s/logs/search", projectID),
+	}
+
+	resp, err := c.request(ctx, info, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to search task logs for project '%s'", projectID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "searching task logs for project '%s'", projectID)
+	}
+
+	results := &restmodel.APITaskLogSearchResponse{}
+	if err = utility.ReadJSON(resp.Body, results); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return results, nil
+}
+
+func (c *communicatorImpl) GetDistroSchedulerSnapshot(ctx context.Context, distroID string, lookback time.Duration) (*scheduler.SimulationSnapshot, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("distros/%s/scheduler_snapshot?lookback=%s", distroID, lookback),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get scheduler snapshot for distro '%s'", distroID)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting scheduler snapshot for distro '%s'", distroID)
+	}
+
+	snapshot := &scheduler.SimulationSnapshot{}
+	if err = utility.ReadJSON(resp.Body, snapshot); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+
+	return snapshot, nil
+}
+
 // FindHostByIpAddress queries the database for the host with ip matching the ip address
 func (c *communicatorImpl) FindHostByIpAddress(ctx context.Context, ip string) (*model.APIHost, error) {
 	info := requestInfo{
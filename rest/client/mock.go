@@ -101,6 +101,10 @@ func (*Mock) ExtendSpawnHostExpiration(context.Context, string, int) error {
 	return errors.New("(*Mock) ExtendSpawnHostExpiration is not implemented")
 }
 
+func (*Mock) GetSpawnHostTaskEnvironment(context.Context, string) ([]model.HostAPIEventLogEntry, error) {
+	return nil, errors.New("(*Mock) GetSpawnHostTaskEnvironment is not implemented")
+}
+
 func (*Mock) AttachVolume(context.Context, string, *host.VolumeAttachment) error {
 	return errors.New("(*Mock) AttachVolume is not implemented")
 }
@@ -125,6 +129,30 @@ func (*Mock) GetVolumesByUser(context.Context) ([]model.APIVolume, error) {
 	return nil, errors.New("(*Mock) GetVolumesByUser is not implemented")
 }
 
+func (*Mock) CreateVolumeSnapshot(context.Context, string, *model.VolumeSnapshotCreateOptions) (*model.APIVolumeSnapshot, error) {
+	return nil, errors.New("(*Mock) CreateVolumeSnapshot is not implemented")
+}
+
+func (*Mock) GetVolumeSnapshotsByUser(context.Context) ([]model.APIVolumeSnapshot, error) {
+	return nil, errors.New("(*Mock) GetVolumeSnapshotsByUser is not implemented")
+}
+
+func (*Mock) DeleteVolumeSnapshot(context.Context, string) error {
+	return errors.New("(*Mock) DeleteVolumeSnapshot is not implemented")
+}
+
+func (*Mock) RestoreVolumeSnapshot(context.Context, string, *model.VolumeSnapshotRestoreOptions) (*model.APIVolume, error) {
+	return nil, errors.New("(*Mock) RestoreVolumeSnapshot is not implemented")
+}
+
+func (*Mock) GetUserSettings(context.Context) (*model.APIUserSettings, error) {
+	return nil, errors.New("(*Mock) GetUserSettings is not implemented")
+}
+
+func (*Mock) UpdateUserSettings(context.Context, model.APIUserSettings) error {
+	return errors.New("(*Mock) UpdateUserSettings is not implemented")
+}
+
 func (c *Mock) GetVolume(context.Context, string) (*model.APIVolume, error) {
 	return nil, errors.New("(*Mock) GetVolume is not implemented")
 }
@@ -284,6 +312,10 @@ func (c *Mock) EnqueueItem(ctx context.Context, patchID string, force bool) (int
 	return 0, nil
 }
 
+func (c *Mock) CreatePatchFromRemoteRef(ctx context.Context, projectID string, req model.APIRemotePatchRequest) (*model.APIPatch, error) {
+	return nil, nil
+}
+
 func (c *Mock) CreatePatchForMerge(ctx context.Context, patchID, commitMessage string) (*model.APIPatch, error) {
 	return nil, nil
 }
@@ -341,6 +373,10 @@ func (c *Mock) GetClientURLs(context.Context, string) ([]string, error) {
 	return []string{"https://example.com"}, nil
 }
 
+func (c *Mock) SendSpawnHostActivity(context.Context, string, string, restmodel.APISpawnHostActivity) error {
+	return nil
+}
+
 func (c *Mock) GetHostProvisioningOptions(context.Context, string, string) (*restmodel.APIHostProvisioningOptions, error) {
 	return &restmodel.APIHostProvisioningOptions{
 		Content: "echo hello world",
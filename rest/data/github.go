@@ -50,3 +50,25 @@ func (gc *DBGithubConnector) AddCommentToPR(ctx context.Context, owner, repo str
 	err = thirdparty.PostCommentToPullRequest(ctxWithCancel, ghToken, owner, repo, prNum, comment)
 	return errors.Wrap(err, "posting GitHub comment with GitHub API")
 }
+
+// GetGitHubUserPermissionLevel returns the user's permission level on the
+// repository, which is one of "admin", "write", "read", or "none".
+func (gc *DBGithubConnector) GetGitHubUserPermissionLevel(ctx context.Context, args UserRepoInfo) (string, error) {
+	conf, err := evergreen.GetConfig()
+	if err != nil {
+		return "", errors.Wrap(err, "getting admin settings")
+	}
+	ghToken, err := conf.GetGithubOauthToken()
+	if err != nil {
+		return "", errors.Wrap(err, "getting GitHub OAuth token from admin settings")
+	}
+
+	ctxWithCancel, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	permission, err := thirdparty.GitHubUserPermissionLevel(ctxWithCancel, ghToken, args.Owner, args.Repo, args.Username)
+	if err != nil {
+		return "", errors.Wrap(err, "getting GitHub user permissions")
+	}
+
+	return permission, nil
+}
@@ -238,10 +238,12 @@ func makeSpawnOptions(options *restmodel.HostRequestOptions, user *user.DBUser)
 		Expiration:            options.Expiration,
 		UseProjectSetupScript: options.UseProjectSetupScript,
 		ProvisionOptions: &host.ProvisionOptions{
-			TaskId:      options.TaskID,
-			TaskSync:    options.TaskSync,
-			SetupScript: options.SetupScript,
-			OwnerId:     user.Id,
+			TaskId:        options.TaskID,
+			TaskSync:      options.TaskSync,
+			ReproduceTask: options.ReproduceTask,
+			RunTaskSetup:  options.RunTaskSetup,
+			SetupScript:   options.SetupScript,
+			OwnerId:       user.Id,
 		},
 	}
 	return &spawnOptions, nil
@@ -24,4 +24,5 @@ type Connector interface {
 	AddPatchForPR(context.Context, model.ProjectRef, int, []restModel.APIModule, string) (*patch.Patch, error)
 	AddCommentToPR(context.Context, string, string, int, string) error
 	IsAuthorizedToPatchAndMerge(context.Context, *evergreen.Settings, UserRepoInfo) (bool, error)
+	GetGitHubUserPermissionLevel(context.Context, UserRepoInfo) (string, error)
 }
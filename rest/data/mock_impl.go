@@ -61,6 +61,14 @@ func (pc *MockGitHubConnectorImpl) IsAuthorizedToPatchAndMerge(ctx context.Conte
 	return hasPermission, nil
 }
 
+func (pc *MockGitHubConnectorImpl) GetGitHubUserPermissionLevel(ctx context.Context, args UserRepoInfo) (string, error) {
+	permission, ok := pc.UserPermissions[args]
+	if !ok {
+		return "none", nil
+	}
+	return permission, nil
+}
+
 func (pc *MockGitHubConnectorImpl) GetProjectFromFile(ctx context.Context, pRef model.ProjectRef, file string, token string) (model.ProjectInfo, error) {
 	config := `
 buildvariants:
@@ -13,6 +13,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/units"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/google/go-github/v34/github"
@@ -82,6 +83,7 @@ func FindPatchById(patchId string) (*restModel.APIPatch, error) {
 		IncludeChildPatches:        true,
 		IncludeProjectIdentifier:   true,
 		IncludeCommitQueuePosition: true,
+		IncludeBasePatches:         true,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "converting patch '%s' to API model", p.Id.Hex())
@@ -259,3 +261,147 @@ func verifyPullRequestEventForAbort(event *github.PullRequestEvent) (string, str
 
 	return baseRepo[0], baseRepo[1], nil
 }
+
+// RemotePatchOptions describe a patch built from a ref in a GitHub repository
+// rather than from a local diff.
+type RemotePatchOptions struct {
+	// Project is the ID or identifier of the project to patch.
+	Project string
+	// Author is the user creating the patch.
+	Author string
+	// Ref is the branch, tag, or commit to patch.
+	Ref string
+	// Owner and Repo identify the repository that contains Ref, which can
+	// be a fork of the project's repository. They default to the project's
+	// repository.
+	Owner       string
+	Repo        string
+	Description string
+	Alias       string
+	Variants    []string
+	Tasks       []string
+	Parameters  []patch.Parameter
+	Finalize    bool
+}
+
+// CreatePatchFromRemoteRef creates a patch from the changes between a ref in
+// a GitHub repository and its merge base with the project's tracked branch.
+func CreatePatchFromRemoteRef(ctx context.Context, env evergreen.Environment, opts RemotePatchOptions) (*restModel.APIPatch, error) {
+	if opts.Ref == "" {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a ref to patch",
+		}
+	}
+	pRef, err := model.FindMergedProjectRef(opts.Project, "", true)
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    errors.Wrapf(err, "finding project '%s'", opts.Project).Error(),
+		}
+	}
+	if pRef == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", opts.Project),
+		}
+	}
+	if !pRef.Enabled || pRef.IsPatchingDisabled() {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "patching is disabled",
+		}
+	}
+
+	owner, repo, head := remotePatchHead(pRef, opts.Owner, opts.Repo, opts.Ref)
+	token, err := env.Settings().GetGithubOauthToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting GitHub OAuth token")
+	}
+	mergeBase, err := thirdparty.GetGithubMergeBaseRevision(ctx, token, pRef.Owner, pRef.Repo, pRef.Branch, head)
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrapf(err, "finding merge base of '%s/%s' ref '%s' with branch '%s'", owner, repo, opts.Ref, pRef.Branch).Error(),
+		}
+	}
+	diff, err := thirdparty.GetGithubCompareDiff(ctx, token, pRef.Owner, pRef.Repo, mergeBase, head)
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrapf(err, "getting diff of '%s/%s' ref '%s'", owner, repo, opts.Ref).Error(),
+		}
+	}
+	if len(diff) > patch.SizeLimit {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "patch is too large",
+		}
+	}
+
+	description := opts.Description
+	if description == "" {
+		description = fmt.Sprintf("'%s/%s' at '%s'", owner, repo, opts.Ref)
+	}
+	intent, err := patch.NewCliIntent(patch.CLIIntentParams{
+		User:         opts.Author,
+		Project:      pRef.Id,
+		BaseGitHash:  mergeBase,
+		PatchContent: diff,
+		Description:  description,
+		Finalize:     opts.Finalize,
+		Parameters:   opts.Parameters,
+		Variants:     opts.Variants,
+		Tasks:        opts.Tasks,
+		Alias:        opts.Alias,
+	})
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "creating patch intent").Error(),
+		}
+	}
+	if err = intent.Insert(); err != nil {
+		return nil, errors.Wrap(err, "inserting patch intent")
+	}
+
+	patchID := mgobson.NewObjectId()
+	grip.Info(message.Fields{
+		"operation":  "patch creation",
+		"message":    "creating patch",
+		"from":       "remote ref",
+		"patch_id":   patchID.Hex(),
+		"project":    pRef.Id,
+		"repo":       fmt.Sprintf("%s/%s", owner, repo),
+		"ref":        opts.Ref,
+		"merge_base": mergeBase,
+		"finalizing": opts.Finalize,
+	})
+	job := units.NewPatchIntentProcessor(env, patchID, intent)
+	job.Run(ctx)
+	if err = job.Error(); err != nil {
+		return nil, errors.Wrap(err, "processing patch")
+	}
+
+	return FindPatchById(patchID.Hex())
+}
+
+// remotePatchHead returns the repository containing the ref and the head to
+// compare against the project's repository, using GitHub's cross-repository
+// syntax if the ref is in a fork.
+func remotePatchHead(pRef *model.ProjectRef, owner, repo, ref string) (string, string, string) {
+	if owner == "" {
+		owner = pRef.Owner
+	}
+	if repo == "" {
+		repo = pRef.Repo
+	}
+	switch {
+	case repo != pRef.Repo:
+		return owner, repo, fmt.Sprintf("%s:%s:%s", owner, repo, ref)
+	case owner != pRef.Owner:
+		return owner, repo, fmt.Sprintf("%s:%s", owner, ref)
+	default:
+		return owner, repo, ref
+	}
+}
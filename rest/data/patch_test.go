@@ -15,6 +15,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/google/go-github/v34/github"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -508,3 +509,22 @@ func (s *PatchConnectorFetchByUserSuite) TestFetchKeyOutOfBound() {
 	s.NoError(err)
 	s.Len(patches, 0)
 }
+
+func TestRemotePatchHead(t *testing.T) {
+	pRef := &dbModel.ProjectRef{Owner: "evergreen-ci", Repo: "evergreen"}
+
+	owner, repo, head := remotePatchHead(pRef, "", "", "feature")
+	assert.Equal(t, "evergreen-ci", owner)
+	assert.Equal(t, "evergreen", repo)
+	assert.Equal(t, "feature", head)
+
+	owner, repo, head = remotePatchHead(pRef, "someone", "evergreen", "feature")
+	assert.Equal(t, "someone", owner)
+	assert.Equal(t, "evergreen", repo)
+	assert.Equal(t, "someone:feature", head)
+
+	owner, repo, head = remotePatchHead(pRef, "someone", "evergreen-fork", "feature")
+	assert.Equal(t, "someone", owner)
+	assert.Equal(t, "evergreen-fork", repo)
+	assert.Equal(t, "someone:evergreen-fork:feature", head)
+}
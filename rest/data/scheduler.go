@@ -1,6 +1,9 @@
 package data
 
 import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/scheduler"
@@ -58,3 +61,13 @@ func CompareTasks(taskIds []string, useLegacy bool) ([]string, map[string]map[st
 	}
 	return prioritizedIds, logic, nil
 }
+
+// GetSchedulerSnapshot returns a snapshot of the distro's scheduler settings
+// and workload over the given lookback window for offline simulation.
+func GetSchedulerSnapshot(distroID string, settings *evergreen.Settings, lookback time.Duration) (*scheduler.SimulationSnapshot, error) {
+	snapshot, err := scheduler.MakeSimulationSnapshot(distroID, settings, lookback)
+	if err != nil {
+		return nil, errors.Wrapf(err, "making scheduler snapshot for distro '%s'", distroID)
+	}
+	return snapshot, nil
+}
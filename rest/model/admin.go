@@ -44,6 +44,10 @@ func NewConfigModel() *APIAdminSettings {
 		Ui:                &APIUIConfig{},
 		Spawnhost:         &APISpawnHostConfig{},
 		Tracer:            &APITracerSettings{},
+		EventRetention:    &APIEventRetentionConfig{},
+		Provenance:        &APIProvenanceConfig{},
+		TestResults:       &APITestResultsConfig{},
+		GithubApp:         &APIGithubAppConfig{},
 	}
 }
 
@@ -95,6 +99,10 @@ type APIAdminSettings struct {
 	Ui                  *APIUIConfig                      `json:"ui,omitempty"`
 	Spawnhost           *APISpawnHostConfig               `json:"spawnhost,omitempty"`
 	Tracer              *APITracerSettings                `json:"tracer,omitempty"`
+	EventRetention      *APIEventRetentionConfig          `json:"event_retention,omitempty"`
+	Provenance          *APIProvenanceConfig              `json:"provenance,omitempty"`
+	TestResults         *APITestResultsConfig             `json:"test_results,omitempty"`
+	GithubApp           *APIGithubAppConfig               `json:"github_app,omitempty"`
 	ShutdownWaitSeconds *int                              `json:"shutdown_wait_seconds,omitempty"`
 }
 
@@ -2834,6 +2842,134 @@ func (c *APITracerSettings) ToService() (interface{}, error) {
 	return config, nil
 }
 
+type APIEventRetentionConfig struct {
+	BucketType *string                   `json:"bucket_type"`
+	Bucket     *string                   `json:"bucket"`
+	Prefix     *string                   `json:"prefix"`
+	Key        *string                   `json:"key"`
+	Secret     *string                   `json:"secret"`
+	Policies   []APIEventRetentionPolicy `json:"policies"`
+}
+
+type APIEventRetentionPolicy struct {
+	ResourceType  *string `json:"resource_type"`
+	RetentionDays int     `json:"retention_days"`
+}
+
+func (c *APIEventRetentionConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.EventRetentionConfig:
+		c.BucketType = utility.ToStringPtr(v.BucketType)
+		c.Bucket = utility.ToStringPtr(v.Bucket)
+		c.Prefix = utility.ToStringPtr(v.Prefix)
+		c.Key = utility.ToStringPtr(v.Key)
+		c.Secret = utility.ToStringPtr(v.Secret)
+		c.Policies = []APIEventRetentionPolicy{}
+		for _, p := range v.Policies {
+			c.Policies = append(c.Policies, APIEventRetentionPolicy{
+				ResourceType:  utility.ToStringPtr(p.ResourceType),
+				RetentionDays: p.RetentionDays,
+			})
+		}
+	default:
+		return errors.Errorf("programmatic error: expected event retention config but got type %T", h)
+	}
+	return nil
+}
+
+func (c *APIEventRetentionConfig) ToService() (interface{}, error) {
+	config := evergreen.EventRetentionConfig{
+		BucketType: utility.FromStringPtr(c.BucketType),
+		Bucket:     utility.FromStringPtr(c.Bucket),
+		Prefix:     utility.FromStringPtr(c.Prefix),
+		Key:        utility.FromStringPtr(c.Key),
+		Secret:     utility.FromStringPtr(c.Secret),
+	}
+	for _, p := range c.Policies {
+		config.Policies = append(config.Policies, evergreen.EventRetentionPolicy{
+			ResourceType:  utility.FromStringPtr(p.ResourceType),
+			RetentionDays: p.RetentionDays,
+		})
+	}
+
+	return config, nil
+}
+
+type APIProvenanceConfig struct {
+	SigningKey *string `json:"signing_key"`
+	KeyID      *string `json:"key_id"`
+	BuilderID  *string `json:"builder_id"`
+}
+
+func (c *APIProvenanceConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.ProvenanceConfig:
+		c.SigningKey = utility.ToStringPtr(v.SigningKey)
+		c.KeyID = utility.ToStringPtr(v.KeyID)
+		c.BuilderID = utility.ToStringPtr(v.BuilderID)
+	default:
+		return errors.Errorf("programmatic error: expected provenance config but got type %T", h)
+	}
+	return nil
+}
+
+func (c *APIProvenanceConfig) ToService() (interface{}, error) {
+	return evergreen.ProvenanceConfig{
+		SigningKey: utility.FromStringPtr(c.SigningKey),
+		KeyID:      utility.FromStringPtr(c.KeyID),
+		BuilderID:  utility.FromStringPtr(c.BuilderID),
+	}, nil
+}
+
+type APIGithubAppConfig struct {
+	AppID      int64   `json:"app_id"`
+	PrivateKey *string `json:"private_key"`
+}
+
+func (c *APIGithubAppConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.GithubAppConfig:
+		c.AppID = v.AppID
+		c.PrivateKey = utility.ToStringPtr(v.PrivateKey)
+	default:
+		return errors.Errorf("programmatic error: expected GitHub App config but got type %T", h)
+	}
+	return nil
+}
+
+func (c *APIGithubAppConfig) ToService() (interface{}, error) {
+	return evergreen.GithubAppConfig{
+		AppID:      c.AppID,
+		PrivateKey: utility.FromStringPtr(c.PrivateKey),
+	}, nil
+}
+
+type APITestResultsConfig struct {
+	Service           *string `json:"service"`
+	BackfillFromCedar bool    `json:"backfill_from_cedar"`
+	BackfillBatchSize int     `json:"backfill_batch_size"`
+}
+
+func (c *APITestResultsConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.TestResultsConfig:
+		c.Service = utility.ToStringPtr(v.Service)
+		c.BackfillFromCedar = v.BackfillFromCedar
+		c.BackfillBatchSize = v.BackfillBatchSize
+	default:
+		return errors.Errorf("programmatic error: expected test results config but got type %T", h)
+	}
+	return nil
+}
+
+func (c *APITestResultsConfig) ToService() (interface{}, error) {
+	return evergreen.TestResultsConfig{
+		Service:           utility.FromStringPtr(c.Service),
+		BackfillFromCedar: c.BackfillFromCedar,
+		BackfillBatchSize: c.BackfillBatchSize,
+	}, nil
+}
+
 type APIDataPipesConfig struct {
 	Host         *string `json:"host"`
 	Region       *string `json:"region"`
@@ -228,6 +228,12 @@ func TestModelConversion(t *testing.T) {
 	assert.Equal(testSettings.Spawnhost.UnexpirableVolumesPerUser, *apiSettings.Spawnhost.UnexpirableVolumesPerUser)
 	assert.Equal(testSettings.Tracer.Enabled, *apiSettings.Tracer.Enabled)
 	assert.Equal(testSettings.Tracer.CollectorEndpoint, *apiSettings.Tracer.CollectorEndpoint)
+	assert.Equal(testSettings.EventRetention.Bucket, utility.FromStringPtr(apiSettings.EventRetention.Bucket))
+	require.Len(apiSettings.EventRetention.Policies, len(testSettings.EventRetention.Policies))
+	for i, policy := range testSettings.EventRetention.Policies {
+		assert.Equal(policy.ResourceType, utility.FromStringPtr(apiSettings.EventRetention.Policies[i].ResourceType))
+		assert.Equal(policy.RetentionDays, apiSettings.EventRetention.Policies[i].RetentionDays)
+	}
 
 	// test converting from the API model back to a DB model
 	dbInterface, err := apiSettings.ToService()
@@ -332,6 +338,7 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Spawnhost.UnexpirableVolumesPerUser, dbSettings.Spawnhost.UnexpirableVolumesPerUser)
 	assert.EqualValues(testSettings.Tracer.Enabled, dbSettings.Tracer.Enabled)
 	assert.EqualValues(testSettings.Tracer.CollectorEndpoint, dbSettings.Tracer.CollectorEndpoint)
+	assert.EqualValues(testSettings.EventRetention, dbSettings.EventRetention)
 }
 
 func TestRestart(t *testing.T) {
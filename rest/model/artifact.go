@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/evergreen/model/artifact"
 	"github.com/evergreen-ci/utility"
 )
@@ -61,3 +63,41 @@ func (e *APIEntry) ToService() artifact.Entry {
 
 	return entry
 }
+
+// APIProvenance is a signed provenance attestation for artifacts produced by
+// a task.
+type APIProvenance struct {
+	TaskID     *string                      `json:"task_id"`
+	ProjectID  *string                      `json:"project_id"`
+	Execution  int                          `json:"execution"`
+	Subjects   []artifact.ProvenanceSubject `json:"subjects"`
+	Envelope   artifact.ProvenanceEnvelope  `json:"envelope"`
+	CreateTime *time.Time                   `json:"create_time"`
+	// Verified is whether the envelope's signature is valid for the app
+	// server's current signing key.
+	Verified bool `json:"verified"`
+}
+
+func (p *APIProvenance) BuildFromService(v artifact.Provenance) {
+	p.TaskID = utility.ToStringPtr(v.TaskID)
+	p.ProjectID = utility.ToStringPtr(v.ProjectID)
+	p.Execution = v.Execution
+	p.Subjects = v.Subjects
+	p.Envelope = v.Envelope
+	p.CreateTime = ToTimePtr(v.CreateTime)
+}
+
+// APIProvenanceVerification is the result of verifying an artifact digest
+// against recorded provenance.
+type APIProvenanceVerification struct {
+	Algorithm *string `json:"algorithm"`
+	Digest    *string `json:"digest"`
+	// Verified is whether at least one attestation for the digest has a
+	// valid signature.
+	Verified bool `json:"verified"`
+	// KeyID and PublicKey identify the key that signatures were checked
+	// against, so that the attestations can also be verified independently.
+	KeyID        *string         `json:"key_id"`
+	PublicKey    *string         `json:"public_key"`
+	Attestations []APIProvenance `json:"attestations"`
+}
@@ -13,11 +13,12 @@ import (
 )
 
 var (
-	commitOrigin  = "commit"
-	patchOrigin   = "patch"
-	triggerOrigin = "trigger"
-	triggerAdHoc  = "ad_hoc"
-	gitTagOrigin  = "git_tag"
+	commitOrigin     = "commit"
+	patchOrigin      = "patch"
+	triggerOrigin    = "trigger"
+	triggerAdHoc     = "ad_hoc"
+	gitTagOrigin     = "git_tag"
+	mergeQueueOrigin = "github_merge_queue"
 )
 
 // APIBuild is the model to be returned by the API whenever builds are fetched.
@@ -112,6 +113,8 @@ func (apiBuild *APIBuild) BuildFromService(v build.Build, pp *model.ParserProjec
 		origin = triggerAdHoc
 	case evergreen.GitTagRequester:
 		origin = gitTagOrigin
+	case evergreen.GithubMergeRequester:
+		origin = mergeQueueOrigin
 	}
 	apiBuild.Origin = utility.ToStringPtr(origin)
 	if v.Project != "" {
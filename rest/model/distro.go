@@ -1,9 +1,12 @@
 package model
 
 import (
+	"time"
+
 	"github.com/evergreen-ci/birch"
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/utility"
 )
 
@@ -61,13 +64,16 @@ func (s *APIPlannerSettings) ToService() distro.PlannerSettings {
 // APIHostAllocatorSettings is the model to be returned by the API whenever distro.HostAllocatorSettings are fetched
 
 type APIHostAllocatorSettings struct {
-	Version                *string     `json:"version"`
-	MinimumHosts           int         `json:"minimum_hosts"`
-	MaximumHosts           int         `json:"maximum_hosts"`
-	RoundingRule           *string     `json:"rounding_rule"`
-	FeedbackRule           *string     `json:"feedback_rule"`
-	HostsOverallocatedRule *string     `json:"hosts_overallocated_rule"`
-	AcceptableHostIdleTime APIDuration `json:"acceptable_host_idle_time"`
+	Version                *string             `json:"version"`
+	MinimumHosts           int                 `json:"minimum_hosts"`
+	MaximumHosts           int                 `json:"maximum_hosts"`
+	RoundingRule           *string             `json:"rounding_rule"`
+	FeedbackRule           *string             `json:"feedback_rule"`
+	HostsOverallocatedRule *string             `json:"hosts_overallocated_rule"`
+	AcceptableHostIdleTime APIDuration         `json:"acceptable_host_idle_time"`
+	WarmPool               APIWarmPoolSettings `json:"warm_pool"`
+	ForecastWindow         APIDuration         `json:"forecast_window"`
+	ForecastDryRun         bool                `json:"forecast_dry_run"`
 }
 
 // BuildFromService converts from service level distro.HostAllocatorSettings to an APIHostAllocatorSettings
@@ -83,7 +89,9 @@ func (s *APIHostAllocatorSettings) BuildFromService(settings distro.HostAllocato
 	s.RoundingRule = utility.ToStringPtr(settings.RoundingRule)
 	s.FeedbackRule = utility.ToStringPtr(settings.FeedbackRule)
 	s.HostsOverallocatedRule = utility.ToStringPtr(settings.HostsOverallocatedRule)
-
+	s.WarmPool.BuildFromService(settings.WarmPool)
+	s.ForecastWindow = NewAPIDuration(settings.ForecastWindow)
+	s.ForecastDryRun = settings.ForecastDryRun
 }
 
 // ToService returns a service layer distro.HostAllocatorSettings using the data from APIHostAllocatorSettings
@@ -100,7 +108,67 @@ func (s *APIHostAllocatorSettings) ToService() distro.HostAllocatorSettings {
 	settings.RoundingRule = utility.FromStringPtr(s.RoundingRule)
 	settings.FeedbackRule = utility.FromStringPtr(s.FeedbackRule)
 	settings.HostsOverallocatedRule = utility.FromStringPtr(s.HostsOverallocatedRule)
+	settings.WarmPool = s.WarmPool.ToService()
+	settings.ForecastWindow = s.ForecastWindow.ToDuration()
+	settings.ForecastDryRun = s.ForecastDryRun
+
+	return settings
+}
 
+// APIWarmPoolSettings is the pool of free hosts that a distro keeps ready.
+type APIWarmPoolSettings struct {
+	MinReadyHosts int                        `json:"min_ready_hosts"`
+	Schedule      []APIWarmPoolScheduleEntry `json:"schedule"`
+	TimeZone      *string                    `json:"time_zone"`
+}
+
+// APIWarmPoolScheduleEntry is a weekly window during which a distro keeps a
+// given number of free hosts ready.
+type APIWarmPoolScheduleEntry struct {
+	// Weekdays are the names of the days of the week on which the window
+	// applies.
+	Weekdays   []string `json:"weekdays"`
+	StartTime  *string  `json:"start_time"`
+	EndTime    *string  `json:"end_time"`
+	ReadyHosts int      `json:"ready_hosts"`
+}
+
+func (s *APIWarmPoolSettings) BuildFromService(settings distro.WarmPoolSettings) {
+	s.MinReadyHosts = settings.MinReadyHosts
+	s.TimeZone = utility.ToStringPtr(settings.TimeZone)
+	s.Schedule = nil
+	for _, entry := range settings.Schedule {
+		s.Schedule = append(s.Schedule, APIWarmPoolScheduleEntry{
+			Weekdays:   user.FormatWeekdays(entry.Weekdays),
+			StartTime:  utility.ToStringPtr(entry.StartTime),
+			EndTime:    utility.ToStringPtr(entry.EndTime),
+			ReadyHosts: entry.ReadyHosts,
+		})
+	}
+}
+
+func (s *APIWarmPoolSettings) ToService() distro.WarmPoolSettings {
+	settings := distro.WarmPoolSettings{
+		MinReadyHosts: s.MinReadyHosts,
+		TimeZone:      utility.FromStringPtr(s.TimeZone),
+	}
+	for _, entry := range s.Schedule {
+		serviceEntry := distro.WarmPoolScheduleEntry{
+			StartTime:  utility.FromStringPtr(entry.StartTime),
+			EndTime:    utility.FromStringPtr(entry.EndTime),
+			ReadyHosts: entry.ReadyHosts,
+		}
+		for _, name := range entry.Weekdays {
+			day, err := user.ParseWeekday(name)
+			if err != nil {
+				// Keep the unrecognized day so that distro validation
+				// rejects it rather than silently ignoring it.
+				day = -1
+			}
+			serviceEntry.Weekdays = append(serviceEntry.Weekdays, day)
+		}
+		settings.Schedule = append(settings.Schedule, serviceEntry)
+	}
 	return settings
 }
 
@@ -329,40 +397,58 @@ func (s *APIIceCreamSettings) ToService() distro.IceCreamSettings {
 	}
 }
 
+type APISpawnHostAutoStopSettings struct {
+	IdleTimeoutMins int     `json:"idle_timeout_mins"`
+	LoadThreshold   float64 `json:"load_threshold"`
+}
+
+func (s *APISpawnHostAutoStopSettings) BuildFromService(settings distro.SpawnHostAutoStopSettings) {
+	s.IdleTimeoutMins = int(settings.IdleTimeout / time.Minute)
+	s.LoadThreshold = settings.LoadThreshold
+}
+
+func (s *APISpawnHostAutoStopSettings) ToService() distro.SpawnHostAutoStopSettings {
+	return distro.SpawnHostAutoStopSettings{
+		IdleTimeout:   time.Duration(s.IdleTimeoutMins) * time.Minute,
+		LoadThreshold: s.LoadThreshold,
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 //
 // APIDistro is the model to be returned by the API whenever distros are fetched
 
 type APIDistro struct {
-	Name                  *string                  `json:"name"`
-	Aliases               []string                 `json:"aliases"`
-	UserSpawnAllowed      bool                     `json:"user_spawn_allowed"`
-	Provider              *string                  `json:"provider"`
-	ProviderSettingsList  []*birch.Document        `json:"provider_settings"`
-	Arch                  *string                  `json:"arch"`
-	WorkDir               *string                  `json:"work_dir"`
-	SetupAsSudo           bool                     `json:"setup_as_sudo"`
-	Setup                 *string                  `json:"setup"`
-	User                  *string                  `json:"user"`
-	BootstrapSettings     APIBootstrapSettings     `json:"bootstrap_settings"`
-	CloneMethod           *string                  `json:"clone_method"`
-	SSHKey                *string                  `json:"ssh_key"`
-	SSHOptions            []string                 `json:"ssh_options"`
-	AuthorizedKeysFile    *string                  `json:"authorized_keys_file"`
-	Expansions            []APIExpansion           `json:"expansions"`
-	Disabled              bool                     `json:"disabled"`
-	ContainerPool         *string                  `json:"container_pool"`
-	FinderSettings        APIFinderSettings        `json:"finder_settings"`
-	PlannerSettings       APIPlannerSettings       `json:"planner_settings"`
-	DispatcherSettings    APIDispatcherSettings    `json:"dispatcher_settings"`
-	HostAllocatorSettings APIHostAllocatorSettings `json:"host_allocator_settings"`
-	DisableShallowClone   bool                     `json:"disable_shallow_clone"`
-	HomeVolumeSettings    APIHomeVolumeSettings    `json:"home_volume_settings"`
-	IcecreamSettings      APIIceCreamSettings      `json:"icecream_settings"`
-	IsVirtualWorkstation  bool                     `json:"is_virtual_workstation"`
-	IsCluster             bool                     `json:"is_cluster"`
-	Note                  *string                  `json:"note"`
-	ValidProjects         []*string                `json:"valid_projects"`
+	Name                  *string                      `json:"name"`
+	Aliases               []string                     `json:"aliases"`
+	UserSpawnAllowed      bool                         `json:"user_spawn_allowed"`
+	Provider              *string                      `json:"provider"`
+	ProviderSettingsList  []*birch.Document            `json:"provider_settings"`
+	Arch                  *string                      `json:"arch"`
+	WorkDir               *string                      `json:"work_dir"`
+	SetupAsSudo           bool                         `json:"setup_as_sudo"`
+	Setup                 *string                      `json:"setup"`
+	User                  *string                      `json:"user"`
+	BootstrapSettings     APIBootstrapSettings         `json:"bootstrap_settings"`
+	CloneMethod           *string                      `json:"clone_method"`
+	SSHKey                *string                      `json:"ssh_key"`
+	SSHOptions            []string                     `json:"ssh_options"`
+	AuthorizedKeysFile    *string                      `json:"authorized_keys_file"`
+	Expansions            []APIExpansion               `json:"expansions"`
+	Disabled              bool                         `json:"disabled"`
+	ContainerPool         *string                      `json:"container_pool"`
+	FinderSettings        APIFinderSettings            `json:"finder_settings"`
+	PlannerSettings       APIPlannerSettings           `json:"planner_settings"`
+	DispatcherSettings    APIDispatcherSettings        `json:"dispatcher_settings"`
+	HostAllocatorSettings APIHostAllocatorSettings     `json:"host_allocator_settings"`
+	DisableShallowClone   bool                         `json:"disable_shallow_clone"`
+	HomeVolumeSettings    APIHomeVolumeSettings        `json:"home_volume_settings"`
+	IcecreamSettings      APIIceCreamSettings          `json:"icecream_settings"`
+	SpawnHostAutoStop     APISpawnHostAutoStopSettings `json:"spawn_host_auto_stop"`
+	IsVirtualWorkstation  bool                         `json:"is_virtual_workstation"`
+	IsCluster             bool                         `json:"is_cluster"`
+	Note                  *string                      `json:"note"`
+	ValidProjects         []*string                    `json:"valid_projects"`
 }
 
 // BuildFromService converts from service level distro.Distro to an APIDistro
@@ -421,6 +507,10 @@ func (apiDistro *APIDistro) BuildFromService(d distro.Distro) {
 	icecreamSettings := APIIceCreamSettings{}
 	icecreamSettings.BuildFromService(d.IceCreamSettings)
 	apiDistro.IcecreamSettings = icecreamSettings
+
+	autoStopSettings := APISpawnHostAutoStopSettings{}
+	autoStopSettings.BuildFromService(d.SpawnHostAutoStop)
+	apiDistro.SpawnHostAutoStop = autoStopSettings
 	apiDistro.IsVirtualWorkstation = d.IsVirtualWorkstation
 	apiDistro.IsCluster = d.IsCluster
 
@@ -463,6 +553,7 @@ func (apiDistro *APIDistro) ToService() *distro.Distro {
 	d.DispatcherSettings = apiDistro.DispatcherSettings.ToService()
 	d.HomeVolumeSettings = apiDistro.HomeVolumeSettings.ToService()
 	d.IceCreamSettings = apiDistro.IcecreamSettings.ToService()
+	d.SpawnHostAutoStop = apiDistro.SpawnHostAutoStop.ToService()
 
 	d.DisableShallowClone = apiDistro.DisableShallowClone
 	d.Note = utility.FromStringPtr(apiDistro.Note)
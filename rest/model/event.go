@@ -49,6 +49,7 @@ type HostAPIEventData struct {
 	Logs               *string     `bson:"log,omitempty" json:"logs,omitempty"`
 	Hostname           *string     `bson:"hn,omitempty" json:"hostname,omitempty"`
 	ProvisioningMethod *string     `bson:"prov_method" json:"provisioning_method,omitempty"`
+	ProvisioningStep   *string     `bson:"prov_step,omitempty" json:"provisioning_step,omitempty"`
 	TaskId             *string     `bson:"t_id,omitempty" json:"task_id,omitempty"`
 	TaskPid            *string     `bson:"t_pid,omitempty" json:"task_pid,omitempty"`
 	TaskStatus         *string     `bson:"t_st,omitempty" json:"task_status,omitempty"`
@@ -132,6 +133,7 @@ func (el *HostAPIEventData) BuildFromService(v *event.HostEventData) {
 	el.Logs = utility.ToStringPtr(v.Logs)
 	el.Hostname = utility.ToStringPtr(v.Hostname)
 	el.ProvisioningMethod = utility.ToStringPtr(v.ProvisioningMethod)
+	el.ProvisioningStep = utility.ToStringPtr(v.ProvisioningStep)
 	el.TaskId = utility.ToStringPtr(v.TaskId)
 	el.TaskPid = utility.ToStringPtr(v.TaskPid)
 	el.TaskStatus = utility.ToStringPtr(v.TaskStatus)
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIExportedEvent is an event in a bulk event export.
+type APIExportedEvent struct {
+	ID           *string     `json:"id"`
+	ResourceType *string     `json:"resource_type"`
+	ResourceId   *string     `json:"resource_id"`
+	EventType    *string     `json:"event_type"`
+	Timestamp    *time.Time  `json:"timestamp"`
+	ProcessedAt  *time.Time  `json:"processed_at"`
+	Data         interface{} `json:"data"`
+}
+
+// BuildFromService converts a service level event to an API model.
+func (e *APIExportedEvent) BuildFromService(in event.EventLogEntry) {
+	e.ID = utility.ToStringPtr(in.ID)
+	e.ResourceType = utility.ToStringPtr(in.ResourceType)
+	e.ResourceId = utility.ToStringPtr(in.ResourceId)
+	e.EventType = utility.ToStringPtr(in.EventType)
+	e.Timestamp = ToTimePtr(in.Timestamp)
+	e.ProcessedAt = ToTimePtr(in.ProcessedAt)
+	e.Data = in.Data
+}
+
+// APIEventExportResponse is a single page of exported events.
+type APIEventExportResponse struct {
+	Events []APIExportedEvent `json:"events"`
+	// NextStart is the start time to use to fetch the next page of events. It
+	// is empty if there are no more events.
+	NextStart *time.Time `json:"next_start,omitempty"`
+}
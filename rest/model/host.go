@@ -44,6 +44,8 @@ type HostRequestOptions struct {
 	DistroID              string     `json:"distro" yaml:"distro"`
 	TaskID                string     `json:"task" yaml:"task"`
 	TaskSync              bool       `json:"task_sync" yaml:"task_sync"`
+	ReproduceTask         bool       `json:"reproduce_task" yaml:"reproduce_task"`
+	RunTaskSetup          bool       `json:"run_task_setup" yaml:"run_task_setup"`
 	Region                string     `json:"region" yaml:"region"`
 	KeyName               string     `json:"keyname" yaml:"key"`
 	UserData              string     `json:"userdata" yaml:"userdata_file"`
@@ -230,6 +232,56 @@ func (apiVolume *APIVolume) ToService() (host.Volume, error) {
 	}, nil
 }
 
+// APIVolumeSnapshot is the model to be returned by the API whenever volume
+// snapshots are fetched.
+type APIVolumeSnapshot struct {
+	ID               *string    `json:"snapshot_id"`
+	VolumeID         *string    `json:"volume_id"`
+	DisplayName      *string    `json:"display_name"`
+	CreatedBy        *string    `json:"created_by"`
+	Size             int        `json:"size"`
+	VolumeType       *string    `json:"volume_type"`
+	AvailabilityZone *string    `json:"zone"`
+	HomeVolume       bool       `json:"home_volume"`
+	Status           *string    `json:"status"`
+	CreationTime     *time.Time `json:"creation_time"`
+	Expiration       *time.Time `json:"expiration"`
+	Automatic        bool       `json:"automatic"`
+}
+
+// BuildFromService converts from a service level host.VolumeSnapshot to an
+// APIVolumeSnapshot.
+func (apiSnapshot *APIVolumeSnapshot) BuildFromService(s host.VolumeSnapshot) {
+	apiSnapshot.ID = utility.ToStringPtr(s.ID)
+	apiSnapshot.VolumeID = utility.ToStringPtr(s.VolumeID)
+	apiSnapshot.DisplayName = utility.ToStringPtr(s.DisplayName)
+	apiSnapshot.CreatedBy = utility.ToStringPtr(s.CreatedBy)
+	apiSnapshot.Size = s.Size
+	apiSnapshot.VolumeType = utility.ToStringPtr(s.VolumeType)
+	apiSnapshot.AvailabilityZone = utility.ToStringPtr(s.AvailabilityZone)
+	apiSnapshot.HomeVolume = s.HomeVolume
+	apiSnapshot.Status = utility.ToStringPtr(s.Status)
+	apiSnapshot.CreationTime = ToTimePtr(s.CreationDate)
+	apiSnapshot.Expiration = ToTimePtr(s.Expiration)
+	apiSnapshot.Automatic = s.Automatic
+}
+
+// VolumeSnapshotCreateOptions are the options for snapshotting a volume.
+type VolumeSnapshotCreateOptions struct {
+	DisplayName string    `json:"display_name"`
+	Expiration  time.Time `json:"expiration"`
+}
+
+// VolumeSnapshotRestoreOptions are the options for the volume that a
+// snapshot is restored into. Options that are not set default to those of
+// the snapshotted volume.
+type VolumeSnapshotRestoreOptions struct {
+	DisplayName      string `json:"display_name"`
+	Type             string `json:"type"`
+	Size             int    `json:"size"`
+	AvailabilityZone string `json:"zone"`
+}
+
 type APISpawnHostModify struct {
 	Action       *string    `json:"action"`
 	HostID       *string    `json:"host_id"`
@@ -277,6 +329,23 @@ type APIOffboardUserResults struct {
 	TerminatedVolumes []string `json:"terminated_volumes"`
 }
 
+// APISpawnHostActivity is the activity on a spawn host reported by its agent
+// monitor.
+type APISpawnHostActivity struct {
+	SSHSessions   int     `json:"ssh_sessions"`
+	LoadAverage   float64 `json:"load_average"`
+	ProcessStarts int     `json:"process_starts"`
+}
+
+// ToService returns the service model for the spawn host activity.
+func (a *APISpawnHostActivity) ToService() host.SpawnHostActivity {
+	return host.SpawnHostActivity{
+		SSHSessions:   a.SSHSessions,
+		LoadAverage:   a.LoadAverage,
+		ProcessStarts: a.ProcessStarts,
+	}
+}
+
 // APIHostProvisioningOptions represents the script to provision a host.
 type APIHostProvisioningOptions struct {
 	Content string `json:"content"`
@@ -49,6 +49,18 @@ type APIPatch struct {
 	Requester               *string              `json:"requester"`
 	MergedFrom              *string              `json:"merged_from"`
 	CommitQueuePosition     *int                 `json:"commit_queue_position,omitempty"`
+	BasePatchID             *string              `json:"base_patch_id,omitempty"`
+	BasePatches             []APIPatch           `json:"base_patches,omitempty"`
+	ImpactedTasks           []APIImpactedTask    `json:"impacted_tasks,omitempty"`
+}
+
+// APIImpactedTask is a task that was selected for a patch because of the
+// files it changes, along with the reasons why it was selected.
+type APIImpactedTask struct {
+	Variant  *string  `json:"variant"`
+	Task     *string  `json:"task"`
+	Reasons  []string `json:"reasons"`
+	TestTags []string `json:"test_tags,omitempty"`
 }
 
 type DownstreamTasks struct {
@@ -86,6 +98,23 @@ type APIModulePatch struct {
 	RawLink        *string    `json:"raw_link"`
 	CommitMessages []*string  `json:"commit_messages"`
 	FileDiffs      []FileDiff `json:"file_diffs"`
+	BasePatchID    *string    `json:"base_patch_id,omitempty"`
+}
+
+// APIRemotePatchRequest is the body of a request to create a patch from a ref
+// in a GitHub repository.
+type APIRemotePatchRequest struct {
+	// Ref is the branch, tag, or commit to patch.
+	Ref *string `json:"ref"`
+	// Repo is the repository containing the ref in the form "owner/name".
+	// It defaults to the project's repository and can be a fork of it.
+	Repo        *string        `json:"repo"`
+	Description *string        `json:"description"`
+	Alias       *string        `json:"alias"`
+	Variants    []string       `json:"variants"`
+	Tasks       []string       `json:"tasks"`
+	Parameters  []APIParameter `json:"parameters"`
+	Finalize    bool           `json:"finalize"`
 }
 
 type APIParameter struct {
@@ -105,10 +134,12 @@ type APIPatchArgs struct {
 	IncludeProjectIdentifier   bool
 	IncludeCommitQueuePosition bool
 	IncludeChildPatches        bool
+	IncludeBasePatches         bool
 }
 
 // BuildFromService converts from service level structs to an APIPatch.
-// If args are set, includes identifier, commit queue position, and/or child patches from the DB, if applicable.
+// If args are set, includes identifier, commit queue position, child patches, and/or the patches
+// that the patch is stacked on from the DB, if applicable.
 func (apiPatch *APIPatch) BuildFromService(p patch.Patch, args *APIPatchArgs) error {
 	apiPatch.buildBasePatch(p)
 
@@ -129,12 +160,35 @@ func (apiPatch *APIPatch) BuildFromService(p patch.Patch, args *APIPatchArgs) er
 	}
 	apiPatch.buildModuleChanges(p, projectIdentifier)
 
+	if args != nil && args.IncludeBasePatches {
+		if err := apiPatch.buildBasePatches(p); err != nil {
+			return errors.Wrap(err, "getting base patches")
+		}
+	}
 	if args != nil && args.IncludeChildPatches {
 		return apiPatch.buildChildPatches(p)
 	}
 	return nil
 }
 
+// buildBasePatches populates the patches that the patch is stacked on,
+// ordered from the bottom of the stack up.
+func (apiPatch *APIPatch) buildBasePatches(p patch.Patch) error {
+	if !p.IsStacked() {
+		return nil
+	}
+	basePatches, err := p.FindBasePatches()
+	if err != nil {
+		return err
+	}
+	for _, basePatch := range basePatches {
+		apiBasePatch := APIPatch{}
+		apiBasePatch.buildBasePatch(basePatch)
+		apiPatch.BasePatches = append(apiPatch.BasePatches, apiBasePatch)
+	}
+	return nil
+}
+
 func (apiPatch *APIPatch) GetCommitQueuePosition() error {
 	if apiPatch.CommitQueuePosition != nil {
 		return nil
@@ -184,6 +238,9 @@ func (apiPatch *APIPatch) buildBasePatch(p patch.Patch) {
 	apiPatch.StartTime = ToTimePtr(p.StartTime)
 	apiPatch.FinishTime = ToTimePtr(p.FinishTime)
 	apiPatch.MergedFrom = utility.ToStringPtr(p.MergedFrom)
+	if p.BasePatchID != "" {
+		apiPatch.BasePatchID = utility.ToStringPtr(p.BasePatchID)
+	}
 	builds := make([]*string, 0)
 	for _, b := range p.BuildVariants {
 		builds = append(builds, utility.ToStringPtr(b))
@@ -208,6 +265,14 @@ func (apiPatch *APIPatch) buildBasePatch(p patch.Patch) {
 	apiPatch.VariantsTasks = variantTasks
 	apiPatch.Activated = p.Activated
 	apiPatch.Alias = utility.ToStringPtr(p.Alias)
+	for _, it := range p.ImpactedTasks {
+		apiPatch.ImpactedTasks = append(apiPatch.ImpactedTasks, APIImpactedTask{
+			Variant:  utility.ToStringPtr(it.Variant),
+			Task:     utility.ToStringPtr(it.Task),
+			Reasons:  it.Reasons,
+			TestTags: it.TestTags,
+		})
+	}
 	apiPatch.GithubPatchData = githubPatch{}
 	apiPatch.Requester = utility.ToStringPtr(p.GetRequester())
 
@@ -333,6 +398,9 @@ func (apiPatch *APIPatch) buildModuleChanges(p patch.Patch, identifier string) {
 			FileDiffs:      fileDiffs,
 			CommitMessages: utility.ToStringPtrSlice(modPatch.PatchSet.CommitMessages),
 		}
+		if modPatch.BasePatchID != "" {
+			apiModPatch.BasePatchID = utility.ToStringPtr(modPatch.BasePatchID)
+		}
 		codeChanges = append(codeChanges, apiModPatch)
 	}
 
@@ -353,6 +421,15 @@ func (apiPatch *APIPatch) ToService() (patch.Patch, error) {
 	res.Version = utility.FromStringPtr(apiPatch.Version)
 	res.Status = utility.FromStringPtr(apiPatch.Status)
 	res.Alias = utility.FromStringPtr(apiPatch.Alias)
+	res.BasePatchID = utility.FromStringPtr(apiPatch.BasePatchID)
+	for _, it := range apiPatch.ImpactedTasks {
+		res.ImpactedTasks = append(res.ImpactedTasks, patch.ImpactedTask{
+			Variant:  utility.FromStringPtr(it.Variant),
+			Task:     utility.FromStringPtr(it.Task),
+			Reasons:  it.Reasons,
+			TestTags: it.TestTags,
+		})
+	}
 	res.Activated = apiPatch.Activated
 	res.CreateTime, err = FromTimePtr(apiPatch.CreateTime)
 	catcher.Add(err)
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/perf"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIPerfChangePoint is a point in a mainline performance series where the
+// distribution of its values changed.
+type APIPerfChangePoint struct {
+	ID            *string    `json:"id"`
+	Project       *string    `json:"project"`
+	Variant       *string    `json:"variant"`
+	Task          *string    `json:"task"`
+	Test          *string    `json:"test"`
+	Measurement   *string    `json:"measurement"`
+	Args          *string    `json:"args,omitempty"`
+	Version       *string    `json:"version"`
+	Order         int        `json:"order"`
+	TaskID        *string    `json:"task_id"`
+	Execution     int        `json:"execution"`
+	MeanBefore    float64    `json:"mean_before"`
+	MeanAfter     float64    `json:"mean_after"`
+	PercentChange float64    `json:"percent_change"`
+	CreateTime    *time.Time `json:"create_time"`
+}
+
+func (cp *APIPerfChangePoint) BuildFromService(v perf.ChangePoint) {
+	cp.ID = utility.ToStringPtr(v.ID)
+	cp.Project = utility.ToStringPtr(v.Project)
+	cp.Variant = utility.ToStringPtr(v.Variant)
+	cp.Task = utility.ToStringPtr(v.Task)
+	cp.Test = utility.ToStringPtr(v.Test)
+	cp.Measurement = utility.ToStringPtr(v.Measurement)
+	if v.Args != "" {
+		cp.Args = utility.ToStringPtr(v.Args)
+	}
+	cp.Version = utility.ToStringPtr(v.Version)
+	cp.Order = v.Order
+	cp.TaskID = utility.ToStringPtr(v.TaskID)
+	cp.Execution = v.Execution
+	cp.MeanBefore = v.MeanBefore
+	cp.MeanAfter = v.MeanAfter
+	cp.PercentChange = v.PercentChange
+	cp.CreateTime = ToTimePtr(v.CreateTime)
+}
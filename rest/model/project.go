@@ -469,6 +469,11 @@ type APIProjectRef struct {
 	ManualPRTestingEnabled      *bool                     `json:"manual_pr_testing_enabled"`
 	GitTagVersionsEnabled       *bool                     `json:"git_tag_versions_enabled"`
 	GithubChecksEnabled         *bool                     `json:"github_checks_enabled"`
+	GithubCheckRunsEnabled      *bool                     `json:"github_check_runs_enabled"`
+	GithubCheckRunsPerTask      *bool                     `json:"github_check_runs_per_task"`
+	GithubMergeQueueEnabled     *bool                     `json:"github_merge_queue_enabled"`
+	GithubMergeQueueAlias       *string                   `json:"github_merge_queue_alias"`
+	PRSummaryCommentEnabled     *bool                     `json:"pr_summary_comment_enabled"`
 	UseRepoSettings             *bool                     `json:"use_repo_settings"`
 	RepoRefId                   *string                   `json:"repo_ref_id"`
 	CommitQueue                 APICommitQueueParams      `json:"commit_queue"`
@@ -512,43 +517,48 @@ type APIProjectRef struct {
 // ToService returns a service layer ProjectRef using the data from APIProjectRef
 func (p *APIProjectRef) ToService() (*model.ProjectRef, error) {
 	projectRef := model.ProjectRef{
-		Owner:                  utility.FromStringPtr(p.Owner),
-		Repo:                   utility.FromStringPtr(p.Repo),
-		Branch:                 utility.FromStringPtr(p.Branch),
-		Enabled:                utility.FromBoolPtr(p.Enabled),
-		Private:                utility.BoolPtrCopy(p.Private),
-		Restricted:             utility.BoolPtrCopy(p.Restricted),
-		BatchTime:              p.BatchTime,
-		RemotePath:             utility.FromStringPtr(p.RemotePath),
-		Id:                     utility.FromStringPtr(p.Id),
-		Identifier:             utility.FromStringPtr(p.Identifier),
-		DisplayName:            utility.FromStringPtr(p.DisplayName),
-		DeactivatePrevious:     utility.BoolPtrCopy(p.DeactivatePrevious),
-		TracksPushEvents:       utility.BoolPtrCopy(p.TracksPushEvents),
-		PRTestingEnabled:       utility.BoolPtrCopy(p.PRTestingEnabled),
-		ManualPRTestingEnabled: utility.BoolPtrCopy(p.ManualPRTestingEnabled),
-		GitTagVersionsEnabled:  utility.BoolPtrCopy(p.GitTagVersionsEnabled),
-		GithubChecksEnabled:    utility.BoolPtrCopy(p.GithubChecksEnabled),
-		RepoRefId:              utility.FromStringPtr(p.RepoRefId),
-		CommitQueue:            p.CommitQueue.ToService(),
-		TaskSync:               p.TaskSync.ToService(),
-		WorkstationConfig:      p.WorkstationConfig.ToService(),
-		BuildBaronSettings:     p.BuildBaronSettings.ToService(),
-		TaskAnnotationSettings: p.TaskAnnotationSettings.ToService(),
-		PerfEnabled:            utility.BoolPtrCopy(p.PerfEnabled),
-		Hidden:                 utility.BoolPtrCopy(p.Hidden),
-		PatchingDisabled:       utility.BoolPtrCopy(p.PatchingDisabled),
-		RepotrackerDisabled:    utility.BoolPtrCopy(p.RepotrackerDisabled),
-		DispatchingDisabled:    utility.BoolPtrCopy(p.DispatchingDisabled),
-		StepbackDisabled:       utility.BoolPtrCopy(p.StepbackDisabled),
-		VersionControlEnabled:  utility.BoolPtrCopy(p.VersionControlEnabled),
-		DisabledStatsCache:     utility.BoolPtrCopy(p.DisabledStatsCache),
-		NotifyOnBuildFailure:   utility.BoolPtrCopy(p.NotifyOnBuildFailure),
-		SpawnHostScriptPath:    utility.FromStringPtr(p.SpawnHostScriptPath),
-		Admins:                 utility.FromStringPtrSlice(p.Admins),
-		GitTagAuthorizedUsers:  utility.FromStringPtrSlice(p.GitTagAuthorizedUsers),
-		GitTagAuthorizedTeams:  utility.FromStringPtrSlice(p.GitTagAuthorizedTeams),
-		GithubTriggerAliases:   utility.FromStringPtrSlice(p.GithubTriggerAliases),
+		Owner:                   utility.FromStringPtr(p.Owner),
+		Repo:                    utility.FromStringPtr(p.Repo),
+		Branch:                  utility.FromStringPtr(p.Branch),
+		Enabled:                 utility.FromBoolPtr(p.Enabled),
+		Private:                 utility.BoolPtrCopy(p.Private),
+		Restricted:              utility.BoolPtrCopy(p.Restricted),
+		BatchTime:               p.BatchTime,
+		RemotePath:              utility.FromStringPtr(p.RemotePath),
+		Id:                      utility.FromStringPtr(p.Id),
+		Identifier:              utility.FromStringPtr(p.Identifier),
+		DisplayName:             utility.FromStringPtr(p.DisplayName),
+		DeactivatePrevious:      utility.BoolPtrCopy(p.DeactivatePrevious),
+		TracksPushEvents:        utility.BoolPtrCopy(p.TracksPushEvents),
+		PRTestingEnabled:        utility.BoolPtrCopy(p.PRTestingEnabled),
+		ManualPRTestingEnabled:  utility.BoolPtrCopy(p.ManualPRTestingEnabled),
+		GitTagVersionsEnabled:   utility.BoolPtrCopy(p.GitTagVersionsEnabled),
+		GithubChecksEnabled:     utility.BoolPtrCopy(p.GithubChecksEnabled),
+		GithubCheckRunsEnabled:  utility.BoolPtrCopy(p.GithubCheckRunsEnabled),
+		GithubCheckRunsPerTask:  utility.BoolPtrCopy(p.GithubCheckRunsPerTask),
+		GithubMergeQueueEnabled: utility.BoolPtrCopy(p.GithubMergeQueueEnabled),
+		GithubMergeQueueAlias:   utility.FromStringPtr(p.GithubMergeQueueAlias),
+		PRSummaryCommentEnabled: utility.BoolPtrCopy(p.PRSummaryCommentEnabled),
+		RepoRefId:               utility.FromStringPtr(p.RepoRefId),
+		CommitQueue:             p.CommitQueue.ToService(),
+		TaskSync:                p.TaskSync.ToService(),
+		WorkstationConfig:       p.WorkstationConfig.ToService(),
+		BuildBaronSettings:      p.BuildBaronSettings.ToService(),
+		TaskAnnotationSettings:  p.TaskAnnotationSettings.ToService(),
+		PerfEnabled:             utility.BoolPtrCopy(p.PerfEnabled),
+		Hidden:                  utility.BoolPtrCopy(p.Hidden),
+		PatchingDisabled:        utility.BoolPtrCopy(p.PatchingDisabled),
+		RepotrackerDisabled:     utility.BoolPtrCopy(p.RepotrackerDisabled),
+		DispatchingDisabled:     utility.BoolPtrCopy(p.DispatchingDisabled),
+		StepbackDisabled:        utility.BoolPtrCopy(p.StepbackDisabled),
+		VersionControlEnabled:   utility.BoolPtrCopy(p.VersionControlEnabled),
+		DisabledStatsCache:      utility.BoolPtrCopy(p.DisabledStatsCache),
+		NotifyOnBuildFailure:    utility.BoolPtrCopy(p.NotifyOnBuildFailure),
+		SpawnHostScriptPath:     utility.FromStringPtr(p.SpawnHostScriptPath),
+		Admins:                  utility.FromStringPtrSlice(p.Admins),
+		GitTagAuthorizedUsers:   utility.FromStringPtrSlice(p.GitTagAuthorizedUsers),
+		GitTagAuthorizedTeams:   utility.FromStringPtrSlice(p.GitTagAuthorizedTeams),
+		GithubTriggerAliases:    utility.FromStringPtrSlice(p.GithubTriggerAliases),
 	}
 
 	// Copy triggers
@@ -625,6 +635,11 @@ func (p *APIProjectRef) BuildPublicFields(projectRef model.ProjectRef) error {
 	p.ManualPRTestingEnabled = utility.BoolPtrCopy(projectRef.ManualPRTestingEnabled)
 	p.GitTagVersionsEnabled = utility.BoolPtrCopy(projectRef.GitTagVersionsEnabled)
 	p.GithubChecksEnabled = utility.BoolPtrCopy(projectRef.GithubChecksEnabled)
+	p.GithubCheckRunsEnabled = utility.BoolPtrCopy(projectRef.GithubCheckRunsEnabled)
+	p.GithubCheckRunsPerTask = utility.BoolPtrCopy(projectRef.GithubCheckRunsPerTask)
+	p.GithubMergeQueueEnabled = utility.BoolPtrCopy(projectRef.GithubMergeQueueEnabled)
+	p.GithubMergeQueueAlias = utility.ToStringPtr(projectRef.GithubMergeQueueAlias)
+	p.PRSummaryCommentEnabled = utility.BoolPtrCopy(projectRef.PRSummaryCommentEnabled)
 	p.UseRepoSettings = utility.ToBoolPtr(projectRef.UseRepoSettings())
 	p.RepoRefId = utility.ToStringPtr(projectRef.RepoRefId)
 	p.PerfEnabled = utility.BoolPtrCopy(projectRef.PerfEnabled)
@@ -59,14 +59,14 @@ func (s *APISubscriber) BuildFromService(in event.Subscriber) error {
 	var target interface{}
 
 	switch in.Type {
-	case event.GithubPullRequestSubscriberType:
+	case event.GithubPullRequestSubscriberType, event.GithubPRCommentSubscriberType:
 		sub := APIGithubPRSubscriber{}
 		err := sub.BuildFromService(in.Target)
 		if err != nil {
 			return err
 		}
 		target = sub
-	case event.GithubCheckSubscriberType:
+	case event.GithubCheckSubscriberType, event.GithubCheckRunSubscriberType:
 		sub := APIGithubCheckSubscriber{}
 		err := sub.BuildFromService(in.Target)
 		if err != nil {
@@ -109,7 +109,7 @@ func (s *APISubscriber) ToService() (event.Subscriber, error) {
 		Type: utility.FromStringPtr(s.Type),
 	}
 	switch utility.FromStringPtr(s.Type) {
-	case event.GithubPullRequestSubscriberType:
+	case event.GithubPullRequestSubscriberType, event.GithubPRCommentSubscriberType:
 		apiModel := APIGithubPRSubscriber{}
 		if err = mapstructure.Decode(s.Target, &apiModel); err != nil {
 			return event.Subscriber{}, gimlet.ErrorResponse{
@@ -119,7 +119,7 @@ func (s *APISubscriber) ToService() (event.Subscriber, error) {
 		}
 		target = apiModel.ToService()
 
-	case event.GithubCheckSubscriberType:
+	case event.GithubCheckSubscriberType, event.GithubCheckRunSubscriberType:
 		apiModel := APIGithubCheckSubscriber{}
 		if err = mapstructure.Decode(s.Target, &apiModel); err != nil {
 			return event.Subscriber{}, gimlet.ErrorResponse{
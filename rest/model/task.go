@@ -113,6 +113,9 @@ type ApiTaskEndDetail struct {
 	TimedOut    bool              `json:"timed_out"`
 	TimeoutType *string           `json:"timeout_type"`
 	OOMTracker  APIOomTrackerInfo `json:"oom_tracker_info"`
+	// ResourceLimitOOM is set if commands were OOM killed for exceeding
+	// their own memory limit.
+	ResourceLimitOOM *APIResourceLimitOOMInfo `json:"resource_limit_oom_info,omitempty"`
 }
 
 func (at *ApiTaskEndDetail) BuildFromService(t apimodels.TaskEndDetail) error {
@@ -126,11 +129,16 @@ func (at *ApiTaskEndDetail) BuildFromService(t apimodels.TaskEndDetail) error {
 	apiOomTracker.BuildFromService(t.OOMTracker)
 	at.OOMTracker = apiOomTracker
 
+	if t.ResourceLimitOOM != nil {
+		at.ResourceLimitOOM = &APIResourceLimitOOMInfo{}
+		at.ResourceLimitOOM.BuildFromService(t.ResourceLimitOOM)
+	}
+
 	return nil
 }
 
 func (ad *ApiTaskEndDetail) ToService() apimodels.TaskEndDetail {
-	return apimodels.TaskEndDetail{
+	detail := apimodels.TaskEndDetail{
 		Status:      utility.FromStringPtr(ad.Status),
 		Type:        utility.FromStringPtr(ad.Type),
 		Description: utility.FromStringPtr(ad.Description),
@@ -138,6 +146,10 @@ func (ad *ApiTaskEndDetail) ToService() apimodels.TaskEndDetail {
 		TimeoutType: utility.FromStringPtr(ad.TimeoutType),
 		OOMTracker:  ad.OOMTracker.ToService(),
 	}
+	if ad.ResourceLimitOOM != nil {
+		detail.ResourceLimitOOM = ad.ResourceLimitOOM.ToService()
+	}
+	return detail
 }
 
 type APIOomTrackerInfo struct {
@@ -159,6 +171,25 @@ func (ad *APIOomTrackerInfo) ToService() *apimodels.OOMTrackerInfo {
 	}
 }
 
+type APIResourceLimitOOMInfo struct {
+	Detected bool     `json:"detected"`
+	Commands []string `json:"commands"`
+}
+
+func (at *APIResourceLimitOOMInfo) BuildFromService(t *apimodels.ResourceLimitOOMInfo) {
+	if t != nil {
+		at.Detected = t.Detected
+		at.Commands = t.Commands
+	}
+}
+
+func (ad *APIResourceLimitOOMInfo) ToService() *apimodels.ResourceLimitOOMInfo {
+	return &apimodels.ResourceLimitOOMInfo{
+		Detected: ad.Detected,
+		Commands: ad.Commands,
+	}
+}
+
 // BuildPreviousExecutions adds the given previous executions to the given API task.
 func (at *APITask) BuildPreviousExecutions(tasks []task.Task, logURL, parsleyURL string) error {
 	at.PreviousExecutions = make([]APITask, len(tasks))
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/utility"
+)
+
+// APITaskLogSearchResult is the matching log lines for a single task
+// execution returned by a task log search.
+type APITaskLogSearchResult struct {
+	TaskID       *string                 `json:"task_id"`
+	Execution    int                     `json:"execution"`
+	BuildVariant *string                 `json:"build_variant"`
+	TaskName     *string                 `json:"task_name"`
+	Requester    *string                 `json:"requester"`
+	FinishTime   *time.Time              `json:"finish_time"`
+	Matches      []APITaskLogSearchMatch `json:"matches"`
+	Truncated    bool                    `json:"truncated"`
+}
+
+// APITaskLogSearchMatch is a single log line matched by a task log search.
+type APITaskLogSearchMatch struct {
+	Timestamp *time.Time `json:"timestamp"`
+	Severity  *string    `json:"severity"`
+	Type      *string    `json:"type"`
+	Excerpt   *string    `json:"excerpt"`
+}
+
+// BuildFromService converts a service level task log search result to an API
+// model.
+func (r *APITaskLogSearchResult) BuildFromService(in model.TaskLogSearchResult) {
+	r.TaskID = utility.ToStringPtr(in.TaskID)
+	r.Execution = in.Execution
+	r.BuildVariant = utility.ToStringPtr(in.BuildVariant)
+	r.TaskName = utility.ToStringPtr(in.TaskName)
+	r.Requester = utility.ToStringPtr(in.Requester)
+	r.FinishTime = ToTimePtr(in.FinishTime)
+	r.Truncated = in.Truncated
+
+	r.Matches = make([]APITaskLogSearchMatch, 0, len(in.Matches))
+	for _, match := range in.Matches {
+		r.Matches = append(r.Matches, APITaskLogSearchMatch{
+			Timestamp: ToTimePtr(match.Timestamp),
+			Severity:  utility.ToStringPtr(match.Severity),
+			Type:      utility.ToStringPtr(match.Type),
+			Excerpt:   utility.ToStringPtr(match.Excerpt),
+		})
+	}
+}
+
+// APITaskLogSearchRequest is the body of a request to search the logs of a
+// project's tasks.
+type APITaskLogSearchRequest struct {
+	BuildVariant   *string    `json:"build_variant"`
+	TaskName       *string    `json:"task_name"`
+	Requesters     []string   `json:"requesters"`
+	StartTime      *time.Time `json:"start_time"`
+	EndTime        *time.Time `json:"end_time"`
+	Pattern        *string    `json:"pattern"`
+	Regex          bool       `json:"regex"`
+	StartAt        *string    `json:"start_at"`
+	Limit          int        `json:"limit"`
+	MatchesPerTask int        `json:"matches_per_task"`
+}
+
+// ToService returns the task log search options for the given project.
+func (r *APITaskLogSearchRequest) ToService(projectID string) model.TaskLogSearchOptions {
+	opts := model.TaskLogSearchOptions{
+		Project:        projectID,
+		BuildVariant:   utility.FromStringPtr(r.BuildVariant),
+		TaskName:       utility.FromStringPtr(r.TaskName),
+		Requesters:     r.Requesters,
+		Pattern:        utility.FromStringPtr(r.Pattern),
+		Regex:          r.Regex,
+		StartAt:        utility.FromStringPtr(r.StartAt),
+		Limit:          r.Limit,
+		MatchesPerTask: r.MatchesPerTask,
+	}
+	if r.StartTime != nil {
+		opts.StartTime = *r.StartTime
+	}
+	if r.EndTime != nil {
+		opts.EndTime = *r.EndTime
+	}
+	return opts
+}
+
+// APITaskLogSearchResponse is a single page of task log search results.
+type APITaskLogSearchResponse struct {
+	Results []APITaskLogSearchResult `json:"results"`
+	// NextKey is the start_at value to use to fetch the next page of results.
+	// It is empty if there are no more results.
+	NextKey *string `json:"next_key,omitempty"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/utility"
+)
+
+// APITestHistory is the history of a single test across recent mainline
+// versions and build variants.
+type APITestHistory struct {
+	TestName          *string                `json:"test_name"`
+	Results           []APITestHistoryResult `json:"results"`
+	NumPassed         int                    `json:"num_passed"`
+	NumFailed         int                    `json:"num_failed"`
+	FailureRate       float64                `json:"failure_rate"`
+	LastPassRevision  *string                `json:"last_pass_revision,omitempty"`
+	LastPassVersion   *string                `json:"last_pass_version,omitempty"`
+	FirstFailRevision *string                `json:"first_fail_revision,omitempty"`
+	FirstFailVersion  *string                `json:"first_fail_version,omitempty"`
+}
+
+// APITestHistoryResult is the result of a test in a single task.
+type APITestHistoryResult struct {
+	Version      *string `json:"version"`
+	Revision     *string `json:"revision"`
+	Order        int     `json:"order"`
+	BuildVariant *string `json:"build_variant"`
+	TaskID       *string `json:"task_id"`
+	Execution    int     `json:"execution"`
+	Status       *string `json:"status"`
+	Duration     float64 `json:"duration"`
+}
+
+func (h *APITestHistory) BuildFromService(v model.TestHistory) {
+	h.TestName = utility.ToStringPtr(v.TestName)
+	h.Results = make([]APITestHistoryResult, 0, len(v.Results))
+	for _, r := range v.Results {
+		h.Results = append(h.Results, APITestHistoryResult{
+			Version:      utility.ToStringPtr(r.Version),
+			Revision:     utility.ToStringPtr(r.Revision),
+			Order:        r.RevisionOrderNumber,
+			BuildVariant: utility.ToStringPtr(r.BuildVariant),
+			TaskID:       utility.ToStringPtr(r.TaskID),
+			Execution:    r.Execution,
+			Status:       utility.ToStringPtr(r.Status),
+			Duration:     r.Duration.Seconds(),
+		})
+	}
+	h.NumPassed = v.NumPassed
+	h.NumFailed = v.NumFailed
+	h.FailureRate = v.FailureRate
+	if v.LastPassRevision != "" {
+		h.LastPassRevision = utility.ToStringPtr(v.LastPassRevision)
+		h.LastPassVersion = utility.ToStringPtr(v.LastPassVersion)
+	}
+	if v.FirstFailRevision != "" {
+		h.FirstFailRevision = utility.ToStringPtr(v.FirstFailRevision)
+		h.FirstFailVersion = utility.ToStringPtr(v.FirstFailVersion)
+	}
+}
@@ -36,6 +36,9 @@ type APIUserSettings struct {
 	Notifications    *APINotificationPreferences `json:"notifications"`
 	SpruceFeedback   *APIFeedbackSubmission      `json:"spruce_feedback"`
 	DateFormat       *string                     `json:"date_format"`
+	// SpawnHostAutoStop is the policy for automatically stopping the user's
+	// spawn hosts.
+	SpawnHostAutoStop *APISpawnHostAutoStopPolicy `json:"spawn_host_auto_stop"`
 }
 
 type APIUseSpruceOptions struct {
@@ -59,6 +62,8 @@ func (s *APIUserSettings) BuildFromService(settings user.UserSettings) {
 	s.Notifications = &APINotificationPreferences{}
 	s.Notifications.BuildFromService(settings.Notifications)
 	s.DateFormat = utility.ToStringPtr(settings.DateFormat)
+	s.SpawnHostAutoStop = &APISpawnHostAutoStopPolicy{}
+	s.SpawnHostAutoStop.BuildFromService(settings.SpawnHostAutoStop)
 }
 
 func (s *APIUserSettings) ToService() (user.UserSettings, error) {
@@ -68,6 +73,11 @@ func (s *APIUserSettings) ToService() (user.UserSettings, error) {
 		return user.UserSettings{}, err
 	}
 
+	autoStop, err := s.SpawnHostAutoStop.ToService()
+	if err != nil {
+		return user.UserSettings{}, errors.Wrap(err, "invalid spawn host auto-stop policy")
+	}
+
 	useSpruceOptions := user.UseSpruceOptions{}
 	if s.UseSpruceOptions != nil {
 		useSpruceOptions.HasUsedSpruceBefore = utility.FromBoolPtr(s.UseSpruceOptions.HasUsedSpruceBefore)
@@ -75,17 +85,85 @@ func (s *APIUserSettings) ToService() (user.UserSettings, error) {
 		useSpruceOptions.HasUsedMainlineCommitsBefore = utility.FromBoolPtr(s.UseSpruceOptions.HasUsedMainlineCommitsBefore)
 	}
 	return user.UserSettings{
-		Timezone:         utility.FromStringPtr(s.Timezone),
-		Region:           utility.FromStringPtr(s.Region),
-		SlackUsername:    utility.FromStringPtr(s.SlackUsername),
-		SlackMemberId:    utility.FromStringPtr(s.SlackMemberId),
-		GithubUser:       githubUser,
-		Notifications:    preferences,
-		UseSpruceOptions: useSpruceOptions,
-		DateFormat:       utility.FromStringPtr(s.DateFormat),
+		Timezone:          utility.FromStringPtr(s.Timezone),
+		Region:            utility.FromStringPtr(s.Region),
+		SlackUsername:     utility.FromStringPtr(s.SlackUsername),
+		SlackMemberId:     utility.FromStringPtr(s.SlackMemberId),
+		GithubUser:        githubUser,
+		Notifications:     preferences,
+		UseSpruceOptions:  useSpruceOptions,
+		DateFormat:        utility.FromStringPtr(s.DateFormat),
+		SpawnHostAutoStop: autoStop,
 	}, nil
 }
 
+// APISpawnHostAutoStopPolicy is the policy for automatically stopping a user's
+// spawn hosts.
+type APISpawnHostAutoStopPolicy struct {
+	// IdleTimeoutMins is how long a spawn host can be idle before it is
+	// stopped. If zero, idle spawn hosts are only stopped if their distro
+	// has an idle timeout.
+	IdleTimeoutMins *int              `json:"idle_timeout_mins"`
+	SleepSchedule   *APISleepSchedule `json:"sleep_schedule"`
+}
+
+// APISleepSchedule is the schedule on which a user's spawn hosts are stopped
+// and started again.
+type APISleepSchedule struct {
+	// StopTime is the time of day in the format HH:MM at which hosts are
+	// stopped.
+	StopTime *string `json:"stop_time"`
+	// StartTime is the time of day in the format HH:MM at which hosts are
+	// started again.
+	StartTime *string `json:"start_time"`
+	// WholeWeekdaysOff are the names of the days of the week on which hosts
+	// stay stopped all day.
+	WholeWeekdaysOff []string `json:"whole_weekdays_off"`
+	// TimeZone is the name of the time zone for the schedule. If empty, the
+	// user's time zone is used.
+	TimeZone *string `json:"time_zone"`
+}
+
+func (p *APISpawnHostAutoStopPolicy) BuildFromService(in user.SpawnHostAutoStopPolicy) {
+	p.IdleTimeoutMins = utility.ToIntPtr(int(in.IdleTimeout / time.Minute))
+	p.SleepSchedule = &APISleepSchedule{
+		StopTime:         utility.ToStringPtr(in.SleepSchedule.StopTime),
+		StartTime:        utility.ToStringPtr(in.SleepSchedule.StartTime),
+		WholeWeekdaysOff: user.FormatWeekdays(in.SleepSchedule.WholeWeekdaysOff),
+		TimeZone:         utility.ToStringPtr(in.SleepSchedule.TimeZone),
+	}
+}
+
+// ToService converts the policy to its service model and validates it.
+func (p *APISpawnHostAutoStopPolicy) ToService() (user.SpawnHostAutoStopPolicy, error) {
+	if p == nil {
+		return user.SpawnHostAutoStopPolicy{}, nil
+	}
+
+	policy := user.SpawnHostAutoStopPolicy{
+		IdleTimeout: time.Duration(utility.FromIntPtr(p.IdleTimeoutMins)) * time.Minute,
+	}
+	if p.SleepSchedule != nil {
+		policy.SleepSchedule = user.SleepSchedule{
+			StopTime:  utility.FromStringPtr(p.SleepSchedule.StopTime),
+			StartTime: utility.FromStringPtr(p.SleepSchedule.StartTime),
+			TimeZone:  utility.FromStringPtr(p.SleepSchedule.TimeZone),
+		}
+		for _, name := range p.SleepSchedule.WholeWeekdaysOff {
+			day, err := user.ParseWeekday(name)
+			if err != nil {
+				return user.SpawnHostAutoStopPolicy{}, err
+			}
+			policy.SleepSchedule.WholeWeekdaysOff = append(policy.SleepSchedule.WholeWeekdaysOff, day)
+		}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return user.SpawnHostAutoStopPolicy{}, err
+	}
+	return policy, nil
+}
+
 type APIGithubUser struct {
 	UID         int     `json:"uid,omitempty"`
 	LastKnownAs *string `json:"last_known_as,omitempty"`
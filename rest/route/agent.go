@@ -17,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
@@ -85,6 +86,32 @@ func (h *agentDataPipesConfig) Run(ctx context.Context) gimlet.Responder {
 	})
 }
 
+// GET /rest/v2/agent/test_results_config
+
+type agentTestResultsConfig struct {
+	config evergreen.TestResultsConfig
+}
+
+func makeAgentTestResultsConfig(config evergreen.TestResultsConfig) *agentTestResultsConfig {
+	return &agentTestResultsConfig{
+		config: config,
+	}
+}
+
+func (h *agentTestResultsConfig) Factory() gimlet.RouteHandler {
+	return &agentTestResultsConfig{
+		config: h.config,
+	}
+}
+
+func (*agentTestResultsConfig) Parse(_ context.Context, _ *http.Request) error { return nil }
+
+func (h *agentTestResultsConfig) Run(ctx context.Context) gimlet.Responder {
+	return gimlet.NewJSONResponse(apimodels.TestResultsConfig{
+		Service: h.config.GetService(),
+	})
+}
+
 // GET /rest/v2/agent/setup
 type agentSetup struct {
 	settings *evergreen.Settings
@@ -681,6 +708,78 @@ func (h *attachTestLogHandler) Run(ctx context.Context) gimlet.Responder {
 	return gimlet.NewJSONResponse(logReply)
 }
 
+// POST /task/{task_id}/test_results
+type attachTestResultsHandler struct {
+	taskID  string
+	results []testresult.TestResult
+	env     evergreen.Environment
+}
+
+func makeAttachTestResults(env evergreen.Environment) gimlet.RouteHandler {
+	return &attachTestResultsHandler{
+		env: env,
+	}
+}
+
+func (h *attachTestResultsHandler) Factory() gimlet.RouteHandler {
+	return &attachTestResultsHandler{
+		env: h.env,
+	}
+}
+
+func (h *attachTestResultsHandler) Parse(ctx context.Context, r *http.Request) error {
+	if h.taskID = gimlet.GetVars(r)["task_id"]; h.taskID == "" {
+		return errors.New("missing task ID")
+	}
+	if err := utility.ReadJSON(r.Body, &h.results); err != nil {
+		return errors.Wrap(err, "reading test results from JSON request body")
+	}
+	return nil
+}
+
+// Run stores the test results in the local test results service and records
+// that the task uses it.
+func (h *attachTestResultsHandler) Run(ctx context.Context) gimlet.Responder {
+	t, err := task.FindOneId(h.taskID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", h.taskID))
+	}
+	if t == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", h.taskID),
+		})
+	}
+	if t.ResultsService != "" && t.ResultsService != testresult.TestResultsServiceLocal {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("task '%s' already uses the test results service '%s'", t.Id, t.ResultsService),
+		})
+	}
+	if len(h.results) == 0 {
+		return gimlet.NewJSONResponse(struct{}{})
+	}
+
+	var failed bool
+	for i := range h.results {
+		// Enforce the proper task ID and execution.
+		h.results[i].TaskID = t.Id
+		h.results[i].Execution = t.Execution
+		if h.results[i].Status == evergreen.TestFailedStatus {
+			failed = true
+		}
+	}
+
+	if err = testresult.InsertLocal(ctx, h.env, h.results...); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "inserting test results for task '%s'", t.Id))
+	}
+	if err = t.SetResultsInfo(testresult.TestResultsServiceLocal, failed); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "setting results info for task '%s'", t.Id))
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
+
 // POST /task/{task_id}/heartbeat
 type heartbeatHandler struct {
 	taskID string
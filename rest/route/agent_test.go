@@ -273,6 +273,50 @@ func TestAgentDataPipesConfig(t *testing.T) {
 	}
 }
 
+func TestAgentTestResultsConfig(t *testing.T) {
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, rh *agentTestResultsConfig){
+		"FactorySucceeds": func(ctx context.Context, t *testing.T, rh *agentTestResultsConfig) {
+			copied := rh.Factory()
+			assert.NotZero(t, copied)
+			_, ok := copied.(*agentTestResultsConfig)
+			assert.True(t, ok)
+		},
+		"ParseSucceeds": func(ctx context.Context, t *testing.T, rh *agentTestResultsConfig) {
+			req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/agent/test_results_config", nil)
+			require.NoError(t, err)
+			assert.NoError(t, rh.Parse(ctx, req))
+		},
+		"RunSucceeds": func(ctx context.Context, t *testing.T, rh *agentTestResultsConfig) {
+			resp := rh.Run(ctx)
+			require.NotZero(t, resp)
+			assert.Equal(t, http.StatusOK, resp.Status())
+
+			data, ok := resp.Data().(apimodels.TestResultsConfig)
+			require.True(t, ok)
+			assert.Equal(t, evergreen.TestResultsServiceCedar, data.Service)
+		},
+		"DefaultsToLocal": func(ctx context.Context, t *testing.T, rh *agentTestResultsConfig) {
+			rh.config = evergreen.TestResultsConfig{}
+			resp := rh.Run(ctx)
+			require.NotZero(t, resp)
+			assert.Equal(t, http.StatusOK, resp.Status())
+
+			data, ok := resp.Data().(apimodels.TestResultsConfig)
+			require.True(t, ok)
+			assert.Equal(t, evergreen.TestResultsServiceLocal, data.Service)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			r := makeAgentTestResultsConfig(evergreen.TestResultsConfig{Service: evergreen.TestResultsServiceCedar})
+
+			tCase(ctx, t, r)
+		})
+	}
+}
+
 func TestAgentSetup(t *testing.T) {
 	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, rh *agentSetup, s *evergreen.Settings){
 		"FactorySucceeds": func(ctx context.Context, t *testing.T, rh *agentSetup, s *evergreen.Settings) {
@@ -0,0 +1,110 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/admin/events/export
+
+type eventExportHandler struct {
+	opts event.ExportOptions
+}
+
+func makeExportEvents() gimlet.RouteHandler {
+	return &eventExportHandler{}
+}
+
+func (h *eventExportHandler) Factory() gimlet.RouteHandler {
+	return &eventExportHandler{}
+}
+
+func (h *eventExportHandler) Parse(ctx context.Context, r *http.Request) error {
+	vals := r.URL.Query()
+	h.opts = event.ExportOptions{
+		ResourceTypes: splitQueryList(vals["resource_type"]),
+		ResourceIDs:   splitQueryList(vals["resource_id"]),
+		EndTime:       time.Now(),
+	}
+
+	var err error
+	if start := vals.Get("start_time"); start != "" {
+		if h.opts.StartTime, err = time.Parse(time.RFC3339, start); err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing start time as RFC-3339").Error(),
+			}
+		}
+	}
+	if end := vals.Get("end_time"); end != "" {
+		if h.opts.EndTime, err = time.Parse(time.RFC3339, end); err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing end time as RFC-3339").Error(),
+			}
+		}
+	}
+	if limit := vals.Get("limit"); limit != "" {
+		if h.opts.Limit, err = strconv.Atoi(limit); err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing limit").Error(),
+			}
+		}
+	}
+
+	if err = h.opts.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "invalid event export").Error(),
+		}
+	}
+
+	return nil
+}
+
+func (h *eventExportHandler) Run(ctx context.Context) gimlet.Responder {
+	events, next, err := event.FindForExport(h.opts)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "exporting events"))
+	}
+
+	resp := model.APIEventExportResponse{
+		Events: make([]model.APIExportedEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		apiEvent := model.APIExportedEvent{}
+		apiEvent.BuildFromService(e)
+		resp.Events = append(resp.Events, apiEvent)
+	}
+	if !utility.IsZeroTime(next) {
+		resp.NextStart = &next
+	}
+
+	return gimlet.NewJSONResponse(resp)
+}
+
+// splitQueryList returns the values of a query parameter that can be
+// repeated or given as a comma-separated list.
+func splitQueryList(vals []string) []string {
+	var out []string
+	for _, val := range vals {
+		for _, v := range strings.Split(val, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/event"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventExportHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(event.EventCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(event.EventCollection))
+	}()
+
+	ts := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, e := range []event.EventLogEntry{
+		{ResourceType: event.ResourceTypeHost, ResourceId: "h1", EventType: event.EventHostCreated, Timestamp: ts, Data: &event.HostEventData{}},
+		{ResourceType: event.ResourceTypeHost, ResourceId: "h2", EventType: event.EventHostCreated, Timestamp: ts.Add(time.Minute), Data: &event.HostEventData{}},
+		{ResourceType: event.ResourceTypeAdmin, EventType: event.EventTypeValueChanged, Timestamp: ts, Data: &event.AdminEventData{Section: "tracer"}},
+	} {
+		require.NoError(t, e.Log())
+	}
+
+	t.Run("FiltersByResourceType", func(t *testing.T) {
+		rh := makeExportEvents()
+		req, err := http.NewRequest(http.MethodGet, "/admin/events/export?resource_type=HOST&start_time="+ts.Add(-time.Minute).Format(time.RFC3339), nil)
+		require.NoError(t, err)
+		require.NoError(t, rh.Parse(ctx, req))
+
+		resp := rh.Run(ctx)
+		require.Equal(t, http.StatusOK, resp.Status())
+		export, ok := resp.Data().(restModel.APIEventExportResponse)
+		require.True(t, ok)
+		require.Len(t, export.Events, 2)
+		assert.Equal(t, "h1", utility.FromStringPtr(export.Events[0].ResourceId))
+		assert.Equal(t, "h2", utility.FromStringPtr(export.Events[1].ResourceId))
+		assert.NotEmpty(t, utility.FromStringPtr(export.Events[0].ID))
+		assert.Nil(t, export.NextStart)
+	})
+	t.Run("FailsWithInvalidTimeRange", func(t *testing.T) {
+		rh := makeExportEvents()
+		req, err := http.NewRequest(http.MethodGet, "/admin/events/export?start_time="+time.Now().Add(time.Hour).Format(time.RFC3339), nil)
+		require.NoError(t, err)
+		assert.Error(t, rh.Parse(ctx, req))
+	})
+	t.Run("FailsWithInvalidLimit", func(t *testing.T) {
+		rh := makeExportEvents()
+		req, err := http.NewRequest(http.MethodGet, "/admin/events/export?limit=abc", nil)
+		require.NoError(t, err)
+		assert.Error(t, rh.Parse(ctx, req))
+	})
+}
@@ -2,6 +2,7 @@ package route
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/repotracker"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/units"
@@ -29,14 +31,23 @@ const (
 	githubActionSynchronize = "synchronize"
 	githubActionReopened    = "reopened"
 
+	// merge queue events, which the GitHub client library does not support
+	githubMergeGroupEventType     = "merge_group"
+	githubActionChecksRequested   = "checks_requested"
+	githubActionMergeGroupDestroy = "destroyed"
+
 	// pull request comments
 	retryComment            = "evergreen retry"
 	refreshStatusComment    = "evergreen refresh"
 	patchComment            = "evergreen patch"
+	restartComment          = "evergreen restart"
+	abortComment            = "evergreen abort"
+	runComment              = "evergreen run"
 	commitQueueMergeComment = "evergreen merge"
 	evergreenHelpComment    = "evergreen help"
 
-	refTags = "refs/tags/"
+	refTags  = "refs/tags/"
+	refHeads = "refs/heads/"
 )
 
 type githubHookApi struct {
@@ -90,6 +101,15 @@ func (gh *githubHookApi) Parse(ctx context.Context, r *http.Request) error {
 		return errors.Wrap(err, "reading and validating GitHub request payload")
 	}
 
+	if gh.eventType == githubMergeGroupEventType {
+		mergeGroupEvent := &githubMergeGroupEvent{}
+		if err = json.Unmarshal(body, mergeGroupEvent); err != nil {
+			return errors.Wrap(err, "parsing merge group webhook")
+		}
+		gh.event = mergeGroupEvent
+		return nil
+	}
+
 	gh.event, err = github.ParseWebHook(gh.eventType, body)
 	if err != nil {
 		return errors.Wrap(err, "parsing webhook")
@@ -142,7 +162,7 @@ func (gh *githubHookApi) Run(ctx context.Context) gimlet.Responder {
 				"user":      *event.Sender.Login,
 				"message":   "PR accepted, attempting to queue",
 			})
-			if err := gh.AddIntentForPR(event.PullRequest, event.Sender.GetLogin(), patch.AutomatedCaller); err != nil {
+			if err := gh.AddIntentForPR(event.PullRequest, event.Sender.GetLogin(), patch.AutomatedCaller, ""); err != nil {
 				grip.Error(message.WrapError(err, message.Fields{
 					"source":    "GitHub hook",
 					"msg_id":    gh.msgID,
@@ -202,6 +222,18 @@ func (gh *githubHookApi) Run(ctx context.Context) gimlet.Responder {
 			return gimlet.MakeJSONInternalErrorResponder(err)
 		}
 
+	case *githubMergeGroupEvent:
+		switch event.Action {
+		case githubActionChecksRequested:
+			if err := gh.handleMergeGroupChecksRequested(ctx, event); err != nil {
+				return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "handling merge group checks request"))
+			}
+		case githubActionMergeGroupDestroy:
+			if err := gh.handleMergeGroupDestroyed(event); err != nil {
+				return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "handling destroyed merge group"))
+			}
+		}
+
 	case *github.MetaEvent:
 		if event.GetAction() == "deleted" {
 			hookID := event.GetHookID()
@@ -244,33 +276,22 @@ func (gh *githubHookApi) handleComment(ctx context.Context, event *github.IssueC
 		return errors.Wrap(err, "enqueueing in commit queue")
 	}
 
-	if triggerPatch, callerType := triggersPatch(commentBody); triggerPatch {
-		grip.Info(gh.getCommentLogWithMessage(event, fmt.Sprintf("'%s' triggered", commentBody)))
-
-		err := gh.createPRPatch(ctx, event.Repo.Owner.GetLogin(), event.Repo.GetName(), callerType, event.Issue.GetNumber())
-		grip.Error(message.WrapError(err, gh.getCommentLogWithMessage(event,
-			fmt.Sprintf("can't create PR for '%s'", commentBody))))
-		return errors.Wrap(err, "creating patch")
-	}
-
-	if triggersStatusRefresh(commentBody) {
-		grip.Info(gh.getCommentLogWithMessage(event, fmt.Sprintf("'%s' triggered", commentBody)))
-
-		err := gh.refreshPatchStatus(ctx, event.Repo.Owner.GetLogin(), event.Repo.GetName(), event.Issue.GetNumber())
-		grip.Error(message.WrapError(err, gh.getCommentLogWithMessage(event,
-			"problem triggering status refresh")))
-		return errors.Wrap(err, "triggering status refresh")
+	cmd, err := parseGithubCommentCommand(commentBody)
+	if err != nil {
+		grip.Info(message.WrapError(err, gh.getCommentLogWithMessage(event, "invalid comment command")))
+		err = gh.replyToComment(ctx, event, err.Error())
+		grip.Error(message.WrapError(err, gh.getCommentLogWithMessage(event, "problem replying to invalid comment command")))
+		return errors.Wrap(err, "replying to invalid comment command")
 	}
-
-	if triggersHelpText(commentBody) {
-		grip.Info(gh.getCommentLogWithMessage(event, fmt.Sprintf("'%s' triggered", commentBody)))
-		err := gh.displayHelpText(ctx, event.Repo.Owner.GetLogin(), event.Repo.GetName(), event.Issue.GetNumber())
-		grip.Error(message.WrapError(err, gh.getCommentLogWithMessage(event,
-			"problem sending help comment")))
-		return errors.Wrap(err, "sending help comment")
+	if cmd == nil {
+		return nil
 	}
 
-	return nil
+	grip.Info(gh.getCommentLogWithMessage(event, fmt.Sprintf("'%s' triggered", commentBody)))
+	err = gh.runCommentCommand(ctx, event, cmd)
+	grip.Error(message.WrapError(err, gh.getCommentLogWithMessage(event,
+		fmt.Sprintf("problem running comment command '%s'", cmd.name))))
+	return errors.Wrapf(err, "running comment command '%s'", cmd.name)
 }
 
 func (gh *githubHookApi) getCommentLogWithMessage(event *github.IssueCommentEvent, msg string) message.Fields {
@@ -347,15 +368,22 @@ func getHelpTextFromProjects(repoRef *model.RepoRef, projectRefs []model.Project
 	formatStrStrikethrough := "- ~`%s`~ \n    - %s\n"
 	res := fmt.Sprintf("### %s\n", "Available Evergreen Comment Commands")
 	if autoPRProjectEnabled {
-		res += fmt.Sprintf(formatStr, retryComment, "attempts to create a new PR patch; "+
-			"this is useful when something went wrong with automatically creating PR patches")
+		res += fmt.Sprintf(formatStr, githubCommentCommands["retry"].usage, "attempts to create a new PR patch; "+
+			"this is useful when something went wrong with automatically creating PR patches. "+
+			"`--alias` runs the tasks in the given patch alias instead and requires write permission")
 	}
 	if manualPRProjectEnabled {
-		res += fmt.Sprintf(formatStr, patchComment, "attempts to create a new PR patch; "+""+
-			"this is required to create a PR patch when only manual PR testing is enabled")
+		res += fmt.Sprintf(formatStr, githubCommentCommands["patch"].usage, "attempts to create a new PR patch; "+""+
+			"this is required to create a PR patch when only manual PR testing is enabled. "+
+			"`--alias` runs the tasks in the given patch alias instead and requires write permission")
 	}
 	if autoPRProjectEnabled || manualPRProjectEnabled {
 		res += fmt.Sprintf(formatStr, refreshStatusComment, "resyncs PR GitHub checks")
+		res += fmt.Sprintf(formatStr, githubCommentCommands["restart"].usage, "restarts the finished tasks in the latest PR patch, "+
+			"or only the failed tasks with `--failed`; requires write permission")
+		res += fmt.Sprintf(formatStr, abortComment, "aborts the latest PR patch; requires write permission")
+		res += fmt.Sprintf(formatStr, githubCommentCommands["run"].usage, "schedules the given tasks on the given variants "+
+			"in the latest PR patch; requires write permission")
 	}
 	if cqProjectEnabled {
 		res += fmt.Sprintf(formatStr, commitQueueMergeComment, "adds PR to the commit queue")
@@ -370,7 +398,7 @@ func getHelpTextFromProjects(repoRef *model.RepoRef, projectRefs []model.Project
 	return res
 }
 
-func (gh *githubHookApi) createPRPatch(ctx context.Context, owner, repo, calledBy string, prNumber int) error {
+func (gh *githubHookApi) createPRPatch(ctx context.Context, owner, repo, calledBy, alias string, prNumber int) error {
 	settings, err := evergreen.GetConfig()
 	if err != nil {
 		return errors.Wrap(err, "getting admin settings")
@@ -385,28 +413,38 @@ func (gh *githubHookApi) createPRPatch(ctx context.Context, owner, repo, calledB
 		return errors.Wrapf(err, "getting PR for repo '%s:%s', PR #%d", owner, repo, prNumber)
 	}
 
-	return gh.AddIntentForPR(pr, pr.User.GetLogin(), calledBy)
+	return gh.AddIntentForPR(pr, pr.User.GetLogin(), calledBy, alias)
+}
+
+func (gh *githubHookApi) refreshPatchStatus(ctx context.Context, owner, repo string, prNumber int) (*patch.Patch, error) {
+	p, err := findLatestFinalizedPRPatch(owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	job := units.NewGithubStatusRefreshJob(p)
+	job.Run(ctx)
+	return p, nil
 }
 
-func (gh *githubHookApi) refreshPatchStatus(ctx context.Context, owner, repo string, prNumber int) error {
+// findLatestFinalizedPRPatch returns the most recent patch for the PR, which
+// must have been finalized.
+func findLatestFinalizedPRPatch(owner, repo string, prNumber int) (*patch.Patch, error) {
 	p, err := patch.FindLatestGithubPRPatch(owner, repo, prNumber)
 	if err != nil {
-		return errors.Wrap(err, "finding patch")
+		return nil, errors.Wrap(err, "finding patch")
 	}
 	if p == nil {
-		return errors.Errorf("couldn't find patch for PR '%s/%s:%d'", owner, repo, prNumber)
+		return nil, errors.Errorf("couldn't find patch for PR '%s/%s:%d'", owner, repo, prNumber)
 	}
 	if p.Version == "" {
-		return errors.Errorf("patch '%s' not finalized", p.Version)
+		return nil, errors.Errorf("patch '%s' not finalized", p.Id.Hex())
 	}
-
-	job := units.NewGithubStatusRefreshJob(p)
-	job.Run(ctx)
-	return nil
+	return p, nil
 }
 
-func (gh *githubHookApi) AddIntentForPR(pr *github.PullRequest, owner, calledBy string) error {
-	ghi, err := patch.NewGithubIntent(gh.msgID, owner, calledBy, pr)
+func (gh *githubHookApi) AddIntentForPR(pr *github.PullRequest, owner, calledBy, alias string) error {
+	ghi, err := patch.NewGithubIntent(gh.msgID, owner, calledBy, alias, pr)
 	if err != nil {
 		return errors.Wrap(err, "creating GitHub patch intent")
 	}
@@ -630,6 +668,184 @@ func (gh *githubHookApi) createVersionForTag(ctx context.Context, pRef model.Pro
 	return gh.sc.CreateVersionFromConfig(ctx, &projectInfo, metadata)
 }
 
+// githubMergeGroupEvent is the webhook payload that GitHub sends when a merge
+// group is added to or removed from a merge queue.
+type githubMergeGroupEvent struct {
+	Action string `json:"action"`
+	// Reason is why a destroyed merge group was removed from the queue.
+	Reason     string             `json:"reason"`
+	MergeGroup githubMergeGroup   `json:"merge_group"`
+	Repo       *github.Repository `json:"repository"`
+	Sender     *github.User       `json:"sender"`
+}
+
+type githubMergeGroup struct {
+	HeadSHA    string                 `json:"head_sha"`
+	HeadRef    string                 `json:"head_ref"`
+	BaseSHA    string                 `json:"base_sha"`
+	BaseRef    string                 `json:"base_ref"`
+	HeadCommit githubMergeGroupCommit `json:"head_commit"`
+}
+
+type githubMergeGroupCommit struct {
+	Message   string    `json:"message"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+func validateMergeGroupEvent(event *githubMergeGroupEvent) error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(event.Repo.GetOwner().GetLogin() == "" || event.Repo.GetName() == "", "repo is missing owner or name")
+	catcher.NewWhen(event.MergeGroup.HeadSHA == "", "merge group is missing head SHA")
+	catcher.NewWhen(!strings.HasPrefix(event.MergeGroup.BaseRef, refHeads), "merge group base ref is not a branch")
+	return catcher.Resolve()
+}
+
+// handleMergeGroupChecksRequested creates a version for the merge group's head
+// commit in each project that tracks the merge queue's branch and uses the
+// GitHub merge queue. The version's status is sent to GitHub as the merge
+// group's required check.
+func (gh *githubHookApi) handleMergeGroupChecksRequested(ctx context.Context, event *githubMergeGroupEvent) error {
+	if err := validateMergeGroupEvent(event); err != nil {
+		return errors.Wrap(err, "validating merge group event")
+	}
+	owner := event.Repo.GetOwner().GetLogin()
+	repo := event.Repo.GetName()
+	branch := strings.TrimPrefix(event.MergeGroup.BaseRef, refHeads)
+
+	projectRefs, err := model.FindMergedEnabledProjectRefsByRepoAndBranch(owner, repo, branch)
+	if err != nil {
+		return errors.Wrapf(err, "finding projects for '%s/%s' tracking branch '%s'", owner, repo, branch)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, pRef := range projectRefs {
+		if !pRef.IsGithubMergeQueueEnabled() {
+			continue
+		}
+		v, err := gh.createVersionForMergeGroup(ctx, pRef, event.MergeGroup)
+		if err != nil {
+			catcher.Wrapf(err, "creating version for merge group '%s' in project '%s'", event.MergeGroup.HeadRef, pRef.Identifier)
+			continue
+		}
+		if v == nil {
+			continue
+		}
+		catcher.Wrapf(repotracker.AddGithubMergeQueueSubscriptions(v), "adding GitHub merge queue subscriptions for version '%s'", v.Id)
+
+		grip.Info(message.Fields{
+			"source":   "GitHub hook",
+			"msg_id":   gh.msgID,
+			"event":    gh.eventType,
+			"owner":    owner,
+			"repo":     repo,
+			"head_ref": event.MergeGroup.HeadRef,
+			"head_sha": event.MergeGroup.HeadSHA,
+			"project":  pRef.Id,
+			"version":  v.Id,
+			"message":  "created version for merge group",
+		})
+	}
+
+	return catcher.Resolve()
+}
+
+// createVersionForMergeGroup creates a version that runs the tasks selected by
+// the project's merge queue alias on the merge group's head commit. It returns
+// a nil version if the project has no such aliases or if the version already
+// exists.
+func (gh *githubHookApi) createVersionForMergeGroup(ctx context.Context, pRef model.ProjectRef, mergeGroup githubMergeGroup) (*model.Version, error) {
+	// GitHub may redeliver the event, so don't create the version twice.
+	existing, err := model.VersionFind(model.VersionsByGithubMergeGroup(pRef.Owner, pRef.Repo, mergeGroup.HeadSHA).WithFields(model.VersionIdentifierKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "checking for existing versions")
+	}
+	for _, v := range existing {
+		if v.Identifier == pRef.Id {
+			return nil, nil
+		}
+	}
+
+	alias := pRef.GetGithubMergeQueueAlias()
+	aliases, err := model.FindAliasInProjectRepoOrConfig(pRef.Id, alias)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding aliases '%s'", alias)
+	}
+	if len(aliases) == 0 {
+		grip.Warning(message.Fields{
+			"source":   "GitHub hook",
+			"msg_id":   gh.msgID,
+			"event":    gh.eventType,
+			"project":  pRef.Id,
+			"alias":    alias,
+			"head_ref": mergeGroup.HeadRef,
+			"message":  "project uses the GitHub merge queue but has no aliases defined for it",
+		})
+		return nil, nil
+	}
+
+	token, err := gh.settings.GetGithubOauthToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting GitHub token")
+	}
+	projectInfo, err := model.GetProjectFromFile(ctx, model.GetProjectOpts{
+		Ref:        &pRef,
+		Revision:   mergeGroup.HeadSHA,
+		RemotePath: pRef.RemotePath,
+		Token:      token,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading project config at revision '%s'", mergeGroup.HeadSHA)
+	}
+	projectInfo.Ref = &pRef
+
+	metadata := model.VersionMetadata{
+		Revision: model.Revision{
+			Author:          mergeGroup.HeadCommit.Author.Name,
+			AuthorEmail:     mergeGroup.HeadCommit.Author.Email,
+			Revision:        mergeGroup.HeadSHA,
+			RevisionMessage: mergeGroup.HeadCommit.Message,
+			CreateTime:      mergeGroup.HeadCommit.Timestamp,
+		},
+		GithubMergeGroup: model.GithubMergeGroup{
+			HeadSHA: mergeGroup.HeadSHA,
+			HeadRef: mergeGroup.HeadRef,
+			BaseRef: mergeGroup.BaseRef,
+		},
+		Alias:    alias,
+		Activate: true,
+	}
+	return gh.sc.CreateVersionFromConfig(ctx, &projectInfo, metadata)
+}
+
+// handleMergeGroupDestroyed aborts the versions for a merge group that GitHub
+// has removed from the merge queue, either because it was merged, because a
+// check failed, or because a pull request in it was removed from the queue.
+func (gh *githubHookApi) handleMergeGroupDestroyed(event *githubMergeGroupEvent) error {
+	if err := validateMergeGroupEvent(event); err != nil {
+		return errors.Wrap(err, "validating merge group event")
+	}
+	owner := event.Repo.GetOwner().GetLogin()
+	repo := event.Repo.GetName()
+
+	grip.Info(message.Fields{
+		"source":   "GitHub hook",
+		"msg_id":   gh.msgID,
+		"event":    gh.eventType,
+		"owner":    owner,
+		"repo":     repo,
+		"head_ref": event.MergeGroup.HeadRef,
+		"head_sha": event.MergeGroup.HeadSHA,
+		"reason":   event.Reason,
+		"message":  "merge group destroyed; aborting versions",
+	})
+
+	return model.AbortGithubMergeGroupVersions(owner, repo, event.MergeGroup.HeadSHA, evergreen.GithubMergeUser)
+}
+
 func validatePushTagEvent(event *github.PushEvent) error {
 	if len(strings.Split(event.Repo.GetFullName(), "/")) != 2 {
 		return errors.New("repo name is invalid (expected [owner]/[repo])")
@@ -683,40 +899,12 @@ func trimComment(comment string) string {
 	return strings.Join(strings.Fields(strings.ToLower(comment)), " ")
 }
 
-func isRetryComment(comment string) bool {
-	return trimComment(comment) == retryComment
-}
-
-func isPatchComment(comment string) bool {
-	return trimComment(comment) == patchComment
-}
-
 // triggersCommitQueue checks if "evergreen merge" is present in the comment, as
 // it may be followed by a newline and a message.
 func triggersCommitQueue(comment string) bool {
 	return strings.HasPrefix(trimComment(comment), commitQueueMergeComment)
 }
 
-// The bool value returns whether the patch should be created or not.
-// The string value returns the correct caller for the command.
-func triggersPatch(comment string) (bool, string) {
-	if isPatchComment(comment) {
-		return true, patch.ManualCaller
-	}
-	if isRetryComment(comment) {
-		return true, patch.AllCallers
-	}
-
-	return false, ""
-}
-func triggersStatusRefresh(comment string) bool {
-	return trimComment(comment) == refreshStatusComment
-}
-
-func triggersHelpText(comment string) bool {
-	return trimComment(comment) == evergreenHelpComment
-}
-
 func isTag(ref string) bool {
 	return strings.Contains(ref, refTags)
 }
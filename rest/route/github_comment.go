@@ -0,0 +1,381 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/evergreen-ci/utility"
+	"github.com/google/go-github/v34/github"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+// GitHub repository permission levels, as returned by the GitHub API, from
+// least to most privileged.
+const (
+	githubPermissionNone  = "none"
+	githubPermissionRead  = "read"
+	githubPermissionWrite = "write"
+	githubPermissionAdmin = "admin"
+)
+
+var githubPermissionRanks = map[string]int{
+	githubPermissionNone:  0,
+	githubPermissionRead:  1,
+	githubPermissionWrite: 2,
+	githubPermissionAdmin: 3,
+}
+
+// githubCommentCommandSpec describes the arguments a PR comment command
+// accepts and who is allowed to run it.
+type githubCommentCommandSpec struct {
+	usage string
+	// flags maps each flag the command accepts to whether the flag takes a
+	// value.
+	flags map[string]bool
+	// params are the keys the command accepts as key=value arguments.
+	params []string
+	// requiredParams are the params that must be given at least once.
+	requiredParams []string
+	// permission is the minimum GitHub permission level the commenter must
+	// have on the repository to run the command.
+	permission string
+	// flagPermissions raises the required permission level when the flag is
+	// given.
+	flagPermissions map[string]string
+}
+
+// githubCommentCommands are the PR comment commands, keyed by the word that
+// follows "evergreen" in the comment. The commit queue's "evergreen merge" is
+// handled separately since everything that follows it is the commit message.
+var githubCommentCommands = map[string]githubCommentCommandSpec{
+	"patch": {
+		usage:           patchComment + " [--alias <alias>]",
+		flags:           map[string]bool{"alias": true},
+		permission:      githubPermissionNone,
+		flagPermissions: map[string]string{"alias": githubPermissionWrite},
+	},
+	"retry": {
+		usage:           retryComment + " [--alias <alias>]",
+		flags:           map[string]bool{"alias": true},
+		permission:      githubPermissionNone,
+		flagPermissions: map[string]string{"alias": githubPermissionWrite},
+	},
+	"refresh": {
+		usage:      refreshStatusComment,
+		permission: githubPermissionNone,
+	},
+	"restart": {
+		usage:      restartComment + " [--failed]",
+		flags:      map[string]bool{"failed": false},
+		permission: githubPermissionWrite,
+	},
+	"abort": {
+		usage:      abortComment,
+		permission: githubPermissionWrite,
+	},
+	"run": {
+		usage:          runComment + " task=<task> [task=<task>...] variant=<variant> [variant=<variant>...]",
+		params:         []string{"task", "variant"},
+		requiredParams: []string{"task", "variant"},
+		permission:     githubPermissionWrite,
+	},
+	"help": {
+		usage:      evergreenHelpComment,
+		permission: githubPermissionNone,
+	},
+}
+
+// githubCommentCommand is a command parsed from a PR comment.
+type githubCommentCommand struct {
+	name   string
+	flags  map[string]string
+	params map[string][]string
+}
+
+// parseGithubCommentCommand parses a PR comment of the form
+// "evergreen <command> [--flag] [--flag <value>] [key=value]...". It returns
+// nil if the comment is not an Evergreen command, and an error describing the
+// command's usage if the command's arguments are invalid.
+func parseGithubCommentCommand(comment string) (*githubCommentCommand, error) {
+	fields := strings.Fields(comment)
+	if len(fields) < 2 || strings.ToLower(fields[0]) != "evergreen" {
+		return nil, nil
+	}
+	name := strings.ToLower(fields[1])
+	spec, ok := githubCommentCommands[name]
+	if !ok {
+		return nil, nil
+	}
+
+	cmd := &githubCommentCommand{
+		name:   name,
+		flags:  map[string]string{},
+		params: map[string][]string{},
+	}
+	args := fields[2:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			parts := strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)
+			flag := strings.ToLower(parts[0])
+			takesValue, ok := spec.flags[flag]
+			if !ok {
+				return nil, errors.Errorf("unknown flag '--%s'; usage: `%s`", flag, spec.usage)
+			}
+			var value string
+			if len(parts) == 2 {
+				value = parts[1]
+			} else if takesValue && i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+				value = args[i]
+			}
+			if takesValue && value == "" {
+				return nil, errors.Errorf("flag '--%s' requires a value; usage: `%s`", flag, spec.usage)
+			}
+			if !takesValue && value != "" {
+				return nil, errors.Errorf("flag '--%s' does not take a value; usage: `%s`", flag, spec.usage)
+			}
+			cmd.flags[flag] = value
+		case strings.Contains(arg, "="):
+			parts := strings.SplitN(arg, "=", 2)
+			key := strings.ToLower(parts[0])
+			if !utility.StringSliceContains(spec.params, key) {
+				return nil, errors.Errorf("unknown argument '%s'; usage: `%s`", key, spec.usage)
+			}
+			if parts[1] == "" {
+				return nil, errors.Errorf("argument '%s' requires a value; usage: `%s`", key, spec.usage)
+			}
+			cmd.params[key] = append(cmd.params[key], parts[1])
+		default:
+			return nil, errors.Errorf("unexpected argument '%s'; usage: `%s`", arg, spec.usage)
+		}
+	}
+	for _, key := range spec.requiredParams {
+		if len(cmd.params[key]) == 0 {
+			return nil, errors.Errorf("missing required argument '%s'; usage: `%s`", key, spec.usage)
+		}
+	}
+
+	return cmd, nil
+}
+
+// String returns the command in its canonical form.
+func (c *githubCommentCommand) String() string {
+	parts := []string{"evergreen", c.name}
+	flags := make([]string, 0, len(c.flags))
+	for flag := range c.flags {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	for _, flag := range flags {
+		if value := c.flags[flag]; value != "" {
+			parts = append(parts, fmt.Sprintf("--%s %s", flag, value))
+		} else {
+			parts = append(parts, "--"+flag)
+		}
+	}
+	for _, key := range githubCommentCommands[c.name].params {
+		for _, value := range c.params[key] {
+			parts = append(parts, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// requiredPermission returns the minimum GitHub permission level needed to
+// run the command with its given flags.
+func (c *githubCommentCommand) requiredPermission() string {
+	spec := githubCommentCommands[c.name]
+	required := spec.permission
+	for flag := range c.flags {
+		if permission, ok := spec.flagPermissions[flag]; ok && !hasGithubPermission(required, permission) {
+			required = permission
+		}
+	}
+	return required
+}
+
+// hasGithubPermission returns whether the permission level is at least the
+// required level. Unrecognized permission levels have no permissions.
+func hasGithubPermission(permission, required string) bool {
+	rank, ok := githubPermissionRanks[permission]
+	if !ok {
+		return false
+	}
+	return rank >= githubPermissionRanks[required]
+}
+
+// runCommentCommand checks that the commenter is allowed to run the command,
+// runs it, and replies to the comment with a summary of what was done.
+func (gh *githubHookApi) runCommentCommand(ctx context.Context, event *github.IssueCommentEvent, cmd *githubCommentCommand) error {
+	owner := event.Repo.Owner.GetLogin()
+	repo := event.Repo.GetName()
+	prNum := event.Issue.GetNumber()
+	user := event.Comment.User.GetLogin()
+
+	if required := cmd.requiredPermission(); required != githubPermissionNone {
+		permission, err := gh.sc.GetGitHubUserPermissionLevel(ctx, data.UserRepoInfo{
+			Username: user,
+			Owner:    owner,
+			Repo:     repo,
+		})
+		if err != nil {
+			return errors.Wrap(err, "getting commenter's GitHub permission level")
+		}
+		if !hasGithubPermission(permission, required) {
+			return gh.replyToComment(ctx, event, fmt.Sprintf("`%s` requires '%s' permission on this repository.", cmd, required))
+		}
+	}
+
+	var (
+		reply string
+		err   error
+	)
+	switch cmd.name {
+	case "help":
+		return gh.displayHelpText(ctx, owner, repo, prNum)
+	case "patch", "retry":
+		calledBy := patch.ManualCaller
+		if cmd.name == "retry" {
+			calledBy = patch.AllCallers
+		}
+		alias := cmd.flags["alias"]
+		err = gh.createPRPatch(ctx, owner, repo, calledBy, alias, prNum)
+		reply = "Creating a new patch for this PR."
+		if alias != "" {
+			reply = fmt.Sprintf("Creating a new patch for this PR with alias '%s'.", alias)
+		}
+	case "refresh":
+		var p *patch.Patch
+		p, err = gh.refreshPatchStatus(ctx, owner, repo, prNum)
+		if err == nil {
+			reply = fmt.Sprintf("Refreshed the GitHub statuses for [the latest patch](%s).", gh.versionURL(p.Version))
+		}
+	case "restart":
+		_, failedOnly := cmd.flags["failed"]
+		reply, err = gh.restartPRPatchTasks(owner, repo, prNum, user, failedOnly)
+	case "abort":
+		reply, err = gh.abortPRPatch(owner, repo, prNum, user)
+	case "run":
+		reply, err = gh.runPRPatchTasks(ctx, owner, repo, prNum, cmd.params["variant"], cmd.params["task"])
+	default:
+		return errors.Errorf("unhandled comment command '%s'", cmd.name)
+	}
+	if err != nil {
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(err)
+		catcher.Wrap(gh.replyToComment(ctx, event, fmt.Sprintf("`%s` failed: %s", cmd, err.Error())), "replying to comment")
+		return catcher.Resolve()
+	}
+
+	return gh.replyToComment(ctx, event, reply)
+}
+
+// replyToComment adds a comment to the PR mentioning the commenter.
+func (gh *githubHookApi) replyToComment(ctx context.Context, event *github.IssueCommentEvent, msg string) error {
+	reply := fmt.Sprintf("@%s %s", event.Comment.User.GetLogin(), msg)
+	return gh.sc.AddCommentToPR(ctx, event.Repo.Owner.GetLogin(), event.Repo.GetName(), event.Issue.GetNumber(), reply)
+}
+
+func (gh *githubHookApi) versionURL(versionID string) string {
+	return fmt.Sprintf("%s/version/%s?redirect_spruce_users=true", gh.settings.Ui.Url, versionID)
+}
+
+// restartPRPatchTasks restarts the finished tasks in the PR's latest patch. If
+// failedOnly is true, only failed tasks are restarted.
+func (gh *githubHookApi) restartPRPatchTasks(owner, repo string, prNum int, caller string, failedOnly bool) (string, error) {
+	p, err := findLatestFinalizedPRPatch(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+
+	query := task.ByVersion(p.Version)
+	if failedOnly {
+		query = task.FailedTasksByVersion(p.Version)
+	}
+	tasks, err := task.Find(query)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding tasks for version '%s'", p.Version)
+	}
+	var taskIDs []string
+	for _, t := range tasks {
+		if t.IsFinished() {
+			taskIDs = append(taskIDs, t.Id)
+		}
+	}
+	description := "finished"
+	if failedOnly {
+		description = "failed"
+	}
+	if len(taskIDs) == 0 {
+		return fmt.Sprintf("There are no %s tasks to restart in [the latest patch](%s).", description, gh.versionURL(p.Version)), nil
+	}
+	if err = model.RestartVersion(p.Version, taskIDs, false, caller); err != nil {
+		return "", errors.Wrapf(err, "restarting tasks in version '%s'", p.Version)
+	}
+
+	return fmt.Sprintf("Restarted %d %s task(s) in [the latest patch](%s).", len(taskIDs), description, gh.versionURL(p.Version)), nil
+}
+
+// abortPRPatch aborts the in-progress tasks and deactivates the undispatched
+// tasks in the PR's latest patch.
+func (gh *githubHookApi) abortPRPatch(owner, repo string, prNum int, caller string) (string, error) {
+	p, err := findLatestFinalizedPRPatch(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	if err = model.CancelPatch(p, task.AbortInfo{User: caller}); err != nil {
+		return "", errors.Wrapf(err, "aborting patch '%s'", p.Id.Hex())
+	}
+
+	return fmt.Sprintf("Aborted [the latest patch](%s).", gh.versionURL(p.Version)), nil
+}
+
+// runPRPatchTasks adds the given tasks in each of the given variants to the
+// PR's latest patch and schedules them.
+func (gh *githubHookApi) runPRPatchTasks(ctx context.Context, owner, repo string, prNum int, variants, tasks []string) (string, error) {
+	p, err := findLatestFinalizedPRPatch(owner, repo, prNum)
+	if err != nil {
+		return "", err
+	}
+	v, err := model.VersionFindOneId(p.Version)
+	if err != nil {
+		return "", errors.Wrapf(err, "finding version '%s'", p.Version)
+	}
+	if v == nil {
+		return "", errors.Errorf("version '%s' not found", p.Version)
+	}
+
+	var toAdd []patch.VariantTasks
+	for _, variant := range variants {
+		toAdd = append(toAdd, patch.VariantTasks{Variant: variant, Tasks: tasks})
+	}
+	patchUpdate := model.PatchUpdate{
+		Description:   p.Description,
+		VariantsTasks: patch.MergeVariantsTasks(p.VariantsTasks, toAdd),
+	}
+	if _, err = units.SchedulePatch(ctx, evergreen.GetEnvironment(), p.Id.Hex(), v, patchUpdate); err != nil {
+		return "", errors.Wrap(err, "scheduling tasks")
+	}
+
+	return fmt.Sprintf("Scheduled task(s) %s on variant(s) %s in [the latest patch](%s).",
+		formatCommentList(tasks), formatCommentList(variants), gh.versionURL(p.Version)), nil
+}
+
+func formatCommentList(items []string) string {
+	quoted := make([]string, 0, len(items))
+	for _, item := range items {
+		quoted = append(quoted, fmt.Sprintf("`%s`", item))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,179 @@
+package route
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/utility"
+	"github.com/google/go-github/v34/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGithubCommentCommand(t *testing.T) {
+	t.Run("IgnoresNonCommands", func(t *testing.T) {
+		for _, comment := range []string{"", "lgtm", "evergreen", "evergreen is green", "please evergreen patch"} {
+			cmd, err := parseGithubCommentCommand(comment)
+			assert.NoError(t, err, comment)
+			assert.Nil(t, cmd, comment)
+		}
+	})
+	t.Run("CommandWithoutArguments", func(t *testing.T) {
+		cmd, err := parseGithubCommentCommand(" \n Evergreen   ABORT \n")
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		assert.Equal(t, "abort", cmd.name)
+		assert.Empty(t, cmd.flags)
+		assert.Empty(t, cmd.params)
+		assert.Equal(t, abortComment, cmd.String())
+	})
+	t.Run("FlagWithSeparateValue", func(t *testing.T) {
+		cmd, err := parseGithubCommentCommand("evergreen patch --alias perf")
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		assert.Equal(t, "patch", cmd.name)
+		assert.Equal(t, "perf", cmd.flags["alias"])
+		assert.Equal(t, "evergreen patch --alias perf", cmd.String())
+	})
+	t.Run("FlagWithInlineValue", func(t *testing.T) {
+		cmd, err := parseGithubCommentCommand("evergreen retry --alias=Perf")
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		assert.Equal(t, "Perf", cmd.flags["alias"])
+	})
+	t.Run("BooleanFlag", func(t *testing.T) {
+		cmd, err := parseGithubCommentCommand("evergreen restart --failed")
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		_, ok := cmd.flags["failed"]
+		assert.True(t, ok)
+	})
+	t.Run("Params", func(t *testing.T) {
+		cmd, err := parseGithubCommentCommand("evergreen run task=lint variant=ubuntu task=compile")
+		require.NoError(t, err)
+		require.NotNil(t, cmd)
+		assert.Equal(t, []string{"lint", "compile"}, cmd.params["task"])
+		assert.Equal(t, []string{"ubuntu"}, cmd.params["variant"])
+		assert.Equal(t, "evergreen run task=lint task=compile variant=ubuntu", cmd.String())
+	})
+	t.Run("InvalidArguments", func(t *testing.T) {
+		for _, comment := range []string{
+			"evergreen patch --alias",
+			"evergreen patch --foo",
+			"evergreen restart --failed=true",
+			"evergreen abort now",
+			"evergreen run task=lint",
+			"evergreen run task= variant=ubuntu",
+			"evergreen run task=lint variant=ubuntu distro=rhel",
+		} {
+			cmd, err := parseGithubCommentCommand(comment)
+			assert.Error(t, err, comment)
+			assert.Contains(t, err.Error(), "usage", comment)
+			assert.Nil(t, cmd, comment)
+		}
+	})
+}
+
+func TestGithubCommentCommandRequiredPermission(t *testing.T) {
+	for comment, expected := range map[string]string{
+		"evergreen help":                        githubPermissionNone,
+		"evergreen refresh":                     githubPermissionNone,
+		"evergreen patch":                       githubPermissionNone,
+		"evergreen patch --alias perf":          githubPermissionWrite,
+		"evergreen restart --failed":            githubPermissionWrite,
+		"evergreen abort":                       githubPermissionWrite,
+		"evergreen run task=lint variant=linux": githubPermissionWrite,
+	} {
+		cmd, err := parseGithubCommentCommand(comment)
+		require.NoError(t, err, comment)
+		require.NotNil(t, cmd, comment)
+		assert.Equal(t, expected, cmd.requiredPermission(), comment)
+	}
+
+	assert.True(t, hasGithubPermission(githubPermissionAdmin, githubPermissionWrite))
+	assert.True(t, hasGithubPermission(githubPermissionWrite, githubPermissionWrite))
+	assert.False(t, hasGithubPermission(githubPermissionRead, githubPermissionWrite))
+	assert.False(t, hasGithubPermission("", githubPermissionWrite))
+	assert.True(t, hasGithubPermission(githubPermissionNone, githubPermissionNone))
+}
+
+func TestAbortCommentCommand(t *testing.T) {
+	require.NoError(t, db.ClearCollections(patch.Collection, model.VersionCollection, build.Collection, task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(patch.Collection, model.VersionCollection, build.Collection, task.Collection))
+	}()
+
+	p := patch.Patch{
+		Id:      mgobson.NewObjectId(),
+		Version: "v0",
+		Alias:   evergreen.GithubPRAlias,
+		GithubPatchData: thirdparty.GithubPatch{
+			PRNumber:  5,
+			BaseOwner: "evergreen-ci",
+			BaseRepo:  "evergreen",
+		},
+	}
+	require.NoError(t, p.Insert())
+	v := model.Version{
+		Id:        "v0",
+		Requester: evergreen.GithubPRRequester,
+		Activated: utility.TruePtr(),
+	}
+	require.NoError(t, v.Insert())
+	tsk := task.Task{
+		Id:        "t0",
+		Version:   v.Id,
+		Requester: evergreen.GithubPRRequester,
+		Status:    evergreen.TaskStarted,
+		Activated: true,
+	}
+	require.NoError(t, tsk.Insert())
+
+	sc := &data.MockGitHubConnector{}
+	gh := &githubHookApi{sc: sc, settings: testutil.TestConfig()}
+	event := &github.IssueCommentEvent{
+		Action: github.String("created"),
+		Issue: &github.Issue{
+			Number:           github.Int(5),
+			PullRequestLinks: &github.PullRequestLinks{},
+		},
+		Comment: &github.IssueComment{
+			Body: github.String("evergreen abort"),
+			User: &github.User{Login: github.String("octocat")},
+		},
+		Repo: &github.Repository{
+			Name:     github.String("evergreen"),
+			FullName: github.String("evergreen-ci/evergreen"),
+			Owner:    &github.User{Login: github.String("evergreen-ci")},
+		},
+		Sender: &github.User{Login: github.String("octocat")},
+	}
+	ctx := context.Background()
+
+	// Users without write permission can't abort the patch.
+	require.NoError(t, gh.handleComment(ctx, event))
+	dbTask, err := task.FindOneId(tsk.Id)
+	require.NoError(t, err)
+	require.NotNil(t, dbTask)
+	assert.False(t, dbTask.Aborted)
+
+	sc.UserPermissions = map[data.UserRepoInfo]string{
+		{Username: "octocat", Owner: "evergreen-ci", Repo: "evergreen"}: githubPermissionWrite,
+	}
+	require.NoError(t, gh.handleComment(ctx, event))
+	dbTask, err = task.FindOneId(tsk.Id)
+	require.NoError(t, err)
+	require.NotNil(t, dbTask)
+	assert.True(t, dbTask.Aborted)
+	assert.Equal(t, "octocat", dbTask.AbortInfo.User)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
@@ -303,18 +304,29 @@ func (s *GithubWebhookRouteSuite) TestRetryCommentTrigger() {
 	commentString := issueComment.Comment.GetBody()
 	s.Equal(retryComment, commentString)
 
-	s.True(triggersPatch(commentString))
+	cmd, err := parseGithubCommentCommand(commentString)
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("retry", cmd.name)
 
 	//test whitespace trimming
-	s.True(triggersPatch("  evergreen retry "))
+	cmd, err = parseGithubCommentCommand("  evergreen retry ")
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("retry", cmd.name)
 }
 
 func (s *GithubWebhookRouteSuite) TestRefreshStatusTrigger() {
-	s.True(triggersStatusRefresh(refreshStatusComment))
-	s.False(triggersStatusRefresh(retryComment))
+	cmd, err := parseGithubCommentCommand(refreshStatusComment)
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("refresh", cmd.name)
 
 	//test whitespace trimming
-	s.True(triggersStatusRefresh("  evergreen refresh "))
+	cmd, err = parseGithubCommentCommand("  evergreen refresh ")
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("refresh", cmd.name)
 }
 
 func (s *GithubWebhookRouteSuite) TestPatchCommentTrigger() {
@@ -327,10 +339,16 @@ func (s *GithubWebhookRouteSuite) TestPatchCommentTrigger() {
 	commentString := issueComment.Comment.GetBody()
 	s.Equal(patchComment, commentString)
 
-	s.True(triggersPatch(commentString))
+	cmd, err := parseGithubCommentCommand(commentString)
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("patch", cmd.name)
 
 	//test whitespace trimming
-	s.True(triggersPatch("  evergreen patch "))
+	cmd, err = parseGithubCommentCommand("  evergreen patch ")
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("patch", cmd.name)
 }
 
 func (s *CommitQueueSuite) TestCommentTrigger() {
@@ -347,12 +365,18 @@ func (s *CommitQueueSuite) TestCommentCleanup() {
 	retry := " \n Evergreen       \n  Retry \n "
 
 	s.False(triggersCommitQueue(patch))
-	s.False(isPatchComment(retry))
-	s.False(isRetryComment(trigger))
-
+	s.False(triggersCommitQueue(retry))
 	s.True(triggersCommitQueue(trigger))
-	s.True(isPatchComment(patch))
-	s.True(isRetryComment(retry))
+
+	cmd, err := parseGithubCommentCommand(patch)
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("patch", cmd.name)
+
+	cmd, err = parseGithubCommentCommand(retry)
+	s.NoError(err)
+	s.Require().NotNil(cmd)
+	s.Equal("retry", cmd.name)
 }
 
 func (s *GithubWebhookRouteSuite) TestUnknownEventType() {
@@ -401,6 +425,73 @@ func (s *GithubWebhookRouteSuite) TestCreateVersionForTag() {
 	s.NotNil(v)
 }
 
+func (s *GithubWebhookRouteSuite) TestMergeGroupEvent() {
+	s.NoError(db.ClearCollections(model.ProjectRefCollection, model.VersionCollection, task.Collection))
+	body, err := os.ReadFile(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "merge_group_event.json"))
+	s.Require().NoError(err)
+
+	ctx := context.Background()
+	req, err := makeRequest("1", githubMergeGroupEventType, body, []byte(s.conf.Api.GithubWebhookSecret))
+	s.Require().NoError(err)
+	s.Require().NoError(s.h.Parse(ctx, req))
+	event, ok := s.h.event.(*githubMergeGroupEvent)
+	s.Require().True(ok)
+	s.Equal(githubActionChecksRequested, event.Action)
+	s.Equal("ec26c3e57ca3a959ca5aad62de7213c562f8c821", event.MergeGroup.HeadSHA)
+	s.Equal("refs/heads/main", event.MergeGroup.BaseRef)
+	s.Equal("baxterthehacker", event.Repo.GetOwner().GetLogin())
+	s.Equal("public-repo", event.Repo.GetName())
+	s.Equal("baxterthehacker", event.MergeGroup.HeadCommit.Author.Name)
+
+	// A project that doesn't use the merge queue ignores the merge group.
+	pRef := model.ProjectRef{
+		Id:      "proj",
+		Owner:   "baxterthehacker",
+		Repo:    "public-repo",
+		Branch:  "main",
+		Enabled: true,
+	}
+	s.Require().NoError(pRef.Insert())
+	resp := s.h.Run(ctx)
+	s.Require().NotNil(resp)
+	s.Equal(http.StatusOK, resp.Status())
+	count, err := model.VersionCount(model.VersionsByGithubMergeGroup("baxterthehacker", "public-repo", event.MergeGroup.HeadSHA))
+	s.NoError(err)
+	s.Zero(count)
+
+	// Destroying the merge group aborts its versions.
+	v := model.Version{
+		Id:         "v0",
+		Identifier: "proj",
+		Owner:      "baxterthehacker",
+		Repo:       "public-repo",
+		Revision:   event.MergeGroup.HeadSHA,
+		Requester:  evergreen.GithubMergeRequester,
+		Activated:  utility.TruePtr(),
+	}
+	s.Require().NoError(v.Insert())
+	tsk := task.Task{
+		Id:        "t0",
+		Version:   v.Id,
+		Requester: evergreen.GithubMergeRequester,
+		Status:    evergreen.TaskStarted,
+		Activated: true,
+	}
+	s.Require().NoError(tsk.Insert())
+
+	event.Action = githubActionMergeGroupDestroy
+	event.Reason = "dequeued"
+	resp = s.h.Run(ctx)
+	s.Require().NotNil(resp)
+	s.Equal(http.StatusOK, resp.Status())
+
+	dbTask, err := task.FindOneId(tsk.Id)
+	s.Require().NoError(err)
+	s.Require().NotNil(dbTask)
+	s.True(dbTask.Aborted)
+	s.Equal(evergreen.GithubMergeUser, dbTask.AbortInfo.User)
+}
+
 func TestGetHelpTextFromProjects(t *testing.T) {
 	cqAndPREnabledProject := model.ProjectRef{
 		Id:      "cqEnabled",
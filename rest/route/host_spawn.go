@@ -12,6 +12,7 @@ import (
 	"github.com/evergreen-ci/evergreen/cloud"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
@@ -60,6 +61,12 @@ func (hph *hostPostHandler) Run(ctx context.Context) gimlet.Responder {
 		}
 	}
 
+	if hph.options.ReproduceTask {
+		if err := checkCanReproduceTask(user, hph.options.TaskID); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+	}
+
 	intentHost, err := data.NewIntentHost(ctx, hph.options, user, hph.settings)
 	if err != nil {
 		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "creating intent host"))
@@ -70,6 +77,42 @@ func (hph *hostPostHandler) Run(ctx context.Context) gimlet.Responder {
 	return gimlet.NewJSONResponse(hostModel)
 }
 
+// checkCanReproduceTask checks that the user can view the task that a spawn
+// host reproduces, since the task's environment is loaded onto the host.
+func checkCanReproduceTask(u *user.DBUser, taskID string) error {
+	if taskID == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a task to reproduce",
+		}
+	}
+	t, err := task.FindOneId(taskID)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    errors.Wrapf(err, "finding task '%s'", taskID).Error(),
+		}
+	}
+	if t == nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", taskID),
+		}
+	}
+	if !u.HasPermission(gimlet.PermissionOpts{
+		Resource:      t.Project,
+		ResourceType:  evergreen.ProjectResourceType,
+		Permission:    evergreen.PermissionTasks,
+		RequiredLevel: evergreen.TasksView.Value,
+	}) {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("not authorized to view task '%s'", taskID),
+		}
+	}
+	return nil
+}
+
 ////////////////////////////////////////////////////////////////////////
 //
 // PATCH /rest/v2/hosts/{host_id}
@@ -1121,6 +1164,60 @@ func (h *hostExtendExpirationHandler) Run(ctx context.Context) gimlet.Responder
 	return gimlet.NewJSONResponse(struct{}{})
 }
 
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/hosts/{host_id}/task_environment
+//
+
+type hostTaskEnvironmentHandler struct {
+	hostID string
+}
+
+func makeHostTaskEnvironment() gimlet.RouteHandler {
+	return &hostTaskEnvironmentHandler{}
+}
+
+func (h *hostTaskEnvironmentHandler) Factory() gimlet.RouteHandler {
+	return &hostTaskEnvironmentHandler{}
+}
+
+func (h *hostTaskEnvironmentHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	h.hostID, err = validateID(gimlet.GetVars(r)["host_id"])
+	return err
+}
+
+// Run returns the events for each step of loading the environment of the task
+// that the spawn host reproduces, in the order that they happened.
+func (h *hostTaskEnvironmentHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	foundHost, err := data.FindHostByIdWithOwner(h.hostID, u)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrapf(err, "finding host '%s' with user '%s'", h.hostID, u.Id))
+	}
+	if foundHost.ProvisionOptions == nil || !foundHost.ProvisionOptions.ReproduceTask {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("host '%s' does not reproduce a task", h.hostID),
+		})
+	}
+
+	events, err := event.Find(event.HostTaskEnvironmentEvents(foundHost.Id, foundHost.Tag))
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task environment events for host '%s'", h.hostID))
+	}
+	apiEvents := make([]model.HostAPIEventLogEntry, 0, len(events))
+	for _, e := range events {
+		apiEvent := model.HostAPIEventLogEntry{}
+		if err = apiEvent.BuildFromService(e); err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "converting host event to API model"))
+		}
+		apiEvents = append(apiEvents, apiEvent)
+	}
+
+	return gimlet.NewJSONResponse(apiEvents)
+}
+
 // //////////////////////////////////////////////////////////////////////
 //
 // POST /rest/v2/host/start_process
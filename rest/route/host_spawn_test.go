@@ -15,12 +15,14 @@ import (
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -117,6 +119,119 @@ func TestHostPostHandler(t *testing.T) {
 	assert.Equal("test_instance_type", *h3.InstanceType)
 }
 
+func TestHostPostHandlerReproduceTask(t *testing.T) {
+	require.NoError(t, db.ClearCollections(distro.Collection, host.Collection, task.Collection, evergreen.RoleCollection, evergreen.ScopeCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(distro.Collection, host.Collection, task.Collection, evergreen.RoleCollection, evergreen.ScopeCollection))
+	}()
+
+	config, err := evergreen.GetConfig()
+	require.NoError(t, err)
+	config.Spawnhost.SpawnHostsPerUser = 4
+	require.NoError(t, (&distro.Distro{
+		Id:           "task_distro",
+		SpawnAllowed: true,
+		Provider:     evergreen.ProviderNameMock,
+	}).Insert())
+	require.NoError(t, (&task.Task{Id: "t1", Project: "p1", DistroId: "task_distro"}).Insert())
+
+	rm := evergreen.GetEnvironment().RoleManager()
+	require.NoError(t, rm.AddScope(gimlet.Scope{
+		ID:        "p1_scope",
+		Resources: []string{"p1"},
+		Type:      evergreen.ProjectResourceType,
+	}))
+	require.NoError(t, rm.UpdateRole(gimlet.Role{
+		ID:          "p1_viewer",
+		Scope:       "p1_scope",
+		Permissions: gimlet.Permissions{evergreen.PermissionTasks: evergreen.TasksView.Value},
+	}))
+
+	h := &hostPostHandler{
+		settings: config,
+		options: &model.HostRequestOptions{
+			TaskID:        "t1",
+			ReproduceTask: true,
+			RunTaskSetup:  true,
+			KeyName:       "ssh-rsa YWJjZDEyMzQK",
+		},
+	}
+
+	t.Run("FailsWithoutPermissionToViewTask", func(t *testing.T) {
+		resp := h.Run(gimlet.AttachUser(context.Background(), &user.DBUser{Id: "other"}))
+		assert.Equal(t, http.StatusUnauthorized, resp.Status())
+	})
+	t.Run("FailsForNonexistentTask", func(t *testing.T) {
+		h.options.TaskID = "nonexistent"
+		defer func() {
+			h.options.TaskID = "t1"
+		}()
+		resp := h.Run(gimlet.AttachUser(context.Background(), &user.DBUser{Id: "user", SystemRoles: []string{"p1_viewer"}}))
+		assert.Equal(t, http.StatusNotFound, resp.Status())
+	})
+	t.Run("UsesTaskDistro", func(t *testing.T) {
+		resp := h.Run(gimlet.AttachUser(context.Background(), &user.DBUser{Id: "user", SystemRoles: []string{"p1_viewer"}}))
+		require.Equal(t, http.StatusOK, resp.Status())
+		apiHost, ok := resp.Data().(*model.APIHost)
+		require.True(t, ok)
+		assert.Equal(t, "task_distro", utility.FromStringPtr(apiHost.Distro.Id))
+
+		hosts, err := host.Find(host.ByUserWithRunningStatus("user"))
+		require.NoError(t, err)
+		require.Len(t, hosts, 1)
+		require.NotNil(t, hosts[0].ProvisionOptions)
+		assert.Equal(t, "t1", hosts[0].ProvisionOptions.TaskId)
+		assert.True(t, hosts[0].ProvisionOptions.ReproduceTask)
+		assert.True(t, hosts[0].ProvisionOptions.RunTaskSetup)
+	})
+}
+
+func TestHostTaskEnvironmentHandler(t *testing.T) {
+	require.NoError(t, db.ClearCollections(host.Collection, event.EventCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(host.Collection, event.EventCollection))
+	}()
+
+	require.NoError(t, (&host.Host{
+		Id:               "reproducing",
+		StartedBy:        "user",
+		ProvisionOptions: &host.ProvisionOptions{TaskId: "t1", ReproduceTask: true},
+	}).Insert())
+	require.NoError(t, (&host.Host{
+		Id:        "plain",
+		StartedBy: "user",
+	}).Insert())
+	event.LogHostCreated("reproducing")
+	event.LogHostTaskEnvironmentStepSucceeded("reproducing", "fetch-task-data", "")
+	event.LogHostTaskEnvironmentStepFailed("reproducing", "write-task-environment", errors.New("disk full"))
+
+	ctx := gimlet.AttachUser(context.Background(), &user.DBUser{Id: "user"})
+
+	t.Run("ReturnsStepEvents", func(t *testing.T) {
+		h := &hostTaskEnvironmentHandler{hostID: "reproducing"}
+		resp := h.Run(ctx)
+		require.Equal(t, http.StatusOK, resp.Status())
+		events, ok := resp.Data().([]model.HostAPIEventLogEntry)
+		require.True(t, ok)
+		require.Len(t, events, 2)
+		assert.Equal(t, "fetch-task-data", utility.FromStringPtr(events[0].Data.ProvisioningStep))
+		assert.True(t, events[0].Data.Successful)
+		assert.Equal(t, "write-task-environment", utility.FromStringPtr(events[1].Data.ProvisioningStep))
+		assert.False(t, events[1].Data.Successful)
+		assert.Equal(t, "disk full", utility.FromStringPtr(events[1].Data.Logs))
+	})
+	t.Run("FailsForHostNotReproducingTask", func(t *testing.T) {
+		h := &hostTaskEnvironmentHandler{hostID: "plain"}
+		resp := h.Run(ctx)
+		assert.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+	t.Run("FailsForOtherUsersHost", func(t *testing.T) {
+		h := &hostTaskEnvironmentHandler{hostID: "reproducing"}
+		resp := h.Run(gimlet.AttachUser(context.Background(), &user.DBUser{Id: "other"}))
+		assert.Equal(t, http.StatusUnauthorized, resp.Status())
+	})
+}
+
 func TestHostStopHandler(t *testing.T) {
 	require.NoError(t, db.ClearCollections(host.Collection, event.SubscriptionsCollection))
 	defer func() {
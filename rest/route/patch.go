@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -301,6 +302,88 @@ func (p *patchesByProjectHandler) Run(ctx context.Context) gimlet.Responder {
 	return resp
 }
 
+////////////////////////////////////////////////////////////////////////
+//
+// Handler for creating a patch from a ref in a GitHub repository
+//
+//    /projects/{project_id}/patches
+
+type remotePatchHandler struct {
+	req       model.APIRemotePatchRequest
+	projectId string
+	owner     string
+	repo      string
+	env       evergreen.Environment
+}
+
+func makeCreateRemotePatch(env evergreen.Environment) gimlet.RouteHandler {
+	return &remotePatchHandler{env: env}
+}
+
+func (p *remotePatchHandler) Factory() gimlet.RouteHandler {
+	return &remotePatchHandler{env: p.env}
+}
+
+func (p *remotePatchHandler) Parse(ctx context.Context, r *http.Request) error {
+	p.projectId = gimlet.GetVars(r)["project_id"]
+
+	body := utility.NewRequestReader(r)
+	defer body.Close()
+	if err := utility.ReadJSON(body, &p.req); err != nil {
+		return errors.Wrap(err, "reading patch options from JSON request body")
+	}
+	if utility.FromStringPtr(p.req.Ref) == "" {
+		return errors.New("must specify a ref to patch")
+	}
+	if repo := utility.FromStringPtr(p.req.Repo); repo != "" {
+		var err error
+		if p.owner, p.repo, err = parseRemotePatchRepo(repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseRemotePatchRepo parses a repository in the form "owner/name".
+func parseRemotePatchRepo(repo string) (string, string, error) {
+	parts := strings.Split(repo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("repo '%s' must be in the form 'owner/name'", repo)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (p *remotePatchHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	params := make([]patch.Parameter, 0, len(p.req.Parameters))
+	for _, param := range p.req.Parameters {
+		params = append(params, param.ToService())
+	}
+	ref := utility.FromStringPtr(p.req.Ref)
+	apiPatch, err := data.CreatePatchFromRemoteRef(ctx, p.env, data.RemotePatchOptions{
+		Project:     p.projectId,
+		Author:      u.Username(),
+		Ref:         ref,
+		Owner:       p.owner,
+		Repo:        p.repo,
+		Description: utility.FromStringPtr(p.req.Description),
+		Alias:       utility.FromStringPtr(p.req.Alias),
+		Variants:    p.req.Variants,
+		Tasks:       p.req.Tasks,
+		Parameters:  params,
+		Finalize:    p.req.Finalize,
+	})
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrapf(err, "creating patch from ref '%s'", ref))
+	}
+
+	responder := gimlet.NewJSONResponse(apiPatch)
+	if err = responder.SetStatus(http.StatusCreated); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "setting HTTP status code to %d", http.StatusCreated))
+	}
+	return responder
+}
+
 ////////////////////////////////////////////////////////////////////////
 //
 // Handler for aborting patches by id
@@ -1136,3 +1136,48 @@ tasks:
 		}
 	}
 }
+
+func TestRemotePatchHandlerParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for tName, tCase := range map[string]func(t *testing.T, h *remotePatchHandler){
+		"SucceedsWithRefInProjectRepo": func(t *testing.T, h *remotePatchHandler) {
+			req, err := http.NewRequest(http.MethodPost, "/projects/proj/patches", strings.NewReader(`{"ref": "feature", "finalize": true}`))
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "proj"})
+			require.NoError(t, h.Parse(ctx, req))
+			assert.Equal(t, "proj", h.projectId)
+			assert.Equal(t, "feature", utility.FromStringPtr(h.req.Ref))
+			assert.True(t, h.req.Finalize)
+			assert.Empty(t, h.owner)
+			assert.Empty(t, h.repo)
+		},
+		"SucceedsWithRefInFork": func(t *testing.T, h *remotePatchHandler) {
+			req, err := http.NewRequest(http.MethodPost, "/projects/proj/patches", strings.NewReader(`{"ref": "feature", "repo": "someone/evergreen"}`))
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "proj"})
+			require.NoError(t, h.Parse(ctx, req))
+			assert.Equal(t, "someone", h.owner)
+			assert.Equal(t, "evergreen", h.repo)
+		},
+		"FailsWithoutRef": func(t *testing.T, h *remotePatchHandler) {
+			req, err := http.NewRequest(http.MethodPost, "/projects/proj/patches", strings.NewReader(`{"repo": "someone/evergreen"}`))
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "proj"})
+			assert.Error(t, h.Parse(ctx, req))
+		},
+		"FailsWithMalformedRepo": func(t *testing.T, h *remotePatchHandler) {
+			req, err := http.NewRequest(http.MethodPost, "/projects/proj/patches", strings.NewReader(`{"ref": "feature", "repo": "evergreen"}`))
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "proj"})
+			assert.Error(t, h.Parse(ctx, req))
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			h, ok := makeCreateRemotePatch(nil).Factory().(*remotePatchHandler)
+			require.True(t, ok)
+			tCase(t, h)
+		})
+	}
+}
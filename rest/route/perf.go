@@ -0,0 +1,152 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/perf"
+	"github.com/evergreen-ci/evergreen/model/task"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/units"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/task/{task_id}/perf_results
+
+type sendPerfResultsHandler struct {
+	taskID  string
+	results []apimodels.PerfResult
+	env     evergreen.Environment
+}
+
+func makeSendPerfResults(env evergreen.Environment) gimlet.RouteHandler {
+	return &sendPerfResultsHandler{env: env}
+}
+
+func (h *sendPerfResultsHandler) Factory() gimlet.RouteHandler {
+	return &sendPerfResultsHandler{env: h.env}
+}
+
+func (h *sendPerfResultsHandler) Parse(ctx context.Context, r *http.Request) error {
+	if h.taskID = gimlet.GetVars(r)["task_id"]; h.taskID == "" {
+		return errors.New("missing task ID")
+	}
+	if err := utility.ReadJSON(r.Body, &h.results); err != nil {
+		return errors.Wrap(err, "reading perf results from request body")
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, r := range h.results {
+		catcher.NewWhen(r.Test == "", "test name cannot be empty")
+		catcher.ErrorfWhen(r.Measurement == "", "measurement for test '%s' cannot be empty", r.Test)
+	}
+	return catcher.Resolve()
+}
+
+// Run adds the results to the tests' performance series using the task's
+// metadata, and detects change points in the series if the task is mainline.
+func (h *sendPerfResultsHandler) Run(ctx context.Context) gimlet.Responder {
+	t, err := task.FindOneId(h.taskID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", h.taskID))
+	}
+	if t == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", h.taskID),
+		})
+	}
+
+	mainline := t.Requester == evergreen.RepotrackerVersionRequester
+	now := time.Now()
+	results := make([]perf.Result, 0, len(h.results))
+	for _, r := range h.results {
+		key := perf.SeriesKey{
+			Project:     t.Project,
+			Variant:     t.BuildVariant,
+			Task:        t.DisplayName,
+			Test:        r.Test,
+			Measurement: r.Measurement,
+			Args:        perf.FormatArgs(r.Args),
+		}
+		results = append(results, perf.Result{
+			ID:         perf.NewResultID(t.Id, t.Execution, key),
+			SeriesKey:  key,
+			Version:    t.Version,
+			Order:      t.RevisionOrderNumber,
+			TaskID:     t.Id,
+			Execution:  t.Execution,
+			Mainline:   mainline,
+			Value:      r.Value,
+			CreateTime: now,
+		})
+	}
+	if err = perf.SaveResults(results); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "saving perf results for task '%s'", t.Id))
+	}
+
+	if mainline && len(results) > 0 {
+		j := units.NewPerfChangePointDetectionJob(t.Id, t.Execution)
+		if err = amboy.EnqueueUniqueJob(ctx, h.env.RemoteQueue(), j); err != nil {
+			grip.Error(message.WrapError(err, message.Fields{
+				"message": "could not enqueue Amboy job to detect perf change points",
+				"task_id": t.Id,
+				"job_id":  j.ID(),
+				"route":   "/rest/v2/task/{task_id}/perf_results",
+			}))
+		}
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/versions/{version_id}/perf_change_points
+
+type getVersionPerfChangePointsHandler struct {
+	versionID string
+}
+
+func makeGetVersionPerfChangePoints() gimlet.RouteHandler {
+	return &getVersionPerfChangePointsHandler{}
+}
+
+func (h *getVersionPerfChangePointsHandler) Factory() gimlet.RouteHandler {
+	return &getVersionPerfChangePointsHandler{}
+}
+
+func (h *getVersionPerfChangePointsHandler) Parse(ctx context.Context, r *http.Request) error {
+	if h.versionID = gimlet.GetVars(r)["version_id"]; h.versionID == "" {
+		return errors.New("missing version ID")
+	}
+	return nil
+}
+
+// Run returns the performance change points detected at the version.
+func (h *getVersionPerfChangePointsHandler) Run(ctx context.Context) gimlet.Responder {
+	found, err := perf.FindChangePointsByVersion(h.versionID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	out := []restModel.APIPerfChangePoint{}
+	for _, cp := range found {
+		apiChangePoint := restModel.APIPerfChangePoint{}
+		apiChangePoint.BuildFromService(cp)
+		out = append(out, apiChangePoint)
+	}
+
+	return gimlet.NewJSONResponse(out)
+}
@@ -410,6 +410,13 @@ func (h *projectIDPatchHandler) Run(ctx context.Context) gimlet.Responder {
 			}
 		}
 
+		// verify enabling the GitHub merge queue is valid
+		if mergedProjectRef.IsGithubMergeQueueEnabled() && !h.originalProject.IsGithubMergeQueueEnabled() {
+			if !hasAliasDefined(allAliases, mergedProjectRef.GetGithubMergeQueueAlias()) {
+				return gimlet.MakeJSONErrorResponder(errors.Errorf("cannot enable the GitHub merge queue without a definition for alias '%s'", mergedProjectRef.GetGithubMergeQueueAlias()))
+			}
+		}
+
 		// verify enabling git tag versions is valid
 		if mergedProjectRef.IsGitTagVersionsEnabled() && !h.originalProject.IsGitTagVersionsEnabled() {
 			if !hasAliasDefined(allAliases, evergreen.GitTagAlias) {
@@ -0,0 +1,376 @@
+package route
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/task"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/task/{task_id}/provenance
+
+type createProvenanceHandler struct {
+	taskID   string
+	subjects []artifact.ProvenanceSubject
+	env      evergreen.Environment
+}
+
+func makeCreateProvenance(env evergreen.Environment) gimlet.RouteHandler {
+	return &createProvenanceHandler{env: env}
+}
+
+func (h *createProvenanceHandler) Factory() gimlet.RouteHandler {
+	return &createProvenanceHandler{env: h.env}
+}
+
+func (h *createProvenanceHandler) Parse(ctx context.Context, r *http.Request) error {
+	if h.taskID = gimlet.GetVars(r)["task_id"]; h.taskID == "" {
+		return errors.New("missing task ID")
+	}
+	if err := utility.ReadJSON(r.Body, &h.subjects); err != nil {
+		return errors.Wrap(err, "reading provenance subjects from request body")
+	}
+	if len(h.subjects) == 0 {
+		return errors.New("must specify at least one artifact to attest to")
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for i, subject := range h.subjects {
+		catcher.NewWhen(subject.Name == "", "artifact name cannot be empty")
+		digest, err := parseSHA256Digest(subject.Digest[artifact.DigestAlgorithmSHA256])
+		if err != nil {
+			catcher.Wrapf(err, "artifact '%s'", subject.Name)
+			continue
+		}
+		h.subjects[i].Digest = map[string]string{artifact.DigestAlgorithmSHA256: digest}
+	}
+	return catcher.Resolve()
+}
+
+// Run creates a provenance statement for the task's artifacts from what the
+// app server knows about the task, signs it, and attaches it to the task.
+func (h *createProvenanceHandler) Run(ctx context.Context) gimlet.Responder {
+	settings := h.env.Settings()
+	if !settings.Provenance.Enabled() {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "provenance signing is not configured",
+		})
+	}
+	key, err := settings.Provenance.PrivateKey()
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting provenance signing key"))
+	}
+
+	t, err := task.FindOneId(h.taskID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", h.taskID))
+	}
+	if t == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", h.taskID),
+		})
+	}
+
+	info, err := h.getTaskInfo(ctx, t)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "getting provenance info for task '%s'", t.Id))
+	}
+
+	builderID := settings.Provenance.BuilderID
+	if builderID == "" {
+		builderID = settings.ApiUrl
+	}
+	now := time.Now()
+	statement := artifact.NewProvenanceStatement(builderID, *info, h.subjects, now)
+	envelope, err := artifact.SignProvenance(statement, key, settings.Provenance.KeyID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "signing provenance statement"))
+	}
+
+	p := artifact.Provenance{
+		ID:         utility.RandomString(),
+		TaskID:     t.Id,
+		ProjectID:  t.Project,
+		Execution:  t.Execution,
+		Subjects:   h.subjects,
+		Envelope:   *envelope,
+		CreateTime: now,
+	}
+	if err = p.Insert(); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "saving provenance"))
+	}
+
+	entry := artifact.Entry{
+		TaskId:          t.Id,
+		TaskDisplayName: t.DisplayName,
+		BuildId:         t.BuildId,
+		Execution:       t.Execution,
+		CreateTime:      now,
+		Files: []artifact.File{{
+			Name:           "Provenance attestation",
+			Link:           fmt.Sprintf("%s/rest/v2/tasks/%s/provenance?execution=%d", settings.ApiUrl, t.Id, t.Execution),
+			Visibility:     artifact.Private,
+			IgnoreForFetch: true,
+		}},
+	}
+	if err = entry.Upsert(); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "attaching provenance to task"))
+	}
+
+	grip.Info(message.Fields{
+		"message":   "created provenance attestation",
+		"task_id":   t.Id,
+		"execution": t.Execution,
+		"subjects":  len(h.subjects),
+		"key_id":    settings.Provenance.KeyID,
+	})
+
+	return gimlet.NewJSONResponse(envelope)
+}
+
+func (h *createProvenanceHandler) getTaskInfo(ctx context.Context, t *task.Task) (*artifact.ProvenanceTaskInfo, error) {
+	v, err := model.VersionFindOne(model.VersionById(t.Version))
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding version '%s'", t.Version)
+	}
+	if v == nil {
+		return nil, errors.Errorf("version '%s' not found", t.Version)
+	}
+	pp, err := model.ParserProjectFindOneByID(ctx, h.env.Settings(), v.ProjectStorageMethod, v.Id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding parser project '%s'", v.Id)
+	}
+	if pp == nil {
+		return nil, errors.Errorf("parser project '%s' not found", v.Id)
+	}
+	configHash, err := pp.ConfigDigest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "hashing parser project '%s'", v.Id)
+	}
+
+	info := &artifact.ProvenanceTaskInfo{
+		TaskID:       t.Id,
+		Execution:    t.Execution,
+		TaskName:     t.DisplayName,
+		Project:      t.Project,
+		Version:      t.Version,
+		Revision:     t.Revision,
+		BuildVariant: t.BuildVariant,
+		Requester:    t.Requester,
+		ConfigHash:   configHash,
+		StartTime:    t.StartTime,
+	}
+
+	pRef, err := model.FindMergedProjectRef(t.Project, t.Version, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding project ref '%s'", t.Project)
+	}
+	if pRef != nil {
+		info.Project = pRef.Identifier
+		if pRef.Owner != "" && pRef.Repo != "" {
+			info.SourceURI = fmt.Sprintf("git+https://github.com/%s/%s", pRef.Owner, pRef.Repo)
+		}
+	}
+
+	return info, nil
+}
+
+// parseSHA256Digest returns the lowercase hex-encoded digest, with an
+// optional "sha256:" prefix removed, or an error if it is not a valid SHA-256
+// digest.
+func parseSHA256Digest(digest string) (string, error) {
+	digest = strings.ToLower(strings.TrimPrefix(digest, artifact.DigestAlgorithmSHA256+":"))
+	decoded, err := hex.DecodeString(digest)
+	if err != nil {
+		return "", errors.Wrap(err, "SHA-256 digest must be hex-encoded")
+	}
+	if len(decoded) != sha256.Size {
+		return "", errors.Errorf("SHA-256 digest must be %d bytes, but is %d bytes", sha256.Size, len(decoded))
+	}
+	return digest, nil
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/tasks/{task_id}/provenance
+//
+// Returns the signed provenance attestations for artifacts produced by a
+// task execution, which defaults to the latest one.
+
+type getTaskProvenanceHandler struct {
+	taskID    string
+	execution *int
+	env       evergreen.Environment
+}
+
+func makeGetTaskProvenance(env evergreen.Environment) gimlet.RouteHandler {
+	return &getTaskProvenanceHandler{env: env}
+}
+
+func (h *getTaskProvenanceHandler) Factory() gimlet.RouteHandler {
+	return &getTaskProvenanceHandler{env: h.env}
+}
+
+func (h *getTaskProvenanceHandler) Parse(ctx context.Context, r *http.Request) error {
+	if h.taskID = gimlet.GetVars(r)["task_id"]; h.taskID == "" {
+		return errors.New("missing task ID")
+	}
+	if execution := r.URL.Query().Get("execution"); execution != "" {
+		parsed, err := strconv.Atoi(execution)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    "execution must be an integer",
+			}
+		}
+		h.execution = &parsed
+	}
+	return nil
+}
+
+func (h *getTaskProvenanceHandler) Run(ctx context.Context) gimlet.Responder {
+	execution := 0
+	if h.execution != nil {
+		execution = *h.execution
+	} else {
+		t, err := task.FindOneId(h.taskID)
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", h.taskID))
+		}
+		if t == nil {
+			return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("task '%s' not found", h.taskID),
+			})
+		}
+		execution = t.Execution
+	}
+
+	found, err := artifact.FindProvenanceByTask(h.taskID, execution)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	verify := newProvenanceVerifier(h.env.Settings().Provenance)
+	out := []restModel.APIProvenance{}
+	for _, p := range found {
+		apiProvenance := restModel.APIProvenance{}
+		apiProvenance.BuildFromService(p)
+		apiProvenance.Verified = verify(p.Envelope)
+		out = append(out, apiProvenance)
+	}
+
+	return gimlet.NewJSONResponse(out)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/provenance/{digest}
+//
+// Finds the provenance attestations for an artifact by its SHA-256 digest
+// and verifies their signatures. Only attestations for projects that the user
+// can view tasks in are returned.
+
+type verifyProvenanceHandler struct {
+	digest string
+	env    evergreen.Environment
+}
+
+func makeVerifyProvenance(env evergreen.Environment) gimlet.RouteHandler {
+	return &verifyProvenanceHandler{env: env}
+}
+
+func (h *verifyProvenanceHandler) Factory() gimlet.RouteHandler {
+	return &verifyProvenanceHandler{env: h.env}
+}
+
+func (h *verifyProvenanceHandler) Parse(ctx context.Context, r *http.Request) error {
+	digest, err := parseSHA256Digest(gimlet.GetVars(r)["digest"])
+	if err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	h.digest = digest
+	return nil
+}
+
+func (h *verifyProvenanceHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	config := h.env.Settings().Provenance
+
+	found, err := artifact.FindProvenanceByDigest(artifact.DigestAlgorithmSHA256, h.digest)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	out := restModel.APIProvenanceVerification{
+		Algorithm:    utility.ToStringPtr(artifact.DigestAlgorithmSHA256),
+		Digest:       utility.ToStringPtr(h.digest),
+		Attestations: []restModel.APIProvenance{},
+	}
+	if pub, err := config.PublicKey(); err == nil {
+		out.KeyID = utility.ToStringPtr(config.KeyID)
+		out.PublicKey = utility.ToStringPtr(base64.StdEncoding.EncodeToString(pub))
+	}
+
+	verify := newProvenanceVerifier(config)
+	for _, p := range found {
+		if !u.HasPermission(gimlet.PermissionOpts{
+			Resource:      p.ProjectID,
+			ResourceType:  evergreen.ProjectResourceType,
+			Permission:    evergreen.PermissionTasks,
+			RequiredLevel: evergreen.TasksView.Value,
+		}) {
+			continue
+		}
+		apiProvenance := restModel.APIProvenance{}
+		apiProvenance.BuildFromService(p)
+		apiProvenance.Verified = verify(p.Envelope)
+		out.Verified = out.Verified || apiProvenance.Verified
+		out.Attestations = append(out.Attestations, apiProvenance)
+	}
+
+	if len(out.Attestations) == 0 {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("no provenance found for %s digest '%s'", artifact.DigestAlgorithmSHA256, h.digest),
+		})
+	}
+
+	return gimlet.NewJSONResponse(out)
+}
+
+// newProvenanceVerifier returns a function that checks whether an envelope
+// has a valid signature from the app server's signing key. If no signing key
+// is configured, nothing can be verified.
+func newProvenanceVerifier(config evergreen.ProvenanceConfig) func(artifact.ProvenanceEnvelope) bool {
+	pub, err := config.PublicKey()
+	if err != nil {
+		return func(artifact.ProvenanceEnvelope) bool { return false }
+	}
+	return func(envelope artifact.ProvenanceEnvelope) bool {
+		return envelope.Verify(pub, config.KeyID) == nil
+	}
+}
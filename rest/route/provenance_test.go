@@ -0,0 +1,224 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/artifact"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/user"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	pub := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	digest := strings.Repeat("ab", 32)
+	viewer := &user.DBUser{Id: "viewer", SystemRoles: []string{"p1_viewer"}}
+
+	createProvenance := func(ctx context.Context, t *testing.T, env evergreen.Environment, subjects []artifact.ProvenanceSubject) gimlet.Responder {
+		body, err := json.Marshal(subjects)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/rest/v2/task/t1/provenance", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"task_id": "t1"})
+
+		rh := makeCreateProvenance(env).Factory()
+		if err = rh.Parse(ctx, req); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+		return rh.Run(ctx)
+	}
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, env *mock.Environment){
+		"CreateSignsStatementFromTaskInfo": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			resp := createProvenance(ctx, t, env, []artifact.ProvenanceSubject{
+				{Name: "dist/a.tgz", Digest: map[string]string{artifact.DigestAlgorithmSHA256: "sha256:" + strings.ToUpper(digest)}},
+			})
+			require.Equal(t, http.StatusOK, resp.Status())
+			envelope, ok := resp.Data().(*artifact.ProvenanceEnvelope)
+			require.True(t, ok)
+			require.NoError(t, envelope.Verify(pub, "test-key"))
+
+			statement, err := envelope.Statement()
+			require.NoError(t, err)
+			require.Len(t, statement.Subject, 1)
+			assert.Equal(t, digest, statement.Subject[0].Digest[artifact.DigestAlgorithmSHA256], "digest should be normalized")
+			params := statement.Predicate.BuildDefinition.ExternalParameters
+			assert.Equal(t, "my-project", params.Project)
+			assert.Equal(t, "v1", params.Version)
+			assert.Equal(t, "abcdef", params.Revision)
+			assert.Equal(t, "bv", params.BuildVariant)
+			assert.Equal(t, "compile", params.Task)
+			assert.NotEmpty(t, statement.Predicate.BuildDefinition.InternalParameters.ConfigHash)
+			assert.Equal(t, "https://evergreen.example.com", statement.Predicate.RunDetails.Builder.ID)
+			require.Len(t, statement.Predicate.BuildDefinition.ResolvedDependencies, 1)
+			assert.Equal(t, "git+https://github.com/evergreen-ci/evergreen", statement.Predicate.BuildDefinition.ResolvedDependencies[0].URI)
+
+			found, err := artifact.FindProvenanceByTask("t1", 0)
+			require.NoError(t, err)
+			require.Len(t, found, 1)
+			assert.Equal(t, "p1", found[0].ProjectID)
+
+			entries, err := artifact.FindAll(artifact.ByTaskIdAndExecution("t1", 0))
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Len(t, entries[0].Files, 1)
+			assert.Contains(t, entries[0].Files[0].Link, "/rest/v2/tasks/t1/provenance")
+		},
+		"CreateFailsWithInvalidDigest": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			resp := createProvenance(ctx, t, env, []artifact.ProvenanceSubject{
+				{Name: "dist/a.tgz", Digest: map[string]string{artifact.DigestAlgorithmSHA256: "abc"}},
+			})
+			assert.NotEqual(t, http.StatusOK, resp.Status())
+		},
+		"CreateFailsWithoutSigningKey": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			env.EvergreenSettings.Provenance = evergreen.ProvenanceConfig{}
+			resp := createProvenance(ctx, t, env, []artifact.ProvenanceSubject{
+				{Name: "dist/a.tgz", Digest: map[string]string{artifact.DigestAlgorithmSHA256: digest}},
+			})
+			assert.Equal(t, http.StatusBadRequest, resp.Status())
+		},
+		"VerifyFindsSignedProvenance": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			resp := createProvenance(ctx, t, env, []artifact.ProvenanceSubject{
+				{Name: "dist/a.tgz", Digest: map[string]string{artifact.DigestAlgorithmSHA256: digest}},
+			})
+			require.Equal(t, http.StatusOK, resp.Status())
+
+			req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/provenance/sha256:"+digest, nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"digest": "sha256:" + digest})
+			rh := makeVerifyProvenance(env).Factory()
+			require.NoError(t, rh.Parse(ctx, req))
+
+			resp = rh.Run(gimlet.AttachUser(ctx, viewer))
+			require.Equal(t, http.StatusOK, resp.Status())
+			verification, ok := resp.Data().(restModel.APIProvenanceVerification)
+			require.True(t, ok)
+			assert.True(t, verification.Verified)
+			assert.Equal(t, "test-key", *verification.KeyID)
+			assert.Equal(t, base64.StdEncoding.EncodeToString(pub), *verification.PublicKey)
+			require.Len(t, verification.Attestations, 1)
+			assert.True(t, verification.Attestations[0].Verified)
+			assert.Equal(t, "t1", *verification.Attestations[0].TaskID)
+		},
+		"VerifyReportsTamperedProvenance": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			require.NoError(t, (&artifact.Provenance{
+				ID:        "forged",
+				TaskID:    "t1",
+				ProjectID: "p1",
+				Subjects:  []artifact.ProvenanceSubject{{Name: "a", Digest: map[string]string{artifact.DigestAlgorithmSHA256: digest}}},
+				Envelope: artifact.ProvenanceEnvelope{
+					PayloadType: artifact.InTotoPayloadType,
+					Payload:     []byte("{}"),
+					Signatures:  []artifact.ProvenanceSignature{{KeyID: "test-key", Sig: make([]byte, ed25519.SignatureSize)}},
+				},
+				CreateTime: time.Now(),
+			}).Insert())
+
+			req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/provenance/"+digest, nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"digest": digest})
+			rh := makeVerifyProvenance(env).Factory()
+			require.NoError(t, rh.Parse(ctx, req))
+
+			resp := rh.Run(gimlet.AttachUser(ctx, viewer))
+			require.Equal(t, http.StatusOK, resp.Status())
+			verification, ok := resp.Data().(restModel.APIProvenanceVerification)
+			require.True(t, ok)
+			assert.False(t, verification.Verified)
+			require.Len(t, verification.Attestations, 1)
+			assert.False(t, verification.Attestations[0].Verified)
+		},
+		"VerifyReturnsNotFoundForUnknownDigest": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/provenance/"+digest, nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"digest": digest})
+			rh := makeVerifyProvenance(env).Factory()
+			require.NoError(t, rh.Parse(ctx, req))
+
+			resp := rh.Run(gimlet.AttachUser(ctx, viewer))
+			assert.Equal(t, http.StatusNotFound, resp.Status())
+		},
+		"VerifyHidesProvenanceForProjectsUserCannotView": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			resp := createProvenance(ctx, t, env, []artifact.ProvenanceSubject{
+				{Name: "dist/a.tgz", Digest: map[string]string{artifact.DigestAlgorithmSHA256: digest}},
+			})
+			require.Equal(t, http.StatusOK, resp.Status())
+
+			req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/provenance/"+digest, nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"digest": digest})
+			rh := makeVerifyProvenance(env).Factory()
+			require.NoError(t, rh.Parse(ctx, req))
+
+			resp = rh.Run(gimlet.AttachUser(ctx, &user.DBUser{Id: "other"}))
+			assert.Equal(t, http.StatusNotFound, resp.Status())
+		},
+		"VerifyRejectsInvalidDigest": func(ctx context.Context, t *testing.T, env *mock.Environment) {
+			req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/provenance/xyz", nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"digest": "xyz"})
+			assert.Error(t, makeVerifyProvenance(env).Factory().Parse(ctx, req))
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			env := &mock.Environment{}
+			require.NoError(t, env.Configure(ctx))
+			env.EvergreenSettings.Provenance = evergreen.ProvenanceConfig{
+				SigningKey: base64.StdEncoding.EncodeToString(seed),
+				KeyID:      "test-key",
+				BuilderID:  "https://evergreen.example.com",
+			}
+
+			require.NoError(t, db.ClearCollections(task.Collection, model.VersionCollection, model.ParserProjectCollection, model.ProjectRefCollection, artifact.Collection, artifact.ProvenanceCollection, evergreen.RoleCollection, evergreen.ScopeCollection))
+
+			rm := evergreen.GetEnvironment().RoleManager()
+			require.NoError(t, rm.AddScope(gimlet.Scope{
+				ID:        "p1_scope",
+				Resources: []string{"p1"},
+				Type:      evergreen.ProjectResourceType,
+			}))
+			require.NoError(t, rm.UpdateRole(gimlet.Role{
+				ID:          "p1_viewer",
+				Scope:       "p1_scope",
+				Permissions: gimlet.Permissions{evergreen.PermissionTasks: evergreen.TasksView.Value},
+			}))
+
+			require.NoError(t, (&task.Task{
+				Id:           "t1",
+				DisplayName:  "compile",
+				Project:      "p1",
+				Version:      "v1",
+				Revision:     "abcdef",
+				BuildVariant: "bv",
+				Requester:    evergreen.RepotrackerVersionRequester,
+			}).Insert())
+			require.NoError(t, (&model.Version{Id: "v1", Revision: "abcdef"}).Insert())
+			require.NoError(t, (&model.ParserProject{Id: "v1"}).Insert())
+			require.NoError(t, (&model.ProjectRef{Id: "p1", Identifier: "my-project", Owner: "evergreen-ci", Repo: "evergreen"}).Insert())
+
+			tCase(ctx, t, env)
+		})
+	}
+}
@@ -2,8 +2,12 @@ package route
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/rest/data"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	"github.com/evergreen-ci/gimlet"
@@ -44,3 +48,74 @@ func (p *compareTasksRoute) Run(ctx context.Context) gimlet.Responder {
 	}
 	return gimlet.NewJSONResponse(resp)
 }
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/distros/{distro_id}/scheduler_snapshot
+
+// maxSchedulerSnapshotLookback is the longest window of task history that a
+// scheduler snapshot can replay.
+const maxSchedulerSnapshotLookback = 7 * 24 * time.Hour
+
+type distroSchedulerSnapshotHandler struct {
+	distroID string
+	lookback time.Duration
+	env      evergreen.Environment
+}
+
+func makeGetDistroSchedulerSnapshot(env evergreen.Environment) gimlet.RouteHandler {
+	return &distroSchedulerSnapshotHandler{env: env}
+}
+
+func (h *distroSchedulerSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &distroSchedulerSnapshotHandler{env: h.env}
+}
+
+func (h *distroSchedulerSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.distroID = gimlet.GetVars(r)["distro_id"]
+
+	if lookback := r.URL.Query().Get("lookback"); lookback != "" {
+		var err error
+		h.lookback, err = time.ParseDuration(lookback)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrapf(err, "parsing lookback duration '%s'", lookback).Error(),
+			}
+		}
+		if h.lookback < 0 {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    "lookback duration cannot be negative",
+			}
+		}
+		if h.lookback > maxSchedulerSnapshotLookback {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    fmt.Sprintf("lookback duration cannot exceed %s", maxSchedulerSnapshotLookback),
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *distroSchedulerSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	d, err := distro.FindOneId(h.distroID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding distro '%s'", h.distroID))
+	}
+	if d == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("distro '%s' not found", h.distroID),
+		})
+	}
+
+	snapshot, err := data.GetSchedulerSnapshot(h.distroID, h.env.Settings(), h.lookback)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting scheduler snapshot"))
+	}
+
+	return gimlet.NewJSONResponse(snapshot)
+}
@@ -0,0 +1,46 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDistroSchedulerSnapshotParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parse := func(t *testing.T, query string) (*distroSchedulerSnapshotHandler, error) {
+		r, err := http.NewRequest(http.MethodGet, "/distros/distro/scheduler_snapshot?"+query, nil)
+		require.NoError(t, err)
+		r = gimlet.SetURLVars(r, map[string]string{"distro_id": "distro"})
+
+		rh, ok := makeGetDistroSchedulerSnapshot(&mock.Environment{}).Factory().(*distroSchedulerSnapshotHandler)
+		require.True(t, ok)
+		return rh, rh.Parse(ctx, r)
+	}
+
+	t.Run("Succeeds", func(t *testing.T) {
+		rh, err := parse(t, "lookback=24h")
+		require.NoError(t, err)
+		assert.Equal(t, "distro", rh.distroID)
+		assert.Equal(t, 24*time.Hour, rh.lookback)
+	})
+	t.Run("FailsWithNegativeLookback", func(t *testing.T) {
+		_, err := parse(t, "lookback=-1h")
+		assert.Error(t, err)
+	})
+	t.Run("FailsWithLookbackAboveMaximum", func(t *testing.T) {
+		_, err := parse(t, "lookback=100000h")
+		require.Error(t, err)
+		errResp, ok := err.(gimlet.ErrorResponse)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, errResp.StatusCode)
+	})
+}
@@ -0,0 +1,79 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/hosts/{host_id}/spawn_host_activity
+
+type spawnHostActivityPostHandler struct {
+	hostID   string
+	activity model.APISpawnHostActivity
+}
+
+func makeSpawnHostActivityPostHandler() gimlet.RouteHandler {
+	return &spawnHostActivityPostHandler{}
+}
+
+func (h *spawnHostActivityPostHandler) Factory() gimlet.RouteHandler {
+	return &spawnHostActivityPostHandler{}
+}
+
+func (h *spawnHostActivityPostHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.hostID = gimlet.GetVars(r)["host_id"]
+	if h.hostID == "" {
+		return errors.New("missing host ID")
+	}
+	body := utility.NewRequestReader(r)
+	defer body.Close()
+	if err := utility.ReadJSON(body, &h.activity); err != nil {
+		return errors.Wrap(err, "reading spawn host activity from request body")
+	}
+	return nil
+}
+
+func (h *spawnHostActivityPostHandler) Run(ctx context.Context) gimlet.Responder {
+	hst, err := host.FindOneId(h.hostID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding host '%s'", h.hostID))
+	}
+	if hst == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("host '%s' not found", h.hostID),
+		})
+	}
+	if !hst.UserHost {
+		return gimlet.MakeJSONErrorResponder(errors.Errorf("host '%s' is not a spawn host", h.hostID))
+	}
+
+	// The distro may have changed since the host was created, so use its
+	// current load threshold if it still exists.
+	d, err := distro.FindOneId(hst.Distro.Id)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding distro '%s'", hst.Distro.Id))
+	}
+	if d == nil {
+		d = &hst.Distro
+	}
+
+	activity := h.activity.ToService()
+	activity.ReportTime = time.Now()
+	if err = hst.RecordSpawnHostActivity(activity, activity.IsBusy(d.SpawnHostAutoStop.GetLoadThreshold())); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "recording activity for host '%s'", h.hostID))
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
@@ -0,0 +1,87 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnHostActivityPostHandler(t *testing.T) {
+	defer func() {
+		assert.NoError(t, db.ClearCollections(host.Collection, distro.Collection))
+	}()
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, h *host.Host){
+		"RecordsIdleActivity": func(ctx context.Context, t *testing.T, h *host.Host) {
+			rh := &spawnHostActivityPostHandler{
+				hostID:   h.Id,
+				activity: model.APISpawnHostActivity{LoadAverage: 0.1},
+			}
+			require.Equal(t, http.StatusOK, rh.Run(ctx).Status())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost.SpawnHostActivity)
+			assert.Equal(t, 0.1, dbHost.SpawnHostActivity.LoadAverage)
+			assert.WithinDuration(t, time.Now(), dbHost.SpawnHostActivity.ReportTime, time.Minute)
+		},
+		"UsesDistroLoadThreshold": func(ctx context.Context, t *testing.T, h *host.Host) {
+			lastActive := time.Now().Add(-10 * time.Minute)
+			require.NoError(t, h.RecordSpawnHostActivity(host.SpawnHostActivity{ReportTime: lastActive}, true))
+
+			// The load is below the default threshold but above the distro's.
+			rh := &spawnHostActivityPostHandler{
+				hostID:   h.Id,
+				activity: model.APISpawnHostActivity{LoadAverage: 0.2},
+			}
+			require.Equal(t, http.StatusOK, rh.Run(ctx).Status())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost.SpawnHostActivity)
+			assert.True(t, dbHost.SpawnHostActivity.LastActiveTime.After(lastActive))
+		},
+		"FailsForNonexistentHost": func(ctx context.Context, t *testing.T, h *host.Host) {
+			rh := &spawnHostActivityPostHandler{hostID: "nonexistent"}
+			assert.Equal(t, http.StatusNotFound, rh.Run(ctx).Status())
+		},
+		"FailsForTaskHost": func(ctx context.Context, t *testing.T, h *host.Host) {
+			taskHost := &host.Host{Id: "h1", Status: evergreen.HostRunning}
+			require.NoError(t, taskHost.Insert())
+
+			rh := &spawnHostActivityPostHandler{hostID: taskHost.Id}
+			assert.Equal(t, http.StatusBadRequest, rh.Run(ctx).Status())
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			require.NoError(t, db.ClearCollections(host.Collection, distro.Collection))
+
+			d := distro.Distro{
+				Id:                "d0",
+				SpawnHostAutoStop: distro.SpawnHostAutoStopSettings{LoadThreshold: 0.15},
+			}
+			require.NoError(t, d.Insert())
+			h := &host.Host{
+				Id:       "h0",
+				UserHost: true,
+				Status:   evergreen.HostRunning,
+				Distro:   distro.Distro{Id: d.Id},
+			}
+			require.NoError(t, h.Insert())
+
+			tCase(ctx, t, h)
+		})
+	}
+}
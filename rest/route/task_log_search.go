@@ -0,0 +1,90 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+// defaultTaskLogSearchWindow is how far back a task log search looks if the
+// request does not specify a start time.
+const defaultTaskLogSearchWindow = 7 * 24 * time.Hour
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/projects/{project_id}/logs/search
+
+type taskLogSearchHandler struct {
+	opts dbModel.TaskLogSearchOptions
+}
+
+func makeSearchTaskLogs() gimlet.RouteHandler {
+	return &taskLogSearchHandler{}
+}
+
+func (h *taskLogSearchHandler) Factory() gimlet.RouteHandler {
+	return &taskLogSearchHandler{}
+}
+
+func (h *taskLogSearchHandler) Parse(ctx context.Context, r *http.Request) error {
+	body := utility.NewRequestReader(r)
+	defer body.Close()
+
+	req := model.APITaskLogSearchRequest{}
+	if err := utility.ReadJSON(body, &req); err != nil {
+		return errors.Wrap(err, "reading task log search request from JSON request body")
+	}
+
+	projectID, err := dbModel.GetIdForProject(gimlet.GetVars(r)["project_id"])
+	if err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    errors.Wrap(err, "finding project").Error(),
+		}
+	}
+
+	h.opts = req.ToService(projectID)
+	if utility.IsZeroTime(h.opts.StartTime) {
+		h.opts.StartTime = time.Now().Add(-defaultTaskLogSearchWindow)
+	}
+	if err = h.opts.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "invalid task log search").Error(),
+		}
+	}
+
+	return nil
+}
+
+func (h *taskLogSearchHandler) Run(ctx context.Context) gimlet.Responder {
+	store, err := dbModel.GetTaskLogStore(dbModel.TaskLogSearchStoreDB)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting task log store"))
+	}
+
+	res, err := dbModel.SearchTaskLogs(ctx, store, h.opts)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "searching task logs"))
+	}
+
+	resp := model.APITaskLogSearchResponse{
+		Results: make([]model.APITaskLogSearchResult, 0, len(res.Results)),
+	}
+	for _, result := range res.Results {
+		apiResult := model.APITaskLogSearchResult{}
+		apiResult.BuildFromService(result)
+		resp.Results = append(resp.Results, apiResult)
+	}
+	if res.NextKey != "" {
+		resp.NextKey = utility.ToStringPtr(res.NextKey)
+	}
+
+	return gimlet.NewJSONResponse(resp)
+}
@@ -0,0 +1,85 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogSearchHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(model.ProjectRefCollection, task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(model.ProjectRefCollection, task.Collection))
+	}()
+
+	pRef := model.ProjectRef{Id: "project_id", Identifier: "project"}
+	require.NoError(t, pRef.Insert())
+	tsk := task.Task{
+		Id:           "t1",
+		Project:      pRef.Id,
+		BuildVariant: "bv",
+		DisplayName:  "test",
+		Requester:    evergreen.RepotrackerVersionRequester,
+		FinishTime:   time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, tsk.Insert())
+	taskLog := &model.TaskLog{
+		TaskId:       tsk.Id,
+		Timestamp:    tsk.FinishTime,
+		MessageCount: 1,
+		Messages:     []apimodels.LogMessage{{Message: "Segmentation fault"}},
+	}
+	require.NoError(t, taskLog.Insert())
+
+	makeRequest := func(t *testing.T, project string, body restModel.APITaskLogSearchRequest) *http.Request {
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/projects/"+project+"/logs/search", bytes.NewBuffer(b))
+		require.NoError(t, err)
+		return gimlet.SetURLVars(req, map[string]string{"project_id": project})
+	}
+
+	t.Run("FindsMatchingTask", func(t *testing.T) {
+		rh := makeSearchTaskLogs()
+		require.NoError(t, rh.Parse(ctx, makeRequest(t, pRef.Identifier, restModel.APITaskLogSearchRequest{
+			Pattern: utility.ToStringPtr("Segmentation"),
+		})))
+
+		resp := rh.Run(ctx)
+		require.Equal(t, http.StatusOK, resp.Status())
+		searchResp, ok := resp.Data().(restModel.APITaskLogSearchResponse)
+		require.True(t, ok)
+		require.Len(t, searchResp.Results, 1)
+		assert.Equal(t, tsk.Id, utility.FromStringPtr(searchResp.Results[0].TaskID))
+		require.Len(t, searchResp.Results[0].Matches, 1)
+		assert.Equal(t, "Segmentation fault", utility.FromStringPtr(searchResp.Results[0].Matches[0].Excerpt))
+		assert.Nil(t, searchResp.NextKey)
+	})
+	t.Run("FailsWithoutPattern", func(t *testing.T) {
+		rh := makeSearchTaskLogs()
+		assert.Error(t, rh.Parse(ctx, makeRequest(t, pRef.Identifier, restModel.APITaskLogSearchRequest{})))
+	})
+	t.Run("FailsWithNonexistentProject", func(t *testing.T) {
+		rh := makeSearchTaskLogs()
+		assert.Error(t, rh.Parse(ctx, makeRequest(t, "nonexistent", restModel.APITaskLogSearchRequest{
+			Pattern: utility.ToStringPtr("Segmentation"),
+		})))
+	})
+}
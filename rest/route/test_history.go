@@ -0,0 +1,97 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/test_history
+
+type getProjectTestHistoryHandler struct {
+	opts model.TestHistoryOptions
+	env  evergreen.Environment
+}
+
+func makeGetProjectTestHistory(env evergreen.Environment) gimlet.RouteHandler {
+	return &getProjectTestHistoryHandler{env: env}
+}
+
+func (h *getProjectTestHistoryHandler) Factory() gimlet.RouteHandler {
+	return &getProjectTestHistoryHandler{env: h.env}
+}
+
+func (h *getProjectTestHistoryHandler) Parse(ctx context.Context, r *http.Request) error {
+	vals := r.URL.Query()
+	h.opts = model.TestHistoryOptions{
+		ProjectID: gimlet.GetVars(r)["project_id"],
+		TaskName:  vals.Get("task_name"),
+		TestName:  vals.Get("test_name"),
+	}
+	for _, variants := range vals["variants"] {
+		h.opts.BuildVariants = append(h.opts.BuildVariants, strings.Split(variants, ",")...)
+	}
+
+	var err error
+	if numVersions := vals.Get("num_versions"); numVersions != "" {
+		if h.opts.NumVersions, err = strconv.Atoi(numVersions); err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing 'num_versions'").Error(),
+			}
+		}
+	}
+	if startAt := vals.Get("start_at"); startAt != "" {
+		if h.opts.StartAt, err = strconv.Atoi(startAt); err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing 'start_at'").Error(),
+			}
+		}
+	}
+
+	if err = h.opts.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// Run returns the history of the tests run by the task over the project's
+// recent mainline versions.
+func (h *getProjectTestHistoryHandler) Run(ctx context.Context) gimlet.Responder {
+	projectID, err := model.GetIdForProject(h.opts.ProjectID)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    errors.Wrapf(err, "getting ID for project '%s'", h.opts.ProjectID).Error(),
+		})
+	}
+	h.opts.ProjectID = projectID
+
+	histories, err := model.GetTestHistory(ctx, h.env, h.opts)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "getting test history for task '%s' in project '%s'", h.opts.TaskName, h.opts.ProjectID))
+	}
+
+	out := []restModel.APITestHistory{}
+	for _, history := range histories {
+		apiHistory := restModel.APITestHistory{}
+		apiHistory.BuildFromService(history)
+		out = append(out, apiHistory)
+	}
+
+	return gimlet.NewJSONResponse(out)
+}
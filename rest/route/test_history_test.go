@@ -0,0 +1,89 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProjectTestHistoryParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &mock.Environment{}
+
+	parse := func(t *testing.T, query string) (*getProjectTestHistoryHandler, error) {
+		r, err := http.NewRequest(http.MethodGet, "/projects/project/test_history?"+query, nil)
+		require.NoError(t, err)
+		r = gimlet.SetURLVars(r, map[string]string{"project_id": "project"})
+
+		rh, ok := makeGetProjectTestHistory(env).Factory().(*getProjectTestHistoryHandler)
+		require.True(t, ok)
+		return rh, rh.Parse(ctx, r)
+	}
+
+	t.Run("Succeeds", func(t *testing.T) {
+		rh, err := parse(t, "task_name=unit&test_name=^test_&variants=linux,windows&variants=macos&num_versions=10&start_at=50")
+		require.NoError(t, err)
+		assert.Equal(t, model.TestHistoryOptions{
+			ProjectID:     "project",
+			TaskName:      "unit",
+			TestName:      "^test_",
+			BuildVariants: []string{"linux", "windows", "macos"},
+			NumVersions:   10,
+			StartAt:       50,
+		}, rh.opts)
+	})
+	t.Run("SetsDefaults", func(t *testing.T) {
+		rh, err := parse(t, "task_name=unit&test_name=test")
+		require.NoError(t, err)
+		assert.Equal(t, 20, rh.opts.NumVersions)
+		assert.Zero(t, rh.opts.StartAt)
+		assert.Empty(t, rh.opts.BuildVariants)
+	})
+	t.Run("FailsWithoutTaskName", func(t *testing.T) {
+		_, err := parse(t, "test_name=test")
+		assert.Error(t, err)
+	})
+	t.Run("FailsWithoutTestName", func(t *testing.T) {
+		_, err := parse(t, "task_name=unit")
+		assert.Error(t, err)
+	})
+	t.Run("FailsWithTooManyVariants", func(t *testing.T) {
+		_, err := parse(t, "task_name=unit&test_name=test&variants="+strings.Repeat("linux,", model.MaxTestHistoryBuildVariants)+"windows")
+		assert.Error(t, err)
+	})
+	t.Run("FailsWithInvalidRegex", func(t *testing.T) {
+		_, err := parse(t, "task_name=unit&test_name=test(")
+		assert.Error(t, err)
+	})
+	t.Run("FailsWithInvalidNumVersions", func(t *testing.T) {
+		_, err := parse(t, "task_name=unit&test_name=test&num_versions=abc")
+		assert.Error(t, err)
+
+		_, err = parse(t, "task_name=unit&test_name=test&num_versions=1000")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetProjectTestHistoryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.Clear(model.ProjectRefCollection))
+
+	rh := makeGetProjectTestHistory(&mock.Environment{}).(*getProjectTestHistoryHandler)
+	rh.opts = model.TestHistoryOptions{ProjectID: "nonexistent", TaskName: "unit", TestName: "test", NumVersions: 20}
+
+	resp := rh.Run(ctx)
+	require.NotNil(t, resp)
+	assert.Equal(t, http.StatusNotFound, resp.Status())
+}
@@ -0,0 +1,370 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/volumes/{volume_id}/snapshots
+
+type createVolumeSnapshotHandler struct {
+	env evergreen.Environment
+
+	provider string
+	volumeID string
+	opts     *model.VolumeSnapshotCreateOptions
+}
+
+func makeCreateVolumeSnapshot(env evergreen.Environment) gimlet.RouteHandler {
+	return &createVolumeSnapshotHandler{
+		env: env,
+	}
+}
+
+func (h *createVolumeSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &createVolumeSnapshotHandler{
+		env:  h.env,
+		opts: &model.VolumeSnapshotCreateOptions{},
+	}
+}
+
+func (h *createVolumeSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	if h.volumeID, err = validateID(gimlet.GetVars(r)["volume_id"]); err != nil {
+		return errors.Wrap(err, "invalid volume ID")
+	}
+	if r.Body != nil && r.ContentLength != 0 {
+		if err = utility.ReadJSON(r.Body, h.opts); err != nil {
+			return errors.Wrap(err, "reading volume snapshot options from JSON request body")
+		}
+	}
+	if !utility.IsZeroTime(h.opts.Expiration) {
+		if h.opts.Expiration.Before(time.Now()) {
+			return errors.New("snapshot expiration cannot be in the past")
+		}
+		if time.Until(h.opts.Expiration) > evergreen.MaxVolumeSnapshotExpiration {
+			return errors.Errorf("cannot set snapshot expiration past max expiration %s", time.Now().Add(evergreen.MaxVolumeSnapshotExpiration).Format(time.RFC1123))
+		}
+	}
+	h.provider = evergreen.ProviderNameEc2OnDemand
+
+	return nil
+}
+
+func (h *createVolumeSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	volume, err := host.FindVolumeByID(h.volumeID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding volume '%s'", h.volumeID))
+	}
+	if volume == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("volume '%s' not found", h.volumeID),
+		})
+	}
+
+	// Only allow users to snapshot their own volumes
+	if u.Id != volume.CreatedBy {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("not authorized to snapshot volume '%s'", volume.ID),
+		})
+	}
+
+	displayName := h.opts.DisplayName
+	if displayName == "" {
+		displayName = volume.DisplayName
+	}
+	mgrOpts := cloud.ManagerOpts{
+		Provider: h.provider,
+		Region:   cloud.AztoRegion(volume.AvailabilityZone),
+	}
+	mgr, err := cloud.GetManager(ctx, h.env, mgrOpts)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting cloud manager"))
+	}
+	snapshot, err := mgr.CreateVolumeSnapshot(ctx, volume, &host.VolumeSnapshot{
+		DisplayName: displayName,
+		CreatedBy:   u.Id,
+		Expiration:  h.opts.Expiration,
+	})
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "creating snapshot of volume '%s'", volume.ID))
+	}
+
+	snapshotModel := &model.APIVolumeSnapshot{}
+	snapshotModel.BuildFromService(*snapshot)
+
+	return gimlet.NewJSONResponse(snapshotModel)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/volume_snapshots
+
+type getVolumeSnapshotsHandler struct {
+	env evergreen.Environment
+
+	provider string
+}
+
+func makeGetVolumeSnapshots(env evergreen.Environment) gimlet.RouteHandler {
+	return &getVolumeSnapshotsHandler{
+		env: env,
+	}
+}
+
+func (h *getVolumeSnapshotsHandler) Factory() gimlet.RouteHandler {
+	return &getVolumeSnapshotsHandler{
+		env: h.env,
+	}
+}
+
+func (h *getVolumeSnapshotsHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.provider = evergreen.ProviderNameEc2OnDemand
+	return nil
+}
+
+func (h *getVolumeSnapshotsHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	snapshots, err := host.FindVolumeSnapshotsByUser(u.Id)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding volume snapshots for user '%s'", u.Id))
+	}
+
+	// Each cloud manager only lists the snapshots in its own region.
+	var regions []string
+	for _, s := range snapshots {
+		region := evergreen.DefaultEC2Region
+		if s.AvailabilityZone != "" {
+			region = cloud.AztoRegion(s.AvailabilityZone)
+		}
+		if !utility.StringSliceContains(regions, region) {
+			regions = append(regions, region)
+		}
+	}
+
+	snapshotDocs := []model.APIVolumeSnapshot{}
+	for _, region := range regions {
+		mgr, err := cloud.GetManager(ctx, h.env, cloud.ManagerOpts{
+			Provider: h.provider,
+			Region:   region,
+		})
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "getting cloud manager for region '%s'", region))
+		}
+		regionSnapshots, err := mgr.ListVolumeSnapshots(ctx, u.Id)
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "listing volume snapshots in region '%s'", region))
+		}
+		for _, s := range regionSnapshots {
+			snapshotDoc := model.APIVolumeSnapshot{}
+			snapshotDoc.BuildFromService(s)
+			snapshotDocs = append(snapshotDocs, snapshotDoc)
+		}
+	}
+
+	return gimlet.NewJSONResponse(snapshotDocs)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// DELETE /rest/v2/volume_snapshots/{snapshot_id}
+
+type deleteVolumeSnapshotHandler struct {
+	env evergreen.Environment
+
+	provider   string
+	snapshotID string
+}
+
+func makeDeleteVolumeSnapshot(env evergreen.Environment) gimlet.RouteHandler {
+	return &deleteVolumeSnapshotHandler{
+		env: env,
+	}
+}
+
+func (h *deleteVolumeSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &deleteVolumeSnapshotHandler{
+		env: h.env,
+	}
+}
+
+func (h *deleteVolumeSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	h.snapshotID, err = validateID(gimlet.GetVars(r)["snapshot_id"])
+	h.provider = evergreen.ProviderNameEc2OnDemand
+	return err
+}
+
+func (h *deleteVolumeSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	snapshot, errResp := findUserVolumeSnapshot(ctx, h.snapshotID)
+	if errResp != nil {
+		return errResp
+	}
+
+	mgr, err := cloud.GetManager(ctx, h.env, cloud.ManagerOpts{
+		Provider: h.provider,
+		Region:   cloud.AztoRegion(snapshot.AvailabilityZone),
+	})
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting cloud manager"))
+	}
+	if err = mgr.DeleteVolumeSnapshot(ctx, snapshot); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "deleting volume snapshot '%s'", snapshot.ID))
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/volume_snapshots/{snapshot_id}/restore
+
+type restoreVolumeSnapshotHandler struct {
+	env evergreen.Environment
+
+	provider   string
+	snapshotID string
+	opts       *model.VolumeSnapshotRestoreOptions
+}
+
+func makeRestoreVolumeSnapshot(env evergreen.Environment) gimlet.RouteHandler {
+	return &restoreVolumeSnapshotHandler{
+		env: env,
+	}
+}
+
+func (h *restoreVolumeSnapshotHandler) Factory() gimlet.RouteHandler {
+	return &restoreVolumeSnapshotHandler{
+		env:  h.env,
+		opts: &model.VolumeSnapshotRestoreOptions{},
+	}
+}
+
+func (h *restoreVolumeSnapshotHandler) Parse(ctx context.Context, r *http.Request) error {
+	var err error
+	if h.snapshotID, err = validateID(gimlet.GetVars(r)["snapshot_id"]); err != nil {
+		return errors.Wrap(err, "invalid snapshot ID")
+	}
+	if r.Body != nil && r.ContentLength != 0 {
+		if err = utility.ReadJSON(r.Body, h.opts); err != nil {
+			return errors.Wrap(err, "reading volume snapshot restore options from JSON request body")
+		}
+	}
+	h.provider = evergreen.ProviderNameEc2OnDemand
+
+	return nil
+}
+
+func (h *restoreVolumeSnapshotHandler) Run(ctx context.Context) gimlet.Responder {
+	u := MustHaveUser(ctx)
+	snapshot, errResp := findUserVolumeSnapshot(ctx, h.snapshotID)
+	if errResp != nil {
+		return errResp
+	}
+
+	mgr, err := cloud.GetManager(ctx, h.env, cloud.ManagerOpts{
+		Provider: h.provider,
+		Region:   cloud.AztoRegion(snapshot.AvailabilityZone),
+	})
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting cloud manager"))
+	}
+	// The stored status of a pending snapshot is only updated when the
+	// user's snapshots are listed, so refresh it in case the snapshot has
+	// completed since then.
+	if snapshot.Status == host.VolumeSnapshotStatusPending {
+		if _, err = mgr.ListVolumeSnapshots(ctx, u.Id); err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "refreshing volume snapshot statuses"))
+		}
+		if snapshot, errResp = findUserVolumeSnapshot(ctx, h.snapshotID); errResp != nil {
+			return errResp
+		}
+	}
+	if snapshot.Status != host.VolumeSnapshotStatusCompleted {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("cannot restore snapshot '%s' because its status is '%s'", snapshot.ID, snapshot.Status),
+		})
+	}
+
+	volume := &host.Volume{
+		DisplayName:      h.opts.DisplayName,
+		CreatedBy:        u.Id,
+		Type:             h.opts.Type,
+		Size:             h.opts.Size,
+		AvailabilityZone: h.opts.AvailabilityZone,
+	}
+	if volume.DisplayName == "" {
+		volume.DisplayName = snapshot.DisplayName
+	}
+	if volume.Type == "" {
+		volume.Type = snapshot.VolumeType
+	}
+	if volume.Size < snapshot.Size {
+		volume.Size = snapshot.Size
+	}
+	if volume.AvailabilityZone == "" {
+		volume.AvailabilityZone = snapshot.AvailabilityZone
+	}
+	if volume.Type == evergreen.DefaultEBSType {
+		volume.IOPS = cloud.Gp2EquivalentIOPSForGp3(volume.Size)
+		volume.Throughput = cloud.Gp2EquivalentThroughputForGp3(volume.Size)
+	}
+
+	if err := cloud.ValidVolumeOptions(volume, h.env.Settings()); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "invalid volume options"))
+	}
+	maxVolumeFromSettings := h.env.Settings().Providers.AWS.MaxVolumeSizePerUser
+	if err := checkVolumeLimitExceeded(u.Username(), volume.Size, maxVolumeFromSettings); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "checking volume limit"))
+	}
+
+	res, err := mgr.RestoreVolumeSnapshot(ctx, snapshot, volume)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "restoring volume snapshot '%s'", snapshot.ID))
+	}
+
+	volumeModel := &model.APIVolume{}
+	volumeModel.BuildFromService(*res)
+
+	return gimlet.NewJSONResponse(volumeModel)
+}
+
+// findUserVolumeSnapshot finds the volume snapshot and checks that it was
+// created by the user in the context.
+func findUserVolumeSnapshot(ctx context.Context, snapshotID string) (*host.VolumeSnapshot, gimlet.Responder) {
+	u := MustHaveUser(ctx)
+	snapshot, err := host.FindVolumeSnapshotByID(snapshotID)
+	if err != nil {
+		return nil, gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding volume snapshot '%s'", snapshotID))
+	}
+	if snapshot == nil {
+		return nil, gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("volume snapshot '%s' not found", snapshotID),
+		})
+	}
+	// Only allow users to manage their own snapshots
+	if u.Id != snapshot.CreatedBy {
+		return nil, gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusUnauthorized,
+			Message:    fmt.Sprintf("not authorized to access volume snapshot '%s'", snapshotID),
+		})
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,172 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeSnapshotHandlers(t *testing.T) {
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, env evergreen.Environment){
+		"CreateSnapshotsVolume": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			h := &createVolumeSnapshotHandler{
+				env:      env,
+				provider: evergreen.ProviderNameMock,
+				volumeID: "v0",
+				opts:     &model.VolumeSnapshotCreateOptions{},
+			}
+			resp := h.Run(ctx)
+			require.Equal(t, http.StatusOK, resp.Status())
+			snapshot, ok := resp.Data().(*model.APIVolumeSnapshot)
+			require.True(t, ok)
+			assert.Equal(t, "v0", *snapshot.VolumeID)
+			assert.Equal(t, "my volume", *snapshot.DisplayName)
+			assert.Equal(t, 16, snapshot.Size)
+
+			snapshots, err := host.FindVolumeSnapshotsByVolume("v0")
+			require.NoError(t, err)
+			assert.Len(t, snapshots, 1)
+		},
+		"CreateFailsForNonexistentVolume": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			h := &createVolumeSnapshotHandler{
+				env:      env,
+				provider: evergreen.ProviderNameMock,
+				volumeID: "nonexistent",
+				opts:     &model.VolumeSnapshotCreateOptions{},
+			}
+			assert.Equal(t, http.StatusNotFound, h.Run(ctx).Status())
+		},
+		"CreateFailsForOtherUsersVolume": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			h := &createVolumeSnapshotHandler{
+				env:      env,
+				provider: evergreen.ProviderNameMock,
+				volumeID: "v0",
+				opts:     &model.VolumeSnapshotCreateOptions{},
+			}
+			assert.Equal(t, http.StatusUnauthorized, h.Run(gimlet.AttachUser(ctx, &user.DBUser{Id: "other"})).Status())
+		},
+		"ListReturnsOnlyUsersSnapshots": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			require.NoError(t, (&host.VolumeSnapshot{ID: "s0", CreatedBy: "user", AvailabilityZone: "us-east-1a"}).Insert())
+			require.NoError(t, (&host.VolumeSnapshot{ID: "s1", CreatedBy: "other", AvailabilityZone: "us-east-1a"}).Insert())
+
+			h := &getVolumeSnapshotsHandler{env: env, provider: evergreen.ProviderNameMock}
+			resp := h.Run(ctx)
+			require.Equal(t, http.StatusOK, resp.Status())
+			snapshots, ok := resp.Data().([]model.APIVolumeSnapshot)
+			require.True(t, ok)
+			require.Len(t, snapshots, 1)
+			assert.Equal(t, "s0", *snapshots[0].ID)
+		},
+		"DeleteRemovesSnapshot": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			require.NoError(t, (&host.VolumeSnapshot{ID: "s0", CreatedBy: "user", AvailabilityZone: "us-east-1a"}).Insert())
+
+			h := &deleteVolumeSnapshotHandler{env: env, provider: evergreen.ProviderNameMock, snapshotID: "s0"}
+			assert.Equal(t, http.StatusUnauthorized, h.Run(gimlet.AttachUser(ctx, &user.DBUser{Id: "other"})).Status())
+			require.Equal(t, http.StatusOK, h.Run(ctx).Status())
+
+			snapshot, err := host.FindVolumeSnapshotByID("s0")
+			require.NoError(t, err)
+			assert.Zero(t, snapshot)
+		},
+		"RestoreCreatesVolumeFromSnapshot": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			require.NoError(t, (&host.VolumeSnapshot{
+				ID:               "s0",
+				CreatedBy:        "user",
+				Size:             32,
+				VolumeType:       "gp3",
+				AvailabilityZone: "us-east-1a",
+				Status:           host.VolumeSnapshotStatusCompleted,
+			}).Insert())
+
+			h := &restoreVolumeSnapshotHandler{
+				env:        env,
+				provider:   evergreen.ProviderNameMock,
+				snapshotID: "s0",
+				opts:       &model.VolumeSnapshotRestoreOptions{DisplayName: "restored"},
+			}
+			resp := h.Run(ctx)
+			require.Equal(t, http.StatusOK, resp.Status())
+			volume, ok := resp.Data().(*model.APIVolume)
+			require.True(t, ok)
+			assert.Equal(t, 32, volume.Size)
+			assert.Equal(t, "restored", *volume.DisplayName)
+			assert.Equal(t, "us-east-1a", *volume.AvailabilityZone)
+
+			dbVolume, err := host.FindVolumeByID(*volume.ID)
+			require.NoError(t, err)
+			require.NotZero(t, dbVolume)
+			assert.Equal(t, "user", dbVolume.CreatedBy)
+		},
+		"RestoreFailsForPendingSnapshot": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			require.NoError(t, (&host.VolumeSnapshot{
+				ID:               "s0",
+				CreatedBy:        "user",
+				Size:             32,
+				AvailabilityZone: "us-east-1a",
+				Status:           host.VolumeSnapshotStatusPending,
+			}).Insert())
+
+			h := &restoreVolumeSnapshotHandler{
+				env:        env,
+				provider:   evergreen.ProviderNameMock,
+				snapshotID: "s0",
+				opts:       &model.VolumeSnapshotRestoreOptions{},
+			}
+			assert.Equal(t, http.StatusBadRequest, h.Run(ctx).Status())
+		},
+		"RestoreFailsWhenExceedingVolumeLimit": func(ctx context.Context, t *testing.T, env evergreen.Environment) {
+			require.NoError(t, (&host.VolumeSnapshot{
+				ID:               "s0",
+				CreatedBy:        "user",
+				Size:             100,
+				VolumeType:       "gp3",
+				AvailabilityZone: "us-east-1a",
+				Status:           host.VolumeSnapshotStatusCompleted,
+			}).Insert())
+
+			h := &restoreVolumeSnapshotHandler{
+				env:        env,
+				provider:   evergreen.ProviderNameMock,
+				snapshotID: "s0",
+				opts:       &model.VolumeSnapshotRestoreOptions{},
+			}
+			assert.Equal(t, http.StatusBadRequest, h.Run(ctx).Status())
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			require.NoError(t, db.ClearCollections(host.VolumesCollection, host.VolumeSnapshotsCollection))
+			defer func() {
+				assert.NoError(t, db.ClearCollections(host.VolumesCollection, host.VolumeSnapshotsCollection))
+			}()
+
+			env := testutil.NewEnvironment(ctx, t)
+			env.Settings().Providers.AWS.MaxVolumeSizePerUser = 100
+			env.Settings().Providers.AWS.Subnets = []evergreen.Subnet{
+				{AZ: "us-east-1a", SubnetID: "123"},
+			}
+			require.NoError(t, (&host.Volume{
+				ID:               "v0",
+				DisplayName:      "my volume",
+				CreatedBy:        "user",
+				Type:             "gp3",
+				Size:             16,
+				AvailabilityZone: "us-east-1a",
+			}).Insert())
+
+			tCase(gimlet.AttachUser(ctx, &user.DBUser{Id: "user"}), t, env)
+		})
+	}
+}
@@ -43,5 +43,7 @@ func deficitNumNewHostsForDistro(ctx context.Context, hostAllocatorData *HostAll
 		numNewHosts = 0
 	}
 
+	numNewHosts += numWarmPoolHostsToRequest(distro, hostAllocatorData.Now, len(hostAllocatorData.ExistingHosts), len(freeHosts), numNewHosts, hostAllocatorData.DistroQueueInfo.Length)
+
 	return numNewHosts, len(freeHosts)
 }
@@ -2,11 +2,14 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
 )
 
 // HostAllocator is responsible for determining how many new hosts should be
@@ -20,6 +23,13 @@ type HostAllocatorData struct {
 	UsesContainers  bool
 	ContainerPool   *evergreen.ContainerPool
 	DistroQueueInfo model.DistroQueueInfo
+	// RunningTasks optionally contains the tasks running on the existing
+	// hosts, keyed by task ID. Running tasks that are not present are looked
+	// up in the database.
+	RunningTasks map[string]task.Task
+	// Now is the time at which hosts are being allocated, which determines
+	// time-dependent targets such as the distro's warm pool size.
+	Now time.Time
 }
 
 func GetHostAllocator(name string) HostAllocator {
@@ -28,7 +38,51 @@ func GetHostAllocator(name string) HostAllocator {
 		return DeficitBasedHostAllocator
 	case evergreen.HostAllocatorUtilization:
 		return UtilizationBasedHostAllocator
+	case evergreen.HostAllocatorPredictive:
+		return PredictiveHostAllocator
 	default:
 		return UtilizationBasedHostAllocator
 	}
 }
+
+// GetDistroHostAllocator returns the host allocator that the distro is
+// configured to use. Distros that do not set a host allocator version use the
+// one from the admin settings.
+func GetDistroHostAllocator(d distro.Distro, settings *evergreen.Settings) (HostAllocator, error) {
+	resolved, err := d.GetResolvedHostAllocatorSettings(settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving distro '%s' host allocator settings", d.Id)
+	}
+	return GetHostAllocator(resolved.Version), nil
+}
+
+// numWarmPoolHostsToRequest returns how many hosts to request in addition to
+// the ones needed for the task queue so that the distro's warm pool has enough
+// free hosts ready once the queued tasks have been dispatched. The result does
+// not exceed the distro's maximum hosts.
+func numWarmPoolHostsToRequest(d distro.Distro, now time.Time, numExistingHosts, numFreeHosts, numNewHosts, queueLength int) int {
+	return numSpareHostsToRequest(d, d.HostAllocatorSettings.WarmPool.TargetReadyHosts(now), numExistingHosts, numFreeHosts, numNewHosts, queueLength)
+}
+
+// numSpareHostsToRequest returns how many hosts to request in addition to the
+// ones needed for the task queue so that the distro has the target number of
+// free hosts once the queued tasks have been dispatched. The result does not
+// exceed the distro's maximum hosts.
+func numSpareHostsToRequest(d distro.Distro, target, numExistingHosts, numFreeHosts, numNewHosts, queueLength int) int {
+	if d.Disabled || !d.IsEphemeral() || target <= 0 {
+		return 0
+	}
+
+	numSpareHosts := numFreeHosts + numNewHosts - queueLength
+	if numSpareHosts < 0 {
+		numSpareHosts = 0
+	}
+	numToRequest := target - numSpareHosts
+	if maxToRequest := d.HostAllocatorSettings.MaximumHosts - numExistingHosts - numNewHosts; numToRequest > maxToRequest {
+		numToRequest = maxToRequest
+	}
+	if numToRequest < 0 {
+		return 0
+	}
+	return numToRequest
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumWarmPoolHostsToRequest(t *testing.T) {
+	now := time.Now()
+	d := distro.Distro{
+		Id:       "d0",
+		Provider: evergreen.ProviderNameEc2Fleet,
+		HostAllocatorSettings: distro.HostAllocatorSettings{
+			MaximumHosts: 10,
+			WarmPool:     distro.WarmPoolSettings{MinReadyHosts: 3},
+		},
+	}
+
+	t.Run("RequestsHostsToFillWarmPool", func(t *testing.T) {
+		assert.Equal(t, 3, numWarmPoolHostsToRequest(d, now, 0, 0, 0, 0))
+		assert.Equal(t, 2, numWarmPoolHostsToRequest(d, now, 1, 1, 0, 0))
+	})
+	t.Run("CountsHostsLeftOverAfterQueue", func(t *testing.T) {
+		assert.Equal(t, 1, numWarmPoolHostsToRequest(d, now, 4, 4, 0, 2))
+		assert.Equal(t, 0, numWarmPoolHostsToRequest(d, now, 2, 2, 3, 2))
+	})
+	t.Run("QueuedTasksConsumeFreeHosts", func(t *testing.T) {
+		assert.Equal(t, 3, numWarmPoolHostsToRequest(d, now, 2, 2, 1, 5))
+	})
+	t.Run("DoesNotExceedMaximumHosts", func(t *testing.T) {
+		assert.Equal(t, 1, numWarmPoolHostsToRequest(d, now, 8, 0, 1, 0))
+		assert.Equal(t, 0, numWarmPoolHostsToRequest(d, now, 10, 0, 0, 0))
+	})
+	t.Run("IgnoresDistroWithoutWarmPool", func(t *testing.T) {
+		noPool := d
+		noPool.HostAllocatorSettings.WarmPool = distro.WarmPoolSettings{}
+		assert.Zero(t, numWarmPoolHostsToRequest(noPool, now, 0, 0, 0, 0))
+	})
+	t.Run("IgnoresDisabledDistro", func(t *testing.T) {
+		disabled := d
+		disabled.Disabled = true
+		assert.Zero(t, numWarmPoolHostsToRequest(disabled, now, 0, 0, 0, 0))
+	})
+	t.Run("IgnoresStaticDistro", func(t *testing.T) {
+		static := d
+		static.Provider = evergreen.ProviderNameStatic
+		assert.Zero(t, numWarmPoolHostsToRequest(static, now, 0, 0, 0, 0))
+	})
+}
+
+func TestGetDistroHostAllocator(t *testing.T) {
+	settings := &evergreen.Settings{Scheduler: evergreen.SchedulerConfig{HostAllocator: evergreen.HostAllocatorDeficit}}
+	allocatorName := func(allocator HostAllocator) string {
+		return runtime.FuncForPC(reflect.ValueOf(allocator).Pointer()).Name()
+	}
+
+	t.Run("UsesDistroHostAllocator", func(t *testing.T) {
+		d := distro.Distro{
+			Id:                    "d0",
+			HostAllocatorSettings: distro.HostAllocatorSettings{Version: evergreen.HostAllocatorPredictive},
+		}
+		allocator, err := GetDistroHostAllocator(d, settings)
+		require.NoError(t, err)
+		assert.Equal(t, allocatorName(PredictiveHostAllocator), allocatorName(allocator))
+	})
+	t.Run("FallsBackToAdminSettingsHostAllocator", func(t *testing.T) {
+		allocator, err := GetDistroHostAllocator(distro.Distro{Id: "d0"}, settings)
+		require.NoError(t, err)
+		assert.Equal(t, allocatorName(DeficitBasedHostAllocator), allocatorName(allocator))
+	})
+	t.Run("ErrorsForInvalidHostAllocator", func(t *testing.T) {
+		d := distro.Distro{
+			Id:                    "d0",
+			HostAllocatorSettings: distro.HostAllocatorSettings{Version: "foo"},
+		}
+		_, err := GetDistroHostAllocator(d, settings)
+		assert.Error(t, err)
+	})
+}
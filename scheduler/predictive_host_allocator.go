@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+// forecastHistoryWeeks is the number of previous weeks of task activity that
+// the predictive host allocator uses to forecast demand.
+const forecastHistoryWeeks = 4
+
+// demandForecast is the forecast demand for hosts in a distro during the next
+// forecast window.
+type demandForecast struct {
+	numTasks float64
+	duration time.Duration
+	numHosts int
+}
+
+// PredictiveHostAllocator requests the hosts that the utilization-based host
+// allocator requests for the current task queue, plus enough hosts for the
+// tasks that it forecasts will be activated during the distro's forecast
+// window. The forecast is the average demand during the same window of the
+// week over the previous weeks. If the distro is in dry-run mode, it only logs
+// the hosts it would have requested for the forecast.
+func PredictiveHostAllocator(ctx context.Context, hostAllocatorData *HostAllocatorData) (int, int, error) {
+	numNewHosts, numFreeHosts, err := UtilizationBasedHostAllocator(ctx, hostAllocatorData)
+	if err != nil {
+		return numNewHosts, numFreeHosts, err
+	}
+
+	d := hostAllocatorData.Distro
+	if d.Disabled || !d.IsEphemeral() {
+		return numNewHosts, numFreeHosts, nil
+	}
+
+	window := d.HostAllocatorSettings.ForecastWindow
+	if window <= 0 {
+		window = distro.DefaultForecastWindow
+	}
+	forecast, err := forecastDistroDemand(d.Id, hostAllocatorData.Now, window)
+	if err != nil {
+		// The forecast is only an optimization, so fall back to the hosts
+		// needed for the current task queue.
+		grip.Warning(message.WrapError(err, message.Fields{
+			"runner":  RunnerName,
+			"message": "could not forecast demand for distro",
+			"distro":  d.Id,
+		}))
+		return numNewHosts, numFreeHosts, nil
+	}
+
+	numIdleHosts := 0
+	for _, h := range hostAllocatorData.ExistingHosts {
+		if h.RunningTask == "" {
+			numIdleHosts++
+		}
+	}
+	// The utilization-based host allocator already keeps the warm pool's
+	// free hosts ready, so those hosts cannot also absorb the forecast
+	// demand.
+	numForecastHosts := 0
+	if forecast.numHosts > 0 {
+		numWarmPoolHosts := d.HostAllocatorSettings.WarmPool.TargetReadyHosts(hostAllocatorData.Now)
+		numForecastHosts = numSpareHostsToRequest(d, forecast.numHosts+numWarmPoolHosts, len(hostAllocatorData.ExistingHosts), numIdleHosts, numNewHosts, hostAllocatorData.DistroQueueInfo.Length)
+	}
+
+	grip.Info(message.Fields{
+		"runner":                    RunnerName,
+		"message":                   "forecast demand for distro",
+		"distro":                    d.Id,
+		"dry_run":                   d.HostAllocatorSettings.ForecastDryRun,
+		"forecast_window_secs":      window.Seconds(),
+		"forecast_num_tasks":        forecast.numTasks,
+		"forecast_duration_secs":    forecast.duration.Seconds(),
+		"forecast_num_hosts":        forecast.numHosts,
+		"num_forecast_hosts":        numForecastHosts,
+		"num_new_hosts_utilization": numNewHosts,
+		"num_new_hosts_predictive":  numNewHosts + numForecastHosts,
+	})
+
+	if d.HostAllocatorSettings.ForecastDryRun {
+		return numNewHosts, numFreeHosts, nil
+	}
+
+	return numNewHosts + numForecastHosts, numFreeHosts, nil
+}
+
+// forecastDistroDemand forecasts the demand for hosts in the distro during the
+// window beginning now from the demand during the same window in each of the
+// previous weeks.
+func forecastDistroDemand(distroID string, now time.Time, window time.Duration) (demandForecast, error) {
+	starts := make([]time.Time, 0, forecastHistoryWeeks)
+	for i := 1; i <= forecastHistoryWeeks; i++ {
+		starts = append(starts, now.AddDate(0, 0, -7*i))
+	}
+	history, err := task.GetDistroDemand(distroID, starts, window)
+	if err != nil {
+		return demandForecast{}, err
+	}
+
+	return forecastFromHistory(history, window), nil
+}
+
+// forecastFromHistory averages the historical demand and converts it into the
+// number of hosts needed to run the forecast tasks within the window.
+func forecastFromHistory(history []task.DistroDemand, window time.Duration) demandForecast {
+	if len(history) == 0 || window <= 0 {
+		return demandForecast{}
+	}
+
+	var numTasks int
+	var duration time.Duration
+	for _, demand := range history {
+		numTasks += demand.NumTasks
+		duration += demand.TotalDuration
+	}
+
+	forecast := demandForecast{
+		numTasks: float64(numTasks) / float64(len(history)),
+		duration: duration / time.Duration(len(history)),
+	}
+	forecast.numHosts = int(math.Ceil(float64(forecast.duration) / float64(window)))
+
+	return forecast
+}
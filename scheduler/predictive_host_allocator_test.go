@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForecastFromHistory(t *testing.T) {
+	window := 30 * time.Minute
+
+	assert.Zero(t, forecastFromHistory(nil, window))
+
+	forecast := forecastFromHistory([]task.DistroDemand{
+		{NumTasks: 4, TotalDuration: 90 * time.Minute},
+		{NumTasks: 2, TotalDuration: 30 * time.Minute},
+		{},
+		{NumTasks: 2, TotalDuration: 40 * time.Minute},
+	}, window)
+	assert.Equal(t, 2.0, forecast.numTasks)
+	assert.Equal(t, 40*time.Minute, forecast.duration)
+	assert.Equal(t, 2, forecast.numHosts)
+}
+
+func TestPredictiveHostAllocator(t *testing.T) {
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, host.Collection))
+	}()
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, d distro.Distro){
+		"RequestsHostsForForecastDemand": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{Distro: d, Now: time.Now()})
+			require.NoError(t, err)
+			assert.Equal(t, 2, numHosts)
+		},
+		"CountsIdleHostsTowardForecast": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+				Distro:        d,
+				ExistingHosts: []host.Host{{Id: "h0", Distro: d}},
+				Now:           time.Now(),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 1, numHosts)
+		},
+		"DoesNotCountWarmPoolHostsTowardForecast": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			d.HostAllocatorSettings.WarmPool = distro.WarmPoolSettings{MinReadyHosts: 1}
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+				Distro:        d,
+				ExistingHosts: []host.Host{{Id: "h0", Distro: d, Status: evergreen.HostRunning}},
+				Now:           time.Now(),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 2, numHosts, "the idle host is kept for the warm pool, so the forecast needs two new hosts")
+		},
+		"DoesNotExceedMaximumHosts": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			d.HostAllocatorSettings.MaximumHosts = 1
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{Distro: d, Now: time.Now()})
+			require.NoError(t, err)
+			assert.Equal(t, 1, numHosts)
+		},
+		"DryRunOnlyRequestsHostsForQueue": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			d.HostAllocatorSettings.ForecastDryRun = true
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{Distro: d, Now: time.Now()})
+			require.NoError(t, err)
+			assert.Zero(t, numHosts)
+		},
+		"IgnoresDisabledDistro": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			d.Disabled = true
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{Distro: d, Now: time.Now()})
+			require.NoError(t, err)
+			assert.Zero(t, numHosts)
+		},
+		"NoHistoryRequestsHostsForQueue": func(ctx context.Context, t *testing.T, d distro.Distro) {
+			d.Id = "d1"
+			numHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+				Distro:          d,
+				DistroQueueInfo: model.DistroQueueInfo{},
+				Now:             time.Now(),
+			})
+			require.NoError(t, err)
+			assert.Zero(t, numHosts)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			require.NoError(t, db.ClearCollections(task.Collection, host.Collection))
+
+			d := distro.Distro{
+				Id:       "d0",
+				Provider: evergreen.ProviderNameEc2Fleet,
+				HostAllocatorSettings: distro.HostAllocatorSettings{
+					Version:        evergreen.HostAllocatorPredictive,
+					MaximumHosts:   10,
+					RoundingRule:   evergreen.HostAllocatorRoundDown,
+					FeedbackRule:   evergreen.HostAllocatorNoFeedback,
+					ForecastWindow: 30 * time.Minute,
+				},
+			}
+
+			// Last week, eight tasks that each took the whole window were
+			// activated shortly after this time, for an average of two hosts'
+			// worth of work per window over the history.
+			lastWeek := time.Now().AddDate(0, 0, -7).Add(time.Minute)
+			for _, id := range []string{"t0", "t1", "t2", "t3", "t4", "t5", "t6", "t7"} {
+				tsk := task.Task{
+					Id:            id,
+					DistroId:      d.Id,
+					ActivatedTime: lastWeek,
+					TimeTaken:     time.Hour,
+				}
+				require.NoError(t, tsk.Insert())
+			}
+
+			tCase(ctx, t, d)
+		})
+	}
+}
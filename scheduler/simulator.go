@@ -0,0 +1,688 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultSimulationSchedulerInterval is how often the simulated scheduler
+	// plans the queue and allocates hosts.
+	defaultSimulationSchedulerInterval = time.Minute
+	// defaultSimulationHostStartupDuration is the time it takes a simulated
+	// host to become ready to run tasks if the snapshot has no host startup
+	// history.
+	defaultSimulationHostStartupDuration = 8 * time.Minute
+	// defaultSimulationMaxDuration is the longest that a simulation will
+	// run, so that a configuration that can never finish the queue does not
+	// simulate forever.
+	defaultSimulationMaxDuration = 7 * 24 * time.Hour
+	// defaultSimulationTaskDuration is how long a simulated task runs if it
+	// has neither a runtime nor a historical expected duration.
+	defaultSimulationTaskDuration = 10 * time.Minute
+)
+
+// SimulationSnapshot is a point-in-time capture of a distro's scheduling
+// workload that can be replayed offline by Simulate.
+type SimulationSnapshot struct {
+	DistroID  string    `json:"distro_id"`
+	Provider  string    `json:"provider"`
+	CreatedAt time.Time `json:"created_at"`
+	// WindowStart is the time from which the snapshot's tasks and hosts are
+	// replayed. All offsets in the snapshot are relative to it.
+	WindowStart           time.Time                    `json:"window_start"`
+	PlannerSettings       distro.PlannerSettings       `json:"planner_settings"`
+	HostAllocatorSettings distro.HostAllocatorSettings `json:"host_allocator_settings"`
+	// HostStartupDuration is the average time it took recent hosts in the
+	// distro to go from creation to running their agent.
+	HostStartupDuration time.Duration        `json:"host_startup_duration_ns"`
+	Tasks               []SimulationTask     `json:"tasks"`
+	Hosts               []SimulationHostInfo `json:"hosts"`
+}
+
+// SimulationTask is a task in a simulation snapshot.
+type SimulationTask struct {
+	ID                  string   `json:"id"`
+	DisplayName         string   `json:"display_name"`
+	BuildVariant        string   `json:"build_variant"`
+	BuildID             string   `json:"build_id"`
+	Project             string   `json:"project"`
+	Version             string   `json:"version"`
+	Requester           string   `json:"requester"`
+	RevisionOrderNumber int      `json:"order"`
+	Priority            int64    `json:"priority"`
+	TaskGroup           string   `json:"task_group,omitempty"`
+	TaskGroupMaxHosts   int      `json:"task_group_max_hosts,omitempty"`
+	TaskGroupOrder      int      `json:"task_group_order,omitempty"`
+	GenerateTask        bool     `json:"generate_task,omitempty"`
+	ActivatedByStepback bool     `json:"activated_by_stepback,omitempty"`
+	NumDependents       int      `json:"num_dependents,omitempty"`
+	DependsOn           []string `json:"depends_on,omitempty"`
+	// ArrivalOffset is when the task became schedulable, relative to the
+	// snapshot's window start.
+	ArrivalOffset time.Duration `json:"arrival_offset_ns"`
+	// ExpectedDuration and ExpectedDurationStdDev are the historical
+	// runtime statistics that the scheduler uses for planning.
+	ExpectedDuration       time.Duration `json:"expected_duration_ns"`
+	ExpectedDurationStdDev time.Duration `json:"expected_duration_std_dev_ns"`
+	// Duration is how long the task runs in the simulation. It is the
+	// task's actual runtime if the task finished, and its expected duration
+	// otherwise.
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// SimulationHostInfo is a host that is up at the start of a simulation.
+type SimulationHostInfo struct {
+	ID string `json:"id"`
+	// AvailableOffset is when the host can start running tasks, relative to
+	// the snapshot's window start.
+	AvailableOffset time.Duration `json:"available_offset_ns"`
+}
+
+// SimulationOptions are the settings to replay a snapshot under. Unset
+// settings default to those captured in the snapshot.
+type SimulationOptions struct {
+	PlannerSettings       *distro.PlannerSettings
+	HostAllocatorSettings *distro.HostAllocatorSettings
+	// SchedulerInterval is how often the queue is planned and hosts are
+	// allocated.
+	SchedulerInterval time.Duration
+	// HostStartupDuration overrides the snapshot's host startup duration.
+	HostStartupDuration time.Duration
+	// MaxDuration bounds the simulated time.
+	MaxDuration time.Duration
+}
+
+// SimulationReport summarizes the outcome of a simulation.
+type SimulationReport struct {
+	PlannerVersion       string        `json:"planner_version"`
+	HostAllocatorVersion string        `json:"host_allocator_version"`
+	MaxHosts             int           `json:"max_hosts"`
+	NumTasks             int           `json:"num_tasks"`
+	NumTasksCompleted    int           `json:"num_tasks_completed"`
+	QueueWaitMean        time.Duration `json:"queue_wait_mean_ns"`
+	QueueWaitP50         time.Duration `json:"queue_wait_p50_ns"`
+	QueueWaitP90         time.Duration `json:"queue_wait_p90_ns"`
+	QueueWaitP99         time.Duration `json:"queue_wait_p99_ns"`
+	QueueWaitMax         time.Duration `json:"queue_wait_max_ns"`
+	HostHours            float64       `json:"host_hours"`
+	NumHostsCreated      int           `json:"num_hosts_created"`
+	PeakHosts            int           `json:"peak_hosts"`
+	// Makespan is the time from the start of the window until the last
+	// task finished.
+	Makespan time.Duration `json:"makespan_ns"`
+	// TimedOut indicates that the simulation reached its maximum duration
+	// before all tasks finished.
+	TimedOut bool `json:"timed_out"`
+}
+
+type simTask struct {
+	SimulationTask
+	deps     []*simTask
+	started  bool
+	startAt  time.Duration
+	finishAt time.Duration
+}
+
+type simHost struct {
+	id           string
+	createdAt    time.Duration
+	terminatedAt time.Duration
+	terminated   bool
+	// availableAt is when the host can next start a task, either because
+	// it finishes starting up or it finishes its current task.
+	availableAt time.Duration
+	running     *simTask
+	idle        bool
+}
+
+// finishedBy returns whether the task has finished running by the given
+// simulation time.
+func (t *simTask) finishedBy(at time.Duration) bool {
+	return t.started && t.finishAt <= at
+}
+
+// readyAt returns when the task's dependencies finished, or false if they
+// have not all finished by the given simulation time.
+func (t *simTask) readyAt(at time.Duration) (time.Duration, bool) {
+	ready := t.ArrivalOffset
+	for _, dep := range t.deps {
+		if !dep.finishedBy(at) {
+			return 0, false
+		}
+		if dep.finishAt > ready {
+			ready = dep.finishAt
+		}
+	}
+	return ready, true
+}
+
+type simulator struct {
+	distro          distro.Distro
+	interval        time.Duration
+	startupDuration time.Duration
+	maxDuration     time.Duration
+	allocator       HostAllocator
+	// windowStart is the time that the start of the simulation corresponds
+	// to in the snapshot's window.
+	windowStart time.Time
+
+	tasks  []*simTask
+	hosts  []*simHost
+	queue  []*simTask
+	now    time.Duration
+	nextID int
+}
+
+// Simulate replays the snapshot's tasks under the given scheduler settings
+// without touching the database and reports the resulting queue wait times,
+// host usage and makespan. The simulation is approximate: tasks are
+// dispatched in plan order to the first available host, subject to
+// dependencies and task group host limits, and hosts are terminated after
+// being idle for the distro's acceptable host idle time.
+func Simulate(ctx context.Context, snapshot SimulationSnapshot, opts SimulationOptions) (*SimulationReport, error) {
+	s, err := newSimulator(snapshot, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.run(ctx); err != nil {
+		return nil, err
+	}
+	return s.report(), nil
+}
+
+func newSimulator(snapshot SimulationSnapshot, opts SimulationOptions) (*simulator, error) {
+	d := distro.Distro{
+		Id:                    snapshot.DistroID,
+		Provider:              snapshot.Provider,
+		PlannerSettings:       snapshot.PlannerSettings,
+		HostAllocatorSettings: snapshot.HostAllocatorSettings,
+	}
+	if opts.PlannerSettings != nil {
+		d.PlannerSettings = *opts.PlannerSettings
+	}
+	if opts.HostAllocatorSettings != nil {
+		d.HostAllocatorSettings = *opts.HostAllocatorSettings
+	}
+
+	catcher := grip.NewBasicCatcher()
+	catcher.ErrorfWhen(d.PlannerSettings.Version != "" && !utility.StringSliceContains(evergreen.ValidTaskPlannerVersions, d.PlannerSettings.Version), "invalid planner version '%s'", d.PlannerSettings.Version)
+	catcher.ErrorfWhen(d.HostAllocatorSettings.Version != "" && !utility.StringSliceContains(evergreen.ValidHostAllocators, d.HostAllocatorSettings.Version), "invalid host allocator version '%s'", d.HostAllocatorSettings.Version)
+	// The predictive host allocator forecasts from the real task history
+	// rather than the simulated queue.
+	catcher.ErrorfWhen(d.HostAllocatorSettings.Version == evergreen.HostAllocatorPredictive, "the '%s' host allocator cannot be simulated", evergreen.HostAllocatorPredictive)
+	catcher.NewWhen(d.HostAllocatorSettings.MaximumHosts <= 0, "maximum hosts must be positive")
+	catcher.NewWhen(d.HostAllocatorSettings.MinimumHosts > d.HostAllocatorSettings.MaximumHosts, "minimum hosts cannot exceed maximum hosts")
+	catcher.NewWhen(d.HostAllocatorSettings.FutureHostFraction > 1, "future host fraction cannot be greater than 1")
+	if catcher.HasErrors() {
+		return nil, errors.Wrap(catcher.Resolve(), "invalid simulation settings")
+	}
+
+	s := &simulator{
+		distro:          d,
+		interval:        opts.SchedulerInterval,
+		startupDuration: snapshot.HostStartupDuration,
+		maxDuration:     opts.MaxDuration,
+		allocator:       GetHostAllocator(d.HostAllocatorSettings.Version),
+		windowStart:     snapshot.WindowStart,
+	}
+	if s.windowStart.IsZero() {
+		s.windowStart = time.Now()
+	}
+	if s.interval <= 0 {
+		s.interval = defaultSimulationSchedulerInterval
+	}
+	if opts.HostStartupDuration > 0 {
+		s.startupDuration = opts.HostStartupDuration
+	}
+	if s.startupDuration <= 0 {
+		s.startupDuration = defaultSimulationHostStartupDuration
+	}
+	if s.maxDuration <= 0 {
+		s.maxDuration = defaultSimulationMaxDuration
+	}
+
+	byID := make(map[string]*simTask, len(snapshot.Tasks))
+	for _, t := range snapshot.Tasks {
+		st := &simTask{SimulationTask: t}
+		if st.ExpectedDuration <= 0 {
+			st.ExpectedDuration = st.Duration
+		}
+		if st.ExpectedDuration <= 0 {
+			st.ExpectedDuration = defaultSimulationTaskDuration
+		}
+		if st.Duration <= 0 {
+			st.Duration = st.ExpectedDuration
+		}
+		byID[t.ID] = st
+		s.tasks = append(s.tasks, st)
+	}
+	// Dependencies outside of the snapshot are assumed to already be
+	// satisfied.
+	for _, st := range s.tasks {
+		for _, depID := range st.DependsOn {
+			if dep, ok := byID[depID]; ok {
+				st.deps = append(st.deps, dep)
+			}
+		}
+	}
+
+	for _, h := range snapshot.Hosts {
+		s.hosts = append(s.hosts, &simHost{
+			id:          h.ID,
+			availableAt: h.AvailableOffset,
+		})
+	}
+
+	return s, nil
+}
+
+func (s *simulator) run(ctx context.Context) error {
+	for ; s.now <= s.maxDuration; s.now += s.interval {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		s.dispatchUntil(s.now)
+		s.terminateIdleHosts()
+		if s.done() {
+			return nil
+		}
+
+		if err := s.plan(); err != nil {
+			return errors.Wrapf(err, "planning at simulated time %s", s.now)
+		}
+		if err := s.allocateHosts(ctx); err != nil {
+			return errors.Wrapf(err, "allocating hosts at simulated time %s", s.now)
+		}
+		// Idle hosts pick up the newly planned queue right away.
+		s.dispatchUntil(s.now)
+	}
+
+	return nil
+}
+
+// done returns whether every task in the simulation has finished.
+func (s *simulator) done() bool {
+	for _, t := range s.tasks {
+		if !t.finishedBy(s.now) {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatchUntil processes hosts becoming available up to the given time in
+// order, assigning each one the next dispatchable task from the current plan.
+func (s *simulator) dispatchUntil(until time.Duration) {
+	for {
+		var next *simHost
+		for _, h := range s.hosts {
+			if h.terminated || h.idle || h.availableAt > until {
+				continue
+			}
+			if next == nil || h.availableAt < next.availableAt {
+				next = h
+			}
+		}
+		if next == nil {
+			break
+		}
+		s.dispatch(next, next.availableAt)
+	}
+
+	// Idle hosts poll for work, so they pick up newly planned tasks
+	// immediately.
+	for _, h := range s.hosts {
+		if !h.terminated && h.idle {
+			s.dispatch(h, until)
+		}
+	}
+}
+
+// dispatch gives the host the next dispatchable task from the plan at the
+// given time, or marks it idle if there is nothing for it to run.
+func (s *simulator) dispatch(h *simHost, at time.Duration) {
+	h.running = nil
+	for i, t := range s.queue {
+		if t.started {
+			continue
+		}
+		if _, ok := t.readyAt(at); !ok {
+			continue
+		}
+		if t.TaskGroup != "" && t.TaskGroupMaxHosts > 0 && s.numHostsRunningGroup(t, at) >= t.TaskGroupMaxHosts {
+			continue
+		}
+
+		t.started = true
+		t.startAt = at
+		t.finishAt = at + t.Duration
+		h.running = t
+		h.idle = false
+		h.availableAt = t.finishAt
+		s.queue = append(s.queue[:i], s.queue[i+1:]...)
+		return
+	}
+
+	if !h.idle {
+		h.idle = true
+		h.availableAt = at
+	}
+}
+
+func (s *simulator) numHostsRunningGroup(t *simTask, at time.Duration) int {
+	var num int
+	for _, h := range s.hosts {
+		if h.terminated || h.running == nil || h.running.finishedBy(at) {
+			continue
+		}
+		if h.running.TaskGroup == t.TaskGroup && h.running.BuildVariant == t.BuildVariant &&
+			h.running.Project == t.Project && h.running.Version == t.Version {
+			num++
+		}
+	}
+	return num
+}
+
+// terminateIdleHosts terminates hosts that have been idle for longer than the
+// acceptable idle time, while keeping the distro's minimum number of hosts.
+func (s *simulator) terminateIdleHosts() {
+	idleThreshold := s.distro.HostAllocatorSettings.AcceptableHostIdleTime
+	numUp := len(s.upHosts())
+	for _, h := range s.hosts {
+		if numUp <= s.distro.HostAllocatorSettings.MinimumHosts {
+			return
+		}
+		if h.terminated || !h.idle || s.now-h.availableAt < idleThreshold {
+			continue
+		}
+		h.terminated = true
+		h.terminatedAt = s.now
+		numUp--
+	}
+}
+
+func (s *simulator) upHosts() []*simHost {
+	var up []*simHost
+	for _, h := range s.hosts {
+		if !h.terminated {
+			up = append(up, h)
+		}
+	}
+	return up
+}
+
+// wallTime converts a simulation time to a wall clock time such that the
+// current simulation time is the present. The planner and host allocator
+// measure time in queue and time remaining relative to the present.
+func (s *simulator) wallTime(at time.Duration) time.Time {
+	return time.Now().Add(at - s.now)
+}
+
+// simulatedTime returns the time in the snapshot's window that the current
+// simulation time corresponds to. Unlike wallTime, it is used for settings
+// that depend on the time of day, such as the warm pool schedule.
+func (s *simulator) simulatedTime() time.Time {
+	return s.windowStart.Add(s.now)
+}
+
+// toTask converts the simulated task to a task that can be passed to the
+// planner and host allocator without needing the database.
+func (s *simulator) toTask(t *simTask) task.Task {
+	arrivedAt := s.wallTime(t.ArrivalOffset)
+	tsk := task.Task{
+		Id:                     t.ID,
+		DisplayName:            t.DisplayName,
+		BuildVariant:           t.BuildVariant,
+		BuildId:                t.BuildID,
+		Project:                t.Project,
+		Version:                t.Version,
+		Requester:              t.Requester,
+		RevisionOrderNumber:    t.RevisionOrderNumber,
+		Priority:               t.Priority,
+		TaskGroup:              t.TaskGroup,
+		TaskGroupMaxHosts:      t.TaskGroupMaxHosts,
+		TaskGroupOrder:         t.TaskGroupOrder,
+		GenerateTask:           t.GenerateTask,
+		NumDependents:          t.NumDependents,
+		DistroId:               s.distro.Id,
+		ActivatedTime:          arrivedAt,
+		IngestTime:             arrivedAt,
+		ScheduledTime:          arrivedAt,
+		ExpectedDuration:       t.ExpectedDuration,
+		ExpectedDurationStdDev: t.ExpectedDurationStdDev,
+		// Pre-populating a fresh duration prediction prevents the planner
+		// from refreshing it from the database.
+		DurationPrediction: util.CachedDurationValue{
+			Value:       t.ExpectedDuration,
+			StdDev:      t.ExpectedDurationStdDev,
+			CollectedAt: time.Now(),
+			TTL:         s.maxDuration,
+		},
+	}
+	if t.ActivatedByStepback {
+		tsk.ActivatedBy = evergreen.StepbackTaskActivator
+	}
+	if readyAt, ok := t.readyAt(s.now); ok {
+		tsk.DependenciesMetTime = s.wallTime(readyAt)
+	}
+	for _, dep := range t.DependsOn {
+		tsk.DependsOn = append(tsk.DependsOn, task.Dependency{TaskId: dep})
+	}
+	if t.started {
+		tsk.StartTime = s.wallTime(t.startAt)
+		tsk.DispatchTime = tsk.StartTime
+	}
+	return tsk
+}
+
+// plan orders the tasks that are ready to run with the distro's planner.
+func (s *simulator) plan() error {
+	var ready []task.Task
+	byID := map[string]*simTask{}
+	for _, t := range s.tasks {
+		if t.started || t.ArrivalOffset > s.now {
+			continue
+		}
+		if _, ok := t.readyAt(s.now); !ok {
+			continue
+		}
+		ready = append(ready, s.toTask(t))
+		byID[t.ID] = t
+	}
+
+	var planned []task.Task
+	switch s.distro.PlannerSettings.Version {
+	case evergreen.PlannerVersionTunable:
+		planned = PrepareTasksForPlanning(&s.distro, ready).Export()
+	default:
+		versions := map[string]model.Version{}
+		for _, t := range ready {
+			versions[t.Version] = model.Version{Id: t.Version, Requester: t.Requester}
+		}
+		prioritizer := &CmpBasedTaskPrioritizer{}
+		var err error
+		planned, _, err = prioritizer.PrioritizeTasks(s.distro.Id, ready, versions)
+		if err != nil {
+			return errors.Wrap(err, "prioritizing tasks")
+		}
+	}
+
+	s.queue = make([]*simTask, 0, len(planned))
+	for _, t := range planned {
+		s.queue = append(s.queue, byID[t.Id])
+	}
+
+	return nil
+}
+
+// allocateHosts runs the distro's host allocator against the current plan and
+// starts the requested number of hosts.
+func (s *simulator) allocateHosts(ctx context.Context) error {
+	planned := make([]task.Task, 0, len(s.queue))
+	for _, t := range s.queue {
+		planned = append(planned, s.toTask(t))
+	}
+	queueInfo := GetDistroQueueInfo(s.distro.Id, planned, s.distro.GetTargetTime(), TaskPlannerOptions{})
+
+	var existingHosts []host.Host
+	runningTasks := map[string]task.Task{}
+	for _, h := range s.upHosts() {
+		existing := host.Host{
+			Id:        h.id,
+			Distro:    s.distro,
+			Status:    evergreen.HostRunning,
+			StartedBy: evergreen.User,
+		}
+		if h.availableAt > s.now && h.running == nil {
+			existing.Status = evergreen.HostStarting
+		}
+		if h.running != nil && !h.running.finishedBy(s.now) {
+			existing.RunningTask = h.running.ID
+			existing.RunningTaskGroup = h.running.TaskGroup
+			existing.RunningTaskBuildVariant = h.running.BuildVariant
+			existing.RunningTaskProject = h.running.Project
+			existing.RunningTaskVersion = h.running.Version
+			runningTasks[h.running.ID] = s.toTask(h.running)
+		}
+		existingHosts = append(existingHosts, existing)
+	}
+
+	numNewHosts, _, err := s.allocator(ctx, &HostAllocatorData{
+		Distro:          s.distro,
+		ExistingHosts:   existingHosts,
+		DistroQueueInfo: queueInfo,
+		RunningTasks:    runningTasks,
+		Now:             s.simulatedTime(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "running host allocator")
+	}
+
+	for i := 0; i < numNewHosts; i++ {
+		s.nextID++
+		s.hosts = append(s.hosts, &simHost{
+			id:          fmt.Sprintf("simulated-host-%d", s.nextID),
+			createdAt:   s.now,
+			availableAt: s.now + s.startupDuration,
+		})
+	}
+
+	return nil
+}
+
+func (s *simulator) report() *SimulationReport {
+	r := &SimulationReport{
+		PlannerVersion:       s.distro.PlannerSettings.Version,
+		HostAllocatorVersion: s.distro.HostAllocatorSettings.Version,
+		MaxHosts:             s.distro.HostAllocatorSettings.MaximumHosts,
+		NumTasks:             len(s.tasks),
+		NumHostsCreated:      s.nextID,
+	}
+	if r.PlannerVersion == "" {
+		r.PlannerVersion = evergreen.PlannerVersionLegacy
+	}
+	if r.HostAllocatorVersion == "" {
+		r.HostAllocatorVersion = evergreen.HostAllocatorUtilization
+	}
+
+	end := s.now
+	var waits []time.Duration
+	var totalWait time.Duration
+	for _, t := range s.tasks {
+		if !t.finishedBy(s.now) {
+			r.TimedOut = true
+			continue
+		}
+		r.NumTasksCompleted++
+		if t.finishAt > r.Makespan {
+			r.Makespan = t.finishAt
+		}
+		readyAt, _ := t.readyAt(t.startAt)
+		wait := t.startAt - readyAt
+		waits = append(waits, wait)
+		totalWait += wait
+	}
+	if !r.TimedOut {
+		end = r.Makespan
+	}
+
+	sort.Slice(waits, func(i, j int) bool { return waits[i] < waits[j] })
+	if len(waits) > 0 {
+		r.QueueWaitMean = totalWait / time.Duration(len(waits))
+		r.QueueWaitP50 = percentileDuration(waits, 0.5)
+		r.QueueWaitP90 = percentileDuration(waits, 0.9)
+		r.QueueWaitP99 = percentileDuration(waits, 0.99)
+		r.QueueWaitMax = waits[len(waits)-1]
+	}
+
+	var hostTime time.Duration
+	for _, h := range s.hosts {
+		upUntil := end
+		if h.terminated && h.terminatedAt < end {
+			upUntil = h.terminatedAt
+		}
+		if upUntil > h.createdAt {
+			hostTime += upUntil - h.createdAt
+		}
+	}
+	r.HostHours = hostTime.Hours()
+	r.PeakHosts = s.peakHosts(end)
+
+	return r
+}
+
+// peakHosts returns the largest number of hosts that were up at once before
+// the given time.
+func (s *simulator) peakHosts(end time.Duration) int {
+	type change struct {
+		at    time.Duration
+		delta int
+	}
+	var changes []change
+	for _, h := range s.hosts {
+		changes = append(changes, change{at: h.createdAt, delta: 1})
+		if h.terminated && h.terminatedAt < end {
+			changes = append(changes, change{at: h.terminatedAt, delta: -1})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].at == changes[j].at {
+			return changes[i].delta < changes[j].delta
+		}
+		return changes[i].at < changes[j].at
+	})
+
+	var current, peak int
+	for _, c := range changes {
+		current += c.delta
+		if current > peak {
+			peak = current
+		}
+	}
+	return peak
+}
+
+// percentileDuration returns the nearest-rank percentile of the sorted
+// durations.
+func percentileDuration(sorted []time.Duration, p float64) time.Duration {
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	return sorted[idx]
+}
@@ -0,0 +1,245 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// hostStartupHistoryWindow is how far back to look at host creations when
+// estimating how long it takes for a new host to start running tasks.
+const hostStartupHistoryWindow = 24 * time.Hour
+
+// MakeSimulationSnapshot captures the distro's resolved scheduler settings
+// and its workload for offline simulation. If lookback is zero, the snapshot
+// contains the distro's current task queue and hosts. Otherwise, it contains
+// every task that was activated on the distro within the lookback window and
+// the hosts that were up at the start of the window, so that the window can
+// be replayed under different settings.
+func MakeSimulationSnapshot(distroID string, settings *evergreen.Settings, lookback time.Duration) (*SimulationSnapshot, error) {
+	d, err := distro.FindOneId(distroID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding distro '%s'", distroID)
+	}
+	if d == nil {
+		return nil, errors.Errorf("distro '%s' not found", distroID)
+	}
+
+	plannerSettings, err := d.GetResolvedPlannerSettings(settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving planner settings")
+	}
+	allocatorSettings, err := d.GetResolvedHostAllocatorSettings(settings)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolving host allocator settings")
+	}
+
+	now := time.Now()
+	snapshot := &SimulationSnapshot{
+		DistroID:              d.Id,
+		Provider:              d.Provider,
+		CreatedAt:             now,
+		WindowStart:           now.Add(-lookback),
+		PlannerSettings:       plannerSettings,
+		HostAllocatorSettings: allocatorSettings,
+	}
+
+	tasks, err := findSimulationTasks(d.Id, snapshot.WindowStart, lookback > 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks")
+	}
+	for _, t := range tasks {
+		snapshot.Tasks = append(snapshot.Tasks, makeSimulationTask(t, snapshot.WindowStart))
+	}
+
+	hosts, err := findSimulationHosts(d.Id, snapshot.WindowStart, lookback > 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding hosts")
+	}
+	snapshot.Hosts = hosts
+
+	snapshot.HostStartupDuration, err = getAverageHostStartupDuration(d.Id, now.Add(-hostStartupHistoryWindow))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting average host startup duration")
+	}
+
+	return snapshot, nil
+}
+
+// findSimulationTasks returns the tasks in the distro's current task queue
+// along with, if includeHistory is set, the tasks that were activated on the
+// distro since the window start.
+func findSimulationTasks(distroID string, windowStart time.Time, includeHistory bool) ([]task.Task, error) {
+	queue, err := model.LoadTaskQueue(distroID)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading task queue")
+	}
+
+	var queuedIDs []string
+	if queue != nil {
+		for _, item := range queue.Queue {
+			if !item.IsDispatched {
+				queuedIDs = append(queuedIDs, item.Id)
+			}
+		}
+	}
+
+	var tasks []task.Task
+	if len(queuedIDs) > 0 {
+		tasks, err = task.Find(task.ByIds(queuedIDs))
+		if err != nil {
+			return nil, errors.Wrap(err, "finding queued tasks")
+		}
+	}
+	if !includeHistory {
+		return tasks, nil
+	}
+
+	historical, err := task.Find(bson.M{
+		task.DistroIdKey:      distroID,
+		task.ActivatedTimeKey: bson.M{"$gte": windowStart},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks activated in the window")
+	}
+
+	seen := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		seen[t.Id] = true
+	}
+	for _, t := range historical {
+		if !seen[t.Id] {
+			tasks = append(tasks, t)
+			seen[t.Id] = true
+		}
+	}
+
+	return tasks, nil
+}
+
+func makeSimulationTask(t task.Task, windowStart time.Time) SimulationTask {
+	st := SimulationTask{
+		ID:                     t.Id,
+		DisplayName:            t.DisplayName,
+		BuildVariant:           t.BuildVariant,
+		BuildID:                t.BuildId,
+		Project:                t.Project,
+		Version:                t.Version,
+		Requester:              t.Requester,
+		RevisionOrderNumber:    t.RevisionOrderNumber,
+		Priority:               t.Priority,
+		TaskGroup:              t.TaskGroup,
+		TaskGroupMaxHosts:      t.TaskGroupMaxHosts,
+		TaskGroupOrder:         t.TaskGroupOrder,
+		GenerateTask:           t.GenerateTask,
+		ActivatedByStepback:    t.ActivatedBy == evergreen.StepbackTaskActivator,
+		NumDependents:          t.NumDependents,
+		ExpectedDuration:       t.ExpectedDuration,
+		ExpectedDurationStdDev: t.ExpectedDurationStdDev,
+	}
+	for _, dep := range t.DependsOn {
+		st.DependsOn = append(st.DependsOn, dep.TaskId)
+	}
+
+	arrival := t.ActivatedTime
+	if utility.IsZeroTime(arrival) || arrival.Before(windowStart) {
+		arrival = windowStart
+	}
+	st.ArrivalOffset = arrival.Sub(windowStart)
+
+	if t.IsFinished() && !utility.IsZeroTime(t.StartTime) && t.FinishTime.After(t.StartTime) {
+		st.Duration = t.FinishTime.Sub(t.StartTime)
+	} else {
+		st.Duration = t.ExpectedDuration
+	}
+
+	return st
+}
+
+// findSimulationHosts returns the distro's hosts that were up at the window
+// start. If includeHistory is not set, it returns the distro's current hosts
+// instead, which become available once their running task is expected to
+// finish.
+func findSimulationHosts(distroID string, windowStart time.Time, includeHistory bool) ([]SimulationHostInfo, error) {
+	var hosts []SimulationHostInfo
+	if includeHistory {
+		found, err := host.Find(db.Query(bson.M{
+			bsonutil.GetDottedKeyName(host.DistroKey, distro.IdKey): distroID,
+			host.StartedByKey:  evergreen.User,
+			host.CreateTimeKey: bson.M{"$lte": windowStart},
+			"$or": []bson.M{
+				{host.TerminationTimeKey: bson.M{"$gt": windowStart}},
+				{host.TerminationTimeKey: utility.ZeroTime},
+				{host.TerminationTimeKey: bson.M{"$exists": false}},
+			},
+		}))
+		if err != nil {
+			return nil, errors.Wrap(err, "finding hosts that were up at the start of the window")
+		}
+		for _, h := range found {
+			hosts = append(hosts, SimulationHostInfo{ID: h.Id})
+		}
+		return hosts, nil
+	}
+
+	found, err := host.AllActiveHosts(distroID)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding active hosts")
+	}
+	for _, h := range found {
+		info := SimulationHostInfo{ID: h.Id}
+		if h.RunningTask != "" {
+			t, err := task.FindOneId(h.RunningTask)
+			if err != nil {
+				return nil, errors.Wrapf(err, "finding running task '%s' for host '%s'", h.RunningTask, h.Id)
+			}
+			if t != nil {
+				remaining := t.ExpectedDuration - windowStart.Sub(t.StartTime)
+				if remaining > 0 {
+					info.AvailableOffset = remaining
+				}
+			}
+		}
+		hosts = append(hosts, info)
+	}
+
+	return hosts, nil
+}
+
+// getAverageHostStartupDuration returns the average time between creating a
+// host in the distro and the host's agent starting for hosts created since
+// the given time.
+func getAverageHostStartupDuration(distroID string, since time.Time) (time.Duration, error) {
+	found, err := host.Find(db.Query(bson.M{
+		bsonutil.GetDottedKeyName(host.DistroKey, distro.IdKey): distroID,
+		host.StartedByKey:      evergreen.User,
+		host.CreateTimeKey:     bson.M{"$gte": since},
+		host.AgentStartTimeKey: bson.M{"$gt": utility.ZeroTime},
+	}).WithFields(host.CreateTimeKey, host.AgentStartTimeKey))
+	if err != nil {
+		return 0, errors.Wrap(err, "finding recently started hosts")
+	}
+
+	var total time.Duration
+	var num int
+	for _, h := range found {
+		if h.AgentStartTime.After(h.CreationTime) {
+			total += h.AgentStartTime.Sub(h.CreationTime)
+			num++
+		}
+	}
+	if num == 0 {
+		return 0, nil
+	}
+
+	return total / time.Duration(num), nil
+}
@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeSimulationTestSnapshot(numTasks int, taskDuration time.Duration) SimulationSnapshot {
+	snapshot := SimulationSnapshot{
+		DistroID: "distro",
+		Provider: evergreen.ProviderNameMock,
+		PlannerSettings: distro.PlannerSettings{
+			Version:    evergreen.PlannerVersionLegacy,
+			TargetTime: 30 * time.Minute,
+		},
+		HostAllocatorSettings: distro.HostAllocatorSettings{
+			Version:                evergreen.HostAllocatorUtilization,
+			MaximumHosts:           10,
+			RoundingRule:           evergreen.HostAllocatorRoundDefault,
+			FeedbackRule:           evergreen.HostAllocatorNoFeedback,
+			HostsOverallocatedRule: evergreen.HostsOverallocatedIgnore,
+			AcceptableHostIdleTime: 5 * time.Minute,
+		},
+		HostStartupDuration: 2 * time.Minute,
+	}
+	for i := 0; i < numTasks; i++ {
+		snapshot.Tasks = append(snapshot.Tasks, SimulationTask{
+			ID:               fmt.Sprintf("t%d", i),
+			DisplayName:      fmt.Sprintf("task%d", i),
+			BuildVariant:     "bv",
+			Project:          "project",
+			Version:          "version",
+			Requester:        evergreen.RepotrackerVersionRequester,
+			ExpectedDuration: taskDuration,
+		})
+	}
+	return snapshot
+}
+
+func TestSimulate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("CompletesAllTasks", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(20, 10*time.Minute)
+		report, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 20, report.NumTasks)
+		assert.Equal(t, 20, report.NumTasksCompleted)
+		assert.False(t, report.TimedOut)
+		assert.NotZero(t, report.NumHostsCreated)
+		assert.True(t, report.PeakHosts <= snapshot.HostAllocatorSettings.MaximumHosts)
+		assert.True(t, report.QueueWaitP50 <= report.QueueWaitP90)
+		assert.True(t, report.QueueWaitP90 <= report.QueueWaitMax)
+		assert.True(t, report.Makespan >= 10*time.Minute)
+		assert.NotZero(t, report.HostHours)
+	})
+	t.Run("MoreHostsReduceQueueWait", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(40, 10*time.Minute)
+		snapshot.HostAllocatorSettings.MaximumHosts = 2
+		few, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+
+		allocatorSettings := snapshot.HostAllocatorSettings
+		allocatorSettings.MaximumHosts = 20
+		many, err := Simulate(ctx, snapshot, SimulationOptions{HostAllocatorSettings: &allocatorSettings})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, few.MaxHosts)
+		assert.Equal(t, 20, many.MaxHosts)
+		assert.True(t, many.QueueWaitMean < few.QueueWaitMean)
+		assert.True(t, many.Makespan < few.Makespan)
+		assert.True(t, few.PeakHosts <= 2)
+	})
+	t.Run("UsesExistingHosts", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(2, 10*time.Minute)
+		snapshot.HostAllocatorSettings.MaximumHosts = 2
+		snapshot.Hosts = []SimulationHostInfo{{ID: "h1"}, {ID: "h2"}}
+
+		report, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, report.NumTasksCompleted)
+		assert.Zero(t, report.NumHostsCreated)
+		assert.Zero(t, report.QueueWaitMax)
+		assert.Equal(t, 10*time.Minute, report.Makespan)
+	})
+	t.Run("RespectsDependencies", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(2, 10*time.Minute)
+		snapshot.Tasks[1].DependsOn = []string{snapshot.Tasks[0].ID}
+		snapshot.Hosts = []SimulationHostInfo{{ID: "h1"}, {ID: "h2"}}
+
+		report, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, report.NumTasksCompleted)
+		assert.True(t, report.Makespan >= 20*time.Minute)
+	})
+	t.Run("UsesActualDuration", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(1, 10*time.Minute)
+		snapshot.Tasks[0].Duration = time.Hour
+		snapshot.Hosts = []SimulationHostInfo{{ID: "h1"}}
+
+		report, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Hour, report.Makespan)
+	})
+	t.Run("SupportsTunablePlanner", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(10, 5*time.Minute)
+		plannerSettings := snapshot.PlannerSettings
+		plannerSettings.Version = evergreen.PlannerVersionTunable
+
+		report, err := Simulate(ctx, snapshot, SimulationOptions{PlannerSettings: &plannerSettings})
+		require.NoError(t, err)
+
+		assert.Equal(t, evergreen.PlannerVersionTunable, report.PlannerVersion)
+		assert.Equal(t, 10, report.NumTasksCompleted)
+	})
+	t.Run("UsesWarmPoolScheduleAtSimulatedTime", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(1, 10*time.Minute)
+		snapshot.Hosts = []SimulationHostInfo{{ID: "h1"}}
+		snapshot.HostAllocatorSettings.WarmPool = distro.WarmPoolSettings{
+			Schedule: []distro.WarmPoolScheduleEntry{{StartTime: "02:00", EndTime: "04:00", ReadyHosts: 4}},
+		}
+
+		snapshot.WindowStart = time.Date(2023, time.January, 1, 3, 0, 0, 0, time.UTC)
+		inWindow, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+		assert.NotZero(t, inWindow.NumHostsCreated, "warm pool should be filled during the scheduled window")
+
+		snapshot.WindowStart = time.Date(2023, time.January, 1, 12, 0, 0, 0, time.UTC)
+		outOfWindow, err := Simulate(ctx, snapshot, SimulationOptions{})
+		require.NoError(t, err)
+		assert.Zero(t, outOfWindow.NumHostsCreated, "warm pool should not be filled outside the scheduled window")
+	})
+	t.Run("TimesOut", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(10, time.Hour)
+		snapshot.HostAllocatorSettings.MaximumHosts = 1
+
+		report, err := Simulate(ctx, snapshot, SimulationOptions{MaxDuration: 2 * time.Hour})
+		require.NoError(t, err)
+
+		assert.True(t, report.TimedOut)
+		assert.True(t, report.NumTasksCompleted < report.NumTasks)
+	})
+	t.Run("FailsWithInvalidSettings", func(t *testing.T) {
+		snapshot := makeSimulationTestSnapshot(1, time.Minute)
+		snapshot.HostAllocatorSettings.MaximumHosts = 0
+
+		_, err := Simulate(ctx, snapshot, SimulationOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -223,7 +223,7 @@ func (cbtc *CmpBasedTaskComparator) splitTasksByRequester(
 			repoTrackerTasks = append(repoTrackerTasks, task)
 		case evergreen.IsPatchRequester(task.Requester):
 			patchTasks = append(patchTasks, task)
-		case task.Requester == evergreen.AdHocRequester:
+		case task.Requester == evergreen.AdHocRequester, evergreen.IsGithubMergeQueueRequester(task.Requester):
 			patchTasks = append(patchTasks, task)
 		default:
 			grip.Error(message.Fields{
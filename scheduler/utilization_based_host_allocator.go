@@ -97,7 +97,8 @@ func UtilizationBasedHostAllocator(ctx context.Context, hostAllocatorData *HostA
 			distro.HostAllocatorSettings.FutureHostFraction,
 			hostAllocatorData.ContainerPool,
 			hostAllocatorData.DistroQueueInfo.MaxDurationThreshold,
-			maxHosts)
+			maxHosts,
+			hostAllocatorData.RunningTasks)
 
 		if err != nil {
 			return 0, len(freeHosts), errors.Wrapf(err, "error calculating hosts for distro %s", distro.Id)
@@ -120,6 +121,10 @@ func UtilizationBasedHostAllocator(ctx context.Context, hostAllocatorData *HostA
 	}
 	numNewHostsToRequest := numNewHostsRequired + numAdditionalHostsToMeetMinimum
 
+	// Keep the distro's warm pool of free hosts ready for bursts of new tasks.
+	numWarmPoolHosts := numWarmPoolHostsToRequest(distro, hostAllocatorData.Now, numExistingHosts, len(freeHosts), numNewHostsToRequest, hostAllocatorData.DistroQueueInfo.Length)
+	numNewHostsToRequest += numWarmPoolHosts
+
 	grip.Info(message.Fields{
 		"runner":                               RunnerName,
 		"message":                              "requesting new hosts",
@@ -130,6 +135,7 @@ func UtilizationBasedHostAllocator(ctx context.Context, hostAllocatorData *HostA
 		"num_existing_hosts":                   numExistingHosts,
 		"num_new_hosts_required:":              numNewHostsRequired,
 		"num_additional_hosts_to_meet_minimum": numAdditionalHostsToMeetMinimum,
+		"num_warm_pool_hosts":                  numWarmPoolHosts,
 		"total_new_hosts_to_request":           numNewHostsToRequest,
 	})
 
@@ -139,7 +145,7 @@ func UtilizationBasedHostAllocator(ctx context.Context, hostAllocatorData *HostA
 // Calculate the number of hosts needed by taking the total task scheduled task time
 // and dividing it by the target duration. Request however many hosts are needed to
 // achieve that minus the number of free hosts
-func evalHostUtilization(ctx context.Context, d distro.Distro, taskGroupData TaskGroupData, futureHostFraction float64, containerPool *evergreen.ContainerPool, maxDurationThreshold time.Duration, maxHosts int) (int, int, error) {
+func evalHostUtilization(ctx context.Context, d distro.Distro, taskGroupData TaskGroupData, futureHostFraction float64, containerPool *evergreen.ContainerPool, maxDurationThreshold time.Duration, maxHosts int, runningTasks map[string]task.Task) (int, int, error) {
 	evalStartAt := time.Now()
 	existingHosts := taskGroupData.Hosts
 	taskGroupInfo := taskGroupData.Info
@@ -169,7 +175,7 @@ func evalHostUtilization(ctx context.Context, d distro.Distro, taskGroupData Tas
 
 	// determine how many free hosts we have that are already up
 	startAt := time.Now()
-	numFreeHosts, err := calcExistingFreeHosts(existingHosts, futureHostFraction, maxDurationThreshold)
+	numFreeHosts, err := calcExistingFreeHosts(existingHosts, runningTasks, futureHostFraction, maxDurationThreshold)
 	if err != nil {
 		return numNewHosts, numFreeHosts, err
 	}
@@ -328,7 +334,7 @@ func calcNewHostsNeeded(scheduledDuration, maxDurationPerHost time.Duration, num
 
 // calcExistingFreeHosts returns the number of hosts that are not running a task,
 // plus hosts that will soon be free scaled by some fraction
-func calcExistingFreeHosts(existingHosts []host.Host, futureHostFactor float64, maxDurationPerHost time.Duration) (int, error) {
+func calcExistingFreeHosts(existingHosts []host.Host, runningTasks map[string]task.Task, futureHostFactor float64, maxDurationPerHost time.Duration) (int, error) {
 	numFreeHosts := 0
 	if futureHostFactor > 1 {
 		return numFreeHosts, errors.New("future host factor cannot be greater than 1")
@@ -340,7 +346,7 @@ func calcExistingFreeHosts(existingHosts []host.Host, futureHostFactor float64,
 		}
 	}
 
-	soonToBeFree, err := getSoonToBeFreeHosts(existingHosts, futureHostFactor, maxDurationPerHost)
+	soonToBeFree, err := getSoonToBeFreeHosts(existingHosts, runningTasks, futureHostFactor, maxDurationPerHost)
 	if err != nil {
 		return 0, err
 	}
@@ -352,22 +358,31 @@ func calcExistingFreeHosts(existingHosts []host.Host, futureHostFactor float64,
 // to be free for some fraction of the next maxDurationPerHost interval
 // the final value is scaled by some fraction representing how confident we are that
 // the hosts will actually be free in the expected amount of time
-func getSoonToBeFreeHosts(existingHosts []host.Host, futureHostFraction float64, maxDurationPerHost time.Duration) (float64, error) {
+func getSoonToBeFreeHosts(existingHosts []host.Host, cachedRunningTasks map[string]task.Task, futureHostFraction float64, maxDurationPerHost time.Duration) (float64, error) {
 	runningTaskIds := []string{}
+	runningTasks := []task.Task{}
 
 	for _, existingDistroHost := range existingHosts {
-		if existingDistroHost.RunningTask != "" {
+		if existingDistroHost.RunningTask == "" {
+			continue
+		}
+		if t, ok := cachedRunningTasks[existingDistroHost.RunningTask]; ok {
+			runningTasks = append(runningTasks, t)
+		} else {
 			runningTaskIds = append(runningTaskIds, existingDistroHost.RunningTask)
 		}
 	}
 
-	if len(runningTaskIds) == 0 {
-		return 0.0, nil
+	if len(runningTaskIds) > 0 {
+		foundTasks, err := task.Find(task.ByIds(runningTaskIds))
+		if err != nil {
+			return 0.0, err
+		}
+		runningTasks = append(runningTasks, foundTasks...)
 	}
 
-	runningTasks, err := task.Find(task.ByIds(runningTaskIds))
-	if err != nil {
-		return 0.0, err
+	if len(runningTasks) == 0 {
+		return 0.0, nil
 	}
 
 	nums := make(chan float64, len(runningTasks))
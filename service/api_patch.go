@@ -123,6 +123,7 @@ type patchData struct {
 	RepeatPatchId     string             `json:"repeat_patch_id"`
 	GithubAuthor      string             `json:"github_author"`
 	PatchAuthor       string             `json:"patch_author"`
+	BasePatchID       string             `json:"base_patch_id"`
 }
 
 // submitPatch creates the Patch document, adds the patched project config to it,
@@ -198,6 +199,7 @@ func (as *APIServer) submitPatch(w http.ResponseWriter, r *http.Request) {
 		RepeatDefinition: data.RepeatDefinition,
 		RepeatFailed:     data.RepeatFailed,
 		RepeatPatchId:    data.RepeatPatchId,
+		BasePatchID:      data.BasePatchID,
 		SyncParams: patch.SyncAtEndOptions{
 			BuildVariants: data.SyncBuildVariants,
 			Tasks:         data.SyncTasks,
@@ -98,7 +98,7 @@ func (uis *UIServer) buildPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if evergreen.IsPatchRequester(projCtx.Build.Requester) {
-		buildOnBaseCommit, err := projCtx.Build.FindBuildOnBaseCommit()
+		buildOnBaseCommit, err := model.FindPatchBaseBuild(projCtx.Patch, projCtx.Build)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -338,7 +338,7 @@ func (uis *UIServer) taskPage(w http.ResponseWriter, r *http.Request) {
 	if projCtx.Patch != nil {
 		var taskOnBaseCommit *task.Task
 		var testResultsOnBaseCommit []testresult.TestResult
-		taskOnBaseCommit, err = projCtx.Task.FindTaskOnBaseCommit()
+		taskOnBaseCommit, err = model.FindPatchBaseTask(projCtx.Patch, projCtx.Task)
 		if err != nil {
 			uis.LoggedError(w, r, http.StatusInternalServerError, err)
 			return
@@ -832,55 +832,9 @@ func (uis *UIServer) testLog(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "not found", http.StatusNotFound)
 			return
 		}
-
-		ctx, cancel := context.WithCancel(r.Context())
-		defer cancel()
-
-		data.Data = make(chan apimodels.LogMessage)
-		go func() {
-			defer close(data.Data)
-			for _, line := range testLog.Lines {
-				if ctx.Err() != nil {
-					return
-				}
-				data.Data <- apimodels.LogMessage{
-					Type:     apimodels.TaskLogPrefix,
-					Severity: apimodels.LogInfoPrefix,
-					Version:  evergreen.LogmessageCurrentVersion,
-					Message:  line,
-				}
-			}
-		}()
-	} else if uis.Settings.Cedar.BaseURL != "" {
-		// Search for logs in cedar.
-		opts := apimodels.GetBuildloggerLogsOptions{
-			BaseURL:       uis.Settings.Cedar.BaseURL,
-			TaskID:        taskID,
-			TestName:      testName,
-			GroupID:       vals.Get("group_id"),
-			Execution:     utility.ToIntPtr(taskExec),
-			PrintPriority: !raw,
-		}
-		logReader, err = apimodels.GetBuildloggerLogs(r.Context(), opts)
-		if err == nil {
-			defer func() {
-				grip.Warning(message.WrapError(logReader.Close(), message.Fields{
-					"task_id":   taskID,
-					"test_name": testName,
-					"message":   "failed to close buildlogger log ReadCloser",
-				}))
-			}()
-		} else {
-			grip.Warning(message.WrapError(err, message.Fields{
-				"task_id":   taskID,
-				"test_name": testName,
-				"message":   "problem getting buildlogger logs",
-			}))
-		}
 	} else {
-		// TODO (EVG-17662): Replace this with a more permanent option.
-		// Fallback to legacy test results.
-		// Direct link to a log document in the database.
+		// Tasks that use the local test results service store their test
+		// logs in the database, so check there before Cedar.
 		testLog, err = model.FindOneTestLog(testName, taskID, taskExec)
 		if err != nil {
 			uis.LoggedError(w, r, http.StatusInternalServerError, err)
@@ -888,10 +842,40 @@ func (uis *UIServer) testLog(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if testLog == nil {
-			http.Error(w, "not found", http.StatusNotFound)
-			return
+			if uis.Settings.Cedar.BaseURL == "" {
+				http.Error(w, "not found", http.StatusNotFound)
+				return
+			}
+
+			// Search for logs in cedar.
+			opts := apimodels.GetBuildloggerLogsOptions{
+				BaseURL:       uis.Settings.Cedar.BaseURL,
+				TaskID:        taskID,
+				TestName:      testName,
+				GroupID:       vals.Get("group_id"),
+				Execution:     utility.ToIntPtr(taskExec),
+				PrintPriority: !raw,
+			}
+			logReader, err = apimodels.GetBuildloggerLogs(r.Context(), opts)
+			if err == nil {
+				defer func() {
+					grip.Warning(message.WrapError(logReader.Close(), message.Fields{
+						"task_id":   taskID,
+						"test_name": testName,
+						"message":   "failed to close buildlogger log ReadCloser",
+					}))
+				}()
+			} else {
+				grip.Warning(message.WrapError(err, message.Fields{
+					"task_id":   taskID,
+					"test_name": testName,
+					"message":   "problem getting buildlogger logs",
+				}))
+			}
 		}
+	}
 
+	if testLog != nil {
 		ctx, cancel := context.WithCancel(r.Context())
 		defer cancel()
 
@@ -96,7 +96,7 @@ func (uis *UIServer) versionPage(w http.ResponseWriter, r *http.Request) {
 		versionAsUI.PatchInfo = &uiPatch{Patch: *projCtx.Patch}
 		// diff builds for each build in the version
 		var baseBuilds []build.Build
-		baseBuilds, err = build.Find(build.ByRevisionWithSystemVersionRequester(projCtx.Version.Revision))
+		baseBuilds, err = model.FindPatchBaseBuilds(projCtx.Patch)
 		if err != nil {
 			http.Error(w,
 				fmt.Sprintf("error loading base builds for patch: %v", err),
@@ -126,18 +126,26 @@ func (uis *UIServer) versionPage(w http.ResponseWriter, r *http.Request) {
 				diffs = append(diffs, diff.Tasks...)
 			}
 		}
-		var baseVersion *model.Version
-		baseVersion, err = model.VersionFindOne(model.BaseVersionByProjectIdAndRevision(projCtx.Version.Identifier, projCtx.Version.Revision))
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if baseVersion == nil {
-			grip.Warningln("Could not find version for base commit of patch build: ", projCtx.Version.Id)
-		}
 		baseId := ""
-		if baseVersion != nil {
-			baseId = baseVersion.Id
+		if projCtx.Patch.IsStacked() {
+			baseId, err = model.FindPatchBaseVersionID(projCtx.Patch)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			var baseVersion *model.Version
+			baseVersion, err = model.VersionFindOne(model.BaseVersionByProjectIdAndRevision(projCtx.Version.Identifier, projCtx.Version.Revision))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if baseVersion == nil {
+				grip.Warningln("Could not find version for base commit of patch build: ", projCtx.Version.Id)
+			}
+			if baseVersion != nil {
+				baseId = baseVersion.Id
+			}
 		}
 		versionAsUI.PatchInfo.BaseVersionId = baseId
 		versionAsUI.PatchInfo.StatusDiffs = diffs
@@ -465,6 +465,28 @@ func MockConfig() *evergreen.Settings {
 			Enabled:           true,
 			CollectorEndpoint: "localhost:4317",
 		},
+		EventRetention: evergreen.EventRetentionConfig{
+			BucketType: evergreen.EventArchiveBucketTypeLocal,
+			Bucket:     "/tmp/event_archive",
+			Prefix:     "events",
+			Policies: []evergreen.EventRetentionPolicy{
+				{ResourceType: "ADMIN", RetentionDays: 2557},
+				{ResourceType: "HOST", RetentionDays: 365},
+			},
+		},
+		Provenance: evergreen.ProvenanceConfig{
+			SigningKey: "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
+			KeyID:      "evergreen-test",
+			BuilderID:  "https://evergreen.example.com",
+		},
+		GithubApp: evergreen.GithubAppConfig{
+			AppID: 1234,
+		},
+		TestResults: evergreen.TestResultsConfig{
+			Service:           evergreen.TestResultsServiceLocal,
+			BackfillFromCedar: true,
+			BackfillBatchSize: 50,
+		},
 		ShutdownWaitSeconds: 15,
 	}
 }
@@ -3,6 +3,7 @@ package thirdparty
 import (
 	"bytes"
 	"context"
+	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +15,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/utility"
+	"github.com/golang-jwt/jwt"
 	"github.com/google/go-github/v34/github"
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/mongodb/grip"
@@ -35,6 +37,10 @@ const (
 	githubWrite      = "write"
 
 	GithubInvestigation = "Github API Limit Investigation"
+
+	// githubCompareMaxFiles is the maximum number of changed files that
+	// GitHub lists in a comparison.
+	githubCompareMaxFiles = 300
 )
 
 var UnblockedGithubStatuses = []string{
@@ -334,6 +340,16 @@ func GetGithubFile(ctx context.Context, oauthToken, owner, repo, path, ref strin
 // SendPendingStatusToGithub sends a pending status to a Github PR patch
 // associated with a given version.
 func SendPendingStatusToGithub(input SendGithubStatusInput) error {
+	return sendVersionStatusToGithub(input, message.GithubStatePending)
+}
+
+// SendFailedStatusToGithub sends a failed status to GitHub for a version that
+// cannot run, such as one whose project configuration has errors.
+func SendFailedStatusToGithub(input SendGithubStatusInput) error {
+	return sendVersionStatusToGithub(input, message.GithubStateFailure)
+}
+
+func sendVersionStatusToGithub(input SendGithubStatusInput, state message.GithubState) error {
 	flags, err := evergreen.GetServiceFlags()
 	if err != nil {
 		return errors.Wrap(err, "error retrieving admin settings")
@@ -360,7 +376,7 @@ func SendPendingStatusToGithub(input SendGithubStatusInput) error {
 		Ref:         input.Ref,
 		URL:         fmt.Sprintf("%s/version/%s?redirect_spruce_users=true", urlBase, input.VersionId),
 		Context:     input.Context,
-		State:       message.GithubStatePending,
+		State:       state,
 		Description: input.Desc,
 	}
 	sender, err := env.GetSender(evergreen.SenderGithubStatus)
@@ -493,6 +509,79 @@ func GetCommitDiff(ctx context.Context, oauthToken, repoOwner, repo, sha string)
 	return commit, nil
 }
 
+// GetGithubCompareDiff gets the diff between the base and head refs via an
+// API call to GitHub. The head can be in a fork of the repo by using the
+// "owner:ref" syntax.
+func GetGithubCompareDiff(ctx context.Context, oauthToken, repoOwner, repo, base, head string) (string, error) {
+	httpClient := getGithubClientRetry(oauthToken, "GetGithubCompareDiff")
+	defer utility.PutHTTPClient(httpClient)
+	client := github.NewClient(httpClient)
+
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/compare/%s...%s", repoOwner, repo, base, head), nil)
+	if err != nil {
+		return "", errors.Wrap(err, "creating compare request")
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3.diff")
+
+	diff := &bytes.Buffer{}
+	resp, err := client.Do(ctx, req, diff)
+	if resp != nil {
+		defer resp.Body.Close()
+		if err != nil {
+			return "", parseGithubErrorResponse(resp)
+		}
+	} else {
+		errMsg := fmt.Sprintf("nil response from '%s/%s': compare '%s...%s': %v", repoOwner, repo, base, head, err)
+		grip.Error(message.Fields{
+			"message": errMsg,
+			"owner":   repoOwner,
+			"repo":    repo,
+			"base":    base,
+			"head":    head,
+		})
+		return "", APIResponseError{errMsg}
+	}
+
+	// GitHub silently leaves files out of the diff when the comparison is too
+	// large, so make sure that every changed file is in it.
+	compare, resp, err := client.Repositories.CompareCommits(ctx, repoOwner, repo, base, head)
+	if resp != nil {
+		defer resp.Body.Close()
+		if err != nil {
+			return "", parseGithubErrorResponse(resp)
+		}
+	} else {
+		return "", APIResponseError{fmt.Sprintf("nil response from '%s/%s': compare '%s...%s': %v", repoOwner, repo, base, head, err)}
+	}
+	if compare == nil {
+		return "", APIRequestError{Message: "missing data from GitHub compare response"}
+	}
+	if err = checkGithubCompareDiffComplete(diff.String(), len(compare.Files)); err != nil {
+		return "", errors.Wrapf(err, "comparing '%s...%s' in '%s/%s'", base, head, repoOwner, repo)
+	}
+
+	return diff.String(), nil
+}
+
+// checkGithubCompareDiffComplete returns an error if the diff does not include
+// every one of the files that GitHub reports as changed, or if there are more
+// changed files than GitHub lists in a comparison.
+func checkGithubCompareDiffComplete(diff string, numChangedFiles int) error {
+	if numChangedFiles >= githubCompareMaxFiles {
+		return errors.Errorf("comparison changes at least %d files, which is more than GitHub includes in a diff", githubCompareMaxFiles)
+	}
+	numDiffFiles := 0
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			numDiffFiles++
+		}
+	}
+	if numDiffFiles < numChangedFiles {
+		return errors.Errorf("diff only includes %d of %d changed files because GitHub truncated it", numDiffFiles, numChangedFiles)
+	}
+	return nil
+}
+
 // GetBranchEvent gets the head of the a given branch via an API call to GitHub
 func GetBranchEvent(ctx context.Context, oauthToken, repoOwner, repo, branch string) (*github.Branch, error) {
 	httpClient := getGithubClientRetry(oauthToken, "GetBranchEvent")
@@ -1184,3 +1273,166 @@ func PostCommentToPullRequest(ctx context.Context, token, owner, repo string, pr
 	}
 	return nil
 }
+
+// GetGithubAppInstallationToken returns a short-lived token that acts as the
+// GitHub App's installation on the given repo. GitHub only allows GitHub Apps
+// to use some APIs, such as the checks API.
+func GetGithubAppInstallationToken(ctx context.Context, appID int64, privateKey *rsa.PrivateKey, owner, repo string) (string, error) {
+	appToken, err := makeGithubAppJWT(appID, privateKey, time.Now())
+	if err != nil {
+		return "", errors.Wrap(err, "creating GitHub App JWT")
+	}
+
+	httpClient := getGithubClientRetry(appToken, "GetGithubAppInstallationToken")
+	defer utility.PutHTTPClient(httpClient)
+	githubClient := github.NewClient(httpClient)
+
+	installation, resp, err := githubClient.Apps.FindRepositoryInstallation(ctx, owner, repo)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "finding GitHub App installation for '%s/%s'", owner, repo)
+	}
+	if installation == nil || installation.ID == nil {
+		return "", errors.Errorf("GitHub App is not installed on '%s/%s'", owner, repo)
+	}
+
+	token, tokenResp, err := githubClient.Apps.CreateInstallationToken(ctx, installation.GetID(), nil)
+	if tokenResp != nil {
+		defer tokenResp.Body.Close()
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "creating token for GitHub App installation %d", installation.GetID())
+	}
+	if token.GetToken() == "" {
+		return "", errors.New("unexpected data from GitHub")
+	}
+
+	return token.GetToken(), nil
+}
+
+// makeGithubAppJWT returns a JWT that authenticates as the GitHub App itself.
+// GitHub rejects JWTs that expire more than 10 minutes in the future, and
+// the issued time is backdated to allow for clock drift.
+func makeGithubAppJWT(appID int64, privateKey *rsa.PrivateKey, now time.Time) (string, error) {
+	claims := jwt.StandardClaims{
+		IssuedAt:  now.Add(-time.Minute).Unix(),
+		ExpiresAt: now.Add(9 * time.Minute).Unix(),
+		Issuer:    strconv.FormatInt(appID, 10),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(privateKey)
+}
+
+// CreateCheckRun creates a GitHub check run on the given commit.
+func CreateCheckRun(ctx context.Context, token, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, error) {
+	httpClient := getGithubClient(token, "CreateCheckRun")
+	defer utility.PutHTTPClient(httpClient)
+	githubClient := github.NewClient(httpClient)
+
+	checkRun, resp, err := githubClient.Checks.CreateCheckRun(ctx, owner, repo, opts)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating check run '%s' for '%s/%s'", opts.Name, owner, repo)
+	}
+	if checkRun == nil || checkRun.ID == nil {
+		return nil, errors.New("unexpected data from GitHub")
+	}
+
+	return checkRun, nil
+}
+
+// UpdateCheckRun updates an existing GitHub check run. Annotations in the
+// output are added to the annotations that the check run already has.
+func UpdateCheckRun(ctx context.Context, token, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, error) {
+	httpClient := getGithubClient(token, "UpdateCheckRun")
+	defer utility.PutHTTPClient(httpClient)
+	githubClient := github.NewClient(httpClient)
+
+	checkRun, resp, err := githubClient.Checks.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "updating check run %d for '%s/%s'", checkRunID, owner, repo)
+	}
+
+	return checkRun, nil
+}
+
+// FindCheckRun returns the most recent GitHub check run with the given name on
+// the given commit, or nil if there is none.
+func FindCheckRun(ctx context.Context, token, owner, repo, ref, name string) (*github.CheckRun, error) {
+	httpClient := getGithubClientRetry(token, "FindCheckRun")
+	defer utility.PutHTTPClient(httpClient)
+	githubClient := github.NewClient(httpClient)
+
+	results, resp, err := githubClient.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, &github.ListCheckRunsOptions{
+		CheckName: github.String(name),
+		Filter:    github.String("latest"),
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing check runs named '%s' for '%s/%s' at ref '%s'", name, owner, repo, ref)
+	}
+	if results == nil || len(results.CheckRuns) == 0 {
+		return nil, nil
+	}
+
+	return results.CheckRuns[0], nil
+}
+
+// FindPullRequestComment returns the first comment on the PR whose body
+// contains the given marker, or nil if there is no such comment.
+func FindPullRequestComment(ctx context.Context, token, owner, repo string, prNum int, marker string) (*github.IssueComment, error) {
+	httpClient := getGithubClientRetry(token, "FindPullRequestComment")
+	defer utility.PutHTTPClient(httpClient)
+	githubClient := github.NewClient(httpClient)
+
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		comments, resp, err := githubClient.Issues.ListComments(ctx, owner, repo, prNum, opts)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing comments for PR '%s/%s:%d'", owner, repo, prNum)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				return comment, nil
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// EditPullRequestComment replaces the body of an existing PR comment.
+func EditPullRequestComment(ctx context.Context, token, owner, repo string, commentID int64, comment string) error {
+	httpClient := getGithubClient(token, "EditPullRequestComment")
+	defer utility.PutHTTPClient(httpClient)
+	githubClient := github.NewClient(httpClient)
+
+	respComment, resp, err := githubClient.Issues.EditComment(ctx, owner, repo, commentID, &github.IssueComment{
+		Body: github.String(comment),
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "editing comment %d for '%s/%s'", commentID, owner, repo)
+	}
+	if respComment == nil || respComment.ID == nil {
+		return errors.New("unexpected data from GitHub")
+	}
+	return nil
+}
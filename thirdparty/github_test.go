@@ -2,6 +2,8 @@ package thirdparty
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"io"
 	"net"
@@ -16,9 +18,11 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/golang-jwt/jwt"
 	"github.com/google/go-github/v34/github"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -293,6 +297,25 @@ func TestValidatePR(t *testing.T) {
 	assert.Error(ValidatePR(pr))
 }
 
+func TestMakeGithubAppJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	now := time.Now()
+
+	signed, err := makeGithubAppJWT(1234, key, now)
+	require.NoError(t, err)
+
+	claims := jwt.StandardClaims{}
+	_, err = jwt.ParseWithClaims(signed, &claims, func(token *jwt.Token) (interface{}, error) {
+		assert.Equal(t, jwt.SigningMethodRS256, token.Method)
+		return &key.PublicKey, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1234", claims.Issuer)
+	assert.True(t, claims.IssuedAt < now.Unix())
+	assert.True(t, claims.ExpiresAt <= now.Add(10*time.Minute).Unix())
+}
+
 func TestParseGithubErrorResponse(t *testing.T) {
 	message := "my message"
 	url := "www.github.com"
@@ -310,3 +333,31 @@ func TestParseGithubErrorResponse(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, apiRequestErr.StatusCode)
 	assert.Equal(t, url, apiRequestErr.DocumentationUrl)
 }
+
+func TestCheckGithubCompareDiffComplete(t *testing.T) {
+	diff := `diff --git a/a.go b/a.go
+index 0000000..1111111 100644
+--- a/a.go
++++ b/a.go
+@@ -1 +1 @@
+-a
++b
+diff --git a/b.go b/b.go
+index 0000000..1111111 100644
+--- a/b.go
++++ b/b.go
+@@ -1 +1 @@
+-a
++b
+`
+
+	t.Run("SucceedsWithAllChangedFiles", func(t *testing.T) {
+		assert.NoError(t, checkGithubCompareDiffComplete(diff, 2))
+	})
+	t.Run("FailsWithMissingFiles", func(t *testing.T) {
+		assert.Error(t, checkGithubCompareDiffComplete(diff, 3))
+	})
+	t.Run("FailsWithTooManyChangedFiles", func(t *testing.T) {
+		assert.Error(t, checkGithubCompareDiffComplete(diff, githubCompareMaxFiles))
+	})
+}
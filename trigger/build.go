@@ -216,6 +216,7 @@ func (t *buildTriggers) makeData(sub *event.Subscription, pastTenseOverride stri
 	if t.build.Requester == evergreen.GithubPRRequester || t.build.Requester == evergreen.RepotrackerVersionRequester {
 		data.githubContext = fmt.Sprintf("evergreen/%s", t.build.BuildVariant)
 		data.githubDescription = t.build.GetFinishedNotificationDescription(t.tasks)
+		data.githubChecksOnly = t.event.EventType == event.BuildGithubCheckFinished
 	}
 	if data.PastTenseStatus == evergreen.BuildFailed {
 		data.githubState = message.GithubStateFailure
@@ -17,6 +17,7 @@ import (
 
 func init() {
 	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostExpirationWarningSent, makeHostTriggers)
+	registry.registerEventHandler(event.ResourceTypeHost, event.EventHostIdleStopWarningSent, makeHostIdleStopTriggers)
 }
 
 const (
@@ -25,6 +26,10 @@ const (
 	expiringHostEmailBody            = `Your {{.Distro}} host '{{.Name}}' will be terminated at {{.ExpirationTime}}. Visit the <a href={{.URL}}>spawnhost page</a> to extend its lifetime.`
 	expiringHostSlackBody            = `Your {{.Distro}} host '{{.Name}}' will be terminated at {{.ExpirationTime}}. Visit the <{{.URL}}|spawnhost page> to extend its lifetime.`
 	expiringHostSlackAttachmentTitle = "Spawn Host Page"
+
+	idleHostEmailSubject = `{{.Distro}} host idle reminder`
+	idleHostEmailBody    = `Your {{.Distro}} host '{{.Name}}' has been idle and will be stopped at {{.StopTime}}. Connect to the host to keep it running, or visit the <a href={{.URL}}>spawnhost page</a> to start it again later.`
+	idleHostSlackBody    = `Your {{.Distro}} host '{{.Name}}' has been idle and will be stopped at {{.StopTime}}. Connect to the host to keep it running, or visit the <{{.URL}}|spawnhost page> to start it again later.`
 )
 
 type hostBase struct {
@@ -73,6 +78,7 @@ type hostTemplateData struct {
 	Name           string
 	Distro         string
 	ExpirationTime string
+	StopTime       string
 	URL            string
 }
 
@@ -85,6 +91,15 @@ func makeHostTriggers() eventHandler {
 	return t
 }
 
+func makeHostIdleStopTriggers() eventHandler {
+	t := &hostTriggers{}
+	t.hostBase.base.triggers = map[string]trigger{
+		event.TriggerExpiration: t.hostIdleStop,
+	}
+
+	return t
+}
+
 type hostTriggers struct {
 	templateData hostTemplateData
 
@@ -110,14 +125,14 @@ func (t *hostTriggers) Fetch(e *event.EventLogEntry) error {
 	return nil
 }
 
-func (t *hostTriggers) generate(sub *event.Subscription) (*notification.Notification, error) {
+func (t *hostTriggers) generate(sub *event.Subscription, emailSubject, emailBody, slackBody string) (*notification.Notification, error) {
 	var payload interface{}
 	var err error
 	switch sub.Subscriber.Type {
 	case event.EmailSubscriberType:
-		payload, err = t.templateData.hostExpirationEmailPayload(expiringHostEmailSubject, expiringHostEmailBody, t.Attributes())
+		payload, err = t.templateData.hostExpirationEmailPayload(emailSubject, emailBody, t.Attributes())
 	case event.SlackSubscriberType:
-		payload, err = t.templateData.hostExpirationSlackPayload(expiringHostSlackBody, expiringHostSlackAttachmentTitle)
+		payload, err = t.templateData.hostExpirationSlackPayload(slackBody, expiringHostSlackAttachmentTitle)
 	default:
 		return nil, nil
 	}
@@ -177,19 +192,33 @@ func (t *hostTemplateData) hostExpirationSlackPayload(messageString string, link
 }
 
 func (t *hostTriggers) hostExpiration(sub *event.Subscription) (*notification.Notification, error) {
-	timeZone := time.Local
-	if sub.OwnerType == event.OwnerTypePerson {
-		userTimeZone, err := getUserTimeZone(sub.Owner)
-		if err != nil {
-			grip.Error(message.WrapError(err, message.Fields{
-				"message": "problem getting time zone",
-				"user":    sub.Owner,
-				"trigger": "hostExpiration",
-			}))
-		} else {
-			timeZone = userTimeZone
-		}
-	}
-	t.templateData.ExpirationTime = t.host.ExpirationTime.In(timeZone).Format(time.RFC1123)
-	return t.generate(sub)
+	t.templateData.ExpirationTime = t.host.ExpirationTime.In(subscriberTimeZone(sub, "hostExpiration")).Format(time.RFC1123)
+	return t.generate(sub, expiringHostEmailSubject, expiringHostEmailBody, expiringHostSlackBody)
+}
+
+func (t *hostTriggers) hostIdleStop(sub *event.Subscription) (*notification.Notification, error) {
+	if t.host.AutoStop.IdleStopTime.IsZero() {
+		// The host became busy again after the warning was logged.
+		return nil, nil
+	}
+	t.templateData.StopTime = t.host.AutoStop.IdleStopTime.In(subscriberTimeZone(sub, "hostIdleStop")).Format(time.RFC1123)
+	return t.generate(sub, idleHostEmailSubject, idleHostEmailBody, idleHostSlackBody)
+}
+
+// subscriberTimeZone returns the time zone of the person who owns the
+// subscription, or the local time zone if it cannot be determined.
+func subscriberTimeZone(sub *event.Subscription, triggerName string) *time.Location {
+	if sub.OwnerType != event.OwnerTypePerson {
+		return time.Local
+	}
+	userTimeZone, err := getUserTimeZone(sub.Owner)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "problem getting time zone",
+			"user":    sub.Owner,
+			"trigger": triggerName,
+		}))
+		return time.Local
+	}
+	return userTimeZone
 }
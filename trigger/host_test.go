@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/alertrecord"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/grip/message"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -103,3 +104,17 @@ func (s *hostSuite) TestHostExpiration() {
 	s.NoError(err)
 	s.NotNil(n)
 }
+
+func (s *hostSuite) TestHostIdleStop() {
+	n, err := s.t.hostIdleStop(&s.subs[0])
+	s.NoError(err)
+	s.Nil(n, "host that is not going to be stopped should not notify")
+
+	s.t.host.AutoStop.IdleStopTime = time.Now().Add(30 * time.Minute)
+	n, err = s.t.hostIdleStop(&s.subs[0])
+	s.NoError(err)
+	s.Require().NotNil(n)
+	payload, ok := n.Payload.(*message.Email)
+	s.Require().True(ok)
+	s.Contains(payload.Body, "has been idle and will be stopped at")
+}
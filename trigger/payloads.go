@@ -59,6 +59,9 @@ type commonTemplateData struct {
 	githubContext     string
 	githubState       message.GithubState
 	githubDescription string
+	// githubChecksOnly limits a build's GitHub check run to the tasks that
+	// are mainline GitHub checks.
+	githubChecksOnly bool
 
 	emailContent *template.Template
 }
@@ -427,6 +430,33 @@ func makeCommonPayload(sub *event.Subscription, eventAttributes event.Attributes
 		}
 		return msg, nil
 
+	case event.GithubCheckRunSubscriberType:
+		if len(data.githubContext) == 0 {
+			return nil, errors.Errorf("GitHub check run subscriber not supported for trigger '%s'", sub.Trigger)
+		}
+		checkRun := &model.GithubCheckRun{
+			Name:             data.githubContext,
+			URL:              data.URL,
+			GithubChecksOnly: data.githubChecksOnly,
+		}
+		switch data.Object {
+		case event.ObjectBuild:
+			checkRun.BuildID = data.ID
+		case event.ObjectTask:
+			checkRun.TaskID = data.ID
+		default:
+			return nil, errors.Errorf("GitHub check run subscriber not supported for '%s'", data.Object)
+		}
+		return checkRun, nil
+
+	case event.GithubPRCommentSubscriberType:
+		if data.Object != event.ObjectPatch {
+			return nil, errors.Errorf("GitHub PR comment subscriber not supported for '%s'", data.Object)
+		}
+		return &model.GithubPRSummaryComment{
+			PatchID: data.ID,
+		}, nil
+
 	case event.EnqueuePatchSubscriberType:
 		return &model.EnqueuePatch{
 			PatchID: data.ID,
@@ -2,6 +2,7 @@ package trigger
 
 import (
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/perf"
 	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
@@ -31,6 +33,7 @@ func init() {
 	registry.registerEventHandler(event.ResourceTypeTask, event.TaskStarted, makeTaskTriggers)
 	registry.registerEventHandler(event.ResourceTypeTask, event.TaskFinished, makeTaskTriggers)
 	registry.registerEventHandler(event.ResourceTypeTask, event.TaskBlocked, makeTaskTriggers)
+	registry.registerEventHandler(event.ResourceTypeTask, event.TaskPerfChangePoint, makeTaskPerfTriggers)
 }
 
 const (
@@ -48,6 +51,7 @@ func makeTaskTriggers() eventHandler {
 	}
 	t.base.triggers = map[string]trigger{
 		event.TriggerOutcome:                     t.taskOutcome,
+		event.TriggerGithubCheckOutcome:          t.taskGithubCheckOutcome,
 		event.TriggerFailure:                     t.taskFailure,
 		event.TriggerSuccess:                     t.taskSuccess,
 		event.TriggerExceedsDuration:             t.taskExceedsDuration,
@@ -65,6 +69,20 @@ func makeTaskTriggers() eventHandler {
 	return t
 }
 
+// makeTaskPerfTriggers returns the triggers for performance change points
+// detected at a task, which are separate from the task's other triggers
+// because they are not about the task's status.
+func makeTaskPerfTriggers() eventHandler {
+	t := &taskTriggers{
+		oldTestResults: map[string]*testresult.TestResult{},
+	}
+	t.base.triggers = map[string]trigger{
+		event.TriggerPerfChangePoint: t.taskPerfChangePoint,
+	}
+
+	return t
+}
+
 // newAlertRecord creates an instance of an alert record for the given alert type, populating it
 // with as much data from the triggerContext as possible
 func newAlertRecord(subID string, t *task.Task, alertType string) *alertrecord.AlertRecord {
@@ -261,6 +279,9 @@ func (t *taskTriggers) makeData(sub *event.Subscription, pastTenseOverride, test
 	if len(t.task.OldTaskId) != 0 {
 		data.URL = taskLink(t.uiConfig.Url, t.task.OldTaskId, t.task.Execution)
 	}
+	if t.task.Requester == evergreen.GithubPRRequester || t.task.Requester == evergreen.RepotrackerVersionRequester {
+		data.githubContext = fmt.Sprintf("evergreen/%s/%s", t.task.BuildVariant, t.task.DisplayName)
+	}
 
 	if data.PastTenseStatus == evergreen.TaskSystemFailed {
 		slackColor = evergreenSystemFailColor
@@ -412,6 +433,13 @@ func (t *taskTriggers) taskOutcome(sub *event.Subscription) (*notification.Notif
 	return t.generate(sub, "", "")
 }
 
+func (t *taskTriggers) taskGithubCheckOutcome(sub *event.Subscription) (*notification.Notification, error) {
+	if !t.task.IsGithubCheck {
+		return nil, nil
+	}
+	return t.taskOutcome(sub)
+}
+
 func (t *taskTriggers) taskFailure(sub *event.Subscription) (*notification.Notification, error) {
 	if t.task.IsPartOfDisplay() {
 		return nil, nil
@@ -708,6 +736,40 @@ func (t *taskTriggers) taskRuntimeChange(sub *event.Subscription) (*notification
 	return t.generate(sub, fmt.Sprintf("changed in runtime by %.1f%% (over threshold of %s%%)", percentChange, percentString), "")
 }
 
+func (t *taskTriggers) taskPerfChangePoint(sub *event.Subscription) (*notification.Notification, error) {
+	cp, err := perf.FindChangePointByID(t.data.ChangePointID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding change point '%s'", t.data.ChangePointID)
+	}
+	if cp == nil {
+		return nil, nil
+	}
+
+	if percentString, ok := sub.TriggerData[event.PerfPercentChangeKey]; ok {
+		percent, err := strconv.ParseFloat(percentString, 64)
+		if err != nil {
+			return nil, errors.Errorf("subscription '%s' has an invalid percentage", sub.ID)
+		}
+		if math.Abs(cp.PercentChange) < percent {
+			return nil, nil
+		}
+	}
+	match, err := testMatchesRegex(cp.Test, sub)
+	if err != nil {
+		return nil, errors.Wrapf(err, "matching test '%s' against subscription '%s'", cp.Test, sub.ID)
+	}
+	if !match {
+		return nil, nil
+	}
+
+	pastTense := fmt.Sprintf("changed in performance by %+.1f%% in '%s' (mean %g to %g)", cp.PercentChange, cp.Measurement, cp.MeanBefore, cp.MeanAfter)
+	testName := cp.Test
+	if cp.Args != "" {
+		testName += fmt.Sprintf(" [%s]", cp.Args)
+	}
+	return t.generate(sub, pastTense, testName)
+}
+
 // isValidFailedTaskStatus only matches task statuses that should be triggered for failure.
 // For example, it excludes  setup failures.
 func isValidFailedTaskStatus(status string) bool {
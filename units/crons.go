@@ -1090,6 +1090,26 @@ func PopulateSpawnhostExpirationCheckJob() amboy.QueueOperation {
 	}
 }
 
+// PopulateSpawnHostAutoStopJobs enqueues jobs to stop spawn hosts that are idle
+// or whose owners' sleep schedules have begun, and to start them again when
+// the sleep schedules end.
+func PopulateSpawnHostAutoStopJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		hosts, err := host.FindSpawnHostsToAutoStop()
+		if err != nil {
+			return errors.Wrap(err, "finding spawn hosts to auto-stop")
+		}
+
+		catcher := grip.NewBasicCatcher()
+		ts := utility.RoundPartOfHour(5).Format(TSFormat)
+		for i := range hosts {
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewSpawnHostAutoStopJob(env, &hosts[i], ts)), "enqueueing spawn host auto-stop job for host '%s'", hosts[i].Id)
+		}
+
+		return errors.Wrap(catcher.Resolve(), "populating spawn host auto-stop jobs")
+	}
+}
+
 // PopulateCloudCleanupJob returns a QueueOperation to enqueue a CloudCleanup job for Fleet in the default EC2 region.
 func PopulateCloudCleanupJob(env evergreen.Environment) amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
@@ -1103,6 +1123,30 @@ func PopulateCloudCleanupJob(env evergreen.Environment) amboy.QueueOperation {
 
 }
 
+// PopulateEventRetentionJob returns a QueueOperation to enqueue a job that
+// archives and removes events that have outlived their retention policy.
+func PopulateEventRetentionJob(env evergreen.Environment) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		if len(env.Settings().EventRetention.Policies) == 0 {
+			return nil
+		}
+		ts := utility.RoundPartOfHour(0).Format(TSFormat)
+		return errors.Wrap(amboy.EnqueueUniqueJob(ctx, queue, NewEventRetentionJob(env, ts)), "enqueueing event retention job")
+	}
+}
+
+// PopulateTestResultsBackfillJob returns a QueueOperation to enqueue a job
+// that copies test results from Cedar into the local test results service.
+func PopulateTestResultsBackfillJob(env evergreen.Environment) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		if !env.Settings().TestResults.BackfillFromCedar {
+			return nil
+		}
+		ts := utility.RoundPartOfHour(5).Format(TSFormat)
+		return errors.Wrap(amboy.EnqueueUniqueJob(ctx, queue, NewTestResultsBackfillJob(env, ts)), "enqueueing test results backfill job")
+	}
+}
+
 func PopulateVolumeExpirationCheckJob() amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
 		volumes, err := host.FindVolumesWithNoExpirationToExtend()
@@ -1137,6 +1181,25 @@ func PopulateVolumeExpirationJob() amboy.QueueOperation {
 	}
 }
 
+// PopulateVolumeSnapshotExpirationJob returns a QueueOperation to enqueue
+// jobs that delete expired volume snapshots.
+func PopulateVolumeSnapshotExpirationJob() amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		snapshots, err := host.FindVolumeSnapshotsToDelete(time.Now())
+		if err != nil {
+			return errors.Wrap(err, "finding volume snapshots to delete")
+		}
+
+		catcher := grip.NewBasicCatcher()
+		ts := utility.RoundPartOfHour(0).Format(TSFormat)
+		for i := range snapshots {
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewVolumeSnapshotDeletionJob(ts, &snapshots[i])), "enqueueing volume snapshot deletion job for snapshot '%s'", snapshots[i].ID)
+		}
+
+		return errors.Wrap(catcher.Resolve(), "populating expire volume snapshot jobs")
+	}
+}
+
 func PopulateLocalQueueJobs(env evergreen.Environment) amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
 		catcher := grip.NewBasicCatcher()
@@ -53,9 +53,11 @@ func (j *cronsRemoteHourJob) Run(ctx context.Context) {
 		PopulateCloudCleanupJob(j.env),
 		PopulateVolumeExpirationCheckJob(),
 		PopulateVolumeExpirationJob(),
+		PopulateVolumeSnapshotExpirationJob(),
 		PopulateSSHKeyUpdates(j.env),
 		PopulateDuplicateTaskCheckJobs(),
 		PopulatePodResourceCleanupJobs(),
+		PopulateEventRetentionJob(j.env),
 	}
 
 	queue := j.env.RemoteQueue()
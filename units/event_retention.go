@@ -0,0 +1,97 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventRetentionJobName = "event-retention"
+
+	// eventArchiveBatchSize is the approximate number of events written to
+	// each archive file.
+	eventArchiveBatchSize = 5000
+)
+
+func init() {
+	registry.AddJobType(eventRetentionJobName, func() amboy.Job { return makeEventRetentionJob() })
+}
+
+type eventRetentionJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+func makeEventRetentionJob() *eventRetentionJob {
+	j := &eventRetentionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    eventRetentionJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewEventRetentionJob returns a job that archives events that have outlived
+// their resource type's retention policy to the event archive bucket and
+// removes them from the database.
+func NewEventRetentionJob(env evergreen.Environment, ts string) amboy.Job {
+	j := makeEventRetentionJob()
+	j.SetID(fmt.Sprintf("%s.%s", eventRetentionJobName, ts))
+	j.SetScopes([]string{eventRetentionJobName})
+	j.SetEnqueueAllScopes(true)
+	j.env = env
+	return j
+}
+
+func (j *eventRetentionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	conf := j.env.Settings().EventRetention
+	if len(conf.Policies) == 0 {
+		return
+	}
+
+	bucket, err := event.NewArchiveBucket(ctx, conf)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "getting event archive bucket"))
+		return
+	}
+
+	for _, policy := range conf.Policies {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			return
+		}
+
+		before := time.Now().AddDate(0, 0, -policy.RetentionDays)
+		archived, err := event.ArchiveExpiredEvents(ctx, bucket, policy.ResourceType, before, eventArchiveBatchSize)
+		if err != nil {
+			j.AddError(errors.Wrapf(err, "archiving expired events for resource type '%s'", policy.ResourceType))
+		}
+		grip.InfoWhen(archived > 0, message.Fields{
+			"message":        "archived expired events",
+			"resource_type":  policy.ResourceType,
+			"retention_days": policy.RetentionDays,
+			"num_archived":   archived,
+			"job":            j.ID(),
+		})
+	}
+}
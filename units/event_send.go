@@ -215,7 +215,7 @@ func (j *eventSendJob) send(n *notification.Notification) error {
 
 func (j *eventSendJob) checkDegradedMode(n *notification.Notification) error {
 	switch n.Subscriber.Type {
-	case event.GithubPullRequestSubscriberType, event.GithubCheckSubscriberType:
+	case event.GithubPullRequestSubscriberType, event.GithubCheckSubscriberType, event.GithubCheckRunSubscriberType, event.GithubPRCommentSubscriberType:
 		return checkFlag(j.flags.GithubStatusAPIDisabled)
 
 	case event.SlackSubscriberType:
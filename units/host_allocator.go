@@ -97,8 +97,9 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 		j.AddError(errors.Errorf("distro '%s' not found", j.DistroID))
 		return
 	}
-	if _, err = distro.GetResolvedHostAllocatorSettings(config); err != nil {
-		j.AddError(errors.Errorf("resolving distro '%s' host allocator settings", j.DistroID))
+	hostAllocator, err := scheduler.GetDistroHostAllocator(*distro, config)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "getting host allocator for distro '%s'", j.DistroID))
 		return
 	}
 
@@ -148,14 +149,13 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 
 	hostAllocationBegins := time.Now()
 
-	hostAllocator := scheduler.GetHostAllocator(config.Scheduler.HostAllocator)
-
 	hostAllocatorData := scheduler.HostAllocatorData{
 		Distro:          *distro,
 		ExistingHosts:   upHosts,
 		UsesContainers:  (containerPool != nil),
 		ContainerPool:   containerPool,
 		DistroQueueInfo: distroQueueInfo,
+		Now:             hostAllocationBegins,
 	}
 
 	// nHosts is the number of additional hosts desired.
@@ -250,7 +250,7 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 	if terminationOn && terminatableDistro && hostQueueRatio < lowRatioThresh && len(upHosts) > 0 {
 		distroIsByHour := cloud.UsesHourlyBilling(&upHosts[0].Distro)
 		if !distroIsByHour {
-			j.setTargetAndTerminate(ctx, len(upHosts), hostQueueRatio, distro)
+			j.setTargetAndTerminate(ctx, upHosts, hostQueueRatio, distro)
 		}
 	}
 
@@ -279,7 +279,8 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 	})
 }
 
-func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, numUpHosts int, hostQueueRatio float32, distro *distro.Distro) {
+func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, upHosts []host.Host, hostQueueRatio float32, distro *distro.Distro) {
+	numUpHosts := len(upHosts)
 	var killableHosts, newCapTarget int
 	if hostQueueRatio == 0 {
 		killableHosts = numUpHosts
@@ -291,6 +292,19 @@ func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, numUpHosts
 	if newCapTarget < distro.HostAllocatorSettings.MinimumHosts {
 		newCapTarget = distro.HostAllocatorSettings.MinimumHosts
 	}
+	// Keep enough hosts for the distro's warm pool on top of the hosts that
+	// are running tasks.
+	if numWarmPoolHosts := distro.HostAllocatorSettings.WarmPool.TargetReadyHosts(time.Now()); numWarmPoolHosts > 0 {
+		numBusyHosts := 0
+		for _, h := range upHosts {
+			if h.RunningTask != "" {
+				numBusyHosts++
+			}
+		}
+		if newCapTarget < numBusyHosts+numWarmPoolHosts {
+			newCapTarget = numBusyHosts + numWarmPoolHosts
+		}
+	}
 	// rough value to prevent killing hosts on low-volume distros
 	const lowCountFloor = 0
 	if killableHosts > lowCountFloor {
@@ -122,6 +122,15 @@ func (j *idleHostJob) Run(ctx context.Context) {
 		minNumHostsToEvaluate := getMinNumHostsToEvaluate(info, minimumHostsForDistro)
 
 		currentDistro := distrosMap[info.DistroID]
+		// Idle hosts are sorted from oldest to newest, so the newest idle
+		// hosts are kept as the distro's warm pool.
+		numWarmPoolHosts := currentDistro.HostAllocatorSettings.WarmPool.TargetReadyHosts(time.Now())
+		if maxNumHostsToEvaluate := len(info.IdleHosts) - numWarmPoolHosts; minNumHostsToEvaluate > maxNumHostsToEvaluate {
+			minNumHostsToEvaluate = maxNumHostsToEvaluate
+		}
+		if minNumHostsToEvaluate < 0 {
+			minNumHostsToEvaluate = 0
+		}
 		hostsToEvaluateForTermination := make([]host.Host, 0, minNumHostsToEvaluate)
 		for i := 0; i < len(info.IdleHosts); i++ {
 			if len(hostsToEvaluateForTermination) >= minNumHostsToEvaluate {
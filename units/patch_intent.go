@@ -289,7 +289,7 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 			}
 		}
 	}
-	if err = j.verifyValidAlias(pref.Id, patchDoc.PatchedProjectConfig); err != nil {
+	if err = j.verifyValidAlias(pref.Id, patchedProject, patchDoc.PatchedProjectConfig); err != nil {
 		j.gitHubError = invalidAlias
 		return err
 	}
@@ -363,6 +363,12 @@ func (j *patchIntentProcessor) finishPatch(ctx context.Context, patchDoc *patch.
 
 	if patchDoc.IsGithubPRPatch() {
 		catcher.Wrap(j.createGitHubSubscriptions(patchDoc), "creating GitHub PR patch subscriptions")
+		if pref.IsGithubCheckRunsEnabled() {
+			catcher.Wrap(j.createGitHubCheckRunSubscription(patchDoc, pref.IsGithubCheckRunsPerTask()), "creating GitHub PR check run subscription")
+		}
+		if pref.IsPRSummaryCommentEnabled() {
+			catcher.Wrap(j.createGitHubPRSummaryCommentSubscription(patchDoc), "creating GitHub PR summary comment subscription")
+		}
 	}
 	if patchDoc.IsBackport() {
 		backportSubscription := event.NewExpiringPatchSuccessSubscription(j.PatchID.Hex(), event.NewEnqueuePatchSubscriber())
@@ -466,6 +472,37 @@ func (j *patchIntentProcessor) createGitHubSubscriptions(p *patch.Patch) error {
 	return catcher.Resolve()
 }
 
+// createGitHubCheckRunSubscription creates a subscription to post the results
+// of a GitHub PR patch to the PR as a check run for each build variant or, if
+// perTask is set, for each task.
+func (j *patchIntentProcessor) createGitHubCheckRunSubscription(p *patch.Patch, perTask bool) error {
+	ghSub := event.NewGithubCheckRunSubscriber(event.GithubCheckSubscriber{
+		Owner: p.GithubPatchData.BaseOwner,
+		Repo:  p.GithubPatchData.BaseRepo,
+		Ref:   p.GithubPatchData.HeadHash,
+	})
+	resourceType := event.ResourceTypeBuild
+	if perTask {
+		resourceType = event.ResourceTypeTask
+	}
+	sub := event.NewGithubCheckRunSubscriptionByVersion(j.PatchID.Hex(), resourceType, event.TriggerOutcome, ghSub)
+	return errors.Wrap(sub.Upsert(), "inserting GitHub check run subscription")
+}
+
+// createGitHubPRSummaryCommentSubscription creates a subscription to post a
+// summary of a GitHub PR patch's results compared to its base commit as a
+// comment on the PR when the patch finishes.
+func (j *patchIntentProcessor) createGitHubPRSummaryCommentSubscription(p *patch.Patch) error {
+	commentSub := event.NewGithubPRCommentSubscriber(event.GithubPullRequestSubscriber{
+		Owner:    p.GithubPatchData.BaseOwner,
+		Repo:     p.GithubPatchData.BaseRepo,
+		PRNumber: p.GithubPatchData.PRNumber,
+		Ref:      p.GithubPatchData.HeadHash,
+	})
+	sub := event.NewExpiringPatchOutcomeSubscription(j.PatchID.Hex(), commentSub)
+	return errors.Wrap(sub.Upsert(), "inserting GitHub PR summary comment subscription")
+}
+
 func (j *patchIntentProcessor) buildTasksAndVariants(patchDoc *patch.Patch, project *model.Project) error {
 	var previousPatchStatus string
 	var err error
@@ -705,6 +742,14 @@ func (j *patchIntentProcessor) buildCliPatchDoc(ctx context.Context, patchDoc *p
 		return j.buildBackportPatchDoc(ctx, projectRef, patchDoc)
 	}
 
+	var basePatch *patch.Patch
+	if patchDoc.IsStacked() {
+		if basePatch, err = findStackBasePatch(patchDoc); err != nil {
+			return err
+		}
+		patchDoc.Githash = basePatch.Githash
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -725,10 +770,32 @@ func (j *patchIntentProcessor) buildCliPatchDoc(ctx context.Context, patchDoc *p
 			return errors.Wrap(err, "getting module patch from GridFS")
 		}
 	}
+	if basePatch != nil {
+		patchDoc.StackOn(basePatch)
+	}
 
 	return nil
 }
 
+// findStackBasePatch finds the patch that the given patch is stacked on and
+// checks that the patch can be stacked on it.
+func findStackBasePatch(patchDoc *patch.Patch) (*patch.Patch, error) {
+	basePatch, err := patch.FindOneId(patchDoc.BasePatchID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding base patch '%s'", patchDoc.BasePatchID)
+	}
+	if basePatch == nil {
+		return nil, errors.Errorf("base patch '%s' not found", patchDoc.BasePatchID)
+	}
+	if basePatch.Project != patchDoc.Project {
+		return nil, errors.Errorf("base patch '%s' is for project '%s', not '%s'", patchDoc.BasePatchID, basePatch.Project, patchDoc.Project)
+	}
+	if basePatch.IsCommitQueuePatch() {
+		return nil, errors.Errorf("can't stack a patch on commit queue patch '%s'", patchDoc.BasePatchID)
+	}
+	return basePatch, nil
+}
+
 // getModulePatch reads the patch from GridFS, processes it, and
 // stores the resulting summaries in the returned ModulePatch
 func getModulePatch(modulePatch patch.ModulePatch) (patch.ModulePatch, error) {
@@ -945,11 +1012,17 @@ func (j *patchIntentProcessor) buildTriggerPatchDoc(patchDoc *patch.Patch) (*mod
 	return project, pp, nil
 }
 
-func (j *patchIntentProcessor) verifyValidAlias(projectId string, configStr string) error {
+func (j *patchIntentProcessor) verifyValidAlias(projectId string, project *model.Project, configStr string) error {
 	alias := j.intent.GetAlias()
 	if alias == "" {
 		return nil
 	}
+	if alias == evergreen.ImpactedAlias {
+		if len(project.ImpactedTasks) == 0 {
+			return errors.Errorf("project '%s' does not define any impacted tasks rules", projectId)
+		}
+		return nil
+	}
 	var projectConfig *model.ProjectConfig
 	if configStr != "" {
 		var err error
@@ -1147,7 +1147,7 @@ func (s *PatchIntentUnitsSuite) TestRunInDegradedModeWithGithubIntent() {
 	}
 	s.NoError(evergreen.SetServiceFlags(flags))
 
-	intent, err := patch.NewGithubIntent("1", "", "", testutil.NewGithubPR(s.prNumber, s.repo, s.baseHash, s.headRepo, s.hash, "tychoish", "title1"))
+	intent, err := patch.NewGithubIntent("1", "", "", "", testutil.NewGithubPR(s.prNumber, s.repo, s.baseHash, s.headRepo, s.hash, "tychoish", "title1"))
 	s.NoError(err)
 	s.NotNil(intent)
 	s.NoError(intent.Insert())
@@ -1178,7 +1178,7 @@ func (s *PatchIntentUnitsSuite) TestGithubPRTestFromUnknownUserDoesntCreateVersi
 	}
 	s.Require().NoError(evergreen.SetServiceFlags(flags))
 
-	intent, err := patch.NewGithubIntent("1", "", "", testutil.NewGithubPR(s.prNumber, "evergreen-ci/evergreen", s.baseHash, s.headRepo, "8a425038834326c212d65289e0c9e80e48d07e7e", "octocat", "title1"))
+	intent, err := patch.NewGithubIntent("1", "", "", "", testutil.NewGithubPR(s.prNumber, "evergreen-ci/evergreen", s.baseHash, s.headRepo, "8a425038834326c212d65289e0c9e80e48d07e7e", "octocat", "title1"))
 	s.NoError(err)
 	s.NotNil(intent)
 	s.NoError(intent.Insert())
@@ -1275,6 +1275,40 @@ func (s *PatchIntentUnitsSuite) TestCliBackport() {
 	s.Equal(sourcePatch.Patches[0].PatchSet.Patch, backportPatch.Patches[0].PatchSet.Patch)
 }
 
+func (s *PatchIntentUnitsSuite) TestFindStackBasePatch() {
+	basePatch := &patch.Patch{
+		Id:      mgobson.NewObjectId(),
+		Project: s.project,
+		Githash: s.hash,
+	}
+	s.Require().NoError(basePatch.Insert())
+	commitQueuePatch := &patch.Patch{
+		Id:      mgobson.NewObjectId(),
+		Project: s.project,
+		Githash: s.hash,
+		Alias:   evergreen.CommitQueueAlias,
+	}
+	s.Require().NoError(commitQueuePatch.Insert())
+
+	patchDoc := &patch.Patch{Project: s.project, BasePatchID: basePatch.Id.Hex()}
+	found, err := findStackBasePatch(patchDoc)
+	s.Require().NoError(err)
+	s.Require().NotNil(found)
+	s.Equal(basePatch.Id, found.Id)
+
+	patchDoc.Project = "other_project"
+	_, err = findStackBasePatch(patchDoc)
+	s.Error(err)
+
+	patchDoc = &patch.Patch{Project: s.project, BasePatchID: commitQueuePatch.Id.Hex()}
+	_, err = findStackBasePatch(patchDoc)
+	s.Error(err)
+
+	patchDoc.BasePatchID = mgobson.NewObjectId().Hex()
+	_, err = findStackBasePatch(patchDoc)
+	s.Error(err)
+}
+
 func (s *PatchIntentUnitsSuite) TestProcessTriggerAliases() {
 	latestVersion := model.Version{
 		Id:         "childProj-some-version",
@@ -0,0 +1,141 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/perf"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	perfChangePointDetectionJobName = "perf-change-point-detection"
+
+	// perfChangePointMinDistance is the number of points within which a
+	// detected change point is considered the same as an existing one.
+	perfChangePointMinDistance = 3
+)
+
+func init() {
+	registry.AddJobType(perfChangePointDetectionJobName, func() amboy.Job { return makePerfChangePointDetectionJob() })
+}
+
+type perfChangePointDetectionJob struct {
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+	TaskID    string `bson:"task_id" json:"task_id" yaml:"task_id"`
+	Execution int    `bson:"execution" json:"execution" yaml:"execution"`
+}
+
+func makePerfChangePointDetectionJob() *perfChangePointDetectionJob {
+	j := &perfChangePointDetectionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    perfChangePointDetectionJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewPerfChangePointDetectionJob detects change points in the mainline
+// performance series that the task execution reported results for.
+func NewPerfChangePointDetectionJob(taskID string, execution int) amboy.Job {
+	j := makePerfChangePointDetectionJob()
+	j.TaskID = taskID
+	j.Execution = execution
+	j.SetID(fmt.Sprintf("%s.%s.%d", perfChangePointDetectionJobName, taskID, execution))
+	return j
+}
+
+func (j *perfChangePointDetectionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	results, err := perf.FindResultsByTask(j.TaskID, j.Execution)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+
+	seen := map[perf.SeriesKey]bool{}
+	for _, r := range results {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			return
+		}
+		if !r.Mainline || seen[r.SeriesKey] {
+			continue
+		}
+		seen[r.SeriesKey] = true
+		j.AddError(errors.Wrapf(j.detectChangePoints(r.SeriesKey), "detecting change points in series '%s'", r.SeriesKey.String()))
+	}
+}
+
+// detectChangePoints stores the change points detected in the most recent
+// points of the series and logs an event for each new one. Detection is rerun
+// every time a point is added, so a change point that was already detected may
+// move slightly as more points become available. Change points close to one
+// that was already detected are considered the same change.
+func (j *perfChangePointDetectionJob) detectChangePoints(key perf.SeriesKey) error {
+	series, err := perf.FindMainlineSeries(key, perf.DefaultSeriesWindow)
+	if err != nil {
+		return err
+	}
+	existing, err := perf.FindChangePointsBySeries(key)
+	if err != nil {
+		return err
+	}
+
+	positions := map[int]int{}
+	for i, r := range series {
+		positions[r.Order] = i
+	}
+	var existingPositions []int
+	for _, cp := range existing {
+		if pos, ok := positions[cp.Order]; ok {
+			existingPositions = append(existingPositions, pos)
+		}
+	}
+
+	for _, cp := range perf.DetectChangePoints(series, perf.EDivisiveOptions{}) {
+		if isNearChangePoint(positions[cp.Order], existingPositions, perfChangePointMinDistance) {
+			continue
+		}
+		isNew, err := cp.Insert()
+		if err != nil {
+			return err
+		}
+		if !isNew {
+			continue
+		}
+		existingPositions = append(existingPositions, positions[cp.Order])
+
+		grip.Info(message.Fields{
+			"message":        "detected performance change point",
+			"series":         key.String(),
+			"version":        cp.Version,
+			"task_id":        cp.TaskID,
+			"percent_change": cp.PercentChange,
+			"job":            j.ID(),
+			"job_type":       j.Type().Name,
+		})
+		event.LogTaskPerfChangePoint(cp.TaskID, cp.Execution, cp.ID)
+	}
+
+	return nil
+}
+
+func isNearChangePoint(pos int, existing []int, distance int) bool {
+	for _, other := range existing {
+		if pos-other < distance && other-pos < distance {
+			return true
+		}
+	}
+	return false
+}
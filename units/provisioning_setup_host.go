@@ -518,6 +518,9 @@ func (j *setupHostJob) provisionHost(ctx context.Context, settings *evergreen.Se
 			// data setup script must wait for all task data to be loaded.
 			j.AddError(amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewHostSetupScriptJob(j.env, j.host)))
 		}
+		if j.host.ProvisionOptions != nil && j.host.ProvisionOptions.ReproduceTask {
+			j.AddError(amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewSpawnHostTaskEnvironmentJob(j.env, j.host)))
+		}
 	}
 
 	grip.Info(message.Fields{
@@ -154,6 +154,10 @@ func (j *userDataDoneJob) Run(ctx context.Context) {
 		// this job to wait for task data to be loaded.
 		j.AddError(j.env.RemoteQueue().Put(ctx, NewHostSetupScriptJob(j.env, j.host)))
 	}
+	if j.host.ProvisionOptions != nil && j.host.ProvisionOptions.ReproduceTask {
+		// Load the task's environment in a separate job for the same reason.
+		j.AddError(j.env.RemoteQueue().Put(ctx, NewSpawnHostTaskEnvironmentJob(j.env, j.host)))
+	}
 
 	j.finishJob()
 }
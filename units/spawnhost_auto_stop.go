@@ -0,0 +1,258 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	spawnHostAutoStopJobName = "spawnhost-auto-stop"
+
+	// spawnHostIdleStopWarningLeadTime is how long before an idle spawn host
+	// is stopped that its owner is warned.
+	spawnHostIdleStopWarningLeadTime = 30 * time.Minute
+)
+
+func init() {
+	registry.AddJobType(spawnHostAutoStopJobName, func() amboy.Job {
+		return makeSpawnHostAutoStopJob()
+	})
+}
+
+type spawnHostAutoStopJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+
+	host   *host.Host
+	distro *distro.Distro
+	policy user.SpawnHostAutoStopPolicy
+	userTZ string
+	env    evergreen.Environment
+}
+
+func makeSpawnHostAutoStopJob() *spawnHostAutoStopJob {
+	j := &spawnHostAutoStopJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    spawnHostAutoStopJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewSpawnHostAutoStopJob returns a job to stop a spawn host that has been
+// idle for too long or whose owner's sleep schedule has begun, and to start
+// it again once the sleep schedule ends.
+func NewSpawnHostAutoStopJob(env evergreen.Environment, h *host.Host, ts string) amboy.Job {
+	j := makeSpawnHostAutoStopJob()
+	j.SetID(fmt.Sprintf("%s.%s.%s", spawnHostAutoStopJobName, h.Id, ts))
+	j.SetScopes([]string{fmt.Sprintf("%s.%s", spawnHostAutoStopJobName, h.Id)})
+	j.SetEnqueueAllScopes(true)
+	j.HostID = h.Id
+	j.env = env
+	return j
+}
+
+func (j *spawnHostAutoStopJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if err := j.populate(); err != nil {
+		j.AddError(err)
+		return
+	}
+	if !j.host.UserHost {
+		return
+	}
+
+	now := time.Now()
+	changedStatus, err := j.checkSleepSchedule(ctx, now)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "checking sleep schedule for host '%s'", j.host.Id))
+		return
+	}
+	if changedStatus {
+		return
+	}
+	j.AddError(errors.Wrapf(j.checkIdle(ctx, now), "checking whether host '%s' is idle", j.host.Id))
+}
+
+func (j *spawnHostAutoStopJob) populate() error {
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	if j.host == nil {
+		h, err := host.FindOneId(j.HostID)
+		if err != nil {
+			return errors.Wrapf(err, "finding host '%s'", j.HostID)
+		}
+		if h == nil {
+			return errors.Errorf("host '%s' not found", j.HostID)
+		}
+		j.host = h
+	}
+
+	if j.distro == nil {
+		// The distro may have changed since the host was created, so use its
+		// current auto-stop settings if it still exists.
+		d, err := distro.FindOneId(j.host.Distro.Id)
+		if err != nil {
+			return errors.Wrapf(err, "finding distro '%s'", j.host.Distro.Id)
+		}
+		if d == nil {
+			d = &j.host.Distro
+		}
+		j.distro = d
+	}
+
+	owner, err := user.FindOneById(j.host.StartedBy)
+	if err != nil {
+		return errors.Wrapf(err, "finding owner '%s' of host '%s'", j.host.StartedBy, j.host.Id)
+	}
+	if owner != nil {
+		j.policy = owner.Settings.SpawnHostAutoStop
+		j.userTZ = owner.Settings.Timezone
+	}
+
+	return nil
+}
+
+// checkSleepSchedule stops the host when the owner's sleep schedule begins and
+// starts it again when the schedule ends. It returns whether it changed the
+// host's status.
+func (j *spawnHostAutoStopJob) checkSleepSchedule(ctx context.Context, now time.Time) (bool, error) {
+	schedule := j.policy.SleepSchedule
+	if schedule.IsZero() {
+		if j.host.AutoStop.StoppedBySleepSchedule {
+			return false, j.host.UnsetStoppedBySleepSchedule()
+		}
+		return false, nil
+	}
+
+	loc := schedule.Location(j.userTZ)
+	if schedule.IsAsleep(now, loc) {
+		// If the host has already been stopped during this period of sleep,
+		// the owner must have started it again, so leave it running.
+		if j.host.Status != evergreen.HostRunning || !j.host.AutoStop.SleepScheduleStopTime.Before(schedule.SleepStart(now, loc)) {
+			return false, nil
+		}
+		if err := amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewSpawnhostStopJob(j.host, evergreen.User, now.Format(TSFormat))); err != nil {
+			return false, errors.Wrap(err, "enqueueing job to stop host")
+		}
+		grip.Info(message.Fields{
+			"message": "stopping spawn host for owner's sleep schedule",
+			"host_id": j.host.Id,
+			"owner":   j.host.StartedBy,
+			"job":     j.ID(),
+		})
+		return true, j.host.SetStoppedBySleepSchedule(now)
+	}
+
+	if !j.host.AutoStop.StoppedBySleepSchedule {
+		return false, nil
+	}
+	var started bool
+	if j.host.Status == evergreen.HostStopped {
+		if err := amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewSpawnhostStartJob(j.host, evergreen.User, now.Format(TSFormat))); err != nil {
+			return false, errors.Wrap(err, "enqueueing job to start host")
+		}
+		grip.Info(message.Fields{
+			"message": "starting spawn host after owner's sleep schedule",
+			"host_id": j.host.Id,
+			"owner":   j.host.StartedBy,
+			"job":     j.ID(),
+		})
+		started = true
+	}
+	return started, j.host.UnsetStoppedBySleepSchedule()
+}
+
+// checkIdle warns the owner when the host will soon be stopped for being idle
+// and stops it once the idle timeout has passed.
+func (j *spawnHostAutoStopJob) checkIdle(ctx context.Context, now time.Time) error {
+	timeout := host.SpawnHostIdleTimeout(j.distro.SpawnHostAutoStop, j.policy)
+	if j.host.Status != evergreen.HostRunning || timeout == 0 {
+		return j.clearIdleStopTime()
+	}
+
+	if j.host.SpawnHostActivity == nil || j.host.SpawnHostActivity.IsStale(now) {
+		catcher := grip.NewBasicCatcher()
+		catcher.Add(j.clearIdleStopTime())
+		catcher.Wrap(j.startActivityMonitor(ctx), "starting activity monitor")
+		return catcher.Resolve()
+	}
+
+	if !utility.IsZeroTime(j.host.AutoStop.IdleStopTime) {
+		if now.Before(j.host.AutoStop.IdleStopTime) {
+			return nil
+		}
+		if err := amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewSpawnhostStopJob(j.host, evergreen.User, now.Format(TSFormat))); err != nil {
+			return errors.Wrap(err, "enqueueing job to stop host")
+		}
+		grip.Info(message.Fields{
+			"message":      "stopping idle spawn host",
+			"host_id":      j.host.Id,
+			"owner":        j.host.StartedBy,
+			"idle_timeout": timeout.String(),
+			"job":          j.ID(),
+		})
+		return j.clearIdleStopTime()
+	}
+
+	if j.host.SpawnHostIdleTime(now) < timeout-spawnHostIdleStopWarningLeadTime {
+		return nil
+	}
+
+	// Give the owner time to respond to the warning even if the host has
+	// already been idle for longer than the timeout.
+	stopTime := j.host.SpawnHostActivity.LastActiveTime.Add(timeout)
+	if minStopTime := now.Add(spawnHostIdleStopWarningLeadTime); stopTime.Before(minStopTime) {
+		stopTime = minStopTime
+	}
+	if err := j.host.SetIdleStopTime(stopTime); err != nil {
+		return err
+	}
+	event.LogSpawnHostIdleStopWarningSent(j.host.Id)
+
+	return nil
+}
+
+func (j *spawnHostAutoStopJob) clearIdleStopTime() error {
+	if utility.IsZeroTime(j.host.AutoStop.IdleStopTime) {
+		return nil
+	}
+	return j.host.SetIdleStopTime(time.Time{})
+}
+
+// startActivityMonitor starts the agent monitor on the host so that it
+// reports the host's activity. If the monitor is already running, the new
+// one exits immediately.
+func (j *spawnHostAutoStopJob) startActivityMonitor(ctx context.Context) error {
+	if j.host.Distro.LegacyBootstrap() {
+		return nil
+	}
+	if j.host.Secret == "" {
+		if err := j.host.CreateSecret(); err != nil {
+			return errors.Wrapf(err, "creating secret for host '%s'", j.host.Id)
+		}
+	}
+
+	_, err := j.host.StartJasperProcess(ctx, j.env, j.host.SpawnHostActivityMonitorOptions(j.env.Settings()))
+	return err
+}
@@ -0,0 +1,230 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnHostAutoStopJob(t *testing.T) {
+	defer func() {
+		assert.NoError(t, db.ClearCollections(host.Collection, distro.Collection, user.Collection, event.EventCollection))
+	}()
+
+	freshActivity := func(idle time.Duration) *host.SpawnHostActivity {
+		now := time.Now()
+		return &host.SpawnHostActivity{
+			ReportTime:     now,
+			LastActiveTime: now.Add(-idle),
+		}
+	}
+	today := time.Now().UTC().Weekday()
+	tomorrow := (today + 1) % 7
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser){
+		"WarnsOwnerBeforeIdleTimeout": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			h.SpawnHostActivity = freshActivity(45 * time.Minute)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			expectedStopTime := h.SpawnHostActivity.LastActiveTime.Add(time.Hour)
+			assert.WithinDuration(t, expectedStopTime, dbHost.AutoStop.IdleStopTime, time.Second)
+
+			events, err := event.FindAllByResourceID(h.Id)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, event.EventHostIdleStopWarningSent, events[0].EventType)
+			assert.Zero(t, env.RemoteQueue().Stats(ctx).Total)
+		},
+		"GivesOwnerTimeToRespondToWarning": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			h.SpawnHostActivity = freshActivity(2 * time.Hour)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(spawnHostIdleStopWarningLeadTime), dbHost.AutoStop.IdleStopTime, time.Minute)
+			assert.Zero(t, env.RemoteQueue().Stats(ctx).Total)
+		},
+		"DoesNotWarnRecentlyActiveHost": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			h.SpawnHostActivity = freshActivity(10 * time.Minute)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.Zero(t, dbHost.AutoStop.IdleStopTime)
+		},
+		"StopsHostAfterIdleStopTime": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			h.SpawnHostActivity = freshActivity(2 * time.Hour)
+			h.AutoStop.IdleStopTime = time.Now().Add(-time.Minute)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Equal(t, 1, env.RemoteQueue().Stats(ctx).Total)
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.Zero(t, dbHost.AutoStop.IdleStopTime)
+		},
+		"UsesShorterOwnerIdleTimeout": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			require.NoError(t, db.UpdateId(user.Collection, u.Id, map[string]interface{}{
+				"$set": map[string]interface{}{"settings.spawn_host_auto_stop.idle_timeout": time.Hour},
+			}))
+			require.NoError(t, db.UpdateId(distro.Collection, h.Distro.Id, map[string]interface{}{
+				"$set": map[string]interface{}{"spawn_host_auto_stop.idle_timeout": 10 * time.Hour},
+			}))
+			h.SpawnHostActivity = freshActivity(45 * time.Minute)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.NotZero(t, dbHost.AutoStop.IdleStopTime)
+		},
+		"ClearsIdleStopTimeForStoppedHost": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			h.Status = evergreen.HostStopped
+			h.AutoStop.IdleStopTime = time.Now().Add(time.Minute)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.Zero(t, dbHost.AutoStop.IdleStopTime)
+			assert.Zero(t, env.RemoteQueue().Stats(ctx).Total)
+		},
+		"StopsHostWhenSleepScheduleBegins": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			require.NoError(t, db.UpdateId(user.Collection, u.Id, map[string]interface{}{
+				"$set": map[string]interface{}{"settings.spawn_host_auto_stop.sleep_schedule": user.SleepSchedule{
+					WholeWeekdaysOff: []time.Weekday{today},
+					TimeZone:         "UTC",
+				}},
+			}))
+			h.SpawnHostActivity = freshActivity(0)
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Equal(t, 1, env.RemoteQueue().Stats(ctx).Total)
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.True(t, dbHost.AutoStop.StoppedBySleepSchedule)
+			assert.NotZero(t, dbHost.AutoStop.SleepScheduleStopTime)
+		},
+		"DoesNotStopHostStartedDuringSleepSchedule": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			require.NoError(t, db.UpdateId(user.Collection, u.Id, map[string]interface{}{
+				"$set": map[string]interface{}{"settings.spawn_host_auto_stop.sleep_schedule": user.SleepSchedule{
+					WholeWeekdaysOff: []time.Weekday{today},
+					TimeZone:         "UTC",
+				}},
+			}))
+			h.SpawnHostActivity = freshActivity(0)
+			h.AutoStop.SleepScheduleStopTime = time.Now()
+			h.AutoStop.StoppedBySleepSchedule = true
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Zero(t, env.RemoteQueue().Stats(ctx).Total)
+		},
+		"StartsHostWhenSleepScheduleEnds": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			require.NoError(t, db.UpdateId(user.Collection, u.Id, map[string]interface{}{
+				"$set": map[string]interface{}{"settings.spawn_host_auto_stop.sleep_schedule": user.SleepSchedule{
+					WholeWeekdaysOff: []time.Weekday{tomorrow},
+					TimeZone:         "UTC",
+				}},
+			}))
+			h.Status = evergreen.HostStopped
+			h.AutoStop.SleepScheduleStopTime = time.Now().Add(-24 * time.Hour)
+			h.AutoStop.StoppedBySleepSchedule = true
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Equal(t, 1, env.RemoteQueue().Stats(ctx).Total)
+			dbHost, err := host.FindOneId(h.Id)
+			require.NoError(t, err)
+			assert.False(t, dbHost.AutoStop.StoppedBySleepSchedule)
+		},
+		"DoesNotStartHostStoppedByOwner": func(ctx context.Context, t *testing.T, env *mock.Environment, h *host.Host, u *user.DBUser) {
+			require.NoError(t, db.UpdateId(user.Collection, u.Id, map[string]interface{}{
+				"$set": map[string]interface{}{"settings.spawn_host_auto_stop.sleep_schedule": user.SleepSchedule{
+					WholeWeekdaysOff: []time.Weekday{tomorrow},
+					TimeZone:         "UTC",
+				}},
+			}))
+			h.Status = evergreen.HostStopped
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostAutoStopJob(env, h, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Zero(t, env.RemoteQueue().Stats(ctx).Total)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			require.NoError(t, db.ClearCollections(host.Collection, distro.Collection, user.Collection, event.EventCollection))
+
+			env := &mock.Environment{}
+			require.NoError(t, env.Configure(ctx))
+
+			d := distro.Distro{
+				Id:                "d0",
+				Provider:          evergreen.ProviderNameMock,
+				SpawnHostAutoStop: distro.SpawnHostAutoStopSettings{IdleTimeout: time.Hour},
+			}
+			require.NoError(t, d.Insert())
+			u := &user.DBUser{Id: "u0"}
+			require.NoError(t, u.Insert())
+			h := &host.Host{
+				Id:        "h0",
+				UserHost:  true,
+				StartedBy: u.Id,
+				Status:    evergreen.HostRunning,
+				Provider:  evergreen.ProviderNameMock,
+				Distro:    d,
+			}
+
+			tCase(ctx, t, env, h, u)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package units
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/jasper/options"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	spawnHostTaskEnvironmentJobName    = "spawnhost-task-environment"
+	spawnHostTaskEnvironmentRetryLimit = 5
+
+	// The steps of loading a task's environment onto a spawn host, which are
+	// logged as host events so that users can follow the progress.
+	taskEnvironmentStepFetchTaskData    = "fetch-task-data"
+	taskEnvironmentStepPullTaskSync     = "pull-task-sync"
+	taskEnvironmentStepWriteEnvironment = "write-task-environment"
+	taskEnvironmentStepRunTaskSetup     = "run-task-setup"
+
+	taskEnvironmentExpansionsFile = "expansions.yml"
+	taskEnvironmentSetupFile      = "setup.sh"
+	taskEnvironmentTaskFile       = "task.sh"
+)
+
+func init() {
+	registry.AddJobType(spawnHostTaskEnvironmentJobName, func() amboy.Job { return makeSpawnHostTaskEnvironmentJob() })
+}
+
+type spawnHostTaskEnvironmentJob struct {
+	HostID   string `bson:"host_id" json:"host_id" yaml:"host_id"`
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	host *host.Host
+	env  evergreen.Environment
+}
+
+func makeSpawnHostTaskEnvironmentJob() *spawnHostTaskEnvironmentJob {
+	j := &spawnHostTaskEnvironmentJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    spawnHostTaskEnvironmentJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewSpawnHostTaskEnvironmentJob creates a job that loads the environment of
+// the task that a spawn host reproduces onto the host once its task data has
+// been fetched. It pulls the task's sync data, writes the task's expansions
+// and scripts of its commands into the home directory, and optionally runs
+// the task's setup commands.
+func NewSpawnHostTaskEnvironmentJob(env evergreen.Environment, h *host.Host) amboy.Job {
+	j := makeSpawnHostTaskEnvironmentJob()
+	j.env = env
+	j.host = h
+	j.HostID = h.Id
+	j.SetPriority(1)
+	j.SetScopes([]string{fmt.Sprintf("%s.%s", spawnHostTaskEnvironmentJobName, h.Id)})
+	j.SetEnqueueAllScopes(true)
+	j.UpdateRetryInfo(amboy.JobRetryOptions{
+		Retryable:   utility.TruePtr(),
+		MaxAttempts: utility.ToIntPtr(spawnHostTaskEnvironmentRetryLimit),
+	})
+	j.SetID(fmt.Sprintf("%s.%s", spawnHostTaskEnvironmentJobName, j.HostID))
+	return j
+}
+
+func (j *spawnHostTaskEnvironmentJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	step := taskEnvironmentStepFetchTaskData
+	defer func() {
+		if j.HasErrors() && (j.RetryInfo().GetRemainingAttempts() == 0 || !j.RetryInfo().ShouldRetry()) {
+			grip.Error(message.WrapError(j.Error(), message.Fields{
+				"message": "failed to load task environment onto spawn host",
+				"host_id": j.HostID,
+				"step":    step,
+				"job":     j.ID(),
+			}))
+			event.LogHostTaskEnvironmentStepFailed(j.HostID, step, j.Error())
+		}
+	}()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+	if j.host == nil {
+		var err error
+		j.host, err = host.FindOneByIdOrTag(j.HostID)
+		if err != nil {
+			j.AddRetryableError(errors.Wrapf(err, "finding host '%s'", j.HostID))
+			return
+		}
+		if j.host == nil {
+			j.AddError(errors.Errorf("host '%s' not found", j.HostID))
+			return
+		}
+	}
+	if j.host.ProvisionOptions == nil || !j.host.ProvisionOptions.ReproduceTask || j.host.ProvisionOptions.TaskId == "" {
+		j.AddError(errors.Errorf("host '%s' does not reproduce a task", j.host.Id))
+		return
+	}
+
+	if !j.host.Distro.LegacyBootstrap() {
+		// Hosts provisioned with the legacy method fetch the task data
+		// before this job is created.
+		if err := j.host.CheckTaskDataFetched(ctx, j.env); err != nil {
+			j.AddRetryableError(errors.Wrap(err, "checking if task data is fetched yet"))
+			return
+		}
+	}
+	event.LogHostTaskEnvironmentStepSucceeded(j.host.Id, step, "")
+
+	// Do not retry after this point because there's no guarantee that the
+	// task's setup commands are idempotent.
+	t, err := task.FindOneId(j.host.ProvisionOptions.TaskId)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "finding task '%s'", j.host.ProvisionOptions.TaskId))
+		return
+	}
+	if t == nil {
+		j.AddError(errors.Errorf("task '%s' not found", j.host.ProvisionOptions.TaskId))
+		return
+	}
+
+	step = taskEnvironmentStepPullTaskSync
+	if t.CanSync {
+		// A task can sync its working directory without having done so yet,
+		// so the rest of its environment is still useful if this fails.
+		logs, err := j.runScript(ctx, strings.Join(j.host.SpawnHostPullTaskSyncCommand(), " "))
+		if err != nil {
+			event.LogHostTaskEnvironmentStepFailed(j.host.Id, step, errors.Wrapf(err, "pulling task sync data: %s", logs))
+		} else {
+			event.LogHostTaskEnvironmentStepSucceeded(j.host.Id, step, logs)
+		}
+	} else {
+		event.LogHostTaskEnvironmentStepSucceeded(j.host.Id, step, "task does not sync its working directory")
+	}
+
+	step = taskEnvironmentStepWriteEnvironment
+	taskEnv, err := model.MakeTaskEnvironment(t, j.host, j.host.Distro.WorkDir)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "making task environment"))
+		return
+	}
+	expansions, err := yaml.Marshal(taskEnv.Expansions)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "marshalling task expansions"))
+		return
+	}
+	dir := j.host.SpawnHostTaskEnvironmentDir()
+	logs, err := j.runScript(ctx, writeTaskEnvironmentFilesScript(dir, map[string][]byte{
+		taskEnvironmentExpansionsFile: expansions,
+		taskEnvironmentSetupFile:      []byte(taskEnv.SetupScript),
+		taskEnvironmentTaskFile:       []byte(taskEnv.TaskScript),
+	}))
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "writing task environment: %s", logs))
+		return
+	}
+	event.LogHostTaskEnvironmentStepSucceeded(j.host.Id, step, fmt.Sprintf("wrote task environment to directory '%s'", dir))
+
+	if !j.host.ProvisionOptions.RunTaskSetup {
+		return
+	}
+	step = taskEnvironmentStepRunTaskSetup
+	logs, err = j.runScript(ctx, fmt.Sprintf("bash %s", path.Join(dir, taskEnvironmentSetupFile)))
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "running task setup commands: %s", logs))
+		return
+	}
+	event.LogHostTaskEnvironmentStepSucceeded(j.host.Id, step, logs)
+}
+
+// runScript runs the shell script on the host and returns its output.
+func (j *spawnHostTaskEnvironmentJob) runScript(ctx context.Context, script string) (string, error) {
+	if j.host.Distro.LegacyBootstrap() {
+		return j.host.RunSSHShellScript(ctx, script, false, "")
+	}
+	output, err := j.host.RunJasperProcess(ctx, j.env, &options.Create{
+		Args:               []string{j.host.Distro.ShellBinary(), "-s", "-l"},
+		StandardInputBytes: []byte(script),
+	})
+	return strings.Join(output, "\n"), err
+}
+
+// writeTaskEnvironmentFilesScript returns a shell script that writes the
+// files into the directory. The contents are base64-encoded so that they are
+// written verbatim regardless of what they contain.
+func writeTaskEnvironmentFilesScript(dir string, files map[string][]byte) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{"set -o errexit", fmt.Sprintf("mkdir -p '%s'", dir)}
+	for _, name := range names {
+		fn := path.Join(dir, name)
+		lines = append(lines, fmt.Sprintf("echo '%s' | base64 --decode > '%s'", base64.StdEncoding.EncodeToString(files[name]), fn))
+		if path.Ext(name) == ".sh" {
+			lines = append(lines, fmt.Sprintf("chmod +x '%s'", fn))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
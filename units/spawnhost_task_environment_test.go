@@ -0,0 +1,87 @@
+package units
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTaskEnvironmentFilesScript(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "evergreen_task")
+	files := map[string][]byte{
+		"expansions.yml": []byte("task_id: t1\n"),
+		"task.sh":        []byte("#!/usr/bin/env bash\necho 'quoted' \"$HOME\"\ncat <<'EOF'\nheredoc\nEOF\n"),
+	}
+
+	out, err := exec.Command("bash", "-c", writeTaskEnvironmentFilesScript(dir, files)).CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	for name, content := range files {
+		written, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(written))
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "task.sh"))
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "scripts should be executable")
+	info, err = os.Stat(filepath.Join(dir, "expansions.yml"))
+	require.NoError(t, err)
+	assert.Zero(t, info.Mode()&0100)
+}
+
+func TestSpawnHostTaskEnvironmentJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &mock.Environment{}
+	require.NoError(t, env.Configure(ctx))
+
+	for tName, tCase := range map[string]func(t *testing.T, h *host.Host){
+		"FailsForHostNotReproducingTask": func(t *testing.T, h *host.Host) {
+			h.ProvisionOptions.ReproduceTask = false
+			require.NoError(t, h.Insert())
+
+			j := NewSpawnHostTaskEnvironmentJob(env, h)
+			j.Run(ctx)
+			assert.Error(t, j.Error())
+
+			events, err := event.Find(event.HostTaskEnvironmentEvents(h.Id, ""))
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			data, ok := events[0].Data.(*event.HostEventData)
+			require.True(t, ok)
+			assert.False(t, data.Successful)
+			assert.Equal(t, taskEnvironmentStepFetchTaskData, data.ProvisioningStep)
+		},
+		"FailsForNonexistentHost": func(t *testing.T, h *host.Host) {
+			j := NewSpawnHostTaskEnvironmentJob(env, h)
+			j.(*spawnHostTaskEnvironmentJob).host = nil
+			j.Run(ctx)
+			assert.Error(t, j.Error())
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(host.Collection, event.EventCollection))
+			defer func() {
+				assert.NoError(t, db.ClearCollections(host.Collection, event.EventCollection))
+			}()
+			tCase(t, &host.Host{
+				Id: "h1",
+				ProvisionOptions: &host.ProvisionOptions{
+					TaskId:        "t1",
+					ReproduceTask: true,
+				},
+			})
+		})
+	}
+}
@@ -3,8 +3,10 @@ package units
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/job"
@@ -74,6 +76,7 @@ func (j *hostStatsCollector) Run(_ context.Context) {
 
 	j.AddError(j.statsByDistro())
 	j.AddError(j.statsByProvider())
+	j.AddError(j.statsForWarmPools())
 }
 
 type hostCountStats struct {
@@ -151,3 +154,45 @@ func (j *hostStatsCollector) statsByProvider() error {
 
 	return nil
 }
+
+// statsForWarmPools reports how many free hosts each distro with a warm pool
+// has ready compared to its target.
+func (j *hostStatsCollector) statsForWarmPools() error {
+	distros, err := distro.Find(distro.ByHasWarmPool())
+	if err != nil {
+		return errors.Wrap(err, "finding distros with warm pools")
+	}
+	if len(distros) == 0 {
+		return nil
+	}
+
+	stats, err := host.GetStatsByDistro()
+	if err != nil {
+		return errors.Wrap(err, "getting host stats by distro")
+	}
+	numReady := map[string]int{}
+	for _, s := range stats {
+		if s.Status == evergreen.HostRunning {
+			numReady[s.Distro] += s.Count - s.NumTasks
+		}
+	}
+
+	now := time.Now()
+	for _, d := range distros {
+		target := d.HostAllocatorSettings.WarmPool.TargetReadyHosts(now)
+		ready := numReady[d.Id]
+		deficit := 0
+		if target > ready {
+			deficit = target - ready
+		}
+		j.logger.Info(message.Fields{
+			"report":      "warm pool by distro",
+			"distro":      d.Id,
+			"target":      target,
+			"ready_hosts": ready,
+			"deficit":     deficit,
+		})
+	}
+
+	return nil
+}
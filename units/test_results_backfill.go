@@ -0,0 +1,143 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	adb "github.com/mongodb/anser/db"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const testResultsBackfillJobName = "test-results-backfill"
+
+func init() {
+	registry.AddJobType(testResultsBackfillJobName, func() amboy.Job { return makeTestResultsBackfillJob() })
+}
+
+type testResultsBackfillJob struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+func makeTestResultsBackfillJob() *testResultsBackfillJob {
+	j := &testResultsBackfillJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    testResultsBackfillJobName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewTestResultsBackfillJob returns a job that copies the test results of a
+// batch of finished tasks from Cedar into the local test results service and
+// switches the tasks over to the local service.
+func NewTestResultsBackfillJob(env evergreen.Environment, ts string) amboy.Job {
+	j := makeTestResultsBackfillJob()
+	j.SetID(fmt.Sprintf("%s.%s", testResultsBackfillJobName, ts))
+	j.SetScopes([]string{testResultsBackfillJobName})
+	j.SetEnqueueAllScopes(true)
+	j.env = env
+	return j
+}
+
+func (j *testResultsBackfillJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	conf := j.env.Settings().TestResults
+	if !conf.BackfillFromCedar || j.env.Settings().Cedar.BaseURL == "" {
+		return
+	}
+	batchSize := conf.BackfillBatchSize
+	if batchSize <= 0 {
+		return
+	}
+
+	tasks, err := task.FindWithCedarTestResults(batchSize)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "finding tasks with Cedar test results"))
+		return
+	}
+	if len(tasks) < batchSize {
+		oldTasks, err := task.FindOldWithCedarTestResults(batchSize - len(tasks))
+		if err != nil {
+			j.AddError(errors.Wrap(err, "finding archived tasks with Cedar test results"))
+			return
+		}
+		tasks = append(tasks, oldTasks...)
+	}
+
+	var numCopied, numMissing, numErrors int
+	for _, t := range tasks {
+		if ctx.Err() != nil {
+			j.AddError(ctx.Err())
+			break
+		}
+
+		found, err := j.backfillTask(ctx, t)
+		if err != nil {
+			// Continue so that one task's results do not prevent the
+			// rest of the batch from being copied, and record the failure
+			// so that a task that keeps failing is eventually skipped.
+			j.AddError(err)
+			if ctx.Err() == nil {
+				j.AddError(t.IncResultsBackfillFailures())
+			}
+			numErrors++
+			continue
+		}
+		if found {
+			numCopied++
+		} else {
+			numMissing++
+		}
+	}
+
+	grip.Info(message.Fields{
+		"message":     "backfilled local test results from Cedar",
+		"num_tasks":   len(tasks),
+		"num_copied":  numCopied,
+		"num_missing": numMissing,
+		"num_errors":  numErrors,
+		"job":         j.ID(),
+		"job_type":    j.Type().Name,
+	})
+}
+
+// backfillTask copies the task's test results from Cedar into the local test
+// results service, then switches the task over to the local service. It
+// returns whether Cedar had test results for the task.
+func (j *testResultsBackfillJob) backfillTask(ctx context.Context, t task.Task) (bool, error) {
+	taskID := t.Id
+	if t.Archived {
+		taskID = t.OldTaskId
+	}
+
+	found, err := testresult.CopyCedarToLocal(ctx, j.env, taskID, t.Execution)
+	if err != nil {
+		return false, errors.Wrapf(err, "copying test results for task '%s' execution %d", taskID, t.Execution)
+	}
+
+	// A task that Cedar has no test results for is switched over as well
+	// so that it is not checked again.
+	if err = t.MigrateResultsService(testresult.TestResultsServiceLocal); err != nil && !adb.ResultsNotFound(err) {
+		return false, err
+	}
+
+	return found, nil
+}
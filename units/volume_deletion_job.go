@@ -3,6 +3,7 @@ package units
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
@@ -10,6 +11,8 @@ import (
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/job"
 	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
 )
 
@@ -83,8 +86,47 @@ func (j *volumeDeletionJob) Run(ctx context.Context) {
 		return
 	}
 
+	if err := j.snapshotVolume(ctx, mgr); err != nil {
+		j.AddError(errors.Wrapf(err, "snapshotting volume '%s' before deleting it", j.VolumeID))
+		return
+	}
+
 	if err := mgr.DeleteVolume(ctx, j.volume); err != nil {
 		j.AddError(errors.Wrapf(err, "deleting volume '%s'", j.VolumeID))
 		return
 	}
 }
+
+// snapshotVolume takes a snapshot of the volume so that its owner can restore
+// it after it's deleted. If a previous attempt already took the snapshot, it
+// is not taken again.
+func (j *volumeDeletionJob) snapshotVolume(ctx context.Context, mgr cloud.Manager) error {
+	snapshots, err := host.FindVolumeSnapshotsByVolume(j.volume.ID)
+	if err != nil {
+		return errors.Wrap(err, "finding existing snapshots")
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Automatic && snapshot.Status != host.VolumeSnapshotStatusError && snapshot.CreationDate.After(j.volume.Expiration) {
+			return nil
+		}
+	}
+
+	snapshot, err := mgr.CreateVolumeSnapshot(ctx, j.volume, &host.VolumeSnapshot{
+		DisplayName: j.volume.DisplayName,
+		CreatedBy:   j.volume.CreatedBy,
+		Expiration:  time.Now().Add(evergreen.DefaultVolumeSnapshotExpiration),
+		Automatic:   true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "creating snapshot")
+	}
+	grip.Info(message.Fields{
+		"message":     "created snapshot of expired volume before deleting it",
+		"volume_id":   j.volume.ID,
+		"snapshot_id": snapshot.ID,
+		"user":        j.volume.CreatedBy,
+		"job":         j.ID(),
+	})
+
+	return nil
+}
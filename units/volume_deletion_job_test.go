@@ -0,0 +1,105 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVolumeDeletionJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &mock.Environment{}
+	require.NoError(t, env.Configure(ctx))
+
+	for tName, tCase := range map[string]func(t *testing.T, v *host.Volume){
+		"SnapshotsVolumeBeforeDeletingIt": func(t *testing.T, v *host.Volume) {
+			j := NewVolumeDeletionJob("ts", v).(*volumeDeletionJob)
+			j.env = env
+			j.Provider = evergreen.ProviderNameMock
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			dbVolume, err := host.FindVolumeByID(v.ID)
+			require.NoError(t, err)
+			assert.Zero(t, dbVolume)
+
+			snapshots, err := host.FindVolumeSnapshotsByVolume(v.ID)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 1)
+			assert.True(t, snapshots[0].Automatic)
+			assert.Equal(t, "user", snapshots[0].CreatedBy)
+			assert.Equal(t, v.Size, snapshots[0].Size)
+			assert.True(t, snapshots[0].Expiration.After(time.Now()))
+		},
+		"DoesNotSnapshotVolumeAgain": func(t *testing.T, v *host.Volume) {
+			require.NoError(t, (&host.VolumeSnapshot{
+				ID:        "existing",
+				VolumeID:  v.ID,
+				Automatic: true,
+				Status:    host.VolumeSnapshotStatusPending,
+			}).Insert())
+
+			j := NewVolumeDeletionJob("ts", v).(*volumeDeletionJob)
+			j.env = env
+			j.Provider = evergreen.ProviderNameMock
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			snapshots, err := host.FindVolumeSnapshotsByVolume(v.ID)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 1)
+			assert.Equal(t, "existing", snapshots[0].ID)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(host.VolumesCollection, host.VolumeSnapshotsCollection))
+			defer func() {
+				assert.NoError(t, db.ClearCollections(host.VolumesCollection, host.VolumeSnapshotsCollection))
+			}()
+			v := &host.Volume{
+				ID:               "v0",
+				CreatedBy:        "user",
+				Size:             16,
+				AvailabilityZone: "us-east-1a",
+				Expiration:       time.Now().Add(-time.Hour),
+			}
+			require.NoError(t, v.Insert())
+			tCase(t, v)
+		})
+	}
+}
+
+func TestVolumeSnapshotDeletionJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := &mock.Environment{}
+	require.NoError(t, env.Configure(ctx))
+
+	require.NoError(t, db.ClearCollections(host.VolumeSnapshotsCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(host.VolumeSnapshotsCollection))
+	}()
+
+	s := &host.VolumeSnapshot{ID: "s0", AvailabilityZone: "us-east-1a", Expiration: time.Now().Add(-time.Hour)}
+	require.NoError(t, s.Insert())
+
+	j := NewVolumeSnapshotDeletionJob("ts", s).(*volumeSnapshotDeletionJob)
+	j.env = env
+	j.Provider = evergreen.ProviderNameMock
+	j.Run(ctx)
+	require.NoError(t, j.Error())
+
+	dbSnapshot, err := host.FindVolumeSnapshotByID(s.ID)
+	require.NoError(t, err)
+	assert.Zero(t, dbSnapshot)
+}
@@ -0,0 +1,91 @@
+package units
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/pkg/errors"
+)
+
+const (
+	volumeSnapshotDeletionName = "volume-snapshot-deletion"
+)
+
+func init() {
+	registry.AddJobType(volumeSnapshotDeletionName,
+		func() amboy.Job { return makeVolumeSnapshotDeletionJob() })
+}
+
+type volumeSnapshotDeletionJob struct {
+	job.Base   `bson:"job_base" json:"job_base" yaml:"job_base"`
+	SnapshotID string `bson:"snapshot_id" yaml:"snapshot_id"`
+	Provider   string `bson:"provider" yaml:"provider"`
+
+	snapshot *host.VolumeSnapshot
+	env      evergreen.Environment
+}
+
+func makeVolumeSnapshotDeletionJob() *volumeSnapshotDeletionJob {
+	j := &volumeSnapshotDeletionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    volumeSnapshotDeletionName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+// NewVolumeSnapshotDeletionJob creates a job that deletes an expired volume
+// snapshot.
+func NewVolumeSnapshotDeletionJob(ts string, s *host.VolumeSnapshot) amboy.Job {
+	j := makeVolumeSnapshotDeletionJob()
+	j.SetID(fmt.Sprintf("%s.%s.%s", volumeSnapshotDeletionName, s.ID, ts))
+	j.SetScopes([]string{fmt.Sprintf("%s.%s", volumeSnapshotDeletionName, s.ID)})
+	j.SetEnqueueAllScopes(true)
+	j.SnapshotID = s.ID
+	j.Provider = evergreen.ProviderNameEc2OnDemand
+	return j
+}
+
+func (j *volumeSnapshotDeletionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+	var err error
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	if j.snapshot == nil {
+		j.snapshot, err = host.FindVolumeSnapshotByID(j.SnapshotID)
+		if err != nil {
+			j.AddError(errors.Wrapf(err, "finding volume snapshot '%s'", j.SnapshotID))
+			return
+		}
+		if j.snapshot == nil {
+			return
+		}
+	}
+
+	mgrOpts := cloud.ManagerOpts{
+		Provider: j.Provider,
+		Region:   cloud.AztoRegion(j.snapshot.AvailabilityZone),
+	}
+	mgr, err := cloud.GetManager(ctx, j.env, mgrOpts)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "getting cloud manager for volume snapshot '%s'", j.SnapshotID))
+		return
+	}
+
+	if err := mgr.DeleteVolumeSnapshot(ctx, j.snapshot); err != nil {
+		j.AddError(errors.Wrapf(err, "deleting volume snapshot '%s'", j.SnapshotID))
+		return
+	}
+}
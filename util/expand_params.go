@@ -73,8 +73,20 @@ func expandMap(inputMap reflect.Value, expansions *Expansions) error {
 				return errors.Wrapf(err, "expanding value '%s'", val.String())
 			}
 			expandedVal = val
+		case reflect.Slice:
+			if val.Type().Elem().Kind() != reflect.String {
+				return errors.Errorf("could not expand value '%s' because it is not a slice of strings", val.String())
+			}
+			expandedVal = reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+			for i := 0; i < val.Len(); i++ {
+				expandedElem, err := expansions.ExpandString(val.Index(i).String())
+				if err != nil {
+					return errors.Wrapf(err, "expanding value '%s'", val.Index(i).String())
+				}
+				expandedVal.Index(i).SetString(expandedElem)
+			}
 		default:
-			return errors.Errorf("could not expand value '%s' because it is not a string, map, or struct", val.String())
+			return errors.Errorf("could not expand value '%s' because it is not a string, slice of strings, map, or struct", val.String())
 		}
 
 		// unset unexpanded key then set expanded key
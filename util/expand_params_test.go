@@ -264,5 +264,14 @@ func TestExpandValues(t *testing.T) {
 			So(testmap["A"]["no"], ShouldEqual, "same")
 			So(testmap["A"]["deep"], ShouldEqual, "C")
 		})
+
+		Convey("a map of string slices expands properly", func() {
+			testmap := map[string][]string{
+				"${a}": {"${b}/src", "same"},
+			}
+			So(ExpandValues(&testmap, expansions), ShouldBeNil)
+			So(len(testmap), ShouldEqual, 1)
+			So(testmap["A"], ShouldResemble, []string{"B/src", "same"})
+		})
 	})
 }
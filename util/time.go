@@ -0,0 +1,17 @@
+package util
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ParseTimeOfDay parses a time of day in the format HH:MM and returns how long
+// after midnight it is.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.Errorf("time of day '%s' must be in the format HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
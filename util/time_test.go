@@ -0,0 +1,28 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeOfDay(t *testing.T) {
+	d, err := ParseTimeOfDay("00:00")
+	require.NoError(t, err)
+	assert.Zero(t, d)
+
+	d, err = ParseTimeOfDay("09:30")
+	require.NoError(t, err)
+	assert.Equal(t, 9*time.Hour+30*time.Minute, d)
+
+	d, err = ParseTimeOfDay("23:59")
+	require.NoError(t, err)
+	assert.Equal(t, 23*time.Hour+59*time.Minute, d)
+
+	for _, s := range []string{"", "9", "24:00", "12:60", "noon"} {
+		_, err = ParseTimeOfDay(s)
+		assert.Error(t, err, s)
+	}
+}
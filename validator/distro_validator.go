@@ -40,6 +40,7 @@ var distroSyntaxValidators = []distroValidator{
 	ensureHasValidFinderSettings,
 	ensureHasValidDispatcherSettings,
 	ensureHasValidVirtualWorkstationSettings,
+	ensureHasValidSpawnHostAutoStopSettings,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -440,6 +441,18 @@ func ensureHasValidHostAllocatorSettings(ctx context.Context, d *distro.Distro,
 			Level:   Error,
 		})
 	}
+	if settings.ForecastWindow < 0 || settings.ForecastWindow > distro.MaxForecastWindow {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("invalid host_allocator_settings.forecast_window value of %s for distro '%s' - its value must be between 0 and %s", settings.ForecastWindow, d.Id, distro.MaxForecastWindow),
+			Level:   Error,
+		})
+	}
+	if err := settings.WarmPool.Validate(settings.MaximumHosts); err != nil {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("invalid host_allocator_settings.warm_pool for distro '%s': %s", d.Id, err.Error()),
+			Level:   Error,
+		})
+	}
 
 	return errs
 }
@@ -549,3 +562,21 @@ func ensureHasValidVirtualWorkstationSettings(ctx context.Context, d *distro.Dis
 	}
 	return errs
 }
+
+func ensureHasValidSpawnHostAutoStopSettings(ctx context.Context, d *distro.Distro, s *evergreen.Settings) ValidationErrors {
+	var errs ValidationErrors
+	settings := d.SpawnHostAutoStop
+	if settings.IdleTimeout < 0 || (settings.IdleTimeout > 0 && settings.IdleTimeout < evergreen.MinSpawnHostIdleTimeout) {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("spawn host idle timeout must be either zero or at least %s", evergreen.MinSpawnHostIdleTimeout),
+			Level:   Error,
+		})
+	}
+	if settings.LoadThreshold < 0 {
+		errs = append(errs, ValidationError{
+			Message: "spawn host load threshold cannot be negative",
+			Level:   Error,
+		})
+	}
+	return errs
+}
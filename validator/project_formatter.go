@@ -0,0 +1,650 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/command"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const projectConfigIndent = 2
+
+// The canonical key orders for the sections of a project configuration file.
+// Keys that are not listed are kept in their original relative order after
+// the listed keys.
+var (
+	projectConfigKeyOrder = []string{
+		"include",
+		"variables",
+		"identifier",
+		"display_name",
+		"owner",
+		"repo",
+		"branch",
+		"remote_path",
+		"enabled",
+		"stepback",
+		"batchtime",
+		"command_type",
+		"ignore",
+		"parameters",
+		"exec_timeout_secs",
+		"callback_timeout_secs",
+		"pre_error_fails_task",
+		"post_error_fails_task",
+		"oom_tracker",
+		"loggers",
+		"modules",
+		"containers",
+		"pre",
+		"post",
+		"timeout",
+		"early_termination",
+		"functions",
+		"tasks",
+		"task_groups",
+		"axes",
+		"buildvariants",
+	}
+	projectConfigTaskKeyOrder = []string{
+		"name",
+		"tags",
+		"priority",
+		"exec_timeout_secs",
+		"run_on",
+		"patchable",
+		"patch_only",
+		"allow_for_git_tag",
+		"git_tag_only",
+		"disable",
+		"stepback",
+		"must_have_test_results",
+		"depends_on",
+		"commands",
+	}
+	projectConfigTaskGroupKeyOrder = []string{
+		"name",
+		"tags",
+		"max_hosts",
+		"priority",
+		"exec_timeout_secs",
+		"patchable",
+		"patch_only",
+		"allow_for_git_tag",
+		"git_tag_only",
+		"stepback",
+		"share_processes",
+		"setup_group_can_fail_task",
+		"setup_group_timeout_secs",
+		"teardown_task_can_fail_task",
+		"depends_on",
+		"setup_group",
+		"setup_task",
+		"tasks",
+		"teardown_task",
+		"teardown_group",
+		"timeout",
+	}
+	projectConfigVariantKeyOrder = []string{
+		"name",
+		"matrix_name",
+		"display_name",
+		"matrix_spec",
+		"exclude_spec",
+		"rules",
+		"tags",
+		"modules",
+		"disable",
+		"activate",
+		"batchtime",
+		"cron",
+		"stepback",
+		"run_on",
+		"expansions",
+		"depends_on",
+		"tasks",
+		"display_tasks",
+	}
+	projectConfigVariantTaskKeyOrder = []string{
+		"name",
+		"run_on",
+		"distros",
+		"priority",
+		"exec_timeout_secs",
+		"patchable",
+		"patch_only",
+		"allow_for_git_tag",
+		"git_tag_only",
+		"disable",
+		"activate",
+		"batchtime",
+		"cron",
+		"stepback",
+		"commit_queue_merge",
+		"depends_on",
+		"task_group",
+	}
+	projectConfigCommandKeyOrder = []string{
+		"command",
+		"func",
+		"type",
+		"display_name",
+		"timeout_secs",
+		"variants",
+		"loggers",
+		"params",
+		"params_yaml",
+		"vars",
+	}
+)
+
+// ProjectConfigChange describes a change made by UpgradeProjectConfig to a
+// project configuration file.
+type ProjectConfigChange struct {
+	// Line is the line of the original file that the change applies to.
+	Line    int
+	Message string
+	// Skipped indicates that the deprecated construct could not be upgraded
+	// automatically and must be fixed by hand.
+	Skipped bool
+}
+
+func (c ProjectConfigChange) String() string {
+	if c.Skipped {
+		return fmt.Sprintf("%d: not upgraded: %s", c.Line, c.Message)
+	}
+	return fmt.Sprintf("%d: %s", c.Line, c.Message)
+}
+
+// FormatProjectConfig rewrites the project configuration YAML in a canonical
+// form: keys of known sections are put in a stable order and everything is
+// indented consistently. Comments, anchors, and includes are preserved, and
+// the configuration that the file describes does not change.
+func FormatProjectConfig(data []byte) ([]byte, error) {
+	doc, err := parseProjectConfigNode(data)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return data, nil
+	}
+
+	w := projectConfigWalker{
+		mapping: func(n *yaml.Node, order []string) {
+			sortProjectConfigMapping(n, order)
+		},
+	}
+	w.walk(doc.Content[0])
+
+	return encodeProjectConfigNode(doc)
+}
+
+// UpgradeProjectConfig mechanically rewrites deprecated constructs in the
+// project configuration YAML and returns the new YAML along with the changes
+// that were made. Deprecated constructs that cannot be rewritten safely are
+// left in place and returned as skipped changes.
+func UpgradeProjectConfig(data []byte) ([]byte, []ProjectConfigChange, error) {
+	doc, err := parseProjectConfigNode(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc == nil {
+		return data, nil, nil
+	}
+
+	u := projectConfigUpgrader{}
+	w := projectConfigWalker{
+		commands:    u.upgradeCommands,
+		command:     u.upgradeCommand,
+		variantTask: u.upgradeVariantTask,
+	}
+	w.walk(doc.Content[0])
+
+	out, err := encodeProjectConfigNode(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, u.changes, nil
+}
+
+func parseProjectConfigNode(data []byte) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, errors.Wrap(err, "parsing project config YAML")
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("project config must be a YAML mapping")
+	}
+	return doc, nil
+}
+
+func encodeProjectConfigNode(doc *yaml.Node) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(projectConfigIndent)
+	if err := enc.Encode(doc); err != nil {
+		return nil, errors.Wrap(err, "encoding project config YAML")
+	}
+	if err := enc.Close(); err != nil {
+		return nil, errors.Wrap(err, "closing project config YAML encoder")
+	}
+	return buf.Bytes(), nil
+}
+
+// projectConfigWalker visits the sections of a project configuration YAML
+// document that have a known structure. Nodes reached through aliases are
+// visited only once.
+type projectConfigWalker struct {
+	// mapping is called for every mapping with a known structure, along with
+	// the canonical order of its keys.
+	mapping func(n *yaml.Node, order []string)
+	// commands is called for every list of commands. A list containing a
+	// single command may be a mapping rather than a sequence.
+	commands func(n *yaml.Node, section string)
+	// command is called for every command.
+	command func(n *yaml.Node)
+	// variantTask is called for every task listed in a build variant.
+	variantTask func(n *yaml.Node)
+
+	seen map[*yaml.Node]bool
+}
+
+func (w *projectConfigWalker) visit(n *yaml.Node) (*yaml.Node, bool) {
+	n = resolveYAMLAlias(n)
+	if w.seen == nil {
+		w.seen = map[*yaml.Node]bool{}
+	}
+	if w.seen[n] {
+		return n, false
+	}
+	w.seen[n] = true
+	return n, true
+}
+
+func (w *projectConfigWalker) visitMapping(n *yaml.Node, order []string) {
+	if w.mapping != nil {
+		w.mapping(n, order)
+	}
+}
+
+func (w *projectConfigWalker) walk(root *yaml.Node) {
+	w.visitMapping(root, projectConfigKeyOrder)
+
+	forEachYAMLMappingEntry(root, func(key string, val *yaml.Node) {
+		switch key {
+		case "pre", "post", "timeout", "early_termination":
+			w.walkCommands(val, fmt.Sprintf("'%s'", key))
+		case "functions":
+			val = resolveYAMLAlias(val)
+			forEachYAMLMappingEntry(val, func(name string, cmds *yaml.Node) {
+				w.walkCommands(cmds, fmt.Sprintf("function '%s'", name))
+			})
+		case "tasks":
+			forEachYAMLSequenceItem(val, w.walkTask)
+		case "task_groups":
+			forEachYAMLSequenceItem(val, w.walkTaskGroup)
+		case "buildvariants":
+			forEachYAMLSequenceItem(val, w.walkVariant)
+		}
+	})
+}
+
+func (w *projectConfigWalker) walkTask(n *yaml.Node) {
+	n, ok := w.visit(n)
+	if !ok || n.Kind != yaml.MappingNode {
+		return
+	}
+	w.visitMapping(n, projectConfigTaskKeyOrder)
+
+	name := yamlMappingScalar(n, "name")
+	forEachYAMLMappingEntry(n, func(key string, val *yaml.Node) {
+		if key == "commands" {
+			w.walkCommands(val, fmt.Sprintf("task '%s'", name))
+		}
+	})
+}
+
+func (w *projectConfigWalker) walkTaskGroup(n *yaml.Node) {
+	n, ok := w.visit(n)
+	if !ok || n.Kind != yaml.MappingNode {
+		return
+	}
+	w.visitMapping(n, projectConfigTaskGroupKeyOrder)
+
+	name := yamlMappingScalar(n, "name")
+	forEachYAMLMappingEntry(n, func(key string, val *yaml.Node) {
+		switch key {
+		case "setup_group", "setup_task", "teardown_task", "teardown_group", "timeout":
+			w.walkCommands(val, fmt.Sprintf("'%s' of task group '%s'", key, name))
+		}
+	})
+}
+
+func (w *projectConfigWalker) walkVariant(n *yaml.Node) {
+	n, ok := w.visit(n)
+	if !ok || n.Kind != yaml.MappingNode {
+		return
+	}
+	w.visitMapping(n, projectConfigVariantKeyOrder)
+
+	forEachYAMLMappingEntry(n, func(key string, val *yaml.Node) {
+		if key == "tasks" {
+			forEachYAMLSequenceItem(val, w.walkVariantTask)
+		}
+	})
+}
+
+func (w *projectConfigWalker) walkVariantTask(n *yaml.Node) {
+	n, ok := w.visit(n)
+	if !ok || n.Kind != yaml.MappingNode {
+		return
+	}
+	w.visitMapping(n, projectConfigVariantTaskKeyOrder)
+	if w.variantTask != nil {
+		w.variantTask(n)
+	}
+
+	forEachYAMLMappingEntry(n, func(key string, val *yaml.Node) {
+		if key == "task_group" {
+			w.walkTaskGroup(val)
+		}
+	})
+}
+
+func (w *projectConfigWalker) walkCommands(n *yaml.Node, section string) {
+	n, ok := w.visit(n)
+	if !ok {
+		return
+	}
+	if w.commands != nil {
+		w.commands(n, section)
+	}
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		w.walkCommand(n)
+	case yaml.SequenceNode:
+		forEachYAMLSequenceItem(n, func(cmd *yaml.Node) {
+			cmd, ok := w.visit(cmd)
+			if ok && cmd.Kind == yaml.MappingNode {
+				w.walkCommand(cmd)
+			}
+		})
+	}
+}
+
+func (w *projectConfigWalker) walkCommand(n *yaml.Node) {
+	w.visitMapping(n, projectConfigCommandKeyOrder)
+	if w.command != nil {
+		w.command(n)
+	}
+}
+
+type projectConfigUpgrader struct {
+	changes []ProjectConfigChange
+}
+
+func (u *projectConfigUpgrader) addChange(n *yaml.Node, skipped bool, format string, args ...interface{}) {
+	u.changes = append(u.changes, ProjectConfigChange{
+		Line:    n.Line,
+		Message: fmt.Sprintf(format, args...),
+		Skipped: skipped,
+	})
+}
+
+// upgradeCommands removes deprecated commands, which do nothing, from the
+// list of commands. Commands are not removed if that would leave the list
+// empty, since an empty list may not be valid in its section.
+func (u *projectConfigUpgrader) upgradeCommands(n *yaml.Node, section string) {
+	if n.Kind == yaml.MappingNode {
+		name := yamlMappingScalar(n, "command")
+		if reason, ok := command.DeprecatedCommands[name]; ok {
+			u.addChange(n, true, "deprecated command '%s' in %s is the only command (%s)", name, section, reason)
+		}
+		return
+	}
+	if n.Kind != yaml.SequenceNode {
+		return
+	}
+
+	var kept, removed []*yaml.Node
+	for _, item := range n.Content {
+		if _, ok := command.DeprecatedCommands[yamlMappingScalar(resolveYAMLAlias(item), "command")]; ok {
+			removed = append(removed, item)
+		} else {
+			kept = append(kept, item)
+		}
+	}
+	if len(removed) == 0 {
+		return
+	}
+	for _, item := range removed {
+		name := yamlMappingScalar(resolveYAMLAlias(item), "command")
+		reason := command.DeprecatedCommands[name]
+		if len(kept) == 0 {
+			u.addChange(item, true, "deprecated command '%s' in %s is the only command (%s)", name, section, reason)
+			continue
+		}
+		u.addChange(item, false, "removed deprecated command '%s' from %s (%s)", name, section, reason)
+	}
+	if len(kept) > 0 {
+		n.Content = kept
+	}
+}
+
+// upgradeCommand normalizes the style of shell.exec scripts so that
+// multi-line scripts are literal blocks, which preserve the script's lines
+// exactly as they are written.
+func (u *projectConfigUpgrader) upgradeCommand(n *yaml.Node) {
+	if yamlMappingScalar(n, "command") != evergreen.ShellExecCommandName {
+		return
+	}
+	_, params := yamlMappingEntry(n, "params")
+	params = resolveYAMLAlias(params)
+	if params == nil || params.Kind != yaml.MappingNode {
+		return
+	}
+	_, script := yamlMappingEntry(params, "script")
+	if script == nil || script.Kind != yaml.ScalarNode {
+		return
+	}
+	if !strings.Contains(script.Value, "\n") || script.Style == yaml.LiteralStyle {
+		return
+	}
+	if !canUseLiteralStyle(script.Value) {
+		u.addChange(script, true, "shell.exec script contains trailing whitespace or control characters and cannot be written as a literal block")
+		return
+	}
+
+	if params.Style == yaml.FlowStyle {
+		params.Style = 0
+		u.addChange(params, false, "rewrote shell.exec params as a block mapping")
+	}
+	previous := "plain"
+	switch script.Style {
+	case yaml.DoubleQuotedStyle:
+		previous = "double-quoted"
+	case yaml.SingleQuotedStyle:
+		previous = "single-quoted"
+	case yaml.FoldedStyle:
+		previous = "folded"
+	}
+	script.Style = yaml.LiteralStyle
+	u.addChange(script, false, "rewrote %s shell.exec script as a literal block", previous)
+}
+
+// upgradeVariantTask renames the deprecated 'distros' field of a build
+// variant task to 'run_on'.
+func (u *projectConfigUpgrader) upgradeVariantTask(n *yaml.Node) {
+	distrosKey, _ := yamlMappingEntry(n, "distros")
+	if distrosKey == nil {
+		return
+	}
+	name := yamlMappingScalar(n, "name")
+	if runOnKey, _ := yamlMappingEntry(n, "run_on"); runOnKey != nil {
+		u.addChange(distrosKey, true, "build variant task '%s' specifies both 'distros' and 'run_on'", name)
+		return
+	}
+	distrosKey.Value = "run_on"
+	u.addChange(distrosKey, false, "renamed 'distros' to 'run_on' for build variant task '%s'", name)
+}
+
+// sortProjectConfigMapping stably reorders the keys of the mapping node
+// according to the given order. Keys are never moved in a way that would put
+// an alias before the anchor it refers to.
+func sortProjectConfigMapping(n *yaml.Node, order []string) {
+	if n.Kind != yaml.MappingNode {
+		return
+	}
+
+	ranks := make(map[string]int, len(order))
+	for i, key := range order {
+		ranks[key] = i + 1
+	}
+
+	type entry struct {
+		key     *yaml.Node
+		val     *yaml.Node
+		rank    int
+		anchors map[string]bool
+		aliases map[string]bool
+	}
+	entries := make([]entry, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		e := entry{
+			key:     n.Content[i],
+			val:     n.Content[i+1],
+			rank:    len(order) + 1,
+			anchors: map[string]bool{},
+			aliases: map[string]bool{},
+		}
+		if rank, ok := ranks[e.key.Value]; ok {
+			e.rank = rank
+		} else if e.key.Value == "<<" {
+			// Merge keys always come first.
+			e.rank = 0
+		}
+		collectYAMLAnchors(e.key, e.anchors, e.aliases)
+		collectYAMLAnchors(e.val, e.anchors, e.aliases)
+		entries = append(entries, e)
+	}
+
+	placed := make([]bool, len(entries))
+	dependsOnUnplaced := func(i int) bool {
+		for alias := range entries[i].aliases {
+			for j := range entries {
+				if j != i && !placed[j] && entries[j].anchors[alias] {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	content := make([]*yaml.Node, 0, len(n.Content))
+	for len(content) < len(n.Content) {
+		next := -1
+		for i := range entries {
+			if placed[i] || dependsOnUnplaced(i) {
+				continue
+			}
+			if next == -1 || entries[i].rank < entries[next].rank {
+				next = i
+			}
+		}
+		if next == -1 {
+			// The anchors cannot be ordered, so leave the mapping as is.
+			return
+		}
+		placed[next] = true
+		content = append(content, entries[next].key, entries[next].val)
+	}
+	n.Content = content
+}
+
+// collectYAMLAnchors adds the anchors defined and the aliases used within the
+// node to the given sets. It does not follow aliases.
+func collectYAMLAnchors(n *yaml.Node, anchors, aliases map[string]bool) {
+	if n == nil {
+		return
+	}
+	if n.Anchor != "" {
+		anchors[n.Anchor] = true
+	}
+	if n.Kind == yaml.AliasNode {
+		aliases[n.Value] = true
+		return
+	}
+	for _, child := range n.Content {
+		collectYAMLAnchors(child, anchors, aliases)
+	}
+}
+
+func canUseLiteralStyle(s string) bool {
+	if strings.HasSuffix(s, " ") || strings.Contains(s, " \n") {
+		return false
+	}
+	for _, r := range s {
+		if r == '\n' {
+			continue
+		}
+		if r < 0x20 || r == 0x7f || r == 0xfeff {
+			return false
+		}
+	}
+	return true
+}
+
+func resolveYAMLAlias(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	return n
+}
+
+func forEachYAMLMappingEntry(n *yaml.Node, f func(key string, val *yaml.Node)) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		f(n.Content[i].Value, n.Content[i+1])
+	}
+}
+
+func forEachYAMLSequenceItem(n *yaml.Node, f func(item *yaml.Node)) {
+	n = resolveYAMLAlias(n)
+	if n == nil || n.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range n.Content {
+		f(item)
+	}
+}
+
+func yamlMappingEntry(n *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i], n.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func yamlMappingScalar(n *yaml.Node, key string) string {
+	_, val := yamlMappingEntry(n, key)
+	val = resolveYAMLAlias(val)
+	if val == nil || val.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return val.Value
+}
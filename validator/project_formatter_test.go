@@ -0,0 +1,298 @@
+package validator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormatProjectConfig(t *testing.T) {
+	t.Run("SortsKnownKeys", func(t *testing.T) {
+		config := `
+buildvariants:
+- tasks:
+  - name: t1
+  name: bv
+  run_on: [d1]
+tasks:
+    - commands:
+        - params:
+            script: echo hi
+          command: shell.exec
+      name: t1
+`
+		expected := `tasks:
+  - name: t1
+    commands:
+      - command: shell.exec
+        params:
+          script: echo hi
+buildvariants:
+  - name: bv
+    run_on: [d1]
+    tasks:
+      - name: t1
+`
+		formatted, err := FormatProjectConfig([]byte(config))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(formatted))
+	})
+	t.Run("PreservesCommentsAndIncludes", func(t *testing.T) {
+		config := `# the project
+
+# the tasks
+tasks:
+  - name: t1 # a task
+    commands:
+      - func: f
+include:
+  - filename: other.yml
+`
+		expected := `# the project
+
+include:
+  - filename: other.yml
+# the tasks
+tasks:
+  - name: t1 # a task
+    commands:
+      - func: f
+`
+		formatted, err := FormatProjectConfig([]byte(config))
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(formatted))
+	})
+	t.Run("KeepsUnknownKeysInOrder", func(t *testing.T) {
+		config := `zzz: 1
+tasks: []
+aaa: 2
+`
+		formatted, err := FormatProjectConfig([]byte(config))
+		require.NoError(t, err)
+		assert.Equal(t, "tasks: []\nzzz: 1\naaa: 2\n", string(formatted))
+	})
+	t.Run("KeepsAnchorsBeforeAliases", func(t *testing.T) {
+		config := `tasks:
+  - name: t1
+    commands:
+      - &cmd
+        params:
+          script: echo hi
+        command: shell.exec
+functions:
+  f: *cmd
+`
+		formatted, err := FormatProjectConfig([]byte(config))
+		require.NoError(t, err)
+
+		var out interface{}
+		require.NoError(t, yaml.Unmarshal(formatted, &out), string(formatted))
+		assert.True(t, strings.HasSuffix(string(formatted), "functions:\n  f: *cmd\n"))
+	})
+	t.Run("IsIdempotent", func(t *testing.T) {
+		config := `buildvariants:
+  - name: bv
+    tasks: [{name: t1, distros: [d1]}]
+pre:
+  command: shell.exec
+  params: {script: "echo hi"}
+tasks:
+  - name: t1
+    commands:
+      - func: f
+        vars:
+          a: b
+functions:
+  f:
+    - command: shell.exec
+      params:
+        script: |
+          echo hi
+`
+		once, err := FormatProjectConfig([]byte(config))
+		require.NoError(t, err)
+		twice, err := FormatProjectConfig(once)
+		require.NoError(t, err)
+		assert.Equal(t, string(once), string(twice))
+	})
+	t.Run("DoesNotChangeProject", func(t *testing.T) {
+		config := `tasks:
+  - name: t1
+    commands:
+      - func: f
+functions:
+  f:
+    command: shell.exec
+    params:
+      script: echo hi
+buildvariants:
+  - name: bv
+    display_name: BV
+    run_on: [d1]
+    tasks:
+      - name: t1
+`
+		formatted, err := FormatProjectConfig([]byte(config))
+		require.NoError(t, err)
+
+		before := &model.Project{}
+		_, err = model.LoadProjectInto(context.Background(), []byte(config), nil, "", before)
+		require.NoError(t, err)
+		after := &model.Project{}
+		_, err = model.LoadProjectInto(context.Background(), formatted, nil, "", after)
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+	t.Run("FailsWithInvalidYAML", func(t *testing.T) {
+		_, err := FormatProjectConfig([]byte("tasks: [\n"))
+		assert.Error(t, err)
+		_, err = FormatProjectConfig([]byte("- a\n- b\n"))
+		assert.Error(t, err)
+	})
+	t.Run("SucceedsWithEmptyConfig", func(t *testing.T) {
+		formatted, err := FormatProjectConfig(nil)
+		require.NoError(t, err)
+		assert.Empty(t, formatted)
+	})
+}
+
+func TestUpgradeProjectConfig(t *testing.T) {
+	t.Run("RenamesDistros", func(t *testing.T) {
+		config := `buildvariants:
+  - name: bv
+    tasks:
+      - name: t1
+        distros: [d1]
+      - name: t2
+        distros: [d1]
+        run_on: [d2]
+`
+		upgraded, changes, err := UpgradeProjectConfig([]byte(config))
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.False(t, changes[0].Skipped)
+		assert.Equal(t, 5, changes[0].Line)
+		assert.True(t, changes[1].Skipped)
+		assert.Contains(t, string(upgraded), "      - name: t1\n        run_on: [d1]\n")
+		assert.Contains(t, string(upgraded), "        distros: [d1]\n        run_on: [d2]\n")
+	})
+	t.Run("RemovesDeprecatedCommands", func(t *testing.T) {
+		config := `pre:
+  - command: shell.track
+  - command: git.get_project
+functions:
+  f:
+    command: shell.cleanup
+  g:
+    - command: expansions.fetch_vars
+tasks:
+  - name: t1
+    commands:
+      - command: git.apply_patch
+      - command: manifest.load
+      - func: g
+`
+		upgraded, changes, err := UpgradeProjectConfig([]byte(config))
+		require.NoError(t, err)
+
+		var removed, skipped int
+		for _, c := range changes {
+			if c.Skipped {
+				skipped++
+			} else {
+				removed++
+			}
+		}
+		assert.Equal(t, 3, removed)
+		assert.Equal(t, 2, skipped, "commands that are the only command in a section should be left in place")
+
+		p := &model.Project{}
+		_, err = model.LoadProjectInto(context.Background(), upgraded, nil, "", p)
+		require.NoError(t, err)
+		require.Len(t, p.Pre.List(), 1)
+		assert.Equal(t, "git.get_project", p.Pre.List()[0].Command)
+		require.Len(t, p.Tasks, 1)
+		require.Len(t, p.Tasks[0].Commands, 1)
+		assert.Equal(t, "g", p.Tasks[0].Commands[0].Function)
+		assert.Equal(t, "shell.cleanup", p.Functions["f"].List()[0].Command)
+		assert.Equal(t, "expansions.fetch_vars", p.Functions["g"].List()[0].Command)
+	})
+	t.Run("NormalizesShellScripts", func(t *testing.T) {
+		config := `tasks:
+  - name: t1
+    commands:
+      - command: shell.exec
+        params: {script: "echo a\necho b\n", working_dir: src}
+      - command: shell.exec
+        params:
+          script: >
+            echo c
+      - command: shell.exec
+        params:
+          script: |
+            echo d
+      - command: shell.exec
+        params:
+          script: echo e
+      - command: shell.exec
+        params:
+          script: "echo f \n"
+`
+		upgraded, changes, err := UpgradeProjectConfig([]byte(config))
+		require.NoError(t, err)
+		require.Len(t, changes, 4)
+		assert.Contains(t, changes[0].Message, "block mapping")
+		assert.Contains(t, changes[1].Message, "double-quoted")
+		assert.Contains(t, changes[2].Message, "folded")
+		assert.True(t, changes[3].Skipped)
+
+		assert.Contains(t, string(upgraded), "        params:\n          script: |\n            echo a\n            echo b\n          working_dir: src\n")
+		assert.Contains(t, string(upgraded), "          script: |\n            echo c\n")
+
+		before := &model.Project{}
+		_, err = model.LoadProjectInto(context.Background(), []byte(config), nil, "", before)
+		require.NoError(t, err)
+		after := &model.Project{}
+		_, err = model.LoadProjectInto(context.Background(), upgraded, nil, "", after)
+		require.NoError(t, err)
+		assert.Equal(t, before.Tasks, after.Tasks)
+	})
+	t.Run("UpgradesAliasedCommandsOnce", func(t *testing.T) {
+		config := `variables:
+  - &script
+    command: shell.exec
+    params:
+      script: "echo a\necho b"
+tasks:
+  - name: t1
+    commands:
+      - *script
+  - name: t2
+    commands:
+      - *script
+`
+		upgraded, changes, err := UpgradeProjectConfig([]byte(config))
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, 5, changes[0].Line)
+		assert.Contains(t, string(upgraded), "      script: |-\n        echo a\n        echo b\n")
+	})
+	t.Run("NoopWithoutDeprecatedConstructs", func(t *testing.T) {
+		config := `tasks:
+  - name: t1
+    commands:
+      - command: shell.exec
+        params:
+          script: echo hi
+`
+		upgraded, changes, err := UpgradeProjectConfig([]byte(config))
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, config, string(upgraded))
+	})
+}
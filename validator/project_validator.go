@@ -132,6 +132,7 @@ var projectErrorValidators = []projectValidator{
 	validateProjectTaskNames,
 	validateProjectTaskIdsAndTags,
 	validateParameters,
+	validateImpactedTasks,
 	validateTaskGroups,
 	validateHostCreates,
 	validateDuplicateBVTasks,
@@ -153,6 +154,7 @@ var projectWarningValidators = []projectValidator{
 	checkModules,
 	checkTasks,
 	checkBuildVariants,
+	checkDeprecatedCommands,
 }
 
 var projectSettingsValidators = []projectSettingsValidator{
@@ -1173,11 +1175,22 @@ func validateCommands(section string, project *model.Project,
 
 	for _, cmd := range commands {
 		commandName := fmt.Sprintf("'%s' command", cmd.Command)
+		if cmd.Function != "" {
+			commandName = fmt.Sprintf("'%s' function", cmd.Function)
+		}
+		errs = append(errs, validateCommandCondition(section, commandName, cmd.If)...)
+		if len(cmd.Parallel) > 0 {
+			errs = append(errs, validateParallelBlock(section, project, cmd)...)
+			continue
+		}
+		if cmd.FailFast {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("%s section in %s: fail_fast can only be set on a parallel block", section, commandName),
+			})
+		}
 		_, err := command.Render(cmd, project)
 		if err != nil {
-			if cmd.Function != "" {
-				commandName = fmt.Sprintf("'%s' function", cmd.Function)
-			}
 			errs = append(errs, ValidationError{Message: fmt.Sprintf("%s section in %s: %s", section, commandName, err)})
 		}
 		if cmd.Type != "" {
@@ -1196,6 +1209,69 @@ func validateCommands(section string, project *model.Project,
 	return errs
 }
 
+// validateParallelBlock checks that a parallel block contains commands and
+// function calls that can run concurrently.
+func validateParallelBlock(section string, project *model.Project, block model.PluginCommandConf) ValidationErrors {
+	errs := ValidationErrors{}
+	if block.Command != "" || block.Function != "" {
+		errs = append(errs, ValidationError{
+			Level:   Error,
+			Message: fmt.Sprintf("%s section: a parallel block cannot also specify a command or function", section),
+		})
+	}
+	if len(block.Parallel) < 2 {
+		errs = append(errs, ValidationError{
+			Level:   Warning,
+			Message: fmt.Sprintf("%s section: parallel block contains only one command", section),
+		})
+	}
+	for _, cmd := range block.Parallel {
+		if len(cmd.Parallel) > 0 {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("%s section: parallel blocks cannot be nested", section),
+			})
+		}
+	}
+	errs = append(errs, validateCommands(section+" parallel block", project, block.Parallel)...)
+	return errs
+}
+
+// validateCommandCondition checks that a command's `if` condition can be
+// parsed and that any requester it compares against is a real requester.
+func validateCommandCondition(section, commandName, condition string) ValidationErrors {
+	if condition == "" {
+		return nil
+	}
+	cond, err := model.ParseCommandCondition(condition)
+	if err != nil {
+		return ValidationErrors{{
+			Level:   Error,
+			Message: fmt.Sprintf("%s section in %s: invalid condition: %s", section, commandName, err),
+		}}
+	}
+
+	requesters := make([]string, 0, len(model.RequesterExpansions))
+	for _, r := range model.RequesterExpansions {
+		requesters = append(requesters, r)
+	}
+	sort.Strings(requesters)
+	errs := ValidationErrors{}
+	for _, term := range cond.Terms() {
+		if term.Expansion != "requester" || term.IsSet {
+			continue
+		}
+		if !utility.StringSliceContains(requesters, term.Value) {
+			errs = append(errs, ValidationError{
+				Level: Warning,
+				Message: fmt.Sprintf("%s section in %s: condition compares requester to '%s', which is not a valid requester (valid requesters are: %s)",
+					section, commandName, term.Value, strings.Join(requesters, ", ")),
+			})
+		}
+	}
+	return errs
+}
+
 // Ensures there any plugin commands referenced in a project's configuration
 // are specified in a valid format
 func validatePluginCommands(project *model.Project) ValidationErrors {
@@ -1233,6 +1309,13 @@ func validatePluginCommands(project *model.Project) ValidationErrors {
 				)
 
 			}
+			if len(c.Parallel) > 0 {
+				errs = append(errs,
+					ValidationError{
+						Message: fmt.Sprintf("can not define a parallel block within a function: '%s'", funcName),
+					},
+				)
+			}
 		}
 
 		// this checks for duplicate function definitions in the project.
@@ -1465,6 +1548,47 @@ func validateParameters(p *model.Project) ValidationErrors {
 	return errs
 }
 
+// validateImpactedTasks checks that each impacted tasks rule matches some
+// paths and selects tasks and build variants that exist in the project.
+func validateImpactedTasks(p *model.Project) ValidationErrors {
+	errs := ValidationErrors{}
+	for i, rule := range p.ImpactedTasks {
+		ruleName := rule.Name
+		if ruleName == "" {
+			ruleName = fmt.Sprintf("#%d", i+1)
+		}
+		if len(rule.Paths) == 0 {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("impacted tasks rule '%s' must specify at least one path", ruleName),
+			})
+		}
+		if len(rule.Tasks) == 0 {
+			errs = append(errs, ValidationError{
+				Level:   Error,
+				Message: fmt.Sprintf("impacted tasks rule '%s' must specify at least one task", ruleName),
+			})
+		}
+		for _, t := range rule.Tasks {
+			if !strings.HasPrefix(t, ".") && p.FindProjectTask(t) == nil {
+				errs = append(errs, ValidationError{
+					Level:   Error,
+					Message: fmt.Sprintf("impacted tasks rule '%s' references nonexistent task '%s'", ruleName, t),
+				})
+			}
+		}
+		for _, bv := range rule.Variants {
+			if !strings.HasPrefix(bv, ".") && p.FindBuildVariant(bv) == nil {
+				errs = append(errs, ValidationError{
+					Level:   Error,
+					Message: fmt.Sprintf("impacted tasks rule '%s' references nonexistent build variant '%s'", ruleName, bv),
+				})
+			}
+		}
+	}
+	return errs
+}
+
 func validateTaskGroups(p *model.Project) ValidationErrors {
 	errs := ValidationErrors{}
 	taskGroups := p.TaskGroups
@@ -1634,13 +1758,15 @@ func tasksThatCallHostCreateByProvider(p *model.Project) hostCreateCounts {
 		if cmds == nil {
 			continue
 		}
-		for _, c := range cmds.List() {
-			if c.Command == evergreen.HostCreateCommandName {
-				provider, ok := c.Params["provider"]
-				if ok && provider.(string) == evergreen.ProviderNameDocker {
-					dockerFs[f] += 1
-				} else {
-					ec2Fs[f] += 1
+		for _, block := range cmds.List() {
+			for _, c := range block.BlockCommands() {
+				if c.Command == evergreen.HostCreateCommandName {
+					provider, ok := c.Params["provider"]
+					if ok && provider.(string) == evergreen.ProviderNameDocker {
+						dockerFs[f] += 1
+					} else {
+						ec2Fs[f] += 1
+					}
 				}
 			}
 		}
@@ -1653,25 +1779,27 @@ func tasksThatCallHostCreateByProvider(p *model.Project) hostCreateCounts {
 		All:    map[string]int{},
 	}
 	for _, t := range p.Tasks {
-		for _, c := range t.Commands {
-			if c.Function != "" {
-				if times, ok := ec2Fs[c.Function]; ok {
-					counts.EC2[t.Name] += times
-					counts.All[t.Name] += times
-				}
-				if times, ok := dockerFs[c.Function]; ok {
-					counts.Docker[t.Name] += times
-					counts.All[t.Name] += times
+		for _, block := range t.Commands {
+			for _, c := range block.BlockCommands() {
+				if c.Function != "" {
+					if times, ok := ec2Fs[c.Function]; ok {
+						counts.EC2[t.Name] += times
+						counts.All[t.Name] += times
+					}
+					if times, ok := dockerFs[c.Function]; ok {
+						counts.Docker[t.Name] += times
+						counts.All[t.Name] += times
+					}
 				}
-			}
-			if c.Command == evergreen.HostCreateCommandName {
-				provider, ok := c.Params["provider"]
-				if ok && provider.(string) == evergreen.ProviderNameDocker {
-					counts.Docker[t.Name] += 1
-					counts.All[t.Name] += 1
-				} else {
-					counts.EC2[t.Name] += 1
-					counts.All[t.Name] += 1
+				if c.Command == evergreen.HostCreateCommandName {
+					provider, ok := c.Params["provider"]
+					if ok && provider.(string) == evergreen.ProviderNameDocker {
+						counts.Docker[t.Name] += 1
+						counts.All[t.Name] += 1
+					} else {
+						counts.EC2[t.Name] += 1
+						counts.All[t.Name] += 1
+					}
 				}
 			}
 		}
@@ -2124,3 +2252,53 @@ func checkBuildVariants(project *model.Project) ValidationErrors {
 	}
 	return errs
 }
+
+// checkDeprecatedCommands checks whether the project uses any deprecated
+// commands, which no longer do anything.
+func checkDeprecatedCommands(project *model.Project) ValidationErrors {
+	errs := ValidationErrors{}
+	checkSection := func(section string, cmds *model.YAMLCommandSet) {
+		if cmds == nil {
+			return
+		}
+		errs = append(errs, checkDeprecatedCommandList(section, cmds.List())...)
+	}
+
+	checkSection("pre", project.Pre)
+	checkSection("post", project.Post)
+	checkSection("timeout", project.Timeout)
+	checkSection("early termination", project.EarlyTermination)
+	for name, cmds := range project.Functions {
+		checkSection(fmt.Sprintf("function '%s'", name), cmds)
+	}
+	for _, t := range project.Tasks {
+		errs = append(errs, checkDeprecatedCommandList(fmt.Sprintf("task '%s'", t.Name), t.Commands)...)
+	}
+	for _, tg := range project.TaskGroups {
+		checkSection(fmt.Sprintf("setup group of task group '%s'", tg.Name), tg.SetupGroup)
+		checkSection(fmt.Sprintf("setup task of task group '%s'", tg.Name), tg.SetupTask)
+		checkSection(fmt.Sprintf("teardown task of task group '%s'", tg.Name), tg.TeardownTask)
+		checkSection(fmt.Sprintf("teardown group of task group '%s'", tg.Name), tg.TeardownGroup)
+		checkSection(fmt.Sprintf("timeout of task group '%s'", tg.Name), tg.Timeout)
+	}
+
+	return errs
+}
+
+func checkDeprecatedCommandList(section string, cmds []model.PluginCommandConf) ValidationErrors {
+	errs := ValidationErrors{}
+	for _, block := range cmds {
+		for _, cmd := range block.BlockCommands() {
+			reason, ok := command.DeprecatedCommands[cmd.Command]
+			if !ok {
+				continue
+			}
+			errs = append(errs, ValidationError{
+				Level: Warning,
+				Message: fmt.Sprintf("%s uses deprecated command '%s', which does nothing because %s; "+
+					"it can be removed with 'evergreen config upgrade'", section, cmd.Command, reason),
+			})
+		}
+	}
+	return errs
+}
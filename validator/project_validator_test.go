@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 
 	"github.com/evergreen-ci/evergreen"
@@ -2152,6 +2153,115 @@ tasks:
 	})
 }
 
+func TestValidateCommandConditionsAndParallelBlocks(t *testing.T) {
+	echo := model.PluginCommandConf{
+		Command: "shell.exec",
+		Params:  map[string]interface{}{"script": "echo hi"},
+	}
+	withIf := func(cmd model.PluginCommandConf, condition string) model.PluginCommandConf {
+		cmd.If = condition
+		return cmd
+	}
+	projectWithCommands := func(cmds ...model.PluginCommandConf) *model.Project {
+		return &model.Project{
+			Tasks: []model.ProjectTask{{Name: "t1", Commands: cmds}},
+		}
+	}
+
+	t.Run("SucceedsWithValidConditions", func(t *testing.T) {
+		p := projectWithCommands(
+			withIf(echo, "requester == patch"),
+			withIf(echo, "is_patch && !skip_lint"),
+			withIf(echo, "(build_variant == ubuntu || build_variant == rhel) && requester != commit"),
+		)
+		assert.Empty(t, validatePluginCommands(p))
+	})
+	t.Run("FailsWithInvalidCondition", func(t *testing.T) {
+		errs := validatePluginCommands(projectWithCommands(withIf(echo, "requester = patch")))
+		require.Len(t, errs, 1)
+		assert.Equal(t, Error, errs[0].Level)
+		assert.Contains(t, errs[0].Message, "invalid condition")
+	})
+	t.Run("WarnsWithUnknownRequester", func(t *testing.T) {
+		errs := validatePluginCommands(projectWithCommands(withIf(echo, "requester == pr")))
+		require.Len(t, errs, 1)
+		assert.Equal(t, Warning, errs[0].Level)
+		assert.Contains(t, errs[0].Message, "not a valid requester")
+	})
+	t.Run("SucceedsWithParallelBlock", func(t *testing.T) {
+		p := projectWithCommands(model.PluginCommandConf{
+			Parallel: []model.PluginCommandConf{echo, withIf(echo, "is_patch")},
+			FailFast: true,
+		})
+		assert.Empty(t, validatePluginCommands(p))
+	})
+	t.Run("ValidatesCommandsInParallelBlock", func(t *testing.T) {
+		p := projectWithCommands(model.PluginCommandConf{
+			Parallel: []model.PluginCommandConf{echo, {Command: "a.b"}},
+		})
+		errs := validatePluginCommands(p)
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Message, "parallel block")
+	})
+	t.Run("FailsWithNestedParallelBlock", func(t *testing.T) {
+		p := projectWithCommands(model.PluginCommandConf{
+			Parallel: []model.PluginCommandConf{echo, {Parallel: []model.PluginCommandConf{echo, echo}}},
+		})
+		errs := validatePluginCommands(p)
+		require.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Message, "cannot be nested")
+	})
+	t.Run("SucceedsWithVarsInParallelBlock", func(t *testing.T) {
+		withVars := echo
+		withVars.Vars = map[string]string{"foo": "bar"}
+		p := projectWithCommands(model.PluginCommandConf{
+			Parallel: []model.PluginCommandConf{echo, withVars},
+		})
+		assert.Empty(t, validatePluginCommands(p))
+	})
+	t.Run("SucceedsWithExpansionUpdateInParallelBlock", func(t *testing.T) {
+		p := projectWithCommands(model.PluginCommandConf{
+			Parallel: []model.PluginCommandConf{echo, {
+				Command: "expansions.update",
+				Params:  map[string]interface{}{"updates": []map[string]string{{"key": "foo", "value": "bar"}}},
+			}},
+		})
+		assert.Empty(t, validatePluginCommands(p))
+	})
+	t.Run("FailsWithParallelBlockAndCommand", func(t *testing.T) {
+		block := echo
+		block.Parallel = []model.PluginCommandConf{echo, echo}
+		errs := validatePluginCommands(projectWithCommands(block))
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Message, "cannot also specify a command or function")
+	})
+	t.Run("FailsWithFailFastOutsideParallelBlock", func(t *testing.T) {
+		failFast := echo
+		failFast.FailFast = true
+		errs := validatePluginCommands(projectWithCommands(failFast))
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Message, "fail_fast")
+	})
+	t.Run("FailsWithParallelBlockInFunction", func(t *testing.T) {
+		p := &model.Project{
+			Functions: map[string]*model.YAMLCommandSet{
+				"f": {SingleCommand: &model.PluginCommandConf{
+					Parallel: []model.PluginCommandConf{echo, echo},
+				}},
+			},
+		}
+		errs := validatePluginCommands(p)
+		require.NotEmpty(t, errs)
+		var found bool
+		for _, err := range errs {
+			if strings.Contains(err.Message, "parallel block within a function") {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	})
+}
+
 func TestCheckProjectWarnings(t *testing.T) {
 	Convey("When validating a project's semantics", t, func() {
 		Convey("if the project passes all of the validation funcs, no errors"+
@@ -2842,6 +2952,26 @@ func TestValidateParameters(t *testing.T) {
 	assert.Len(t, validateParameters(p), 0)
 }
 
+func TestValidateImpactedTasks(t *testing.T) {
+	p := &model.Project{
+		Tasks:         []model.ProjectTask{{Name: "t1"}},
+		BuildVariants: []model.BuildVariant{{Name: "bv1"}},
+		ImpactedTasks: []model.ImpactedTasksRule{
+			{Paths: []string{"src/**"}, Tasks: []string{"t1", ".tag"}, Variants: []string{"bv1", ".tag"}},
+		},
+	}
+	assert.Empty(t, validateImpactedTasks(p))
+
+	p.ImpactedTasks[0].Tasks = []string{"nonexistent"}
+	p.ImpactedTasks[0].Variants = []string{"nonexistent"}
+	assert.Len(t, validateImpactedTasks(p), 2)
+
+	p.ImpactedTasks[0] = model.ImpactedTasksRule{Name: "empty"}
+	errs := validateImpactedTasks(p)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Message, "'empty'")
+}
+
 func TestDuplicateTaskInBV(t *testing.T) {
 	assert := assert.New(t)
 
@@ -5136,3 +5266,33 @@ func TestValidationErrorsAtLevel(t *testing.T) {
 		assert.Empty(t, errs.AtLevel(Error))
 	})
 }
+
+func TestCheckDeprecatedCommands(t *testing.T) {
+	t.Run("WarnsForDeprecatedCommands", func(t *testing.T) {
+		project := &model.Project{
+			Pre: &model.YAMLCommandSet{SingleCommand: &model.PluginCommandConf{Command: "shell.track"}},
+			Functions: map[string]*model.YAMLCommandSet{
+				"f": {MultiCommand: []model.PluginCommandConf{{Command: "shell.exec"}, {Command: "expansions.fetch_vars"}}},
+			},
+			Tasks: []model.ProjectTask{
+				{Name: "t1", Commands: []model.PluginCommandConf{{Command: "git.apply_patch"}, {Function: "f"}}},
+			},
+			TaskGroups: []model.TaskGroup{
+				{Name: "tg", SetupGroup: &model.YAMLCommandSet{MultiCommand: []model.PluginCommandConf{{Command: evergreen.ManifestLoadCommandName}}}},
+			},
+		}
+		errs := checkDeprecatedCommands(project)
+		require.Len(t, errs, 4)
+		for _, err := range errs {
+			assert.Equal(t, Warning, err.Level)
+		}
+	})
+	t.Run("SucceedsWithoutDeprecatedCommands", func(t *testing.T) {
+		project := &model.Project{
+			Tasks: []model.ProjectTask{
+				{Name: "t1", Commands: []model.PluginCommandConf{{Command: "shell.exec"}}},
+			},
+		}
+		assert.Empty(t, checkDeprecatedCommands(project))
+	})
+}